@@ -0,0 +1,126 @@
+package gg
+
+// AlphaPixmap is an 8-bit alpha-only (A8) render target: one coverage byte
+// per pixel, no color channels. It is the cheap surface for clip masks,
+// text atlases, and shadow sources — a quarter of the memory of an RGBA
+// Pixmap, written with blend-free coverage accumulation instead of
+// source-over compositing.
+//
+// Rendering goes through the same core rasterizer as Pixmap fills: the
+// coverage spans the Filler produces are written directly into the byte
+// buffer (see SoftwareRenderer.FillMask). Repeated fills accumulate with
+// max(), so overlapping shapes union cleanly without double-coverage seams
+// at shared anti-aliased edges.
+type AlphaPixmap struct {
+	width  int
+	height int
+	data   []uint8
+}
+
+// NewAlphaPixmap creates an alpha pixmap with the given dimensions,
+// initialized to zero coverage.
+func NewAlphaPixmap(width, height int) *AlphaPixmap {
+	return &AlphaPixmap{
+		width:  width,
+		height: height,
+		data:   make([]uint8, width*height),
+	}
+}
+
+// Width returns the width of the alpha pixmap.
+func (a *AlphaPixmap) Width() int {
+	return a.width
+}
+
+// Height returns the height of the alpha pixmap.
+func (a *AlphaPixmap) Height() int {
+	return a.height
+}
+
+// Data returns the raw coverage bytes in row-major order. The slice aliases
+// the pixmap's storage; it plugs directly into Paint.ClipMask for Layer B
+// clipping (ADR-052) without a copy.
+func (a *AlphaPixmap) Data() []uint8 {
+	return a.data
+}
+
+// At returns the coverage at (x, y), or 0 outside the bounds.
+func (a *AlphaPixmap) At(x, y int) uint8 {
+	if x < 0 || x >= a.width || y < 0 || y >= a.height {
+		return 0
+	}
+	return a.data[y*a.width+x]
+}
+
+// Set sets the coverage at (x, y). Out-of-bounds coordinates are ignored.
+func (a *AlphaPixmap) Set(x, y int, coverage uint8) {
+	if x < 0 || x >= a.width || y < 0 || y >= a.height {
+		return
+	}
+	a.data[y*a.width+x] = coverage
+}
+
+// Clear resets every pixel to zero coverage.
+func (a *AlphaPixmap) Clear() {
+	for i := range a.data {
+		a.data[i] = 0
+	}
+}
+
+// FillPath rasterizes the path into the alpha pixmap using a temporary
+// software renderer. For repeated rasterization (e.g. packing a text atlas),
+// create a SoftwareRenderer once and call its FillMask method instead to
+// reuse the edge builder and filler buffers.
+func (a *AlphaPixmap) FillPath(p *Path, fillRule FillRule) {
+	NewSoftwareRenderer(a.width, a.height).FillMask(a, p, fillRule)
+}
+
+// Mask wraps the alpha pixmap as a Mask sharing the same storage (no copy),
+// for use with Context.SetMask and the mask compositing subsystem. Writes
+// through either view are visible in both.
+func (a *AlphaPixmap) Mask() *Mask {
+	return &Mask{
+		width:  a.width,
+		height: a.height,
+		data:   a.data,
+	}
+}
+
+// FillMask rasterizes the path into an A8 alpha pixmap with blend-free
+// coverage accumulation: each covered pixel takes max(existing, coverage).
+// No color blending happens — spans from the core rasterizer are written
+// straight into the byte buffer, which makes this the fast route for clip
+// masks, text atlas glyphs, and shadow sources.
+//
+// The renderer's anti-alias state applies: non-AA fills write binary (255)
+// coverage. The path must already be in the alpha pixmap's pixel space.
+func (r *SoftwareRenderer) FillMask(dst *AlphaPixmap, p *Path, fillRule FillRule) {
+	r.FillSpans(p, fillRule, func(y, x, length int, coverage uint8) {
+		if y < 0 || y >= dst.height {
+			return
+		}
+		if x < 0 {
+			length += x
+			x = 0
+		}
+		if x+length > dst.width {
+			length = dst.width - x
+		}
+		if length <= 0 {
+			return
+		}
+		row := dst.data[y*dst.width+x : y*dst.width+x+length]
+		if coverage == 255 {
+			// Full coverage dominates any accumulated value.
+			for i := range row {
+				row[i] = 255
+			}
+			return
+		}
+		for i, v := range row {
+			if coverage > v {
+				row[i] = coverage
+			}
+		}
+	})
+}
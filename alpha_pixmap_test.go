@@ -0,0 +1,145 @@
+package gg
+
+import "testing"
+
+func TestNewAlphaPixmap(t *testing.T) {
+	a := NewAlphaPixmap(40, 30)
+	if a.Width() != 40 || a.Height() != 30 {
+		t.Errorf("dimensions = %dx%d, want 40x30", a.Width(), a.Height())
+	}
+	if len(a.Data()) != 40*30 {
+		t.Errorf("data length = %d, want %d", len(a.Data()), 40*30)
+	}
+
+	a.Set(5, 5, 200)
+	if a.At(5, 5) != 200 {
+		t.Errorf("At(5,5) = %d, want 200", a.At(5, 5))
+	}
+	// Out-of-bounds access is safe.
+	a.Set(-1, 0, 99)
+	a.Set(40, 0, 99)
+	if a.At(-1, 0) != 0 || a.At(0, 30) != 0 {
+		t.Error("out-of-bounds At should return 0")
+	}
+
+	a.Clear()
+	if a.At(5, 5) != 0 {
+		t.Error("Clear did not zero coverage")
+	}
+}
+
+func TestAlphaPixmapFillPathRect(t *testing.T) {
+	a := NewAlphaPixmap(60, 60)
+	p := NewPath()
+	p.Rectangle(10, 10, 20, 20)
+	a.FillPath(p, FillRuleNonZero)
+
+	if got := a.At(20, 20); got != 255 {
+		t.Errorf("interior coverage = %d, want 255", got)
+	}
+	if got := a.At(5, 20); got != 0 {
+		t.Errorf("exterior coverage = %d, want 0", got)
+	}
+}
+
+func TestAlphaPixmapFillMatchesPixmapAlpha(t *testing.T) {
+	// Rendering opaque black onto a transparent Pixmap leaves the coverage
+	// in the alpha channel; FillMask must produce the same coverage.
+	const size = 60
+	p := NewPath()
+	p.Circle(30, 30, 18)
+
+	pm := NewPixmap(size, size)
+	r := NewSoftwareRenderer(size, size)
+	if err := r.Fill(pm, p, solidPaint(RGBA{A: 1})); err != nil {
+		t.Fatal(err)
+	}
+
+	a := NewAlphaPixmap(size, size)
+	r.FillMask(a, p, FillRuleNonZero)
+
+	data := pm.Data()
+	for y := 0; y < size; y++ {
+		for x := 0; x < size; x++ {
+			got := int(a.At(x, y))
+			want := int(data[(y*size+x)*4+3])
+			if d := got - want; d < -1 || d > 1 {
+				t.Fatalf("coverage at (%d,%d) = %d, pixmap alpha %d", x, y, got, want)
+			}
+		}
+	}
+}
+
+func TestAlphaPixmapMaxAccumulation(t *testing.T) {
+	// Repeated fills union with max(): coverage never decreases, even at
+	// shared anti-aliased edges of overlapping shapes.
+	const size = 60
+	a := NewAlphaPixmap(size, size)
+	r := NewSoftwareRenderer(size, size)
+
+	first := NewPath()
+	first.Circle(25, 30, 12)
+	r.FillMask(a, first, FillRuleNonZero)
+
+	before := make([]uint8, len(a.Data()))
+	copy(before, a.Data())
+
+	second := NewPath()
+	second.Circle(35, 30, 12)
+	r.FillMask(a, second, FillRuleNonZero)
+
+	for i, v := range a.Data() {
+		if v < before[i] {
+			t.Fatalf("coverage decreased at pixel %d: %d -> %d", i, before[i], v)
+		}
+	}
+	// The overlap region stays fully covered.
+	if got := a.At(30, 30); got != 255 {
+		t.Errorf("overlap coverage = %d, want 255", got)
+	}
+}
+
+func TestAlphaPixmapMaskSharesStorage(t *testing.T) {
+	a := NewAlphaPixmap(20, 20)
+	m := a.Mask()
+
+	a.Set(3, 4, 128)
+	if m.At(3, 4) != 128 {
+		t.Error("write through AlphaPixmap not visible in Mask view")
+	}
+	m.Set(5, 6, 77)
+	if a.At(5, 6) != 77 {
+		t.Error("write through Mask not visible in AlphaPixmap")
+	}
+}
+
+func TestAlphaPixmapAsClipMask(t *testing.T) {
+	// An A8 mask plugs directly into Paint.ClipMask (Layer B, ADR-052).
+	const size = 60
+	clip := NewAlphaPixmap(size, size)
+	circle := NewPath()
+	circle.Circle(30, 30, 15)
+	clip.FillPath(circle, FillRuleNonZero)
+
+	pm := NewPixmap(size, size)
+	pm.Clear(White)
+
+	paint := solidPaint(RGBA{A: 1})
+	paint.ClipMask = clip.Data()
+	paint.ClipMaskW = size
+	paint.ClipMaskH = size
+
+	full := NewPath()
+	full.Rectangle(0, 0, size, size)
+	r := NewSoftwareRenderer(size, size)
+	if err := r.Fill(pm, full, paint); err != nil {
+		t.Fatal(err)
+	}
+
+	if c := pm.GetPixel(30, 30); c.R > 0.05 {
+		t.Errorf("pixel inside clip circle R = %v, want filled", c.R)
+	}
+	if c := pm.GetPixel(5, 5); c.R < 0.95 {
+		t.Errorf("pixel outside clip circle R = %v, want untouched", c.R)
+	}
+}
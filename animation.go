@@ -0,0 +1,426 @@
+package gg
+
+// Animation encoding: GIF and APNG output for a sequence of Pixmaps.
+//
+// GIF frames are palette-quantized with a median-cut quantizer and
+// Floyd-Steinberg dithering. APNG output keeps full 32-bit color by
+// wrapping standard PNG frame data in acTL/fcTL/fdAT chunks.
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"hash/crc32"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/gif"
+	"image/png"
+	"io"
+	"os"
+	"sort"
+	"time"
+)
+
+// Animation accumulates frames for GIF or APNG encoding.
+// Create with NewAnimation, add frames with AddFrame, then save with
+// SaveGIF or SaveAPNG.
+//
+// Example:
+//
+//	anim := gg.NewAnimation(200, 200)
+//	for i := 0; i < 30; i++ {
+//	    dc := gg.NewContext(200, 200)
+//	    // ... draw frame i ...
+//	    anim.AddFrame(dc.Pixmap(), 50*time.Millisecond)
+//	}
+//	anim.SaveGIF("out.gif")
+type Animation struct {
+	width  int
+	height int
+	frames []*image.RGBA
+	delays []time.Duration
+
+	// LoopCount is the number of times the animation repeats.
+	// 0 (the default) loops forever.
+	LoopCount int
+}
+
+// NewAnimation creates an empty animation with the given frame dimensions.
+func NewAnimation(width, height int) *Animation {
+	return &Animation{width: width, height: height}
+}
+
+// Width returns the frame width in pixels.
+func (a *Animation) Width() int { return a.width }
+
+// Height returns the frame height in pixels.
+func (a *Animation) Height() int { return a.height }
+
+// FrameCount returns the number of frames added so far.
+func (a *Animation) FrameCount() int { return len(a.frames) }
+
+// AddFrame appends a frame shown for the given delay.
+// The pixmap is copied, so it may be reused or mutated afterwards.
+// Pixmaps whose size differs from the animation dimensions are rejected.
+func (a *Animation) AddFrame(p *Pixmap, delay time.Duration) error {
+	if p == nil {
+		return fmt.Errorf("gg: AddFrame: nil pixmap")
+	}
+	if p.Width() != a.width || p.Height() != a.height {
+		return fmt.Errorf("gg: AddFrame: frame size %dx%d does not match animation %dx%d",
+			p.Width(), p.Height(), a.width, a.height)
+	}
+	a.frames = append(a.frames, p.ToImage())
+	a.delays = append(a.delays, delay)
+	return nil
+}
+
+// --------------------------------------------------------------------------
+// GIF
+// --------------------------------------------------------------------------
+
+// EncodeGIF writes the animation as an animated GIF.
+// Each frame is quantized to at most 256 colors with a median-cut
+// quantizer and dithered with Floyd-Steinberg error diffusion.
+func (a *Animation) EncodeGIF(w io.Writer) error {
+	if len(a.frames) == 0 {
+		return fmt.Errorf("gg: EncodeGIF: no frames")
+	}
+	out := &gif.GIF{
+		Image:     make([]*image.Paletted, len(a.frames)),
+		Delay:     make([]int, len(a.frames)),
+		LoopCount: a.LoopCount,
+		Config: image.Config{
+			Width:  a.width,
+			Height: a.height,
+		},
+	}
+	for i, frame := range a.frames {
+		palette := medianCutPalette(frame, 256)
+		paletted := image.NewPaletted(frame.Bounds(), palette)
+		draw.FloydSteinberg.Draw(paletted, frame.Bounds(), frame, image.Point{})
+		out.Image[i] = paletted
+		out.Delay[i] = int(a.delays[i] / (10 * time.Millisecond)) // GIF uses 1/100s
+	}
+	return gif.EncodeAll(w, out)
+}
+
+// SaveGIF saves the animation as an animated GIF file.
+func (a *Animation) SaveGIF(path string) error {
+	f, err := os.Create(path) // #nosec G304 -- caller-provided output path
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	return a.EncodeGIF(f)
+}
+
+// --------------------------------------------------------------------------
+// APNG
+// --------------------------------------------------------------------------
+
+// EncodeAPNG writes the animation as an APNG (animated PNG).
+// Frames keep full 32-bit RGBA color; no quantization is applied.
+func (a *Animation) EncodeAPNG(w io.Writer) error {
+	if len(a.frames) == 0 {
+		return fmt.Errorf("gg: EncodeAPNG: no frames")
+	}
+
+	// Encode every frame as a standalone PNG and pull out its chunks.
+	// The first frame contributes IHDR and IDAT; later frames contribute
+	// fdAT data wrapped around their IDAT payloads.
+	type encodedFrame struct {
+		idat []byte
+	}
+	var ihdr []byte
+	encoded := make([]encodedFrame, len(a.frames))
+	for i, frame := range a.frames {
+		var buf bytes.Buffer
+		if err := png.Encode(&buf, frame); err != nil {
+			return err
+		}
+		chunks, err := pngChunks(buf.Bytes())
+		if err != nil {
+			return err
+		}
+		var idat []byte
+		for _, c := range chunks {
+			switch c.typ {
+			case "IHDR":
+				if i == 0 {
+					ihdr = c.data
+				}
+			case "IDAT":
+				idat = append(idat, c.data...)
+			}
+		}
+		encoded[i] = encodedFrame{idat: idat}
+	}
+
+	// PNG signature.
+	if _, err := w.Write([]byte{0x89, 'P', 'N', 'G', '\r', '\n', 0x1a, '\n'}); err != nil {
+		return err
+	}
+	if err := writeChunk(w, "IHDR", ihdr); err != nil {
+		return err
+	}
+
+	// acTL: frame count and loop count (0 = infinite).
+	acTL := make([]byte, 8)
+	// #nosec G115 -- frame count bounded by memory long before uint32
+	binary.BigEndian.PutUint32(acTL[0:], uint32(len(a.frames)))
+	// #nosec G115 -- loop count is a small non-negative repeat count
+	binary.BigEndian.PutUint32(acTL[4:], uint32(a.LoopCount))
+	if err := writeChunk(w, "acTL", acTL); err != nil {
+		return err
+	}
+
+	seq := uint32(0)
+	for i, ef := range encoded {
+		// fcTL: frame control.
+		fcTL := make([]byte, 26)
+		binary.BigEndian.PutUint32(fcTL[0:], seq)
+		seq++
+		// #nosec G115 -- dimensions validated positive in NewAnimation callers
+		binary.BigEndian.PutUint32(fcTL[4:], uint32(a.width))
+		// #nosec G115 -- dimensions validated positive in NewAnimation callers
+		binary.BigEndian.PutUint32(fcTL[8:], uint32(a.height))
+		// x/y offsets are zero: frames are full-canvas.
+		num, den := delayFraction(a.delays[i])
+		binary.BigEndian.PutUint16(fcTL[20:], num)
+		binary.BigEndian.PutUint16(fcTL[22:], den)
+		fcTL[24] = 0 // dispose: none
+		fcTL[25] = 0 // blend: source
+		if err := writeChunk(w, "fcTL", fcTL); err != nil {
+			return err
+		}
+
+		if i == 0 {
+			if err := writeChunk(w, "IDAT", ef.idat); err != nil {
+				return err
+			}
+			continue
+		}
+		// fdAT: sequence number followed by the frame's IDAT payload.
+		fdAT := make([]byte, 4+len(ef.idat))
+		binary.BigEndian.PutUint32(fdAT[0:], seq)
+		seq++
+		copy(fdAT[4:], ef.idat)
+		if err := writeChunk(w, "fdAT", fdAT); err != nil {
+			return err
+		}
+	}
+
+	return writeChunk(w, "IEND", nil)
+}
+
+// SaveAPNG saves the animation as an APNG file.
+func (a *Animation) SaveAPNG(path string) error {
+	f, err := os.Create(path) // #nosec G304 -- caller-provided output path
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	return a.EncodeAPNG(f)
+}
+
+// delayFraction converts a duration to an APNG num/den seconds fraction.
+func delayFraction(d time.Duration) (num, den uint16) {
+	ms := d.Milliseconds()
+	if ms < 0 {
+		ms = 0
+	}
+	if ms > 65535 {
+		// Fall back to whole seconds for very long delays.
+		s := ms / 1000
+		if s > 65535 {
+			s = 65535
+		}
+		return uint16(s), 1
+	}
+	return uint16(ms), 1000
+}
+
+// pngChunk is a single chunk parsed from an encoded PNG.
+type pngChunk struct {
+	typ  string
+	data []byte
+}
+
+// pngChunks splits an encoded PNG into its chunks (signature skipped).
+func pngChunks(data []byte) ([]pngChunk, error) {
+	if len(data) < 8 {
+		return nil, fmt.Errorf("gg: malformed PNG: too short")
+	}
+	data = data[8:] // skip signature
+	var chunks []pngChunk
+	for len(data) > 0 {
+		if len(data) < 12 {
+			return nil, fmt.Errorf("gg: malformed PNG: truncated chunk")
+		}
+		length := binary.BigEndian.Uint32(data[0:4])
+		if uint32(len(data)) < 12+length {
+			return nil, fmt.Errorf("gg: malformed PNG: chunk overruns data")
+		}
+		chunks = append(chunks, pngChunk{
+			typ:  string(data[4:8]),
+			data: data[8 : 8+length],
+		})
+		data = data[12+length:]
+	}
+	return chunks, nil
+}
+
+// writeChunk writes a PNG chunk with length and CRC framing.
+func writeChunk(w io.Writer, typ string, data []byte) error {
+	var header [8]byte
+	// #nosec G115 -- chunk payloads are bounded by frame size
+	binary.BigEndian.PutUint32(header[0:], uint32(len(data)))
+	copy(header[4:], typ)
+	if _, err := w.Write(header[:]); err != nil {
+		return err
+	}
+	if _, err := w.Write(data); err != nil {
+		return err
+	}
+	crc := crc32.NewIEEE()
+	crc.Write(header[4:])
+	crc.Write(data)
+	var sum [4]byte
+	binary.BigEndian.PutUint32(sum[:], crc.Sum32())
+	_, err := w.Write(sum[:])
+	return err
+}
+
+// --------------------------------------------------------------------------
+// Median-cut palette quantization
+// --------------------------------------------------------------------------
+
+// medianCutPalette builds a palette of at most maxColors colors from the
+// image using median-cut partitioning in RGB space. Fully transparent
+// pixels map to a reserved transparent palette entry.
+func medianCutPalette(img *image.RGBA, maxColors int) color.Palette {
+	bounds := img.Bounds()
+
+	// Collect unique opaque colors (sampled: every pixel for small
+	// frames, strided for large ones to bound quantization cost).
+	stride := 1
+	if n := bounds.Dx() * bounds.Dy(); n > 1<<16 {
+		stride = n / (1 << 16)
+		if stride < 1 {
+			stride = 1
+		}
+	}
+	seen := make(map[[3]uint8]struct{})
+	hasAlpha := false
+	i := 0
+	for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+		for x := bounds.Min.X; x < bounds.Max.X; x++ {
+			if i%stride != 0 {
+				i++
+				continue
+			}
+			i++
+			c := img.RGBAAt(x, y)
+			if c.A < 128 {
+				hasAlpha = true
+				continue
+			}
+			seen[[3]uint8{c.R, c.G, c.B}] = struct{}{}
+		}
+	}
+
+	colors := make([][3]uint8, 0, len(seen))
+	for c := range seen {
+		colors = append(colors, c)
+	}
+
+	budget := maxColors
+	if hasAlpha {
+		budget--
+	}
+	var palette color.Palette
+	if len(colors) <= budget {
+		// Fewer unique colors than the budget: use them directly.
+		sort.Slice(colors, func(i, j int) bool {
+			a, b := colors[i], colors[j]
+			if a[0] != b[0] {
+				return a[0] < b[0]
+			}
+			if a[1] != b[1] {
+				return a[1] < b[1]
+			}
+			return a[2] < b[2]
+		})
+		for _, c := range colors {
+			palette = append(palette, color.RGBA{R: c[0], G: c[1], B: c[2], A: 255})
+		}
+	} else {
+		palette = medianCut(colors, budget)
+	}
+	if len(palette) == 0 {
+		palette = append(palette, color.RGBA{A: 255})
+	}
+	if hasAlpha {
+		palette = append(palette, color.RGBA{})
+	}
+	return palette
+}
+
+// medianCut recursively splits color boxes along their widest channel
+// until the target number of boxes is reached, then averages each box.
+func medianCut(colors [][3]uint8, target int) color.Palette {
+	boxes := [][][3]uint8{colors}
+	for len(boxes) < target {
+		// Split the box with the widest channel range.
+		bestBox, bestChan, bestRange := -1, 0, -1
+		for i, box := range boxes {
+			if len(box) < 2 {
+				continue
+			}
+			for ch := 0; ch < 3; ch++ {
+				lo, hi := 255, 0
+				for _, c := range box {
+					v := int(c[ch])
+					if v < lo {
+						lo = v
+					}
+					if v > hi {
+						hi = v
+					}
+				}
+				if r := hi - lo; r > bestRange {
+					bestBox, bestChan, bestRange = i, ch, r
+				}
+			}
+		}
+		if bestBox < 0 || bestRange == 0 {
+			break
+		}
+		box := boxes[bestBox]
+		ch := bestChan
+		sort.Slice(box, func(i, j int) bool { return box[i][ch] < box[j][ch] })
+		mid := len(box) / 2
+		boxes[bestBox] = box[:mid]
+		boxes = append(boxes, box[mid:])
+	}
+
+	palette := make(color.Palette, 0, len(boxes))
+	for _, box := range boxes {
+		if len(box) == 0 {
+			continue
+		}
+		var r, g, b int
+		for _, c := range box {
+			r += int(c[0])
+			g += int(c[1])
+			b += int(c[2])
+		}
+		n := len(box)
+		palette = append(palette, color.RGBA{
+			R: uint8(r / n), G: uint8(g / n), B: uint8(b / n), A: 255,
+		})
+	}
+	return palette
+}
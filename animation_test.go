@@ -0,0 +1,186 @@
+package gg
+
+import (
+	"bytes"
+	"encoding/binary"
+	"image/gif"
+	"image/png"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// animTestFrame returns a pixmap filled with the given color plus a
+// contrasting square, so quantization has more than one color to keep.
+func animTestFrame(w, h int, c RGBA) *Pixmap {
+	p := NewPixmap(w, h)
+	p.Clear(c)
+	for y := h / 4; y < h/2; y++ {
+		for x := w / 4; x < w/2; x++ {
+			p.SetPixel(x, y, White)
+		}
+	}
+	return p
+}
+
+func TestAnimationAddFrame(t *testing.T) {
+	anim := NewAnimation(32, 32)
+	if anim.FrameCount() != 0 {
+		t.Errorf("new animation has %d frames", anim.FrameCount())
+	}
+	if err := anim.AddFrame(nil, time.Second); err == nil {
+		t.Error("expected error for nil pixmap")
+	}
+	if err := anim.AddFrame(NewPixmap(16, 16), time.Second); err == nil {
+		t.Error("expected error for mismatched frame size")
+	}
+	if err := anim.AddFrame(animTestFrame(32, 32, Red), 100*time.Millisecond); err != nil {
+		t.Fatalf("AddFrame failed: %v", err)
+	}
+	if anim.FrameCount() != 1 {
+		t.Errorf("FrameCount = %d, want 1", anim.FrameCount())
+	}
+}
+
+func TestAnimationEncodeGIF(t *testing.T) {
+	anim := NewAnimation(32, 32)
+	colors := []RGBA{Red, Green, Blue}
+	for _, c := range colors {
+		if err := anim.AddFrame(animTestFrame(32, 32, c), 50*time.Millisecond); err != nil {
+			t.Fatalf("AddFrame failed: %v", err)
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := anim.EncodeGIF(&buf); err != nil {
+		t.Fatalf("EncodeGIF failed: %v", err)
+	}
+
+	decoded, err := gif.DecodeAll(&buf)
+	if err != nil {
+		t.Fatalf("output is not a valid GIF: %v", err)
+	}
+	if len(decoded.Image) != 3 {
+		t.Errorf("decoded %d frames, want 3", len(decoded.Image))
+	}
+	for i, d := range decoded.Delay {
+		if d != 5 { // 50ms = 5 centiseconds
+			t.Errorf("frame %d delay = %d, want 5", i, d)
+		}
+	}
+	// First frame should be predominantly red.
+	frame := decoded.Image[0]
+	r, g, b, _ := frame.At(2, 2).RGBA()
+	if r < 0xc000 || g > 0x4000 || b > 0x4000 {
+		t.Errorf("first frame pixel not red: %04x %04x %04x", r, g, b)
+	}
+}
+
+func TestAnimationEncodeGIFEmpty(t *testing.T) {
+	anim := NewAnimation(10, 10)
+	var buf bytes.Buffer
+	if err := anim.EncodeGIF(&buf); err == nil {
+		t.Error("expected error encoding empty animation")
+	}
+	if err := anim.EncodeAPNG(&buf); err == nil {
+		t.Error("expected error encoding empty animation")
+	}
+}
+
+func TestAnimationEncodeAPNG(t *testing.T) {
+	anim := NewAnimation(16, 16)
+	for _, c := range []RGBA{Red, Blue} {
+		if err := anim.AddFrame(animTestFrame(16, 16, c), 100*time.Millisecond); err != nil {
+			t.Fatalf("AddFrame failed: %v", err)
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := anim.EncodeAPNG(&buf); err != nil {
+		t.Fatalf("EncodeAPNG failed: %v", err)
+	}
+	data := buf.Bytes()
+
+	// The output must still decode as a plain PNG (first frame).
+	img, err := png.Decode(bytes.NewReader(data))
+	if err != nil {
+		t.Fatalf("APNG does not decode as PNG: %v", err)
+	}
+	if img.Bounds().Dx() != 16 || img.Bounds().Dy() != 16 {
+		t.Errorf("decoded size %v, want 16x16", img.Bounds())
+	}
+
+	// Verify animation chunks are present with the right frame count.
+	chunks, err := pngChunks(data)
+	if err != nil {
+		t.Fatalf("pngChunks failed: %v", err)
+	}
+	var sawACTL, sawFDAT bool
+	fcTLCount := 0
+	for _, c := range chunks {
+		switch c.typ {
+		case "acTL":
+			sawACTL = true
+			if n := binary.BigEndian.Uint32(c.data[0:4]); n != 2 {
+				t.Errorf("acTL frame count = %d, want 2", n)
+			}
+		case "fcTL":
+			fcTLCount++
+		case "fdAT":
+			sawFDAT = true
+		}
+	}
+	if !sawACTL {
+		t.Error("missing acTL chunk")
+	}
+	if fcTLCount != 2 {
+		t.Errorf("fcTL count = %d, want 2", fcTLCount)
+	}
+	if !sawFDAT {
+		t.Error("missing fdAT chunk for second frame")
+	}
+}
+
+func TestAnimationSaveFiles(t *testing.T) {
+	anim := NewAnimation(8, 8)
+	if err := anim.AddFrame(animTestFrame(8, 8, Green), 20*time.Millisecond); err != nil {
+		t.Fatalf("AddFrame failed: %v", err)
+	}
+	dir := t.TempDir()
+	if err := anim.SaveGIF(filepath.Join(dir, "out.gif")); err != nil {
+		t.Errorf("SaveGIF failed: %v", err)
+	}
+	if err := anim.SaveAPNG(filepath.Join(dir, "out.png")); err != nil {
+		t.Errorf("SaveAPNG failed: %v", err)
+	}
+}
+
+func TestMedianCutPalette(t *testing.T) {
+	// A gradient frame has more than 256 unique colors, forcing real
+	// median-cut splits.
+	p := NewPixmap(64, 64)
+	for y := 0; y < 64; y++ {
+		for x := 0; x < 64; x++ {
+			p.SetPixel(x, y, RGB(float64(x)/63, float64(y)/63, 0.5))
+		}
+	}
+	palette := medianCutPalette(p.ToImage(), 256)
+	if len(palette) == 0 || len(palette) > 256 {
+		t.Fatalf("palette size %d out of range", len(palette))
+	}
+
+	// Transparent pixels reserve a transparent entry.
+	p2 := NewPixmap(8, 8)
+	p2.Clear(Transparent)
+	p2.SetPixel(0, 0, Red)
+	palette2 := medianCutPalette(p2.ToImage(), 256)
+	foundTransparent := false
+	for _, c := range palette2 {
+		if _, _, _, a := c.RGBA(); a == 0 {
+			foundTransparent = true
+		}
+	}
+	if !foundTransparent {
+		t.Error("palette missing transparent entry")
+	}
+}
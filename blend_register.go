@@ -0,0 +1,94 @@
+package gg
+
+import (
+	"errors"
+	"sync"
+
+	intImage "github.com/gogpu/gg/internal/image"
+)
+
+// CustomBlendMode describes a blend mode registered with RegisterBlendMode.
+type CustomBlendMode struct {
+	// Mode is the allocated BlendMode value, usable wherever a built-in
+	// mode is (PushLayer, DrawParams).
+	Mode BlendMode
+
+	// Name is the registered name, returned by BlendMode.String.
+	Name string
+
+	// WGSL is the shader snippet for GPU blending, or "" for CPU-only modes.
+	WGSL string
+}
+
+var (
+	customBlendMu    sync.RWMutex
+	customBlendModes []CustomBlendMode
+)
+
+// RegisterBlendMode registers a custom separable blend mode and returns
+// the BlendMode value allocated for it. The mode can then be used like a
+// built-in one, e.g. with PushLayer.
+//
+// cpuFn is the per-channel blend math B(src, dst) on straight-alpha
+// channel values in [0, 1] (the W3C compositing B function); the software
+// blender applies it to each color channel and handles alpha compositing,
+// so a linear burn variant is simply func(s, d float64) float64
+// { return s + d - 1 }. Results are clamped to [0, 1].
+//
+// wgslSnippet, if non-empty, is the body of a WGSL function with
+// signature (src: vec3<f32>, dst: vec3<f32>) -> vec3<f32> operating on
+// straight-alpha colors. It is appended to the blend shader source used
+// by the GPU pipeline cache. Pass "" for modes that only render on CPU.
+//
+// Registration is global and permanent; names must be unique and
+// non-empty. Safe for concurrent use.
+func RegisterBlendMode(name string, cpuFn func(src, dst float64) float64, wgslSnippet string) (BlendMode, error) {
+	if name == "" {
+		return 0, errors.New("gg: blend mode name must not be empty")
+	}
+	if cpuFn == nil {
+		return 0, errors.New("gg: blend mode function must not be nil")
+	}
+
+	customBlendMu.Lock()
+	defer customBlendMu.Unlock()
+	for _, m := range customBlendModes {
+		if m.Name == name {
+			return 0, errors.New("gg: blend mode " + name + " already registered")
+		}
+	}
+
+	channelFn := func(src, dst uint8) uint8 {
+		v := cpuFn(float64(src)/255, float64(dst)/255)
+		if v <= 0 {
+			return 0
+		}
+		if v >= 1 {
+			return 255
+		}
+		return uint8(v*255 + 0.5)
+	}
+
+	mode, ok := intImage.RegisterBlendFunc(name, channelFn)
+	if !ok {
+		return 0, errors.New("gg: blend mode value space exhausted")
+	}
+
+	customBlendModes = append(customBlendModes, CustomBlendMode{
+		Mode: mode,
+		Name: name,
+		WGSL: wgslSnippet,
+	})
+	return mode, nil
+}
+
+// CustomBlendModes returns the registered custom blend modes in
+// registration order. The GPU pipeline uses this to append custom WGSL
+// blend functions to the generated blend shader.
+func CustomBlendModes() []CustomBlendMode {
+	customBlendMu.RLock()
+	defer customBlendMu.RUnlock()
+	out := make([]CustomBlendMode, len(customBlendModes))
+	copy(out, customBlendModes)
+	return out
+}
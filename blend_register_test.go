@@ -0,0 +1,77 @@
+package gg
+
+import (
+	"math"
+	"testing"
+)
+
+// TestRegisterBlendMode tests registration and use of a custom blend mode
+// through the layer compositing path.
+func TestRegisterBlendMode(t *testing.T) {
+	// Linear burn: B(s, d) = s + d - 1.
+	mode, err := RegisterBlendMode("TestLinearBurn", func(s, d float64) float64 {
+		return s + d - 1
+	}, "return src + dst - vec3<f32>(1.0);")
+	if err != nil {
+		t.Fatalf("RegisterBlendMode: %v", err)
+	}
+	if mode.String() != "TestLinearBurn" {
+		t.Errorf("String() = %q, want TestLinearBurn", mode.String())
+	}
+
+	dc := NewContext(8, 8)
+	defer dc.Close()
+	dc.ClearWithColor(RGBA{R: 0.5, G: 0.5, B: 0.5, A: 1})
+	dc.PushLayer(mode, 1.0)
+	dc.ClearWithColor(RGBA{R: 0.75, G: 0.75, B: 0.75, A: 1})
+	dc.PopLayer()
+
+	// linear burn: 0.75 + 0.5 - 1 = 0.25
+	got := dc.pixmap.GetPixel(4, 4)
+	if math.Abs(got.R-0.25) > 0.02 {
+		t.Errorf("blended pixel R = %v, want 0.25", got.R)
+	}
+}
+
+// TestRegisterBlendModeErrors tests the registration error cases.
+func TestRegisterBlendModeErrors(t *testing.T) {
+	identity := func(s, _ float64) float64 { return s }
+
+	if _, err := RegisterBlendMode("", identity, ""); err == nil {
+		t.Error("empty name should be rejected")
+	}
+	if _, err := RegisterBlendMode("TestNilFn", nil, ""); err == nil {
+		t.Error("nil function should be rejected")
+	}
+	if _, err := RegisterBlendMode("TestDuplicate", identity, ""); err != nil {
+		t.Fatalf("first registration: %v", err)
+	}
+	if _, err := RegisterBlendMode("TestDuplicate", identity, ""); err == nil {
+		t.Error("duplicate name should be rejected")
+	}
+}
+
+// TestCustomBlendModes tests the registry accessor used by the GPU
+// shader generation.
+func TestCustomBlendModes(t *testing.T) {
+	const wgsl = "return min(src, dst);"
+	mode, err := RegisterBlendMode("TestDarkenClone", func(s, d float64) float64 {
+		return math.Min(s, d)
+	}, wgsl)
+	if err != nil {
+		t.Fatalf("RegisterBlendMode: %v", err)
+	}
+
+	found := false
+	for _, m := range CustomBlendModes() {
+		if m.Mode == mode {
+			found = true
+			if m.Name != "TestDarkenClone" || m.WGSL != wgsl {
+				t.Errorf("registry entry = %+v, want name/WGSL preserved", m)
+			}
+		}
+	}
+	if !found {
+		t.Error("registered mode missing from CustomBlendModes()")
+	}
+}
@@ -1,6 +1,10 @@
 package gg
 
-import "math"
+import (
+	"math"
+
+	"github.com/gogpu/gg/internal/detmath"
+)
 
 // ColorFunc is a function that returns a color at a given position.
 // Used by CustomBrush to define custom brush patterns.
@@ -110,7 +114,7 @@ func VerticalGradient(c0, c1 RGBA, y0, y1 float64) CustomBrush {
 func LinearGradient(c0, c1 RGBA, x0, y0, x1, y1 float64) CustomBrush {
 	dx := x1 - x0
 	dy := y1 - y0
-	length := math.Sqrt(dx*dx + dy*dy)
+	length := detmath.Sqrt(dx*dx + dy*dy)
 	if length == 0 {
 		return Solid(c0).toCustomBrush()
 	}
@@ -149,7 +153,7 @@ func RadialGradient(c0, c1 RGBA, cx, cy, r float64) CustomBrush {
 		Func: func(x, y float64) RGBA {
 			dx := x - cx
 			dy := y - cy
-			dist := math.Sqrt(dx*dx + dy*dy)
+			dist := detmath.Sqrt(dx*dx + dy*dy)
 			t := dist / r
 			t = clampT(t)
 			return c0.Lerp(c1, t)
@@ -197,8 +201,8 @@ func Stripes(c0, c1 RGBA, width, angle float64) CustomBrush {
 		width = 1
 	}
 
-	cos := math.Cos(angle)
-	sin := math.Sin(angle)
+	cos := detmath.Cos(angle)
+	sin := detmath.Sin(angle)
 
 	return CustomBrush{
 		Func: func(x, y float64) RGBA {
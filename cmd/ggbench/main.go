@@ -0,0 +1,308 @@
+// Command ggbench benchmarks the standardized gg scene suite across
+// rasterizer tiers and registered surface backends, producing a
+// comparative report to guide backend selection.
+//
+// Usage:
+//
+//	ggbench [-width 800] [-height 600] [-frames 30] [-font path.ttf] [-json]
+//
+// Each tier runs every scene for the requested number of frames and
+// reports milliseconds per frame plus the pixel difference against the
+// reference tier (the Auto rasterizer), so both speed and fidelity of a
+// tier are visible at a glance. Surface backends registered through the
+// surface registry (GPU integrations register themselves in init) are
+// benchmarked with the same shapes workload through the surface API.
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"image/color"
+	"log"
+	"math"
+	"os"
+	"time"
+
+	"github.com/gogpu/gg"
+	"github.com/gogpu/gg/surface"
+)
+
+func main() {
+	var (
+		width    = flag.Int("width", 800, "scene width in pixels")
+		height   = flag.Int("height", 600, "scene height in pixels")
+		frames   = flag.Int("frames", 30, "frames per scene (after one warmup)")
+		fontPath = flag.String("font", "", "TTF font for the text scene (skipped when empty)")
+		jsonOut  = flag.Bool("json", false, "emit the report as JSON")
+	)
+	flag.Parse()
+
+	scenes := sceneSuite(*fontPath)
+	tiers := []gg.RasterizerMode{
+		gg.RasterizerAuto,
+		gg.RasterizerAnalytic,
+		gg.RasterizerSparseStrips,
+		gg.RasterizerTileCompute,
+		gg.RasterizerSDF,
+	}
+
+	var report []result
+
+	// Reference pixels per scene come from the Auto tier.
+	reference := map[string][]byte{}
+	for _, mode := range tiers {
+		for _, sc := range scenes {
+			r := benchContext(mode, sc, *width, *height, *frames)
+			if mode == gg.RasterizerAuto {
+				reference[sc.name] = r.pixels
+			}
+			r.DiffPercent = pixelDiff(reference[sc.name], r.pixels)
+			report = append(report, r)
+		}
+	}
+
+	for _, name := range surface.Available() {
+		r, err := benchSurface(name, *width, *height, *frames)
+		if err != nil {
+			log.Printf("backend %s: %v", name, err)
+			continue
+		}
+		r.DiffPercent = pixelDiff(reference["shapes"], r.pixels)
+		report = append(report, r)
+	}
+
+	if *jsonOut {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(report); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+	printTable(report)
+}
+
+// result is one tier x scene measurement.
+type result struct {
+	Tier        string  `json:"tier"`
+	Scene       string  `json:"scene"`
+	MsPerFrame  float64 `json:"ms_per_frame"`
+	DiffPercent float64 `json:"diff_vs_reference_percent"`
+
+	pixels []byte
+}
+
+// scene is one standardized workload.
+type scene struct {
+	name string
+	draw func(dc *gg.Context, frame int)
+}
+
+// sceneSuite returns the standardized scenes. The text scene is included
+// only when a font file is provided.
+func sceneSuite(fontPath string) []scene {
+	scenes := []scene{
+		{"shapes", drawShapes},
+		{"gradients", drawGradients},
+		{"layers", drawLayers},
+		{"animation", drawAnimation},
+	}
+	if fontPath != "" {
+		scenes = append(scenes, scene{"text", func(dc *gg.Context, frame int) {
+			drawText(dc, fontPath)
+		}})
+	}
+	return scenes
+}
+
+// benchContext times a scene on a gg.Context with a forced rasterizer.
+func benchContext(mode gg.RasterizerMode, sc scene, width, height, frames int) result {
+	dc := gg.NewContext(width, height)
+	defer dc.Close()
+	dc.SetRasterizerMode(mode)
+
+	renderFrame := func(frame int) {
+		dc.ClearWithColor(gg.RGB(1, 1, 1))
+		sc.draw(dc, frame)
+		_ = dc.FlushGPU()
+	}
+
+	renderFrame(0) // warmup: caches, pools, lazy init
+	start := time.Now()
+	for f := 0; f < frames; f++ {
+		renderFrame(f)
+	}
+	elapsed := time.Since(start)
+
+	pix := dc.ResizeTarget().Data()
+	pixels := make([]byte, len(pix))
+	copy(pixels, pix)
+
+	return result{
+		Tier:       "context/" + mode.String(),
+		Scene:      sc.name,
+		MsPerFrame: float64(elapsed.Microseconds()) / float64(frames) / 1000,
+		pixels:     pixels,
+	}
+}
+
+// benchSurface times the shapes workload on a registered surface backend.
+func benchSurface(name string, width, height, frames int) (result, error) {
+	s, err := surface.NewSurfaceByName(name, width, height)
+	if err != nil {
+		return result{}, err
+	}
+	defer s.Close()
+
+	renderFrame := func() {
+		s.Clear(color.White)
+		drawSurfaceShapes(s, width, height)
+		_ = s.Flush()
+	}
+
+	renderFrame()
+	start := time.Now()
+	for f := 0; f < frames; f++ {
+		renderFrame()
+	}
+	elapsed := time.Since(start)
+
+	var pixels []byte
+	if img := s.Snapshot(); img != nil {
+		pixels = img.Pix
+	}
+	return result{
+		Tier:       "surface/" + name,
+		Scene:      "shapes",
+		MsPerFrame: float64(elapsed.Microseconds()) / float64(frames) / 1000,
+		pixels:     pixels,
+	}, nil
+}
+
+// pixelDiff returns the percentage of pixels whose RGBA differs by more
+// than 2 per channel — a tolerance covering rounding differences between
+// rasterizers without hiding real divergence.
+func pixelDiff(ref, got []byte) float64 {
+	if len(ref) == 0 || len(ref) != len(got) {
+		return 0
+	}
+	diff := 0
+	for i := 0; i < len(ref); i += 4 {
+		for c := 0; c < 4; c++ {
+			d := int(ref[i+c]) - int(got[i+c])
+			if d > 2 || d < -2 {
+				diff++
+				break
+			}
+		}
+	}
+	return float64(diff) * 100 / float64(len(ref)/4)
+}
+
+// printTable writes the human-readable report.
+func printTable(report []result) {
+	fmt.Printf("%-28s %-12s %12s %10s\n", "TIER", "SCENE", "MS/FRAME", "DIFF%")
+	for _, r := range report {
+		fmt.Printf("%-28s %-12s %12.3f %10.2f\n", r.Tier, r.Scene, r.MsPerFrame, r.DiffPercent)
+	}
+}
+
+// drawShapes fills and strokes a spread of circles and rectangles.
+func drawShapes(dc *gg.Context, frame int) {
+	w, h := float64(dc.Width()), float64(dc.Height())
+	for i := 0; i < 40; i++ {
+		t := float64(i) / 40
+		dc.SetRGBA(t, 0.2, 1-t, 0.8)
+		dc.DrawCircle(t*w, h*0.3, 30)
+		_ = dc.Fill()
+		dc.SetRGB(0.1, 0.6, 0.3)
+		dc.DrawRoundedRectangle(t*w, h*0.6, 48, 32, 8)
+		_ = dc.Fill()
+	}
+	dc.SetRGB(0, 0, 0)
+	dc.SetLineWidth(3)
+	dc.DrawEllipse(w/2, h/2, w/3, h/4)
+	_ = dc.Stroke()
+}
+
+// drawGradients covers linear, radial, and sweep fills.
+func drawGradients(dc *gg.Context, frame int) {
+	w, h := float64(dc.Width()), float64(dc.Height())
+
+	lin := gg.NewLinearGradientBrush(0, 0, w, 0).
+		AddColorStop(0, gg.RGB(1, 0, 0)).
+		AddColorStop(1, gg.RGB(0, 0, 1))
+	dc.SetFillBrush(lin)
+	dc.DrawRectangle(0, 0, w, h/3)
+	_ = dc.Fill()
+
+	rad := gg.NewRadialGradientBrush(w/2, h/2, 0, h/3).
+		AddColorStop(0, gg.RGB(1, 1, 0)).
+		AddColorStop(1, gg.RGB(0, 0.5, 0))
+	dc.SetFillBrush(rad)
+	dc.DrawRectangle(0, h/3, w, h/3)
+	_ = dc.Fill()
+
+	sweep := gg.NewSweepGradientBrush(w/2, h*5/6, 0).
+		AddColorStop(0, gg.RGB(0, 1, 1)).
+		AddColorStop(1, gg.RGB(1, 0, 1))
+	dc.SetFillBrush(sweep)
+	dc.DrawRectangle(0, h*2/3, w, h/3)
+	_ = dc.Fill()
+}
+
+// drawLayers exercises layer push/pop with group alpha.
+func drawLayers(dc *gg.Context, frame int) {
+	w, h := float64(dc.Width()), float64(dc.Height())
+	for i := 0; i < 6; i++ {
+		dc.PushLayer(gg.BlendNormal, 0.5)
+		t := float64(i) / 6
+		dc.SetRGB(t, 1-t, 0.5)
+		dc.DrawCircle(w*(0.2+t*0.6), h/2, h/4)
+		_ = dc.Fill()
+		dc.PopLayer()
+	}
+}
+
+// drawAnimation moves shapes with the frame counter, the way an
+// animation loop would between presents.
+func drawAnimation(dc *gg.Context, frame int) {
+	w, h := float64(dc.Width()), float64(dc.Height())
+	phase := float64(frame) * 0.2
+	for i := 0; i < 20; i++ {
+		a := phase + float64(i)*math.Pi/10
+		dc.SetRGBA(0.2, 0.4, 1, 0.7)
+		dc.DrawCircle(w/2+math.Cos(a)*w/3, h/2+math.Sin(a)*h/3, 24)
+		_ = dc.Fill()
+	}
+}
+
+// drawText renders wrapped strings at several sizes.
+func drawText(dc *gg.Context, fontPath string) {
+	if err := dc.LoadFontFace(fontPath, 24); err != nil {
+		return
+	}
+	dc.SetRGB(0, 0, 0)
+	for i := 0; i < 10; i++ {
+		dc.DrawString("The quick brown fox jumps over the lazy dog 0123456789",
+			20, 40+float64(i)*48)
+	}
+}
+
+// drawSurfaceShapes is the shapes workload expressed in the surface API.
+func drawSurfaceShapes(s surface.Surface, width, height int) {
+	w, h := float64(width), float64(height)
+	for i := 0; i < 40; i++ {
+		t := float64(i) / 40
+		path := surface.NewPath()
+		path.Circle(t*w, h*0.3, 30)
+		s.Fill(path, surface.FillStyle{
+			Color: color.RGBA{uint8(t * 255), 51, uint8((1 - t) * 255), 204},
+		})
+
+		rect := surface.NewPath()
+		rect.Rectangle(t*w, h*0.6, 48, 32)
+		s.Fill(rect, surface.FillStyle{Color: color.RGBA{26, 153, 77, 255}})
+	}
+}
@@ -10,6 +10,8 @@ import (
 	"math"
 
 	"github.com/gogpu/gg/internal/clip"
+	"github.com/gogpu/gg/internal/detmath"
+	"github.com/gogpu/gg/internal/trace"
 	"github.com/gogpu/gg/text"
 	"github.com/gogpu/gpucontext"
 )
@@ -51,12 +53,20 @@ type Context struct {
 	mask      *Mask   // Current alpha mask
 	maskStack []*Mask // Mask stack for Push/Pop
 
+	// Drop shadow state (Canvas 2D shadowOffset/shadowBlur/shadowColor)
+	shadow       *shadowState // nil when no shadow is active
+	inShadowPass bool         // true while rendering the shadow scratch pass
+
+	// Watertight fill group (seam-free shared edges); nil when closed.
+	coverageGroup *coverageGroup
+
 	// Per-frame damage tracking (ADR-021 Level 1).
 	// List of per-operation bounding boxes — NOT a single union rect.
 	// Each Fill/Stroke adds its own rect. Passed as-is to PresentWithDamage
 	// for per-rect OS blit. Merged to bounding box if count exceeds threshold.
 	frameDamageRects      []image.Rectangle
 	damageTrackingEnabled bool
+	dirtyBounds           image.Rectangle // exact union since last Clear/ResetDirty
 
 	// Pipeline mode
 	pipelineMode PipelineMode // GPU pipeline selection mode
@@ -64,14 +74,24 @@ type Context struct {
 	// Rasterizer mode
 	rasterizerMode RasterizerMode // CPU rasterizer selection mode
 
+	// Curve flattening tolerance override in device pixels (0 = default)
+	flattenTolerance float64
+
 	// Anti-aliasing
 	antiAlias      bool   // anti-aliasing enabled (default: true)
 	antiAliasStack []bool // Push/Pop stack for antiAlias state
 
+	// Pixel snapping (axis-aligned strokes and rect fills, default: false)
+	pixelSnapping bool
+
 	// Text rendering
 	textMode         TextMode               // text strategy selection (default: Auto)
 	outlineExtractor *text.OutlineExtractor // lazy: for transform-aware text (Strategy B)
 	glyphCache       *text.GlyphCache       // lazy: cached glyph outlines for drawStringAsOutlines
+	textGamma        float64                // glyph mask gamma correction (0 or 1 = off)
+	textContrast     float64                // glyph mask contrast boost (0 = off)
+	stemDarkening    bool                   // boost glyph coverage at small sizes
+	textRuns         textRunCache           // cached string measurements for the current face
 
 	// Per-context GPU render context (isolated pending commands, clips, frame tracking).
 	// Lazily created when GPURenderContextProvider is available.
@@ -121,6 +141,10 @@ func NewContext(width, height int, opts ...ContextOption) *Context {
 		opt(&options)
 	}
 
+	if options.deterministicMath {
+		SetDeterministicMath(true)
+	}
+
 	scale := options.deviceScale
 	if scale <= 0 {
 		scale = 1.0
@@ -274,6 +298,14 @@ func (c *Context) Close() error {
 	c.mask = nil
 	c.gpuClipPath = nil
 
+	// Release memory accounting for layers that were never popped.
+	if c.layerStack != nil {
+		for _, layer := range c.layerStack.layers {
+			releaseMemory(MemoryLayers, layer.bytes)
+		}
+		c.layerStack.layers = nil
+	}
+
 	return nil
 }
 
@@ -314,6 +346,31 @@ func (c *Context) RasterizerMode() RasterizerMode {
 	return c.rasterizerMode
 }
 
+// SetFlattenTolerance sets the curve flattening tolerance in device pixels
+// — the maximum deviation allowed between a curve and the line segments
+// it is flattened into. Paths are flattened after the CTM is applied, so
+// the bound holds at any zoom: a 4x-scaled curve is subdivided 4x more
+// finely in user-space terms, and a zoomed-out curve wastes no segments.
+//
+// Lower values trade speed for smoothness. Zero (the default) keeps the
+// built-in defaults: 0.1 px for the scanline rasterizer, 0.25 px for the
+// tile-based fillers. The override is threaded through the software
+// renderer, the stroke expander, and tile fillers that implement
+// FlattenToleranceSetter. On HiDPI the effective tolerance is divided by
+// the device scale so curves stay smooth at physical resolution.
+func (c *Context) SetFlattenTolerance(tolerance float64) {
+	if tolerance < 0 {
+		tolerance = 0
+	}
+	c.flattenTolerance = tolerance
+}
+
+// FlattenTolerance returns the curve flattening tolerance override in
+// device pixels, or 0 when the built-in defaults apply.
+func (c *Context) FlattenTolerance() float64 {
+	return c.flattenTolerance
+}
+
 // SetAntiAlias enables or disables anti-aliasing for geometry rendering.
 //
 // When enabled (default), shapes are rendered with smooth edges using analytic
@@ -463,14 +520,18 @@ func (c *Context) SavePNG(path string) error {
 
 // Clear resets the entire context to transparent (zero alpha).
 // To fill with a specific background color, use [ClearWithColor].
+// Clear starts a new dirty-region accumulation period (see DirtyBounds).
 func (c *Context) Clear() {
 	c.pixmap.Clear(Transparent)
+	c.ResetDirty()
 }
 
 // ClearWithColor fills the entire context with the specified color.
 // This is the recommended way to set a background color before drawing.
+// ClearWithColor starts a new dirty-region accumulation period.
 func (c *Context) ClearWithColor(col RGBA) {
 	c.pixmap.Clear(col)
+	c.ResetDirty()
 }
 
 // maxDamageRects is the threshold above which individual rects are merged
@@ -509,6 +570,26 @@ func (c *Context) ResetFrameDamage() {
 	c.frameDamageRects = c.frameDamageRects[:0]
 }
 
+// DirtyBounds returns the device-space bounding box of all draw
+// operations since the last Clear, ClearWithColor, or ResetDirty — a
+// single accumulated rect, unlike the per-frame FrameDamage list.
+// Embedders (ggcanvas, terminal UIs) can use it to upload or blit only
+// the changed region. Returns the zero rectangle when nothing was drawn.
+//
+// Unlike FrameDamage, the accumulator is never merged or truncated, so
+// it stays exact across any number of operations. It respects
+// SetDamageTracking: draws during suppressed (cached replay) phases are
+// not accumulated.
+func (c *Context) DirtyBounds() image.Rectangle {
+	return c.dirtyBounds
+}
+
+// ResetDirty clears the accumulated dirty bounds. Call after consuming
+// DirtyBounds (e.g. after blitting the changed region).
+func (c *Context) ResetDirty() {
+	c.dirtyBounds = image.Rectangle{}
+}
+
 // SetDamageTracking enables or disables per-operation damage recording.
 // When disabled, Fill/Stroke do not append to FrameDamage.
 // Used by retained-mode compositors to suppress damage during replay
@@ -553,6 +634,10 @@ func (c *Context) trackDamage(bounds image.Rectangle) {
 		)
 	}
 
+	// Accumulate the exact union for DirtyBounds (device space, never
+	// merged or truncated like frameDamageRects below).
+	c.dirtyBounds = c.dirtyBounds.Union(bounds)
+
 	c.frameDamageRects = append(c.frameDamageRects, bounds)
 	if len(c.frameDamageRects) > maxDamageRects {
 		merged := c.frameDamageRects[0]
@@ -593,12 +678,14 @@ func (c *Context) FillRectCPU(x, y, w, h float64, col RGBA) {
 	c.pixmap.FillRect(image.Rect(px0, py0, px1, py1), pr, pg, pb, pa)
 }
 
-// SetColor sets the current drawing color.
+// SetColor sets the current drawing color for both fill and stroke,
+// clearing any dedicated stroke brush.
 func (c *Context) SetColor(col color.Color) {
 	c.paint.solidColor = FromColor(col)
 	c.paint.isSolid = true
 	c.paint.Brush = nil
 	c.paint.Pattern = nil
+	c.paint.SetStrokeBrush(nil)
 }
 
 // SetRGB sets the current color using RGB values (0-1).
@@ -607,6 +694,7 @@ func (c *Context) SetRGB(r, g, b float64) {
 	c.paint.isSolid = true
 	c.paint.Brush = nil
 	c.paint.Pattern = nil
+	c.paint.SetStrokeBrush(nil)
 }
 
 // SetRGBA sets the current color using RGBA values (0-1).
@@ -615,6 +703,7 @@ func (c *Context) SetRGBA(r, g, b, a float64) {
 	c.paint.isSolid = true
 	c.paint.Brush = nil
 	c.paint.Pattern = nil
+	c.paint.SetStrokeBrush(nil)
 }
 
 // SetHexColor sets the current color using a hex string.
@@ -623,6 +712,7 @@ func (c *Context) SetHexColor(hex string) {
 	c.paint.isSolid = true
 	c.paint.Brush = nil
 	c.paint.Pattern = nil
+	c.paint.SetStrokeBrush(nil)
 }
 
 // SetFillBrush sets the brush used for fill operations.
@@ -637,16 +727,23 @@ func (c *Context) SetFillBrush(b Brush) {
 	c.paint.SetBrush(b)
 }
 
-// SetStrokeBrush sets the brush used for stroke operations.
-// Note: In the current implementation, fill and stroke share the same brush.
-// This method is provided for API symmetry and future extensibility.
+// SetStrokeBrush sets the brush used for stroke operations, independent of
+// the fill brush. Gradients and patterns are supported just like for fills;
+// they are sampled in the same coordinate space as fill brushes, so a
+// stroked and a filled shape share one gradient seamlessly. Passing nil
+// clears the stroke brush, making strokes follow the fill brush again.
+//
+// SetColor, SetRGB, SetRGBA, and SetHexColor reset the stroke brush along
+// with the fill brush, since they set the color for all drawing operations.
 //
 // Example:
 //
 //	ctx.SetStrokeBrush(gg.Solid(gg.Black))
-//	ctx.SetStrokeBrush(gg.SolidRGB(0.5, 0.5, 0.5))
+//	ctx.SetStrokeBrush(gg.NewLinearGradientBrush(0, 0, 100, 0).
+//		AddColorStop(0, gg.Red).
+//		AddColorStop(1, gg.Blue))
 func (c *Context) SetStrokeBrush(b Brush) {
-	c.paint.SetBrush(b)
+	c.paint.SetStrokeBrush(b)
 }
 
 // FillBrush returns the current fill brush.
@@ -654,10 +751,10 @@ func (c *Context) FillBrush() Brush {
 	return c.paint.GetBrush()
 }
 
-// StrokeBrush returns the current stroke brush.
-// Note: In the current implementation, fill and stroke share the same brush.
+// StrokeBrush returns the current effective stroke brush: the brush set via
+// SetStrokeBrush, or the fill brush when no dedicated stroke brush is set.
 func (c *Context) StrokeBrush() Brush {
-	return c.paint.GetBrush()
+	return c.paint.GetStrokeBrush()
 }
 
 // SetLineWidth sets the line width for stroking.
@@ -665,6 +762,20 @@ func (c *Context) SetLineWidth(width float64) {
 	c.paint.LineWidth = width
 }
 
+// SetStrokeScaling sets how stroke width reacts to the current transform:
+// StrokeScalingNormal (scaled by the transform, the default),
+// StrokeScalingHairline (always one physical pixel), or
+// StrokeScalingNonScaling (line width in device pixels, like SVG's
+// vector-effect="non-scaling-stroke").
+func (c *Context) SetStrokeScaling(mode StrokeScaling) {
+	c.paint.StrokeScaling = mode
+}
+
+// GetStrokeScaling returns the current stroke scaling mode.
+func (c *Context) GetStrokeScaling() StrokeScaling {
+	return c.paint.StrokeScaling
+}
+
 // SetLineCap sets the line cap style.
 func (c *Context) SetLineCap(lineCap LineCap) {
 	c.paint.LineCap = lineCap
@@ -680,6 +791,16 @@ func (c *Context) SetFillRule(rule FillRule) {
 	c.paint.FillRule = rule
 }
 
+// SetNonOverlappingAlpha toggles non-overlapping alpha rendering: fills
+// and strokes are rasterized to a coverage mask first and the paint's
+// alpha is applied exactly once per pixel, so self-overlapping geometry
+// (figure-eight strokes, retraced polylines, duplicated subpaths) does
+// not darken where it folds over itself. Forces the software renderer
+// while enabled.
+func (c *Context) SetNonOverlappingAlpha(enabled bool) {
+	c.paint.NonOverlappingAlpha = enabled
+}
+
 // SetMiterLimit sets the miter limit for line joins.
 func (c *Context) SetMiterLimit(limit float64) {
 	c.paint.MiterLimit = limit
@@ -878,6 +999,7 @@ func (c *Context) NewSubPath() {
 // The RasterizerMode set via SetRasterizerMode controls algorithm selection.
 // Returns an error if the rendering operation fails.
 func (c *Context) Fill() error {
+	c.snapFillPath()
 	c.trackDamage(c.path.Bounds())
 	err := c.doFill()
 	c.path.Clear()
@@ -890,6 +1012,7 @@ func (c *Context) Fill() error {
 // The RasterizerMode set via SetRasterizerMode controls algorithm selection.
 // Returns an error if the rendering operation fails.
 func (c *Context) Stroke() error {
+	c.snapStrokePath()
 	bounds := c.path.Bounds()
 	// Inflate by half line width + 1px AA margin. Stroke extends beyond path bounds.
 	inset := int(math.Ceil(c.paint.LineWidth/2)) + 1
@@ -908,6 +1031,7 @@ func (c *Context) Stroke() error {
 // Otherwise, the software renderer handles the operation.
 // Returns an error if the rendering operation fails.
 func (c *Context) FillPreserve() error {
+	c.snapFillPath()
 	return c.doFill()
 }
 
@@ -916,6 +1040,7 @@ func (c *Context) FillPreserve() error {
 // Otherwise, the software renderer handles the operation.
 // Returns an error if the rendering operation fails.
 func (c *Context) StrokePreserve() error {
+	c.snapStrokePath()
 	return c.doStroke()
 }
 
@@ -1179,10 +1304,10 @@ func (c *Context) DrawArc(x, y, r, angle1, angle2 float64) {
 
 // arcSegment draws a single arc segment.
 func (c *Context) arcSegment(cx, cy, r, a1, a2 float64) {
-	alpha := math.Sin(a2-a1) * (math.Sqrt(4+3*math.Tan((a2-a1)/2)*math.Tan((a2-a1)/2)) - 1) / 3
+	alpha := detmath.Sin(a2-a1) * (detmath.Sqrt(4+3*math.Tan((a2-a1)/2)*math.Tan((a2-a1)/2)) - 1) / 3
 
-	cos1, sin1 := math.Cos(a1), math.Sin(a1)
-	cos2, sin2 := math.Cos(a2), math.Sin(a2)
+	cos1, sin1 := detmath.Cos(a1), detmath.Sin(a1)
+	cos2, sin2 := detmath.Cos(a2), detmath.Sin(a2)
 
 	x1 := cx + r*cos1
 	y1 := cy + r*sin1
@@ -1200,6 +1325,59 @@ func (c *Context) arcSegment(cx, cy, r, a1, a2 float64) {
 	c.path.CubicTo(c1x, c1y, c2x, c2y, x2, y2)
 }
 
+// DrawArcNegative draws a circular arc sweeping negatively (decreasing
+// angle) from angle1 to angle2 in radians — the cairo-style mirror of
+// DrawArc. Use it for clockwise arcs and for carving holes with
+// opposite winding under non-zero fill.
+func (c *Context) DrawArcNegative(x, y, r, angle1, angle2 float64) {
+	center := c.matrix.TransformPoint(Pt(x, y))
+
+	const twoPi = 2 * math.Pi
+	for angle2 > angle1 {
+		angle2 -= twoPi
+	}
+
+	const maxAngle = math.Pi / 2
+	numSegments := int(math.Ceil((angle1 - angle2) / maxAngle))
+	angleStep := (angle2 - angle1) / float64(numSegments)
+
+	for i := 0; i < numSegments; i++ {
+		a1 := angle1 + float64(i)*angleStep
+		a2 := a1 + angleStep
+		c.arcSegment(center.X, center.Y, r, a1, a2)
+	}
+}
+
+// EllipticalArcTo adds an elliptical arc from the current point to
+// (x, y) with SVG "A" command endpoint semantics: radii rx and ry, the
+// ellipse's x-axis rotation in radians, and the large-arc and sweep
+// flags. The arc is built in user space and transformed through the
+// current matrix, so rotation and scale apply as for any other path
+// segment. See Path.EllipticalArcTo for the degenerate-input rules.
+func (c *Context) EllipticalArcTo(rx, ry, xAxisRotation float64, largeArc, sweep bool, x, y float64) {
+	if !c.path.HasCurrentPoint() {
+		c.MoveTo(x, y)
+		return
+	}
+
+	// The arc endpoint parameterization is defined in user space; the
+	// path's current point is in device space, so map it back.
+	cur := c.matrix.Invert().TransformPoint(c.path.CurrentPoint())
+
+	arc := NewPath()
+	arc.MoveTo(cur.X, cur.Y)
+	arc.EllipticalArcTo(rx, ry, xAxisRotation, largeArc, sweep, x, y)
+
+	arc.Iterate(func(verb PathVerb, coords []float64) {
+		switch verb {
+		case LineTo:
+			c.LineTo(coords[0], coords[1])
+		case CubicTo:
+			c.CubicTo(coords[0], coords[1], coords[2], coords[3], coords[4], coords[5])
+		}
+	})
+}
+
 // DrawEllipticalArc draws an elliptical arc (advanced).
 func (c *Context) DrawEllipticalArc(x, y, rx, ry, angle1, angle2 float64) {
 	// This is a simplified version; full implementation would handle rotation
@@ -1379,6 +1557,9 @@ func (c *Context) BeginGPUFrame() {
 }
 
 func (c *Context) FlushGPU() error {
+	sp := trace.Start("gg.flush_gpu")
+	defer sp.End()
+
 	t := c.gpuRenderTarget()
 	if rc := c.gpuCtxOps(); rc != nil {
 		return rc.Flush(t)
@@ -1713,6 +1894,18 @@ func sdfAccelForShape(kind ShapeKind) AcceleratedOp {
 
 // doFill performs the fill operation respecting the current RasterizerMode.
 func (c *Context) doFill() error {
+	sp := trace.Start("gg.fill")
+	sp.SetAttr("verbs", c.path.NumVerbs())
+	defer sp.End()
+
+	// Inside a coverage group, fills accumulate instead of compositing.
+	if c.coverageGroup != nil {
+		return c.fillCoverageGroup()
+	}
+
+	if c.shadowActive() {
+		c.shadowPass(c.doFill)
+	}
 	mode := c.rasterizerMode
 
 	// Set GPU scissor rect for rectangular clips.
@@ -1739,10 +1932,17 @@ func (c *Context) doFill() error {
 
 	// Temporarily swap c.path to device-space for GPU tryGPUOp
 	// (which reads c.path for shape detection and path rendering).
-	origPath := c.path
-	c.path = devicePath
-	ok, cpuMode := c.tryGPUFillWithMode(mode)
-	c.path = origPath
+	// The shadow scratch pass must land in c.pixmap, so it stays on CPU.
+	// Non-overlapping alpha needs the mask-based software path.
+	ok, cpuMode := false, mode
+	if !c.inShadowPass && !c.paint.NonOverlappingAlpha {
+		origPath := c.path
+		c.path = devicePath
+		ok, cpuMode = c.tryGPUFillWithMode(mode)
+		c.path = origPath
+	} else if cpuMode == RasterizerSDF {
+		cpuMode = RasterizerAuto
+	}
 	if ok {
 		return nil
 	}
@@ -1752,9 +1952,15 @@ func (c *Context) doFill() error {
 	if sr, ok := c.renderer.(*SoftwareRenderer); ok {
 		sr.rasterizerMode = cpuMode
 		sr.antiAlias = c.antiAlias
+		if c.flattenTolerance > 0 {
+			sr.SetFlattenTolerance(c.flattenTolerance)
+		}
 		defer func() {
 			sr.rasterizerMode = RasterizerAuto
 			sr.antiAlias = true
+			if c.flattenTolerance > 0 {
+				sr.SetFlattenTolerance(0)
+			}
 		}()
 	}
 
@@ -1763,6 +1969,13 @@ func (c *Context) doFill() error {
 
 // doStroke performs the stroke operation respecting the current RasterizerMode.
 func (c *Context) doStroke() error {
+	sp := trace.Start("gg.stroke")
+	sp.SetAttr("verbs", c.path.NumVerbs())
+	defer sp.End()
+
+	if c.shadowActive() {
+		c.shadowPass(c.doStroke)
+	}
 	c.paint.TransformScale = c.totalMatrix().ScaleFactor()
 	mode := c.rasterizerMode
 
@@ -1786,10 +1999,19 @@ func (c *Context) doStroke() error {
 	}
 
 	// Temporarily swap c.path to device-space for GPU tryGPUOp.
-	origPath := c.path
-	c.path = devicePath
-	ok, cpuMode := c.tryGPUStrokeWithMode(mode)
-	c.path = origPath
+	// Hairline and non-scaling strokes are only honored by the software
+	// renderer, the shadow scratch pass must land in c.pixmap, and
+	// non-overlapping alpha needs the mask-based software path, so all
+	// three skip the GPU attempt.
+	ok, cpuMode := false, mode
+	if c.paint.StrokeScaling == StrokeScalingNormal && !c.inShadowPass && !c.paint.NonOverlappingAlpha {
+		origPath := c.path
+		c.path = devicePath
+		ok, cpuMode = c.tryGPUStrokeWithMode(mode)
+		c.path = origPath
+	} else if cpuMode == RasterizerSDF {
+		cpuMode = RasterizerAuto
+	}
 	if ok {
 		return nil
 	}
@@ -1797,9 +2019,15 @@ func (c *Context) doStroke() error {
 	if sr, ok := c.renderer.(*SoftwareRenderer); ok {
 		sr.rasterizerMode = cpuMode
 		sr.antiAlias = c.antiAlias
+		if c.flattenTolerance > 0 {
+			sr.SetFlattenTolerance(c.flattenTolerance)
+		}
 		defer func() {
 			sr.rasterizerMode = RasterizerAuto
 			sr.antiAlias = true
+			if c.flattenTolerance > 0 {
+				sr.SetFlattenTolerance(0)
+			}
 		}()
 	}
 
@@ -50,6 +50,55 @@ func (c *Context) ClipPreserve() {
 	// Path is preserved
 }
 
+// ClipStroke uses the stroked outline of the current path as the clipping
+// region and clears the path. The outline is computed with the current
+// stroke parameters (line width, cap, join, dashes), so e.g. a circle
+// with a wide line width clips to a ring — progress indicators and
+// ring-shaped masks need no manual outline math.
+// The clip region is intersected with any existing clip regions.
+func (c *Context) ClipStroke() {
+	c.ClipStrokePreserve()
+	c.path.Clear()
+}
+
+// ClipStrokePreserve is like ClipStroke but keeps the path, allowing you
+// to both clip to the stroked region and then fill/stroke the same path.
+func (c *Context) ClipStrokePreserve() {
+	if c.clipStack == nil {
+		c.initClipStack()
+	}
+
+	// Stroke expansion runs on the device-space path with the paint's
+	// transform scale, matching doStroke.
+	c.paint.TransformScale = c.totalMatrix().ScaleFactor()
+	devicePath := c.deviceSpacePath()
+	outline := c.strokeOutlineForClip(devicePath)
+
+	clipVerbs, clipCoords := ConvertPathToClipVerbs(outline)
+	_ = c.clipStack.PushPath(clipVerbs, clipCoords, true) // anti-aliased by default
+
+	// Store the device-space outline for GPU depth clipping (GPU-CLIP-003a).
+	c.gpuClipPath = outline.Clone()
+}
+
+// strokeOutlineForClip expands a device-space path into its stroke
+// outline. The software renderer's arena is reused when available; with
+// an injected renderer (GPU) the expansion runs on one-shot scratch.
+func (c *Context) strokeOutlineForClip(devicePath *Path) *Path {
+	if sr, ok := c.renderer.(*SoftwareRenderer); ok {
+		return sr.strokeOutline(devicePath, c.paint)
+	}
+	tol := c.flattenTolerance
+	if tol <= 0 {
+		tol = defaultFlattenTolerance
+	}
+	if c.deviceScale > 1.0 {
+		tol /= c.deviceScale
+	}
+	return expandStrokeOutline(&geometryArena{}, NewPath(), devicePath, c.paint,
+		float32(c.deviceScale), tol)
+}
+
 // ClipRect sets a rectangular clipping region.
 // This is a faster alternative to creating a rectangular path and calling Clip().
 // The clip region is intersected with any existing clip regions.
@@ -130,6 +179,37 @@ func (c *Context) ResetClip() {
 	c.gpuClipPath = nil
 }
 
+// ClipBounds returns the device-space bounding rectangle of the current
+// clip region — the intersection of all active clips. With no clip set it
+// covers the full canvas in physical pixels. A zero-area result means
+// everything is clipped out, so callers can skip drawing work entirely:
+//
+//	if b := dc.ClipBounds(); b.Width() <= 0 || b.Height() <= 0 {
+//		return // widget fully clipped
+//	}
+func (c *Context) ClipBounds() Rect {
+	if c.clipStack == nil {
+		return Rect{Max: Pt(float64(c.pixmap.Width()), float64(c.pixmap.Height()))}
+	}
+	b := c.clipStack.Bounds()
+	return Rect{Min: Pt(b.X, b.Y), Max: Pt(b.X+b.W, b.Y+b.H)}
+}
+
+// InClip reports whether the user-space point (x, y) is inside the
+// current clip region. The point is transformed through the current
+// matrix, so it is expressed in the same coordinates as drawing calls.
+// Path and rounded-rect clips are tested exactly (mask coverage / SDF),
+// not just against the bounding box. With no clip set, InClip reports
+// whether the point lands on the canvas.
+func (c *Context) InClip(x, y float64) bool {
+	dp := c.totalMatrix().TransformPoint(Pt(x, y))
+	if c.clipStack == nil {
+		return dp.X >= 0 && dp.Y >= 0 &&
+			dp.X < float64(c.pixmap.Width()) && dp.Y < float64(c.pixmap.Height())
+	}
+	return c.clipStack.IsVisible(dp.X, dp.Y)
+}
+
 // initClipStack initializes the clip stack with canvas bounds in device-space.
 func (c *Context) initClipStack() {
 	bounds := clip.NewRect(0, 0, float64(c.pixmap.Width()), float64(c.pixmap.Height()))
@@ -758,3 +758,164 @@ func TestClipRoundRectFillPath(t *testing.T) {
 			outsideLeft.R, outsideLeft.G, outsideLeft.B)
 	}
 }
+
+func TestClipStroke(t *testing.T) {
+	dc := NewContext(100, 100)
+
+	// Clip to a ring: a circle outline with a wide stroke.
+	dc.SetLineWidth(10)
+	dc.DrawCircle(50, 50, 30)
+	dc.ClipStroke()
+
+	// Path should be cleared after ClipStroke()
+	if dc.path.NumVerbs() != 0 {
+		t.Errorf("Expected path to be cleared after ClipStroke(), got %d elements", dc.path.NumVerbs())
+	}
+	if dc.clipStack == nil {
+		t.Fatal("Expected clipStack to be initialized")
+	}
+	if dc.clipStack.Depth() != 1 {
+		t.Errorf("Expected clip depth 1, got %d", dc.clipStack.Depth())
+	}
+
+	// Fill everything red; only the ring should be painted.
+	dc.SetRGB(1, 0, 0)
+	dc.DrawRectangle(0, 0, 100, 100)
+	dc.Fill()
+
+	// On the ring (distance 30 from center): painted.
+	if px := dc.pixmap.GetPixel(50+30, 50); px.R < 0.5 {
+		t.Errorf("ring pixel not painted, got %v", px)
+	}
+	// Center of the circle: outside the stroked region.
+	if px := dc.pixmap.GetPixel(50, 50); px.R > 0.1 {
+		t.Errorf("center pixel should be clipped out, got %v", px)
+	}
+	// Well outside the circle: clipped out.
+	if px := dc.pixmap.GetPixel(5, 5); px.R > 0.1 {
+		t.Errorf("corner pixel should be clipped out, got %v", px)
+	}
+}
+
+func TestClipStrokePreserve(t *testing.T) {
+	dc := NewContext(100, 100)
+
+	dc.SetLineWidth(8)
+	dc.DrawLine(20, 50, 80, 50)
+	elemCount := dc.path.NumVerbs()
+	dc.ClipStrokePreserve()
+
+	// Path should be preserved after ClipStrokePreserve()
+	if dc.path.NumVerbs() != elemCount {
+		t.Errorf("Expected path to be preserved with %d elements, got %d", elemCount, dc.path.NumVerbs())
+	}
+	if dc.clipStack == nil || dc.clipStack.Depth() != 1 {
+		t.Fatal("Expected clip depth 1")
+	}
+}
+
+func TestClipStrokeWithTransform(t *testing.T) {
+	dc := NewContext(100, 100)
+
+	// The stroked clip must follow the user transform like Clip does.
+	dc.Scale(2, 2)
+	dc.SetLineWidth(5)
+	dc.DrawLine(10, 25, 40, 25)
+	dc.ClipStroke()
+
+	dc.SetRGB(0, 1, 0)
+	dc.DrawRectangle(0, 0, 50, 50)
+	dc.Fill()
+
+	// Device-space line runs y=50, x=20..80 with ~10px effective width.
+	if px := dc.pixmap.GetPixel(50, 50); px.G < 0.5 {
+		t.Errorf("pixel on transformed stroke not painted, got %v", px)
+	}
+	if px := dc.pixmap.GetPixel(50, 80); px.G > 0.1 {
+		t.Errorf("pixel off the stroke should be clipped out, got %v", px)
+	}
+}
+
+func TestClipBounds(t *testing.T) {
+	dc := NewContext(200, 100)
+
+	// No clip: full canvas.
+	b := dc.ClipBounds()
+	if b.Min.X != 0 || b.Min.Y != 0 || b.Max.X != 200 || b.Max.Y != 100 {
+		t.Errorf("unclipped bounds = %+v, want full canvas", b)
+	}
+
+	// Rect clip narrows the bounds.
+	dc.ClipRect(20, 10, 60, 40)
+	b = dc.ClipBounds()
+	if b.Min.X != 20 || b.Min.Y != 10 || b.Max.X != 80 || b.Max.Y != 50 {
+		t.Errorf("clipped bounds = %+v, want [20,10..80,50]", b)
+	}
+
+	// Intersecting clips shrink further.
+	dc.ClipRect(50, 0, 100, 100)
+	b = dc.ClipBounds()
+	if b.Min.X != 50 || b.Max.X != 80 {
+		t.Errorf("intersected bounds = %+v, want x in [50, 80]", b)
+	}
+
+	// Disjoint clip: zero-area bounds signal "skip all drawing".
+	dc.ClipRect(150, 60, 40, 30)
+	b = dc.ClipBounds()
+	if b.Width() > 0 && b.Height() > 0 {
+		t.Errorf("disjoint clips should yield empty bounds, got %+v", b)
+	}
+}
+
+func TestClipBoundsDeviceSpace(t *testing.T) {
+	dc := NewContext(100, 100)
+
+	// ClipBounds is device-space: a scaled ClipRect reports scaled bounds.
+	dc.Scale(2, 2)
+	dc.ClipRect(10, 10, 20, 20)
+	b := dc.ClipBounds()
+	if b.Min.X != 20 || b.Min.Y != 20 || b.Max.X != 60 || b.Max.Y != 60 {
+		t.Errorf("device-space bounds = %+v, want [20,20..60,60]", b)
+	}
+}
+
+func TestInClip(t *testing.T) {
+	dc := NewContext(100, 100)
+
+	// No clip: canvas membership.
+	if !dc.InClip(50, 50) {
+		t.Error("point on canvas should be in clip")
+	}
+	if dc.InClip(-5, 50) || dc.InClip(50, 150) {
+		t.Error("points off canvas should not be in clip")
+	}
+
+	// Circular path clip: exact test, not bounding box.
+	dc.DrawCircle(50, 50, 30)
+	dc.Clip()
+	if !dc.InClip(50, 50) {
+		t.Error("circle center should be in clip")
+	}
+	if dc.InClip(25, 25) {
+		t.Error("bbox corner outside the circle should not be in clip")
+	}
+	if dc.InClip(95, 95) {
+		t.Error("point far outside should not be in clip")
+	}
+}
+
+func TestInClipWithTransform(t *testing.T) {
+	dc := NewContext(100, 100)
+
+	dc.ClipRect(40, 40, 20, 20) // device [40,60) before any transform
+
+	// InClip takes user-space coordinates: after Translate, user (0, 0)
+	// maps to device (50, 50), inside the clip.
+	dc.Translate(50, 50)
+	if !dc.InClip(0, 0) {
+		t.Error("translated origin should be in clip")
+	}
+	if dc.InClip(40, 40) {
+		t.Error("user (40,40) maps to device (90,90), outside the clip")
+	}
+}
@@ -0,0 +1,156 @@
+package gg
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+// fillHalvesAt fills the canvas with two rects meeting at a fractional
+// vertical edge, optionally inside a coverage group.
+func fillHalvesAt(dc *Context, seamX float64, grouped bool) {
+	if grouped {
+		dc.PushCoverageGroup()
+	}
+	dc.SetRGB(1, 0, 0)
+	dc.DrawRectangle(0, 0, seamX, float64(dc.Height()))
+	_ = dc.Fill()
+	dc.DrawRectangle(seamX, 0, float64(dc.Width())-seamX, float64(dc.Height()))
+	_ = dc.Fill()
+	if grouped {
+		_ = dc.PopCoverageGroup()
+	}
+}
+
+// TestCoverageGroupRectSeam tests that two rects sharing a fractional
+// edge show a seam with plain fills and none inside a coverage group.
+func TestCoverageGroupRectSeam(t *testing.T) {
+	const seamX = 31.5
+
+	// Baseline: sequential source-over leaks background at the seam.
+	plain := NewContext(64, 64)
+	defer plain.Close()
+	plain.ClearWithColor(RGB(1, 1, 1))
+	fillHalvesAt(plain, seamX, false)
+	leak := plain.pixmap.GetPixel(31, 32)
+	if leak.G < 0.05 {
+		t.Fatal("baseline shows no seam; the grouped assertion below would be vacuous")
+	}
+
+	// Grouped: the seam column must be pure fill color.
+	dc := NewContext(64, 64)
+	defer dc.Close()
+	dc.ClearWithColor(RGB(1, 1, 1))
+	fillHalvesAt(dc, seamX, true)
+	for y := 0; y < 64; y++ {
+		for _, x := range []int{30, 31, 32, 33} {
+			px := dc.pixmap.GetPixel(x, y)
+			if math.Abs(px.R-1) > 0.01 || px.G > 0.01 || px.B > 0.01 {
+				t.Fatalf("seam at (%d,%d): got %+v, want pure red", x, y, px)
+			}
+		}
+	}
+}
+
+// TestCoverageGroupTriangleSeam tests the diagonal shared edge of two
+// triangles partitioning a square.
+func TestCoverageGroupTriangleSeam(t *testing.T) {
+	dc := NewContext(64, 64)
+	defer dc.Close()
+	dc.ClearWithColor(RGB(1, 1, 1))
+
+	dc.PushCoverageGroup()
+	dc.SetRGB(0, 0.5, 0)
+	// Upper-left triangle.
+	dc.MoveTo(0, 0)
+	dc.LineTo(64, 0)
+	dc.LineTo(0, 64)
+	dc.ClosePath()
+	if err := dc.Fill(); err != nil {
+		t.Fatalf("Fill: %v", err)
+	}
+	// Lower-right triangle sharing the diagonal.
+	dc.MoveTo(64, 0)
+	dc.LineTo(64, 64)
+	dc.LineTo(0, 64)
+	dc.ClosePath()
+	if err := dc.Fill(); err != nil {
+		t.Fatalf("Fill: %v", err)
+	}
+	if err := dc.PopCoverageGroup(); err != nil {
+		t.Fatalf("PopCoverageGroup: %v", err)
+	}
+
+	// Every pixel, including those the diagonal crosses, must be the
+	// fill color with no background bleed.
+	for y := 0; y < 64; y++ {
+		for x := 0; x < 64; x++ {
+			px := dc.pixmap.GetPixel(x, y)
+			if px.R > 0.02 || math.Abs(px.G-0.5) > 0.02 || px.B > 0.02 {
+				t.Fatalf("pixel (%d,%d) = %+v, want pure green", x, y, px)
+			}
+		}
+	}
+}
+
+// TestCoverageGroupDifferentColors tests that differing fill colors
+// blend with each other at the shared edge, not with the background.
+func TestCoverageGroupDifferentColors(t *testing.T) {
+	const seamX = 31.5
+	dc := NewContext(64, 64)
+	defer dc.Close()
+	dc.ClearWithColor(RGB(0, 1, 0)) // green background would leak into G
+
+	dc.PushCoverageGroup()
+	dc.SetRGB(1, 0, 0)
+	dc.DrawRectangle(0, 0, seamX, 64)
+	_ = dc.Fill()
+	dc.SetRGB(0, 0, 1)
+	dc.DrawRectangle(seamX, 0, 64-seamX, 64)
+	_ = dc.Fill()
+	if err := dc.PopCoverageGroup(); err != nil {
+		t.Fatalf("PopCoverageGroup: %v", err)
+	}
+
+	for _, x := range []int{30, 31, 32, 33} {
+		px := dc.pixmap.GetPixel(x, 32)
+		if px.G > 0.01 {
+			t.Errorf("background leaked at x=%d: %+v", x, px)
+		}
+		if math.Abs(px.R+px.B-1) > 0.02 {
+			t.Errorf("seam at x=%d not a red/blue partition: %+v", x, px)
+		}
+	}
+}
+
+// TestCoverageGroupOverlapSaturates tests that genuine overlaps inside
+// a group renormalize instead of over-brightening.
+func TestCoverageGroupOverlapSaturates(t *testing.T) {
+	dc := NewContext(32, 32)
+	defer dc.Close()
+	dc.ClearWithColor(RGB(1, 1, 1))
+
+	dc.PushCoverageGroup()
+	dc.SetRGB(0.2, 0.4, 0.6)
+	dc.DrawRectangle(0, 0, 24, 32)
+	_ = dc.Fill()
+	dc.DrawRectangle(8, 0, 24, 32) // overlaps [8,24)
+	_ = dc.Fill()
+	if err := dc.PopCoverageGroup(); err != nil {
+		t.Fatalf("PopCoverageGroup: %v", err)
+	}
+
+	px := dc.pixmap.GetPixel(16, 16) // double-covered
+	if math.Abs(px.R-0.2) > 0.02 || math.Abs(px.G-0.4) > 0.02 || math.Abs(px.B-0.6) > 0.02 {
+		t.Errorf("overlap pixel = %+v, want the fill color", px)
+	}
+}
+
+// TestPopCoverageGroupWithoutPush tests the unbalanced-pop error.
+func TestPopCoverageGroupWithoutPush(t *testing.T) {
+	dc := NewContext(8, 8)
+	defer dc.Close()
+	if err := dc.PopCoverageGroup(); !errors.Is(err, ErrNoCoverageGroup) {
+		t.Fatalf("PopCoverageGroup = %v, want ErrNoCoverageGroup", err)
+	}
+}
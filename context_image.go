@@ -28,6 +28,15 @@ const (
 	// InterpBicubic performs cubic interpolation using a 4x4 pixel neighborhood.
 	// Highest quality but slower than bilinear.
 	InterpBicubic = intImage.InterpBicubic
+
+	// InterpMitchell performs cubic interpolation with the Mitchell-Netravali
+	// filter. Softer than bicubic with fewer ringing artifacts; a good
+	// default for downscaling.
+	InterpMitchell = intImage.InterpMitchell
+
+	// InterpLanczos performs Lanczos3 resampling using a 6x6 pixel
+	// neighborhood. Sharpest results for photographic content.
+	InterpLanczos = intImage.InterpLanczos
 )
 
 // ImageFormat represents a pixel storage format.
@@ -107,6 +116,13 @@ type DrawImageOptions struct {
 	// BlendMode specifies how to blend source and destination pixels.
 	// Default is BlendNormal.
 	BlendMode BlendMode
+
+	// DstQuad projects the image onto an arbitrary quadrilateral instead of
+	// an axis-aligned rectangle, enabling card-flip and free-transform
+	// effects. Corners are in user space, in source-image order: top-left,
+	// top-right, bottom-right, bottom-left. When set, X/Y/DstWidth/DstHeight
+	// are ignored; SrcRect, Interpolation, and Opacity still apply.
+	DstQuad *[4]Point
 }
 
 // DrawImage draws an image at the specified position.
@@ -173,10 +189,18 @@ func (c *Context) DrawImageEx(img *ImageBuf, opts DrawImageOptions) {
 		dstHeight = float64(srcH)
 	}
 
+	// Projective quad mapping bypasses the rectangle pipeline entirely.
+	if opts.DstQuad != nil {
+		c.drawImagePerspective(img, opts, srcX, srcY, srcW, srcH)
+		return
+	}
+
 	// Try GPU textured quad path first (Tier 3).
 	// This avoids the SetFillPattern→Fill() path which triggers mid-frame
 	// CPU flushes when GPU is active (the ImagePattern fallback problem).
-	if c.tryGPUDrawImage(img, opts, srcX, srcY, srcW, srcH, dstWidth, dstHeight) {
+	// Skipped when a shadow is active: the SetFillPattern→Fill() path runs
+	// the shadow pass (using the image alpha as silhouette), Tier 3 doesn't.
+	if c.shadow == nil && c.tryGPUDrawImage(img, opts, srcX, srcY, srcW, srcH, dstWidth, dstHeight) {
 		return
 	}
 
@@ -211,6 +235,7 @@ func (c *Context) DrawImageEx(img *ImageBuf, opts DrawImageOptions) {
 		inverse: inverse,
 		opacity: opts.Opacity,
 		clamp:   true, // Don't tile — clamp to image bounds
+		interp:  opts.Interpolation,
 	}
 
 	// Save current state (paint, path, transform).
@@ -229,6 +254,86 @@ func (c *Context) DrawImageEx(img *ImageBuf, opts DrawImageOptions) {
 	c.Pop()
 }
 
+// drawImagePerspective draws the source region projectively mapped onto
+// opts.DstQuad. The quad corners are transformed by the CTM (affine
+// transforms compose cleanly with the homography), a unit-square-to-quad
+// homography is built in device space, and the quad is filled through the
+// normal Fill() pipeline with a perspective pattern — so clipping, masks,
+// and edge anti-aliasing all behave as for rectangular image draws.
+func (c *Context) drawImagePerspective(img *ImageBuf, opts DrawImageOptions, srcX, srcY, srcW, srcH int) {
+	quad := *opts.DstQuad
+	ctm := c.totalMatrix()
+	var deviceQuad [4]Point
+	for i, pt := range quad {
+		deviceQuad[i] = ctm.TransformPoint(pt)
+	}
+
+	homography, ok := squareToQuad(deviceQuad)
+	if !ok {
+		return // degenerate quad: nothing sensible to draw
+	}
+
+	pattern := &perspectivePattern{
+		image:   img,
+		x:       srcX,
+		y:       srcY,
+		w:       srcW,
+		h:       srcH,
+		inverse: homography.Invert(),
+		interp:  opts.Interpolation,
+		opacity: opts.Opacity,
+	}
+
+	c.Push()
+	c.SetFillPattern(pattern)
+	c.MoveTo(quad[0].X, quad[0].Y)
+	c.LineTo(quad[1].X, quad[1].Y)
+	c.LineTo(quad[2].X, quad[2].Y)
+	c.LineTo(quad[3].X, quad[3].Y)
+	c.ClosePath()
+	_ = c.Fill()
+	c.Pop()
+}
+
+// perspectivePattern samples an image through an inverse homography,
+// mapping device-space pixels back to the unit square over the source
+// region. Outside the quad it is transparent; the fill pipeline's edge
+// coverage provides anti-aliasing.
+type perspectivePattern struct {
+	image   *ImageBuf
+	x, y    int       // source region offset within the image
+	w, h    int       // source region size
+	inverse Matrix3x3 // maps device-space -> unit square over the region
+	interp  InterpolationMode
+	opacity float64
+}
+
+// ColorAt implements the Pattern interface with projective sampling.
+func (p *perspectivePattern) ColorAt(x, y float64) RGBA {
+	uv := p.inverse.TransformPoint(Pt(x, y))
+	if uv.X < 0 || uv.X > 1 || uv.Y < 0 || uv.Y > 1 {
+		return RGBA{}
+	}
+
+	// Map the unit square to the source region, normalized to the full
+	// image as intImage.Sample expects.
+	imgW, imgH := p.image.Bounds()
+	u := (float64(p.x) + uv.X*float64(p.w)) / float64(imgW)
+	v := (float64(p.y) + uv.Y*float64(p.h)) / float64(imgH)
+
+	r, g, b, a := intImage.Sample(p.image, u, v, p.interp)
+	col := RGBA{
+		R: float64(r) / 255.0,
+		G: float64(g) / 255.0,
+		B: float64(b) / 255.0,
+		A: float64(a) / 255.0,
+	}
+	if p.opacity > 0 && p.opacity < 1.0 {
+		col.A *= p.opacity
+	}
+	return col
+}
+
 // tryGPUDrawImage attempts to render the image via GPU Tier 3 (textured quad).
 // Returns true if the image was queued for GPU rendering, false if the caller
 // should fall back to the CPU SetFillPattern→Fill() path.
@@ -353,11 +458,12 @@ func (c *Context) SetStrokePattern(pattern Pattern) {
 // is set directly by DrawImageEx or via SetTransform.
 type ImagePattern struct {
 	image   *ImageBuf
-	x, y    int     // source region offset within the image
-	w, h    int     // source region size (0 = full image dimension)
-	inverse Matrix  // maps device-space -> image-space (pre-computed)
-	opacity float64 // opacity multiplier (0=transparent, 1=opaque; 0 means default=1)
-	clamp   bool    // when true, out-of-bounds returns transparent instead of tiling
+	x, y    int               // source region offset within the image
+	w, h    int               // source region size (0 = full image dimension)
+	inverse Matrix            // maps device-space -> image-space (pre-computed)
+	opacity float64           // opacity multiplier (0=transparent, 1=opaque; 0 means default=1)
+	clamp   bool              // when true, out-of-bounds returns transparent instead of tiling
+	interp  InterpolationMode // sampling filter; zero value is InterpNearest
 
 	// Legacy fields for SetAnchor/SetScale backward compatibility.
 	// When these are used, rebuildInverse() computes the inverse from them.
@@ -388,6 +494,12 @@ func (p *ImagePattern) SetClamp(clamp bool) {
 	p.clamp = clamp
 }
 
+// SetInterpolation sets the sampling filter used in clamp mode.
+// The default is InterpNearest. Tiling mode always samples nearest.
+func (p *ImagePattern) SetInterpolation(mode InterpolationMode) {
+	p.interp = mode
+}
+
 // SetScale sets the scale factors for the pattern. The source image is scaled
 // by these factors before being sampled. For example, SetScale(2, 2) makes
 // each source pixel cover 2x2 destination pixels.
@@ -454,6 +566,24 @@ func (p *ImagePattern) ColorAt(x, y float64) RGBA {
 		if ix < 0 || ix >= patternW || iy < 0 || iy >= patternH {
 			return RGBA{}
 		}
+
+		// Filtered modes sample in normalized full-image coordinates.
+		if p.interp != InterpNearest {
+			u := (float64(p.x) + lx) / float64(imgW)
+			v := (float64(p.y) + ly) / float64(imgH)
+			r, g, b, a := intImage.Sample(p.image, u, v, p.interp)
+			col := RGBA{
+				R: float64(r) / 255.0,
+				G: float64(g) / 255.0,
+				B: float64(b) / 255.0,
+				A: float64(a) / 255.0,
+			}
+			if p.opacity > 0 && p.opacity < 1.0 {
+				col.A *= p.opacity
+			}
+			return col
+		}
+
 		ix += p.x
 		iy += p.y
 		r, g, b, a := p.image.GetRGBA(ix, iy)
@@ -576,6 +706,18 @@ func ImageBufFromImage(img image.Image) *ImageBuf {
 	return intImage.FromStdImage(img)
 }
 
+// ResizeImage resamples an image to the given dimensions using the
+// specified interpolation mode. Heavy downscales (more than 2x) go
+// through a mipmap chain before the final filtering pass, avoiding
+// aliasing on large reductions.
+//
+// Example:
+//
+//	thumb := gg.ResizeImage(img, 160, 120, gg.InterpLanczos)
+func ResizeImage(img *ImageBuf, width, height int, mode InterpolationMode) *ImageBuf {
+	return intImage.ResizeWithMipmaps(img, width, height, mode)
+}
+
 // DrawGPUTexture composites an existing GPU texture view as a textured quad
 // at (x, y) with the given dimensions. No CPU readback or upload — pure
 // GPU-to-GPU compositing. The view must be from the same device (e.g.,
@@ -354,6 +354,8 @@ func TestInterpolationModes(t *testing.T) {
 		InterpNearest,
 		InterpBilinear,
 		InterpBicubic,
+		InterpMitchell,
+		InterpLanczos,
 	}
 
 	for _, mode := range modes {
@@ -398,6 +400,48 @@ func TestInterpolationModes(t *testing.T) {
 	}
 }
 
+func TestResizeImage(t *testing.T) {
+	// Fine checkerboard: heavy downscales must average to gray rather
+	// than alias to pure black or white.
+	img, err := NewImageBuf(64, 64, FormatRGBA8)
+	if err != nil {
+		t.Fatalf("Failed to create image: %v", err)
+	}
+	for y := 0; y < 64; y++ {
+		for x := 0; x < 64; x++ {
+			v := byte(0)
+			if (x+y)%2 == 0 {
+				v = 255
+			}
+			_ = img.SetRGBA(x, y, v, v, v, 255)
+		}
+	}
+
+	modes := []InterpolationMode{InterpBilinear, InterpMitchell, InterpLanczos}
+	for _, mode := range modes {
+		t.Run(mode.String(), func(t *testing.T) {
+			thumb := ResizeImage(img, 8, 8, mode)
+			if thumb == nil {
+				t.Fatal("ResizeImage returned nil")
+			}
+			if w, h := thumb.Bounds(); w != 8 || h != 8 {
+				t.Fatalf("thumb size = %dx%d, want 8x8", w, h)
+			}
+			r, _, _, a := thumb.GetRGBA(4, 4)
+			if a != 255 {
+				t.Errorf("thumb alpha = %d, want 255", a)
+			}
+			if r < 96 || r > 160 {
+				t.Errorf("thumb center = %d, want ~128 (averaged)", r)
+			}
+		})
+	}
+
+	if ResizeImage(nil, 8, 8, InterpBilinear) != nil {
+		t.Error("ResizeImage(nil) should return nil")
+	}
+}
+
 func TestBlendModes(t *testing.T) {
 	modes := []struct {
 		mode BlendMode
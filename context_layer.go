@@ -12,7 +12,26 @@ type Layer struct {
 	pixmap    *Pixmap
 	blendMode BlendMode
 	opacity   float64
-	mask      *Mask // optional alpha mask, applied on PopLayer (nil = no mask)
+	mask      *Mask       // optional alpha mask, applied on PopLayer (nil = no mask)
+	filter    LayerFilter // optional filter, applied on PopLayer before the mask
+
+	// passthrough marks a layer that aliases its parent pixmap instead of
+	// isolating drawing, the memory-budget degradation for layers whose
+	// compositing (source-over, full opacity) makes isolation unobservable.
+	passthrough bool
+
+	// bytes is the memory tracked for this layer's pixmap, released on
+	// PopLayer. Zero for passthrough layers.
+	bytes int64
+}
+
+// LayerFilter processes a popped layer's pixels before compositing.
+// It reads the layer content from src and writes the filtered result to
+// dst (an equally sized, transparent pixmap). Implemented by
+// filter.Graph; defined as an interface here so the root package does
+// not depend on the filter package.
+type LayerFilter interface {
+	FilterPixmap(src, dst *Pixmap)
 }
 
 // layerStack manages the layer hierarchy for the context.
@@ -55,28 +74,80 @@ func (c *Context) PushLayer(blendMode BlendMode, opacity float64) {
 		opacity = 1
 	}
 
-	// Initialize layer stack if needed
+	c.prepareLayerPush()
+
+	bytes := c.layerPixmapBytes()
+	if err := reserveMemory(MemoryLayers, bytes); err != nil {
+		if blendMode == BlendNormal && opacity == 1 {
+			// Compositing a fresh transparent layer source-over at full
+			// opacity produces the same pixels as drawing directly on the
+			// parent, so degrade to a passthrough layer instead of
+			// allocating over budget.
+			c.layerStack.layers = append(c.layerStack.layers, &Layer{
+				pixmap:      c.pixmap,
+				blendMode:   blendMode,
+				opacity:     opacity,
+				passthrough: true,
+			})
+			return
+		}
+		// Isolation is observable for this blend/opacity; allocate anyway
+		// so output stays correct, but record the overshoot.
+		Logger().Warn("PushLayer over memory budget",
+			"bytes", bytes, "budget", MemoryBudget())
+		trackMemory(MemoryLayers, bytes)
+	}
+
+	c.pushLayerPixmap(&Layer{blendMode: blendMode, opacity: opacity}, bytes)
+}
+
+// TryPushLayer is PushLayer for budget-strict callers: if the layer pixmap
+// would exceed the memory budget (SetMemoryBudget), it pushes nothing and
+// returns ErrMemoryBudget instead of degrading or over-allocating.
+func (c *Context) TryPushLayer(blendMode BlendMode, opacity float64) error {
+	if opacity < 0 {
+		opacity = 0
+	}
+	if opacity > 1 {
+		opacity = 1
+	}
+
+	c.prepareLayerPush()
+
+	bytes := c.layerPixmapBytes()
+	if err := reserveMemory(MemoryLayers, bytes); err != nil {
+		return err
+	}
+	c.pushLayerPixmap(&Layer{blendMode: blendMode, opacity: opacity}, bytes)
+	return nil
+}
+
+// prepareLayerPush initializes the layer stack and saves the base pixmap
+// ahead of the first push.
+func (c *Context) prepareLayerPush() {
 	if c.layerStack == nil {
 		c.layerStack = newLayerStack()
 	}
-
-	// Save base pixmap on first push
 	if len(c.layerStack.layers) == 0 && c.basePixmap == nil {
 		c.basePixmap = c.pixmap
 	}
+}
+
+// layerPixmapBytes returns the size of one layer pixmap for accounting.
+func (c *Context) layerPixmapBytes() int64 {
+	return int64(c.width) * int64(c.height) * 4
+}
 
-	// Create new pixmap for the layer (same size as context)
+// pushLayerPixmap allocates the layer's pixmap (same size as the context),
+// pushes the layer, and makes it the active drawing target. bytes is the
+// memory already tracked for the pixmap, released on PopLayer.
+func (c *Context) pushLayerPixmap(layer *Layer, bytes int64) {
 	layerPixmap := NewPixmap(c.width, c.height)
 	layerPixmap.Clear(Transparent)
 
-	// Create layer
-	layer := &Layer{
-		pixmap:    layerPixmap,
-		blendMode: blendMode,
-		opacity:   opacity,
-	}
+	layer.pixmap = layerPixmap
+	layer.bytes = bytes
 
-	// Save current pixmap and switch to layer pixmap
 	c.layerStack.layers = append(c.layerStack.layers, layer)
 	c.pixmap = layerPixmap
 }
@@ -114,6 +185,25 @@ func (c *Context) PopLayer() {
 		c.basePixmap = nil
 	}
 
+	// Passthrough layers drew straight onto the parent; nothing to
+	// composite or release.
+	if layer.passthrough {
+		c.pixmap = parentPixmap
+		return
+	}
+
+	// Apply filter to layer content before masking and compositing
+	// (PushLayerWithFilter).
+	var filterBytes int64
+	if layer.filter != nil {
+		filterBytes = int64(layer.pixmap.Width()) * int64(layer.pixmap.Height()) * 4
+		trackMemory(MemoryFilters, filterBytes)
+		filtered := NewPixmap(layer.pixmap.Width(), layer.pixmap.Height())
+		filtered.Clear(Transparent)
+		layer.filter.FilterPixmap(layer.pixmap, filtered)
+		layer.pixmap = filtered
+	}
+
 	// Apply mask to layer content before compositing (PushMaskLayer).
 	if layer.mask != nil {
 		c.applyMaskToPixmap(layer.pixmap, layer.mask)
@@ -122,10 +212,49 @@ func (c *Context) PopLayer() {
 	// Composite layer onto parent
 	c.compositeLayer(layer, parentPixmap)
 
+	releaseMemory(MemoryLayers, layer.bytes)
+	if filterBytes > 0 {
+		releaseMemory(MemoryFilters, filterBytes)
+	}
+
 	// Restore parent pixmap as current drawing target
 	c.pixmap = parentPixmap
 }
 
+// PushLayerWithFilter creates an isolated layer whose content is run
+// through the given filter when the layer is popped, before it is
+// composited onto the parent with the given blend mode and opacity.
+// A nil filter is equivalent to PushLayer.
+//
+// Example:
+//
+//	glow := filter.NewGraph(filter.Blur(8)).BlendOriginal(filter.OriginalOver, 1.0)
+//	dc.PushLayerWithFilter(gg.BlendNormal, 1.0, glow)
+//	// ... draw ...
+//	dc.PopLayer() // blurred halo with the crisp original on top
+func (c *Context) PushLayerWithFilter(blendMode BlendMode, opacity float64, f LayerFilter) {
+	c.PushLayer(blendMode, opacity)
+	if f == nil || c.layerStack == nil || len(c.layerStack.layers) == 0 {
+		return
+	}
+	layer := c.layerStack.layers[len(c.layerStack.layers)-1]
+	if layer.passthrough {
+		// A filter makes isolation observable, so a budget-degraded
+		// passthrough layer must be upgraded to a real one.
+		bytes := c.layerPixmapBytes()
+		Logger().Warn("PushLayerWithFilter over memory budget",
+			"bytes", bytes, "budget", MemoryBudget())
+		trackMemory(MemoryLayers, bytes)
+		layerPixmap := NewPixmap(c.width, c.height)
+		layerPixmap.Clear(Transparent)
+		layer.pixmap = layerPixmap
+		layer.bytes = bytes
+		layer.passthrough = false
+		c.pixmap = layerPixmap
+	}
+	layer.filter = f
+}
+
 // PushMaskLayer creates an isolated layer with an associated alpha mask.
 // All subsequent drawing operations render to this layer normally (without masking).
 // When PopLayer is called, the ENTIRE layer is masked by the mask and then
@@ -152,31 +281,22 @@ func (c *Context) PushMaskLayer(mask *Mask) {
 		return
 	}
 
-	// Initialize layer stack if needed.
-	if c.layerStack == nil {
-		c.layerStack = newLayerStack()
-	}
+	c.prepareLayerPush()
 
-	// Save base pixmap on first push.
-	if len(c.layerStack.layers) == 0 && c.basePixmap == nil {
-		c.basePixmap = c.pixmap
+	// Masking the composited group is observable, so there is no
+	// passthrough degradation; over budget the layer is still allocated.
+	bytes := c.layerPixmapBytes()
+	if err := reserveMemory(MemoryLayers, bytes); err != nil {
+		Logger().Warn("PushMaskLayer over memory budget",
+			"bytes", bytes, "budget", MemoryBudget())
+		trackMemory(MemoryLayers, bytes)
 	}
 
-	// Create new pixmap for the layer (same size as context).
-	layerPixmap := NewPixmap(c.width, c.height)
-	layerPixmap.Clear(Transparent)
-
-	// Create layer with mask.
-	layer := &Layer{
-		pixmap:    layerPixmap,
+	c.pushLayerPixmap(&Layer{
 		blendMode: BlendNormal,
 		opacity:   1.0,
 		mask:      mask,
-	}
-
-	// Switch to layer pixmap.
-	c.layerStack.layers = append(c.layerStack.layers, layer)
-	c.pixmap = layerPixmap
+	}, bytes)
 }
 
 // applyMaskToPixmap applies a DestinationIn mask to a pixmap's pixel data.
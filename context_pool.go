@@ -0,0 +1,145 @@
+package gg
+
+import "sync"
+
+// ContextPool recycles Contexts — and with them their backing Pixmaps and
+// renderer scratch state — across renders. Servers that render a small
+// image per request can Get a context, draw, export, and Put it back,
+// paying the pixmap and rasterizer allocations only on the first render
+// of each size.
+//
+// ContextPool is safe for concurrent use by multiple goroutines. The
+// individual Contexts it hands out are not: each Get/Put pair must stay
+// on one goroutine at a time, as with any Context.
+//
+// The zero value is not usable; create pools with NewContextPool or
+// NewContextPoolWithFactory.
+type ContextPool struct {
+	mu      sync.Mutex
+	free    map[poolSize][]*Context
+	factory func(width, height int) *Context
+}
+
+type poolSize struct {
+	width, height int
+}
+
+// maxPooledPerSize bounds how many idle contexts the pool retains for a
+// single size; extras handed back via Put are closed instead of kept.
+const maxPooledPerSize = 8
+
+// NewContextPool creates a pool that builds contexts with NewContext.
+func NewContextPool() *ContextPool {
+	return NewContextPoolWithFactory(func(width, height int) *Context {
+		return NewContext(width, height)
+	})
+}
+
+// NewContextPoolWithFactory creates a pool that builds new contexts with
+// the given factory, e.g. to inject a custom renderer per context.
+// The factory is called only on pool misses. Returns nil if factory is nil.
+func NewContextPoolWithFactory(factory func(width, height int) *Context) *ContextPool {
+	if factory == nil {
+		return nil
+	}
+	return &ContextPool{factory: factory}
+}
+
+// Get returns a context with the given logical dimensions, reusing a
+// pooled one when available. Reused contexts come back with default
+// graphics state and fully transparent pixels, as from NewContext.
+// Returns nil for non-positive dimensions.
+func (p *ContextPool) Get(width, height int) *Context {
+	if width <= 0 || height <= 0 {
+		return nil
+	}
+	key := poolSize{width, height}
+
+	var dc *Context
+	p.mu.Lock()
+	if list := p.free[key]; len(list) > 0 {
+		n := len(list) - 1
+		dc = list[n]
+		list[n] = nil
+		p.free[key] = list[:n]
+	}
+	p.mu.Unlock()
+
+	if dc == nil {
+		return p.factory(width, height)
+	}
+	dc.pixmap.Clear(RGBA{})
+	dc.ResetDirty()
+	return dc
+}
+
+// Put returns a context to the pool for reuse. The context must not be
+// used after Put. Contexts that cannot be safely recycled — nil, already
+// closed, using a device scale other than 1.0, or surplus to the
+// per-size retention limit — are closed instead of pooled.
+func (p *ContextPool) Put(dc *Context) {
+	if dc == nil || dc.closed {
+		return
+	}
+	if dc.deviceScale != 1.0 {
+		_ = dc.Close()
+		return
+	}
+
+	// Unwind any layers the caller left open so the pooled context does
+	// not carry layer pixmaps (or their memory accounting) across renders.
+	for dc.layerStack != nil && len(dc.layerStack.layers) > 0 {
+		dc.PopLayer()
+	}
+	dc.resetForReuse()
+
+	key := poolSize{dc.width, dc.height}
+	p.mu.Lock()
+	if p.free == nil {
+		p.free = make(map[poolSize][]*Context)
+	}
+	pooled := len(p.free[key]) < maxPooledPerSize
+	if pooled {
+		p.free[key] = append(p.free[key], dc)
+	}
+	p.mu.Unlock()
+
+	if !pooled {
+		_ = dc.Close()
+	}
+}
+
+// Close releases all idle contexts held by the pool. Contexts currently
+// checked out are unaffected; Put after Close pools them again.
+func (p *ContextPool) Close() error {
+	p.mu.Lock()
+	free := p.free
+	p.free = nil
+	p.mu.Unlock()
+
+	for _, list := range free {
+		for _, dc := range list {
+			_ = dc.Close()
+		}
+	}
+	return nil
+}
+
+// resetForReuse restores everything Reset leaves alone but that must not
+// leak from one pooled render into the next: text tuning, pixel snapping,
+// rasterizer/pipeline selection, and damage tracking.
+func (c *Context) resetForReuse() {
+	c.Reset()
+	c.pipelineMode = PipelineModeAuto
+	c.rasterizerMode = RasterizerAuto
+	c.flattenTolerance = 0
+	c.pixelSnapping = false
+	c.textMode = TextModeAuto
+	c.textGamma = 0
+	c.textContrast = 0
+	c.stemDarkening = false
+	c.textRuns = textRunCache{}
+	c.frameDamageRects = c.frameDamageRects[:0]
+	c.damageTrackingEnabled = true
+	c.ResetDirty()
+}
@@ -0,0 +1,143 @@
+package gg
+
+import (
+	"sync"
+	"testing"
+)
+
+func TestContextPoolReuse(t *testing.T) {
+	pool := NewContextPool()
+	defer pool.Close()
+
+	dc := pool.Get(64, 64)
+	if dc == nil {
+		t.Fatal("Get(64, 64) returned nil")
+	}
+	pm := dc.pixmap
+	dc.SetRGB(1, 0, 0)
+	dc.DrawRectangle(0, 0, 64, 64)
+	dc.Fill()
+	pool.Put(dc)
+
+	again := pool.Get(64, 64)
+	if again != dc {
+		t.Error("Get after Put did not reuse the pooled context")
+	}
+	if again.pixmap != pm {
+		t.Error("reused context did not keep its pixmap")
+	}
+	if px := again.pixmap.GetPixel(32, 32); px != (RGBA{}) {
+		t.Errorf("reused context pixel = %v, want transparent", px)
+	}
+	pool.Put(again)
+}
+
+func TestContextPoolSeparateSizes(t *testing.T) {
+	pool := NewContextPool()
+	defer pool.Close()
+
+	small := pool.Get(32, 32)
+	pool.Put(small)
+
+	large := pool.Get(128, 128)
+	if large == small {
+		t.Error("Get(128, 128) returned a 32x32 context")
+	}
+	if large.Width() != 128 || large.Height() != 128 {
+		t.Errorf("dimensions = %dx%d, want 128x128", large.Width(), large.Height())
+	}
+	pool.Put(large)
+}
+
+func TestContextPoolStateReset(t *testing.T) {
+	pool := NewContextPool()
+	defer pool.Close()
+
+	dc := pool.Get(48, 48)
+	dc.SetPixelSnapping(true)
+	dc.SetTextMode(TextModeMSDF)
+	dc.Scale(2, 2)
+	dc.PushLayer(BlendMultiply, 0.5) // left open deliberately
+	pool.Put(dc)
+
+	again := pool.Get(48, 48)
+	if again != dc {
+		t.Fatal("expected the pooled context back")
+	}
+	if again.pixelSnapping {
+		t.Error("pixel snapping leaked through Put")
+	}
+	if again.textMode != TextModeAuto {
+		t.Error("text mode leaked through Put")
+	}
+	if again.matrix != Identity() {
+		t.Error("transform leaked through Put")
+	}
+	if again.layerStack != nil && len(again.layerStack.layers) != 0 {
+		t.Error("open layer leaked through Put")
+	}
+	pool.Put(again)
+}
+
+func TestContextPoolRejects(t *testing.T) {
+	pool := NewContextPool()
+	defer pool.Close()
+
+	if pool.Get(0, 10) != nil {
+		t.Error("Get(0, 10) should return nil")
+	}
+	pool.Put(nil) // must not panic
+
+	closed := pool.Get(16, 16)
+	if err := closed.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+	pool.Put(closed)
+	if pool.Get(16, 16) == closed {
+		t.Error("closed context was pooled")
+	}
+
+	if NewContextPoolWithFactory(nil) != nil {
+		t.Error("NewContextPoolWithFactory(nil) should return nil")
+	}
+}
+
+func TestContextPoolFactory(t *testing.T) {
+	calls := 0
+	pool := NewContextPoolWithFactory(func(width, height int) *Context {
+		calls++
+		return NewContext(width, height)
+	})
+	defer pool.Close()
+
+	dc := pool.Get(40, 40)
+	pool.Put(dc)
+	pool.Get(40, 40) // pool hit — factory not called again
+	if calls != 1 {
+		t.Errorf("factory called %d times, want 1", calls)
+	}
+}
+
+func TestContextPoolConcurrent(t *testing.T) {
+	pool := NewContextPool()
+	defer pool.Close()
+
+	var wg sync.WaitGroup
+	for g := 0; g < 8; g++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 20; i++ {
+				dc := pool.Get(50, 50)
+				dc.SetRGB(0, 1, 0)
+				dc.DrawCircle(25, 25, 20)
+				dc.Fill()
+				if px := dc.pixmap.GetPixel(25, 25); px.G == 0 {
+					t.Error("circle not rendered on pooled context")
+				}
+				pool.Put(dc)
+			}
+		}()
+	}
+	wg.Wait()
+}
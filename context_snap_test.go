@@ -0,0 +1,148 @@
+package gg
+
+import "testing"
+
+func TestPixelSnappingDefault(t *testing.T) {
+	dc := NewContext(10, 10)
+	if dc.PixelSnapping() {
+		t.Error("pixel snapping should be disabled by default")
+	}
+	dc.SetPixelSnapping(true)
+	if !dc.PixelSnapping() {
+		t.Error("SetPixelSnapping(true) not reflected")
+	}
+}
+
+func TestPixelSnappingHorizontalStroke(t *testing.T) {
+	// A 1px stroke centered on the pixel boundary y=20 normally splits its
+	// coverage across rows 19 and 20. Snapping moves the centerline to
+	// y=20.5, so row 20 gets full coverage and its neighbors stay white.
+	dc := NewContext(100, 100)
+	dc.ClearWithColor(White)
+	dc.SetPixelSnapping(true)
+	dc.SetRGB(0, 0, 0)
+	dc.SetLineWidth(1)
+	dc.DrawLine(10, 20, 90, 20)
+	if err := dc.Stroke(); err != nil {
+		t.Fatal(err)
+	}
+
+	on := dc.pixmap.GetPixel(50, 20)
+	if on.R > 0.1 {
+		t.Errorf("snapped stroke row R = %v, want fully dark", on.R)
+	}
+	for _, y := range []int{19, 21} {
+		off := dc.pixmap.GetPixel(50, y)
+		if off.R < 0.9 {
+			t.Errorf("row y=%d R = %v, want white (no gray bleed)", y, off.R)
+		}
+	}
+}
+
+func TestPixelSnappingRectFill(t *testing.T) {
+	// Fractional rect edges snap to whole pixels: no partially covered
+	// edge columns or rows.
+	dc := NewContext(100, 100)
+	dc.ClearWithColor(White)
+	dc.SetPixelSnapping(true)
+	dc.SetRGB(0, 0, 0)
+	dc.DrawRectangle(10.4, 10.4, 20.2, 20.2)
+	if err := dc.Fill(); err != nil {
+		t.Fatal(err)
+	}
+
+	// Snapped to [10, 31) x [10, 31).
+	if c := dc.pixmap.GetPixel(10, 15); c.R > 0.05 {
+		t.Errorf("left edge column R = %v, want fully dark", c.R)
+	}
+	if c := dc.pixmap.GetPixel(30, 15); c.R > 0.05 {
+		t.Errorf("right edge column R = %v, want fully dark", c.R)
+	}
+	if c := dc.pixmap.GetPixel(9, 15); c.R < 0.95 {
+		t.Errorf("column left of rect R = %v, want white", c.R)
+	}
+	if c := dc.pixmap.GetPixel(31, 15); c.R < 0.95 {
+		t.Errorf("column right of rect R = %v, want white", c.R)
+	}
+}
+
+func TestPixelSnappingDeviceScale(t *testing.T) {
+	// At device scale 2, a 1px logical stroke is 2 device pixels (even
+	// width), so the centerline snaps to a device pixel boundary and the
+	// stroke covers exactly two physical rows.
+	dc := NewContext(50, 50, WithDeviceScale(2.0))
+	dc.ClearWithColor(White)
+	dc.SetPixelSnapping(true)
+	dc.SetRGB(0, 0, 0)
+	dc.SetLineWidth(1)
+	dc.DrawLine(5, 20.3, 45, 20.3)
+	if err := dc.Stroke(); err != nil {
+		t.Fatal(err)
+	}
+
+	// Snapped center: device y=41, covering physical rows 40 and 41.
+	for _, y := range []int{40, 41} {
+		if c := dc.pixmap.GetPixel(40, y); c.R > 0.1 {
+			t.Errorf("physical row y=%d R = %v, want fully dark", y, c.R)
+		}
+	}
+	for _, y := range []int{39, 42} {
+		if c := dc.pixmap.GetPixel(40, y); c.R < 0.9 {
+			t.Errorf("physical row y=%d R = %v, want white", y, c.R)
+		}
+	}
+}
+
+func TestPixelSnappingLeavesDiagonalsAlone(t *testing.T) {
+	snapped := NewContext(100, 100)
+	snapped.ClearWithColor(White)
+	snapped.SetPixelSnapping(true)
+
+	plain := NewContext(100, 100)
+	plain.ClearWithColor(White)
+
+	for _, dc := range []*Context{snapped, plain} {
+		dc.SetRGB(0, 0, 0)
+		dc.SetLineWidth(1)
+		dc.DrawLine(10.3, 10.3, 80.7, 65.2)
+		if err := dc.Stroke(); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	for y := 0; y < 100; y += 5 {
+		for x := 0; x < 100; x += 5 {
+			a := snapped.pixmap.GetPixel(x, y)
+			b := plain.pixmap.GetPixel(x, y)
+			if a != b {
+				t.Fatalf("diagonal stroke changed by snapping at (%d,%d): %v vs %v", x, y, a, b)
+			}
+		}
+	}
+}
+
+func TestPathIsAxisAligned(t *testing.T) {
+	rect := NewPath()
+	rect.Rectangle(10, 10, 30, 20)
+	if !pathIsAxisAligned(rect) {
+		t.Error("rectangle should be axis-aligned")
+	}
+
+	diag := NewPath()
+	diag.MoveTo(0, 0)
+	diag.LineTo(10, 10)
+	if pathIsAxisAligned(diag) {
+		t.Error("diagonal should not be axis-aligned")
+	}
+
+	curved := NewPath()
+	curved.MoveTo(0, 0)
+	curved.QuadraticTo(5, 0, 10, 0)
+	if pathIsAxisAligned(curved) {
+		t.Error("curves should not be axis-aligned")
+	}
+
+	if pathIsAxisAligned(NewPath()) {
+		t.Error("empty path should not report axis-aligned")
+	}
+}
@@ -0,0 +1,113 @@
+package gg
+
+import (
+	"github.com/gogpu/gg/internal/clip"
+	"github.com/gogpu/gg/text"
+)
+
+// Random-access graphics state snapshots (beyond the LIFO Push/Pop stack).
+//
+// Push/Pop restores states in strict LIFO order, which is the right model
+// for nested drawing but not for editor-style tools that jump between
+// saved states. SaveState captures the full graphics state as an
+// immutable snapshot that can be restored any number of times, in any
+// order, independent of the Push/Pop stack.
+
+// StateSnapshot is an immutable capture of a Context's graphics state:
+// transform, paint, font face, clip regions, mask, shadow, and
+// anti-aliasing.
+// It does not capture the current path or pixel contents.
+//
+// Snapshots are created with Context.SaveState and applied with
+// Context.RestoreState. A snapshot stays valid for the lifetime of the
+// context it was taken from and can be restored multiple times.
+type StateSnapshot struct {
+	matrix      Matrix
+	paint       *Paint
+	face        text.Face
+	antiAlias   bool
+	clipStack   *clip.ClipStack
+	gpuClipPath *Path
+	mask        *Mask
+	shadow      *shadowState
+}
+
+// SaveState captures the current graphics state as an immutable snapshot.
+// Unlike Push, this does not modify the state stack; the snapshot can be
+// restored at any later point with RestoreState, in any order.
+func (c *Context) SaveState() *StateSnapshot {
+	s := &StateSnapshot{
+		matrix:    c.matrix,
+		paint:     c.paint.Clone(),
+		antiAlias: c.antiAlias,
+	}
+	s.face = c.face
+	if c.clipStack != nil {
+		s.clipStack = c.clipStack.Clone()
+	}
+	if c.gpuClipPath != nil {
+		s.gpuClipPath = c.gpuClipPath.Clone()
+	}
+	if c.mask != nil {
+		s.mask = c.mask.Clone()
+	}
+	if c.shadow != nil {
+		shadow := *c.shadow
+		s.shadow = &shadow
+	}
+	return s
+}
+
+// RestoreState restores the graphics state captured in the snapshot.
+// The Push/Pop stack is left untouched, so mixing RestoreState with an
+// open Push is allowed: the next Pop still restores the state saved by
+// the matching Push. Passing nil is a no-op.
+func (c *Context) RestoreState(s *StateSnapshot) {
+	if s == nil {
+		return
+	}
+	c.matrix = s.matrix
+	c.paint = s.paint.Clone()
+	c.face = s.face
+	c.antiAlias = s.antiAlias
+	if s.clipStack != nil {
+		c.clipStack = s.clipStack.Clone()
+	} else {
+		c.clipStack = nil
+	}
+	if s.gpuClipPath != nil {
+		c.gpuClipPath = s.gpuClipPath.Clone()
+	} else {
+		c.gpuClipPath = nil
+	}
+	if s.mask != nil {
+		c.mask = s.mask.Clone()
+	} else {
+		c.mask = nil
+	}
+	if s.shadow != nil {
+		shadow := *s.shadow
+		c.shadow = &shadow
+	} else {
+		c.shadow = nil
+	}
+}
+
+// Reset restores the context to its initial graphics state: identity
+// transform, default paint, no clip, no mask, empty path, and empty
+// state stacks. Pixel contents are not touched; use Clear for that.
+func (c *Context) Reset() {
+	c.matrix = Identity()
+	c.paint = NewPaint()
+	c.face = nil
+	c.antiAlias = true
+	c.path.Clear()
+	c.clipStack = nil
+	c.gpuClipPath = nil
+	c.mask = nil
+	c.shadow = nil
+	c.stack = c.stack[:0]
+	c.clipStackDepth = c.clipStackDepth[:0]
+	c.maskStack = c.maskStack[:0]
+	c.antiAliasStack = c.antiAliasStack[:0]
+}
@@ -0,0 +1,144 @@
+package gg
+
+import "testing"
+
+func TestSaveStateRestoreState(t *testing.T) {
+	dc := NewContext(100, 100)
+	dc.Translate(10, 20)
+	dc.SetRGB(1, 0, 0)
+	dc.SetLineWidth(5)
+	snap := dc.SaveState()
+
+	// Mutate everything the snapshot covers.
+	dc.Identity()
+	dc.SetRGB(0, 0, 1)
+	dc.SetLineWidth(1)
+	dc.SetAntiAlias(false)
+
+	dc.RestoreState(snap)
+
+	if m := dc.GetTransform(); m != Translate(10, 20) {
+		t.Errorf("matrix not restored: %+v", m)
+	}
+	if c := dc.paint.ColorAt(0, 0); c != RGB(1, 0, 0) {
+		t.Errorf("color not restored: %+v", c)
+	}
+	if dc.paint.LineWidth != 5 {
+		t.Errorf("line width not restored: %v", dc.paint.LineWidth)
+	}
+	if !dc.antiAlias {
+		t.Error("anti-alias not restored")
+	}
+}
+
+func TestRestoreStateOutOfOrder(t *testing.T) {
+	dc := NewContext(100, 100)
+
+	dc.SetRGB(1, 0, 0)
+	red := dc.SaveState()
+	dc.SetRGB(0, 1, 0)
+	green := dc.SaveState()
+	dc.SetRGB(0, 0, 1)
+
+	// Restore in arbitrary order, each snapshot more than once.
+	dc.RestoreState(red)
+	if dc.paint.ColorAt(0, 0) != RGB(1, 0, 0) {
+		t.Error("red snapshot not restored")
+	}
+	dc.RestoreState(green)
+	if dc.paint.ColorAt(0, 0) != RGB(0, 1, 0) {
+		t.Error("green snapshot not restored")
+	}
+	dc.RestoreState(red)
+	if dc.paint.ColorAt(0, 0) != RGB(1, 0, 0) {
+		t.Error("red snapshot not restorable a second time")
+	}
+}
+
+func TestSnapshotImmutable(t *testing.T) {
+	dc := NewContext(100, 100)
+	dc.SetRGB(1, 0, 0)
+	snap := dc.SaveState()
+
+	// Mutating the context after restore must not leak into the snapshot.
+	dc.RestoreState(snap)
+	dc.SetRGB(0, 0, 1)
+	dc.RestoreState(snap)
+	if dc.paint.ColorAt(0, 0) != RGB(1, 0, 0) {
+		t.Error("snapshot mutated by post-restore changes")
+	}
+}
+
+func TestRestoreStateClip(t *testing.T) {
+	dc := NewContext(100, 100)
+	unclipped := dc.SaveState()
+
+	dc.ClipRect(25, 25, 50, 50)
+	clipped := dc.SaveState()
+
+	dc.RestoreState(unclipped)
+	if dc.clipStack != nil && dc.clipStack.Depth() != 0 {
+		t.Error("clip not cleared by restoring unclipped state")
+	}
+
+	dc.RestoreState(clipped)
+	if dc.clipStack == nil || dc.clipStack.Depth() != 1 {
+		t.Error("clip not restored from clipped snapshot")
+	}
+
+	// Fill full canvas; only the clipped region should be painted.
+	dc.SetRGB(1, 0, 0)
+	dc.DrawRectangle(0, 0, 100, 100)
+	_ = dc.Fill()
+	if got := dc.pixmap.GetPixel(50, 50); got.R < 0.9 {
+		t.Errorf("inside clip not painted: %+v", got)
+	}
+	if got := dc.pixmap.GetPixel(5, 5); got.R > 0.1 {
+		t.Errorf("outside clip painted: %+v", got)
+	}
+}
+
+func TestRestoreStateNil(t *testing.T) {
+	dc := NewContext(10, 10)
+	dc.SetRGB(1, 0, 0)
+	dc.RestoreState(nil) // must not panic or change state
+	if dc.paint.ColorAt(0, 0) != RGB(1, 0, 0) {
+		t.Error("nil restore changed state")
+	}
+}
+
+func TestContextReset(t *testing.T) {
+	dc := NewContext(100, 100)
+	dc.Push()
+	dc.Translate(10, 10)
+	dc.SetRGB(0, 1, 0)
+	dc.SetLineWidth(7)
+	dc.ClipRect(0, 0, 50, 50)
+	dc.MoveTo(0, 0)
+	dc.LineTo(10, 10)
+	dc.SetAntiAlias(false)
+
+	dc.Reset()
+
+	if dc.GetTransform() != Identity() {
+		t.Error("matrix not reset")
+	}
+	if dc.paint.ColorAt(0, 0) != Black {
+		t.Errorf("paint not reset: %+v", dc.paint.ColorAt(0, 0))
+	}
+	if dc.paint.LineWidth != 1 {
+		t.Errorf("line width not reset: %v", dc.paint.LineWidth)
+	}
+	if dc.clipStack != nil {
+		t.Error("clip not reset")
+	}
+	if dc.path.NumVerbs() != 0 {
+		t.Error("path not cleared")
+	}
+	if len(dc.stack) != 0 {
+		t.Error("state stack not cleared")
+	}
+	if !dc.antiAlias {
+		t.Error("anti-alias not reset")
+	}
+}
@@ -38,6 +38,14 @@ type CoverageFiller interface {
 		callback func(x, y int, coverage uint8))
 }
 
+// FlattenToleranceSetter is an optional interface for CoverageFillers with
+// an adjustable curve flattening tolerance. The renderer calls it before
+// FillCoverage with the tolerance override in device pixels, or 0 to
+// restore the implementation's own default.
+type FlattenToleranceSetter interface {
+	SetFlattenTolerance(tolerance float32)
+}
+
 var (
 	coverageMu     sync.RWMutex
 	coverageFiller CoverageFiller
@@ -0,0 +1,102 @@
+package gg
+
+import (
+	"image"
+	"testing"
+)
+
+func TestDirtyBoundsAccumulates(t *testing.T) {
+	dc := NewContext(200, 200)
+	defer dc.Close()
+
+	if !dc.DirtyBounds().Empty() {
+		t.Fatalf("fresh context should have empty dirty bounds, got %v", dc.DirtyBounds())
+	}
+
+	dc.DrawRectangle(10, 10, 20, 20)
+	dc.Fill()
+	dc.DrawRectangle(100, 150, 30, 30)
+	dc.Fill()
+
+	got := dc.DirtyBounds()
+	want := image.Rect(10, 10, 130, 180)
+	if !want.In(got) {
+		t.Errorf("dirty bounds %v does not cover both draws (want at least %v)", got, want)
+	}
+}
+
+func TestDirtyBoundsExactAcrossManyDraws(t *testing.T) {
+	// FrameDamage merges rects past a threshold; DirtyBounds must stay
+	// the exact union regardless of draw count.
+	dc := NewContext(400, 100)
+	defer dc.Close()
+
+	for i := 0; i < 32; i++ {
+		dc.DrawRectangle(float64(i*10+5), 40, 5, 10)
+		dc.Fill()
+	}
+
+	got := dc.DirtyBounds()
+	if got.Min.X > 5 || got.Max.X < 320 || got.Min.Y > 40 || got.Max.Y < 50 {
+		t.Errorf("dirty bounds %v does not span all 32 draws", got)
+	}
+	if got.Min.Y < 35 || got.Max.Y > 55 {
+		t.Errorf("dirty bounds %v overestimates vertical extent", got)
+	}
+}
+
+func TestDirtyBoundsResetOnClear(t *testing.T) {
+	dc := NewContext(100, 100)
+	defer dc.Close()
+
+	dc.DrawRectangle(10, 10, 20, 20)
+	dc.Fill()
+	if dc.DirtyBounds().Empty() {
+		t.Fatal("expected non-empty dirty bounds after draw")
+	}
+
+	dc.Clear()
+	if !dc.DirtyBounds().Empty() {
+		t.Errorf("Clear did not reset dirty bounds: %v", dc.DirtyBounds())
+	}
+
+	dc.DrawRectangle(50, 50, 10, 10)
+	dc.Fill()
+	dc.ResetDirty()
+	if !dc.DirtyBounds().Empty() {
+		t.Errorf("ResetDirty did not reset dirty bounds: %v", dc.DirtyBounds())
+	}
+}
+
+func TestDirtyBoundsHiDPI(t *testing.T) {
+	dc := NewContext(100, 100, WithDeviceScale(2.0))
+	defer dc.Close()
+
+	dc.DrawRectangle(10, 10, 20, 20)
+	dc.Fill()
+
+	// Device space: logical coordinates scaled by 2.
+	got := dc.DirtyBounds()
+	if got.Min.X > 20 || got.Max.X < 60 {
+		t.Errorf("dirty bounds %v not in physical pixels", got)
+	}
+}
+
+func TestDirtyBoundsRespectsTrackingToggle(t *testing.T) {
+	dc := NewContext(100, 100)
+	defer dc.Close()
+
+	dc.SetDamageTracking(false)
+	dc.DrawRectangle(10, 10, 20, 20)
+	dc.Fill()
+	if !dc.DirtyBounds().Empty() {
+		t.Errorf("suppressed draw accumulated dirty bounds: %v", dc.DirtyBounds())
+	}
+
+	dc.SetDamageTracking(true)
+	dc.DrawRectangle(10, 10, 20, 20)
+	dc.Fill()
+	if dc.DirtyBounds().Empty() {
+		t.Error("re-enabled tracking did not accumulate")
+	}
+}
@@ -0,0 +1,173 @@
+// Package filter exposes composable image effects as a public API over
+// the internal filter implementations. Effects are modeled as nodes —
+// similar to SVG filter primitives — that can be chained into a Graph
+// (for example blur -> color matrix -> blend with the original) and
+// attached to scene layers or to gg.Context.PushLayerWithFilter.
+//
+// # Supported Features
+//
+//   - Gaussian blur (uniform and per-axis radii)
+//   - Color matrix effects: brightness, contrast, saturation, grayscale,
+//     sepia, hue rotation, invert, opacity, tint, and arbitrary 5x4 matrices
+//   - Drop shadow
+//   - Graphs of nodes applied in sequence, optionally blended with the
+//     unfiltered original (glow/overlay-style effects)
+//
+// # Limitations
+//
+//   - Execution is CPU-only. Graphs implement scene.Filter, so a GPU
+//     executor can take over per node once compute-shader filters land.
+//   - Nodes process full premultiplied RGBA pixmaps; there is no region
+//     streaming for very large layers yet.
+//
+// # Example
+//
+//	glow := filter.NewGraph(
+//	    filter.Blur(8),
+//	    filter.Brightness(1.4),
+//	).BlendOriginal(filter.OriginalOver, 1.0)
+//
+//	dc.PushLayerWithFilter(gg.BlendNormal, 1.0, glow)
+//	// ... draw ...
+//	dc.PopLayer()
+package filter
+
+import (
+	"github.com/gogpu/gg"
+	intfilter "github.com/gogpu/gg/internal/filter"
+	"github.com/gogpu/gg/scene"
+)
+
+// Node is a single filter primitive in a graph. It is an alias for
+// scene.Filter, so any node is also directly attachable to a filtered
+// scene layer.
+type Node = scene.Filter
+
+// Blur returns a Gaussian blur node with the given radius in pixels.
+func Blur(radius float64) Node {
+	return intfilter.NewBlurFilter(radius)
+}
+
+// BlurXY returns a Gaussian blur node with independent horizontal and
+// vertical radii, for motion-blur-like effects.
+func BlurXY(radiusX, radiusY float64) Node {
+	return intfilter.NewBlurFilterXY(radiusX, radiusY)
+}
+
+// ColorMatrix returns a node applying an arbitrary 5x4 color matrix in
+// row-major order (the SVG feColorMatrix layout: 4 rows of R, G, B, A
+// coefficients plus a bias column).
+func ColorMatrix(matrix [20]float32) Node {
+	return intfilter.NewColorMatrixFilter(matrix)
+}
+
+// Brightness returns a node scaling color channels by factor
+// (1.0 = unchanged, >1 brighter, <1 darker).
+func Brightness(factor float32) Node {
+	return intfilter.NewBrightnessFilter(factor)
+}
+
+// Contrast returns a node adjusting contrast around mid-gray
+// (1.0 = unchanged).
+func Contrast(factor float32) Node {
+	return intfilter.NewContrastFilter(factor)
+}
+
+// Saturate returns a saturation node (0 = grayscale, 1 = unchanged,
+// >1 oversaturated).
+func Saturate(factor float32) Node {
+	return intfilter.NewSaturationFilter(factor)
+}
+
+// Grayscale returns a luminance-preserving grayscale node.
+func Grayscale() Node {
+	return intfilter.NewGrayscaleFilter()
+}
+
+// Sepia returns a sepia-tone node.
+func Sepia() Node {
+	return intfilter.NewSepiaFilter()
+}
+
+// HueRotate returns a hue rotation node (angle in degrees).
+func HueRotate(degrees float32) Node {
+	return intfilter.NewHueRotateFilter(degrees)
+}
+
+// Invert returns a color inversion node (alpha is preserved).
+func Invert() Node {
+	return intfilter.NewInvertFilter()
+}
+
+// Opacity returns a node scaling alpha by factor (0 = transparent,
+// 1 = unchanged).
+func Opacity(factor float32) Node {
+	return intfilter.NewOpacityFilter(factor)
+}
+
+// Tint returns a node tinting colors toward the given color while
+// preserving luminance variation.
+func Tint(color gg.RGBA) Node {
+	return intfilter.NewColorTintFilter(color)
+}
+
+// DropShadow returns a drop shadow node with the given offset, blur
+// radius, and shadow color.
+func DropShadow(offsetX, offsetY, blurRadius float64, color gg.RGBA) Node {
+	return intfilter.NewDropShadowFilter(offsetX, offsetY, blurRadius, color)
+}
+
+// Dilate returns a morphology node growing opaque regions by radius
+// pixels in both axes (SVG feMorphology operator="dilate").
+func Dilate(radius int) Node {
+	return intfilter.NewDilateFilter(radius)
+}
+
+// Erode returns a morphology node shrinking opaque regions by radius
+// pixels in both axes (SVG feMorphology operator="erode").
+func Erode(radius int) Node {
+	return intfilter.NewErodeFilter(radius)
+}
+
+// DisplacementChannel selects which channel of a displacement map
+// drives an axis (SVG xChannelSelector/yChannelSelector).
+type DisplacementChannel = intfilter.DisplacementChannel
+
+// Displacement channel selectors.
+const (
+	ChannelR = intfilter.ChannelR
+	ChannelG = intfilter.ChannelG
+	ChannelB = intfilter.ChannelB
+	ChannelA = intfilter.ChannelA
+)
+
+// Displace returns a displacement map node (SVG feDisplacementMap):
+// each pixel is sampled at an offset of up to scale pixels, driven by
+// the red (X) and green (Y) channels of displacementMap. Use
+// DisplaceChannels to pick different channels.
+func Displace(displacementMap *gg.Pixmap, scale float64) Node {
+	return intfilter.NewDisplacementFilter(displacementMap, scale)
+}
+
+// DisplaceChannels returns a displacement map node with explicit
+// channel selectors for the X and Y axes.
+func DisplaceChannels(displacementMap *gg.Pixmap, scale float64, xChannel, yChannel DisplacementChannel) Node {
+	f := intfilter.NewDisplacementFilter(displacementMap, scale)
+	f.XChannel = xChannel
+	f.YChannel = yChannel
+	return f
+}
+
+// Turbulence returns a seeded Perlin turbulence generator node (SVG
+// feTurbulence type="turbulence"): absolute-value octave sums giving
+// billowy, marble-like noise. Generators ignore their input pixels.
+func Turbulence(baseFreqX, baseFreqY float64, octaves int, seed int64) Node {
+	return intfilter.NewTurbulenceFilter(intfilter.TurbulenceTurbulence, baseFreqX, baseFreqY, octaves, seed)
+}
+
+// FractalNoise returns a seeded Perlin fractal noise generator node
+// (SVG feTurbulence type="fractalNoise"): signed octave sums giving
+// smooth, cloud-like noise.
+func FractalNoise(baseFreqX, baseFreqY float64, octaves int, seed int64) Node {
+	return intfilter.NewTurbulenceFilter(intfilter.TurbulenceFractalNoise, baseFreqX, baseFreqY, octaves, seed)
+}
@@ -0,0 +1,145 @@
+package filter
+
+import (
+	"github.com/gogpu/gg"
+	"github.com/gogpu/gg/scene"
+)
+
+// OriginalBlend selects how a Graph recombines its filtered output with
+// the unfiltered input, mirroring SVG's feMerge with SourceGraphic.
+type OriginalBlend uint8
+
+const (
+	// OriginalNone outputs only the filtered result (the default).
+	OriginalNone OriginalBlend = iota
+	// OriginalOver composites the unfiltered original over the filtered
+	// result — the classic glow/halo construction.
+	OriginalOver
+	// OriginalUnder composites the filtered result over the unfiltered
+	// original, for overlay-style effects.
+	OriginalUnder
+)
+
+// Graph is an ordered chain of filter nodes, optionally blended with the
+// unfiltered original. A Graph implements scene.Filter and can therefore
+// be attached to filtered scene layers; it also implements gg.LayerFilter
+// for Context.PushLayerWithFilter.
+type Graph struct {
+	nodes           []Node
+	originalBlend   OriginalBlend
+	originalOpacity float64
+}
+
+// NewGraph creates a filter graph applying the given nodes in order.
+// Nil nodes are skipped.
+func NewGraph(nodes ...Node) *Graph {
+	g := &Graph{nodes: make([]Node, 0, len(nodes))}
+	for _, n := range nodes {
+		g.Add(n)
+	}
+	return g
+}
+
+// Add appends a node to the graph and returns the graph for chaining.
+func (g *Graph) Add(n Node) *Graph {
+	if n != nil {
+		g.nodes = append(g.nodes, n)
+	}
+	return g
+}
+
+// BlendOriginal configures how the unfiltered original is recombined
+// with the filtered result, at the given opacity (clamped to [0, 1]).
+// Returns the graph for chaining.
+func (g *Graph) BlendOriginal(mode OriginalBlend, opacity float64) *Graph {
+	if opacity < 0 {
+		opacity = 0
+	}
+	if opacity > 1 {
+		opacity = 1
+	}
+	g.originalBlend = mode
+	g.originalOpacity = opacity
+	return g
+}
+
+// Apply implements scene.Filter: src is processed through all nodes in
+// order and written to dst, then the original is blended in if
+// configured. src and dst may be the same pixmap.
+func (g *Graph) Apply(src, dst *gg.Pixmap, bounds scene.Rect) {
+	// Preserve the original when it must be blended back, or when the
+	// chain is applied in place and needs a stable input.
+	var original *gg.Pixmap
+	if g.originalBlend != OriginalNone {
+		original = clonePixmap(src)
+	}
+
+	chain := scene.NewFilterChain(g.nodes...)
+	chain.Apply(src, dst, bounds)
+
+	switch g.originalBlend {
+	case OriginalOver:
+		blendOver(dst, original, g.originalOpacity)
+	case OriginalUnder:
+		// Filtered result over original: blend dst onto the original
+		// copy, then move the result back into dst.
+		blendOver(original, dst, 1.0)
+		copy(dst.Data(), original.Data())
+	}
+}
+
+// ExpandBounds implements scene.Filter by folding each node's expansion.
+func (g *Graph) ExpandBounds(input scene.Rect) scene.Rect {
+	bounds := input
+	for _, n := range g.nodes {
+		bounds = n.ExpandBounds(bounds)
+	}
+	return bounds
+}
+
+// FilterPixmap implements gg.LayerFilter: the whole pixmap is processed.
+func (g *Graph) FilterPixmap(src, dst *gg.Pixmap) {
+	g.Apply(src, dst, scene.Rect{
+		MinX: 0,
+		MinY: 0,
+		MaxX: float32(src.Width()),
+		MaxY: float32(src.Height()),
+	})
+}
+
+// clonePixmap returns an independent copy of a pixmap.
+func clonePixmap(p *gg.Pixmap) *gg.Pixmap {
+	c := gg.NewPixmap(p.Width(), p.Height())
+	copy(c.Data(), p.Data())
+	return c
+}
+
+// blendOver composites src over dst (premultiplied source-over) with the
+// given source opacity. Both pixmaps must have identical dimensions.
+func blendOver(dst, src *gg.Pixmap, opacity float64) {
+	if dst == nil || src == nil {
+		return
+	}
+	dstData := dst.Data()
+	srcData := src.Data()
+	n := len(dstData)
+	if len(srcData) < n {
+		n = len(srcData)
+	}
+	for i := 0; i+3 < n; i += 4 {
+		sa := float64(srcData[i+3]) / 255.0 * opacity
+		if sa <= 0 {
+			continue
+		}
+		inv := 1 - sa
+		for ch := 0; ch < 4; ch++ {
+			s := float64(srcData[i+ch]) / 255.0 * opacity
+			d := float64(dstData[i+ch]) / 255.0
+			v := s + d*inv
+			if v > 1 {
+				v = 1
+			}
+			dstData[i+ch] = uint8(v * 255.0) // #nosec G115 -- v clamped to [0,1]
+		}
+	}
+}
@@ -0,0 +1,116 @@
+package filter
+
+import (
+	"testing"
+
+	"github.com/gogpu/gg"
+	"github.com/gogpu/gg/scene"
+)
+
+// redSquare returns a pixmap with a centered red square on transparent.
+func redSquare(size, square int) *gg.Pixmap {
+	p := gg.NewPixmap(size, size)
+	off := (size - square) / 2
+	for y := off; y < off+square; y++ {
+		for x := off; x < off+square; x++ {
+			p.SetPixel(x, y, gg.Red)
+		}
+	}
+	return p
+}
+
+func fullBounds(p *gg.Pixmap) scene.Rect {
+	return scene.Rect{MaxX: float32(p.Width()), MaxY: float32(p.Height())}
+}
+
+func TestGraphBlurSpreadsAlpha(t *testing.T) {
+	src := redSquare(64, 20)
+	dst := gg.NewPixmap(64, 64)
+
+	g := NewGraph(Blur(6))
+	g.Apply(src, dst, fullBounds(src))
+
+	// A pixel just outside the square must have picked up blurred alpha.
+	if a := dst.GetPixel(19, 32).A; a <= 0.01 {
+		t.Errorf("no blur spread outside square: alpha %v", a)
+	}
+	// The center keeps substantial coverage.
+	if a := dst.GetPixel(32, 32).A; a < 0.5 {
+		t.Errorf("center lost too much alpha: %v", a)
+	}
+}
+
+func TestGraphChainOrder(t *testing.T) {
+	src := redSquare(32, 32)
+	dst := gg.NewPixmap(32, 32)
+
+	// Grayscale then invert: red (lum ~0.21) -> gray -> light gray.
+	g := NewGraph(Grayscale(), Invert())
+	g.Apply(src, dst, fullBounds(src))
+
+	got := dst.GetPixel(16, 16)
+	if got.R < 0.6 || got.G < 0.6 || got.B < 0.6 {
+		t.Errorf("grayscale+invert of red should be light gray, got %+v", got)
+	}
+	if d := got.R - got.G; d > 0.05 || d < -0.05 {
+		t.Errorf("result not gray: %+v", got)
+	}
+}
+
+func TestGraphExpandBounds(t *testing.T) {
+	g := NewGraph(Blur(5), Grayscale())
+	in := scene.Rect{MinX: 10, MinY: 10, MaxX: 20, MaxY: 20}
+	out := g.ExpandBounds(in)
+	if out.MinX >= in.MinX || out.MaxX <= in.MaxX {
+		t.Errorf("blur did not expand bounds: %+v -> %+v", in, out)
+	}
+}
+
+func TestGraphBlendOriginalOver(t *testing.T) {
+	src := redSquare(64, 20)
+	dst := gg.NewPixmap(64, 64)
+
+	// Heavy blur alone would soften the center; blending the original
+	// back over the result must keep the center fully red.
+	g := NewGraph(Blur(10)).BlendOriginal(OriginalOver, 1.0)
+	g.Apply(src, dst, fullBounds(src))
+
+	center := dst.GetPixel(32, 32)
+	if center.R < 0.95 || center.A < 0.95 {
+		t.Errorf("original not restored over blur at center: %+v", center)
+	}
+	// Halo outside the square still present.
+	if a := dst.GetPixel(18, 32).A; a <= 0.01 {
+		t.Errorf("halo missing outside square: alpha %v", a)
+	}
+}
+
+func TestGraphEmptyCopies(t *testing.T) {
+	src := redSquare(16, 8)
+	dst := gg.NewPixmap(16, 16)
+	NewGraph().Apply(src, dst, fullBounds(src))
+	if got := dst.GetPixel(8, 8); got.R < 0.95 {
+		t.Errorf("empty graph should copy source: %+v", got)
+	}
+}
+
+func TestPushLayerWithFilter(t *testing.T) {
+	dc := gg.NewContext(64, 64)
+
+	dc.PushLayerWithFilter(gg.BlendNormal, 1.0, NewGraph(Grayscale()))
+	dc.SetRGB(1, 0, 0)
+	dc.DrawRectangle(16, 16, 32, 32)
+	if err := dc.Fill(); err != nil {
+		t.Fatalf("Fill failed: %v", err)
+	}
+	dc.PopLayer()
+
+	img := dc.Image()
+	r, gch, b, a := img.At(32, 32).RGBA()
+	if a == 0 {
+		t.Fatal("layer content lost")
+	}
+	if r != gch || gch != b {
+		t.Errorf("layer not grayscaled on pop: r=%d g=%d b=%d", r, gch, b)
+	}
+}
@@ -0,0 +1,122 @@
+package gg
+
+import "testing"
+
+// TestSetFlattenTolerance tests the override getter and clamping.
+func TestSetFlattenTolerance(t *testing.T) {
+	dc := NewContext(16, 16)
+	defer dc.Close()
+	if got := dc.FlattenTolerance(); got != 0 {
+		t.Errorf("default override = %v, want 0", got)
+	}
+	dc.SetFlattenTolerance(0.5)
+	if got := dc.FlattenTolerance(); got != 0.5 {
+		t.Errorf("FlattenTolerance() = %v, want 0.5", got)
+	}
+	dc.SetFlattenTolerance(-1)
+	if got := dc.FlattenTolerance(); got != 0 {
+		t.Errorf("negative tolerance should clamp to 0, got %v", got)
+	}
+}
+
+// TestFlattenToleranceCoarse tests that a very coarse tolerance visibly
+// polygonizes a circle while the default stays smooth, and that the
+// override resets after the fill (doFill restores the renderer state).
+func TestFlattenToleranceCoarse(t *testing.T) {
+	render := func(tol float64) *Context {
+		dc := NewContext(64, 64)
+		dc.SetFlattenTolerance(tol)
+		dc.SetRGB(0, 0, 0)
+		dc.DrawCircle(32, 32, 28)
+		if err := dc.Fill(); err != nil {
+			t.Fatalf("Fill: %v", err)
+		}
+		return dc
+	}
+
+	base := render(0)
+	defer base.Close()
+	coarse := render(8)
+	defer coarse.Close()
+
+	diff := 0
+	for y := 0; y < 64; y++ {
+		for x := 0; x < 64; x++ {
+			b := base.pixmap.GetPixel(x, y)
+			c := coarse.pixmap.GetPixel(x, y)
+			if b.A != c.A {
+				diff++
+			}
+		}
+	}
+	if diff == 0 {
+		t.Error("tolerance 8 px should visibly polygonize the circle")
+	}
+
+	// The override is renderer-transient: the edge builder must be back
+	// at the effective default after the fill returns.
+	if sr, ok := coarse.renderer.(*SoftwareRenderer); ok {
+		if got := sr.FlattenTolerance(); got != defaultFlattenTolerance {
+			t.Errorf("renderer tolerance after fill = %v, want default %v", got, defaultFlattenTolerance)
+		}
+	}
+}
+
+// TestEffectiveFlattenToleranceHiDPI tests the deviceScale division.
+func TestEffectiveFlattenToleranceHiDPI(t *testing.T) {
+	r := NewSoftwareRenderer(32, 32)
+	if got := r.effectiveFlattenTolerance(); got != defaultFlattenTolerance {
+		t.Errorf("effective = %v, want %v", got, defaultFlattenTolerance)
+	}
+	r.SetDeviceScale(2)
+	if got := r.effectiveFlattenTolerance(); got != defaultFlattenTolerance/2 {
+		t.Errorf("effective at 2x = %v, want %v", got, defaultFlattenTolerance/2)
+	}
+	r.SetFlattenTolerance(0.5)
+	if got := r.effectiveFlattenTolerance(); got != 0.25 {
+		t.Errorf("effective override at 2x = %v, want 0.25", got)
+	}
+	if got := r.FlattenTolerance(); got != 0.5 {
+		t.Errorf("base = %v, want 0.5", got)
+	}
+}
+
+// toleranceRecordingFiller records the tolerance threaded through the
+// optional FlattenToleranceSetter interface.
+type toleranceRecordingFiller struct {
+	tolerance float32
+}
+
+func (f *toleranceRecordingFiller) SetFlattenTolerance(tolerance float32) {
+	f.tolerance = tolerance
+}
+
+func (f *toleranceRecordingFiller) FillCoverage(
+	*Path, int, int, FillRule, *ClipBounds, func(x, y int, coverage uint8),
+) {
+}
+
+// TestFillerToleranceThreading tests that fillWithCoverageFiller forwards
+// an explicit override to the filler and 0 (its own default) otherwise.
+func TestFillerToleranceThreading(t *testing.T) {
+	r := NewSoftwareRenderer(32, 32)
+	pm := NewPixmap(32, 32)
+	p := NewPath()
+	p.MoveTo(2, 2)
+	p.LineTo(30, 2)
+	p.LineTo(30, 30)
+	p.Close()
+	paint := NewPaint()
+
+	filler := &toleranceRecordingFiller{tolerance: -1}
+	r.fillWithCoverageFiller(pm, p, paint, filler)
+	if filler.tolerance != 0 {
+		t.Errorf("no override: filler got %v, want 0 (default)", filler.tolerance)
+	}
+
+	r.SetFlattenTolerance(0.5)
+	r.fillWithCoverageFiller(pm, p, paint, filler)
+	if filler.tolerance != 0.5 {
+		t.Errorf("override: filler got %v, want 0.5", filler.tolerance)
+	}
+}
@@ -3,16 +3,17 @@ module github.com/gogpu/gg
 go 1.25.0
 
 require (
+	github.com/andybalholm/brotli v1.2.3
 	github.com/gogpu/gpucontext v0.21.1
 	github.com/gogpu/gputypes v0.5.1
 	github.com/gogpu/naga v0.17.15
 	github.com/gogpu/wgpu v0.30.22
 	golang.org/x/image v0.44.0
+	golang.org/x/sys v0.47.0
 	golang.org/x/text v0.40.0
 )
 
 require (
 	github.com/go-webgpu/goffi v0.6.0 // indirect
 	github.com/go-webgpu/webgpu v0.5.3 // indirect
-	golang.org/x/sys v0.47.0 // indirect
 )
@@ -1,6 +1,10 @@
 package gg
 
-import "math"
+import (
+	"math"
+
+	"github.com/gogpu/gg/internal/detmath"
+)
 
 // RadialGradientBrush represents a radial color transition.
 // Colors radiate from a focal point within a circle defined by center and end radius.
@@ -120,7 +124,7 @@ func (g *RadialGradientBrush) computeT(x, y float64) float64 {
 func (g *RadialGradientBrush) computeTSimple(x, y float64) float64 {
 	dx := x - g.Center.X
 	dy := y - g.Center.Y
-	distance := math.Sqrt(dx*dx + dy*dy)
+	distance := detmath.Sqrt(dx*dx + dy*dy)
 
 	radiusDiff := g.EndRadius - g.StartRadius
 	if radiusDiff == 0 {
@@ -165,7 +169,7 @@ func (g *RadialGradientBrush) computeTFocal(x, y float64) float64 {
 	}
 
 	// We want the positive root (forward along ray)
-	sqrtD := math.Sqrt(discriminant)
+	sqrtD := detmath.Sqrt(discriminant)
 	t1 := (-b - sqrtD) / (2 * a)
 	t2 := (-b + sqrtD) / (2 * a)
 
@@ -184,7 +188,7 @@ func (g *RadialGradientBrush) computeTFocal(x, y float64) float64 {
 	}
 
 	// The gradient parameter is the ratio of actual distance to intersection distance
-	pointDist := math.Sqrt(a) // Distance from focus to point
+	pointDist := detmath.Sqrt(a) // Distance from focus to point
 	intersectDist := t * pointDist
 
 	if intersectDist == 0 {
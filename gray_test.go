@@ -0,0 +1,160 @@
+package gg
+
+import (
+	"image"
+	"testing"
+)
+
+// TestNewGrayRenderer tests constructor validation.
+func TestNewGrayRenderer(t *testing.T) {
+	if NewGrayRenderer(0, 10) != nil {
+		t.Error("zero width should return nil")
+	}
+	r := NewGrayRenderer(10, 10)
+	if r == nil {
+		t.Fatal("valid arguments returned nil")
+	}
+	if got := r.Image().Bounds(); got != image.Rect(0, 0, 10, 10) {
+		t.Errorf("image bounds = %v, want 10x10", got)
+	}
+}
+
+// TestGrayRendererFill tests a solid fill converts to the expected luma.
+func TestGrayRendererFill(t *testing.T) {
+	r := NewGrayRenderer(32, 32)
+	r.Clear(255) // white paper
+
+	p := NewPath()
+	p.MoveTo(8, 8)
+	p.LineTo(24, 8)
+	p.LineTo(24, 24)
+	p.LineTo(8, 24)
+	p.Close()
+
+	paint := NewPaint()
+	paint.SetBrush(SolidBrush{Color: RGBA{R: 1, A: 1}}) // pure red, luma ≈ 54
+	if err := r.Fill(p, paint); err != nil {
+		t.Fatalf("Fill: %v", err)
+	}
+
+	img := r.Image()
+	if got := img.GrayAt(16, 16).Y; got < 52 || got > 56 {
+		t.Errorf("inside luma = %d, want ≈54 (0.2126*255)", got)
+	}
+	if got := img.GrayAt(2, 2).Y; got != 255 {
+		t.Errorf("outside luma = %d, want 255 (untouched paper)", got)
+	}
+}
+
+// TestGrayRendererAlphaBlend tests that partial alpha blends against the
+// existing gray value.
+func TestGrayRendererAlphaBlend(t *testing.T) {
+	r := NewGrayRenderer(16, 16)
+	r.Clear(200)
+
+	p := NewPath()
+	p.MoveTo(0, 0)
+	p.LineTo(16, 0)
+	p.LineTo(16, 16)
+	p.LineTo(0, 16)
+	p.Close()
+
+	paint := NewPaint()
+	paint.SetBrush(SolidBrush{Color: RGBA{A: 0.5}}) // 50% black over gray 200
+	if err := r.Fill(p, paint); err != nil {
+		t.Fatalf("Fill: %v", err)
+	}
+	if got := r.Image().GrayAt(8, 8).Y; got < 98 || got > 102 {
+		t.Errorf("blended luma = %d, want ≈100", got)
+	}
+}
+
+// TestGrayRendererStroke tests stroke outlines reach the grayscale image.
+func TestGrayRendererStroke(t *testing.T) {
+	r := NewGrayRenderer(32, 32)
+	r.Clear(255)
+
+	p := NewPath()
+	p.MoveTo(4, 16)
+	p.LineTo(28, 16)
+
+	paint := NewPaint()
+	paint.SetBrush(SolidBrush{Color: RGBA{A: 1}})
+	paint.LineWidth = 4
+	if err := r.Stroke(p, paint); err != nil {
+		t.Fatalf("Stroke: %v", err)
+	}
+
+	img := r.Image()
+	if got := img.GrayAt(16, 16).Y; got != 0 {
+		t.Errorf("on-stroke luma = %d, want 0 (black)", got)
+	}
+	if got := img.GrayAt(16, 4).Y; got != 255 {
+		t.Errorf("off-stroke luma = %d, want 255 (white)", got)
+	}
+}
+
+// TestGrayRendererFillCoverage tests coverage-as-luminance output.
+func TestGrayRendererFillCoverage(t *testing.T) {
+	r := NewGrayRenderer(32, 32)
+
+	p := NewPath()
+	p.MoveTo(8, 8)
+	p.LineTo(24, 8)
+	p.LineTo(24, 24)
+	p.LineTo(8, 24)
+	p.Close()
+	r.FillCoverage(p, FillRuleNonZero)
+
+	img := r.Image()
+	if got := img.GrayAt(16, 16).Y; got != 255 {
+		t.Errorf("interior coverage = %d, want 255", got)
+	}
+	if got := img.GrayAt(2, 2).Y; got != 0 {
+		t.Errorf("exterior coverage = %d, want 0", got)
+	}
+
+	// Overlapping fills accumulate with max: a second fill must not
+	// darken the anti-aliased edge.
+	edge := img.GrayAt(8, 16).Y
+	r.FillCoverage(p, FillRuleNonZero)
+	if got := img.GrayAt(8, 16).Y; got < edge {
+		t.Errorf("edge coverage dropped from %d to %d after refill", edge, got)
+	}
+}
+
+// TestPixmapToGray tests luma conversion of an RGBA pixmap.
+func TestPixmapToGray(t *testing.T) {
+	pm := NewPixmap(4, 4)
+	pm.Clear(RGBA{R: 1, G: 1, B: 1, A: 1})
+	pm.SetPixel(1, 1, RGBA{G: 1, A: 1}) // pure green, luma ≈ 182
+
+	img := pm.ToGray()
+	if got := img.GrayAt(0, 0).Y; got != 255 {
+		t.Errorf("white luma = %d, want 255", got)
+	}
+	if got := img.GrayAt(1, 1).Y; got < 180 || got > 184 {
+		t.Errorf("green luma = %d, want ≈182 (0.7152*255)", got)
+	}
+}
+
+// TestContextImageGray tests the Context grayscale export.
+func TestContextImageGray(t *testing.T) {
+	dc := NewContext(16, 16)
+	defer dc.Close()
+	dc.ClearWithColor(White)
+	dc.SetRGBA(0, 0, 0, 1)
+	dc.DrawRectangle(4, 4, 8, 8)
+	dc.Fill()
+
+	img := dc.ImageGray()
+	if got := img.Bounds(); got != image.Rect(0, 0, 16, 16) {
+		t.Fatalf("bounds = %v, want 16x16", got)
+	}
+	if got := img.GrayAt(8, 8).Y; got != 0 {
+		t.Errorf("filled luma = %d, want 0", got)
+	}
+	if got := img.GrayAt(1, 1).Y; got != 255 {
+		t.Errorf("background luma = %d, want 255", got)
+	}
+}
@@ -0,0 +1,332 @@
+package gg
+
+import (
+	"image"
+	"image/color"
+	"sort"
+)
+
+// IndexedRenderer rasterizes paths directly into an 8-bit palette-indexed
+// image. Coverage spans from the software rasterizer are blended against
+// the palette colors of the existing pixels and requantized in place, so
+// no full-canvas RGBA intermediate is allocated. This targets e-ink
+// dashboards and embedded displays that consume indexed framebuffers.
+//
+// Quantization uses nearest palette color, optionally with 4x4 ordered
+// (Bayer) dithering. Ordered dithering is position-based, so it is
+// independent of span order and stable across incremental redraws —
+// error-diffusion dithers are not, which is why one is not offered here.
+type IndexedRenderer struct {
+	sr  *SoftwareRenderer
+	img *image.Paletted
+
+	// palette mirrors img.Palette as straight-alpha RGBA for blending.
+	palette []RGBA
+	dither  bool
+
+	width, height int
+}
+
+// NewIndexedRenderer creates a palette-indexed renderer for the given
+// dimensions. The palette may hold up to 256 colors; extra entries are
+// ignored. Returns nil for invalid dimensions or an empty palette.
+func NewIndexedRenderer(width, height int, palette color.Palette) *IndexedRenderer {
+	if width <= 0 || height <= 0 || len(palette) == 0 {
+		return nil
+	}
+	if len(palette) > 256 {
+		palette = palette[:256]
+	}
+
+	mirror := make([]RGBA, len(palette))
+	for i, c := range palette {
+		r16, g16, b16, a16 := c.RGBA()
+		if a16 == 0 {
+			mirror[i] = RGBA{}
+			continue
+		}
+		// color.RGBA() is alpha-premultiplied 16-bit; unpremultiply to
+		// the straight float64 channels the blender works in.
+		a := float64(a16) / 0xffff
+		mirror[i] = RGBA{
+			R: float64(r16) / float64(a16),
+			G: float64(g16) / float64(a16),
+			B: float64(b16) / float64(a16),
+			A: a,
+		}
+	}
+
+	return &IndexedRenderer{
+		sr:      NewSoftwareRenderer(width, height),
+		img:     image.NewPaletted(image.Rect(0, 0, width, height), palette),
+		palette: mirror,
+		width:   width,
+		height:  height,
+	}
+}
+
+// Image returns the palette-indexed image being rendered into.
+func (r *IndexedRenderer) Image() *image.Paletted {
+	return r.img
+}
+
+// SetDithering enables or disables ordered dithering during quantization.
+func (r *IndexedRenderer) SetDithering(enabled bool) {
+	r.dither = enabled
+}
+
+// Dithering returns whether ordered dithering is enabled.
+func (r *IndexedRenderer) Dithering() bool {
+	return r.dither
+}
+
+// SetAntiAlias enables or disables anti-aliased span coverage.
+func (r *IndexedRenderer) SetAntiAlias(enabled bool) {
+	r.sr.SetAntiAlias(enabled)
+}
+
+// Clear fills the whole image with the given palette index.
+func (r *IndexedRenderer) Clear(index uint8) {
+	pix := r.img.Pix
+	for i := range pix {
+		pix[i] = index
+	}
+}
+
+// Fill rasterizes the path and blends it into the indexed image using
+// the paint's color or brush and fill rule.
+func (r *IndexedRenderer) Fill(p *Path, paint *Paint) error {
+	if p == nil || paint == nil {
+		return nil
+	}
+	fillRule := FillRuleNonZero
+	if paint.FillRule == FillRuleEvenOdd {
+		fillRule = FillRuleEvenOdd
+	}
+	r.fillPath(p, paint, fillRule)
+	return nil
+}
+
+// Stroke expands the path's stroke outline and blends it into the
+// indexed image, mirroring SoftwareRenderer.Stroke.
+func (r *IndexedRenderer) Stroke(p *Path, paint *Paint) error {
+	if p == nil || paint == nil {
+		return nil
+	}
+
+	// Install the stroke brush for sampling, as SoftwareRenderer.Stroke does.
+	if paint.hasStrokeBrush {
+		savedColor, savedSolid, savedBrush := paint.solidColor, paint.isSolid, paint.Brush
+		paint.solidColor, paint.isSolid, paint.Brush = paint.strokeSolidColor, paint.strokeIsSolid, paint.strokeBrush
+		defer func() {
+			paint.solidColor, paint.isSolid, paint.Brush = savedColor, savedSolid, savedBrush
+		}()
+	}
+
+	outline := r.sr.strokeOutline(p, paint)
+	r.fillPath(outline, paint, FillRuleNonZero)
+	return nil
+}
+
+// fillPath streams coverage spans into the indexed image.
+func (r *IndexedRenderer) fillPath(p *Path, paint *Paint, fillRule FillRule) {
+	solid, isSolid := solidColorFromPaint(paint)
+	r.sr.FillSpans(p, fillRule, func(y, x, length int, coverage uint8) {
+		if y < 0 || y >= r.height {
+			return
+		}
+		if x < 0 {
+			length += x
+			x = 0
+		}
+		if x+length > r.width {
+			length = r.width - x
+		}
+		row := r.img.Pix[y*r.img.Stride:]
+		for i := 0; i < length; i++ {
+			px := x + i
+			src := solid
+			if !isSolid {
+				src = paint.ColorAt(float64(px)+0.5, float64(y)+0.5)
+			}
+			r.blendPixel(row, px, y, src, coverage)
+		}
+	})
+}
+
+// blendPixel blends src (scaled by coverage) over the palette color at
+// the pixel and writes back the nearest palette index.
+func (r *IndexedRenderer) blendPixel(row []uint8, x, y int, src RGBA, coverage uint8) {
+	sa := src.A * float64(coverage) / 255
+	if sa <= 0 {
+		return
+	}
+
+	dst := r.palette[row[x]]
+	outA := sa + dst.A*(1-sa)
+	var out RGBA
+	if outA > 0 {
+		out = RGBA{
+			R: (src.R*sa + dst.R*dst.A*(1-sa)) / outA,
+			G: (src.G*sa + dst.G*dst.A*(1-sa)) / outA,
+			B: (src.B*sa + dst.B*dst.A*(1-sa)) / outA,
+			A: outA,
+		}
+	}
+	row[x] = r.nearestIndex(out, x, y)
+}
+
+// bayer4 is the classic 4x4 ordered dither threshold matrix.
+var bayer4 = [4][4]float64{
+	{0, 8, 2, 10},
+	{12, 4, 14, 6},
+	{3, 11, 1, 9},
+	{15, 7, 13, 5},
+}
+
+// nearestIndex returns the palette index closest to c, applying the
+// ordered dither offset for pixel (x, y) when dithering is enabled.
+func (r *IndexedRenderer) nearestIndex(c RGBA, x, y int) uint8 {
+	if r.dither {
+		// Threshold in [-0.5, 0.5) scaled by the approximate palette
+		// spacing, so small palettes dither visibly and large ones barely.
+		spread := 1 / float64(len(r.palette))
+		offset := (bayer4[y&3][x&3]/16 - 0.5) * spread
+		c.R = clamp01(c.R + offset)
+		c.G = clamp01(c.G + offset)
+		c.B = clamp01(c.B + offset)
+	}
+
+	best := 0
+	bestDist := -1.0
+	for i, p := range r.palette {
+		dr := c.R - p.R
+		dg := c.G - p.G
+		db := c.B - p.B
+		da := c.A - p.A
+		dist := dr*dr + dg*dg + db*db + da*da
+		if bestDist < 0 || dist < bestDist {
+			best = i
+			bestDist = dist
+		}
+	}
+	return uint8(best) //nolint:gosec // palette length is capped at 256
+}
+
+// GrayPalette returns a fixed palette of evenly spaced opaque gray
+// levels, the common case for e-ink panels (e.g. 2, 4, or 16 levels).
+func GrayPalette(levels int) color.Palette {
+	if levels < 2 {
+		levels = 2
+	}
+	if levels > 256 {
+		levels = 256
+	}
+	pal := make(color.Palette, levels)
+	for i := range pal {
+		v := uint8(i * 255 / (levels - 1)) //nolint:gosec // bounded to [0,255]
+		pal[i] = color.Gray{Y: v}
+	}
+	return pal
+}
+
+// AdaptivePalette derives a palette of at most size colors from an image
+// using median-cut quantization. Transparent pixels are skipped; the
+// resulting colors are opaque.
+func AdaptivePalette(img image.Image, size int) color.Palette {
+	if img == nil || size < 1 {
+		return nil
+	}
+	if size > 256 {
+		size = 256
+	}
+
+	bounds := img.Bounds()
+	var pixels [][3]float64
+	for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+		for x := bounds.Min.X; x < bounds.Max.X; x++ {
+			r16, g16, b16, a16 := img.At(x, y).RGBA()
+			if a16 == 0 {
+				continue
+			}
+			pixels = append(pixels, [3]float64{
+				float64(r16) / float64(a16),
+				float64(g16) / float64(a16),
+				float64(b16) / float64(a16),
+			})
+		}
+	}
+	if len(pixels) == 0 {
+		return color.Palette{color.Black}
+	}
+
+	boxes := [][][3]float64{pixels}
+	for len(boxes) < size {
+		// Split the largest box along its widest channel.
+		bi := largestBox(boxes)
+		box := boxes[bi]
+		if len(box) < 2 {
+			break
+		}
+		ch := widestChannel(box)
+		sort.Slice(box, func(i, j int) bool { return box[i][ch] < box[j][ch] })
+		mid := len(box) / 2
+		boxes[bi] = box[:mid]
+		boxes = append(boxes, box[mid:])
+	}
+
+	pal := make(color.Palette, 0, len(boxes))
+	for _, box := range boxes {
+		var sum [3]float64
+		for _, p := range box {
+			sum[0] += p[0]
+			sum[1] += p[1]
+			sum[2] += p[2]
+		}
+		n := float64(len(box))
+		pal = append(pal, color.RGBA{
+			R: uint8(sum[0]/n*255 + 0.5), //nolint:gosec // channel averages are in [0,1]
+			G: uint8(sum[1]/n*255 + 0.5), //nolint:gosec // channel averages are in [0,1]
+			B: uint8(sum[2]/n*255 + 0.5), //nolint:gosec // channel averages are in [0,1]
+			A: 255,
+		})
+	}
+	return pal
+}
+
+// largestBox returns the index of the box with the most pixels.
+func largestBox(boxes [][][3]float64) int {
+	best := 0
+	for i, b := range boxes {
+		if len(b) > len(boxes[best]) {
+			best = i
+		}
+	}
+	return best
+}
+
+// widestChannel returns the channel (0=R, 1=G, 2=B) with the largest
+// value range across the box.
+func widestChannel(box [][3]float64) int {
+	var minC, maxC [3]float64
+	for ch := 0; ch < 3; ch++ {
+		minC[ch], maxC[ch] = box[0][ch], box[0][ch]
+	}
+	for _, p := range box {
+		for ch := 0; ch < 3; ch++ {
+			if p[ch] < minC[ch] {
+				minC[ch] = p[ch]
+			}
+			if p[ch] > maxC[ch] {
+				maxC[ch] = p[ch]
+			}
+		}
+	}
+	widest := 0
+	for ch := 1; ch < 3; ch++ {
+		if maxC[ch]-minC[ch] > maxC[widest]-minC[widest] {
+			widest = ch
+		}
+	}
+	return widest
+}
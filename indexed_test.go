@@ -0,0 +1,198 @@
+package gg
+
+import (
+	"image"
+	"image/color"
+	"testing"
+)
+
+// bwPalette is white at index 0, black at index 1.
+func bwPalette() color.Palette {
+	return color.Palette{color.White, color.Black}
+}
+
+// TestNewIndexedRenderer tests constructor validation.
+func TestNewIndexedRenderer(t *testing.T) {
+	if NewIndexedRenderer(0, 10, bwPalette()) != nil {
+		t.Error("zero width should return nil")
+	}
+	if NewIndexedRenderer(10, 10, nil) != nil {
+		t.Error("empty palette should return nil")
+	}
+	r := NewIndexedRenderer(10, 10, bwPalette())
+	if r == nil {
+		t.Fatal("valid arguments returned nil")
+	}
+	if got := r.Image().Bounds(); got != image.Rect(0, 0, 10, 10) {
+		t.Errorf("image bounds = %v, want 10x10", got)
+	}
+}
+
+// TestIndexedRendererFill tests a solid fill against a cleared background.
+func TestIndexedRendererFill(t *testing.T) {
+	r := NewIndexedRenderer(32, 32, bwPalette())
+	r.Clear(0) // white
+
+	p := NewPath()
+	p.MoveTo(8, 8)
+	p.LineTo(24, 8)
+	p.LineTo(24, 24)
+	p.LineTo(8, 24)
+	p.Close()
+
+	paint := NewPaint()
+	paint.SetBrush(SolidBrush{Color: RGBA{A: 1}}) // opaque black
+	if err := r.Fill(p, paint); err != nil {
+		t.Fatalf("Fill: %v", err)
+	}
+
+	img := r.Image()
+	if idx := img.ColorIndexAt(16, 16); idx != 1 {
+		t.Errorf("inside index = %d, want 1 (black)", idx)
+	}
+	if idx := img.ColorIndexAt(2, 2); idx != 0 {
+		t.Errorf("outside index = %d, want 0 (white)", idx)
+	}
+}
+
+// TestIndexedRendererAlphaBlend tests that partial alpha blends against
+// the existing palette color before requantization.
+func TestIndexedRendererAlphaBlend(t *testing.T) {
+	pal := GrayPalette(4) // 0, 85, 170, 255
+	r := NewIndexedRenderer(16, 16, pal)
+	r.Clear(3) // white
+
+	p := NewPath()
+	p.MoveTo(0, 0)
+	p.LineTo(16, 0)
+	p.LineTo(16, 16)
+	p.LineTo(0, 16)
+	p.Close()
+
+	paint := NewPaint()
+	paint.SetBrush(SolidBrush{Color: RGBA{A: 0.35}}) // 35% black over white ≈ gray 166
+	if err := r.Fill(p, paint); err != nil {
+		t.Fatalf("Fill: %v", err)
+	}
+	if idx := r.Image().ColorIndexAt(8, 8); idx != 2 {
+		t.Errorf("blended index = %d, want 2 (gray 170)", idx)
+	}
+}
+
+// TestIndexedRendererDithering tests that ordered dithering breaks a
+// mid-gray fill into a mix of palette entries on a two-level palette.
+func TestIndexedRendererDithering(t *testing.T) {
+	fill := func(dither bool) map[uint8]int {
+		r := NewIndexedRenderer(16, 16, GrayPalette(2))
+		r.Clear(1)
+		r.SetDithering(dither)
+
+		p := NewPath()
+		p.MoveTo(0, 0)
+		p.LineTo(16, 0)
+		p.LineTo(16, 16)
+		p.LineTo(0, 16)
+		p.Close()
+
+		paint := NewPaint()
+		paint.SetBrush(SolidBrush{Color: RGBA{R: 0.5, G: 0.5, B: 0.5, A: 1}})
+		if err := r.Fill(p, paint); err != nil {
+			t.Fatalf("Fill: %v", err)
+		}
+
+		counts := map[uint8]int{}
+		for _, idx := range r.Image().Pix {
+			counts[idx]++
+		}
+		return counts
+	}
+
+	flat := fill(false)
+	if len(flat) != 1 {
+		t.Errorf("undithered mid-gray should quantize uniformly, got %v", flat)
+	}
+	dithered := fill(true)
+	if dithered[0] == 0 || dithered[1] == 0 {
+		t.Errorf("dithered mid-gray should mix both levels, got %v", dithered)
+	}
+}
+
+// TestIndexedRendererStroke tests stroke outlines reach the indexed image.
+func TestIndexedRendererStroke(t *testing.T) {
+	r := NewIndexedRenderer(32, 32, bwPalette())
+	r.Clear(0)
+
+	p := NewPath()
+	p.MoveTo(4, 16)
+	p.LineTo(28, 16)
+
+	paint := NewPaint()
+	paint.SetBrush(SolidBrush{Color: RGBA{A: 1}})
+	paint.LineWidth = 4
+	if err := r.Stroke(p, paint); err != nil {
+		t.Fatalf("Stroke: %v", err)
+	}
+
+	img := r.Image()
+	if idx := img.ColorIndexAt(16, 16); idx != 1 {
+		t.Errorf("on-stroke index = %d, want 1 (black)", idx)
+	}
+	if idx := img.ColorIndexAt(16, 4); idx != 0 {
+		t.Errorf("off-stroke index = %d, want 0 (white)", idx)
+	}
+}
+
+// TestGrayPalette tests the fixed gray palette helper.
+func TestGrayPalette(t *testing.T) {
+	pal := GrayPalette(4)
+	if len(pal) != 4 {
+		t.Fatalf("len = %d, want 4", len(pal))
+	}
+	want := []uint8{0, 85, 170, 255}
+	for i, c := range pal {
+		g := color.GrayModel.Convert(c).(color.Gray)
+		if g.Y != want[i] {
+			t.Errorf("level %d = %d, want %d", i, g.Y, want[i])
+		}
+	}
+	if got := len(GrayPalette(0)); got != 2 {
+		t.Errorf("clamped levels = %d, want 2", got)
+	}
+}
+
+// TestAdaptivePalette tests median-cut palette extraction.
+func TestAdaptivePalette(t *testing.T) {
+	img := image.NewRGBA(image.Rect(0, 0, 8, 8))
+	for y := 0; y < 8; y++ {
+		for x := 0; x < 8; x++ {
+			if x < 4 {
+				img.Set(x, y, color.RGBA{R: 255, A: 255})
+			} else {
+				img.Set(x, y, color.RGBA{B: 255, A: 255})
+			}
+		}
+	}
+
+	pal := AdaptivePalette(img, 2)
+	if len(pal) != 2 {
+		t.Fatalf("len = %d, want 2", len(pal))
+	}
+	// One entry must be (near) pure red, the other (near) pure blue.
+	foundRed, foundBlue := false, false
+	for _, c := range pal {
+		r16, _, b16, _ := c.RGBA()
+		if r16 > 0xf000 && b16 < 0x1000 {
+			foundRed = true
+		}
+		if b16 > 0xf000 && r16 < 0x1000 {
+			foundBlue = true
+		}
+	}
+	if !foundRed || !foundBlue {
+		t.Errorf("palette %v should contain red and blue", pal)
+	}
+
+	if AdaptivePalette(nil, 4) != nil {
+		t.Error("nil image should return nil palette")
+	}
+}
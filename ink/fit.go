@@ -0,0 +1,221 @@
+package ink
+
+import (
+	"math"
+
+	"github.com/gogpu/gg"
+)
+
+// Cubic Bezier fitting of digitized strokes, following Schneider's
+// algorithm ("An Algorithm for Automatically Fitting Digitized Curves",
+// Graphics Gems 1990): least-squares fit with chord-length
+// parameterization, Newton-Raphson reparameterization, and recursive
+// splitting at the point of maximum error.
+
+// maxReparamIterations bounds the Newton-Raphson refinement passes before
+// a segment is split instead.
+const maxReparamIterations = 4
+
+// FitStroke fits the stroke's centerline with cubic Bezier curves so the
+// fitted path stays within tolerance pixels of every sample. Consecutive
+// duplicate positions are dropped. Returns an empty path for fewer than
+// two distinct samples.
+func FitStroke(points []Point, tolerance float64) *gg.Path {
+	pts := dedupePositions(points)
+	path := gg.NewPath()
+	if len(pts) < 2 {
+		return path
+	}
+
+	path.MoveTo(pts[0].X, pts[0].Y)
+	leftTangent := pts[1].Sub(pts[0]).Normalize()
+	rightTangent := pts[len(pts)-2].Sub(pts[len(pts)-1]).Normalize()
+	fitCubic(path, pts, leftTangent, rightTangent, tolerance)
+	return path
+}
+
+// dedupePositions extracts sample positions, dropping consecutive
+// duplicates that would produce zero-length chords.
+func dedupePositions(points []Point) []gg.Point {
+	pts := make([]gg.Point, 0, len(points))
+	for _, p := range points {
+		pos := p.Pos()
+		if len(pts) > 0 && pos.Distance(pts[len(pts)-1]) < 1e-12 {
+			continue
+		}
+		pts = append(pts, pos)
+	}
+	return pts
+}
+
+// fitCubic fits one cubic to pts, splitting recursively while the maximum
+// error exceeds tolerance. Fitted curves are appended to path, whose
+// current point is pts[0].
+func fitCubic(path *gg.Path, pts []gg.Point, tHat1, tHat2 gg.Point, tolerance float64) {
+	// Two points: the heuristic of Schneider — control points at one third
+	// of the chord along the end tangents.
+	if len(pts) == 2 {
+		dist := pts[0].Distance(pts[1]) / 3
+		appendBezier(path, gg.CubicBez{
+			P0: pts[0],
+			P1: pts[0].Add(tHat1.Mul(dist)),
+			P2: pts[1].Add(tHat2.Mul(dist)),
+			P3: pts[1],
+		})
+		return
+	}
+
+	u := chordLengthParameterize(pts)
+	bez := generateBezier(pts, u, tHat1, tHat2)
+	maxErr, splitIndex := computeMaxError(pts, bez, u)
+	if maxErr < tolerance {
+		appendBezier(path, bez)
+		return
+	}
+
+	// If the error is not far off, try refining the parameterization.
+	if maxErr < tolerance*4 {
+		for i := 0; i < maxReparamIterations; i++ {
+			u = reparameterize(pts, u, bez)
+			bez = generateBezier(pts, u, tHat1, tHat2)
+			maxErr, splitIndex = computeMaxError(pts, bez, u)
+			if maxErr < tolerance {
+				appendBezier(path, bez)
+				return
+			}
+		}
+	}
+
+	// Split at the point of maximum error and fit both halves, with a
+	// shared center tangent for G1 continuity at the join.
+	centerTangent := pts[splitIndex-1].Sub(pts[splitIndex+1]).Normalize()
+	fitCubic(path, pts[:splitIndex+1], tHat1, centerTangent, tolerance)
+	fitCubic(path, pts[splitIndex:], centerTangent.Mul(-1), tHat2, tolerance)
+}
+
+// appendBezier appends one fitted cubic to the path.
+func appendBezier(path *gg.Path, b gg.CubicBez) {
+	path.CubicTo(b.P1.X, b.P1.Y, b.P2.X, b.P2.Y, b.P3.X, b.P3.Y)
+}
+
+// chordLengthParameterize assigns each point a parameter in [0, 1]
+// proportional to its distance along the polyline.
+func chordLengthParameterize(pts []gg.Point) []float64 {
+	u := make([]float64, len(pts))
+	for i := 1; i < len(pts); i++ {
+		u[i] = u[i-1] + pts[i].Distance(pts[i-1])
+	}
+	total := u[len(u)-1]
+	if total == 0 {
+		return u
+	}
+	for i := range u {
+		u[i] /= total
+	}
+	return u
+}
+
+// generateBezier computes the least-squares cubic for the points at the
+// given parameter values, with end tangent directions tHat1 and tHat2.
+func generateBezier(pts []gg.Point, u []float64, tHat1, tHat2 gg.Point) gg.CubicBez {
+	first := pts[0]
+	last := pts[len(pts)-1]
+
+	// Build the normal equations for the two free scalars alpha1, alpha2
+	// (distances of the inner control points along the end tangents).
+	var c00, c01, c11, x0, x1 float64
+	for i, t := range u {
+		b0 := bernstein0(t)
+		b1 := bernstein1(t)
+		b2 := bernstein2(t)
+		b3 := bernstein3(t)
+
+		a1 := tHat1.Mul(b1)
+		a2 := tHat2.Mul(b2)
+
+		c00 += a1.Dot(a1)
+		c01 += a1.Dot(a2)
+		c11 += a2.Dot(a2)
+
+		tmp := pts[i].Sub(first.Mul(b0 + b1)).Sub(last.Mul(b2 + b3))
+		x0 += a1.Dot(tmp)
+		x1 += a2.Dot(tmp)
+	}
+
+	det := c00*c11 - c01*c01
+	var alpha1, alpha2 float64
+	if det != 0 {
+		alpha1 = (x0*c11 - x1*c01) / det
+		alpha2 = (c00*x1 - c01*x0) / det
+	}
+
+	// Fall back to the one-third-chord heuristic if the least-squares
+	// solution is degenerate or places control points behind the endpoints.
+	segLength := first.Distance(last)
+	epsilon := 1e-6 * segLength
+	if alpha1 < epsilon || alpha2 < epsilon {
+		dist := segLength / 3
+		alpha1 = dist
+		alpha2 = dist
+	}
+
+	return gg.CubicBez{
+		P0: first,
+		P1: first.Add(tHat1.Mul(alpha1)),
+		P2: last.Add(tHat2.Mul(alpha2)),
+		P3: last,
+	}
+}
+
+// computeMaxError returns the maximum distance from the points to the
+// fitted curve at their parameter values, and the index of the worst
+// point (used as the split position).
+func computeMaxError(pts []gg.Point, bez gg.CubicBez, u []float64) (maxErr float64, splitIndex int) {
+	splitIndex = len(pts) / 2
+	for i := 1; i < len(pts)-1; i++ {
+		dist := bez.Eval(u[i]).Distance(pts[i])
+		if dist > maxErr {
+			maxErr = dist
+			splitIndex = i
+		}
+	}
+	return maxErr, splitIndex
+}
+
+// reparameterize refines each point's parameter value with one
+// Newton-Raphson step toward the closest point on the curve.
+func reparameterize(pts []gg.Point, u []float64, bez gg.CubicBez) []float64 {
+	refined := make([]float64, len(u))
+	for i := range u {
+		refined[i] = newtonRaphsonRootFind(bez, pts[i], u[i])
+	}
+	return refined
+}
+
+// newtonRaphsonRootFind performs one Newton iteration minimizing the
+// distance from p to the curve, starting at parameter u.
+func newtonRaphsonRootFind(bez gg.CubicBez, p gg.Point, u float64) float64 {
+	d := bez.Eval(u).Sub(p)
+
+	deriv := bez.Deriv()
+	d1 := deriv.Eval(u)
+	// Derivative of the quadratic derivative curve (linear).
+	d2 := gg.Point{
+		X: 2*(deriv.P1.X-deriv.P0.X) + 2*u*((deriv.P2.X-deriv.P1.X)-(deriv.P1.X-deriv.P0.X)),
+		Y: 2*(deriv.P1.Y-deriv.P0.Y) + 2*u*((deriv.P2.Y-deriv.P1.Y)-(deriv.P1.Y-deriv.P0.Y)),
+	}
+
+	numerator := d.Dot(d1)
+	denominator := d1.Dot(d1) + d.Dot(d2)
+	if denominator == 0 {
+		return u
+	}
+	result := u - numerator/denominator
+	return math.Max(0, math.Min(1, result))
+}
+
+// Bernstein basis polynomials of degree 3.
+func bernstein0(t float64) float64 { mt := 1 - t; return mt * mt * mt }
+func bernstein1(t float64) float64 { mt := 1 - t; return 3 * t * mt * mt }
+func bernstein2(t float64) float64 { mt := 1 - t; return 3 * t * t * mt }
+func bernstein3(t float64) float64 { return t * t * t }
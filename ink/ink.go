@@ -0,0 +1,184 @@
+// Package ink turns raw freehand input into smooth vector strokes.
+//
+// Pointer and stylus events arrive as noisy, unevenly spaced samples. The
+// package processes them in two stages:
+//
+//  1. Smoothing: a 1€ filter (Casiez et al., CHI 2012) removes jitter while
+//     keeping latency low — the cutoff frequency adapts to movement speed,
+//     so slow precise strokes get heavy smoothing and fast strokes stay
+//     responsive.
+//  2. Fitting: least-squares cubic Bezier fitting (Schneider's algorithm
+//     from Graphics Gems) converts the smoothed polyline into a compact
+//     curve path within a pixel tolerance.
+//
+// Pressure is carried through both stages. FitStroke returns the stroke
+// centerline as a gg.Path; OutlineStroke returns a closed, fillable outline
+// whose width follows pressure, for handwriting and annotation rendering.
+//
+// # Example
+//
+//	filter := ink.NewOneEuroFilter(1.0, 0.007)
+//	var smoothed []ink.Point
+//	for _, raw := range samples {
+//	    smoothed = append(smoothed, filter.Filter(raw))
+//	}
+//	outline := ink.OutlineStroke(smoothed, 6, 0.5)
+//	dc.FillPath(outline)
+package ink
+
+import (
+	"math"
+	"time"
+
+	"github.com/gogpu/gg"
+)
+
+// Point is one raw or smoothed input sample.
+type Point struct {
+	// X, Y is the sample position in pixels.
+	X, Y float64
+
+	// Pressure is the normalized stylus pressure in [0, 1].
+	// Zero means "no pressure information" and is treated as full pressure.
+	Pressure float64
+
+	// Time is the sample timestamp relative to the start of the stroke.
+	Time time.Duration
+}
+
+// Pos returns the sample position as a gg.Point.
+func (p Point) Pos() gg.Point {
+	return gg.Pt(p.X, p.Y)
+}
+
+// effectivePressure maps the zero value ("no pressure data") to full pressure
+// and clamps reported values to [0, 1].
+func (p Point) effectivePressure() float64 {
+	if p.Pressure <= 0 {
+		return 1
+	}
+	return math.Min(p.Pressure, 1)
+}
+
+// -------------------------------------------------------------------
+// 1€ filter
+// -------------------------------------------------------------------
+
+// OneEuroFilter smooths a stream of input samples with speed-adaptive
+// low-pass filtering. At low speeds the cutoff frequency approaches
+// MinCutoff (strong smoothing, removes jitter); as speed grows the cutoff
+// rises by Beta per pixel/second of speed, reducing lag on fast strokes.
+//
+// One filter instance smooths one stroke; call Reset between strokes.
+// The filter is not safe for concurrent use.
+type OneEuroFilter struct {
+	// MinCutoff is the cutoff frequency in Hz at zero speed.
+	// Lower values smooth more. A good starting point is 1.0.
+	MinCutoff float64
+
+	// Beta scales how quickly the cutoff rises with speed.
+	// Higher values reduce lag on fast movement. Try 0.007.
+	Beta float64
+
+	// DCutoff is the cutoff frequency for the speed estimate itself.
+	DCutoff float64
+
+	x, y, pressure lowPass
+	dx, dy         lowPass
+	lastTime       time.Duration
+	started        bool
+}
+
+// NewOneEuroFilter creates a filter with the given zero-speed cutoff (Hz)
+// and speed coefficient. The derivative cutoff uses the customary 1 Hz.
+func NewOneEuroFilter(minCutoff, beta float64) *OneEuroFilter {
+	return &OneEuroFilter{
+		MinCutoff: minCutoff,
+		Beta:      beta,
+		DCutoff:   1.0,
+	}
+}
+
+// Reset clears the filter state for a new stroke.
+func (f *OneEuroFilter) Reset() {
+	f.x = lowPass{}
+	f.y = lowPass{}
+	f.pressure = lowPass{}
+	f.dx = lowPass{}
+	f.dy = lowPass{}
+	f.started = false
+}
+
+// Filter smooths one sample. The first sample of a stroke passes through
+// unchanged and initializes the filter state.
+func (f *OneEuroFilter) Filter(p Point) Point {
+	if !f.started {
+		f.started = true
+		f.lastTime = p.Time
+		f.x.init(p.X)
+		f.y.init(p.Y)
+		f.pressure.init(p.Pressure)
+		f.dx.init(0)
+		f.dy.init(0)
+		return p
+	}
+
+	dt := (p.Time - f.lastTime).Seconds()
+	f.lastTime = p.Time
+	if dt <= 0 {
+		// Duplicate or out-of-order timestamp: assume a nominal 120 Hz tick.
+		dt = 1.0 / 120
+	}
+
+	// Estimate speed through its own low-pass filter.
+	dAlpha := smoothingAlpha(dt, f.DCutoff)
+	vx := f.dx.filter((p.X-f.x.value)/dt, dAlpha)
+	vy := f.dy.filter((p.Y-f.y.value)/dt, dAlpha)
+	speed := math.Hypot(vx, vy)
+
+	cutoff := f.MinCutoff + f.Beta*speed
+	alpha := smoothingAlpha(dt, cutoff)
+
+	return Point{
+		X:        f.x.filter(p.X, alpha),
+		Y:        f.y.filter(p.Y, alpha),
+		Pressure: f.pressure.filter(p.Pressure, alpha),
+		Time:     p.Time,
+	}
+}
+
+// lowPass is a first-order exponential low-pass filter.
+type lowPass struct {
+	value float64
+}
+
+func (l *lowPass) init(v float64) {
+	l.value = v
+}
+
+func (l *lowPass) filter(v, alpha float64) float64 {
+	l.value = alpha*v + (1-alpha)*l.value
+	return l.value
+}
+
+// smoothingAlpha converts a cutoff frequency and timestep to the
+// exponential smoothing factor used by the 1€ filter.
+func smoothingAlpha(dt, cutoff float64) float64 {
+	tau := 1.0 / (2 * math.Pi * cutoff)
+	return 1.0 / (1 + tau/dt)
+}
+
+// SmoothStroke smooths a complete stroke through a fresh 1€ filter with
+// the given parameters. For incremental (per-event) smoothing, use an
+// OneEuroFilter directly.
+func SmoothStroke(points []Point, minCutoff, beta float64) []Point {
+	if len(points) == 0 {
+		return nil
+	}
+	f := NewOneEuroFilter(minCutoff, beta)
+	result := make([]Point, len(points))
+	for i, p := range points {
+		result[i] = f.Filter(p)
+	}
+	return result
+}
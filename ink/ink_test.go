@@ -0,0 +1,225 @@
+package ink
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/gogpu/gg"
+)
+
+// jitteredLine generates samples along a horizontal line with uniform
+// noise, at a fixed sample rate.
+func jitteredLine(n int, noise float64, rng *rand.Rand) []Point {
+	points := make([]Point, n)
+	for i := range points {
+		points[i] = Point{
+			X:        float64(i) * 2,
+			Y:        50 + noise*(2*rng.Float64()-1),
+			Pressure: 0.8,
+			Time:     time.Duration(i) * 8 * time.Millisecond,
+		}
+	}
+	return points
+}
+
+// meanSquaredDeviation measures deviation of sample Y values from the
+// known centerline y=50.
+func meanSquaredDeviation(points []Point) float64 {
+	var sum float64
+	for _, p := range points {
+		d := p.Y - 50
+		sum += d * d
+	}
+	return sum / float64(len(points))
+}
+
+func TestOneEuroFilterReducesJitter(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	raw := jitteredLine(200, 2, rng)
+
+	smoothed := SmoothStroke(raw, 1.0, 0.007)
+	if len(smoothed) != len(raw) {
+		t.Fatalf("got %d smoothed points, want %d", len(smoothed), len(raw))
+	}
+
+	rawDev := meanSquaredDeviation(raw)
+	smoothDev := meanSquaredDeviation(smoothed)
+	if smoothDev >= rawDev/2 {
+		t.Errorf("smoothed deviation %v, want well below raw %v", smoothDev, rawDev)
+	}
+}
+
+func TestOneEuroFilterFirstSamplePassthrough(t *testing.T) {
+	f := NewOneEuroFilter(1.0, 0.007)
+	p := Point{X: 3, Y: 7, Pressure: 0.5}
+	if got := f.Filter(p); got != p {
+		t.Errorf("first sample = %+v, want passthrough %+v", got, p)
+	}
+}
+
+func TestOneEuroFilterReset(t *testing.T) {
+	f := NewOneEuroFilter(1.0, 0.007)
+	f.Filter(Point{X: 100, Y: 100})
+	f.Filter(Point{X: 101, Y: 101, Time: 8 * time.Millisecond})
+	f.Reset()
+
+	p := Point{X: 0, Y: 0}
+	if got := f.Filter(p); got != p {
+		t.Errorf("first sample after Reset = %+v, want passthrough", got)
+	}
+}
+
+// samplePathPoints flattens a path into a dense point list.
+func samplePathPoints(p *gg.Path, steps int) []gg.Point {
+	var points []gg.Point
+	var cur gg.Point
+	p.Iterate(func(verb gg.PathVerb, coords []float64) {
+		switch verb {
+		case gg.MoveTo:
+			cur = gg.Pt(coords[0], coords[1])
+			points = append(points, cur)
+		case gg.LineTo:
+			end := gg.Pt(coords[0], coords[1])
+			for i := 1; i <= steps; i++ {
+				points = append(points, cur.Lerp(end, float64(i)/float64(steps)))
+			}
+			cur = end
+		case gg.CubicTo:
+			seg := gg.NewCubicBez(cur,
+				gg.Pt(coords[0], coords[1]),
+				gg.Pt(coords[2], coords[3]),
+				gg.Pt(coords[4], coords[5]))
+			for i := 1; i <= steps; i++ {
+				points = append(points, seg.Eval(float64(i)/float64(steps)))
+			}
+			cur = seg.P3
+		}
+	})
+	return points
+}
+
+func distanceToPath(samples []gg.Point, target gg.Point) float64 {
+	best := math.Inf(1)
+	for _, p := range samples {
+		if d := p.Distance(target); d < best {
+			best = d
+		}
+	}
+	return best
+}
+
+func TestFitStrokeWithinTolerance(t *testing.T) {
+	// Noise-free samples of a half circle.
+	var points []Point
+	for i := 0; i <= 50; i++ {
+		a := math.Pi * float64(i) / 50
+		points = append(points, Point{
+			X:    100 + 80*math.Cos(a),
+			Y:    100 + 80*math.Sin(a),
+			Time: time.Duration(i) * 8 * time.Millisecond,
+		})
+	}
+
+	const tolerance = 0.5
+	path := FitStroke(points, tolerance)
+	if path.NumVerbs() < 2 {
+		t.Fatal("FitStroke produced an empty path")
+	}
+
+	flat := samplePathPoints(path, 512)
+	for _, p := range points {
+		if d := distanceToPath(flat, p.Pos()); d > tolerance {
+			t.Errorf("fitted path misses sample (%v, %v) by %v", p.X, p.Y, d)
+		}
+	}
+
+	// A half circle should compress to far fewer curves than samples.
+	cubics := 0
+	for _, v := range path.Verbs() {
+		if v == gg.CubicTo {
+			cubics++
+		}
+	}
+	if cubics >= len(points)/2 {
+		t.Errorf("fit used %d cubics for %d samples, want compression", cubics, len(points))
+	}
+}
+
+func TestFitStrokeDegenerate(t *testing.T) {
+	if p := FitStroke(nil, 1); p.NumVerbs() != 0 {
+		t.Error("fit of no points should be empty")
+	}
+	single := []Point{{X: 5, Y: 5}}
+	if p := FitStroke(single, 1); p.NumVerbs() != 0 {
+		t.Error("fit of one point should be empty")
+	}
+	// Duplicate positions collapse to a single point.
+	dups := []Point{{X: 5, Y: 5}, {X: 5, Y: 5}, {X: 5, Y: 5}}
+	if p := FitStroke(dups, 1); p.NumVerbs() != 0 {
+		t.Error("fit of coincident points should be empty")
+	}
+}
+
+func TestOutlineStrokeWidthFollowsPressure(t *testing.T) {
+	// A straight horizontal stroke with pressure ramping 0.2 -> 1.0.
+	var points []Point
+	for i := 0; i <= 20; i++ {
+		u := float64(i) / 20
+		points = append(points, Point{
+			X:        10 + 180*u,
+			Y:        50,
+			Pressure: 0.2 + 0.8*u,
+			Time:     time.Duration(i) * 8 * time.Millisecond,
+		})
+	}
+
+	const baseWidth = 10.0
+	outline := OutlineStroke(points, baseWidth, 0.25)
+	if outline.NumVerbs() == 0 {
+		t.Fatal("OutlineStroke produced an empty path")
+	}
+
+	// Full pressure at the right end: the outline spans the full width.
+	if !outline.Contains(gg.Pt(185, 50+4.5)) {
+		t.Error("outline excludes a point inside the full-width end")
+	}
+	// Light pressure at the left end: only a fifth of the width.
+	if outline.Contains(gg.Pt(15, 50+4.5)) {
+		t.Error("outline includes a point outside the thin end")
+	}
+	if !outline.Contains(gg.Pt(15, 50+0.5)) {
+		t.Error("outline excludes the thin end's centerline vicinity")
+	}
+}
+
+func TestOutlineStrokeRoundCaps(t *testing.T) {
+	points := []Point{
+		{X: 50, Y: 50, Pressure: 1},
+		{X: 150, Y: 50, Pressure: 1, Time: 8 * time.Millisecond},
+	}
+	outline := OutlineStroke(points, 10, 0.25)
+
+	// The cap extends half the width beyond the endpoint.
+	if !outline.Contains(gg.Pt(154, 50)) {
+		t.Error("outline excludes the end cap interior")
+	}
+	if outline.Contains(gg.Pt(156, 50)) {
+		t.Error("outline extends past the end cap radius")
+	}
+	if !outline.Contains(gg.Pt(46, 50)) {
+		t.Error("outline excludes the start cap interior")
+	}
+}
+
+func TestOutlineStrokeTapIsDot(t *testing.T) {
+	points := []Point{{X: 30, Y: 30, Pressure: 0.5}}
+	outline := OutlineStroke(points, 8, 0.25)
+	if !outline.Contains(gg.Pt(30, 30)) {
+		t.Error("tap outline excludes its center")
+	}
+	if outline.Contains(gg.Pt(33, 30)) {
+		t.Error("tap outline exceeds the pressure-scaled radius")
+	}
+}
@@ -0,0 +1,78 @@
+package gg
+
+// InstancedShapeAware is an optional interface for accelerators that can
+// render many copies of one detected shape in a single instanced draw.
+// Instead of encoding a strip set per shape, the accelerator receives the
+// canonical shape once plus a device-space matrix per instance, and renders
+// all instances with one pipeline dispatch.
+//
+// This is the fast path for scatter plots, particle fields, and UI grids
+// where thousands of identical circles or rounded rectangles share a brush.
+type InstancedShapeAware interface {
+	// FillShapeInstanced renders the shape once per transform using a
+	// single instanced draw. Transforms are device-space matrices applied
+	// to the shape's own coordinates. Returns ErrFallbackToCPU if the
+	// shape or instance count cannot be accelerated.
+	FillShapeInstanced(target GPURenderTarget, shape DetectedShape, transforms []Matrix, paint *Paint) error
+}
+
+// FillShapeInstanced fills the same detected shape once per transform
+// using the current paint. Each transform is composed with the Context's
+// current transform, as if the shape were drawn inside a Push/Transform/Pop
+// per instance.
+//
+// When the registered accelerator implements InstancedShapeAware, all
+// instances are dispatched as a single instanced draw. Otherwise each
+// instance is filled individually through the normal fill pipeline
+// (which may still use per-shape GPU SDF acceleration).
+func (c *Context) FillShapeInstanced(shape DetectedShape, transforms []Matrix) error {
+	if shape.Kind == ShapeUnknown || len(transforms) == 0 {
+		return nil
+	}
+
+	// Instanced GPU path: one dispatch for all instances.
+	if a := Accelerator(); a != nil && a.CanAccelerate(AccelFill) {
+		if ia, ok := a.(InstancedShapeAware); ok {
+			total := c.totalMatrix()
+			device := make([]Matrix, len(transforms))
+			for i, t := range transforms {
+				device[i] = total.Multiply(t)
+			}
+			err := ia.FillShapeInstanced(c.gpuRenderTarget(), shape, device, c.paint)
+			if err == nil {
+				return nil
+			}
+			// Any error falls through to the per-instance path.
+		}
+	}
+
+	// Per-instance fallback: draw each copy through the normal pipeline.
+	saved := c.matrix
+	defer func() { c.matrix = saved }()
+	for _, t := range transforms {
+		c.matrix = saved.Multiply(t)
+		c.ClearPath()
+		c.drawDetectedShape(shape)
+		if err := c.Fill(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// drawDetectedShape appends a detected shape's geometry to the current path.
+func (c *Context) drawDetectedShape(shape DetectedShape) {
+	switch shape.Kind {
+	case ShapeCircle:
+		c.DrawCircle(shape.CenterX, shape.CenterY, shape.RadiusX)
+	case ShapeEllipse:
+		c.DrawEllipse(shape.CenterX, shape.CenterY, shape.RadiusX, shape.RadiusY)
+	case ShapeRect:
+		c.DrawRectangle(shape.CenterX-shape.Width/2, shape.CenterY-shape.Height/2, shape.Width, shape.Height)
+	case ShapeRRect:
+		c.DrawRoundedRectangle(shape.CenterX-shape.Width/2, shape.CenterY-shape.Height/2,
+			shape.Width, shape.Height, shape.CornerRadius)
+	case ShapeUnknown:
+		// Nothing to draw.
+	}
+}
@@ -0,0 +1,129 @@
+package gg
+
+import (
+	"sync/atomic"
+	"testing"
+)
+
+func TestFillShapeInstancedCPUFallback(t *testing.T) {
+	CloseAccelerator()
+
+	dc := NewContext(100, 100)
+	defer dc.Close()
+
+	dc.SetRGB(1, 0, 0)
+	shape := DetectedShape{Kind: ShapeCircle, CenterX: 0, CenterY: 0, RadiusX: 10, RadiusY: 10}
+	transforms := []Matrix{
+		Translate(25, 25),
+		Translate(75, 25),
+		Translate(50, 75),
+	}
+	if err := dc.FillShapeInstanced(shape, transforms); err != nil {
+		t.Fatalf("FillShapeInstanced: %v", err)
+	}
+
+	// Each instance center must be filled.
+	for _, pt := range [][2]int{{25, 25}, {75, 25}, {50, 75}} {
+		c := dc.pixmap.GetPixel(pt[0], pt[1])
+		if c.R < 0.9 {
+			t.Errorf("pixel at instance center %v = %+v, want red", pt, c)
+		}
+	}
+	// Space between instances stays empty.
+	if c := dc.pixmap.GetPixel(50, 25); c.A > 0.1 {
+		t.Errorf("pixel between instances = %+v, want transparent", c)
+	}
+}
+
+func TestFillShapeInstancedComposesTransform(t *testing.T) {
+	CloseAccelerator()
+
+	dc := NewContext(100, 100)
+	defer dc.Close()
+
+	dc.Translate(40, 0)
+	dc.SetRGB(0, 0, 1)
+	shape := DetectedShape{Kind: ShapeRect, Width: 20, Height: 20}
+	if err := dc.FillShapeInstanced(shape, []Matrix{Translate(20, 50)}); err != nil {
+		t.Fatalf("FillShapeInstanced: %v", err)
+	}
+
+	if c := dc.pixmap.GetPixel(60, 50); c.B < 0.9 {
+		t.Errorf("pixel at composed position = %+v, want blue", c)
+	}
+	if c := dc.pixmap.GetPixel(20, 50); c.A > 0.1 {
+		t.Errorf("pixel at uncomposed position = %+v, want transparent", c)
+	}
+	// The context transform must be restored afterwards.
+	if m := dc.GetTransform(); m.C != 40 {
+		t.Errorf("transform after instanced fill = %+v, want translate(40,0) preserved", m)
+	}
+}
+
+func TestFillShapeInstancedEmpty(t *testing.T) {
+	CloseAccelerator()
+
+	dc := NewContext(10, 10)
+	defer dc.Close()
+
+	if err := dc.FillShapeInstanced(DetectedShape{Kind: ShapeUnknown}, []Matrix{Identity()}); err != nil {
+		t.Errorf("unknown shape = %v, want nil", err)
+	}
+	if err := dc.FillShapeInstanced(DetectedShape{Kind: ShapeCircle, RadiusX: 5, RadiusY: 5}, nil); err != nil {
+		t.Errorf("no transforms = %v, want nil", err)
+	}
+}
+
+// instancedTracker is a mock accelerator that records instanced dispatches.
+type instancedTracker struct {
+	calls     atomic.Int32
+	instances atomic.Int32
+}
+
+func (t *instancedTracker) Name() string                        { return "instanced-tracker" }
+func (t *instancedTracker) Init() error                         { return nil }
+func (t *instancedTracker) Close()                              {}
+func (t *instancedTracker) CanAccelerate(op AcceleratedOp) bool { return op&AccelFill != 0 }
+func (t *instancedTracker) Flush(_ GPURenderTarget) error       { return nil }
+func (t *instancedTracker) FillPath(_ GPURenderTarget, _ *Path, _ *Paint) error {
+	return ErrFallbackToCPU
+}
+func (t *instancedTracker) StrokePath(_ GPURenderTarget, _ *Path, _ *Paint) error {
+	return ErrFallbackToCPU
+}
+func (t *instancedTracker) FillShape(_ GPURenderTarget, _ DetectedShape, _ *Paint) error {
+	return ErrFallbackToCPU
+}
+func (t *instancedTracker) StrokeShape(_ GPURenderTarget, _ DetectedShape, _ *Paint) error {
+	return ErrFallbackToCPU
+}
+
+func (t *instancedTracker) FillShapeInstanced(_ GPURenderTarget, _ DetectedShape, transforms []Matrix, _ *Paint) error {
+	t.calls.Add(1)
+	t.instances.Add(int32(len(transforms)))
+	return nil
+}
+
+func TestFillShapeInstancedDispatchesToAccelerator(t *testing.T) {
+	tracker := &instancedTracker{}
+	if err := RegisterAccelerator(tracker); err != nil {
+		t.Fatalf("RegisterAccelerator: %v", err)
+	}
+	defer CloseAccelerator()
+
+	dc := NewContext(100, 100)
+	defer dc.Close()
+
+	shape := DetectedShape{Kind: ShapeCircle, RadiusX: 5, RadiusY: 5}
+	transforms := []Matrix{Translate(10, 10), Translate(30, 30), Translate(50, 50)}
+	if err := dc.FillShapeInstanced(shape, transforms); err != nil {
+		t.Fatalf("FillShapeInstanced: %v", err)
+	}
+
+	if got := tracker.calls.Load(); got != 1 {
+		t.Errorf("instanced dispatches = %d, want 1", got)
+	}
+	if got := tracker.instances.Load(); got != 3 {
+		t.Errorf("instances dispatched = %d, want 3", got)
+	}
+}
@@ -60,6 +60,8 @@ type Canvas struct {
 	sizeChanged          bool            // Resize pending — texture must be recreated
 	width                int
 	height               int
+	viewportX            int // Surface-space origin for viewport rendering (0 = top-left)
+	viewportY            int
 	closed               bool
 	tracked              bool               // true if auto-registered with a ResourceTracker
 	damageFlashs         damageOverlayState // debug overlay fade state
@@ -0,0 +1,234 @@
+// Copyright 2026 The gogpu Authors
+// SPDX-License-Identifier: MIT
+
+package ggcanvas
+
+import (
+	"errors"
+	"fmt"
+	"image"
+
+	"github.com/gogpu/gpucontext"
+)
+
+// Overlay errors.
+var (
+	// ErrNilImage is returned when a nil or empty sprite image is passed.
+	ErrNilImage = errors.New("ggcanvas: nil or empty sprite image")
+)
+
+// Sprite is a small image composited above the canvas each frame.
+// Sprites are cheap to move: changing position or visibility touches no
+// pixel data, so the base canvas texture is never re-uploaded. Only
+// Update re-uploads, and only this sprite's own (small) texture.
+//
+// Create sprites via Overlay.AddSprite. The zero value is not usable.
+type Sprite struct {
+	width  int
+	height int
+	pixels []byte // tightly packed RGBA, width*height*4
+
+	x, y    float32
+	visible bool
+
+	texture any  // lazily created gpucontext.Texture
+	dirty   bool // pixel data changed since last upload
+}
+
+// SetPosition moves the sprite. Position is in surface pixels, top-left
+// origin. This is the per-frame hot path — no GPU work happens here.
+func (s *Sprite) SetPosition(x, y float32) {
+	s.x = x
+	s.y = y
+}
+
+// Position returns the sprite position.
+func (s *Sprite) Position() (x, y float32) {
+	return s.x, s.y
+}
+
+// SetVisible shows or hides the sprite without releasing its texture.
+func (s *Sprite) SetVisible(visible bool) {
+	s.visible = visible
+}
+
+// Visible reports whether the sprite is drawn.
+func (s *Sprite) Visible() bool {
+	return s.visible
+}
+
+// Size returns the sprite dimensions in pixels.
+func (s *Sprite) Size() (width, height int) {
+	return s.width, s.height
+}
+
+// Update replaces the sprite image. The texture is re-uploaded on the
+// next overlay render — in place when the size is unchanged and the
+// texture supports updates, otherwise recreated.
+func (s *Sprite) Update(img *image.RGBA) error {
+	pixels, w, h, err := packRGBA(img)
+	if err != nil {
+		return err
+	}
+	if w != s.width || h != s.height {
+		// Size changed: the old texture cannot be reused.
+		destroyTexture(s.texture)
+		s.texture = nil
+	}
+	s.width = w
+	s.height = h
+	s.pixels = pixels
+	s.dirty = true
+	return nil
+}
+
+// Overlay composites registered sprites (cursor, selection handles) above
+// the canvas content each frame. Each sprite owns a small GPU texture
+// uploaded once and re-drawn at its current position, so per-frame cost
+// is a handful of textured quads — the base canvas is untouched.
+//
+// Overlay is NOT safe for concurrent use.
+//
+// Example:
+//
+//	overlay := ggcanvas.NewOverlay()
+//	cursor, _ := overlay.AddSprite(cursorImage)
+//
+//	app.OnDraw(func(dc *gogpu.Context) {
+//	    canvas.RenderTo(dc.AsTextureDrawer())
+//	    cursor.SetPosition(mouseX, mouseY)
+//	    overlay.RenderTo(dc.AsTextureDrawer())
+//	})
+type Overlay struct {
+	sprites []*Sprite
+}
+
+// NewOverlay creates an empty sprite overlay.
+func NewOverlay() *Overlay {
+	return &Overlay{}
+}
+
+// AddSprite registers a sprite from an RGBA image. The sprite starts
+// visible at (0, 0); its texture is created lazily on first RenderTo.
+// Sprites draw in registration order (later sprites on top).
+func (o *Overlay) AddSprite(img *image.RGBA) (*Sprite, error) {
+	pixels, w, h, err := packRGBA(img)
+	if err != nil {
+		return nil, err
+	}
+	s := &Sprite{
+		width:   w,
+		height:  h,
+		pixels:  pixels,
+		visible: true,
+		dirty:   true,
+	}
+	o.sprites = append(o.sprites, s)
+	return s, nil
+}
+
+// RemoveSprite unregisters a sprite and destroys its texture.
+// Returns true if the sprite was in the overlay.
+func (o *Overlay) RemoveSprite(s *Sprite) bool {
+	for i := range o.sprites {
+		if o.sprites[i] == s {
+			o.sprites = append(o.sprites[:i], o.sprites[i+1:]...)
+			destroyTexture(s.texture)
+			s.texture = nil
+			return true
+		}
+	}
+	return false
+}
+
+// Len returns the number of registered sprites.
+func (o *Overlay) Len() int {
+	return len(o.sprites)
+}
+
+// RenderTo draws all visible sprites at their current positions, in
+// registration order. Call after presenting the canvas content so sprites
+// composite on top.
+func (o *Overlay) RenderTo(dc gpucontext.TextureDrawer) error {
+	for _, s := range o.sprites {
+		if !s.visible {
+			continue
+		}
+		if err := o.ensureTexture(s, dc); err != nil {
+			return err
+		}
+		tex, ok := s.texture.(gpucontext.Texture)
+		if !ok {
+			return ErrInvalidDrawContext
+		}
+		if err := dc.DrawTexture(tex, s.x, s.y); err != nil {
+			return fmt.Errorf("ggcanvas: sprite draw failed: %w", err)
+		}
+	}
+	return nil
+}
+
+// Close destroys all sprite textures and clears the overlay.
+// The overlay can be reused after Close by adding new sprites.
+func (o *Overlay) Close() error {
+	for _, s := range o.sprites {
+		destroyTexture(s.texture)
+		s.texture = nil
+	}
+	o.sprites = nil
+	return nil
+}
+
+// ensureTexture creates or updates the sprite texture as needed.
+func (o *Overlay) ensureTexture(s *Sprite, dc gpucontext.TextureDrawer) error {
+	if s.texture != nil && !s.dirty {
+		return nil
+	}
+
+	// In-place update when the existing texture supports it.
+	if s.texture != nil {
+		if updater, ok := s.texture.(gpucontext.TextureUpdater); ok {
+			if err := updater.UpdateData(s.pixels); err != nil {
+				return fmt.Errorf("ggcanvas: sprite update failed: %w", err)
+			}
+			s.dirty = false
+			return nil
+		}
+		destroyTexture(s.texture)
+		s.texture = nil
+	}
+
+	creator := dc.TextureCreator()
+	if creator == nil {
+		return ErrInvalidRenderer
+	}
+	tex, err := creator.NewTextureFromRGBA(s.width, s.height, s.pixels)
+	if err != nil {
+		return fmt.Errorf("ggcanvas: sprite texture creation failed: %w", err)
+	}
+	s.texture = tex
+	s.dirty = false
+	return nil
+}
+
+// packRGBA copies an image.RGBA into a tightly packed RGBA buffer as
+// expected by NewTextureFromRGBA, handling sub-images and padded strides.
+func packRGBA(img *image.RGBA) (pixels []byte, width, height int, err error) {
+	if img == nil {
+		return nil, 0, 0, ErrNilImage
+	}
+	b := img.Bounds()
+	width, height = b.Dx(), b.Dy()
+	if width <= 0 || height <= 0 {
+		return nil, 0, 0, ErrNilImage
+	}
+
+	const bytesPerPixel = 4
+	rowLen := width * bytesPerPixel
+	pixels = make([]byte, height*rowLen)
+	for y := 0; y < height; y++ {
+		src := img.PixOffset(b.Min.X, b.Min.Y+y)
+		copy(pixels[y*rowLen:(y+1)*rowLen], img.Pix[src:src+rowLen])
+	}
+	return pixels, width, height, nil
+}
@@ -0,0 +1,189 @@
+// Copyright 2026 The gogpu Authors
+// SPDX-License-Identifier: MIT
+
+package ggcanvas
+
+import (
+	"image"
+	"image/color"
+	"testing"
+)
+
+// testSpriteImage returns a small solid-color RGBA image.
+func testSpriteImage(w, h int, c color.RGBA) *image.RGBA {
+	img := image.NewRGBA(image.Rect(0, 0, w, h))
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			img.SetRGBA(x, y, c)
+		}
+	}
+	return img
+}
+
+func TestOverlayAddAndRender(t *testing.T) {
+	overlay := NewOverlay()
+	defer overlay.Close()
+
+	cursor, err := overlay.AddSprite(testSpriteImage(8, 8, color.RGBA{R: 255, A: 255}))
+	if err != nil {
+		t.Fatalf("AddSprite failed: %v", err)
+	}
+	if overlay.Len() != 1 {
+		t.Fatalf("Len = %d, want 1", overlay.Len())
+	}
+	if w, h := cursor.Size(); w != 8 || h != 8 {
+		t.Errorf("sprite size = %dx%d, want 8x8", w, h)
+	}
+
+	cursor.SetPosition(100, 50)
+
+	renderer := &mockRenderer{}
+	dc := &recordingDrawContext{renderer: renderer}
+	if err := overlay.RenderTo(dc); err != nil {
+		t.Fatalf("RenderTo failed: %v", err)
+	}
+
+	if len(dc.draws) != 1 {
+		t.Fatalf("draws = %d, want 1", len(dc.draws))
+	}
+	if dc.draws[0].x != 100 || dc.draws[0].y != 50 {
+		t.Errorf("sprite drawn at (%v, %v), want (100, 50)", dc.draws[0].x, dc.draws[0].y)
+	}
+	if len(renderer.textures) != 1 {
+		t.Fatalf("textures created = %d, want 1", len(renderer.textures))
+	}
+
+	// Moving and re-rendering must not upload again.
+	cursor.SetPosition(200, 150)
+	if err := overlay.RenderTo(dc); err != nil {
+		t.Fatalf("second RenderTo failed: %v", err)
+	}
+	if len(renderer.textures) != 1 {
+		t.Errorf("textures after move = %d, want 1 (no re-upload)", len(renderer.textures))
+	}
+	if renderer.textures[0].updated != 0 {
+		t.Errorf("texture updated %d times after move, want 0", renderer.textures[0].updated)
+	}
+	if dc.draws[1].x != 200 || dc.draws[1].y != 150 {
+		t.Errorf("moved sprite drawn at (%v, %v), want (200, 150)", dc.draws[1].x, dc.draws[1].y)
+	}
+}
+
+func TestOverlaySpriteVisibility(t *testing.T) {
+	overlay := NewOverlay()
+	defer overlay.Close()
+
+	s, err := overlay.AddSprite(testSpriteImage(4, 4, color.RGBA{G: 255, A: 255}))
+	if err != nil {
+		t.Fatalf("AddSprite failed: %v", err)
+	}
+
+	s.SetVisible(false)
+	if s.Visible() {
+		t.Error("sprite should be hidden")
+	}
+
+	dc := &recordingDrawContext{renderer: &mockRenderer{}}
+	if err := overlay.RenderTo(dc); err != nil {
+		t.Fatalf("RenderTo failed: %v", err)
+	}
+	if len(dc.draws) != 0 {
+		t.Errorf("hidden sprite was drawn %d times", len(dc.draws))
+	}
+
+	s.SetVisible(true)
+	if err := overlay.RenderTo(dc); err != nil {
+		t.Fatalf("RenderTo failed: %v", err)
+	}
+	if len(dc.draws) != 1 {
+		t.Errorf("draws = %d, want 1 after show", len(dc.draws))
+	}
+}
+
+func TestOverlaySpriteUpdate(t *testing.T) {
+	overlay := NewOverlay()
+	defer overlay.Close()
+
+	s, err := overlay.AddSprite(testSpriteImage(4, 4, color.RGBA{B: 255, A: 255}))
+	if err != nil {
+		t.Fatalf("AddSprite failed: %v", err)
+	}
+
+	renderer := &mockRenderer{}
+	dc := &recordingDrawContext{renderer: renderer}
+	if err := overlay.RenderTo(dc); err != nil {
+		t.Fatalf("RenderTo failed: %v", err)
+	}
+
+	// Same-size update reuses the texture via UpdateData.
+	if err := s.Update(testSpriteImage(4, 4, color.RGBA{R: 255, A: 255})); err != nil {
+		t.Fatalf("Update failed: %v", err)
+	}
+	if err := overlay.RenderTo(dc); err != nil {
+		t.Fatalf("RenderTo after update failed: %v", err)
+	}
+	if len(renderer.textures) != 1 {
+		t.Errorf("textures = %d, want 1 (in-place update)", len(renderer.textures))
+	}
+	if renderer.textures[0].updated != 1 {
+		t.Errorf("UpdateData calls = %d, want 1", renderer.textures[0].updated)
+	}
+
+	// Resizing recreates the texture.
+	if err := s.Update(testSpriteImage(8, 8, color.RGBA{R: 255, A: 255})); err != nil {
+		t.Fatalf("resize Update failed: %v", err)
+	}
+	if err := overlay.RenderTo(dc); err != nil {
+		t.Fatalf("RenderTo after resize failed: %v", err)
+	}
+	if len(renderer.textures) != 2 {
+		t.Errorf("textures = %d, want 2 (recreated on resize)", len(renderer.textures))
+	}
+	if !renderer.textures[0].destroyed {
+		t.Error("old texture was not destroyed on resize")
+	}
+}
+
+func TestOverlayRemoveAndClose(t *testing.T) {
+	overlay := NewOverlay()
+
+	a, _ := overlay.AddSprite(testSpriteImage(2, 2, color.RGBA{A: 255}))
+	b, _ := overlay.AddSprite(testSpriteImage(2, 2, color.RGBA{A: 255}))
+
+	renderer := &mockRenderer{}
+	dc := &recordingDrawContext{renderer: renderer}
+	if err := overlay.RenderTo(dc); err != nil {
+		t.Fatalf("RenderTo failed: %v", err)
+	}
+
+	if !overlay.RemoveSprite(a) {
+		t.Error("RemoveSprite returned false for a member")
+	}
+	if overlay.RemoveSprite(a) {
+		t.Error("RemoveSprite returned true for a removed sprite")
+	}
+	if !renderer.textures[0].destroyed {
+		t.Error("removed sprite texture was not destroyed")
+	}
+
+	if err := overlay.Close(); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+	if overlay.Len() != 0 {
+		t.Errorf("Len after Close = %d, want 0", overlay.Len())
+	}
+	if !renderer.textures[1].destroyed {
+		t.Error("Close did not destroy remaining sprite texture")
+	}
+	_ = b
+}
+
+func TestOverlayAddSpriteInvalid(t *testing.T) {
+	overlay := NewOverlay()
+	if _, err := overlay.AddSprite(nil); err == nil {
+		t.Error("expected error for nil image")
+	}
+	if _, err := overlay.AddSprite(image.NewRGBA(image.Rect(0, 0, 0, 0))); err == nil {
+		t.Error("expected error for empty image")
+	}
+}
@@ -0,0 +1,91 @@
+// Copyright 2026 The gogpu Authors
+// SPDX-License-Identifier: MIT
+
+package ggcanvas
+
+import (
+	"github.com/gogpu/gg"
+	"github.com/gogpu/gg/scene"
+)
+
+// RenderScene renders a retained scene for this frame, choosing the most
+// direct path available. Selectable per frame: frames built with Draw()
+// continue to use the pixmap pipeline, frames with retained scenes can
+// call RenderScene instead.
+//
+// On GPU backends with direct surface support, the scene is decoded by
+// scene.GPUSceneRenderer into accelerator draw calls and flushed straight
+// into the surface texture — no CPU rasterization and no pixmap upload.
+//
+// Without direct surface support (software backend, CPU-only adapters),
+// the scene is rasterized into the canvas pixmap and presented through
+// the regular Render path.
+//
+// Example:
+//
+//	app.OnDraw(func(dc *gogpu.Context) {
+//	    canvas.RenderScene(dc.RenderTarget(), myScene)
+//	})
+func (c *Canvas) RenderScene(dc RenderTarget, s *scene.Scene) error {
+	if c.closed {
+		return ErrCanvasClosed
+	}
+	if s == nil {
+		return nil
+	}
+
+	// GPU-direct path: decode scene into accelerator draw calls and flush
+	// to the surface view. Same capability gate as Render(): on CPU-only
+	// adapters the accelerator stays uninitialized and the direct flush
+	// would silently render nothing.
+	sv := dc.SurfaceView()
+	if !sv.IsNil() && gg.AcceleratorCanRenderDirect() {
+		if err := c.renderSceneDirect(dc, s); err == nil {
+			return nil
+		}
+		// Direct path failed — fall through to the pixmap pipeline.
+	}
+
+	// Universal path: rasterize the scene into the canvas pixmap (CPU, or
+	// GPU with readback) and present via the regular texture pipeline.
+	if err := c.renderSceneToPixmap(s); err != nil {
+		return err
+	}
+	return c.Render(dc)
+}
+
+// renderSceneDirect decodes the scene through the GPU accelerator and
+// flushes the resulting draw calls directly to the surface view.
+func (c *Canvas) renderSceneDirect(dc RenderTarget, s *scene.Scene) error {
+	gg.BeginAcceleratorFrame()
+	c.ctx.Push()
+	c.ctx.Identity()
+	c.ctx.ClearPath()
+
+	r := scene.NewGPUSceneRenderer(c.ctx)
+	err := r.RenderScene(s)
+	c.ctx.Pop()
+	if err != nil {
+		return err
+	}
+
+	sw, sh := dc.SurfaceSize()
+	if err := c.ctx.FlushGPUWithView(dc.SurfaceView(), sw, sh); err != nil {
+		return err
+	}
+	c.dirty = false
+	return nil
+}
+
+// renderSceneToPixmap rasterizes the scene into the canvas pixmap via the
+// gg.Context draw pipeline and marks the canvas for upload.
+func (c *Canvas) renderSceneToPixmap(s *scene.Scene) error {
+	var renderErr error
+	if err := c.Draw(func(cc *gg.Context) {
+		r := scene.NewGPUSceneRenderer(cc)
+		renderErr = r.RenderScene(s)
+	}); err != nil {
+		return err
+	}
+	return renderErr
+}
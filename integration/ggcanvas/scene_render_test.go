@@ -0,0 +1,64 @@
+// Copyright 2026 The gogpu Authors
+// SPDX-License-Identifier: MIT
+
+package ggcanvas
+
+import (
+	"testing"
+
+	"github.com/gogpu/gg"
+	"github.com/gogpu/gg/scene"
+)
+
+// TestRenderScene_UniversalPath verifies that without a surface view the
+// scene is rasterized into the pixmap and presented via the texture path.
+func TestRenderScene_UniversalPath(t *testing.T) {
+	c, err := New(newMockProvider(), 64, 64)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer c.Close()
+
+	s := scene.NewScene()
+	s.Fill(scene.FillNonZero, scene.IdentityAffine(),
+		scene.SolidBrush(gg.RGBA{R: 1, A: 1}), scene.NewRectShape(0, 0, 64, 64))
+
+	dc := &renderMockWithCreator{renderer: &mockRenderer{}}
+	if err := c.RenderScene(dc, s); err != nil {
+		t.Fatalf("RenderScene failed: %v", err)
+	}
+
+	if dc.presentedTex == nil {
+		t.Fatal("scene was not presented via the texture path")
+	}
+
+	// The scene content must have reached the pixmap.
+	px := c.ctx.ResizeTarget().GetPixel(32, 32)
+	if px.R < 0.9 || px.A < 0.9 {
+		t.Errorf("center pixel = %+v, want opaque red", px)
+	}
+}
+
+// TestRenderScene_NilAndClosed verifies edge-case handling.
+func TestRenderScene_NilAndClosed(t *testing.T) {
+	c, err := New(newMockProvider(), 32, 32)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dc := &renderMockWithCreator{renderer: &mockRenderer{}}
+
+	// Nil scene is a no-op, not an error.
+	if err := c.RenderScene(dc, nil); err != nil {
+		t.Errorf("RenderScene(nil) = %v, want nil", err)
+	}
+	if dc.presentedTex != nil {
+		t.Error("nil scene should not present")
+	}
+
+	c.Close()
+	s := scene.NewScene()
+	if err := c.RenderScene(dc, s); err != ErrCanvasClosed {
+		t.Errorf("RenderScene on closed canvas = %v, want ErrCanvasClosed", err)
+	}
+}
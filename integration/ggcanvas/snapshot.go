@@ -0,0 +1,65 @@
+// Copyright 2026 The gogpu Authors
+// SPDX-License-Identifier: MIT
+
+package ggcanvas
+
+import (
+	"image"
+
+	"github.com/gogpu/gg"
+)
+
+// Snapshot returns a copy of the current canvas content as an RGBA image.
+//
+// Pending GPU-accelerated shapes are flushed into the pixmap first, so the
+// snapshot reflects everything drawn this frame (post-composite). The
+// returned image has physical pixel dimensions (after device scale) and
+// does not share memory with the canvas — it stays valid across later
+// draws.
+//
+// Intended for tests, "export as PNG" features, and visual diffing:
+//
+//	img, err := canvas.Snapshot()
+//	if err == nil {
+//	    png.Encode(file, img)
+//	}
+func (c *Canvas) Snapshot() (*image.RGBA, error) {
+	return c.SnapshotRegion(image.Rectangle{})
+}
+
+// SnapshotRegion is like Snapshot but reads only the given region, in
+// physical pixel coordinates. An empty rectangle reads the full canvas.
+func (c *Canvas) SnapshotRegion(r image.Rectangle) (*image.RGBA, error) {
+	if c.closed {
+		return nil, ErrCanvasClosed
+	}
+
+	// Flush pending GPU shapes into the pixel buffer so the snapshot is
+	// complete. Non-fatal, matching Flush(): CPU fallback may have already
+	// rendered the content.
+	if err := c.ctx.FlushGPU(); err != nil {
+		gg.Logger().Warn("ggcanvas: FlushGPU before Snapshot failed", "err", err)
+	}
+
+	pixmap := c.ctx.ResizeTarget()
+	bounds := image.Rect(0, 0, pixmap.Width(), pixmap.Height())
+	if r.Empty() {
+		r = bounds
+	} else {
+		r = r.Intersect(bounds)
+		if r.Empty() {
+			return nil, ErrInvalidDimensions
+		}
+	}
+
+	const bytesPerPixel = 4
+	data := pixmap.Data()
+	stride := pixmap.Width() * bytesPerPixel
+	img := image.NewRGBA(image.Rect(0, 0, r.Dx(), r.Dy()))
+	rowLen := r.Dx() * bytesPerPixel
+	for y := 0; y < r.Dy(); y++ {
+		src := (r.Min.Y+y)*stride + r.Min.X*bytesPerPixel
+		copy(img.Pix[y*img.Stride:y*img.Stride+rowLen], data[src:src+rowLen])
+	}
+	return img, nil
+}
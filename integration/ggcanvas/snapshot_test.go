@@ -0,0 +1,85 @@
+// Copyright 2026 The gogpu Authors
+// SPDX-License-Identifier: MIT
+
+package ggcanvas
+
+import (
+	"image"
+	"testing"
+
+	"github.com/gogpu/gg"
+)
+
+func TestSnapshot(t *testing.T) {
+	c, err := New(newMockProvider(), 64, 64)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer c.Close()
+
+	_ = c.Draw(func(cc *gg.Context) {
+		cc.ClearWithColor(gg.RGBA{R: 1, A: 1})
+	})
+
+	img, err := c.Snapshot()
+	if err != nil {
+		t.Fatalf("Snapshot failed: %v", err)
+	}
+	if img.Bounds() != image.Rect(0, 0, 64, 64) {
+		t.Errorf("bounds = %v, want (0,0)-(64,64)", img.Bounds())
+	}
+
+	r, _, _, a := img.At(32, 32).RGBA()
+	if r < 0xF000 || a < 0xF000 {
+		t.Errorf("center pixel = %v, want opaque red", img.At(32, 32))
+	}
+
+	// The snapshot must not share memory with the canvas.
+	_ = c.Draw(func(cc *gg.Context) {
+		cc.ClearWithColor(gg.RGBA{B: 1, A: 1})
+	})
+	r, _, _, _ = img.At(32, 32).RGBA()
+	if r < 0xF000 {
+		t.Error("snapshot changed after later draw — shares canvas memory")
+	}
+}
+
+func TestSnapshotRegion(t *testing.T) {
+	c, err := New(newMockProvider(), 64, 64)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer c.Close()
+
+	// Left half red, right half blue.
+	_ = c.Draw(func(cc *gg.Context) {
+		cc.SetRGB(1, 0, 0)
+		cc.DrawRectangle(0, 0, 32, 64)
+		cc.Fill()
+		cc.SetRGB(0, 0, 1)
+		cc.DrawRectangle(32, 0, 32, 64)
+		cc.Fill()
+	})
+
+	img, err := c.SnapshotRegion(image.Rect(32, 0, 64, 64))
+	if err != nil {
+		t.Fatalf("SnapshotRegion failed: %v", err)
+	}
+	if img.Bounds() != image.Rect(0, 0, 32, 64) {
+		t.Errorf("bounds = %v, want (0,0)-(32,64)", img.Bounds())
+	}
+	_, _, b, _ := img.At(16, 32).RGBA()
+	if b < 0xF000 {
+		t.Errorf("region pixel = %v, want blue (right half)", img.At(16, 32))
+	}
+
+	// Region outside the canvas is an error.
+	if _, err := c.SnapshotRegion(image.Rect(100, 100, 200, 200)); err == nil {
+		t.Error("expected error for out-of-bounds region")
+	}
+
+	c.Close()
+	if _, err := c.Snapshot(); err != ErrCanvasClosed {
+		t.Errorf("Snapshot on closed canvas = %v, want ErrCanvasClosed", err)
+	}
+}
@@ -0,0 +1,159 @@
+// Copyright 2026 The gogpu Authors
+// SPDX-License-Identifier: MIT
+
+package ggcanvas
+
+import (
+	"errors"
+	"fmt"
+	"image"
+	"sort"
+
+	"github.com/gogpu/gpucontext"
+)
+
+// SetViewport restricts the canvas to a sub-rectangle of the surface.
+// The canvas pixmap and texture are sized to w x h (logical units) and the
+// content is drawn at surface position (x, y) by RenderTo and CanvasGroup.
+//
+// Because the canvas texture covers exactly the viewport, drawing it at its
+// offset is inherently scissored — content cannot bleed outside the
+// viewport. Use this with CanvasGroup to tile several canvases into one
+// gogpu surface (split-pane editors, tool palettes).
+//
+// Resizes the canvas when w or h differ from the current dimensions, which
+// clears its content. Returns error if the canvas is closed or dimensions
+// are invalid.
+func (c *Canvas) SetViewport(x, y, w, h int) error {
+	if c.closed {
+		return ErrCanvasClosed
+	}
+	if w <= 0 || h <= 0 {
+		return fmt.Errorf("%w: width=%d, height=%d", ErrInvalidDimensions, w, h)
+	}
+	if err := c.Resize(w, h); err != nil {
+		return err
+	}
+	c.viewportX = x
+	c.viewportY = y
+	c.dirty = true
+	return nil
+}
+
+// Viewport returns the canvas viewport in surface coordinates.
+// For a canvas without an explicit viewport this is (0, 0, width, height).
+func (c *Canvas) Viewport() image.Rectangle {
+	return image.Rect(c.viewportX, c.viewportY, c.viewportX+c.width, c.viewportY+c.height)
+}
+
+// RenderToViewport draws the canvas at its viewport position.
+// Equivalent to RenderToPosition with the viewport origin.
+func (c *Canvas) RenderToViewport(dc gpucontext.TextureDrawer) error {
+	return c.RenderToPosition(dc, float32(c.viewportX), float32(c.viewportY))
+}
+
+// CanvasGroup composites multiple canvases into one gogpu surface.
+// Each canvas renders into its own viewport (SetViewport) and the group
+// draws them back-to-front by z-order, so overlapping panes stack
+// predictably.
+//
+// The group does not own its canvases: Close them individually, or rely on
+// their ResourceTracker registration. CanvasGroup is NOT safe for
+// concurrent use.
+//
+// Example:
+//
+//	left, _ := ggcanvas.New(provider, 400, 600)
+//	right, _ := ggcanvas.New(provider, 400, 600)
+//	left.SetViewport(0, 0, 400, 600)
+//	right.SetViewport(400, 0, 400, 600)
+//
+//	group := ggcanvas.NewCanvasGroup()
+//	group.Add(left, 0)
+//	group.Add(right, 0)
+//
+//	app.OnDraw(func(dc *gogpu.Context) {
+//	    group.RenderTo(dc.AsTextureDrawer())
+//	})
+type CanvasGroup struct {
+	entries []groupEntry
+}
+
+// groupEntry pairs a canvas with its stacking order.
+type groupEntry struct {
+	canvas *Canvas
+	z      int
+}
+
+// NewCanvasGroup creates an empty canvas group.
+func NewCanvasGroup() *CanvasGroup {
+	return &CanvasGroup{}
+}
+
+// Add inserts a canvas with the given z-order. Higher z draws on top.
+// Canvases with equal z draw in insertion order. Adding a canvas that is
+// already in the group updates its z-order instead.
+func (g *CanvasGroup) Add(c *Canvas, z int) {
+	if c == nil {
+		return
+	}
+	for i := range g.entries {
+		if g.entries[i].canvas == c {
+			g.entries[i].z = z
+			return
+		}
+	}
+	g.entries = append(g.entries, groupEntry{canvas: c, z: z})
+}
+
+// Remove takes a canvas out of the group. The canvas itself is not closed.
+// Returns true if the canvas was in the group.
+func (g *CanvasGroup) Remove(c *Canvas) bool {
+	for i := range g.entries {
+		if g.entries[i].canvas == c {
+			g.entries = append(g.entries[:i], g.entries[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+// Len returns the number of canvases in the group.
+func (g *CanvasGroup) Len() int {
+	return len(g.entries)
+}
+
+// Canvases returns the canvases in draw order (back to front).
+func (g *CanvasGroup) Canvases() []*Canvas {
+	g.sortByZ()
+	out := make([]*Canvas, len(g.entries))
+	for i, e := range g.entries {
+		out[i] = e.canvas
+	}
+	return out
+}
+
+// RenderTo draws every canvas into the target at its viewport position,
+// back to front. Closed canvases are skipped. Rendering continues past
+// individual failures; all errors are joined in the returned error.
+func (g *CanvasGroup) RenderTo(dc gpucontext.TextureDrawer) error {
+	g.sortByZ()
+	var errs []error
+	for _, e := range g.entries {
+		if e.canvas.closed {
+			continue
+		}
+		if err := e.canvas.RenderToViewport(dc); err != nil {
+			errs = append(errs, fmt.Errorf("ggcanvas: viewport %v: %w", e.canvas.Viewport(), err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// sortByZ orders entries back to front. The sort is stable so canvases
+// with equal z keep their insertion order.
+func (g *CanvasGroup) sortByZ() {
+	sort.SliceStable(g.entries, func(i, j int) bool {
+		return g.entries[i].z < g.entries[j].z
+	})
+}
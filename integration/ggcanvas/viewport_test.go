@@ -0,0 +1,189 @@
+// Copyright 2026 The gogpu Authors
+// SPDX-License-Identifier: MIT
+
+package ggcanvas
+
+import (
+	"image"
+	"testing"
+
+	"github.com/gogpu/gg"
+	"github.com/gogpu/gpucontext"
+)
+
+// drawRecord captures one DrawTexture call.
+type drawRecord struct {
+	tex  gpucontext.Texture
+	x, y float32
+}
+
+// recordingDrawContext implements gpucontext.TextureDrawer and keeps every
+// draw in order, for verifying z-order and viewport positions.
+type recordingDrawContext struct {
+	renderer *mockRenderer
+	draws    []drawRecord
+}
+
+func (m *recordingDrawContext) DrawTexture(tex gpucontext.Texture, x, y float32) error {
+	m.draws = append(m.draws, drawRecord{tex: tex, x: x, y: y})
+	return nil
+}
+
+func (m *recordingDrawContext) TextureCreator() gpucontext.TextureCreator {
+	return m.renderer
+}
+
+func TestSetViewport(t *testing.T) {
+	canvas, err := New(newMockProvider(), 800, 600)
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	defer canvas.Close()
+
+	// Default viewport covers the whole canvas at the origin.
+	if got := canvas.Viewport(); got != image.Rect(0, 0, 800, 600) {
+		t.Errorf("default viewport = %v, want (0,0)-(800,600)", got)
+	}
+
+	// Setting a viewport moves the origin and resizes the canvas.
+	if err := canvas.SetViewport(400, 100, 400, 500); err != nil {
+		t.Fatalf("SetViewport failed: %v", err)
+	}
+	if got := canvas.Viewport(); got != image.Rect(400, 100, 800, 600) {
+		t.Errorf("viewport = %v, want (400,100)-(800,600)", got)
+	}
+	if canvas.Width() != 400 || canvas.Height() != 500 {
+		t.Errorf("canvas size = %dx%d, want 400x500", canvas.Width(), canvas.Height())
+	}
+
+	// Invalid dimensions are rejected.
+	if err := canvas.SetViewport(0, 0, 0, 100); err == nil {
+		t.Error("expected error for zero width viewport")
+	}
+
+	// Closed canvas is rejected.
+	canvas.Close()
+	if err := canvas.SetViewport(0, 0, 100, 100); err != ErrCanvasClosed {
+		t.Errorf("SetViewport on closed canvas = %v, want ErrCanvasClosed", err)
+	}
+}
+
+func TestRenderToViewport(t *testing.T) {
+	canvas, err := New(newMockProvider(), 200, 100)
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	defer canvas.Close()
+
+	if err := canvas.SetViewport(300, 50, 200, 100); err != nil {
+		t.Fatalf("SetViewport failed: %v", err)
+	}
+	_ = canvas.Draw(func(cc *gg.Context) {
+		cc.SetRGB(1, 0, 0)
+		cc.Clear()
+	})
+
+	dc := &recordingDrawContext{renderer: &mockRenderer{}}
+	if err := canvas.RenderToViewport(dc); err != nil {
+		t.Fatalf("RenderToViewport failed: %v", err)
+	}
+
+	if len(dc.draws) != 1 {
+		t.Fatalf("draws = %d, want 1", len(dc.draws))
+	}
+	if dc.draws[0].x != 300 || dc.draws[0].y != 50 {
+		t.Errorf("drawn at (%v, %v), want (300, 50)", dc.draws[0].x, dc.draws[0].y)
+	}
+}
+
+func TestCanvasGroupZOrder(t *testing.T) {
+	provider := newMockProvider()
+	newPane := func(x, y int) *Canvas {
+		c, err := New(provider, 100, 100)
+		if err != nil {
+			t.Fatalf("New failed: %v", err)
+		}
+		if err := c.SetViewport(x, y, 100, 100); err != nil {
+			t.Fatalf("SetViewport failed: %v", err)
+		}
+		_ = c.Draw(func(cc *gg.Context) { cc.Clear() })
+		return c
+	}
+
+	back := newPane(0, 0)
+	front := newPane(50, 50)
+	middle := newPane(25, 25)
+	defer back.Close()
+	defer front.Close()
+	defer middle.Close()
+
+	group := NewCanvasGroup()
+	group.Add(front, 2)
+	group.Add(back, 0)
+	group.Add(middle, 1)
+	if group.Len() != 3 {
+		t.Fatalf("Len = %d, want 3", group.Len())
+	}
+
+	// Re-adding updates z-order instead of duplicating.
+	group.Add(front, 5)
+	if group.Len() != 3 {
+		t.Fatalf("Len after re-add = %d, want 3", group.Len())
+	}
+
+	dc := &recordingDrawContext{renderer: &mockRenderer{}}
+	if err := group.RenderTo(dc); err != nil {
+		t.Fatalf("RenderTo failed: %v", err)
+	}
+
+	// Back-to-front: back (z=0) at origin, middle (z=1), front (z=5) last.
+	if len(dc.draws) != 3 {
+		t.Fatalf("draws = %d, want 3", len(dc.draws))
+	}
+	wantPos := [][2]float32{{0, 0}, {25, 25}, {50, 50}}
+	for i, want := range wantPos {
+		if dc.draws[i].x != want[0] || dc.draws[i].y != want[1] {
+			t.Errorf("draw %d at (%v, %v), want (%v, %v)",
+				i, dc.draws[i].x, dc.draws[i].y, want[0], want[1])
+		}
+	}
+
+	if order := group.Canvases(); order[0] != back || order[1] != middle || order[2] != front {
+		t.Error("Canvases() not in back-to-front order")
+	}
+}
+
+func TestCanvasGroupRemoveAndClosed(t *testing.T) {
+	provider := newMockProvider()
+	a, _ := New(provider, 50, 50)
+	b, _ := New(provider, 50, 50)
+	defer a.Close()
+
+	_ = a.Draw(func(cc *gg.Context) { cc.Clear() })
+	_ = b.Draw(func(cc *gg.Context) { cc.Clear() })
+
+	group := NewCanvasGroup()
+	group.Add(a, 0)
+	group.Add(b, 1)
+
+	if !group.Remove(b) {
+		t.Error("Remove returned false for a member canvas")
+	}
+	if group.Remove(b) {
+		t.Error("Remove returned true for a non-member canvas")
+	}
+	if group.Len() != 1 {
+		t.Errorf("Len = %d, want 1", group.Len())
+	}
+
+	// Closed canvases are skipped, not errors.
+	group.Add(b, 1)
+	b.Close()
+	dc := &recordingDrawContext{renderer: &mockRenderer{}}
+	if err := group.RenderTo(dc); err != nil {
+		t.Fatalf("RenderTo with closed member failed: %v", err)
+	}
+	if len(dc.draws) != 1 {
+		t.Errorf("draws = %d, want 1 (closed canvas skipped)", len(dc.draws))
+	}
+}
@@ -0,0 +1,67 @@
+// Copyright 2026 The gogpu Authors
+// SPDX-License-Identifier: MIT
+
+package ggtexture
+
+import (
+	"image"
+
+	"github.com/gogpu/gg"
+)
+
+// ImageOpSource returns the current pixels as an image.Image suitable for
+// Gio's paint.NewImageOp. Pending GPU work is flushed first.
+//
+// The returned image is a copy, so it remains valid after subsequent Draw
+// calls — Gio caches ImageOps by backing image identity, and handing it a
+// mutating buffer would corrupt cached frames. For damage-aware uploads
+// use Frame() directly.
+//
+//	frameImg := provider.ImageOpSource()
+//	op := paint.NewImageOp(frameImg)
+//	op.Add(gtx.Ops)
+//
+// Returns nil if the provider is closed.
+func (p *Provider) ImageOpSource() image.Image {
+	if p.closed {
+		return nil
+	}
+	if err := p.ctx.FlushGPU(); err != nil {
+		gg.Logger().Warn("ggtexture: FlushGPU failed", "err", err)
+	}
+	p.dirty = nil
+	return p.ctx.ResizeTarget().ToImage()
+}
+
+// RasterFunc returns a generator compatible with Fyne's canvas.NewRaster.
+// Fyne calls the generator with the widget's current pixel size on every
+// repaint; the provider is resized to match, draw is invoked, and the
+// resulting pixels are returned.
+//
+//	raster := canvas.NewRaster(provider.RasterFunc(func(cc *gg.Context) {
+//	    cc.SetRGB(1, 0, 0)
+//	    cc.DrawCircle(100, 100, 50)
+//	    cc.Fill()
+//	}))
+//
+// The generator returns an empty image if the provider is closed or the
+// requested size is invalid, matching Fyne's tolerance for degenerate
+// sizes during layout.
+func (p *Provider) RasterFunc(draw func(*gg.Context)) func(w, h int) image.Image {
+	return func(w, h int) image.Image {
+		if p.closed || w <= 0 || h <= 0 {
+			return image.NewRGBA(image.Rect(0, 0, 0, 0))
+		}
+		if err := p.Resize(w, h); err != nil {
+			return image.NewRGBA(image.Rect(0, 0, 0, 0))
+		}
+		if draw != nil {
+			_ = p.Draw(draw)
+		}
+		frame, err := p.Frame()
+		if err != nil {
+			return image.NewRGBA(image.Rect(0, 0, 0, 0))
+		}
+		return frame.RGBA
+	}
+}
@@ -0,0 +1,99 @@
+// Copyright 2026 The gogpu Authors
+// SPDX-License-Identifier: MIT
+
+package ggtexture
+
+import (
+	"image"
+	"testing"
+
+	"github.com/gogpu/gg"
+)
+
+// TestImageOpSource verifies the Gio adapter returns a stable copy.
+func TestImageOpSource(t *testing.T) {
+	p, err := New(40, 40)
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	defer p.Close()
+
+	p.Draw(func(cc *gg.Context) {
+		cc.SetRGB(0, 0, 1)
+		cc.DrawRectangle(0, 0, 40, 40)
+		cc.Fill()
+	})
+
+	img := p.ImageOpSource()
+	if img == nil {
+		t.Fatal("ImageOpSource returned nil")
+	}
+	rgba, ok := img.(*image.RGBA)
+	if !ok {
+		t.Fatalf("ImageOpSource returned %T, want *image.RGBA", img)
+	}
+	if c := rgba.RGBAAt(20, 20); c.B < 200 {
+		t.Errorf("pixel = %v, want blue", c)
+	}
+
+	// Drawing afterwards must not mutate the returned copy.
+	p.Draw(func(cc *gg.Context) {
+		cc.SetRGB(1, 0, 0)
+		cc.DrawRectangle(0, 0, 40, 40)
+		cc.Fill()
+	})
+	if c := rgba.RGBAAt(20, 20); c.B < 200 {
+		t.Errorf("copy mutated after Draw: pixel = %v, want blue", c)
+	}
+
+	p.Close()
+	if p.ImageOpSource() != nil {
+		t.Error("ImageOpSource after Close should be nil")
+	}
+}
+
+// TestRasterFunc verifies the Fyne adapter resizes and renders.
+func TestRasterFunc(t *testing.T) {
+	p, err := New(10, 10)
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	defer p.Close()
+
+	gen := p.RasterFunc(func(cc *gg.Context) {
+		cc.SetRGB(0, 1, 0)
+		cc.DrawRectangle(0, 0, float64(cc.Width()), float64(cc.Height()))
+		cc.Fill()
+	})
+
+	img := gen(64, 48)
+	if img.Bounds().Dx() != 64 || img.Bounds().Dy() != 48 {
+		t.Errorf("generated bounds = %v, want 64x48", img.Bounds())
+	}
+	w, h := p.Size()
+	if w != 64 || h != 48 {
+		t.Errorf("provider resized to %dx%d, want 64x48", w, h)
+	}
+	if c := img.(*image.RGBA).RGBAAt(32, 24); c.G < 200 {
+		t.Errorf("pixel = %v, want green", c)
+	}
+}
+
+// TestRasterFuncDegenerateSize verifies tolerance for layout-time sizes.
+func TestRasterFuncDegenerateSize(t *testing.T) {
+	p, err := New(10, 10)
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	defer p.Close()
+
+	gen := p.RasterFunc(nil)
+	if img := gen(0, 0); !img.Bounds().Empty() {
+		t.Errorf("gen(0, 0) bounds = %v, want empty", img.Bounds())
+	}
+
+	p.Close()
+	if img := gen(5, 5); !img.Bounds().Empty() {
+		t.Errorf("gen after Close bounds = %v, want empty", img.Bounds())
+	}
+}
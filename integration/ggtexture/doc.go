@@ -0,0 +1,48 @@
+// Copyright 2026 The gogpu Authors
+// SPDX-License-Identifier: MIT
+
+// Package ggtexture exposes gg rendering as a generic texture provider so
+// UI frameworks can embed gg drawings without gg depending on them.
+//
+// A Provider wraps a gg.Context and hands out frames: the current RGBA
+// pixel data plus the dirty rectangles accumulated since the previous
+// frame (and, when a GPU accelerator produced one, an opaque texture
+// handle). Host frameworks poll Frame() from their own render callback
+// and upload or composite only what changed.
+//
+// # Architecture
+//
+//	Provider.Draw (gg API) -> dirty rect accumulation -> Frame()
+//	                                                      |-> RGBA + dirty rects (CPU upload)
+//	                                                      |-> texture handle (GPU compositing)
+//
+// # Adapters
+//
+// The package ships adapters for common frameworks, implemented purely in
+// terms of the standard library so gg gains no new dependencies:
+//
+//   - Gio: Frame().RGBA is a plain *image.RGBA and can be passed directly
+//     to paint.NewImageOp. See Provider.ImageOpSource.
+//   - Fyne: Provider.RasterFunc returns a generator compatible with
+//     canvas.NewRaster, resizing the provider to the widget's pixel size.
+//
+// # Usage
+//
+// Gio:
+//
+//	provider, _ := ggtexture.New(800, 600)
+//	provider.Draw(func(cc *gg.Context) { ... })
+//	frame, _ := provider.Frame()
+//	op := paint.NewImageOp(frame.RGBA)
+//
+// Fyne:
+//
+//	provider, _ := ggtexture.New(800, 600)
+//	raster := canvas.NewRaster(provider.RasterFunc(func(cc *gg.Context) { ... }))
+//
+// # Thread Safety
+//
+// Provider is NOT safe for concurrent use. Frameworks that render on a
+// dedicated goroutine (both Gio and Fyne do) should confine the provider
+// to that goroutine.
+package ggtexture
@@ -0,0 +1,190 @@
+// Copyright 2026 The gogpu Authors
+// SPDX-License-Identifier: MIT
+
+package ggtexture
+
+import (
+	"errors"
+	"fmt"
+	"image"
+
+	"github.com/gogpu/gg"
+)
+
+// Common errors returned by Provider operations.
+var (
+	// ErrProviderClosed is returned when operations are attempted on a closed provider.
+	ErrProviderClosed = errors.New("ggtexture: provider is closed")
+
+	// ErrInvalidDimensions is returned when width or height is invalid.
+	ErrInvalidDimensions = errors.New("ggtexture: invalid dimensions")
+)
+
+// Frame is one rendered frame handed to the host framework.
+type Frame struct {
+	// RGBA is the frame's pixel data. The buffer aliases the provider's
+	// internal pixmap: it stays valid until the next Draw or Resize and
+	// must not be modified by the caller.
+	RGBA *image.RGBA
+
+	// DirtyRects lists the regions (in pixel coordinates) that changed
+	// since the previous Frame call. Nil means nothing changed; hosts
+	// may skip the upload entirely. A single full-surface rect is
+	// reported after creation and after Resize.
+	DirtyRects []image.Rectangle
+
+	// Texture is an opaque GPU texture handle when the provider's context
+	// rendered through a GPU accelerator, or nil for CPU frames. Hosts
+	// that share a GPU device can composite it directly instead of
+	// uploading RGBA.
+	Texture any
+}
+
+// TextureProvider is the interface host frameworks consume. Provider is
+// the canonical implementation; embedders with their own render pipeline
+// can substitute one that sources frames elsewhere.
+type TextureProvider interface {
+	// Frame returns the current frame. Dirty rects are consumed: a
+	// second call without intervening drawing reports no damage.
+	Frame() (Frame, error)
+
+	// Size returns the provider dimensions in pixels.
+	Size() (width, height int)
+
+	// Close releases the provider's resources.
+	Close() error
+}
+
+// Provider renders with a gg.Context and exposes the result as frames.
+//
+// Provider is NOT safe for concurrent use.
+type Provider struct {
+	ctx     *gg.Context
+	width   int
+	height  int
+	dirty   []image.Rectangle
+	texture any
+	closed  bool
+}
+
+// New creates a Provider with the given pixel dimensions.
+// Returns an error if dimensions are invalid.
+func New(width, height int) (*Provider, error) {
+	if width <= 0 || height <= 0 {
+		return nil, fmt.Errorf("%w: width=%d, height=%d", ErrInvalidDimensions, width, height)
+	}
+	p := &Provider{
+		ctx:    gg.NewContext(width, height),
+		width:  width,
+		height: height,
+	}
+	p.markAllDirty()
+	return p, nil
+}
+
+// Context returns the gg drawing context.
+// Prefer Draw, which also tracks damage; use Context for configuration
+// (fonts, device scale) that should persist across frames.
+// Returns nil if the provider is closed.
+func (p *Provider) Context() *gg.Context {
+	if p.closed {
+		return nil
+	}
+	return p.ctx
+}
+
+// Size returns the provider dimensions in pixels.
+func (p *Provider) Size() (width, height int) {
+	return p.width, p.height
+}
+
+// Draw calls fn with the gg context and accumulates the damage it caused
+// into the next frame's dirty rects. Per-frame state (matrix, path) is
+// restored after fn returns; configuration state persists.
+func (p *Provider) Draw(fn func(*gg.Context)) error {
+	if p.closed {
+		return ErrProviderClosed
+	}
+	p.ctx.Push()
+	p.ctx.Identity()
+	p.ctx.ClearPath()
+	p.ctx.ResetFrameDamage()
+	fn(p.ctx)
+	p.ctx.Pop()
+	p.dirty = append(p.dirty, p.ctx.FrameDamage()...)
+	p.ctx.ResetFrameDamage()
+	return nil
+}
+
+// Frame returns the current frame and consumes the accumulated dirty
+// rects. The returned RGBA aliases the provider's pixmap; see Frame.RGBA.
+func (p *Provider) Frame() (Frame, error) {
+	if p.closed {
+		return Frame{}, ErrProviderClosed
+	}
+	// Flush pending GPU shapes into the pixmap before handing it out.
+	// Non-fatal: CPU rendering may already be complete.
+	if err := p.ctx.FlushGPU(); err != nil {
+		gg.Logger().Warn("ggtexture: FlushGPU failed", "err", err)
+	}
+	f := Frame{
+		RGBA:       p.ctx.ResizeTarget().ImageView(),
+		DirtyRects: p.dirty,
+		Texture:    p.texture,
+	}
+	p.dirty = nil
+	return f, nil
+}
+
+// SetTexture attaches an opaque GPU texture handle to subsequent frames.
+// Embedders that upload the pixmap themselves (e.g. through ggcanvas) can
+// expose the resulting texture here so hosts composite it directly.
+// Pass nil to revert to CPU frames.
+func (p *Provider) SetTexture(tex any) {
+	p.texture = tex
+}
+
+// Resize changes the provider dimensions. The content is cleared and the
+// next frame reports full-surface damage.
+// Returns an error if dimensions are invalid or the provider is closed.
+func (p *Provider) Resize(width, height int) error {
+	if p.closed {
+		return ErrProviderClosed
+	}
+	if width <= 0 || height <= 0 {
+		return fmt.Errorf("%w: width=%d, height=%d", ErrInvalidDimensions, width, height)
+	}
+	if width == p.width && height == p.height {
+		return nil
+	}
+	if err := p.ctx.Resize(width, height); err != nil {
+		return fmt.Errorf("ggtexture: context resize failed: %w", err)
+	}
+	p.width = width
+	p.height = height
+	p.markAllDirty()
+	return nil
+}
+
+// Close releases the provider's resources.
+// Close is idempotent; multiple calls are safe.
+func (p *Provider) Close() error {
+	if p.closed {
+		return nil
+	}
+	p.closed = true
+	p.dirty = nil
+	if p.ctx != nil {
+		_ = p.ctx.Close()
+		p.ctx = nil
+	}
+	return nil
+}
+
+// markAllDirty replaces the accumulated dirty rects with one full-surface rect.
+func (p *Provider) markAllDirty() {
+	p.dirty = []image.Rectangle{image.Rect(0, 0, p.ctx.PixelWidth(), p.ctx.PixelHeight())}
+}
+
+// Verify Provider implements TextureProvider.
+var _ TextureProvider = (*Provider)(nil)
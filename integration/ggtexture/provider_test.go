@@ -0,0 +1,165 @@
+// Copyright 2026 The gogpu Authors
+// SPDX-License-Identifier: MIT
+
+package ggtexture
+
+import (
+	"image"
+	"testing"
+
+	"github.com/gogpu/gg"
+)
+
+// TestNew tests provider creation and initial full-surface damage.
+func TestNew(t *testing.T) {
+	p, err := New(100, 80)
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	defer p.Close()
+
+	w, h := p.Size()
+	if w != 100 || h != 80 {
+		t.Errorf("Size() = %dx%d, want 100x80", w, h)
+	}
+
+	frame, err := p.Frame()
+	if err != nil {
+		t.Fatalf("Frame: %v", err)
+	}
+	want := image.Rect(0, 0, 100, 80)
+	if len(frame.DirtyRects) != 1 || frame.DirtyRects[0] != want {
+		t.Errorf("initial DirtyRects = %v, want [%v]", frame.DirtyRects, want)
+	}
+	if frame.RGBA == nil || frame.RGBA.Bounds() != want {
+		t.Errorf("RGBA bounds = %v, want %v", frame.RGBA.Bounds(), want)
+	}
+}
+
+// TestNewInvalidDimensions tests argument validation.
+func TestNewInvalidDimensions(t *testing.T) {
+	if _, err := New(0, 10); err == nil {
+		t.Error("New(0, 10) should fail")
+	}
+	if _, err := New(10, -1); err == nil {
+		t.Error("New(10, -1) should fail")
+	}
+}
+
+// TestFrameConsumesDirtyRects verifies dirty rects are one-shot.
+func TestFrameConsumesDirtyRects(t *testing.T) {
+	p, err := New(50, 50)
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	defer p.Close()
+
+	if _, err := p.Frame(); err != nil {
+		t.Fatalf("Frame: %v", err)
+	}
+
+	frame, err := p.Frame()
+	if err != nil {
+		t.Fatalf("Frame: %v", err)
+	}
+	if frame.DirtyRects != nil {
+		t.Errorf("second Frame DirtyRects = %v, want nil", frame.DirtyRects)
+	}
+}
+
+// TestDrawAccumulatesDamage verifies Draw damage reaches the next frame.
+func TestDrawAccumulatesDamage(t *testing.T) {
+	p, err := New(100, 100)
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	defer p.Close()
+
+	p.Frame() // consume creation damage
+
+	err = p.Draw(func(cc *gg.Context) {
+		cc.SetRGB(1, 0, 0)
+		cc.DrawRectangle(10, 10, 20, 20)
+		cc.Fill()
+	})
+	if err != nil {
+		t.Fatalf("Draw: %v", err)
+	}
+
+	frame, err := p.Frame()
+	if err != nil {
+		t.Fatalf("Frame: %v", err)
+	}
+	if len(frame.DirtyRects) == 0 {
+		t.Fatal("Draw produced no dirty rects")
+	}
+	var union image.Rectangle
+	for _, r := range frame.DirtyRects {
+		union = union.Union(r)
+	}
+	drawn := image.Rect(10, 10, 30, 30)
+	if !drawn.In(union) {
+		t.Errorf("dirty union %v does not cover drawn rect %v", union, drawn)
+	}
+
+	// Pixels rendered.
+	c := frame.RGBA.RGBAAt(20, 20)
+	if c.R < 200 {
+		t.Errorf("pixel = %v, want red", c)
+	}
+}
+
+// TestResizeMarksFullDamage verifies resize reports full-surface damage.
+func TestResizeMarksFullDamage(t *testing.T) {
+	p, err := New(50, 50)
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	defer p.Close()
+
+	p.Frame()
+
+	if err := p.Resize(80, 60); err != nil {
+		t.Fatalf("Resize: %v", err)
+	}
+	w, h := p.Size()
+	if w != 80 || h != 60 {
+		t.Errorf("Size() = %dx%d, want 80x60", w, h)
+	}
+
+	frame, err := p.Frame()
+	if err != nil {
+		t.Fatalf("Frame: %v", err)
+	}
+	want := image.Rect(0, 0, 80, 60)
+	if len(frame.DirtyRects) != 1 || frame.DirtyRects[0] != want {
+		t.Errorf("DirtyRects = %v, want [%v]", frame.DirtyRects, want)
+	}
+}
+
+// TestProviderClosed verifies operations after Close.
+func TestProviderClosed(t *testing.T) {
+	p, err := New(10, 10)
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	if err := p.Close(); err != nil {
+		t.Errorf("Close: %v", err)
+	}
+	if err := p.Close(); err != nil {
+		t.Errorf("double Close: %v", err)
+	}
+
+	if _, err := p.Frame(); err != ErrProviderClosed {
+		t.Errorf("Frame after Close: err = %v, want ErrProviderClosed", err)
+	}
+	if err := p.Draw(func(*gg.Context) {}); err != ErrProviderClosed {
+		t.Errorf("Draw after Close: err = %v, want ErrProviderClosed", err)
+	}
+	if err := p.Resize(20, 20); err != ErrProviderClosed {
+		t.Errorf("Resize after Close: err = %v, want ErrProviderClosed", err)
+	}
+	if p.Context() != nil {
+		t.Error("Context after Close should be nil")
+	}
+}
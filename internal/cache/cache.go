@@ -203,4 +203,6 @@ type Stats struct {
 	HitRate float64
 	// Evictions is the number of evicted entries (ShardedCache only).
 	Evictions uint64
+	// Cost is the total cost of cached entries (ShardedCache only).
+	Cost int64
 }
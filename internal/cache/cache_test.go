@@ -1,9 +1,12 @@
 package cache
 
 import (
+	"errors"
 	"strconv"
 	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
 )
 
 func TestNew(t *testing.T) {
@@ -403,6 +406,314 @@ func TestShardedCacheConcurrent(t *testing.T) {
 	}
 }
 
+func TestShardedCacheOnEvict(t *testing.T) {
+	c := NewSharded[int, int](2, func(k int) uint64 { return 0 }) // single shard
+
+	var evictedKeys []int
+	var evictedVals []int
+	c.SetOnEvict(func(k, v int) {
+		evictedKeys = append(evictedKeys, k)
+		evictedVals = append(evictedVals, v)
+	})
+
+	c.Set(1, 10)
+	c.Set(2, 20)
+	c.Set(3, 30) // evicts 1
+
+	if len(evictedKeys) != 1 || evictedKeys[0] != 1 || evictedVals[0] != 10 {
+		t.Errorf("expected eviction of (1, 10), got keys %v vals %v", evictedKeys, evictedVals)
+	}
+
+	// Delete and Clear do not invoke the callback.
+	c.Delete(2)
+	c.Clear()
+	if len(evictedKeys) != 1 {
+		t.Errorf("expected no callback for Delete/Clear, got %v", evictedKeys)
+	}
+}
+
+func TestShardedCacheEvictOldest(t *testing.T) {
+	c := NewSharded[int, int](10, func(k int) uint64 { return 0 }) // single shard
+
+	evicted := 0
+	c.SetOnEvict(func(k, v int) { evicted++ })
+
+	for i := 0; i < 5; i++ {
+		c.Set(i, i)
+	}
+
+	if n := c.EvictOldest(2); n != 2 {
+		t.Errorf("expected EvictOldest to remove 2, got %d", n)
+	}
+	if c.Len() != 3 {
+		t.Errorf("expected 3 entries left, got %d", c.Len())
+	}
+	if evicted != 2 {
+		t.Errorf("expected 2 eviction callbacks, got %d", evicted)
+	}
+
+	// Oldest entries (0 and 1) were removed.
+	if _, ok := c.Get(0); ok {
+		t.Error("expected key 0 to be evicted")
+	}
+	if _, ok := c.Get(4); !ok {
+		t.Error("expected key 4 to survive")
+	}
+
+	// Evicting more than present removes everything and stops.
+	if n := c.EvictOldest(10); n != 3 {
+		t.Errorf("expected EvictOldest to remove 3, got %d", n)
+	}
+	if n := c.EvictOldest(1); n != 0 {
+		t.Errorf("expected EvictOldest on empty cache to remove 0, got %d", n)
+	}
+}
+
+// sizedValue implements Sizer for cost accounting tests.
+type sizedValue struct {
+	size int64
+}
+
+func (v sizedValue) SizeBytes() int64 { return v.size }
+
+func TestShardedCacheCostBudget(t *testing.T) {
+	c := NewSharded[int, int](100, func(k int) uint64 { return 0 }) // single shard
+	c.SetCostBudget(100)
+
+	evicted := 0
+	c.SetOnEvict(func(k, v int) { evicted++ })
+
+	for i := 0; i < 10; i++ {
+		c.SetWithCost(i, i, 30)
+		if cost := c.Cost(); cost > 100 {
+			t.Fatalf("after insert %d: cost = %d exceeds budget 100", i, cost)
+		}
+	}
+
+	if c.Len() != 3 {
+		t.Errorf("expected 3 entries within budget, got %d", c.Len())
+	}
+	if evicted != 7 {
+		t.Errorf("expected 7 cost evictions, got %d", evicted)
+	}
+
+	// Most recently inserted entries survive.
+	if _, ok := c.Get(9); !ok {
+		t.Error("expected newest entry to survive")
+	}
+	if _, ok := c.Get(0); ok {
+		t.Error("expected oldest entry to be evicted")
+	}
+}
+
+func TestShardedCacheCostOversizedEntry(t *testing.T) {
+	c := NewSharded[string, int](10, StringHasher)
+	c.SetCostBudget(50)
+
+	// A single entry over the budget still caches.
+	c.SetWithCost("huge", 1, 500)
+	if _, ok := c.Get("huge"); !ok {
+		t.Error("expected oversized entry to remain cached")
+	}
+
+	// The next insertion evicts it.
+	c.SetWithCost("small", 2, 10)
+	if c.Cost() > 50 {
+		t.Errorf("cost = %d, want <= 50", c.Cost())
+	}
+}
+
+func TestShardedCacheCostAccounting(t *testing.T) {
+	c := NewSharded[string, int](10, StringHasher)
+
+	c.SetWithCost("a", 1, 40)
+	c.SetWithCost("b", 2, 25)
+	if c.Cost() != 65 {
+		t.Errorf("cost = %d, want 65", c.Cost())
+	}
+
+	// Updating an entry replaces its cost.
+	c.SetWithCost("a", 3, 10)
+	if c.Cost() != 35 {
+		t.Errorf("cost after update = %d, want 35", c.Cost())
+	}
+
+	// Delete and Clear release cost.
+	c.Delete("a")
+	if c.Cost() != 25 {
+		t.Errorf("cost after delete = %d, want 25", c.Cost())
+	}
+	c.Clear()
+	if c.Cost() != 0 {
+		t.Errorf("cost after clear = %d, want 0", c.Cost())
+	}
+
+	if stats := c.Stats(); stats.Cost != 0 {
+		t.Errorf("stats cost = %d, want 0", stats.Cost)
+	}
+}
+
+func TestShardedCacheSizer(t *testing.T) {
+	c := NewSharded[string, sizedValue](10, StringHasher)
+
+	// Set and GetOrCreate cost Sizer values automatically.
+	c.Set("a", sizedValue{size: 100})
+	c.GetOrCreate("b", func() sizedValue { return sizedValue{size: 50} })
+	if c.Cost() != 150 {
+		t.Errorf("cost = %d, want 150", c.Cost())
+	}
+}
+
+func TestShardedCacheTTL(t *testing.T) {
+	c := NewSharded[string, int](10, StringHasher)
+	c.SetTTL(50 * time.Millisecond)
+
+	expired := 0
+	c.SetOnEvict(func(k string, v int) { expired++ })
+
+	c.Set("key", 42)
+
+	// Fresh entry is a hit.
+	if _, ok := c.Get("key"); !ok {
+		t.Fatal("expected hit before TTL elapses")
+	}
+
+	time.Sleep(100 * time.Millisecond)
+
+	// Expired entry is a miss and is removed.
+	if _, ok := c.Get("key"); ok {
+		t.Error("expected miss after TTL elapsed")
+	}
+	if c.Len() != 0 {
+		t.Errorf("expected expired entry to be removed, len = %d", c.Len())
+	}
+	if expired != 1 {
+		t.Errorf("expected 1 eviction callback for expiry, got %d", expired)
+	}
+	if stats := c.Stats(); stats.Evictions != 1 {
+		t.Errorf("expected 1 eviction in stats, got %d", stats.Evictions)
+	}
+}
+
+func TestShardedCacheTTLRefresh(t *testing.T) {
+	c := NewSharded[string, int](10, StringHasher)
+	c.SetTTL(150 * time.Millisecond)
+
+	c.Set("key", 1)
+	time.Sleep(80 * time.Millisecond)
+
+	// Updating the entry refreshes its deadline.
+	c.Set("key", 2)
+	time.Sleep(80 * time.Millisecond)
+
+	if v, ok := c.Get("key"); !ok || v != 2 {
+		t.Errorf("expected refreshed entry to survive, got (%d, %v)", v, ok)
+	}
+}
+
+func TestShardedCacheGetOrCreateExpired(t *testing.T) {
+	c := NewSharded[string, int](10, StringHasher)
+	c.SetTTL(50 * time.Millisecond)
+
+	c.GetOrCreate("key", func() int { return 1 })
+	time.Sleep(100 * time.Millisecond)
+
+	// The expired entry must be recreated, not returned.
+	if v := c.GetOrCreate("key", func() int { return 2 }); v != 2 {
+		t.Errorf("expected recreated value 2, got %d", v)
+	}
+}
+
+func TestShardedCacheGetOrCompute(t *testing.T) {
+	c := NewSharded[string, int](10, StringHasher)
+
+	computes := 0
+	compute := func() (int, error) {
+		computes++
+		return 42, nil
+	}
+
+	v, err := c.GetOrCompute("key", compute)
+	if err != nil || v != 42 {
+		t.Fatalf("GetOrCompute = (%d, %v), want (42, nil)", v, err)
+	}
+
+	// Second call hits the cache.
+	v, err = c.GetOrCompute("key", compute)
+	if err != nil || v != 42 {
+		t.Fatalf("GetOrCompute = (%d, %v), want (42, nil)", v, err)
+	}
+	if computes != 1 {
+		t.Errorf("compute called %d times, want 1", computes)
+	}
+}
+
+func TestShardedCacheGetOrComputeError(t *testing.T) {
+	c := NewSharded[string, int](10, StringHasher)
+	wantErr := errors.New("compute failed")
+
+	_, err := c.GetOrCompute("key", func() (int, error) { return 0, wantErr })
+	if err != wantErr {
+		t.Fatalf("expected compute error, got %v", err)
+	}
+
+	// Errors are not cached; a later call retries.
+	v, err := c.GetOrCompute("key", func() (int, error) { return 7, nil })
+	if err != nil || v != 7 {
+		t.Errorf("retry = (%d, %v), want (7, nil)", v, err)
+	}
+}
+
+func TestShardedCacheGetOrComputeSingleflight(t *testing.T) {
+	c := NewSharded[string, int](10, StringHasher)
+
+	var computes atomic.Int32
+	release := make(chan struct{})
+	started := make(chan struct{})
+
+	var wg sync.WaitGroup
+	results := make([]int, 10)
+
+	// First goroutine starts the computation and blocks.
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		v, _ := c.GetOrCompute("key", func() (int, error) {
+			computes.Add(1)
+			close(started)
+			<-release
+			return 99, nil
+		})
+		results[0] = v
+	}()
+	<-started
+
+	// The rest must wait for the in-flight call instead of recomputing.
+	for i := 1; i < 10; i++ {
+		wg.Add(1)
+		go func(n int) {
+			defer wg.Done()
+			v, _ := c.GetOrCompute("key", func() (int, error) {
+				computes.Add(1)
+				return -1, nil
+			})
+			results[n] = v
+		}(i)
+	}
+
+	close(release)
+	wg.Wait()
+
+	if got := computes.Load(); got != 1 {
+		t.Errorf("compute called %d times, want 1", got)
+	}
+	for i, v := range results {
+		if v != 99 {
+			t.Errorf("goroutine %d got %d, want 99", i, v)
+		}
+	}
+}
+
 func TestHashers(t *testing.T) {
 	// Test StringHasher
 	h1 := StringHasher("hello")
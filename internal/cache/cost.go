@@ -0,0 +1,63 @@
+package cache
+
+// Sizer estimates the memory footprint of a cached value. Values stored
+// via Set or GetOrCreate that implement Sizer are costed automatically;
+// glyph bitmaps and GPU textures report their pixel data size here so
+// cost-based eviction can compare entries of wildly different sizes.
+type Sizer interface {
+	// SizeBytes returns the estimated size of the value in bytes.
+	SizeBytes() int64
+}
+
+// valueCost returns the cost of a value: its SizeBytes when it
+// implements Sizer, zero otherwise.
+func valueCost[V any](value V) int64 {
+	if s, ok := any(value).(Sizer); ok {
+		if cost := s.SizeBytes(); cost > 0 {
+			return cost
+		}
+	}
+	return 0
+}
+
+// SetCostBudget caps the total cost of cached entries. When an insertion
+// pushes the total over the budget, least recently used entries are
+// evicted (across shards) until it fits, regardless of entry count.
+// A budget of 0 (the default) disables cost-based eviction.
+//
+// Entries without a cost — values that neither implement Sizer nor are
+// stored via SetWithCost — count as zero and are never evicted by cost.
+//
+// Must be set before the cache is used concurrently.
+func (c *ShardedCache[K, V]) SetCostBudget(budget int64) {
+	if budget < 0 {
+		budget = 0
+	}
+	c.costBudget = budget
+}
+
+// Cost returns the total cost of all cached entries.
+func (c *ShardedCache[K, V]) Cost() int64 {
+	return c.cost.Load()
+}
+
+// CostBudget returns the cost budget, or 0 when cost-based eviction is
+// disabled.
+func (c *ShardedCache[K, V]) CostBudget() int64 {
+	return c.costBudget
+}
+
+// enforceCostBudget evicts LRU entries until the total cost fits the
+// budget. Must be called without holding any shard lock. Evicting one
+// entry at a time keeps the accounting exact; the last remaining entry
+// is never evicted, so a single over-budget value still caches.
+func (c *ShardedCache[K, V]) enforceCostBudget() {
+	if c.costBudget <= 0 {
+		return
+	}
+	for c.cost.Load() > c.costBudget && c.Len() > 1 {
+		if c.EvictOldest(1) == 0 {
+			break
+		}
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"hash/fnv"
 	"sync"
 	"sync/atomic"
+	"time"
 )
 
 // Default configuration constants.
@@ -73,6 +74,24 @@ type ShardedCache[K comparable, V any] struct {
 	hits      atomic.Uint64
 	misses    atomic.Uint64
 	evictions atomic.Uint64
+
+	// onEvict, if set, is called for each entry evicted by capacity
+	// pressure (Set, GetOrCreate, EvictOldest) or TTL expiry. Not called
+	// for explicit Delete or Clear. Invoked after the shard lock is
+	// released.
+	onEvict func(key K, value V)
+
+	// ttl is the entry lifetime; 0 means entries never expire.
+	ttl time.Duration
+
+	// Cost accounting (see SetCostBudget). cost is the total cost of all
+	// cached entries; costBudget of 0 means no cost limit.
+	cost       atomic.Int64
+	costBudget int64
+
+	// Singleflight state for GetOrCompute.
+	flightMu    sync.Mutex
+	flightCalls map[K]*inflightCall[V]
 }
 
 // shardedCacheShard is a single shard of the cache.
@@ -87,6 +106,19 @@ type shardedCacheShard[K comparable, V any] struct {
 type shardedCacheEntry[K comparable, V any] struct {
 	value V
 	node  *lruNode[K]
+
+	// expiresAt is the expiry deadline in unix nanoseconds; 0 means the
+	// entry never expires.
+	expiresAt int64
+
+	// cost is the entry's contribution to the cache's total cost.
+	cost int64
+}
+
+// expiredAt reports whether the entry has expired at the given time
+// (unix nanoseconds).
+func (e *shardedCacheEntry[K, V]) expiredAt(now int64) bool {
+	return e.expiresAt > 0 && now > e.expiresAt
 }
 
 // NewSharded creates a new sharded cache with the specified capacity per shard.
@@ -102,8 +134,9 @@ func NewSharded[K comparable, V any](capacity int, hasher Hasher[K]) *ShardedCac
 	}
 
 	c := &ShardedCache[K, V]{
-		hasher:   hasher,
-		capacity: capacity,
+		hasher:      hasher,
+		capacity:    capacity,
+		flightCalls: make(map[K]*inflightCall[V]),
 	}
 
 	for i := range c.shards {
@@ -152,6 +185,13 @@ func (c *ShardedCache[K, V]) Get(key K) (V, bool) {
 		var zero V
 		return zero, false
 	}
+	if expired := c.removeIfExpired(shard, key, entry); expired != nil {
+		shard.mu.Unlock()
+		c.notifyEvicted(expired)
+		c.misses.Add(1)
+		var zero V
+		return zero, false
+	}
 	shard.lru.MoveToFront(entry.node)
 	value := entry.value
 	shard.mu.Unlock()
@@ -160,41 +200,89 @@ func (c *ShardedCache[K, V]) Get(key K) (V, bool) {
 	return value, true
 }
 
+// removeIfExpired removes the entry when its TTL has elapsed. Must be
+// called with the shard lock held. Returns the removed entry for the
+// eviction callback (non-nil means expired, possibly empty when no
+// callback is registered).
+func (c *ShardedCache[K, V]) removeIfExpired(
+	shard *shardedCacheShard[K, V],
+	key K,
+	entry *shardedCacheEntry[K, V],
+) []evictedEntry[K, V] {
+	if entry.expiresAt == 0 || !entry.expiredAt(time.Now().UnixNano()) {
+		return nil
+	}
+	shard.lru.Remove(entry.node)
+	delete(shard.entries, key)
+	c.cost.Add(-entry.cost)
+	c.evictions.Add(1)
+	if c.onEvict == nil {
+		return []evictedEntry[K, V]{}
+	}
+	return []evictedEntry[K, V]{{key: key, value: entry.value}}
+}
+
+// entryDeadline returns the expiry deadline for a new or updated entry,
+// or 0 when no TTL is configured.
+func (c *ShardedCache[K, V]) entryDeadline() int64 {
+	if c.ttl <= 0 {
+		return 0
+	}
+	return time.Now().Add(c.ttl).UnixNano()
+}
+
 // Set stores a value in the cache.
 // If the shard exceeds capacity after insertion, oldest entries are evicted.
 //
 // The value is stored as-is (not copied). Callers should not modify it
 // after caching.
+//
+// The entry cost is taken from the value when it implements Sizer, and
+// is zero otherwise; use SetWithCost for an explicit cost.
 func (c *ShardedCache[K, V]) Set(key K, value V) {
+	c.SetWithCost(key, value, valueCost(value))
+}
+
+// SetWithCost stores a value with an explicit cost (typically its size
+// in bytes). The cost counts toward the budget set with SetCostBudget.
+// Negative costs are treated as zero.
+func (c *ShardedCache[K, V]) SetWithCost(key K, value V, cost int64) {
+	if cost < 0 {
+		cost = 0
+	}
 	shard := c.getShard(key)
 
 	shard.mu.Lock()
-	defer shard.mu.Unlock()
 
 	// Check if entry already exists
 	if existing, ok := shard.entries[key]; ok {
-		// Update existing entry
+		// Update existing entry (refreshes the TTL, if any)
+		c.cost.Add(cost - existing.cost)
 		existing.value = value
+		existing.cost = cost
+		existing.expiresAt = c.entryDeadline()
 		shard.lru.MoveToFront(existing.node)
+		shard.mu.Unlock()
+		c.enforceCostBudget()
 		return
 	}
 
 	// Evict if at capacity
-	for shard.lru.Len() >= c.capacity {
-		if oldest, ok := shard.lru.RemoveOldest(); ok {
-			delete(shard.entries, oldest)
-			c.evictions.Add(1)
-		} else {
-			break
-		}
-	}
+	evicted := c.evictForCapacity(shard)
 
 	// Add new entry
 	node := shard.lru.PushFront(key)
 	shard.entries[key] = &shardedCacheEntry[K, V]{
-		value: value,
-		node:  node,
+		value:     value,
+		node:      node,
+		expiresAt: c.entryDeadline(),
+		cost:      cost,
 	}
+	c.cost.Add(cost)
+	shard.mu.Unlock()
+
+	c.notifyEvicted(evicted)
+	c.enforceCostBudget()
 }
 
 // GetOrCreate returns a cached value or creates it using the provided function.
@@ -214,51 +302,166 @@ func (c *ShardedCache[K, V]) GetOrCreate(key K, create func() V) V {
 		// Update LRU (requires write lock)
 		shard.mu.Lock()
 		if entry, ok := shard.entries[key]; ok {
-			shard.lru.MoveToFront(entry.node)
-			value := entry.value
+			if expired := c.removeIfExpired(shard, key, entry); expired != nil {
+				shard.mu.Unlock()
+				c.notifyEvicted(expired)
+			} else {
+				shard.lru.MoveToFront(entry.node)
+				value := entry.value
+				shard.mu.Unlock()
+				c.hits.Add(1)
+				return value
+			}
+		} else {
 			shard.mu.Unlock()
-			c.hits.Add(1)
-			return value
 		}
-		shard.mu.Unlock()
 	}
 
 	// Slow path: create new entry
 	shard.mu.Lock()
-	defer shard.mu.Unlock()
 
 	// Re-check after acquiring write lock
+	var expired []evictedEntry[K, V]
 	if entry, ok := shard.entries[key]; ok {
-		shard.lru.MoveToFront(entry.node)
-		c.hits.Add(1)
-		return entry.value
+		expired = c.removeIfExpired(shard, key, entry)
+		if expired == nil {
+			shard.lru.MoveToFront(entry.node)
+			value := entry.value
+			shard.mu.Unlock()
+			c.hits.Add(1)
+			return value
+		}
 	}
 
 	c.misses.Add(1)
 
 	// Create new value (under lock)
 	value := create()
+	cost := valueCost(value)
 
 	// Evict if at capacity
-	for shard.lru.Len() >= c.capacity {
-		if oldest, ok := shard.lru.RemoveOldest(); ok {
-			delete(shard.entries, oldest)
-			c.evictions.Add(1)
-		} else {
-			break
-		}
-	}
+	evicted := c.evictForCapacity(shard)
+	evicted = append(expired, evicted...)
 
 	// Add new entry
 	node := shard.lru.PushFront(key)
 	shard.entries[key] = &shardedCacheEntry[K, V]{
-		value: value,
-		node:  node,
+		value:     value,
+		node:      node,
+		expiresAt: c.entryDeadline(),
+		cost:      cost,
 	}
+	c.cost.Add(cost)
+	shard.mu.Unlock()
 
+	c.notifyEvicted(evicted)
+	c.enforceCostBudget()
 	return value
 }
 
+// evictedEntry captures an entry removed under the shard lock so the
+// eviction callback can run after the lock is released.
+type evictedEntry[K comparable, V any] struct {
+	key   K
+	value V
+}
+
+// evictForCapacity removes oldest entries while the shard is at capacity.
+// Must be called with the shard lock held; returns the removed entries
+// (only collected when an eviction callback is registered).
+func (c *ShardedCache[K, V]) evictForCapacity(shard *shardedCacheShard[K, V]) []evictedEntry[K, V] {
+	var evicted []evictedEntry[K, V]
+	for shard.lru.Len() >= c.capacity {
+		oldest, ok := shard.lru.RemoveOldest()
+		if !ok {
+			break
+		}
+		if entry, found := shard.entries[oldest]; found {
+			c.cost.Add(-entry.cost)
+			if c.onEvict != nil {
+				evicted = append(evicted, evictedEntry[K, V]{key: oldest, value: entry.value})
+			}
+		}
+		delete(shard.entries, oldest)
+		c.evictions.Add(1)
+	}
+	return evicted
+}
+
+// notifyEvicted invokes the eviction callback for removed entries.
+// Must be called without holding any shard lock — the callback may touch
+// the cache.
+func (c *ShardedCache[K, V]) notifyEvicted(evicted []evictedEntry[K, V]) {
+	if c.onEvict == nil {
+		return
+	}
+	for _, e := range evicted {
+		c.onEvict(e.key, e.value)
+	}
+}
+
+// SetOnEvict registers a callback invoked for each entry evicted by
+// capacity pressure (Set, GetOrCreate, EvictOldest) or TTL expiry.
+// Explicit Delete and Clear do not trigger it. The callback runs outside
+// the shard lock, so it may safely access the cache.
+//
+// Must be set before the cache is used concurrently.
+func (c *ShardedCache[K, V]) SetOnEvict(fn func(key K, value V)) {
+	c.onEvict = fn
+}
+
+// SetTTL sets the lifetime of cache entries. Entries older than ttl are
+// treated as misses and removed lazily on access; an update via Set
+// refreshes the deadline. A ttl of 0 (the default) disables expiration.
+// Already cached entries keep their original deadline.
+//
+// Must be set before the cache is used concurrently.
+func (c *ShardedCache[K, V]) SetTTL(ttl time.Duration) {
+	if ttl < 0 {
+		ttl = 0
+	}
+	c.ttl = ttl
+}
+
+// EvictOldest evicts up to n least recently used entries, cycling over
+// shards, and returns how many were removed. Intended for callers that
+// enforce budgets beyond entry counts (e.g. byte budgets): evict until
+// the external budget is satisfied.
+func (c *ShardedCache[K, V]) EvictOldest(n int) int {
+	removed := 0
+	var evicted []evictedEntry[K, V]
+
+	for removed < n {
+		progress := false
+		for _, shard := range c.shards {
+			if removed >= n {
+				break
+			}
+			shard.mu.Lock()
+			oldest, ok := shard.lru.RemoveOldest()
+			if ok {
+				if entry, found := shard.entries[oldest]; found {
+					c.cost.Add(-entry.cost)
+					if c.onEvict != nil {
+						evicted = append(evicted, evictedEntry[K, V]{key: oldest, value: entry.value})
+					}
+				}
+				delete(shard.entries, oldest)
+				c.evictions.Add(1)
+				removed++
+				progress = true
+			}
+			shard.mu.Unlock()
+		}
+		if !progress {
+			break
+		}
+	}
+
+	c.notifyEvicted(evicted)
+	return removed
+}
+
 // Delete removes an entry from the cache.
 // Returns true if the entry was found and removed.
 func (c *ShardedCache[K, V]) Delete(key K) bool {
@@ -274,6 +477,7 @@ func (c *ShardedCache[K, V]) Delete(key K) bool {
 
 	shard.lru.Remove(entry.node)
 	delete(shard.entries, key)
+	c.cost.Add(-entry.cost)
 	return true
 }
 
@@ -281,6 +485,9 @@ func (c *ShardedCache[K, V]) Delete(key K) bool {
 func (c *ShardedCache[K, V]) Clear() {
 	for _, shard := range c.shards {
 		shard.mu.Lock()
+		for _, entry := range shard.entries {
+			c.cost.Add(-entry.cost)
+		}
 		shard.entries = make(map[K]*shardedCacheEntry[K, V])
 		shard.lru.Clear()
 		shard.mu.Unlock()
@@ -341,6 +548,7 @@ func (c *ShardedCache[K, V]) Stats() Stats {
 		Misses:        misses,
 		HitRate:       hitRate,
 		Evictions:     evictions,
+		Cost:          c.cost.Load(),
 	}
 }
 
@@ -0,0 +1,53 @@
+package cache
+
+// inflightCall tracks one in-progress computation for GetOrCompute.
+// Waiters block on done and then read value/err.
+type inflightCall[V any] struct {
+	done  chan struct{}
+	value V
+	err   error
+}
+
+// GetOrCompute returns the cached value for key, computing and caching
+// it on a miss. Unlike GetOrCreate, compute runs without holding any
+// shard lock, and concurrent calls for the same key are deduplicated:
+// one goroutine computes while the others wait for its result
+// (singleflight). Use it when compute is expensive — shader compilation,
+// glyph atlas generation — and a thundering herd must be avoided.
+//
+// When compute fails, the error is returned to every waiter and nothing
+// is cached, so a later call retries.
+func (c *ShardedCache[K, V]) GetOrCompute(key K, compute func() (V, error)) (V, error) {
+	if value, ok := c.Get(key); ok {
+		return value, nil
+	}
+
+	c.flightMu.Lock()
+	if call, ok := c.flightCalls[key]; ok {
+		// Another goroutine is computing this key; wait for it.
+		c.flightMu.Unlock()
+		<-call.done
+		return call.value, call.err
+	}
+	call := &inflightCall[V]{done: make(chan struct{})}
+	c.flightCalls[key] = call
+	c.flightMu.Unlock()
+
+	// Re-check the cache: the value may have been stored between the
+	// initial Get and registering the call.
+	if value, ok := c.Get(key); ok {
+		call.value = value
+	} else {
+		call.value, call.err = compute()
+		if call.err == nil {
+			c.Set(key, call.value)
+		}
+	}
+
+	c.flightMu.Lock()
+	delete(c.flightCalls, key)
+	c.flightMu.Unlock()
+	close(call.done)
+
+	return call.value, call.err
+}
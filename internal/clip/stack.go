@@ -295,3 +295,14 @@ func (cs *ClipStack) Reset(bounds Rect) {
 	cs.entries = cs.entries[:0]
 	cs.bounds = bounds
 }
+
+// Clone returns an independent copy of the clip stack.
+// Entry mask data is shared: masks are immutable after being pushed.
+func (cs *ClipStack) Clone() *ClipStack {
+	entries := make([]clipEntry, len(cs.entries))
+	copy(entries, cs.entries)
+	return &ClipStack{
+		entries: entries,
+		bounds:  cs.bounds,
+	}
+}
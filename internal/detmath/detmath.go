@@ -0,0 +1,254 @@
+// Package detmath provides deterministic replacements for the
+// transcendental math functions used by the rendering pipeline.
+//
+// The standard library's math.Sin and math.Cos are pure Go, but the
+// compiler is free to contract multiply-add expressions into fused
+// multiply-add (FMA) instructions on platforms that have them (arm64,
+// ppc64). The fused form skips an intermediate rounding step, so the
+// same Go source produces slightly different results on amd64 and
+// arm64 — enough to flip low bits in golden images.
+//
+// When deterministic mode is enabled, Sin, Cos, and Sqrt switch to
+// software implementations that force an explicit rounding step after
+// every multiply-add candidate, so results are bit-identical on every
+// platform. When disabled (the default), they delegate to the math
+// package with no measurable overhead beyond one atomic load.
+//
+// The software implementations use the same Cody-Waite reduction and
+// minimax polynomials as the standard library, so accuracy is within
+// 1 ULP for the argument ranges rendering produces. Arguments with
+// magnitude at or above 2^29 fall back to a coarser (but still
+// deterministic) modular reduction and lose accuracy; rendering code
+// never generates such angles.
+package detmath
+
+import (
+	"math"
+	"sync/atomic"
+)
+
+var deterministic atomic.Bool
+
+// SetDeterministic enables or disables deterministic mode for the
+// whole process. It is safe to call concurrently with rendering, but
+// callers that need a bit-exact frame should set it before drawing.
+func SetDeterministic(enabled bool) {
+	deterministic.Store(enabled)
+}
+
+// Deterministic reports whether deterministic mode is enabled.
+func Deterministic() bool {
+	return deterministic.Load()
+}
+
+// Sin returns the sine of x (in radians).
+func Sin(x float64) float64 {
+	if deterministic.Load() {
+		return softSin(x)
+	}
+	return math.Sin(x)
+}
+
+// Cos returns the cosine of x (in radians).
+func Cos(x float64) float64 {
+	if deterministic.Load() {
+		return softCos(x)
+	}
+	return math.Cos(x)
+}
+
+// Sqrt returns the square root of x.
+func Sqrt(x float64) float64 {
+	if deterministic.Load() {
+		return softSqrt(x)
+	}
+	return math.Sqrt(x)
+}
+
+// Cody-Waite split of Pi/4 into three parts whose sum is accurate to
+// well beyond double precision, so y*Pi/4 can be subtracted without
+// catastrophic cancellation.
+const (
+	pi4A = 7.85398125648498535156e-1
+	pi4B = 3.77489470793079817668e-8
+	pi4C = 2.69515142907905952645e-15
+
+	// Above this magnitude the Cody-Waite reduction loses all
+	// precision; fall back to modular reduction first.
+	reduceThreshold = 1 << 29
+)
+
+// Minimax polynomial coefficients for sin and cos on |x| <= Pi/4
+// (the classic Cephes/fdlibm coefficients, highest degree first).
+var sinCoeff = [...]float64{
+	1.58962301576546568060e-10,
+	-2.50507477628578072866e-8,
+	2.75573136213857245213e-6,
+	-1.98412698295895385996e-4,
+	8.33333333332211858878e-3,
+	-1.66666666666666307295e-1,
+}
+
+var cosCoeff = [...]float64{
+	-1.13585365213876817300e-11,
+	2.08757008419747316778e-9,
+	-2.75573141792967388112e-7,
+	2.48015872888517179954e-5,
+	-1.38888888888730564116e-3,
+	4.16666666666665929218e-2,
+}
+
+// poly evaluates a polynomial in Horner form with an explicit float64
+// conversion after each multiply, which the language spec defines as a
+// rounding step. That prevents the compiler from contracting the
+// multiply-add pairs into FMA instructions.
+func poly(z float64, c *[6]float64) float64 {
+	r := c[0]
+	for _, k := range c[1:] {
+		r = float64(r*z) + k
+	}
+	return r
+}
+
+// reduce maps |x| into an octant index j (0-3, with a sign flip folded
+// out by the caller via the returned flip) and the remainder z on
+// [-Pi/4, Pi/4], using the same scheme as the standard library but
+// with forced rounding at each step.
+func reduce(x float64) (j uint64, z float64) {
+	if x >= reduceThreshold {
+		// Coarse but deterministic: math.Mod is an exact IEEE
+		// operation, so this is bit-stable even though the reduced
+		// angle drifts from the true remainder for huge inputs.
+		x = math.Mod(x, 2*math.Pi)
+	}
+	j = uint64(x * (4 / math.Pi))
+	y := float64(j)
+	// Map odd octants to even ones so z stays centered.
+	if j&1 == 1 {
+		j++
+		y++
+	}
+	j &= 7
+	z = x - float64(y*pi4A)
+	z -= float64(y * pi4B)
+	z -= float64(y * pi4C)
+	return j, z
+}
+
+// softSin is a deterministic math.Sin.
+func softSin(x float64) float64 {
+	switch {
+	case x == 0 || math.IsNaN(x):
+		return x
+	case math.IsInf(x, 0):
+		return math.NaN()
+	}
+
+	sign := false
+	if x < 0 {
+		x = -x
+		sign = true
+	}
+
+	j, z := reduce(x)
+	if j > 3 {
+		j -= 4
+		sign = !sign
+	}
+
+	zz := float64(z * z)
+	var y float64
+	if j == 1 || j == 2 {
+		y = 1 - float64(0.5*zz) + float64(float64(zz*zz)*poly(zz, &cosCoeff))
+	} else {
+		y = z + float64(float64(z*zz)*poly(zz, &sinCoeff))
+	}
+	if sign {
+		y = -y
+	}
+	return y
+}
+
+// softCos is a deterministic math.Cos.
+func softCos(x float64) float64 {
+	switch {
+	case math.IsNaN(x):
+		return x
+	case math.IsInf(x, 0):
+		return math.NaN()
+	}
+
+	sign := false
+	x = math.Abs(x)
+
+	j, z := reduce(x)
+	if j > 3 {
+		j -= 4
+		sign = !sign
+	}
+	if j > 1 {
+		sign = !sign
+	}
+
+	zz := float64(z * z)
+	var y float64
+	if j == 1 || j == 2 {
+		y = z + float64(float64(z*zz)*poly(zz, &sinCoeff))
+	} else {
+		y = 1 - float64(0.5*zz) + float64(float64(zz*zz)*poly(zz, &cosCoeff))
+	}
+	if sign {
+		y = -y
+	}
+	return y
+}
+
+// softSqrt is a deterministic math.Sqrt using the classic
+// digit-by-digit integer algorithm. IEEE 754 defines sqrt as correctly
+// rounded, so this matches math.Sqrt bit-for-bit on every platform;
+// the software path exists so deterministic mode does not depend on
+// that guarantee holding for a given hardware implementation.
+func softSqrt(x float64) float64 {
+	switch {
+	case x == 0 || math.IsNaN(x) || math.IsInf(x, 1):
+		return x
+	case x < 0:
+		return math.NaN()
+	}
+
+	ix := math.Float64bits(x)
+	exp := int((ix >> 52) & 0x7FF)
+	if exp == 0 {
+		// Subnormal: normalize the mantissa.
+		for ix&(1<<52) == 0 {
+			ix <<= 1
+			exp--
+		}
+		exp++
+	}
+	exp -= 1023
+	ix &^= 0x7FF << 52
+	ix |= 1 << 52
+	if exp&1 == 1 {
+		ix <<= 1
+	}
+	exp >>= 1
+	ix <<= 1
+
+	var q, s uint64
+	r := uint64(1 << 53)
+	for r != 0 {
+		t := s + r
+		if t <= ix {
+			s = t + r
+			ix -= t
+			q += r
+		}
+		ix <<= 1
+		r >>= 1
+	}
+	if ix != 0 {
+		q += q & 1 // round to nearest even
+	}
+	return math.Float64frombits(q>>1 + uint64(exp-1+1023)<<52)
+}
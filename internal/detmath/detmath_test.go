@@ -0,0 +1,135 @@
+package detmath
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+// TestSqrtMatchesStdlib tests that the software sqrt is bit-identical
+// to math.Sqrt, which IEEE 754 requires to be correctly rounded.
+func TestSqrtMatchesStdlib(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	for i := 0; i < 100000; i++ {
+		x := math.Float64frombits(rng.Uint64())
+		if x < 0 || math.IsNaN(x) {
+			continue
+		}
+		got := softSqrt(x)
+		want := math.Sqrt(x)
+		if math.Float64bits(got) != math.Float64bits(want) {
+			t.Fatalf("softSqrt(%g) = %g (%#x), want %g (%#x)",
+				x, got, math.Float64bits(got), want, math.Float64bits(want))
+		}
+	}
+}
+
+// TestSqrtSpecials tests sqrt edge cases.
+func TestSqrtSpecials(t *testing.T) {
+	if got := softSqrt(0); got != 0 || math.Signbit(got) {
+		t.Errorf("softSqrt(0) = %g", got)
+	}
+	if got := softSqrt(math.Copysign(0, -1)); !math.Signbit(got) {
+		t.Errorf("softSqrt(-0) should be -0, got %g", got)
+	}
+	if !math.IsNaN(softSqrt(-1)) {
+		t.Error("softSqrt(-1) should be NaN")
+	}
+	if !math.IsNaN(softSqrt(math.NaN())) {
+		t.Error("softSqrt(NaN) should be NaN")
+	}
+	if !math.IsInf(softSqrt(math.Inf(1)), 1) {
+		t.Error("softSqrt(+Inf) should be +Inf")
+	}
+	// Subnormal input.
+	tiny := math.Float64frombits(1)
+	if got, want := softSqrt(tiny), math.Sqrt(tiny); got != want {
+		t.Errorf("softSqrt(subnormal) = %g, want %g", got, want)
+	}
+}
+
+// TestSinCosAccuracy tests the software sin/cos against the standard
+// library across the angle range rendering actually uses.
+func TestSinCosAccuracy(t *testing.T) {
+	const tol = 1e-14
+	for x := -100.0; x <= 100.0; x += 0.0137 {
+		if d := math.Abs(softSin(x) - math.Sin(x)); d > tol {
+			t.Fatalf("softSin(%g) differs from math.Sin by %g", x, d)
+		}
+		if d := math.Abs(softCos(x) - math.Cos(x)); d > tol {
+			t.Fatalf("softCos(%g) differs from math.Cos by %g", x, d)
+		}
+	}
+}
+
+// TestSinCosSpecials tests sin/cos edge cases.
+func TestSinCosSpecials(t *testing.T) {
+	if got := softSin(0); got != 0 || math.Signbit(got) {
+		t.Errorf("softSin(0) = %g", got)
+	}
+	if got := softSin(math.Copysign(0, -1)); !math.Signbit(got) {
+		t.Errorf("softSin(-0) should be -0, got %g", got)
+	}
+	if got := softCos(0); got != 1 {
+		t.Errorf("softCos(0) = %g, want 1", got)
+	}
+	for _, x := range []float64{math.NaN(), math.Inf(1), math.Inf(-1)} {
+		if !math.IsNaN(softSin(x)) {
+			t.Errorf("softSin(%g) should be NaN", x)
+		}
+		if !math.IsNaN(softCos(x)) {
+			t.Errorf("softCos(%g) should be NaN", x)
+		}
+	}
+}
+
+// TestSinCosSymmetry tests that sin is odd and cos is even, which the
+// sign handling must preserve exactly.
+func TestSinCosSymmetry(t *testing.T) {
+	for x := 0.01; x < 50; x += 0.773 {
+		if softSin(-x) != -softSin(x) {
+			t.Fatalf("softSin not odd at %g", x)
+		}
+		if softCos(-x) != softCos(x) {
+			t.Fatalf("softCos not even at %g", x)
+		}
+	}
+}
+
+// TestLargeArguments tests that huge angles still produce a bounded,
+// finite result via the fallback reduction.
+func TestLargeArguments(t *testing.T) {
+	for _, x := range []float64{1 << 29, 1e12, 1e18, -1e18} {
+		s, c := softSin(x), softCos(x)
+		if math.Abs(s) > 1 || math.Abs(c) > 1 {
+			t.Errorf("softSin/softCos(%g) = %g, %g out of range", x, s, c)
+		}
+	}
+}
+
+// TestModeDispatch tests the package-level mode switch.
+func TestModeDispatch(t *testing.T) {
+	defer SetDeterministic(false)
+
+	SetDeterministic(false)
+	if Deterministic() {
+		t.Fatal("Deterministic() should be false by default")
+	}
+	SetDeterministic(true)
+	if !Deterministic() {
+		t.Fatal("Deterministic() should be true after SetDeterministic(true)")
+	}
+	// In deterministic mode the dispatchers must return the software
+	// results exactly.
+	for _, x := range []float64{0.3, 1.7, -4.2, 100} {
+		if Sin(x) != softSin(x) {
+			t.Errorf("Sin(%g) did not dispatch to softSin", x)
+		}
+		if Cos(x) != softCos(x) {
+			t.Errorf("Cos(%g) did not dispatch to softCos", x)
+		}
+		if x >= 0 && Sqrt(x) != softSqrt(x) {
+			t.Errorf("Sqrt(%g) did not dispatch to softSqrt", x)
+		}
+	}
+}
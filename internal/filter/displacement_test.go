@@ -0,0 +1,77 @@
+package filter
+
+import (
+	"testing"
+
+	"github.com/gogpu/gg"
+)
+
+// uniformMap returns a displacement map filled with the given RGBA bytes.
+func uniformMap(size int, r, g float64) *gg.Pixmap {
+	p := gg.NewPixmap(size, size)
+	for y := 0; y < size; y++ {
+		for x := 0; x < size; x++ {
+			p.SetPixel(x, y, gg.RGBA{R: r, G: g, B: 0.5, A: 1})
+		}
+	}
+	return p
+}
+
+func TestDisplacementNeutralMapIsIdentity(t *testing.T) {
+	src := centerDot(32, 8)
+	dst := gg.NewPixmap(32, 32)
+
+	// Mid-gray in both channels: zero displacement.
+	f := NewDisplacementFilter(uniformMap(32, 0.5, 0.5), 20)
+	f.Apply(src, dst, wholeRect(src))
+
+	for y := 0; y < 32; y++ {
+		for x := 0; x < 32; x++ {
+			if src.GetPixel(x, y) != dst.GetPixel(x, y) {
+				t.Fatalf("neutral map displaced pixel (%d, %d)", x, y)
+			}
+		}
+	}
+}
+
+func TestDisplacementShiftsContent(t *testing.T) {
+	src := centerDot(32, 8) // block covers [12,20)
+	dst := gg.NewPixmap(32, 32)
+
+	// Full red (1.0) in the X channel: dst(x) samples src(x + scale/2),
+	// shifting content left by scale/2 = 5 pixels.
+	f := NewDisplacementFilter(uniformMap(32, 1.0, 0.5), 10)
+	f.Apply(src, dst, wholeRect(src))
+
+	if got := dst.GetPixel(9, 16); got.A < 0.9 {
+		t.Errorf("content not shifted left: %+v", got)
+	}
+	if got := dst.GetPixel(17, 16); got.A > 0.1 {
+		t.Errorf("content still at original right edge: %+v", got)
+	}
+}
+
+func TestDisplacementChannelSelectors(t *testing.T) {
+	src := centerDot(32, 8)
+	dst := gg.NewPixmap(32, 32)
+
+	// Drive Y displacement from the red channel instead.
+	f := NewDisplacementFilter(uniformMap(32, 1.0, 0.5), 10)
+	f.XChannel = ChannelB // blue is 0.5 -> neutral
+	f.YChannel = ChannelR // red is 1.0 -> shift up by 5
+	f.Apply(src, dst, wholeRect(src))
+
+	if got := dst.GetPixel(16, 9); got.A < 0.9 {
+		t.Errorf("content not shifted up: %+v", got)
+	}
+	if got := dst.GetPixel(9, 16); got.A > 0.1 {
+		t.Errorf("content shifted horizontally: %+v", got)
+	}
+}
+
+func TestDisplacementNilMap(t *testing.T) {
+	src := centerDot(8, 2)
+	dst := gg.NewPixmap(8, 8)
+	f := &DisplacementFilter{Scale: 10}
+	f.Apply(src, dst, wholeRect(src)) // must not panic
+}
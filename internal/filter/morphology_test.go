@@ -0,0 +1,96 @@
+package filter
+
+import (
+	"testing"
+
+	"github.com/gogpu/gg"
+	"github.com/gogpu/gg/scene"
+)
+
+// centerDot returns a pixmap with a single opaque white pixel block in
+// the center.
+func centerDot(size, block int) *gg.Pixmap {
+	p := gg.NewPixmap(size, size)
+	off := (size - block) / 2
+	for y := off; y < off+block; y++ {
+		for x := off; x < off+block; x++ {
+			p.SetPixel(x, y, gg.White)
+		}
+	}
+	return p
+}
+
+func wholeRect(p *gg.Pixmap) scene.Rect {
+	return scene.Rect{MaxX: float32(p.Width()), MaxY: float32(p.Height())}
+}
+
+func TestDilateGrowsShape(t *testing.T) {
+	src := centerDot(32, 4) // block covers [14,18)
+	dst := gg.NewPixmap(32, 32)
+
+	NewDilateFilter(3).Apply(src, dst, wholeRect(src))
+
+	// A pixel 3px outside the block must now be opaque.
+	if got := dst.GetPixel(12, 16); got.A < 0.9 {
+		t.Errorf("dilate did not grow shape: %+v", got)
+	}
+	// A pixel outside the grown extent stays transparent.
+	if got := dst.GetPixel(8, 16); got.A > 0.1 {
+		t.Errorf("dilate grew too far: %+v", got)
+	}
+}
+
+func TestErodeShrinksShape(t *testing.T) {
+	src := centerDot(32, 10) // block covers [11,21)
+	dst := gg.NewPixmap(32, 32)
+
+	NewErodeFilter(3).Apply(src, dst, wholeRect(src))
+
+	// Center survives a 3px erosion of a 10px block.
+	if got := dst.GetPixel(16, 16); got.A < 0.9 {
+		t.Errorf("erode removed the center: %+v", got)
+	}
+	// The original edge is eaten away.
+	if got := dst.GetPixel(12, 16); got.A > 0.1 {
+		t.Errorf("erode left the edge: %+v", got)
+	}
+}
+
+func TestMorphologyZeroRadiusIsCopy(t *testing.T) {
+	src := centerDot(16, 6)
+	dst := gg.NewPixmap(16, 16)
+
+	NewDilateFilter(0).Apply(src, dst, wholeRect(src))
+
+	for y := 0; y < 16; y++ {
+		for x := 0; x < 16; x++ {
+			if src.GetPixel(x, y) != dst.GetPixel(x, y) {
+				t.Fatalf("zero-radius morphology changed pixel (%d, %d)", x, y)
+			}
+		}
+	}
+}
+
+func TestMorphologyExpandBounds(t *testing.T) {
+	in := scene.Rect{MinX: 10, MinY: 10, MaxX: 20, MaxY: 20}
+
+	out := NewDilateFilter(4).ExpandBounds(in)
+	if out.MinX != 6 || out.MaxX != 24 {
+		t.Errorf("dilate bounds = %+v, want expansion by 4", out)
+	}
+
+	if out := NewErodeFilter(4).ExpandBounds(in); out != in {
+		t.Errorf("erode bounds = %+v, want unchanged", out)
+	}
+}
+
+func BenchmarkDilate(b *testing.B) {
+	src := centerDot(256, 64)
+	dst := gg.NewPixmap(256, 256)
+	f := NewDilateFilter(5)
+	bounds := wholeRect(src)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		f.Apply(src, dst, bounds)
+	}
+}
@@ -0,0 +1,84 @@
+package filter
+
+import (
+	"testing"
+
+	"github.com/gogpu/gg"
+)
+
+func TestTurbulenceDeterministic(t *testing.T) {
+	a := gg.NewPixmap(32, 32)
+	b := gg.NewPixmap(32, 32)
+
+	NewTurbulenceFilter(TurbulenceTurbulence, 0.05, 0.05, 3, 42).Apply(nil, a, wholeRect(a))
+	NewTurbulenceFilter(TurbulenceTurbulence, 0.05, 0.05, 3, 42).Apply(nil, b, wholeRect(b))
+
+	for i, v := range a.Data() {
+		if v != b.Data()[i] {
+			t.Fatal("same seed produced different noise")
+		}
+	}
+}
+
+func TestTurbulenceSeedChangesOutput(t *testing.T) {
+	a := gg.NewPixmap(32, 32)
+	b := gg.NewPixmap(32, 32)
+
+	NewTurbulenceFilter(TurbulenceFractalNoise, 0.08, 0.08, 4, 1).Apply(nil, a, wholeRect(a))
+	NewTurbulenceFilter(TurbulenceFractalNoise, 0.08, 0.08, 4, 2).Apply(nil, b, wholeRect(b))
+
+	diff := 0
+	for i, v := range a.Data() {
+		if v != b.Data()[i] {
+			diff++
+		}
+	}
+	if diff == 0 {
+		t.Error("different seeds produced identical noise")
+	}
+}
+
+func TestTurbulenceHasVariation(t *testing.T) {
+	p := gg.NewPixmap(64, 64)
+	NewTurbulenceFilter(TurbulenceFractalNoise, 0.1, 0.1, 4, 7).Apply(nil, p, wholeRect(p))
+
+	data := p.Data()
+	minA, maxA := data[3], data[3]
+	for i := 3; i < len(data); i += 4 {
+		if data[i] < minA {
+			minA = data[i]
+		}
+		if data[i] > maxA {
+			maxA = data[i]
+		}
+	}
+	if maxA-minA < 32 {
+		t.Errorf("noise alpha range too narrow: [%d, %d]", minA, maxA)
+	}
+}
+
+func TestTurbulencePremultiplied(t *testing.T) {
+	p := gg.NewPixmap(32, 32)
+	NewTurbulenceFilter(TurbulenceTurbulence, 0.05, 0.05, 2, 3).Apply(nil, p, wholeRect(p))
+
+	data := p.Data()
+	for i := 0; i < len(data); i += 4 {
+		a := data[i+3]
+		for ch := 0; ch < 3; ch++ {
+			if data[i+ch] > a {
+				t.Fatalf("channel %d exceeds alpha at pixel %d: %d > %d",
+					ch, i/4, data[i+ch], a)
+			}
+		}
+	}
+}
+
+func BenchmarkTurbulence(b *testing.B) {
+	p := gg.NewPixmap(256, 256)
+	f := NewTurbulenceFilter(TurbulenceTurbulence, 0.05, 0.05, 4, 42)
+	bounds := wholeRect(p)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		f.Apply(nil, p, bounds)
+	}
+}
@@ -44,6 +44,10 @@ type HALAdapter struct {
 	// Command encoder for current frame
 	encoder    *wgpu.CommandEncoder
 	hasEncoder bool
+
+	// Optional dedicated compute queue for async compute submission.
+	// Nil when the backend exposes only a single queue.
+	computeQueue *wgpu.Queue
 }
 
 // NewHALAdapter creates a new HALAdapter wrapping the given device and queue.
@@ -602,6 +606,12 @@ func (a *HALAdapter) Submit() {
 	a.mu.Lock()
 	defer a.mu.Unlock()
 
+	a.submitLocked(a.queue)
+}
+
+// submitLocked finishes the current encoder and submits it on the given
+// queue. The caller must hold a.mu.
+func (a *HALAdapter) submitLocked(queue *wgpu.Queue) {
 	if !a.hasEncoder || a.encoder == nil {
 		return
 	}
@@ -614,7 +624,7 @@ func (a *HALAdapter) Submit() {
 	}
 
 	// Submit (fire and forget — non-blocking)
-	_, _ = a.queue.Submit(cmdBuffer)
+	_, _ = queue.Submit(cmdBuffer)
 
 	// Clean up
 	// cmdBuffer consumed by Submit
@@ -622,6 +632,49 @@ func (a *HALAdapter) Submit() {
 	a.hasEncoder = false
 }
 
+// SetComputeQueue sets a dedicated queue for compute submission. When set,
+// SubmitAsync sends compute command buffers to this queue instead of the
+// default queue, allowing compute to overlap with render work on backends
+// that schedule the queues independently. Passing nil reverts to the
+// default queue.
+func (a *HALAdapter) SetComputeQueue(queue *wgpu.Queue) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	a.computeQueue = queue
+}
+
+// SupportsAsyncCompute reports whether a dedicated compute queue has been
+// set. It implements gpucore.AsyncComputeAdapter.
+func (a *HALAdapter) SupportsAsyncCompute() bool {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
+	return a.computeQueue != nil
+}
+
+// SubmitAsync submits recorded commands on the dedicated compute queue,
+// falling back to the default queue when none is set. It implements
+// gpucore.AsyncComputeAdapter.
+func (a *HALAdapter) SubmitAsync() {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	queue := a.computeQueue
+	if queue == nil {
+		queue = a.queue
+	}
+	a.submitLocked(queue)
+}
+
+// WaitAsync blocks until work submitted via SubmitAsync has completed.
+// The HAL exposes only device-level synchronization, so this waits for the
+// whole device to go idle. It implements gpucore.AsyncComputeAdapter.
+func (a *HALAdapter) WaitAsync() {
+	a.SubmitAsync()
+	_ = a.device.WaitIdle()
+}
+
 // WaitIdle waits for all GPU operations to complete.
 func (a *HALAdapter) WaitIdle() {
 	// Submit any pending work first
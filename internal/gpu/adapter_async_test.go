@@ -0,0 +1,57 @@
+// Copyright 2026 The gogpu Authors
+// SPDX-License-Identifier: MIT
+
+//go:build !nogpu
+
+package gpu
+
+import (
+	"testing"
+
+	"github.com/gogpu/gg/internal/gpucore"
+)
+
+// TestHALAdapterImplementsAsyncCompute verifies the optional interface is
+// satisfied and that async compute is reported only once a dedicated
+// compute queue has been set.
+func TestHALAdapterImplementsAsyncCompute(t *testing.T) {
+	adapter := NewHALAdapter(nil, nil, nil)
+
+	var _ gpucore.GPUAdapter = adapter
+	var async gpucore.AsyncComputeAdapter = adapter
+
+	if async.SupportsAsyncCompute() {
+		t.Error("SupportsAsyncCompute() = true with no compute queue set")
+	}
+}
+
+// fakeAsyncAdapter records which submission path SubmitCompute takes.
+type fakeAsyncAdapter struct {
+	gpucore.GPUAdapter
+
+	supportsAsync bool
+	submitted     string
+}
+
+func (f *fakeAsyncAdapter) SupportsCompute() bool      { return true }
+func (f *fakeAsyncAdapter) Submit()                    { f.submitted = "default" }
+func (f *fakeAsyncAdapter) SupportsAsyncCompute() bool { return f.supportsAsync }
+func (f *fakeAsyncAdapter) SubmitAsync()               { f.submitted = "async" }
+func (f *fakeAsyncAdapter) WaitAsync()                 {}
+
+// TestSubmitComputeQueueSelection verifies SubmitCompute prefers the
+// dedicated compute queue and falls back to the default queue when the
+// adapter reports no async support.
+func TestSubmitComputeQueueSelection(t *testing.T) {
+	withQueue := &fakeAsyncAdapter{supportsAsync: true}
+	gpucore.SubmitCompute(withQueue)
+	if withQueue.submitted != "async" {
+		t.Errorf("submitted on %q queue, want async", withQueue.submitted)
+	}
+
+	withoutQueue := &fakeAsyncAdapter{supportsAsync: false}
+	gpucore.SubmitCompute(withoutQueue)
+	if withoutQueue.submitted != "default" {
+		t.Errorf("submitted on %q queue, want default", withoutQueue.submitted)
+	}
+}
@@ -0,0 +1,65 @@
+// Copyright 2026 The gogpu Authors
+// SPDX-License-Identifier: MIT
+
+//go:build rust && !nogpu
+
+package gpu
+
+import (
+	"fmt"
+
+	"github.com/gogpu/gg/internal/gpucore"
+	"github.com/gogpu/wgpu"
+)
+
+// NewRustAdapter creates a gpucore.GPUAdapter backed by wgpu-native through
+// the go-webgpu FFI bindings.
+//
+// Under the rust build tag, the gogpu/wgpu types route every call through
+// the wgpu-native shared library instead of the Pure Go HAL, so HALAdapter's
+// ID mapping over buffers, textures, shader modules, and pipelines carries
+// over unchanged — the same HybridPipeline and Vello compute dispatch run
+// on the Rust implementation without backend-specific code.
+//
+// The returned adapter owns its device and queue; callers release GPU
+// resources via the usual Destroy* methods.
+func NewRustAdapter() (*HALAdapter, error) {
+	instance, err := wgpu.CreateInstance(&wgpu.InstanceDescriptor{
+		Flags: debugInstanceFlags(),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("rust adapter: create instance: %w", err)
+	}
+
+	adapter, err := instance.RequestAdapter(&wgpu.RequestAdapterOptions{
+		PowerPreference: wgpu.PowerPreferenceHighPerformance,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("rust adapter: request adapter: %w", err)
+	}
+
+	device, err := adapter.RequestDevice(&wgpu.DeviceDescriptor{Label: "gg-rust-adapter"})
+	if err != nil {
+		return nil, fmt.Errorf("rust adapter: request device: %w", err)
+	}
+
+	limits := adapter.Limits()
+
+	slogger().Info("rust adapter: wgpu-native device initialized",
+		"adapter", adapter.Info().Name,
+		"backend", adapter.Info().Backend,
+	)
+
+	return NewHALAdapter(device, device.Queue(), &limits), nil
+}
+
+// NewRustPipeline creates a HybridPipeline running on wgpu-native via
+// NewRustAdapter. It is a convenience wrapper for users who prefer the
+// Rust implementation over the Pure Go HAL.
+func NewRustPipeline(config *gpucore.PipelineConfig) (*gpucore.HybridPipeline, error) {
+	adapter, err := NewRustAdapter()
+	if err != nil {
+		return nil, err
+	}
+	return gpucore.NewHybridPipeline(adapter, config)
+}
@@ -0,0 +1,318 @@
+//go:build !nogpu
+
+package gpu
+
+import (
+	"math"
+
+	"github.com/gogpu/gg/scene"
+)
+
+// Double-precision flattening for large coordinates.
+//
+// The f32 flatten stage loses about one pixel of precision per 1e5 units
+// of coordinate magnitude (24-bit mantissa), so map data in pixel space —
+// paths placed millions of units from the origin and panned back into
+// view by the transform — exhibits wobble and cracks. The F64 variants
+// below run the transform and all de Casteljau arithmetic in float64 and
+// only round to f32 at segment emission, where coordinates are already
+// viewport-relative and small.
+//
+// For paths whose stored f32 points are themselves large, rebase the
+// path with RebasePathToOrigin and fold the offset back into the
+// transform with FoldOffsetIntoTransform before flattening or GPU
+// upload; subtracting the bounds origin from nearby large values is
+// exact in f32, so the rebase loses nothing.
+
+// PrecisionThreshold is the coordinate magnitude above which f32
+// flattening accumulates visible error and the double-precision path
+// should be used instead.
+const PrecisionThreshold = 1e5
+
+// NeedsF64Flattening reports whether flattening path under transform
+// would exceed f32 precision: any stored coordinate or translation
+// component beyond PrecisionThreshold.
+func NeedsF64Flattening(path *scene.Path, transform scene.Affine) bool {
+	if absf32(transform.C) > PrecisionThreshold || absf32(transform.F) > PrecisionThreshold {
+		return true
+	}
+	if path == nil {
+		return false
+	}
+	for _, v := range path.Points() {
+		if absf32(v) > PrecisionThreshold {
+			return true
+		}
+	}
+	return false
+}
+
+// RebasePathToOrigin translates the path so its bounds origin sits at
+// (0, 0) and returns the rebased path with the applied offset. Paths
+// already within PrecisionThreshold of the origin are returned as-is
+// with a zero offset. Fold the offset back into the view transform with
+// FoldOffsetIntoTransform so rendering is unchanged.
+func RebasePathToOrigin(path *scene.Path) (*scene.Path, float32, float32) {
+	if path == nil || path.IsEmpty() {
+		return path, 0, 0
+	}
+	b := path.Bounds()
+	if absf32(b.MinX) <= PrecisionThreshold && absf32(b.MinY) <= PrecisionThreshold {
+		return path, 0, 0
+	}
+	return path.Transform(scene.TranslateAffine(-b.MinX, -b.MinY)), b.MinX, b.MinY
+}
+
+// FoldOffsetIntoTransform composes transform with a leading translation
+// by (ox, oy), in double precision: the result maps rebased coordinates
+// exactly as transform mapped the originals. Composing in f64 matters —
+// the f32 product of a large offset and the matrix would reintroduce the
+// error the rebase removed.
+func FoldOffsetIntoTransform(t scene.Affine, ox, oy float32) scene.Affine {
+	return scene.Affine{
+		A: t.A, B: t.B,
+		C: float32(float64(t.A)*float64(ox) + float64(t.B)*float64(oy) + float64(t.C)),
+		D: t.D, E: t.E,
+		F: float32(float64(t.D)*float64(ox) + float64(t.E)*float64(oy) + float64(t.F)),
+	}
+}
+
+// transformPointF64 applies the affine transform in double precision.
+func transformPointF64(t scene.Affine, x, y float64) (float64, float64) {
+	return float64(t.A)*x + float64(t.B)*y + float64(t.C),
+		float64(t.D)*x + float64(t.E)*y + float64(t.F)
+}
+
+// FlattenPathAuto flattens a path into the context's segment list,
+// selecting double-precision flattening when the coordinates require it
+// (see NeedsF64Flattening) and the standard f32 path otherwise.
+func (ctx *FlattenContext) FlattenPathAuto(path *scene.Path, transform scene.Affine, tolerance float32) {
+	if NeedsF64Flattening(path, transform) {
+		ctx.FlattenPathToF64(path, transform, tolerance)
+		return
+	}
+	ctx.FlattenPathTo(path, transform, tolerance)
+}
+
+// FlattenPathToF64 flattens a path into the context's segment list with
+// all transform and subdivision arithmetic in double precision. Segments
+// are emitted in f32 after transformation, when coordinates are
+// viewport-relative.
+func (ctx *FlattenContext) FlattenPathToF64(path *scene.Path, transform scene.Affine, tolerance float32) {
+	if path == nil || path.IsEmpty() {
+		return
+	}
+
+	segments := ctx.segments
+	tol := float64(tolerance)
+
+	var curX, curY float64
+	var startX, startY float64
+
+	pointIdx := 0
+	points := path.Points()
+	verbs := path.Verbs()
+
+	for _, verb := range verbs {
+		switch verb {
+		case scene.MoveTo:
+			if curX != startX || curY != startY {
+				addMonotonicLineF64(segments, curX, curY, startX, startY)
+			}
+			x, y := float64(points[pointIdx]), float64(points[pointIdx+1])
+			curX, curY = transformPointF64(transform, x, y)
+			startX, startY = curX, curY
+			pointIdx += 2
+
+		case scene.LineTo:
+			x, y := float64(points[pointIdx]), float64(points[pointIdx+1])
+			nextX, nextY := transformPointF64(transform, x, y)
+			addMonotonicLineF64(segments, curX, curY, nextX, nextY)
+			curX, curY = nextX, nextY
+			pointIdx += 2
+
+		case scene.QuadTo:
+			cx, cy := float64(points[pointIdx]), float64(points[pointIdx+1])
+			x, y := float64(points[pointIdx+2]), float64(points[pointIdx+3])
+			tcx, tcy := transformPointF64(transform, cx, cy)
+			tx, ty := transformPointF64(transform, x, y)
+			flattenQuadraticF64(segments, curX, curY, tcx, tcy, tx, ty, tol, 0)
+			curX, curY = tx, ty
+			pointIdx += 4
+
+		case scene.CubicTo:
+			c1x, c1y := float64(points[pointIdx]), float64(points[pointIdx+1])
+			c2x, c2y := float64(points[pointIdx+2]), float64(points[pointIdx+3])
+			x, y := float64(points[pointIdx+4]), float64(points[pointIdx+5])
+			tc1x, tc1y := transformPointF64(transform, c1x, c1y)
+			tc2x, tc2y := transformPointF64(transform, c2x, c2y)
+			tx, ty := transformPointF64(transform, x, y)
+			flattenCubicF64(segments, curX, curY, tc1x, tc1y, tc2x, tc2y, tx, ty, tol, 0)
+			curX, curY = tx, ty
+			pointIdx += 6
+
+		case scene.Close:
+			if curX != startX || curY != startY {
+				addMonotonicLineF64(segments, curX, curY, startX, startY)
+			}
+			curX, curY = startX, startY
+		}
+	}
+
+	if curX != startX || curY != startY {
+		addMonotonicLineF64(segments, curX, curY, startX, startY)
+	}
+}
+
+// addMonotonicLineF64 mirrors addMonotonicLine with the degeneracy tests
+// in double precision; winding is decided before the f32 rounding so
+// near-horizontal segments keep their true direction.
+func addMonotonicLineF64(segments *SegmentList, x0, y0, x1, y1 float64) {
+	var winding int8 = 1
+	if y1 < y0 {
+		winding = -1
+	}
+
+	const epsilon = 1e-9
+	dy := y1 - y0
+	dx := x1 - x0
+	if math.Abs(dy) < epsilon && math.Abs(dx) < epsilon {
+		return
+	}
+	if math.Abs(dy) < epsilon {
+		return
+	}
+
+	segments.AddLine(float32(x0), float32(y0), float32(x1), float32(y1), winding)
+}
+
+// flattenQuadraticF64 is the double-precision counterpart of
+// flattenQuadraticRec: midpoint-deviation flatness test, split at the Y
+// extremum for monotonicity, midpoint subdivision otherwise.
+func flattenQuadraticF64(segments *SegmentList, x0, y0, cx, cy, x1, y1, tol float64, depth int) {
+	if depth > 10 {
+		addMonotonicLineF64(segments, x0, y0, x1, y1)
+		return
+	}
+
+	midX := 0.25*x0 + 0.5*cx + 0.25*x1
+	midY := 0.25*y0 + 0.5*cy + 0.25*y1
+	dx := midX - 0.5*(x0+x1)
+	dy := midY - 0.5*(y0+y1)
+
+	if dx*dx+dy*dy <= tol*tol {
+		addMonotonicLineF64(segments, x0, y0, x1, y1)
+		return
+	}
+
+	// Split at the Y extremum: t = (y0-cy) / (y0 - 2*cy + y1).
+	denom := y0 - 2*cy + y1
+	if math.Abs(denom) > flattenEpsilonF64 {
+		t := (y0 - cy) / denom
+		if t > flattenEpsilonF64 && t < 1.0-flattenEpsilonF64 {
+			ax, ay := lerp64(x0, cx, t), lerp64(y0, cy, t)
+			bx, by := lerp64(cx, x1, t), lerp64(cy, y1, t)
+			mx, my := lerp64(ax, bx, t), lerp64(ay, by, t)
+			flattenQuadraticF64(segments, x0, y0, ax, ay, mx, my, tol, depth+1)
+			flattenQuadraticF64(segments, mx, my, bx, by, x1, y1, tol, depth+1)
+			return
+		}
+	}
+
+	// Subdivide at the midpoint.
+	ax, ay := 0.5*(x0+cx), 0.5*(y0+cy)
+	bx, by := 0.5*(cx+x1), 0.5*(cy+y1)
+	mx, my := 0.5*(ax+bx), 0.5*(ay+by)
+	flattenQuadraticF64(segments, x0, y0, ax, ay, mx, my, tol, depth+1)
+	flattenQuadraticF64(segments, mx, my, bx, by, x1, y1, tol, depth+1)
+}
+
+// flattenCubicF64 is the double-precision counterpart of flattenCubicRec.
+func flattenCubicF64(segments *SegmentList, x0, y0, c1x, c1y, c2x, c2y, x1, y1, tol float64, depth int) {
+	if depth > 10 {
+		addMonotonicLineF64(segments, x0, y0, x1, y1)
+		return
+	}
+
+	ux := 3*c1x - 2*x0 - x1
+	uy := 3*c1y - 2*y0 - y1
+	vx := 3*c2x - x0 - 2*x1
+	vy := 3*c2y - y0 - 2*y1
+	distSq := math.Max(ux*ux+uy*uy, vx*vx+vy*vy)
+
+	if distSq <= 16*tol*tol {
+		addMonotonicLineF64(segments, x0, y0, x1, y1)
+		return
+	}
+
+	// Split at the first Y extremum for monotonicity.
+	a := y0 - 3*c1y + 3*c2y - y1
+	b := 2 * (c1y - 2*c2y + y1)
+	c := c2y - y1
+	if t, ok := firstRootInUnitF64(a, b, c); ok {
+		splitCubicAtF64(segments, x0, y0, c1x, c1y, c2x, c2y, x1, y1, t, tol, depth)
+		return
+	}
+
+	splitCubicAtF64(segments, x0, y0, c1x, c1y, c2x, c2y, x1, y1, 0.5, tol, depth)
+}
+
+// splitCubicAtF64 splits a cubic at parameter t (de Casteljau) and
+// flattens both halves.
+func splitCubicAtF64(segments *SegmentList, x0, y0, c1x, c1y, c2x, c2y, x1, y1, t, tol float64, depth int) {
+	ax, ay := lerp64(x0, c1x, t), lerp64(y0, c1y, t)
+	bx, by := lerp64(c1x, c2x, t), lerp64(c1y, c2y, t)
+	cx, cy := lerp64(c2x, x1, t), lerp64(c2y, y1, t)
+
+	dx, dy := lerp64(ax, bx, t), lerp64(ay, by, t)
+	ex, ey := lerp64(bx, cx, t), lerp64(by, cy, t)
+
+	mx, my := lerp64(dx, ex, t), lerp64(dy, ey, t)
+
+	flattenCubicF64(segments, x0, y0, ax, ay, dx, dy, mx, my, tol, depth+1)
+	flattenCubicF64(segments, mx, my, ex, ey, cx, cy, x1, y1, tol, depth+1)
+}
+
+// firstRootInUnitF64 returns the smallest root of ax^2 + bx + c = 0
+// strictly inside (0, 1), if any.
+func firstRootInUnitF64(a, b, c float64) (float64, bool) {
+	if math.Abs(a) < flattenEpsilonF64 {
+		if math.Abs(b) < flattenEpsilonF64 {
+			return 0, false
+		}
+		t := -c / b
+		if t > flattenEpsilonF64 && t < 1.0-flattenEpsilonF64 {
+			return t, true
+		}
+		return 0, false
+	}
+
+	discriminant := b*b - 4*a*c
+	if discriminant < 0 {
+		return 0, false
+	}
+
+	sqrtD := math.Sqrt(discriminant)
+	inv2a := 1.0 / (2 * a)
+	r1 := (-b - sqrtD) * inv2a
+	r2 := (-b + sqrtD) * inv2a
+	if r1 > r2 {
+		r1, r2 = r2, r1
+	}
+	if r1 > flattenEpsilonF64 && r1 < 1.0-flattenEpsilonF64 {
+		return r1, true
+	}
+	if r2 > flattenEpsilonF64 && r2 < 1.0-flattenEpsilonF64 {
+		return r2, true
+	}
+	return 0, false
+}
+
+// flattenEpsilonF64 is the double-precision comparison epsilon for the
+// flattening routines.
+const flattenEpsilonF64 = 1e-12
+
+// lerp64 performs double-precision linear interpolation.
+func lerp64(a, b, t float64) float64 {
+	return a + t*(b-a)
+}
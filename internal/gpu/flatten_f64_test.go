@@ -0,0 +1,173 @@
+//go:build !nogpu
+
+package gpu
+
+import (
+	"math"
+	"testing"
+
+	"github.com/gogpu/gg/scene"
+)
+
+func TestNeedsF64Flattening(t *testing.T) {
+	small := scene.NewPath().Circle(50, 50, 30)
+	if NeedsF64Flattening(small, scene.IdentityAffine()) {
+		t.Error("small coordinates should not require f64 flattening")
+	}
+
+	large := scene.NewPath().Circle(2e6, 2e6, 30)
+	if !NeedsF64Flattening(large, scene.IdentityAffine()) {
+		t.Error("coordinates beyond PrecisionThreshold should require f64 flattening")
+	}
+
+	bigTranslate := scene.TranslateAffine(-2e6, -2e6)
+	if !NeedsF64Flattening(small, bigTranslate) {
+		t.Error("large transform translation should require f64 flattening")
+	}
+
+	if NeedsF64Flattening(nil, scene.IdentityAffine()) {
+		t.Error("nil path should not require f64 flattening")
+	}
+}
+
+func TestFlattenPathToF64MatchesF32ForSmallCoords(t *testing.T) {
+	// On well-conditioned coordinates the f64 path must agree with the f32
+	// path to within rounding noise: same segment count, same endpoints.
+	path := scene.NewPath()
+	path.MoveTo(10, 10)
+	path.LineTo(80, 20)
+	path.QuadTo(90, 50, 60, 80)
+	path.CubicTo(40, 90, 20, 70, 10, 40)
+	path.Close()
+
+	f32Ctx := NewFlattenContext()
+	f32Ctx.FlattenPathTo(path, scene.IdentityAffine(), FlattenTolerance)
+
+	f64Ctx := NewFlattenContext()
+	f64Ctx.FlattenPathToF64(path, scene.IdentityAffine(), FlattenTolerance)
+
+	f32Segs := f32Ctx.Segments().Segments()
+	f64Segs := f64Ctx.Segments().Segments()
+	if len(f32Segs) != len(f64Segs) {
+		t.Fatalf("segment count differs: f32 %d, f64 %d", len(f32Segs), len(f64Segs))
+	}
+
+	const tol = 1e-3
+	for i := range f32Segs {
+		a, b := f32Segs[i], f64Segs[i]
+		if absf32(a.X0-b.X0) > tol || absf32(a.Y0-b.Y0) > tol ||
+			absf32(a.X1-b.X1) > tol || absf32(a.Y1-b.Y1) > tol {
+			t.Fatalf("segment %d differs: f32 %+v, f64 %+v", i, a, b)
+		}
+		if a.Winding != b.Winding {
+			t.Fatalf("segment %d winding differs: f32 %d, f64 %d", i, a.Winding, b.Winding)
+		}
+	}
+}
+
+func TestFlattenPathToF64LargeCoordinates(t *testing.T) {
+	// A curve drawn 1e6 units from the origin and panned back into view
+	// must flatten to the same segments as the identical curve drawn at
+	// the origin. The offset is representable exactly in f32 and well
+	// beyond PrecisionThreshold; f64 transform arithmetic keeps the
+	// cancellation exact where f32 would wobble.
+	const offset = float32(1 << 20) // 1048576, exact in f32
+
+	reference := scene.NewPath()
+	reference.MoveTo(10, 10)
+	reference.QuadTo(50, 90, 90, 10)
+	reference.Close()
+
+	farAway := scene.NewPath()
+	farAway.MoveTo(offset+10, offset+10)
+	farAway.QuadTo(offset+50, offset+90, offset+90, offset+10)
+	farAway.Close()
+
+	refCtx := NewFlattenContext()
+	refCtx.FlattenPathToF64(reference, scene.IdentityAffine(), FlattenTolerance)
+
+	farCtx := NewFlattenContext()
+	farCtx.FlattenPathToF64(farAway, scene.TranslateAffine(-offset, -offset), FlattenTolerance)
+
+	refSegs := refCtx.Segments().Segments()
+	farSegs := farCtx.Segments().Segments()
+	if len(refSegs) == 0 {
+		t.Fatal("reference produced no segments")
+	}
+	if len(refSegs) != len(farSegs) {
+		t.Fatalf("segment count differs: origin %d, translated %d", len(refSegs), len(farSegs))
+	}
+
+	const tol = 1e-3
+	for i := range refSegs {
+		a, b := refSegs[i], farSegs[i]
+		if absf32(a.X0-b.X0) > tol || absf32(a.Y0-b.Y0) > tol ||
+			absf32(a.X1-b.X1) > tol || absf32(a.Y1-b.Y1) > tol {
+			t.Fatalf("segment %d differs after translation: origin %+v, translated %+v", i, a, b)
+		}
+	}
+}
+
+func TestRebasePathToOrigin(t *testing.T) {
+	// Small paths pass through untouched.
+	small := scene.NewPath().Rectangle(10, 10, 20, 20)
+	rebased, ox, oy := RebasePathToOrigin(small)
+	if rebased != small || ox != 0 || oy != 0 {
+		t.Error("path near the origin should be returned unchanged")
+	}
+
+	// Large paths are translated so their bounds origin is (0, 0).
+	large := scene.NewPath().Rectangle(3e6, 4e6, 100, 50)
+	rebased, ox, oy = RebasePathToOrigin(large)
+	if ox != 3e6 || oy != 4e6 {
+		t.Errorf("offset = (%v, %v), want (3e6, 4e6)", ox, oy)
+	}
+	b := rebased.Bounds()
+	if b.MinX != 0 || b.MinY != 0 {
+		t.Errorf("rebased bounds origin = (%v, %v), want (0, 0)", b.MinX, b.MinY)
+	}
+	if b.MaxX != 100 || b.MaxY != 50 {
+		t.Errorf("rebased bounds extent = (%v, %v), want (100, 50)", b.MaxX, b.MaxY)
+	}
+}
+
+func TestFoldOffsetIntoTransform(t *testing.T) {
+	// transform ∘ translate(offset) applied to rebased coordinates must
+	// land where transform applied to the originals would, in exact math.
+	transform := scene.NewAffine(2, 0, -2e6, 0, 2, -4e6)
+	const ox, oy = float32(1e6), float32(2e6)
+
+	folded := FoldOffsetIntoTransform(transform, ox, oy)
+
+	// Original point (1e6+30, 2e6+40) rebases to (30, 40).
+	gotX, gotY := folded.TransformPoint(30, 40)
+	wantX := float64(2)*(1e6+30) - 2e6 // 60
+	wantY := float64(2)*(2e6+40) - 4e6 // 80
+	if math.Abs(float64(gotX)-wantX) > 1e-3 || math.Abs(float64(gotY)-wantY) > 1e-3 {
+		t.Errorf("folded transform maps to (%v, %v), want (%v, %v)", gotX, gotY, wantX, wantY)
+	}
+}
+
+func TestFlattenPathAutoLargeCoordinates(t *testing.T) {
+	// End to end through the rasterizer: a shape stored at large
+	// coordinates and panned into view must rasterize coverage inside the
+	// viewport via the auto-selected f64 path.
+	const offset = float32(1 << 21)
+	path := scene.NewPath().Rectangle(offset+20, offset+20, 40, 40)
+
+	ssr := NewSparseStripsRasterizer(DefaultConfig(100, 100))
+	ssr.RasterizePath(path, scene.TranslateAffine(-offset, -offset), FlattenTolerance)
+
+	stats := ssr.GetStats()
+	if stats.SegmentCount == 0 {
+		t.Fatal("no segments produced for large-coordinate path")
+	}
+	if stats.ActiveTileCount == 0 {
+		t.Fatal("no tiles covered for large-coordinate path")
+	}
+
+	minX, minY, maxX, maxY := ssr.Segments().Bounds()
+	if minX < -1 || minY < -1 || maxX > 101 || maxY > 101 {
+		t.Errorf("segment bounds (%v,%v)-(%v,%v) escape the viewport", minX, minY, maxX, maxY)
+	}
+}
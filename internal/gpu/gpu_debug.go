@@ -0,0 +1,98 @@
+// Copyright 2026 The gogpu Authors
+// SPDX-License-Identifier: MIT
+
+//go:build !nogpu
+
+package gpu
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/gogpu/gputypes"
+	"github.com/gogpu/wgpu"
+)
+
+// GPU debug mode: validation-layer capture and error surfacing.
+//
+// When enabled (GOGPU_GPU_DEBUG=1), instance creation requests debug and
+// validation layers from the underlying HAL, and critical operations run
+// inside device error scopes. Captured GPU-side diagnostics are logged and
+// attached to returned errors, so failures that would otherwise manifest
+// as silent zero output (e.g. a shader writing through a mistranslated
+// storage binding) surface an actionable message instead.
+
+var (
+	gpuDebugOnce sync.Once
+	gpuDebugOn   bool
+)
+
+// gpuDebugEnabled reports whether GPU debug mode is enabled via the
+// GOGPU_GPU_DEBUG environment variable. The value is read once; set the
+// variable before the first GPU operation.
+func gpuDebugEnabled() bool {
+	gpuDebugOnce.Do(func() {
+		gpuDebugOn = parseGPUDebug(os.Getenv("GOGPU_GPU_DEBUG"))
+	})
+	return gpuDebugOn
+}
+
+// parseGPUDebug interprets the GOGPU_GPU_DEBUG value: any non-empty value
+// other than "0" enables debug mode.
+func parseGPUDebug(v string) bool {
+	return v != "" && v != "0"
+}
+
+// debugInstanceFlags returns the instance flags for the current debug mode:
+// debug and validation layers when GPU debug is enabled, none otherwise.
+func debugInstanceFlags() gputypes.InstanceFlags {
+	if gpuDebugEnabled() {
+		return gputypes.InstanceFlagsDebug | gputypes.InstanceFlagsValidation
+	}
+	return gputypes.InstanceFlagsNone
+}
+
+// captureGPUErrors runs fn and, when GPU debug mode is enabled, brackets it
+// with device error scopes for validation, out-of-memory, and internal
+// errors. Captured GPU errors are logged via slog and attached to the
+// returned error; if fn itself succeeds but the device reported errors,
+// a new error carrying the diagnostics is returned. With debug mode off
+// (or a nil device) fn runs unwrapped with zero overhead.
+func captureGPUErrors(device *wgpu.Device, op string, fn func() error) error {
+	if device == nil || !gpuDebugEnabled() {
+		return fn()
+	}
+
+	filters := []wgpu.ErrorFilter{
+		wgpu.ErrorFilterValidation,
+		wgpu.ErrorFilterOutOfMemory,
+		wgpu.ErrorFilterInternal,
+	}
+	for _, f := range filters {
+		device.PushErrorScope(f)
+	}
+
+	fnErr := fn()
+
+	var msgs []string
+	for range filters {
+		if gpuErr := device.PopErrorScope(); gpuErr != nil {
+			slogger().Error("gpu-debug: device error captured",
+				"op", op,
+				"error", gpuErr.Error(),
+			)
+			msgs = append(msgs, gpuErr.Error())
+		}
+	}
+	if len(msgs) == 0 {
+		return fnErr
+	}
+
+	diag := strings.Join(msgs, "; ")
+	if fnErr != nil {
+		return fmt.Errorf("%w (gpu diagnostics: %s)", fnErr, diag)
+	}
+	return fmt.Errorf("gpu: %s: device reported errors: %s", op, diag)
+}
@@ -0,0 +1,50 @@
+// Copyright 2026 The gogpu Authors
+// SPDX-License-Identifier: MIT
+
+//go:build !nogpu
+
+package gpu
+
+import (
+	"errors"
+	"testing"
+)
+
+// TestParseGPUDebug covers the GOGPU_GPU_DEBUG value interpretation.
+func TestParseGPUDebug(t *testing.T) {
+	tests := []struct {
+		value string
+		want  bool
+	}{
+		{"", false},
+		{"0", false},
+		{"1", true},
+		{"true", true},
+	}
+	for _, tt := range tests {
+		if got := parseGPUDebug(tt.value); got != tt.want {
+			t.Errorf("parseGPUDebug(%q) = %v, want %v", tt.value, got, tt.want)
+		}
+	}
+}
+
+// TestCaptureGPUErrorsNilDevice verifies that with no device the wrapper is
+// a transparent passthrough: fn runs and its error is returned unchanged.
+func TestCaptureGPUErrorsNilDevice(t *testing.T) {
+	ran := false
+	if err := captureGPUErrors(nil, "test", func() error {
+		ran = true
+		return nil
+	}); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if !ran {
+		t.Error("fn was not invoked")
+	}
+
+	want := errors.New("stage failed")
+	err := captureGPUErrors(nil, "test", func() error { return want })
+	if !errors.Is(err, want) {
+		t.Errorf("error = %v, want %v", err, want)
+	}
+}
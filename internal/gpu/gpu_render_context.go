@@ -4,6 +4,7 @@ package gpu
 
 import (
 	"fmt"
+	"math"
 	"unsafe"
 
 	"github.com/gogpu/gg"
@@ -717,6 +718,13 @@ func (rc *GPURenderContext) StrokePath(target gg.GPURenderTarget, path *gg.Path,
 		return gg.ErrFallbackToCPU
 	}
 
+	// Gradient and pattern stroke brushes need per-pixel sampling; the GPU
+	// draw command carries a single color, so non-solid strokes fall back
+	// to the software renderer.
+	if _, solid := paint.StrokeSolidColor(); !solid {
+		return gg.ErrFallbackToCPU
+	}
+
 	rc.sceneStats.PathCount++
 	rc.sceneStats.ShapeCount++
 
@@ -750,6 +758,11 @@ func (rc *GPURenderContext) StrokePath(target gg.GPURenderTarget, path *gg.Path,
 		clipPath:  rc.clipPath,
 	}
 	cmd.paint.ClipCoverage = nil //nolint:staticcheck // M-1: intentional clear of deprecated stale closure
+	// Collapse the effective stroke color into the paint copy's fill slot so
+	// flush-time color extraction sees the stroke brush, not the fill brush.
+	if color, ok := paint.StrokeSolidColor(); ok {
+		cmd.paint.SetBrush(gg.Solid(color))
+	}
 
 	// Pre-tessellate at draw time: expand stroke geometry, then tessellate
 	// the expanded fill path (ADR-051 fix). The expanded path replaces
@@ -802,6 +815,123 @@ func (rc *GPURenderContext) FillShape(target gg.GPURenderTarget, shape gg.Detect
 	return nil
 }
 
+// FillShapeInstanced queues one copy of the shape per transform, all sharing
+// the current clip snapshot. Because consecutive same-clip fill shapes land in
+// a single ScissorGroup, every instance is rendered from one vertex buffer in
+// one SDF render pass draw at Flush — the instanced fast path the
+// gg.InstancedShapeAware contract asks for.
+//
+// Returns gg.ErrFallbackToCPU when an instance cannot be expressed as an SDF
+// quad: non-axis-aligned transforms, non-uniform scaling of a rounded
+// rectangle, non-solid paints, or shape kinds outside circle/ellipse/rect/
+// rrect. Nothing is queued in that case, so the caller's per-instance
+// fallback renders all instances.
+func (rc *GPURenderContext) FillShapeInstanced(target gg.GPURenderTarget, shape gg.DetectedShape, transforms []gg.Matrix, paint *gg.Paint) error {
+	// Gradient and pattern brushes need per-pixel sampling; the SDF pipeline
+	// renders flat colors only.
+	if !paintIsSolid(paint) {
+		return gg.ErrFallbackToCPU
+	}
+
+	// Compute mode has no instanced path; let the caller fall back so each
+	// instance routes through FillShape's Vello delegation.
+	if rc.pipelineMode == gg.PipelineModeCompute {
+		return gg.ErrFallbackToCPU
+	}
+
+	// Validate and transform every instance before queuing anything, so a
+	// rejected instance never leaves a partial batch behind.
+	instances := make([]gg.DetectedShape, len(transforms))
+	for i, t := range transforms {
+		inst, ok := instanceShape(shape, t)
+		if !ok {
+			return gg.ErrFallbackToCPU
+		}
+		instances[i] = inst
+	}
+
+	if rc.hasPendingTarget && !sameTarget(&rc.pendingTarget, &target) {
+		if err := rc.Flush(rc.pendingTarget); err != nil {
+			return err
+		}
+	}
+
+	rc.sceneStats.ShapeCount += len(instances)
+
+	// One clip snapshot shared by the whole batch: the snapshots are
+	// read-only after queuing, and identical clip state is what keeps the
+	// batch in a single ScissorGroup.
+	clipRect := copyClipRect(rc.clipRect)
+	clipRRect := copyClipRRect(rc.clipRRect)
+	for _, inst := range instances {
+		cmd := drawCommand{
+			kind:      drawCmdFillShape,
+			shape:     inst,
+			paint:     *paint,
+			clipRect:  clipRect,
+			clipRRect: clipRRect,
+			clipPath:  rc.clipPath,
+		}
+		cmd.paint.ClipCoverage = nil //nolint:staticcheck // M-1: intentional clear of deprecated stale closure
+		rc.pendingDraws = append(rc.pendingDraws, cmd)
+	}
+
+	rc.pendingTarget = target
+	rc.hasPendingTarget = true
+	return nil
+}
+
+// instanceShape applies a device-space instance transform to a canonical
+// shape, producing the shape an SDF quad can render. Reports false for
+// transforms with rotation or shear, for non-uniform scaling of a rounded
+// rectangle, and for shape kinds the SDF pipeline does not handle.
+func instanceShape(shape gg.DetectedShape, m gg.Matrix) (gg.DetectedShape, bool) {
+	const eps = 1e-9
+	if math.Abs(m.B) > eps || math.Abs(m.D) > eps {
+		return shape, false
+	}
+	sx, sy := math.Abs(m.A), math.Abs(m.E)
+
+	out := shape
+	center := m.TransformPoint(gg.Point{X: shape.CenterX, Y: shape.CenterY})
+	out.CenterX, out.CenterY = center.X, center.Y
+
+	switch shape.Kind {
+	case gg.ShapeCircle, gg.ShapeEllipse:
+		out.RadiusX *= sx
+		out.RadiusY *= sy
+		if shape.Kind == gg.ShapeCircle && math.Abs(sx-sy) > eps {
+			out.Kind = gg.ShapeEllipse
+		}
+	case gg.ShapeRect, gg.ShapeRRect:
+		out.Width *= sx
+		out.Height *= sy
+		if shape.CornerRadius > 0 {
+			// Non-uniform scaling turns the corner arcs elliptical, which
+			// the rrect SDF cannot represent.
+			if math.Abs(sx-sy) > eps {
+				return shape, false
+			}
+			out.CornerRadius *= sx
+		}
+	default:
+		return shape, false
+	}
+	return out, true
+}
+
+// paintIsSolid reports whether the paint resolves to a single flat color.
+func paintIsSolid(paint *gg.Paint) bool {
+	if _, ok := paint.SolidColor(); ok {
+		return true
+	}
+	if paint.Brush == nil {
+		return true
+	}
+	_, ok := paint.Brush.(gg.SolidBrush)
+	return ok
+}
+
 // StrokeShape queues a stroked shape as a backend-agnostic draw command (ADR-051).
 // Thin strokes (< 2px) fall back to CPU geometric expansion because SDF annular
 // ring is thinner than the smoothstep AA zone (ADR-040).
@@ -813,6 +943,12 @@ func (rc *GPURenderContext) StrokeShape(target gg.GPURenderTarget, shape gg.Dete
 		return gg.ErrFallbackToCPU
 	}
 
+	// Non-solid stroke brushes fall back to CPU per-pixel sampling (see
+	// StrokePath).
+	if _, solid := paint.StrokeSolidColor(); !solid {
+		return gg.ErrFallbackToCPU
+	}
+
 	// Compute mode delegates directly to VelloAccelerator (separate pipeline).
 	if rc.pipelineMode == gg.PipelineModeCompute {
 		rc.shared.mu.Lock()
@@ -839,6 +975,11 @@ func (rc *GPURenderContext) StrokeShape(target gg.GPURenderTarget, shape gg.Dete
 		clipPath:  rc.clipPath,
 	}
 	cmd.paint.ClipCoverage = nil //nolint:staticcheck // M-1: intentional clear of deprecated stale closure
+	// Collapse the effective stroke color into the paint copy's fill slot
+	// (see StrokePath).
+	if color, ok := paint.StrokeSolidColor(); ok {
+		cmd.paint.SetBrush(gg.Solid(color))
+	}
 	rc.pendingDraws = append(rc.pendingDraws, cmd)
 
 	rc.pendingTarget = target
@@ -446,6 +446,7 @@ func (s *GPUShared) ensureTextEngine() {
 func (s *GPUShared) initGPU() error {
 	instance, err := wgpu.CreateInstance(&wgpu.InstanceDescriptor{
 		Backends: wgpu.BackendsVulkan,
+		Flags:    debugInstanceFlags(),
 	})
 	if err != nil {
 		return fmt.Errorf("create instance: %w", err)
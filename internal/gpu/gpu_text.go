@@ -37,6 +37,14 @@ type GPUTextEngine struct {
 
 	// pxRange is the MSDF distance range in pixels (typically 4.0).
 	pxRange float32
+
+	// diskCache persists per-font base atlases across processes.
+	// Nil until EnableDiskCache is called.
+	diskCache *msdf.DiskCache
+
+	// builtFonts tracks fonts whose base atlas has been built (or loaded
+	// from the disk cache), so the build runs once per font.
+	builtFonts map[uint64]bool
 }
 
 // NewGPUTextEngine creates a new GPU text engine with default configuration.
@@ -71,6 +79,110 @@ func NewGPUTextEngine() *GPUTextEngine {
 		msdfSize:        glyphSize,
 		msdfSizeCJK:     glyphSizeCJK,
 		pxRange:         pxRange,
+		builtFonts:      make(map[uint64]bool),
+	}
+}
+
+// EnableDiskCache enables persistence of per-font base atlases in the
+// given directory. On the first use of a font, the engine loads its atlas
+// from the cache (keyed by a checksum of the raw font data) instead of
+// regenerating it; freshly built atlases are stored back. Safe to call
+// before any LayoutText.
+func (e *GPUTextEngine) EnableDiskCache(dir string) error {
+	cache, err := msdf.NewDiskCache(dir)
+	if err != nil {
+		return err
+	}
+	e.mu.Lock()
+	e.diskCache = cache
+	e.mu.Unlock()
+	return nil
+}
+
+// ensureFontAtlas builds the base atlas for a font on its first use: the
+// printable ASCII set is batch-generated into shelf-packed pages and
+// preloaded into the Latin atlas manager, so subsequent LayoutText calls
+// are cache hits instead of per-glyph ad hoc generation. When the disk
+// cache is enabled, a previously built atlas is loaded instead and fresh
+// builds are persisted for the next process.
+//
+// CJK glyphs are not prefetched — the CJK set is too large to build
+// eagerly and display-size CJK text is rare (ADR-027); those glyphs are
+// batched per LayoutText call instead.
+//
+// Must be called with e.mu held.
+func (e *GPUTextEngine) ensureFontAtlas(source *text.FontSource, fontID uint64) {
+	if e.builtFonts[fontID] {
+		return
+	}
+	e.builtFonts[fontID] = true
+
+	parsed := source.Parsed()
+	var checksum uint64
+	if provider, ok := parsed.(text.RawFontDataProvider); ok {
+		if raw := provider.RawFontData(); raw != nil {
+			checksum = msdf.FontChecksum(raw)
+		}
+	}
+
+	cfg := e.atlasManager.Config()
+
+	// Disk cache hit: adopt the prebuilt pages directly.
+	if e.diskCache != nil && checksum != 0 {
+		if built, err := e.diskCache.Load(checksum, cfg); err == nil {
+			if err := e.atlasManager.Preload(built); err == nil {
+				slogger().Info("font atlas loaded from disk cache",
+					"font", parsed.FullName(), "pages", len(built.Pages), "glyphs", len(built.Regions))
+				return
+			}
+		}
+	}
+
+	builder, err := msdf.NewAtlasBuilder(cfg)
+	if err != nil {
+		return
+	}
+	builder.Generator().SetConfig(e.atlasManager.Generator().Config())
+
+	// Batch the printable ASCII range at the default variation instance.
+	refSize := float64(e.msdfSize)
+	for r := rune('!'); r <= '~'; r++ {
+		gid := parsed.GlyphIndex(r)
+		if gid == 0 {
+			continue
+		}
+		outline, err := e.extractor.ExtractOutlineHinted(parsed, text.GlyphID(gid), refSize, text.HintingNone)
+		if err != nil || outline == nil || outline.IsEmpty() {
+			continue
+		}
+		builder.Add(msdf.GlyphKey{
+			FontID:  fontID,
+			GlyphID: gid,
+			Size:    int16(e.msdfSize), //nolint:gosec // msdfSize fits int16
+		}, outline)
+	}
+	if builder.Len() == 0 {
+		return
+	}
+
+	built, err := builder.Build()
+	if err != nil {
+		slogger().Warn("font atlas build failed", "font", parsed.FullName(), "err", err)
+		return
+	}
+	built.FontChecksum = checksum
+
+	if err := e.atlasManager.Preload(built); err != nil {
+		slogger().Warn("font atlas preload failed", "font", parsed.FullName(), "err", err)
+		return
+	}
+	slogger().Info("font atlas built",
+		"font", parsed.FullName(), "pages", len(built.Pages), "glyphs", len(built.Regions))
+
+	if e.diskCache != nil && checksum != 0 {
+		if err := e.diskCache.Store(built); err != nil {
+			slogger().Warn("font atlas disk cache store failed", "font", parsed.FullName(), "err", err)
+		}
 	}
 }
 
@@ -120,6 +232,7 @@ func (e *GPUTextEngine) LayoutText(
 	}
 	fontSource := face.Source()
 	fontID := computeFontID(fontSource)
+	e.ensureFontAtlas(fontSource, fontID)
 
 	// ADR-054: pass variations for variable font gvar deltas.
 	variations := face.Variations()
@@ -139,7 +252,6 @@ func (e *GPUTextEngine) LayoutText(
 	}
 	atlasConfig := activeAtlas.Config()
 
-	var quads []TextQuad
 	var glyphCount, outlineSkip, atlasSkip, boundsSkip int
 
 	// Scale ratio: outline is extracted at msdfSize, quad positions are in user space.
@@ -149,22 +261,31 @@ func (e *GPUTextEngine) LayoutText(
 	// (BUG-MSDF-RETINA-001: was fontSize/refSize which doubled positions on Retina)
 	refSize := float64(e.msdfSize)
 	refSizeCJK := float64(e.msdfSizeCJK)
-	var ratio float64
+
+	// First pass: extract outlines and collect atlas requests. Glyphs are
+	// resolved in one GetBatch per atlas manager instead of one Get per
+	// glyph — after ensureFontAtlas this is typically all cache hits, and
+	// misses are generated together under a single lock acquisition.
+	type pendingQuad struct {
+		x     float64 // pen position
+		ratio float64 // outline-space to user-space scale
+		cjk   bool    // which batch the glyph belongs to
+		slot  int     // index into that batch's regions
+	}
+	var pending []pendingQuad
+	var latinKeys, cjkKeys []msdf.GlyphKey
+	var latinOutlines, cjkOutlines []*text.GlyphOutline
 
 	for glyph := range face.Glyphs(s) {
 		glyphCount++
 
 		// ADR-027: CJK display text uses 128px reference for dense strokes.
 		glyphRefSize := refSize
-		glyphAtlas := activeAtlas
 		glyphMsdfSize := e.msdfSize
-		if text.IsCJKRune(glyph.Rune) {
+		cjk := text.IsCJKRune(glyph.Rune)
+		if cjk {
 			glyphRefSize = refSizeCJK
-			glyphAtlas = e.cjkAtlasManager
 			glyphMsdfSize = e.msdfSizeCJK
-			ratio = logicalSize / refSizeCJK
-		} else {
-			ratio = logicalSize / refSize
 		}
 
 		outline, err := e.extractor.ExtractOutlineHintedVar(fontSource.Parsed(), glyph.GID, glyphRefSize, text.HintingNone, variations)
@@ -179,12 +300,49 @@ func (e *GPUTextEngine) LayoutText(
 			Size:          int16(glyphMsdfSize), //nolint:gosec // msdfSize fits int16
 			VariationHash: varHash,
 		}
-		region, err := glyphAtlas.Get(key, outline)
+
+		p := pendingQuad{x: x + glyph.X, ratio: logicalSize / glyphRefSize, cjk: cjk}
+		if cjk {
+			p.slot = len(cjkKeys)
+			cjkKeys = append(cjkKeys, key)
+			cjkOutlines = append(cjkOutlines, outline)
+		} else {
+			p.slot = len(latinKeys)
+			latinKeys = append(latinKeys, key)
+			latinOutlines = append(latinOutlines, outline)
+		}
+		pending = append(pending, p)
+	}
+
+	// Second pass: resolve regions in bulk, then build quads.
+	var latinRegions, cjkRegions []msdf.Region
+	if len(latinKeys) > 0 {
+		var err error
+		latinRegions, err = e.atlasManager.GetBatch(latinKeys, latinOutlines)
 		if err != nil {
-			slogger().Warn("MSDF atlas get failed", "gid", glyph.GID, "err", err)
-			atlasSkip++
-			continue
+			slogger().Warn("MSDF atlas batch failed", "glyphs", len(latinKeys), "err", err)
+			atlasSkip += len(latinKeys)
+		}
+	}
+	if len(cjkKeys) > 0 {
+		var err error
+		cjkRegions, err = e.cjkAtlasManager.GetBatch(cjkKeys, cjkOutlines)
+		if err != nil {
+			slogger().Warn("MSDF atlas batch failed", "glyphs", len(cjkKeys), "err", err)
+			atlasSkip += len(cjkKeys)
+		}
+	}
+
+	var quads []TextQuad
+	for _, p := range pending {
+		regions := latinRegions
+		if p.cjk {
+			regions = cjkRegions
+		}
+		if regions == nil {
+			continue // batch failed, counted in atlasSkip above
 		}
+		region := regions[p.slot]
 
 		// Skip empty/degenerate regions (e.g. space characters).
 		if region.PlaneMaxX <= region.PlaneMinX || region.PlaneMaxY <= region.PlaneMinY {
@@ -197,10 +355,10 @@ func (e *GPUTextEngine) LayoutText(
 		// to convert to screen pixels. This replaces the 15-line
 		// scale/padding recomputation that previously duplicated the
 		// generator's math.
-		qx0 := float32(x + glyph.X + float64(region.PlaneMinX)*ratio)
-		qx1 := float32(x + glyph.X + float64(region.PlaneMaxX)*ratio)
-		qy0 := float32(y + float64(region.PlaneMinY)*ratio)
-		qy1 := float32(y + float64(region.PlaneMaxY)*ratio)
+		qx0 := float32(p.x + float64(region.PlaneMinX)*p.ratio)
+		qx1 := float32(p.x + float64(region.PlaneMaxX)*p.ratio)
+		qy0 := float32(y + float64(region.PlaneMinY)*p.ratio)
+		qy1 := float32(y + float64(region.PlaneMaxY)*p.ratio)
 
 		quads = append(quads, TextQuad{
 			X0: qx0, Y0: qy0,
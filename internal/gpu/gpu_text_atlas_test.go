@@ -0,0 +1,107 @@
+//go:build !nogpu
+
+package gpu
+
+import (
+	"os"
+	"testing"
+
+	"github.com/gogpu/gg"
+	"github.com/gogpu/gg/text"
+	"golang.org/x/image/font/gofont/goregular"
+)
+
+func TestGPUTextEngine_FontAtlasPrebuilt(t *testing.T) {
+	src, err := text.NewFontSource(goregular.TTF)
+	if err != nil {
+		t.Fatalf("failed to load GoRegular: %v", err)
+	}
+	engine := NewGPUTextEngine()
+	face := src.Face(32)
+
+	batch, err := engine.LayoutText(face, "Hello", 0, 100, gg.RGBA{A: 1}, gg.Identity(), 1.0)
+	if err != nil {
+		t.Fatalf("LayoutText failed: %v", err)
+	}
+	if len(batch.Quads) == 0 {
+		t.Fatal("LayoutText produced no quads")
+	}
+
+	// The first use of a font batch-builds its ASCII base atlas, so the
+	// layout itself must be served entirely from prebuilt pages.
+	hits, misses, _ := engine.atlasManager.Stats()
+	if misses != 0 {
+		t.Errorf("layout after prebuild: hits=%d misses=%d, want 0 misses", hits, misses)
+	}
+	if engine.atlasManager.GlyphCount() < 90 {
+		t.Errorf("prebuilt glyph count = %d, want the printable ASCII set (>= 90)",
+			engine.atlasManager.GlyphCount())
+	}
+
+	// Prebuilt pages must be flagged for GPU upload.
+	if dirty := engine.DirtyAtlases(); len(dirty) == 0 {
+		t.Error("no dirty atlases after prebuild")
+	}
+}
+
+func TestGPUTextEngine_FontAtlasBuiltOnce(t *testing.T) {
+	src, err := text.NewFontSource(goregular.TTF)
+	if err != nil {
+		t.Fatalf("failed to load GoRegular: %v", err)
+	}
+	engine := NewGPUTextEngine()
+	face := src.Face(32)
+
+	if _, err := engine.LayoutText(face, "first", 0, 100, gg.RGBA{A: 1}, gg.Identity(), 1.0); err != nil {
+		t.Fatalf("LayoutText failed: %v", err)
+	}
+	pages := engine.atlasManager.AtlasCount()
+
+	if _, err := engine.LayoutText(face, "second", 0, 100, gg.RGBA{A: 1}, gg.Identity(), 1.0); err != nil {
+		t.Fatalf("LayoutText failed: %v", err)
+	}
+	if got := engine.atlasManager.AtlasCount(); got != pages {
+		t.Errorf("atlas count grew from %d to %d on second layout (base atlas rebuilt?)", pages, got)
+	}
+}
+
+func TestGPUTextEngine_DiskCache(t *testing.T) {
+	src, err := text.NewFontSource(goregular.TTF)
+	if err != nil {
+		t.Fatalf("failed to load GoRegular: %v", err)
+	}
+	dir := t.TempDir()
+
+	// First engine builds the atlas and persists it.
+	engine1 := NewGPUTextEngine()
+	if err := engine1.EnableDiskCache(dir); err != nil {
+		t.Fatalf("EnableDiskCache failed: %v", err)
+	}
+	if _, err := engine1.LayoutText(src.Face(32), "Hello", 0, 100, gg.RGBA{A: 1}, gg.Identity(), 1.0); err != nil {
+		t.Fatalf("LayoutText failed: %v", err)
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatalf("ReadDir failed: %v", err)
+	}
+	if len(entries) != 1 {
+		t.Fatalf("cache file count = %d, want 1", len(entries))
+	}
+
+	// Second engine loads the prebuilt atlas instead of regenerating.
+	engine2 := NewGPUTextEngine()
+	if err := engine2.EnableDiskCache(dir); err != nil {
+		t.Fatalf("EnableDiskCache failed: %v", err)
+	}
+	batch, err := engine2.LayoutText(src.Face(32), "Hello", 0, 100, gg.RGBA{A: 1}, gg.Identity(), 1.0)
+	if err != nil {
+		t.Fatalf("LayoutText failed: %v", err)
+	}
+	if len(batch.Quads) == 0 {
+		t.Fatal("LayoutText with cached atlas produced no quads")
+	}
+	_, misses, _ := engine2.atlasManager.Stats()
+	if misses != 0 {
+		t.Errorf("layout with cached atlas had %d misses, want 0", misses)
+	}
+}
@@ -0,0 +1,131 @@
+//go:build !nogpu
+
+package gpu
+
+import (
+	"math"
+	"testing"
+
+	"github.com/gogpu/gg"
+)
+
+// TestFillShapeInstancedQueuesBatch verifies that an instanced fill queues
+// one SDF shape per transform and that the whole batch lands in a single
+// ScissorGroup, i.e. a single SDF render pass draw at flush.
+func TestFillShapeInstancedQueuesBatch(t *testing.T) {
+	s := NewGPUShared()
+	rc := s.NewRenderContext()
+	defer rc.Close()
+
+	target := gg.GPURenderTarget{Data: make([]byte, 64*64*4), Width: 64, Height: 64, Stride: 256}
+	shape := gg.DetectedShape{Kind: gg.ShapeCircle, RadiusX: 3, RadiusY: 3}
+	paint := gg.NewPaint()
+	paint.SetBrush(gg.Solid(gg.Blue))
+
+	transforms := []gg.Matrix{
+		gg.Translate(10, 20),
+		gg.Translate(30, 20),
+		gg.Translate(50, 20),
+	}
+	if err := rc.FillShapeInstanced(target, shape, transforms, paint); err != nil {
+		t.Fatalf("FillShapeInstanced: %v", err)
+	}
+	if got := rc.PendingCount(); got != len(transforms) {
+		t.Fatalf("PendingCount = %d, want %d", got, len(transforms))
+	}
+
+	groups := rc.buildScissorGroupsFromDraws()
+	if len(groups) != 1 {
+		t.Fatalf("ScissorGroups = %d, want 1 (single draw for the batch)", len(groups))
+	}
+	if got := len(groups[0].SDFShapes); got != len(transforms) {
+		t.Fatalf("SDFShapes = %d, want %d", got, len(transforms))
+	}
+	for i, want := range [][2]float32{{10, 20}, {30, 20}, {50, 20}} {
+		rs := groups[0].SDFShapes[i]
+		if rs.CenterX != want[0] || rs.CenterY != want[1] {
+			t.Errorf("instance %d center = (%g, %g), want (%g, %g)",
+				i, rs.CenterX, rs.CenterY, want[0], want[1])
+		}
+	}
+}
+
+// TestFillShapeInstancedFallback verifies that batches the SDF pipeline
+// cannot express are rejected without queuing anything, so the caller's
+// per-instance path renders them instead.
+func TestFillShapeInstancedFallback(t *testing.T) {
+	circle := gg.DetectedShape{Kind: gg.ShapeCircle, RadiusX: 3, RadiusY: 3}
+	rrect := gg.DetectedShape{Kind: gg.ShapeRRect, Width: 10, Height: 8, CornerRadius: 2}
+	solid := gg.NewPaint()
+	solid.SetBrush(gg.Solid(gg.Red))
+	gradient := gg.NewPaint()
+	gradient.SetBrush(NewTestLinearGradient())
+
+	tests := []struct {
+		name       string
+		shape      gg.DetectedShape
+		transforms []gg.Matrix
+		paint      *gg.Paint
+	}{
+		{"rotation", circle, []gg.Matrix{gg.Rotate(math.Pi / 4)}, solid},
+		{"non-uniform rrect scale", rrect, []gg.Matrix{gg.Scale(2, 1)}, solid},
+		{"unknown shape", gg.DetectedShape{Kind: gg.ShapeUnknown}, []gg.Matrix{gg.Identity()}, solid},
+		{"gradient paint", circle, []gg.Matrix{gg.Identity()}, gradient},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := NewGPUShared()
+			rc := s.NewRenderContext()
+			defer rc.Close()
+
+			target := gg.GPURenderTarget{Data: make([]byte, 64*64*4), Width: 64, Height: 64, Stride: 256}
+			err := rc.FillShapeInstanced(target, tt.shape, tt.transforms, tt.paint)
+			if err != gg.ErrFallbackToCPU {
+				t.Errorf("err = %v, want ErrFallbackToCPU", err)
+			}
+			if got := rc.PendingCount(); got != 0 {
+				t.Errorf("PendingCount = %d after rejected batch, want 0", got)
+			}
+		})
+	}
+}
+
+// NewTestLinearGradient builds a minimal non-solid brush for fallback tests.
+func NewTestLinearGradient() gg.Brush {
+	return gg.NewLinearGradientBrush(0, 0, 100, 0).
+		AddColorStop(0, gg.Red).
+		AddColorStop(1, gg.Blue)
+}
+
+// TestInstanceShape exercises the per-instance transform mapping.
+func TestInstanceShape(t *testing.T) {
+	circle := gg.DetectedShape{Kind: gg.ShapeCircle, CenterX: 5, CenterY: 5, RadiusX: 2, RadiusY: 2}
+
+	// Uniform scale + translation keeps a circle a circle.
+	out, ok := instanceShape(circle, gg.Translate(10, 10).Multiply(gg.Scale(3, 3)))
+	if !ok {
+		t.Fatal("uniform scale rejected")
+	}
+	if out.Kind != gg.ShapeCircle || out.RadiusX != 6 || out.RadiusY != 6 {
+		t.Errorf("scaled circle = %+v, want radius 6 circle", out)
+	}
+
+	// Non-uniform scale turns a circle into an ellipse.
+	out, ok = instanceShape(circle, gg.Scale(2, 1))
+	if !ok {
+		t.Fatal("non-uniform circle scale rejected")
+	}
+	if out.Kind != gg.ShapeEllipse || out.RadiusX != 4 || out.RadiusY != 2 {
+		t.Errorf("non-uniform scaled circle = %+v, want 4x2 ellipse", out)
+	}
+
+	// Uniform scale of an rrect scales the corner radius.
+	rrect := gg.DetectedShape{Kind: gg.ShapeRRect, Width: 10, Height: 8, CornerRadius: 2}
+	out, ok = instanceShape(rrect, gg.Scale(2, 2))
+	if !ok {
+		t.Fatal("uniform rrect scale rejected")
+	}
+	if out.Width != 20 || out.Height != 16 || out.CornerRadius != 4 {
+		t.Errorf("scaled rrect = %+v, want 20x16 corner 4", out)
+	}
+}
@@ -0,0 +1,245 @@
+// Copyright 2026 The gogpu Authors
+// SPDX-License-Identifier: MIT
+
+//go:build !nogpu
+
+package gpu
+
+import (
+	"fmt"
+	"html"
+	"image"
+	"image/color"
+	"image/png"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"sync/atomic"
+)
+
+// Pipeline dump mode: per-stage intermediate buffer visualization.
+//
+// When GOGPU_DEBUG_DUMP is set to a directory path, every compute-scene
+// dispatch writes the intermediate buffers (tile backdrops, per-tile
+// coverage counts, PTCL command heatmaps) and the final output as
+// labeled PNGs into that directory, plus an index.html for browsing.
+// This generalizes logPipelineDiagnostics: instead of sampling buffer
+// words into the log, the full per-tile state becomes visible without
+// modifying code.
+
+var (
+	pipelineDumpOnce sync.Once
+	pipelineDumpPath string
+
+	// pipelineDumpFrame numbers dumped frames across the process.
+	pipelineDumpFrame atomic.Int64
+)
+
+// pipelineDumpDir returns the dump directory from GOGPU_DEBUG_DUMP, or
+// "" when dumping is disabled. The value is read once; set the variable
+// before the first GPU operation.
+func pipelineDumpDir() string {
+	pipelineDumpOnce.Do(func() {
+		pipelineDumpPath = os.Getenv("GOGPU_DEBUG_DUMP")
+	})
+	return pipelineDumpPath
+}
+
+// pathTileMeta is the per-path tile placement decoded from the Paths
+// buffer: bounding box in tile coordinates and the offset of the
+// path's tiles in the flat Tiles buffer.
+type pathTileMeta struct {
+	bbox     [4]uint32 // x0, y0, x1, y1 in tiles
+	tilesOff uint32
+}
+
+// parsePathTileMeta decodes the Paths buffer (5 u32 per path).
+func parsePathTileMeta(pathsBytes []byte, numPaths int) []pathTileMeta {
+	metas := make([]pathTileMeta, 0, numPaths)
+	for i := 0; i < numPaths && (i+1)*20 <= len(pathsBytes); i++ {
+		off := i * 20
+		var m pathTileMeta
+		for j := 0; j < 4; j++ {
+			m.bbox[j] = leU32(pathsBytes[off+j*4:])
+		}
+		m.tilesOff = leU32(pathsBytes[off+16:])
+		metas = append(metas, m)
+	}
+	return metas
+}
+
+// tileGrids accumulates the per-path Tiles buffer (backdrop i32 +
+// segment count u32, 8 bytes per tile) into two global tile grids:
+// summed backdrops and summed segment counts. Paths overlapping the
+// same tile add together, which is exactly the double-coverage
+// visibility the dump is for.
+func tileGrids(metas []pathTileMeta, tilesBytes []byte, wTiles, hTiles int) (backdrops, segCounts []float64) {
+	backdrops = make([]float64, wTiles*hTiles)
+	segCounts = make([]float64, wTiles*hTiles)
+	for _, m := range metas {
+		x0, y0, x1, y1 := int(m.bbox[0]), int(m.bbox[1]), int(m.bbox[2]), int(m.bbox[3])
+		local := 0
+		for ty := y0; ty < y1; ty++ {
+			for tx := x0; tx < x1; tx++ {
+				off := (int(m.tilesOff) + local) * 8
+				local++
+				if off+8 > len(tilesBytes) || tx < 0 || ty < 0 || tx >= wTiles || ty >= hTiles {
+					continue
+				}
+				backdrop := int32(leU32(tilesBytes[off:]))
+				segs := leU32(tilesBytes[off+4:])
+				idx := ty*wTiles + tx
+				backdrops[idx] += float64(backdrop)
+				segCounts[idx] += float64(segs)
+			}
+		}
+	}
+	return backdrops, segCounts
+}
+
+// ptclCommandCounts walks each tile's PTCL stream and counts commands.
+// Command sizes match the coarse stage output: end (0), fill (1, three
+// payload words), solid (3), color (5, one payload word). An unknown
+// command terminates the walk for that tile.
+func ptclCommandCounts(ptclBytes []byte, totalTiles int) []float64 {
+	counts := make([]float64, totalTiles)
+	for t := 0; t < totalTiles; t++ {
+		base := t * velloPTCLMaxPerTile
+		pos := 0
+		for pos < velloPTCLMaxPerTile {
+			off := (base + pos) * 4
+			if off+4 > len(ptclBytes) {
+				break
+			}
+			cmd := leU32(ptclBytes[off:])
+			if cmd == 0 {
+				break
+			}
+			counts[t]++
+			switch cmd {
+			case 1: // CMD_FILL: packed, seg_idx, backdrop
+				pos += 4
+			case 3: // CMD_SOLID
+				pos++
+			case 5: // CMD_COLOR: packed color
+				pos += 2
+			default:
+				pos = velloPTCLMaxPerTile
+			}
+		}
+	}
+	return counts
+}
+
+// leU32 reads a little-endian u32; bounds are the caller's problem.
+func leU32(b []byte) uint32 {
+	return uint32(b[0]) | uint32(b[1])<<8 | uint32(b[2])<<16 | uint32(b[3])<<24
+}
+
+// heatmapImage renders a grid of values as a diverging heatmap:
+// negative values blue, zero white, positive values red, scaled to the
+// largest magnitude. Each cell becomes a scale x scale pixel block so
+// tile grids are legible at native size.
+func heatmapImage(w, h int, vals []float64, scale int) *image.RGBA {
+	if scale < 1 {
+		scale = 1
+	}
+	maxMag := 0.0
+	for _, v := range vals {
+		if m := v; m < 0 {
+			m = -m
+			if m > maxMag {
+				maxMag = m
+			}
+		} else if m > maxMag {
+			maxMag = m
+		}
+	}
+	img := image.NewRGBA(image.Rect(0, 0, w*scale, h*scale))
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			v := 0.0
+			if i := y*w + x; i < len(vals) {
+				v = vals[i]
+			}
+			c := color.RGBA{255, 255, 255, 255}
+			if maxMag > 0 && v != 0 {
+				t := absF(v) / maxMag // 0..1
+				fade := uint8(255 * (1 - t))
+				if v > 0 {
+					c = color.RGBA{255, fade, fade, 255}
+				} else {
+					c = color.RGBA{fade, fade, 255, 255}
+				}
+			}
+			for dy := 0; dy < scale; dy++ {
+				for dx := 0; dx < scale; dx++ {
+					img.SetRGBA(x*scale+dx, y*scale+dy, c)
+				}
+			}
+		}
+	}
+	return img
+}
+
+func absF(v float64) float64 {
+	if v < 0 {
+		return -v
+	}
+	return v
+}
+
+// dumpWriter writes one frame's intermediates into the dump directory
+// and regenerates the HTML index.
+type dumpWriter struct {
+	dir   string
+	frame int64
+}
+
+// newDumpWriter creates the dump directory (if needed) and claims the
+// next frame number.
+func newDumpWriter(dir string) (*dumpWriter, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("gpu: create dump dir: %w", err)
+	}
+	return &dumpWriter{dir: dir, frame: pipelineDumpFrame.Add(1) - 1}, nil
+}
+
+// writePNG saves img under a frame- and stage-labeled name.
+func (d *dumpWriter) writePNG(stage string, img image.Image) error {
+	name := fmt.Sprintf("frame%03d_%s.png", d.frame, stage)
+	f, err := os.Create(filepath.Join(d.dir, name))
+	if err != nil {
+		return fmt.Errorf("gpu: create dump %s: %w", name, err)
+	}
+	defer f.Close()
+	if err := png.Encode(f, img); err != nil {
+		return fmt.Errorf("gpu: encode dump %s: %w", name, err)
+	}
+	return nil
+}
+
+// writeIndex regenerates index.html from the PNGs currently in the
+// dump directory, so the index stays complete across frames and runs.
+func (d *dumpWriter) writeIndex() error {
+	entries, err := filepath.Glob(filepath.Join(d.dir, "*.png"))
+	if err != nil {
+		return err
+	}
+	sort.Strings(entries)
+
+	var b []byte
+	b = append(b, "<!DOCTYPE html>\n<html><head><title>gg pipeline dump</title>\n"...)
+	b = append(b, "<style>body{font-family:monospace;background:#222;color:#eee}"...)
+	b = append(b, "figure{display:inline-block;margin:8px}img{image-rendering:pixelated;border:1px solid #555;max-width:400px}</style>\n"...)
+	b = append(b, "</head><body>\n<h1>gg pipeline dump</h1>\n"...)
+	for _, e := range entries {
+		name := filepath.Base(e)
+		b = append(b, fmt.Sprintf("<figure><img src=%q alt=%q><figcaption>%s</figcaption></figure>\n",
+			name, name, html.EscapeString(name))...)
+	}
+	b = append(b, "</body></html>\n"...)
+
+	return os.WriteFile(filepath.Join(d.dir, "index.html"), b, 0o644)
+}
@@ -0,0 +1,143 @@
+// Copyright 2026 The gogpu Authors
+// SPDX-License-Identifier: MIT
+
+//go:build !nogpu
+
+package gpu
+
+import (
+	"encoding/binary"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// buildTilesBytes packs (backdrop, segCount) pairs as the Tiles buffer.
+func buildTilesBytes(tiles [][2]int32) []byte {
+	b := make([]byte, len(tiles)*8)
+	for i, t := range tiles {
+		binary.LittleEndian.PutUint32(b[i*8:], uint32(t[0]))
+		binary.LittleEndian.PutUint32(b[i*8+4:], uint32(t[1]))
+	}
+	return b
+}
+
+// TestParsePathTileMeta tests decoding of the Paths buffer layout.
+func TestParsePathTileMeta(t *testing.T) {
+	b := make([]byte, 2*20)
+	vals := []uint32{1, 2, 3, 4, 0, 0, 1, 2, 3, 6}
+	for i, v := range vals {
+		binary.LittleEndian.PutUint32(b[i*4:], v)
+	}
+	metas := parsePathTileMeta(b, 2)
+	if len(metas) != 2 {
+		t.Fatalf("parsed %d metas, want 2", len(metas))
+	}
+	if metas[0].bbox != [4]uint32{1, 2, 3, 4} || metas[0].tilesOff != 0 {
+		t.Errorf("metas[0] = %+v", metas[0])
+	}
+	if metas[1].bbox != [4]uint32{0, 1, 2, 3} || metas[1].tilesOff != 6 {
+		t.Errorf("metas[1] = %+v", metas[1])
+	}
+
+	// Truncated buffer yields only the complete entries.
+	if got := parsePathTileMeta(b[:25], 2); len(got) != 1 {
+		t.Errorf("truncated parse yielded %d metas, want 1", len(got))
+	}
+}
+
+// TestTileGrids tests accumulation of per-path tiles into the global
+// grid, including overlapping paths and out-of-range tiles.
+func TestTileGrids(t *testing.T) {
+	// Path 0 covers tiles (0,0)-(2,1): a 2x1 bbox at offset 0.
+	// Path 1 covers tile (1,0) only, at offset 2, overlapping path 0.
+	metas := []pathTileMeta{
+		{bbox: [4]uint32{0, 0, 2, 1}, tilesOff: 0},
+		{bbox: [4]uint32{1, 0, 2, 1}, tilesOff: 2},
+	}
+	tiles := buildTilesBytes([][2]int32{
+		{1, 3},  // path 0, tile (0,0)
+		{-1, 2}, // path 0, tile (1,0)
+		{2, 5},  // path 1, tile (1,0)
+	})
+	backdrops, segs := tileGrids(metas, tiles, 2, 1)
+	if backdrops[0] != 1 || backdrops[1] != 1 { // -1 + 2
+		t.Errorf("backdrops = %v, want [1 1]", backdrops)
+	}
+	if segs[0] != 3 || segs[1] != 7 { // 2 + 5
+		t.Errorf("segCounts = %v, want [3 7]", segs)
+	}
+}
+
+// TestPtclCommandCounts tests the PTCL stream walker against the
+// command sizes the coarse stage emits.
+func TestPtclCommandCounts(t *testing.T) {
+	words := make([]uint32, 2*velloPTCLMaxPerTile)
+	// Tile 0: FILL (3 payload words), COLOR (1 payload word), end.
+	words[0] = 1
+	words[4] = 5
+	words[6] = 0
+	// Tile 1: SOLID, COLOR, end.
+	words[velloPTCLMaxPerTile] = 3
+	words[velloPTCLMaxPerTile+1] = 5
+	b := make([]byte, len(words)*4)
+	for i, w := range words {
+		binary.LittleEndian.PutUint32(b[i*4:], w)
+	}
+
+	counts := ptclCommandCounts(b, 2)
+	if counts[0] != 2 || counts[1] != 2 {
+		t.Errorf("counts = %v, want [2 2]", counts)
+	}
+}
+
+// TestHeatmapImage tests palette mapping and scaling.
+func TestHeatmapImage(t *testing.T) {
+	img := heatmapImage(2, 1, []float64{4, -4}, 3)
+	if got := img.Bounds().Dx(); got != 6 {
+		t.Fatalf("width = %d, want 6", got)
+	}
+	pos := img.RGBAAt(0, 0)
+	neg := img.RGBAAt(3, 0)
+	if pos.R != 255 || pos.B == 255 {
+		t.Errorf("positive cell = %v, want red", pos)
+	}
+	if neg.B != 255 || neg.R == 255 {
+		t.Errorf("negative cell = %v, want blue", neg)
+	}
+
+	// All-zero grid renders white.
+	white := heatmapImage(1, 1, []float64{0}, 1).RGBAAt(0, 0)
+	if white.R != 255 || white.G != 255 || white.B != 255 {
+		t.Errorf("zero cell = %v, want white", white)
+	}
+}
+
+// TestDumpWriter tests PNG naming and index generation.
+func TestDumpWriter(t *testing.T) {
+	dir := t.TempDir()
+	d, err := newDumpWriter(dir)
+	if err != nil {
+		t.Fatalf("newDumpWriter: %v", err)
+	}
+	img := heatmapImage(2, 2, []float64{1, 0, 0, -1}, 4)
+	if err := d.writePNG("backdrops", img); err != nil {
+		t.Fatalf("writePNG: %v", err)
+	}
+	if err := d.writeIndex(); err != nil {
+		t.Fatalf("writeIndex: %v", err)
+	}
+
+	pngs, _ := filepath.Glob(filepath.Join(dir, "*_backdrops.png"))
+	if len(pngs) != 1 {
+		t.Fatalf("found %d backdrops PNGs, want 1", len(pngs))
+	}
+	idx, err := os.ReadFile(filepath.Join(dir, "index.html"))
+	if err != nil {
+		t.Fatalf("read index: %v", err)
+	}
+	if !strings.Contains(string(idx), filepath.Base(pngs[0])) {
+		t.Error("index.html does not reference the dumped PNG")
+	}
+}
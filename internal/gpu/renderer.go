@@ -394,6 +394,11 @@ func (r *GPUSceneRenderer) processCommandsWithContext(ctx context.Context, dec *
 		case tag == scene.TagImage:
 			imageIdx, transform := dec.Image()
 			r.renderImage(imageIdx, transform)
+		case tag == scene.TagImageEx:
+			// Sampling options map to sampler state once textured quads are
+			// implemented; for now decode to keep the stream cursors in sync.
+			imageIdx, _, _, _, transform := dec.ImageEx()
+			r.renderImage(imageIdx, transform)
 		}
 	}
 
@@ -44,6 +44,7 @@ var _ gg.PipelineModeAware = (*SDFAccelerator)(nil)
 var _ gg.ComputePipelineAware = (*SDFAccelerator)(nil)
 var _ gg.ForceSDFAware = (*SDFAccelerator)(nil)
 var _ gg.ClipAware = (*SDFAccelerator)(nil)
+var _ gg.InstancedShapeAware = (*SDFAccelerator)(nil)
 var _ gg.RRectClipAware = (*SDFAccelerator)(nil)
 var _ gg.LCDLayoutAware = (*SDFAccelerator)(nil)
 
@@ -283,6 +284,18 @@ func (a *SDFAccelerator) FillShape(target gg.GPURenderTarget, shape gg.DetectedS
 	return rc.FillShape(target, shape, paint)
 }
 
+// FillShapeInstanced accumulates one copy of the shape per transform via the
+// default context, rendered as a single SDF quad batch at flush. Implements
+// gg.InstancedShapeAware; returns gg.ErrFallbackToCPU for instances the SDF
+// pipeline cannot express (see GPURenderContext.FillShapeInstanced).
+func (a *SDFAccelerator) FillShapeInstanced(target gg.GPURenderTarget, shape gg.DetectedShape, transforms []gg.Matrix, paint *gg.Paint) error {
+	a.mu.Lock()
+	a.ensureDefaultCtx()
+	rc := a.defaultCtx
+	a.mu.Unlock()
+	return rc.FillShapeInstanced(target, shape, transforms, paint)
+}
+
 // StrokeShape accumulates a stroked shape via the default context.
 func (a *SDFAccelerator) StrokeShape(target gg.GPURenderTarget, shape gg.DetectedShape, paint *gg.Paint) error {
 	a.mu.Lock()
@@ -7,7 +7,9 @@ import (
 	_ "embed"
 	"errors"
 	"fmt"
+	"strings"
 
+	"github.com/gogpu/gg"
 	"github.com/gogpu/gg/scene"
 )
 
@@ -104,9 +106,35 @@ func GetBlitShaderSource() string {
 	return blitShaderSource
 }
 
+// customBlendCasesMarker is the line in blend.wgsl's blend_separable
+// switch where generated dispatch cases for custom modes are spliced in.
+const customBlendCasesMarker = "//CUSTOM_BLEND_CASES"
+
 // GetBlendShaderSource returns the WGSL source for the blend shader.
+// Custom blend modes registered via gg.RegisterBlendMode with a WGSL
+// snippet are appended as blend_custom_<mode> functions, and matching
+// dispatch cases are generated in blend_separable's switch so the modes
+// are reachable alongside the 29 built-in ones.
 func GetBlendShaderSource() string {
-	return blendShaderSource
+	custom := gg.CustomBlendModes()
+	if len(custom) == 0 {
+		return blendShaderSource
+	}
+	var fns, cases strings.Builder
+	for _, m := range custom {
+		if m.WGSL == "" {
+			continue
+		}
+		fmt.Fprintf(&fns, "\n// Custom blend mode %q\nfn blend_custom_%d(src: vec3<f32>, dst: vec3<f32>) -> vec3<f32> {\n%s\n}\n",
+			m.Name, m.Mode, m.WGSL)
+		fmt.Fprintf(&cases, "case %du: {\n            return blend_custom_%d(src, dst);\n        }\n        ",
+			m.Mode, m.Mode)
+	}
+	if fns.Len() == 0 {
+		return blendShaderSource
+	}
+	src := strings.Replace(blendShaderSource, customBlendCasesMarker, cases.String()+customBlendCasesMarker, 1)
+	return src + fns.String()
 }
 
 // GetStripShaderSource returns the WGSL source for the strip shader.
@@ -282,6 +282,9 @@ fn blend_separable(src: vec3<f32>, dst: vec3<f32>, mode: u32) -> vec3<f32> {
         case BLEND_EXCLUSION: {
             return blend_exclusion(src, dst);
         }
+        // Dispatch cases for registered custom blend modes are generated
+        // here by GetBlendShaderSource.
+        //CUSTOM_BLEND_CASES
         default: {
             return src;
         }
@@ -10,7 +10,8 @@ struct Strip {
     y: i32,              // Row index (scanline number)
     x: i32,              // Start X coordinate
     width: i32,          // Number of pixels in this strip
-    coverage_offset: i32, // Offset into coverage array (byte index)
+    coverage_offset: i32, // Offset into coverage array (byte index for raw
+                          // coverage, word index of the first run for RLE)
 }
 
 // Parameters for strip rasterization
@@ -77,6 +78,69 @@ fn cs_main(@builtin(global_invocation_id) gid: vec3<u32>) {
     }
 }
 
+// --- RLE coverage decoding ---
+//
+// When strips are packed with StripPacker.PackRLE in Go, the coverage
+// buffer holds runs instead of raw bytes: one u32 per run, coverage value
+// in the low byte and run length in the upper 24 bits. A strip's
+// coverage_offset is then the WORD index of its first run, and its runs
+// cover exactly `width` pixels. Solid interiors collapse to a single run,
+// so large fills upload a few words per scanline instead of one byte per
+// pixel.
+
+// Decode the coverage value of an RLE run (low byte).
+fn rle_value(run: u32) -> f32 {
+    return f32(run & 0xFFu) / 255.0;
+}
+
+// Decode the pixel length of an RLE run (upper 24 bits).
+fn rle_length(run: u32) -> i32 {
+    return i32(run >> 8u);
+}
+
+// Entry point for strips with run-length encoded coverage.
+@compute @workgroup_size(64, 1, 1)
+fn cs_main_rle(@builtin(global_invocation_id) gid: vec3<u32>) {
+    let strip_idx = i32(gid.x);
+
+    if strip_idx >= params.strip_count {
+        return;
+    }
+
+    let strip = strips[strip_idx];
+    let y = strip.y;
+    if y < 0 || y >= params.target_height {
+        return;
+    }
+
+    var run_idx = strip.coverage_offset;
+    var x = strip.x;
+    let end_x = strip.x + strip.width;
+
+    while x < end_x {
+        let run = coverage[run_idx];
+        run_idx = run_idx + 1;
+
+        let cov = rle_value(run);
+        let run_end = min(x + rle_length(run), end_x);
+
+        // Zero-coverage runs only advance the cursor.
+        if cov > 0.0 {
+            let final_color = vec4<f32>(
+                params.color.rgb * cov,
+                params.color.a * cov
+            );
+            for (var px = x; px < run_end; px = px + 1) {
+                if px >= 0 && px < params.target_width {
+                    textureStore(output, vec2<i32>(px, y), final_color);
+                }
+            }
+        }
+
+        x = run_end;
+    }
+}
+
 // Alternative entry point for accumulating coverage (for multi-pass rendering)
 // This reads existing values and blends with Source Over
 @compute @workgroup_size(64, 1, 1)
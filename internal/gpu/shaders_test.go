@@ -3,9 +3,11 @@
 package gpu
 
 import (
+	"fmt"
 	"strings"
 	"testing"
 
+	"github.com/gogpu/gg"
 	"github.com/gogpu/gg/scene"
 )
 
@@ -34,6 +36,50 @@ func TestShaderSourcesNonEmpty(t *testing.T) {
 }
 
 // TestShaderSourcesContainExpectedContent verifies shader sources contain key elements.
+// TestBlendShaderCustomModes verifies that registered custom blend modes
+// are appended to the generated blend shader source.
+func TestBlendShaderCustomModes(t *testing.T) {
+	mode, err := gg.RegisterBlendMode("TestShaderLinearBurn", func(s, d float64) float64 {
+		return s + d - 1
+	}, "return src + dst - vec3<f32>(1.0);")
+	if err != nil {
+		t.Fatalf("RegisterBlendMode: %v", err)
+	}
+
+	src := GetBlendShaderSource()
+	fnName := fmt.Sprintf("fn blend_custom_%d(src: vec3<f32>, dst: vec3<f32>) -> vec3<f32>", mode)
+	if !strings.Contains(src, fnName) {
+		t.Errorf("blend shader missing custom function %q", fnName)
+	}
+	if !strings.Contains(src, "return src + dst - vec3<f32>(1.0);") {
+		t.Error("blend shader missing custom snippet body")
+	}
+
+	// The custom function must be dispatched from blend_separable's switch,
+	// not just defined: extract the dispatcher body and look for the case.
+	start := strings.Index(src, "fn blend_separable(")
+	if start < 0 {
+		t.Fatal("blend shader missing blend_separable")
+	}
+	end := strings.Index(src[start:], "\n}")
+	if end < 0 {
+		t.Fatal("unterminated blend_separable body")
+	}
+	body := src[start : start+end]
+	caseLine := fmt.Sprintf("case %du:", mode)
+	if !strings.Contains(body, caseLine) {
+		t.Errorf("blend_separable missing dispatch %q for custom mode", caseLine)
+	}
+	if !strings.Contains(body, fmt.Sprintf("blend_custom_%d(src, dst)", mode)) {
+		t.Errorf("blend_separable does not call blend_custom_%d", mode)
+	}
+	// Generated cases must come before the default arm that swallows
+	// unknown modes.
+	if d := strings.Index(body, "default:"); d >= 0 && strings.Index(body, caseLine) > d {
+		t.Error("custom dispatch case generated after default arm")
+	}
+}
+
 func TestShaderSourcesContainExpectedContent(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -124,7 +124,7 @@ func (ssr *SparseStripsRasterizer) RasterizePath(
 
 	// Phase 1: Flatten path to monotonic line segments
 	ssr.flattenCtx.Reset()
-	ssr.flattenCtx.FlattenPathTo(path, transform, tolerance)
+	ssr.flattenCtx.FlattenPathAuto(path, transform, tolerance)
 	segments := ssr.flattenCtx.Segments()
 
 	if segments.Len() == 0 {
@@ -159,7 +159,7 @@ func (ssr *SparseStripsRasterizer) RasterizeToStrips(
 
 	// Phase 1: Flatten
 	ssr.flattenCtx.Reset()
-	ssr.flattenCtx.FlattenPathTo(path, transform, tolerance)
+	ssr.flattenCtx.FlattenPathAuto(path, transform, tolerance)
 	segments := ssr.flattenCtx.Segments()
 
 	if segments.Len() == 0 {
@@ -10,7 +10,19 @@ import (
 // SparseStripsFiller implements gg.CoverageFiller using the SparseStrips
 // rasterizer (4x4 tiles). This is the default filler, optimized for CPU
 // with SIMD-friendly tile sizes.
-type SparseStripsFiller struct{}
+type SparseStripsFiller struct {
+	// tolerance overrides the curve flattening tolerance in device pixels
+	// (0 = FlattenTolerance). Set by the renderer via the optional
+	// gg.FlattenToleranceSetter interface.
+	tolerance float32
+}
+
+// SetFlattenTolerance sets the curve flattening tolerance override in
+// device pixels; 0 restores the default. Implements the optional
+// gg.FlattenToleranceSetter interface.
+func (f *SparseStripsFiller) SetFlattenTolerance(tolerance float32) {
+	f.tolerance = tolerance
+}
 
 // FillCoverage rasterizes the path using SparseStrips and calls callback
 // for each pixel with non-zero coverage. If clipBounds is non-nil, tiles
@@ -37,7 +49,11 @@ func (f *SparseStripsFiller) FillCoverage(
 	defer globalSparseStripsPool.Put(ssr)
 
 	// 3. Rasterize
-	ssr.RasterizePath(scenePath, scene.IdentityAffine(), FlattenTolerance)
+	tolerance := f.tolerance
+	if tolerance <= 0 {
+		tolerance = FlattenTolerance
+	}
+	ssr.RasterizePath(scenePath, scene.IdentityAffine(), tolerance)
 
 	// 4. Walk TileGrid → callback
 	// Layer A (ADR-052): skip tiles entirely outside clip bounds.
@@ -0,0 +1,271 @@
+//go:build !nogpu
+
+package gpu
+
+// Strip packing for GPU upload.
+//
+// The StripRenderer produces tile-row strips with column-major per-pixel
+// alphas (see fine.go). The strip compute shader (shaders/strip.wgsl)
+// consumes scanline strips: a GPUStripHeader per horizontal run plus a
+// coverage buffer of packed bytes. StripPacker performs that conversion,
+// merging a strip with the solid gap preceding it into a single scanline
+// run so large filled shapes do not fragment into per-tile headers.
+//
+// Two coverage encodings are supported:
+//
+//   - Raw: one byte per pixel, packed four to a u32, addressed by byte
+//     offset (the cs_main / cs_accumulate entry points).
+//   - RLE: runs of constant coverage packed one per u32 as
+//     value | length<<8, addressed by word offset (the cs_main_rle entry
+//     point). Solid interiors — the gap fills and full-coverage tile
+//     columns of any large shape — collapse to a single run per scanline,
+//     cutting upload bandwidth by an order of magnitude for big fills.
+
+// GPUStripHeader is one scanline strip as consumed by strip.wgsl.
+// The layout matches the Strip struct in the shader: 16 bytes, four i32.
+type GPUStripHeader struct {
+	Y              int32 // Scanline (pixel row)
+	X              int32 // Start X coordinate in pixels
+	Width          int32 // Run length in pixels
+	CoverageOffset int32 // Raw: byte offset into coverage; RLE: word offset of first run
+}
+
+// PackedStrips is the GPU-ready output of a StripPacker pack call. The
+// slices alias the packer's internal buffers and remain valid until the
+// next Pack or PackRLE call.
+type PackedStrips struct {
+	Headers  []GPUStripHeader
+	Coverage []uint32 // Packed bytes (raw) or runs (RLE)
+	RLE      bool     // Coverage encoding: false = raw bytes, true = runs
+
+	// RawCoverageBytes is the per-pixel coverage byte count before
+	// encoding, for bandwidth accounting against CoverageBytes.
+	RawCoverageBytes int
+}
+
+// CoverageBytes returns the upload size of the coverage buffer.
+func (ps *PackedStrips) CoverageBytes() int {
+	return len(ps.Coverage) * 4
+}
+
+// UploadBytes returns the total upload size: headers plus coverage.
+func (ps *PackedStrips) UploadBytes() int {
+	return len(ps.Headers)*16 + ps.CoverageBytes()
+}
+
+// rleMaxRunLength is the longest run a single RLE word can encode
+// (24-bit length field); runs are split beyond it.
+const rleMaxRunLength = 1<<24 - 1
+
+// StripPacker converts StripRenderer output into scanline strip headers
+// and a packed coverage buffer for the strip compute shader. Buffers are
+// reused across calls; create one packer per render loop.
+type StripPacker struct {
+	headers  []GPUStripHeader
+	coverage []uint32
+	rawBytes int
+
+	// Per-scanline pending spans for the tile row being expanded;
+	// adjacent gap and strip spans merge here before encoding.
+	rowX   [TileHeight]int32
+	rowCov [TileHeight][]uint8
+	rowY   int32
+	rowSet bool
+
+	// pendingRLE records the encoder for the pack call in progress, so
+	// mid-row flushes from appendPixel use the same encoding as flushRow.
+	pendingRLE bool
+}
+
+// NewStripPacker creates a new strip packer.
+func NewStripPacker() *StripPacker {
+	return &StripPacker{
+		headers:  make([]GPUStripHeader, 0, 256),
+		coverage: make([]uint32, 0, 1024),
+	}
+}
+
+// Reset clears the packer state for reuse.
+func (sp *StripPacker) Reset() {
+	sp.headers = sp.headers[:0]
+	sp.coverage = sp.coverage[:0]
+	sp.rawBytes = 0
+	sp.rowSet = false
+}
+
+// Pack expands the strips into scanline headers with raw per-pixel
+// coverage bytes, four packed to a u32 (the read_coverage layout).
+func (sp *StripPacker) Pack(strips []SparseStrip, alphas []uint8) PackedStrips {
+	sp.pack(strips, alphas, false)
+	return PackedStrips{
+		Headers:          sp.headers,
+		Coverage:         sp.coverage,
+		RLE:              false,
+		RawCoverageBytes: sp.rawBytes,
+	}
+}
+
+// PackRLE expands the strips into scanline headers with run-length
+// encoded coverage: one u32 per run, value in the low byte and length in
+// the upper 24 bits. Runs of constant coverage — solid interiors above
+// all — collapse to a single word regardless of length.
+func (sp *StripPacker) PackRLE(strips []SparseStrip, alphas []uint8) PackedStrips {
+	sp.pack(strips, alphas, true)
+	return PackedStrips{
+		Headers:          sp.headers,
+		Coverage:         sp.coverage,
+		RLE:              true,
+		RawCoverageBytes: sp.rawBytes,
+	}
+}
+
+// pack walks the sparse strips in order, accumulating per-scanline spans
+// for each tile row and flushing them through the selected encoder.
+func (sp *StripPacker) pack(strips []SparseStrip, alphas []uint8, rle bool) {
+	sp.Reset()
+	sp.pendingRLE = rle
+	if len(strips) == 0 {
+		return
+	}
+
+	for i := 0; i < len(strips)-1; i++ {
+		strip := strips[i]
+		next := strips[i+1]
+
+		if strip.X == 0xFFFF {
+			continue // Sentinel
+		}
+
+		if sp.rowSet && sp.rowY != int32(strip.Y) {
+			sp.flushRow(rle)
+		}
+		sp.rowY = int32(strip.Y)
+		sp.rowSet = true
+
+		// Solid gap between the previous strip and this one: full
+		// coverage across every scanline of the tile row.
+		if strip.FillGap && i > 0 {
+			prev := strips[i-1]
+			if prev.Y == strip.Y && prev.X != 0xFFFF {
+				//nolint:gosec // Integer overflow is bounded by viewport dimensions
+				gapStartX := int32(prev.X) + int32((strip.AlphaIdx-prev.AlphaIdx)/TileHeight)
+				for py := 0; py < TileHeight; py++ {
+					sp.appendSolid(py, gapStartX, int32(strip.X))
+				}
+			}
+		}
+
+		// Strip alphas are column-major: TileHeight bytes per pixel column.
+		cols := alphas[strip.AlphaIdx:next.AlphaIdx]
+		width := len(cols) / TileHeight
+		for px := 0; px < width; px++ {
+			col := cols[px*TileHeight : (px+1)*TileHeight]
+			for py := 0; py < TileHeight; py++ {
+				sp.appendPixel(py, int32(strip.X)+int32(px), col[py])
+			}
+		}
+	}
+
+	if sp.rowSet {
+		sp.flushRow(rle)
+	}
+}
+
+// appendPixel adds one coverage byte at x on scanline py of the current
+// tile row, extending the pending span when contiguous and flushing it
+// otherwise.
+func (sp *StripPacker) appendPixel(py int, x int32, coverage uint8) {
+	if len(sp.rowCov[py]) > 0 && sp.rowX[py]+int32(len(sp.rowCov[py])) != x {
+		sp.flushSpan(py, sp.pendingRLE)
+	}
+	if len(sp.rowCov[py]) == 0 {
+		sp.rowX[py] = x
+	}
+	sp.rowCov[py] = append(sp.rowCov[py], coverage)
+}
+
+// appendSolid adds a full-coverage run [startX, endX) on scanline py.
+func (sp *StripPacker) appendSolid(py int, startX, endX int32) {
+	for x := startX; x < endX; x++ {
+		sp.appendPixel(py, x, 255)
+	}
+}
+
+// flushRow flushes every pending scanline span of the current tile row.
+func (sp *StripPacker) flushRow(rle bool) {
+	sp.pendingRLE = rle
+	for py := 0; py < TileHeight; py++ {
+		sp.flushSpan(py, rle)
+	}
+	sp.rowSet = false
+}
+
+// flushSpan encodes one pending scanline span into a header plus
+// coverage words, then clears it. Spans of all-zero coverage are dropped.
+func (sp *StripPacker) flushSpan(py int, rle bool) {
+	cov := sp.rowCov[py]
+	if len(cov) == 0 {
+		return
+	}
+
+	allZero := true
+	for _, c := range cov {
+		if c != 0 {
+			allZero = false
+			break
+		}
+	}
+	if allZero {
+		sp.rowCov[py] = cov[:0]
+		return
+	}
+
+	var offset int32
+	if rle {
+		offset = int32(len(sp.coverage))
+		sp.encodeRLE(cov)
+	} else {
+		offset = int32(sp.rawBytes)
+		sp.encodeRaw(cov)
+	}
+	sp.headers = append(sp.headers, GPUStripHeader{
+		Y:              sp.rowY + int32(py),
+		X:              sp.rowX[py],
+		Width:          int32(len(cov)),
+		CoverageOffset: offset,
+	})
+	sp.rawBytes += len(cov)
+	sp.rowCov[py] = cov[:0]
+}
+
+// encodeRaw appends coverage bytes packed four to a u32, little-endian
+// byte order matching read_coverage in strip.wgsl. Spans need no word
+// alignment: read_coverage addresses individual bytes, so the byte
+// offset of a span is simply the pre-call rawBytes counter.
+func (sp *StripPacker) encodeRaw(cov []uint8) {
+	byteIdx := sp.rawBytes
+	for _, c := range cov {
+		word := byteIdx / 4
+		shift := uint(byteIdx%4) * 8
+		for word >= len(sp.coverage) {
+			sp.coverage = append(sp.coverage, 0)
+		}
+		sp.coverage[word] |= uint32(c) << shift
+		byteIdx++
+	}
+}
+
+// encodeRLE appends the coverage bytes as runs of constant value, one
+// u32 per run: value | length<<8.
+func (sp *StripPacker) encodeRLE(cov []uint8) {
+	i := 0
+	for i < len(cov) {
+		v := cov[i]
+		j := i + 1
+		for j < len(cov) && cov[j] == v && j-i < rleMaxRunLength {
+			j++
+		}
+		sp.coverage = append(sp.coverage, uint32(v)|uint32(j-i)<<8)
+		i = j
+	}
+}
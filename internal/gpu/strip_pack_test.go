@@ -0,0 +1,228 @@
+//go:build !nogpu
+
+package gpu
+
+import (
+	"testing"
+
+	"github.com/gogpu/gg/scene"
+)
+
+// decodeRawPacked replays the cs_main loop of strip.wgsl on the CPU:
+// per-pixel coverage addressed by byte offset into the packed u32 buffer.
+func decodeRawPacked(ps PackedStrips, width, height int) []uint8 {
+	out := make([]uint8, width*height)
+	for _, h := range ps.Headers {
+		for i := int32(0); i < h.Width; i++ {
+			x, y := h.X+i, h.Y
+			if x < 0 || int(x) >= width || y < 0 || int(y) >= height {
+				continue
+			}
+			byteIdx := h.CoverageOffset + i
+			word := ps.Coverage[byteIdx/4]
+			out[int(y)*width+int(x)] = uint8(word >> (uint(byteIdx%4) * 8)) //nolint:gosec // Low byte extraction
+		}
+	}
+	return out
+}
+
+// decodeRLEPacked replays the cs_main_rle loop of strip.wgsl on the CPU:
+// runs of value|length<<8 addressed by word offset.
+func decodeRLEPacked(ps PackedStrips, width, height int) []uint8 {
+	out := make([]uint8, width*height)
+	for _, h := range ps.Headers {
+		runIdx := h.CoverageOffset
+		x := h.X
+		endX := h.X + h.Width
+		for x < endX {
+			run := ps.Coverage[runIdx]
+			runIdx++
+			cov := uint8(run & 0xFF) //nolint:gosec // Low byte extraction
+			runEnd := min(x+int32(run>>8), endX)
+			if cov > 0 && h.Y >= 0 && int(h.Y) < height {
+				for px := x; px < runEnd; px++ {
+					if px >= 0 && int(px) < width {
+						out[int(h.Y)*width+int(px)] = cov
+					}
+				}
+			}
+			x = runEnd
+		}
+	}
+	return out
+}
+
+// packBoth rasterizes the path to strips and packs the result with both
+// encodings.
+func packBoth(t *testing.T, path *scene.Path, width, height uint16) (raw, rle PackedStrips) {
+	t.Helper()
+	ssr := NewSparseStripsRasterizer(DefaultConfig(width, height))
+	ssr.RasterizeToStrips(path, scene.IdentityAffine(), FlattenTolerance)
+
+	strips := ssr.Strips()
+	if len(strips.Strips()) == 0 {
+		t.Fatal("rasterization produced no strips")
+	}
+
+	// Separate packers: PackedStrips aliases packer buffers.
+	raw = NewStripPacker().Pack(strips.Strips(), strips.Alphas())
+	rle = NewStripPacker().PackRLE(strips.Strips(), strips.Alphas())
+	return raw, rle
+}
+
+func TestStripPackerRawMatchesRLE(t *testing.T) {
+	paths := map[string]*scene.Path{
+		"rect":   scene.NewPath().Rectangle(10, 10, 50, 30),
+		"circle": scene.NewPath().Circle(50, 50, 35),
+		"triangle": func() *scene.Path {
+			p := scene.NewPath()
+			p.MoveTo(50, 10)
+			p.LineTo(90, 85)
+			p.LineTo(10, 85)
+			p.Close()
+			return p
+		}(),
+	}
+
+	const w, h = 100, 100
+	for name, path := range paths {
+		t.Run(name, func(t *testing.T) {
+			raw, rle := packBoth(t, path, w, h)
+
+			rawPixels := decodeRawPacked(raw, w, h)
+			rlePixels := decodeRLEPacked(rle, w, h)
+			for i := range rawPixels {
+				if rawPixels[i] != rlePixels[i] {
+					t.Fatalf("pixel %d: raw decode %d, RLE decode %d",
+						i, rawPixels[i], rlePixels[i])
+				}
+			}
+		})
+	}
+}
+
+func TestStripPackerMatchesCPUStripRender(t *testing.T) {
+	// The packed strips must reproduce the same coverage the CPU strip
+	// renderer produces. Rendering white onto a zeroed buffer leaves the
+	// coverage in the alpha channel.
+	const w, h = 100, 100
+	path := scene.NewPath().Circle(50, 50, 30)
+
+	ssr := NewSparseStripsRasterizer(DefaultConfig(w, h))
+	ssr.RasterizeToStrips(path, scene.IdentityAffine(), FlattenTolerance)
+
+	buffer := make([]uint8, w*h*4)
+	ssr.RenderStripsToBuffer(buffer, w*4, [4]uint8{255, 255, 255, 255})
+
+	strips := ssr.Strips()
+	packed := NewStripPacker().Pack(strips.Strips(), strips.Alphas())
+	decoded := decodeRawPacked(packed, w, h)
+
+	for i := 0; i < w*h; i++ {
+		if decoded[i] != buffer[i*4+3] {
+			t.Fatalf("pixel (%d,%d): packed coverage %d, CPU strip render alpha %d",
+				i%w, i/w, decoded[i], buffer[i*4+3])
+		}
+	}
+}
+
+func TestStripPackerRLECompressesSolidInterior(t *testing.T) {
+	// A large filled rect is almost entirely solid interior: gap fills and
+	// full-coverage columns collapse to one run per scanline, so the RLE
+	// coverage buffer must be far smaller than the raw one.
+	const w, h = 256, 256
+	path := scene.NewPath().Rectangle(8, 8, 240, 240)
+
+	raw, rle := packBoth(t, path, w, h)
+
+	if raw.RawCoverageBytes != rle.RawCoverageBytes {
+		t.Errorf("raw byte accounting differs: %d vs %d",
+			raw.RawCoverageBytes, rle.RawCoverageBytes)
+	}
+	if rle.CoverageBytes() >= raw.CoverageBytes()/4 {
+		t.Errorf("RLE coverage %d bytes, want well under a quarter of raw %d bytes",
+			rle.CoverageBytes(), raw.CoverageBytes())
+	}
+	if rle.UploadBytes() >= raw.UploadBytes() {
+		t.Errorf("RLE upload %d bytes not smaller than raw %d bytes",
+			rle.UploadBytes(), raw.UploadBytes())
+	}
+}
+
+func TestStripPackerMergesGapIntoScanlineRun(t *testing.T) {
+	// Interior scanlines of a filled rect must pack as one header each:
+	// the left edge strip, solid gap, and right edge strip merge into a
+	// single contiguous run instead of fragmenting per tile.
+	const w, h = 128, 128
+	path := scene.NewPath().Rectangle(10, 10, 100, 100)
+
+	_, rle := packBoth(t, path, w, h)
+
+	perScanline := make(map[int32]int)
+	for _, hd := range rle.Headers {
+		perScanline[hd.Y]++
+	}
+	// Pick a scanline well inside the rect, away from the AA edges.
+	if n := perScanline[60]; n != 1 {
+		t.Errorf("interior scanline 60 packed as %d headers, want 1", n)
+	}
+}
+
+func TestStripPackerHeaderLayout(t *testing.T) {
+	// Header fields must stay within the shader's i32 layout and describe
+	// runs inside the coverage buffer.
+	const w, h = 100, 100
+	raw, rle := packBoth(t, scene.NewPath().Circle(50, 50, 30), w, h)
+
+	for _, hd := range raw.Headers {
+		if hd.Width <= 0 {
+			t.Fatalf("raw header with non-positive width: %+v", hd)
+		}
+		if int(hd.CoverageOffset)+int(hd.Width) > raw.RawCoverageBytes {
+			t.Fatalf("raw header overruns coverage buffer: %+v", hd)
+		}
+	}
+	for _, hd := range rle.Headers {
+		if hd.Width <= 0 {
+			t.Fatalf("RLE header with non-positive width: %+v", hd)
+		}
+		if int(hd.CoverageOffset) >= len(rle.Coverage) {
+			t.Fatalf("RLE header offset outside run buffer: %+v", hd)
+		}
+	}
+}
+
+func TestStripPackerEmptyInput(t *testing.T) {
+	packed := NewStripPacker().Pack(nil, nil)
+	if len(packed.Headers) != 0 || len(packed.Coverage) != 0 {
+		t.Errorf("empty input packed %d headers, %d words",
+			len(packed.Headers), len(packed.Coverage))
+	}
+	if packed.UploadBytes() != 0 {
+		t.Errorf("empty input UploadBytes = %d, want 0", packed.UploadBytes())
+	}
+}
+
+func TestStripPackerReuse(t *testing.T) {
+	// A packer reused across paths must produce the same output as a
+	// fresh one.
+	const w, h = 100, 100
+	ssr := NewSparseStripsRasterizer(DefaultConfig(w, h))
+	ssr.RasterizeToStrips(scene.NewPath().Circle(50, 50, 30), scene.IdentityAffine(), FlattenTolerance)
+	strips := ssr.Strips()
+
+	reused := NewStripPacker()
+	reused.PackRLE(strips.Strips(), strips.Alphas()) // Dirty the buffers
+	second := reused.PackRLE(strips.Strips(), strips.Alphas())
+	fresh := NewStripPacker().PackRLE(strips.Strips(), strips.Alphas())
+
+	if len(second.Headers) != len(fresh.Headers) || len(second.Coverage) != len(fresh.Coverage) {
+		t.Fatalf("reused packer output %d/%d differs from fresh %d/%d",
+			len(second.Headers), len(second.Coverage), len(fresh.Headers), len(fresh.Coverage))
+	}
+	for i := range fresh.Coverage {
+		if second.Coverage[i] != fresh.Coverage[i] {
+			t.Fatalf("coverage word %d: reused %#x, fresh %#x", i, second.Coverage[i], fresh.Coverage[i])
+		}
+	}
+}
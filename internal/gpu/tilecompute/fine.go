@@ -35,9 +35,16 @@ const PixelsPerThread = 4
 //   - bgColor: background color as premultiplied float32 RGBA [0,1]
 //
 // Returns per-pixel premultiplied RGBA as [TileWidth*TileHeight][4]float32.
+func fineRasterizeTile(ptcl *PTCL, segments []PathSegment, bgColor [4]float32) [TileWidth * TileHeight][4]float32 {
+	return fineRasterizeTileAA(ptcl, segments, bgColor, AAModeAnalytic)
+}
+
+// fineRasterizeTileAA is fineRasterizeTile with a selectable coverage
+// algorithm for CmdFill: analytic area coverage or 16-sample multisampling
+// (see AAMode). All other commands are unaffected.
 //
 //nolint:funlen,cyclop // Direct port of Vello fine.wgsl PTCL dispatch loop; splitting would hurt cross-reference.
-func fineRasterizeTile(ptcl *PTCL, segments []PathSegment, bgColor [4]float32) [TileWidth * TileHeight][4]float32 {
+func fineRasterizeTileAA(ptcl *PTCL, segments []PathSegment, bgColor [4]float32, aaMode AAMode) [TileWidth * TileHeight][4]float32 {
 	const pixelCount = TileWidth * TileHeight
 
 	// Initialize output with background color.
@@ -88,7 +95,7 @@ func fineRasterizeTile(ptcl *PTCL, segments []PathSegment, bgColor [4]float32) [
 			}
 			tileSegs := segments[segStart:segEnd]
 
-			fillPath(area[:], tileSegs, data.Backdrop, data.EvenOdd)
+			fillPathAA(area[:], tileSegs, data.Backdrop, data.EvenOdd, aaMode)
 
 		case CmdSolid:
 			// Fully covered tile: all area = 1.0.
@@ -0,0 +1,165 @@
+// Copyright 2026 The gogpu Authors
+// SPDX-License-Identifier: MIT
+
+package tilecompute
+
+import (
+	"math"
+	"testing"
+)
+
+// TestMsaa16MatchesAnalyticCircle validates the MSAA16 fine path against
+// the analytic CPU reference: interior and exterior pixels must agree
+// exactly, and edge coverage must stay close to the exact area.
+func TestMsaa16MatchesAnalyticCircle(t *testing.T) {
+	const size = 100
+	lines := FlattenFill(circleCubics(50, 50, 45))
+
+	analytic := NewRasterizer(size, size)
+	msaa := NewRasterizer(size, size)
+	msaa.SetAAMode(AAModeMsaa16)
+	if msaa.AAMode() != AAModeMsaa16 {
+		t.Fatal("AAMode() did not return the configured mode")
+	}
+
+	ref := analytic.Rasterize(lines, FillRuleNonZero)
+	got := msaa.Rasterize(lines, FillRuleNonZero)
+
+	// Classify pixels geometrically: more than 1.5px inside the radius is
+	// interior, more than 1.5px outside is exterior, the rest is edge.
+	var sumDiff float64
+	var edgePixels int
+	for y := 0; y < size; y++ {
+		for x := 0; x < size; x++ {
+			i := y*size + x
+			dist := math.Hypot(float64(x)+0.5-50, float64(y)+0.5-50)
+			switch {
+			case dist < 45-1.5:
+				if got[i] != 1.0 {
+					t.Fatalf("interior pixel (%d,%d): msaa coverage %v, want 1", x, y, got[i])
+				}
+			case dist > 45+1.5:
+				if got[i] != 0.0 {
+					t.Fatalf("exterior pixel (%d,%d): msaa coverage %v, want 0", x, y, got[i])
+				}
+			default:
+				edgePixels++
+				sumDiff += math.Abs(float64(ref[i] - got[i]))
+			}
+		}
+	}
+	if edgePixels == 0 {
+		t.Fatal("no edge pixels found; test scene is degenerate")
+	}
+	if mean := sumDiff / float64(edgePixels); mean > 0.08 {
+		t.Errorf("mean edge coverage error = %.4f, want <= 0.08", mean)
+	}
+}
+
+// TestMsaa16CoverageQuantized verifies that multisampled coverage only
+// takes values k/16 — the defining property of a 16-sample resolve.
+func TestMsaa16CoverageQuantized(t *testing.T) {
+	const size = 64
+	lines := polygonToLineSoup([][2]float32{
+		{5, 5}, {60, 20}, {30, 58},
+	})
+
+	r := NewRasterizer(size, size)
+	r.SetAAMode(AAModeMsaa16)
+	alphas := r.Rasterize(lines, FillRuleNonZero)
+
+	for i, a := range alphas {
+		scaled := a * 16
+		if scaled != float32(math.Round(float64(scaled))) {
+			t.Fatalf("pixel %d: coverage %v is not a multiple of 1/16", i, a)
+		}
+	}
+}
+
+// TestMsaa16EvenOdd verifies the even-odd resolve on a self-intersecting
+// star: the center hole must be empty and the points filled in both modes.
+func TestMsaa16EvenOdd(t *testing.T) {
+	const size = 100
+	// Five-pointed star drawn with crossing edges.
+	star := [][2]float32{
+		{50, 5}, {79, 90}, {5, 38}, {95, 38}, {21, 90},
+	}
+	lines := polygonToLineSoup(star)
+
+	for _, mode := range []AAMode{AAModeAnalytic, AAModeMsaa16} {
+		r := NewRasterizer(size, size)
+		r.SetAAMode(mode)
+		alphas := r.Rasterize(lines, FillRuleEvenOdd)
+
+		if a := alphas[45*size+50]; a != 0 {
+			t.Errorf("mode %d: star hole coverage = %v, want 0", mode, a)
+		}
+		if a := alphas[15*size+50]; a != 1 {
+			t.Errorf("mode %d: star point coverage = %v, want 1", mode, a)
+		}
+	}
+}
+
+// TestMsaa16ScenePTCLDiff runs the full PTCL pipeline in both modes and
+// compares the composited images with the standard diff tooling. Only
+// edge pixels may differ, so the diff stays well below the perimeter
+// fraction of the scene.
+func TestMsaa16ScenePTCLDiff(t *testing.T) {
+	const size = 100
+	paths := []PathDef{
+		{
+			Lines:    FlattenFill(circleCubics(50, 50, 40)),
+			Color:    [4]uint8{0, 255, 0, 255},
+			FillRule: FillRuleNonZero,
+		},
+		{
+			Lines:    polygonToLineSoup([][2]float32{{20, 20}, {85, 40}, {40, 85}}),
+			Color:    [4]uint8{128, 0, 0, 255},
+			FillRule: FillRuleNonZero,
+		},
+	}
+	white := [4]uint8{255, 255, 255, 255}
+
+	analytic := NewRasterizer(size, size)
+	reference := analytic.RasterizeScenePTCL(white, paths)
+
+	msaa := NewRasterizer(size, size)
+	msaa.SetAAMode(AAModeMsaa16)
+	ours := msaa.RasterizeScenePTCL(white, paths)
+
+	diffPercent, diffCount := compareImages(ours, reference)
+	t.Logf("MSAA16 vs analytic PTCL: %d pixels differ (%.2f%%)", diffCount, diffPercent)
+
+	// Edges of a circle plus a triangle cover ~5%% of a 100x100 canvas.
+	if diffPercent > 8.0 {
+		saveDiffImage(t, "tilecompute_msaa16", "scene_ptcl", ours, reference)
+		t.Errorf("FAIL: %.2f%% pixel difference exceeds threshold 8.0%%", diffPercent)
+	}
+}
+
+// TestMsaa16MaskLUT sanity-checks the mask LUT against direct evaluation
+// for a few representative lines.
+func TestMsaa16MaskLUT(t *testing.T) {
+	// A vertical line left of all samples covers everything; right of all
+	// samples covers nothing.
+	if mask := lineSampleMask(-0.5, 0, -0.5, -0.5); mask != 0xFFFF {
+		t.Errorf("line left of pixel: mask = %04x, want ffff", mask)
+	}
+	if mask := lineSampleMask(1.5, 0, 1.5, 1.5); mask != 0 {
+		t.Errorf("line right of pixel: mask = %04x, want 0000", mask)
+	}
+
+	// A vertical line through the pixel center covers roughly half the
+	// samples; LUT quantization may shift the split by a sample or two.
+	if n := popcount16(lineSampleMask(0.5, 0, 0.5, 0.5)); n < 6 || n > 10 {
+		t.Errorf("center line covers %d samples, want ~8", n)
+	}
+}
+
+func popcount16(m uint16) int {
+	n := 0
+	for ; m != 0; m &= m - 1 {
+		n++
+	}
+	return n
+}
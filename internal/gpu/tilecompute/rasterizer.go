@@ -15,6 +15,7 @@ import (
 // Rasterizer runs the complete Vello CPU rasterization pipeline.
 type Rasterizer struct {
 	width, height int
+	aaMode        AAMode
 }
 
 // NewRasterizer creates a new rasterizer for the given canvas size.
@@ -22,6 +23,17 @@ func NewRasterizer(width, height int) *Rasterizer {
 	return &Rasterizer{width: width, height: height}
 }
 
+// SetAAMode selects the fine-stage coverage algorithm: analytic area
+// coverage (default) or 16-sample multisampling. See AAMode.
+func (r *Rasterizer) SetAAMode(mode AAMode) {
+	r.aaMode = mode
+}
+
+// AAMode returns the current fine-stage coverage algorithm.
+func (r *Rasterizer) AAMode() AAMode {
+	return r.aaMode
+}
+
 // Rasterize runs the full pipeline and returns per-pixel alpha values [0.0, 1.0].
 // The result is a flat array of width*height float32 values in row-major order.
 func (r *Rasterizer) Rasterize(lines []LineSoup, fillRule FillRule) []float32 {
@@ -136,7 +148,7 @@ func (r *Rasterizer) Rasterize(lines []LineSoup, fillRule FillRule) []float32 {
 			}
 
 			// Fill
-			fillPath(area, tileSegments, tile.Backdrop, fillRule == FillRuleEvenOdd)
+			fillPathAA(area, tileSegments, tile.Backdrop, fillRule == FillRuleEvenOdd, r.aaMode)
 
 			// Write to result
 			globalTileX := (bboxX0 + tx) * TileWidth
@@ -281,7 +293,7 @@ func (r *Rasterizer) RasterizeScenePTCL(bgColor [4]uint8, paths []PathDef) *imag
 	for ty := 0; ty < heightInTiles; ty++ {
 		for tx := 0; tx < widthInTiles; tx++ {
 			tileIdx := ty*widthInTiles + tx
-			tilePixels := fineRasterizeTile(coarseOut.TilePTCLs[tileIdx], coarseOut.Segments, bgFloat)
+			tilePixels := fineRasterizeTileAA(coarseOut.TilePTCLs[tileIdx], coarseOut.Segments, bgFloat, r.aaMode)
 
 			// Write tile pixels to output image.
 			globalTileX := tx * TileWidth
@@ -391,7 +403,7 @@ func (r *Rasterizer) RasterizeSceneDefPTCL(bgColor [4]uint8, elements []SceneEle
 	for ty := 0; ty < heightInTiles; ty++ {
 		for tx := 0; tx < widthInTiles; tx++ {
 			tileIdx := ty*widthInTiles + tx
-			tilePixels := fineRasterizeTile(coarseOut.TilePTCLs[tileIdx], coarseOut.Segments, bgFloat)
+			tilePixels := fineRasterizeTileAA(coarseOut.TilePTCLs[tileIdx], coarseOut.Segments, bgFloat, r.aaMode)
 
 			globalTileX := tx * TileWidth
 			globalTileY := ty * TileHeight
@@ -0,0 +1,75 @@
+// Copyright 2026 The gogpu Authors
+// SPDX-License-Identifier: MIT
+//
+// dispatch_setup.wgsl — Write indirect dispatch arguments for data-dependent stages.
+//
+// path_tiling processes one thread per SegmentCount record, but the record
+// count is only known on the GPU after path_count has run (bump.seg_counts).
+// The CPU previously dispatched a worst-case estimate (n_lines * 4) and let
+// excess threads return early. This single-thread setup stage runs after
+// coarse and writes exact workgroup counts into an indirect args buffer, so
+// path_tiling and fine are sized from actual pipeline state with no CPU
+// readback between stages.
+//
+// Pipeline order: ... → coarse → **dispatch_setup** → path_tiling → fine
+//
+// Indirect buffer layout (8 u32 words, two 16-byte DispatchIndirectArgs slots):
+//   words 0-2: path_tiling (x, y, z), word 3: padding
+//   words 4-6: fine (x, y, z),        word 7: padding
+
+// --- Shared types ---
+
+struct Config {
+    width_in_tiles: u32,
+    height_in_tiles: u32,
+    target_width: u32,
+    target_height: u32,
+    n_drawobj: u32,
+    n_path: u32,
+    n_clip: u32,
+    pathtag_base: u32,
+    pathdata_base: u32,
+    drawtag_base: u32,
+    drawdata_base: u32,
+    transform_base: u32,
+    style_base: u32,
+    n_lines: u32,
+    bg_color: u32,
+}
+
+struct BumpAlloc {
+    seg_counts: atomic<u32>,
+    segments: atomic<u32>,
+    blend: atomic<u32>,
+    _pad1: atomic<u32>,
+}
+
+// --- Constants ---
+
+// Workgroup size of path_tiling (must match path_tiling.wgsl).
+const PATH_TILING_WG_SIZE: u32 = 256u;
+
+// --- Bindings ---
+
+@group(0) @binding(0) var<uniform> config: Config;
+@group(0) @binding(1) var<storage, read_write> bump: BumpAlloc;
+@group(0) @binding(2) var<storage, read_write> indirect: array<u32>;
+
+// --- Main entry point ---
+// Dispatched with (1, 1, 1) workgroups.
+
+@compute @workgroup_size(1, 1, 1)
+fn main() {
+    // path_tiling: one thread per SegmentCount record.
+    let n_segments = atomicLoad(&bump.seg_counts);
+    indirect[0u] = (n_segments + PATH_TILING_WG_SIZE - 1u) / PATH_TILING_WG_SIZE;
+    indirect[1u] = 1u;
+    indirect[2u] = 1u;
+    indirect[3u] = 0u;
+
+    // fine: one workgroup per tile in the render target grid.
+    indirect[4u] = config.width_in_tiles;
+    indirect[5u] = config.height_in_tiles;
+    indirect[6u] = 1u;
+    indirect[7u] = 0u;
+}
@@ -11,7 +11,19 @@ import (
 // TileComputeFiller implements gg.CoverageFiller using the tilecompute
 // rasterizer (16x16 tiles). This is an alternative filler optimized for
 // GPU workgroup-sized tiles.
-type TileComputeFiller struct{}
+type TileComputeFiller struct {
+	// tolerance overrides the curve flattening tolerance in device pixels
+	// (0 = FlattenTolerance). Set by the renderer via the optional
+	// gg.FlattenToleranceSetter interface.
+	tolerance float32
+}
+
+// SetFlattenTolerance sets the curve flattening tolerance override in
+// device pixels; 0 restores the default. Implements the optional
+// gg.FlattenToleranceSetter interface.
+func (f *TileComputeFiller) SetFlattenTolerance(tolerance float32) {
+	f.tolerance = tolerance
+}
 
 // FillCoverage rasterizes the path using tilecompute and calls callback
 // for each pixel with non-zero coverage. If clipBounds is non-nil, pixels
@@ -31,8 +43,12 @@ func (f *TileComputeFiller) FillCoverage(
 		return
 	}
 
+	tolerance := f.tolerance
+	if tolerance <= 0 {
+		tolerance = FlattenTolerance
+	}
 	flatCtx := NewFlattenContext()
-	flatCtx.FlattenPathTo(scenePath, scene.IdentityAffine(), FlattenTolerance)
+	flatCtx.FlattenPathTo(scenePath, scene.IdentityAffine(), tolerance)
 	segs := flatCtx.Segments()
 	if segs.Len() == 0 {
 		return
@@ -18,6 +18,7 @@ import (
 	"github.com/gogpu/gg"
 	"github.com/gogpu/gg/internal/gpu/tilecompute"
 	"github.com/gogpu/gg/internal/stroke"
+	"github.com/gogpu/gg/internal/trace"
 	"github.com/gogpu/gpucontext"
 	"github.com/gogpu/gputypes"
 	"github.com/gogpu/wgpu"
@@ -240,6 +241,12 @@ func (a *VelloAccelerator) StrokePath(target gg.GPURenderTarget, path *gg.Path,
 		return gg.ErrFallbackToCPU
 	}
 
+	// Non-solid stroke brushes need per-pixel sampling — fall back to CPU.
+	strokeColor, solid := paint.StrokeSolidColor()
+	if !solid {
+		return gg.ErrFallbackToCPU
+	}
+
 	// Convert gg verbs to stroke verbs and expand to filled outline.
 	strokeVerbs := convertPathVerbsToStroke(path.Verbs())
 	style := stroke.Stroke{
@@ -264,6 +271,9 @@ func (a *VelloAccelerator) StrokePath(target gg.GPURenderTarget, path *gg.Path,
 	// Mirrors GPURenderContext.StrokePath. ADR-043, #369, #374.
 	strokePaint := *paint
 	strokePaint.FillRule = gg.FillRuleEvenOdd
+	// Collapse the effective stroke color into the fill slot so the path
+	// color extraction sees the stroke brush, not the fill brush.
+	strokePaint.SetBrush(gg.Solid(strokeColor))
 	return a.FillPath(target, fillPath, &strokePaint)
 }
 
@@ -526,6 +536,8 @@ func (a *VelloAccelerator) dispatchComputeScene(
 	}
 
 	// Step 1: Encode and pack scene.
+	encodeSpan := trace.Start("gpu.encode")
+	encodeSpan.SetAttr("paths", len(paths))
 	enc := tilecompute.EncodeScene(paths)
 	scene := tilecompute.PackScene(enc)
 
@@ -580,39 +592,57 @@ func (a *VelloAccelerator) dispatchComputeScene(
 		BgColor:       uint32(bgColor[0]) | uint32(bgColor[1])<<8 | uint32(bgColor[2])<<16 | uint32(bgColor[3])<<24,
 	}
 
+	encodeSpan.SetAttr("lines", int(numLines))
+	encodeSpan.End()
+
 	// Step 6: Allocate GPU buffers.
+	uploadSpan := trace.Start("gpu.upload")
 	bufs, err := a.dispatcher.AllocateBuffers(config, scene.Data, linesU32, pathsU32, numLines, totalPathTiles)
 	if err != nil {
+		uploadSpan.End()
 		return nil, fmt.Errorf("vello-compute: allocate buffers: %w", err)
 	}
 	defer a.dispatcher.DestroyBuffers(bufs)
 
 	// Step 7: Upload scene data, line segments, and path metadata to GPU.
 	if err := a.queue.WriteBuffer(bufs.Scene, 0, uint32SliceToBytes(scene.Data)); err != nil {
+		uploadSpan.End()
 		return nil, fmt.Errorf("vello-compute: write scene buffer: %w", err)
 	}
 	if err := a.queue.WriteBuffer(bufs.Lines, 0, uint32SliceToBytes(linesU32)); err != nil {
+		uploadSpan.End()
 		return nil, fmt.Errorf("vello-compute: write lines buffer: %w", err)
 	}
 	if err := a.queue.WriteBuffer(bufs.Paths, 0, uint32SliceToBytes(pathsU32)); err != nil {
+		uploadSpan.End()
 		return nil, fmt.Errorf("vello-compute: write paths buffer: %w", err)
 	}
 
 	// Step 8: Upload per-path auxiliary data.
 	numPaths := int(scene.Layout.NumPaths)
 	if err := a.uploadPathAuxData(bufs, numPaths, pathStylesU32); err != nil {
+		uploadSpan.End()
 		return nil, fmt.Errorf("vello-compute: upload path aux data: %w", err)
 	}
+	uploadSpan.End()
 
 	// Step 9: Dispatch all 8 stages.
-	if err := a.dispatcher.Dispatch(bufs, config); err != nil {
+	dispatchSpan := trace.Start("gpu.dispatch")
+	err = a.dispatcher.Dispatch(bufs, config)
+	dispatchSpan.End()
+	if err != nil {
 		return nil, fmt.Errorf("vello-compute: dispatch: %w", err)
 	}
 
 	// Step 9b: Diagnostic readback — verify intermediate buffers have data.
 	a.logPipelineDiagnostics(bufs, config, totalPathTiles)
+	if pipelineDumpDir() != "" {
+		a.dumpPipelineIntermediates(bufs, config, totalPathTiles)
+	}
 
 	// Step 10: Readback output pixels.
+	readbackSpan := trace.Start("gpu.readback")
+	defer readbackSpan.End()
 	outputSize := uint64(width) * uint64(height) * 4
 	resultBytes, err := a.readbackBuffer(bufs.Output, outputSize)
 	if err != nil {
@@ -855,6 +885,58 @@ func unpackPixels(data []byte, width, height int) *image.RGBA {
 	return img
 }
 
+// dumpPipelineIntermediates reads back the per-stage intermediate buffers
+// and writes them as labeled PNGs into the GOGPU_DEBUG_DUMP directory:
+// tile backdrops and per-tile segment counts (from the Tiles buffer),
+// a PTCL command-count heatmap, and the final output image, plus an
+// HTML index. Read-back or encode failures are logged and skipped —
+// a debug dump must never fail the render.
+func (a *VelloAccelerator) dumpPipelineIntermediates(bufs *VelloComputeBuffers, config VelloComputeConfig, totalPathTiles uint32) {
+	d, err := newDumpWriter(pipelineDumpDir())
+	if err != nil {
+		slogger().Warn("vello-dump: cannot create dump dir", "error", err)
+		return
+	}
+	wTiles, hTiles := int(config.WidthInTiles), int(config.HeightInTiles)
+	writePNG := func(stage string, img image.Image) {
+		if err := d.writePNG(stage, img); err != nil {
+			slogger().Warn("vello-dump: write failed", "stage", stage, "error", err)
+		}
+	}
+
+	pathsBytes, pErr := a.readbackBuffer(bufs.Paths, uint64(config.NumPaths)*20)
+	tilesBytes, tErr := a.readbackBuffer(bufs.Tiles, uint64(totalPathTiles)*8)
+	if pErr != nil || tErr != nil {
+		slogger().Warn("vello-dump: cannot read tile buffers", "paths_err", pErr, "tiles_err", tErr)
+	} else {
+		metas := parsePathTileMeta(pathsBytes, int(config.NumPaths))
+		backdrops, segCounts := tileGrids(metas, tilesBytes, wTiles, hTiles)
+		writePNG("backdrops", heatmapImage(wTiles, hTiles, backdrops, 8))
+		writePNG("coverage", heatmapImage(wTiles, hTiles, segCounts, 8))
+	}
+
+	ptclSize := uint64(wTiles*hTiles) * velloPTCLMaxPerTile * 4
+	ptclBytes, ptclErr := a.readbackBuffer(bufs.PTCL, ptclSize)
+	if ptclErr != nil {
+		slogger().Warn("vello-dump: cannot read PTCL", "error", ptclErr)
+	} else {
+		counts := ptclCommandCounts(ptclBytes, wTiles*hTiles)
+		writePNG("ptcl", heatmapImage(wTiles, hTiles, counts, 8))
+	}
+
+	outSize := uint64(config.TargetWidth) * uint64(config.TargetHeight) * 4
+	outBytes, outErr := a.readbackBuffer(bufs.Output, outSize)
+	if outErr != nil {
+		slogger().Warn("vello-dump: cannot read output", "error", outErr)
+	} else {
+		writePNG("output", unpackPixels(outBytes, int(config.TargetWidth), int(config.TargetHeight)))
+	}
+
+	if err := d.writeIndex(); err != nil {
+		slogger().Warn("vello-dump: cannot write index", "error", err)
+	}
+}
+
 // logPipelineDiagnostics reads back key intermediate buffers and logs their state.
 // This detects silent pipeline failures (e.g., all-zero output from a broken stage).
 func (a *VelloAccelerator) logPipelineDiagnostics(bufs *VelloComputeBuffers, config VelloComputeConfig, totalPathTiles uint32) {
@@ -1238,6 +1320,7 @@ func (a *VelloAccelerator) InitStandalone() error {
 func (a *VelloAccelerator) initGPU() error {
 	instance, err := wgpu.CreateInstance(&wgpu.InstanceDescriptor{
 		Backends: wgpu.BackendsVulkan,
+		Flags:    debugInstanceFlags(),
 	})
 	if err != nil {
 		return fmt.Errorf("create instance: %w", err)
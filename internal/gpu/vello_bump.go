@@ -0,0 +1,200 @@
+// Copyright 2026 The gogpu Authors
+// SPDX-License-Identifier: MIT
+
+//go:build !nogpu
+
+package gpu
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+
+	"github.com/gogpu/gputypes"
+	"github.com/gogpu/wgpu"
+)
+
+// Bump-allocator overflow handling for the Vello compute pipeline.
+//
+// SegCounts, Segments, and BlendSpill are sized from CPU-side estimates
+// before dispatch. The shaders allocate from these buffers with atomicAdd
+// on the BumpAlloc counters and do not (and cannot cheaply) abort when an
+// allocation lands past the end — out-of-range writes are clamped by WGSL
+// robustness and the data is silently dropped. The counters, however, keep
+// counting: after a dispatch they record the total demand, whether or not
+// it fit. Dispatch reads them back, compares demand against the allocated
+// capacity, and on overflow resizes the affected buffers to the measured
+// demand and re-dispatches — the same robust buffer sizing loop Vello uses.
+
+// Entry sizes for the bump-allocated buffers, matching the WGSL structs.
+const (
+	// velloSegCountEntrySize is sizeof(SegmentCount): 2 u32 = 8 bytes.
+	velloSegCountEntrySize = 8
+
+	// velloSegmentEntrySize is sizeof(PathSegment): 5 f32 = 20 bytes.
+	velloSegmentEntrySize = 20
+)
+
+// maxDispatchAttempts bounds the resize-and-retry loop in Dispatch.
+// The second attempt uses exact measured demand, so a third overflow
+// indicates demand that grew because previously dropped data became
+// visible; beyond that something is structurally wrong.
+const maxDispatchAttempts = 3
+
+// VelloBumpCounters mirrors the BumpAlloc struct shared by path_count,
+// coarse, and path_tiling (see coarse.wgsl). Values are totals demanded
+// via atomicAdd during a dispatch.
+type VelloBumpCounters struct {
+	// SegCounts is the number of SegmentCount records demanded by path_count.
+	SegCounts uint32
+
+	// Segments is the number of PathSegment slots demanded by coarse.
+	Segments uint32
+
+	// Blend is the number of u32 words of blend spill demanded by coarse
+	// for clip levels beyond BLEND_STACK_SPLIT.
+	Blend uint32
+}
+
+// overflows reports whether any counter exceeds the capacity implied by
+// the allocated buffer sizes.
+func (c VelloBumpCounters) overflows(sz velloBufSizes) bool {
+	return uint64(c.SegCounts)*velloSegCountEntrySize > sz.segCounts ||
+		uint64(c.Segments)*velloSegmentEntrySize > sz.segments ||
+		uint64(c.Blend)*4 > sz.blendSpill
+}
+
+// grownSizes returns sz with the bump-allocated buffer sizes raised to the
+// measured demand. Counters are exact totals, so no extra headroom is
+// needed for the retry; buffers that already fit keep their size.
+func grownSizes(sz velloBufSizes, c VelloBumpCounters) velloBufSizes {
+	if need := uint64(c.SegCounts) * velloSegCountEntrySize; need > sz.segCounts {
+		sz.segCounts = need
+	}
+	if need := uint64(c.Segments) * velloSegmentEntrySize; need > sz.segments {
+		sz.segments = need
+	}
+	if need := uint64(c.Blend) * 4; need > sz.blendSpill {
+		sz.blendSpill = need
+	}
+	return sz
+}
+
+// readBumpCounters copies the BumpAlloc buffer to a staging buffer and
+// reads the counters back to the CPU. The caller must hold d.mu.
+func (d *VelloComputeDispatcher) readBumpCounters(bufs *VelloComputeBuffers) (VelloBumpCounters, error) {
+	const size = 16 // BumpAlloc: 4 u32 words.
+
+	staging, err := d.device.CreateBuffer(&wgpu.BufferDescriptor{
+		Label: "vello_bump_readback",
+		Size:  size,
+		Usage: gputypes.BufferUsageMapRead | gputypes.BufferUsageCopyDst,
+	})
+	if err != nil {
+		return VelloBumpCounters{}, fmt.Errorf("create bump staging buffer: %w", err)
+	}
+	defer staging.Release()
+
+	encoder, err := d.device.CreateCommandEncoder(&wgpu.CommandEncoderDescriptor{
+		Label: "vello_bump_readback",
+	})
+	if err != nil {
+		return VelloBumpCounters{}, fmt.Errorf("create bump readback encoder: %w", err)
+	}
+	encoder.CopyBufferToBuffer(bufs.BumpAlloc, 0, staging, 0, size)
+
+	cmdBuf, err := encoder.Finish()
+	if err != nil {
+		return VelloBumpCounters{}, fmt.Errorf("end bump readback encoding: %w", err)
+	}
+	if _, err := d.queue.Submit(cmdBuf); err != nil {
+		return VelloBumpCounters{}, fmt.Errorf("submit bump readback: %w", err)
+	}
+
+	if err := staging.Map(context.Background(), wgpu.MapModeRead, 0, size); err != nil {
+		return VelloBumpCounters{}, fmt.Errorf("map bump staging: %w", err)
+	}
+	rng, err := staging.MappedRange(0, size)
+	if err != nil {
+		if uErr := staging.Unmap(); uErr != nil {
+			slogger().Warn("unmap failed", "err", uErr)
+		}
+		return VelloBumpCounters{}, fmt.Errorf("bump mapped range: %w", err)
+	}
+	raw := make([]byte, size)
+	copy(raw, rng.Bytes())
+	if err := staging.Unmap(); err != nil {
+		slogger().Warn("unmap failed", "err", err)
+	}
+
+	le := binary.LittleEndian
+	return VelloBumpCounters{
+		SegCounts: le.Uint32(raw[0:4]),
+		Segments:  le.Uint32(raw[4:8]),
+		Blend:     le.Uint32(raw[8:12]),
+	}, nil
+}
+
+// growOverflowBuffers recreates the bump-allocated buffers that overflowed
+// at the sizes demanded by the counters, and re-zeroes every buffer the
+// shaders mutate with atomics so the retry starts from a clean slate.
+// The caller must hold d.mu.
+func (d *VelloComputeDispatcher) growOverflowBuffers(bufs *VelloComputeBuffers, c VelloBumpCounters) error {
+	old := bufs.sizes
+	sz := grownSizes(old, c)
+
+	storageGPU := gputypes.BufferUsageStorage
+	storageZero := gputypes.BufferUsageStorage | gputypes.BufferUsageCopyDst
+
+	recreate := func(target **wgpu.Buffer, label string, size uint64, usage gputypes.BufferUsage) error {
+		buf, err := d.createVelloBuffer(label, size, usage)
+		if err != nil {
+			return fmt.Errorf("vello compute: grow %s buffer: %w", label, err)
+		}
+		(*target).Release()
+		*target = buf
+		return nil
+	}
+
+	if sz.segCounts > old.segCounts {
+		if err := recreate(&bufs.SegCounts, "vello_seg_counts", sz.segCounts, storageGPU); err != nil {
+			return err
+		}
+	}
+	if sz.segments > old.segments {
+		if err := recreate(&bufs.Segments, "vello_segments", sz.segments, storageGPU); err != nil {
+			return err
+		}
+	}
+	if sz.blendSpill > old.blendSpill {
+		if err := recreate(&bufs.BlendSpill, "vello_blend_spill", sz.blendSpill, storageZero); err != nil {
+			return err
+		}
+	}
+	bufs.sizes = sz
+
+	// Reset atomically-mutated state: tile counters/backdrops, PTCL CMD_END
+	// sentinels, bump counters, PTCL write positions, and the blend spill.
+	zeroFill := func(buf *wgpu.Buffer, size uint64, label string) error {
+		if size == 0 {
+			return nil
+		}
+		if err := d.queue.WriteBuffer(buf, 0, make([]byte, size)); err != nil {
+			return fmt.Errorf("vello compute: re-zero %s: %w", label, err)
+		}
+		return nil
+	}
+	if err := zeroFill(bufs.Tiles, sz.tiles, "vello_tiles"); err != nil {
+		return err
+	}
+	if err := zeroFill(bufs.PTCL, sz.ptcl, "vello_ptcl"); err != nil {
+		return err
+	}
+	if err := zeroFill(bufs.BumpAlloc, sz.bumpAlloc, "vello_bump_alloc"); err != nil {
+		return err
+	}
+	if err := zeroFill(bufs.TilePTCLOffsets, sz.tilePTCLOffsets, "vello_tile_ptcl_offsets"); err != nil {
+		return err
+	}
+	return zeroFill(bufs.BlendSpill, sz.blendSpill, "vello_blend_spill")
+}
@@ -0,0 +1,69 @@
+// Copyright 2026 The gogpu Authors
+// SPDX-License-Identifier: MIT
+
+//go:build !nogpu
+
+package gpu
+
+import "testing"
+
+// TestBumpCountersOverflows covers the demand-vs-capacity comparison for
+// each bump-allocated buffer.
+func TestBumpCountersOverflows(t *testing.T) {
+	sz := velloBufSizes{
+		segCounts:  100 * velloSegCountEntrySize,
+		segments:   100 * velloSegmentEntrySize,
+		blendSpill: 100 * 4,
+	}
+
+	tests := []struct {
+		name     string
+		counters VelloBumpCounters
+		want     bool
+	}{
+		{"all within capacity", VelloBumpCounters{SegCounts: 100, Segments: 100, Blend: 100}, false},
+		{"empty scene", VelloBumpCounters{}, false},
+		{"seg_counts overflow", VelloBumpCounters{SegCounts: 101}, true},
+		{"segments overflow", VelloBumpCounters{Segments: 101}, true},
+		{"blend overflow", VelloBumpCounters{Blend: 101}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.counters.overflows(sz); got != tt.want {
+				t.Errorf("overflows() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestGrownSizes verifies that only overflowing buffers grow, and that
+// they grow to the exact measured demand.
+func TestGrownSizes(t *testing.T) {
+	sz := velloBufSizes{
+		segCounts:  10 * velloSegCountEntrySize,
+		segments:   10 * velloSegmentEntrySize,
+		blendSpill: 10 * 4,
+		ptcl:       4096, // unrelated buffers must be untouched
+	}
+
+	counters := VelloBumpCounters{SegCounts: 50, Segments: 8, Blend: 200}
+	grown := grownSizes(sz, counters)
+
+	if grown.segCounts != 50*velloSegCountEntrySize {
+		t.Errorf("segCounts = %d, want %d", grown.segCounts, 50*velloSegCountEntrySize)
+	}
+	if grown.segments != sz.segments {
+		t.Errorf("segments = %d, want unchanged %d", grown.segments, sz.segments)
+	}
+	if grown.blendSpill != 200*4 {
+		t.Errorf("blendSpill = %d, want %d", grown.blendSpill, 200*4)
+	}
+	if grown.ptcl != sz.ptcl {
+		t.Errorf("ptcl = %d, want unchanged %d", grown.ptcl, sz.ptcl)
+	}
+
+	// A grown set of sizes must satisfy the counters that produced it.
+	if counters.overflows(grown) {
+		t.Error("counters still overflow the grown sizes")
+	}
+}
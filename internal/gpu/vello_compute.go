@@ -53,6 +53,9 @@ var shaderPathTiling string
 //go:embed tilecompute/shaders/fine.wgsl
 var shaderVelloFine string
 
+//go:embed tilecompute/shaders/dispatch_setup.wgsl
+var shaderDispatchSetup string
+
 // =============================================================================
 // Constants
 // =============================================================================
@@ -119,6 +122,11 @@ const (
 	// Input: ptcl + segments + tiles. Output: output pixel buffer.
 	VelloStageFine
 
+	// VelloStageDispatchSetup writes indirect dispatch arguments for the
+	// data-dependent stages (path_tiling, fine) from GPU-side counters.
+	// Input: bump. Output: indirect args buffer.
+	VelloStageDispatchSetup
+
 	// VelloStageCount is the total number of pipeline stages.
 	VelloStageCount
 )
@@ -144,6 +152,8 @@ func (s VelloComputeStage) String() string {
 		return "path_tiling"
 	case VelloStageFine:
 		return "fine"
+	case VelloStageDispatchSetup:
+		return "dispatch_setup"
 	default:
 		return fmt.Sprintf("Unknown(%d)", int(s))
 	}
@@ -333,6 +343,16 @@ type VelloComputeBuffers struct {
 	// atomicAdd on bump.blend. Read/written by fine stage.
 	// Size: estimated from max clip depth × total tiles × TILE_WIDTH × TILE_HEIGHT.
 	BlendSpill *wgpu.Buffer
+
+	// Indirect holds dispatch arguments for the data-dependent stages:
+	// two 16-byte DispatchIndirectArgs slots (path_tiling at offset 0,
+	// fine at offset 16). Written by dispatch_setup, consumed by
+	// DispatchIndirect for path_tiling and fine.
+	Indirect *wgpu.Buffer
+
+	// sizes records the allocated byte size of each buffer. Dispatch uses
+	// it to detect bump-allocator overflow and grow the affected buffers.
+	sizes velloBufSizes
 }
 
 // =============================================================================
@@ -410,6 +430,7 @@ func NewVelloComputeDispatcher(device *wgpu.Device, queue *wgpu.Queue) *VelloCom
 		VelloStageCoarse:        shaderVelloCoarse,
 		VelloStagePathTiling:    shaderPathTiling,
 		VelloStageFine:          shaderVelloFine,
+		VelloStageDispatchSetup: shaderDispatchSetup,
 	}
 
 	return d
@@ -542,6 +563,14 @@ func stageBindGroupLayoutEntries(stage VelloComputeStage) []gputypes.BindGroupLa
 			configUniform, storageRO(1), storageRO(2), storageRO(3), storageRW(4), storageRW(5),
 		}
 
+	case VelloStageDispatchSetup:
+		// @binding(0) uniform config
+		// @binding(1) storage(read_write) bump        -- atomicLoad of seg_counts
+		// @binding(2) storage(read_write) indirect
+		return []gputypes.BindGroupLayoutEntry{
+			configUniform, storageRW(1), storageRW(2),
+		}
+
 	default:
 		return nil
 	}
@@ -560,11 +589,14 @@ func (d *VelloComputeDispatcher) Init() error {
 		return nil
 	}
 
+	constants := velloShaderConstants()
+
 	for i := VelloComputeStage(0); i < VelloStageCount; i++ {
 		src := d.shaderSources[i]
 		if src == "" {
 			return fmt.Errorf("vello compute: missing shader source for stage %s", i)
 		}
+		src = injectShaderConstants(src, constants)
 
 		stageName := fmt.Sprintf("vello_%s", i)
 
@@ -726,6 +758,7 @@ type velloBufSizes struct {
 	pathStyles      uint64
 	output          uint64
 	blendSpill      uint64
+	indirect        uint64
 }
 
 // computeBufferSizes calculates byte sizes for all Vello pipeline buffers.
@@ -789,6 +822,7 @@ func (d *VelloComputeDispatcher) computeBufferSizes(
 		pathStyles:      uint64(config.NumPaths) * 4,
 		output:          uint64(config.TargetWidth) * uint64(config.TargetHeight) * 4,
 		blendSpill:      blendSpillSize,
+		indirect:        2 * 16, // two DispatchIndirectArgs slots (path_tiling, fine)
 	}
 }
 
@@ -835,7 +869,7 @@ func (d *VelloComputeDispatcher) AllocateBuffers(
 	}
 
 	sz := d.computeBufferSizes(config, len(sceneData), len(lines), len(paths), numLines, totalPathTiles)
-	bufs := &VelloComputeBuffers{}
+	bufs := &VelloComputeBuffers{sizes: sz}
 
 	// Buffer usage flags:
 	// - storageZero: GPU-side storage that must be zero-initialized (atomics, accumulators).
@@ -876,7 +910,8 @@ func (d *VelloComputeDispatcher) AllocateBuffers(
 		{&bufs.TilePTCLOffsets, "vello_tile_ptcl_offsets", sz.tilePTCLOffsets, storageZero, true},            // coarse write positions
 		{&bufs.PathStyles, "vello_path_styles", sz.pathStyles, storageCPU, false},
 		{&bufs.Output, "vello_output", sz.output, storageOut, false},
-		{&bufs.BlendSpill, "vello_blend_spill", sz.blendSpill, storageZero, true}, // blend stack spill for deep clips
+		{&bufs.BlendSpill, "vello_blend_spill", sz.blendSpill, storageZero, true},                         // blend stack spill for deep clips
+		{&bufs.Indirect, "vello_indirect", sz.indirect, storageGPU | gputypes.BufferUsageIndirect, false}, // written by dispatch_setup
 	}
 
 	for _, s := range specs {
@@ -940,6 +975,7 @@ func (d *VelloComputeDispatcher) DestroyBuffers(bufs *VelloComputeBuffers) {
 	destroyBuf(bufs.PathStyles)
 	destroyBuf(bufs.Output)
 	destroyBuf(bufs.BlendSpill)
+	destroyBuf(bufs.Indirect)
 
 	// Zero out all fields to prevent accidental reuse.
 	*bufs = VelloComputeBuffers{}
@@ -1041,6 +1077,13 @@ func stageBindGroupEntries(stage VelloComputeStage, bufs *VelloComputeBuffers) [
 			entry(5, bufs.BlendSpill),
 		}
 
+	case VelloStageDispatchSetup:
+		return []wgpu.BindGroupEntry{
+			entry(0, bufs.Config),
+			entry(1, bufs.BumpAlloc),
+			entry(2, bufs.Indirect),
+		}
+
 	default:
 		return nil
 	}
@@ -1061,22 +1104,34 @@ func (r *dispatchResources) cleanup() {
 	}
 }
 
-// Dispatch runs the complete 9-stage compute pipeline.
+// Dispatch runs the complete compute pipeline.
 //
 // Each stage dispatches the appropriate shader with the correct buffer
 // bindings and workgroup counts. The stages must execute in order because
 // each stage's output is consumed by subsequent stages.
 //
 // The dispatch sequence is:
-//  1. pathtag_reduce: scene -> reduced (ceil(n_tag_words / 256) workgroups)
-//  2. pathtag_scan:   scene + reduced -> tag_monoids (ceil(n_tag_words / 256) workgroups)
-//  3. draw_reduce:    scene -> draw_reduced (ceil(n_drawobj / 256) workgroups)
-//  4. draw_leaf:      scene + draw_reduced -> draw_monoids + info (ceil(n_drawobj / 256) workgroups)
-//  5. path_count:     lines + paths -> tiles + seg_counts (ceil(n_lines / 256) workgroups)
-//  6. backdrop:       paths + tiles -> tiles (n_paths workgroups)
-//  7. coarse:         draw_monoids + paths + tiles + bump -> ptcl + tiles (ceil(n_drawobj / 256) wg)
-//  8. path_tiling:    seg_counts + lines + paths + tiles -> segments (ceil(n_lines*4 / 256) wg)
-//  9. fine:           ptcl + segments -> output (width_in_tiles * height_in_tiles workgroups)
+//  1. pathtag_reduce:  scene -> reduced (ceil(n_tag_words / 256) workgroups)
+//  2. pathtag_scan:    scene + reduced -> tag_monoids (ceil(n_tag_words / 256) workgroups)
+//  3. draw_reduce:     scene -> draw_reduced (ceil(n_drawobj / 256) workgroups)
+//  4. draw_leaf:       scene + draw_reduced -> draw_monoids + info (ceil(n_drawobj / 256) workgroups)
+//  5. path_count:      lines + paths -> tiles + seg_counts (ceil(n_lines / 256) workgroups)
+//  6. backdrop:        paths + tiles -> tiles (n_paths workgroups)
+//  7. coarse:          draw_monoids + paths + tiles + bump -> ptcl + tiles (ceil(n_drawobj / 256) wg)
+//  8. dispatch_setup:  bump -> indirect args (1 workgroup)
+//  9. path_tiling:     seg_counts + lines + paths + tiles -> segments (indirect: ceil(seg_counts / 256) wg)
+//  10. fine:           ptcl + segments -> output (indirect: width_in_tiles * height_in_tiles workgroups)
+//
+// path_tiling and fine are dispatched indirectly: their workgroup counts
+// are written by dispatch_setup on the GPU, sized from the actual segment
+// count in bump.seg_counts rather than a CPU worst-case estimate.
+//
+// After the stages complete, the BumpAlloc counters are read back and
+// compared against the allocated capacities of the bump-allocated buffers
+// (SegCounts, Segments, BlendSpill). If the CPU-side size estimate was
+// exceeded — meaning the shaders silently dropped data — the affected
+// buffers are reallocated at the measured demand and the whole pipeline
+// re-dispatched, up to maxDispatchAttempts times.
 //
 // Returns an error if any stage fails or if the dispatcher is not initialized.
 func (d *VelloComputeDispatcher) Dispatch(bufs *VelloComputeBuffers, config VelloComputeConfig) error {
@@ -1091,6 +1146,45 @@ func (d *VelloComputeDispatcher) Dispatch(bufs *VelloComputeBuffers, config Vell
 		return fmt.Errorf("vello compute: buffers must not be nil")
 	}
 
+	for attempt := 1; ; attempt++ {
+		err := captureGPUErrors(d.device, "vello dispatch", func() error {
+			return d.dispatchOnce(bufs, config)
+		})
+		if err != nil {
+			return err
+		}
+
+		counters, err := d.readBumpCounters(bufs)
+		if err != nil {
+			// Readback failure leaves us unable to verify sizing; the
+			// dispatch itself succeeded, so keep the result.
+			slogger().Warn("vello compute: bump counter readback failed, skipping overflow check",
+				"error", err)
+			return nil
+		}
+		if !counters.overflows(bufs.sizes) {
+			return nil
+		}
+		if attempt == maxDispatchAttempts {
+			return fmt.Errorf("vello compute: bump allocator overflow persists after %d attempts "+
+				"(seg_counts=%d, segments=%d, blend=%d)",
+				attempt, counters.SegCounts, counters.Segments, counters.Blend)
+		}
+
+		slogger().Debug("vello compute: bump allocator overflow, growing buffers and re-dispatching",
+			"attempt", attempt,
+			"seg_counts", counters.SegCounts,
+			"segments", counters.Segments,
+			"blend", counters.Blend)
+		if err := d.growOverflowBuffers(bufs, counters); err != nil {
+			return err
+		}
+	}
+}
+
+// dispatchOnce encodes and submits one full pass over the pipeline stages.
+// The caller must hold d.mu and have validated bufs.
+func (d *VelloComputeDispatcher) dispatchOnce(bufs *VelloComputeBuffers, config VelloComputeConfig) error {
 	// Upload config uniform to the GPU.
 	if err := d.queue.WriteBuffer(bufs.Config, 0, config.toBytes()); err != nil {
 		return fmt.Errorf("vello compute: write config: %w", err)
@@ -1104,29 +1198,30 @@ func (d *VelloComputeDispatcher) Dispatch(bufs *VelloComputeBuffers, config Vell
 
 	totalTiles := config.WidthInTiles * config.HeightInTiles
 
-	// path_tiling element count: use estimated segment count (n_lines * 4).
-	// The shader itself checks against atomicLoad(&bump.seg_counts) and
-	// returns early for excess threads, so over-dispatching is safe.
-	pathTilingElements := config.NumLines * 4
-
-	stages := [VelloStageCount]stageDispatch{
-		{VelloStagePathtagReduce, nTagWords, 0},
-		{VelloStagePathtagScan, nTagWords, 0},
-		{VelloStageDrawReduce, config.NumDrawObj, 0},
-		{VelloStageDrawLeaf, config.NumDrawObj, 0},
-		{VelloStagePathCount, config.NumLines, 0},
-		{VelloStageBackdrop, config.NumPaths, 0},
-		{VelloStageCoarse, totalTiles, 0},
-		{VelloStagePathTiling, pathTilingElements, 0},
-		// Fine: 2D dispatch (widthInTiles, heightInTiles, 1).
-		// workgroup_size(4,16,1) = 64 threads per tile, one workgroup per tile.
-		{VelloStageFine, config.WidthInTiles, config.HeightInTiles},
+	stages := []stageDispatch{
+		{stage: VelloStagePathtagReduce, elements: nTagWords},
+		{stage: VelloStagePathtagScan, elements: nTagWords},
+		{stage: VelloStageDrawReduce, elements: config.NumDrawObj},
+		{stage: VelloStageDrawLeaf, elements: config.NumDrawObj},
+		{stage: VelloStagePathCount, elements: config.NumLines},
+		{stage: VelloStageBackdrop, elements: config.NumPaths},
+		{stage: VelloStageCoarse, elements: totalTiles},
+		// dispatch_setup writes the indirect args for the data-dependent
+		// stages below from bump.seg_counts and the tile grid.
+		{stage: VelloStageDispatchSetup, elements: 1},
+		// path_tiling: one thread per SegmentCount record; the exact count
+		// lives in bump.seg_counts on the GPU, so the workgroup count comes
+		// from the indirect buffer instead of a CPU worst-case estimate.
+		{stage: VelloStagePathTiling, indirect: true, indirectOffset: 0},
+		// Fine: 2D dispatch (widthInTiles, heightInTiles, 1) via the second
+		// indirect slot. workgroup_size(4,16,1) = 64 threads per tile.
+		{stage: VelloStageFine, indirect: true, indirectOffset: 16},
 	}
 
 	res := &dispatchResources{device: d.device}
 	defer res.cleanup()
 
-	if err := d.encodeComputeStages(res, bufs, stages[:]); err != nil {
+	if err := d.encodeComputeStages(res, bufs, stages); err != nil {
 		return err
 	}
 
@@ -1147,18 +1242,13 @@ func (d *VelloComputeDispatcher) encodeComputeStages(
 	}
 
 	for _, sd := range stages {
-		var wgX, wgY uint32
-		if sd.wgY > 0 {
-			// 2D dispatch: (elements, wgY, 1) — used for fine stage.
-			wgX = sd.elements
-			wgY = sd.wgY
-		} else {
+		var wgX uint32
+		if !sd.indirect {
 			// 1D dispatch: (ComputeWorkgroupCount(elements), 1, 1).
 			wgX = d.ComputeWorkgroupCount(sd.stage, sd.elements)
-			wgY = 1
-		}
-		if wgX == 0 {
-			continue
+			if wgX == 0 {
+				continue
+			}
 		}
 
 		bg, bgErr := d.device.CreateBindGroup(&wgpu.BindGroupDescriptor{
@@ -1181,14 +1271,18 @@ func (d *VelloComputeDispatcher) encodeComputeStages(
 		}
 		pass.SetPipeline(d.pipelines[sd.stage])
 		pass.SetBindGroup(0, bg, nil)
-		pass.Dispatch(wgX, wgY, 1)
+		if sd.indirect {
+			pass.DispatchIndirect(bufs.Indirect, sd.indirectOffset)
+		} else {
+			pass.Dispatch(wgX, 1, 1)
+		}
 		_ = pass.End()
 
 		slogger().Debug("vello compute: dispatched stage",
 			"stage", sd.stage.String(),
 			"elements", sd.elements,
 			"workgroups_x", wgX,
-			"workgroups_y", wgY)
+			"indirect", sd.indirect)
 	}
 
 	cmdBuf, err := encoder.Finish()
@@ -1203,10 +1297,11 @@ func (d *VelloComputeDispatcher) encodeComputeStages(
 type stageDispatch struct {
 	stage    VelloComputeStage
 	elements uint32
-	// For 2D dispatch (fine stage): wgY is the Y workgroup count.
-	// When 0, dispatch is 1D: (ComputeWorkgroupCount(elements), 1, 1).
-	// When >0, dispatch is 2D: (elements, wgY, 1) — elements is used as X count directly.
-	wgY uint32
+	// indirect selects GPU-driven dispatch: workgroup counts are read from
+	// the Indirect buffer at indirectOffset (written by dispatch_setup)
+	// instead of being derived from elements on the CPU.
+	indirect       bool
+	indirectOffset uint64
 }
 
 // submitAndWait submits the command buffer and waits for GPU completion.
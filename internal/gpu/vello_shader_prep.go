@@ -0,0 +1,54 @@
+// Copyright 2026 The gogpu Authors
+// SPDX-License-Identifier: MIT
+
+//go:build !nogpu
+
+package gpu
+
+import (
+	"fmt"
+	"regexp"
+
+	"github.com/gogpu/gg/internal/gpu/tilecompute"
+)
+
+// Shader constant injection.
+//
+// Tile dimensions, workgroup sizes, and the PTCL per-tile budget exist twice:
+// as Go constants driving buffer sizing and workgroup math, and as WGSL
+// `const` literals inside the embedded shaders. Rather than trusting the two
+// copies to stay in sync by review, Init rewrites the declared values from
+// the Go side before compiling each shader, so the Go constants are the
+// single source of truth and the WGSL literals are only defaults.
+
+// wgslConstDecl matches a top-level WGSL u32 constant declaration, capturing
+// the name. Only integer-literal initializers are rewritten; expression
+// initializers are left alone.
+var wgslConstDecl = regexp.MustCompile(`(?m)^const ([A-Z_][A-Z0-9_]*): u32 = \d+u;`)
+
+// velloShaderConstants maps WGSL constant names to their Go-side values.
+// Names not listed here keep their literal value in the shader source.
+func velloShaderConstants() map[string]uint32 {
+	return map[string]uint32{
+		"WG_SIZE":             velloWGSize,
+		"PATH_TILING_WG_SIZE": velloWGSize,
+		"TILE_WIDTH":          tilecompute.TileWidth,
+		"TILE_HEIGHT":         tilecompute.TileHeight,
+		"PTCL_MAX_PER_TILE":   velloPTCLMaxPerTile,
+	}
+}
+
+// injectShaderConstants rewrites the initializers of known `const NAME: u32`
+// declarations in a WGSL source to the values in consts. Declarations whose
+// name is not in the map, and lines that are not simple u32 constant
+// declarations, pass through unchanged.
+func injectShaderConstants(src string, consts map[string]uint32) string {
+	return wgslConstDecl.ReplaceAllStringFunc(src, func(decl string) string {
+		name := wgslConstDecl.FindStringSubmatch(decl)[1]
+		value, ok := consts[name]
+		if !ok {
+			return decl
+		}
+		return fmt.Sprintf("const %s: u32 = %du;", name, value)
+	})
+}
@@ -0,0 +1,62 @@
+// Copyright 2026 The gogpu Authors
+// SPDX-License-Identifier: MIT
+
+//go:build !nogpu
+
+package gpu
+
+import (
+	"strings"
+	"testing"
+)
+
+// TestInjectShaderConstants covers value rewriting, unknown-name
+// passthrough, and non-matching lines.
+func TestInjectShaderConstants(t *testing.T) {
+	src := strings.Join([]string{
+		"const WG_SIZE: u32 = 256u;",
+		"const OTHER: u32 = 7u;",
+		"const FRAC: f32 = 0.5;",
+		"    const INDENTED: u32 = 3u;",
+	}, "\n")
+
+	got := injectShaderConstants(src, map[string]uint32{
+		"WG_SIZE": 128,
+		"MISSING": 999,
+	})
+
+	if !strings.Contains(got, "const WG_SIZE: u32 = 128u;") {
+		t.Errorf("WG_SIZE not rewritten:\n%s", got)
+	}
+	if !strings.Contains(got, "const OTHER: u32 = 7u;") {
+		t.Errorf("unknown constant was modified:\n%s", got)
+	}
+	if !strings.Contains(got, "const FRAC: f32 = 0.5;") {
+		t.Errorf("non-u32 declaration was modified:\n%s", got)
+	}
+	if !strings.Contains(got, "const INDENTED: u32 = 3u;") {
+		t.Errorf("indented (non-top-level) declaration was modified:\n%s", got)
+	}
+	if strings.Contains(got, "MISSING") {
+		t.Errorf("absent constant was injected:\n%s", got)
+	}
+}
+
+// TestShaderConstantsMatchWGSL asserts the embedded shader literals agree
+// with the Go constants: injecting the Go values into every stage source
+// must be a no-op. A failure here means a constant drifted between Go and
+// WGSL.
+func TestShaderConstantsMatchWGSL(t *testing.T) {
+	d := NewVelloComputeDispatcher(nil, nil)
+	constants := velloShaderConstants()
+
+	for i := VelloComputeStage(0); i < VelloStageCount; i++ {
+		src := d.shaderSources[i]
+		if src == "" {
+			t.Fatalf("missing shader source for stage %s", i)
+		}
+		if injected := injectShaderConstants(src, constants); injected != src {
+			t.Errorf("stage %s: WGSL constant literals differ from Go constants", i)
+		}
+	}
+}
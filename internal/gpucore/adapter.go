@@ -182,6 +182,41 @@ type ComputePassEncoder interface {
 	End()
 }
 
+// AsyncComputeAdapter is an optional interface for adapters that can submit
+// compute work on a queue separate from the host application's render queue.
+// On backends with a dedicated compute queue (e.g. Vulkan async compute),
+// this lets the Vello pipeline overlap with frame rendering instead of
+// serializing behind it.
+//
+// Callers probe for the interface with a type assertion and should prefer
+// [SubmitCompute], which falls back to [GPUAdapter.Submit] when the adapter
+// does not implement it or reports no dedicated queue.
+type AsyncComputeAdapter interface {
+	// SupportsAsyncCompute reports whether a dedicated compute queue is
+	// available. When false, SubmitAsync behaves exactly like Submit.
+	SupportsAsyncCompute() bool
+
+	// SubmitAsync submits recorded compute commands on the dedicated
+	// compute queue when one is available, and on the default queue
+	// otherwise. It does not block on GPU completion.
+	SubmitAsync()
+
+	// WaitAsync blocks until all work submitted via SubmitAsync has
+	// completed, including any pending recorded commands.
+	WaitAsync()
+}
+
+// SubmitCompute submits recorded compute commands on the adapter, using the
+// dedicated compute queue when the adapter offers one and the default queue
+// otherwise.
+func SubmitCompute(a GPUAdapter) {
+	if async, ok := a.(AsyncComputeAdapter); ok && async.SupportsAsyncCompute() {
+		async.SubmitAsync()
+		return
+	}
+	a.Submit()
+}
+
 // AdapterCapabilities describes GPU adapter capabilities.
 type AdapterCapabilities struct {
 	// SupportsCompute indicates compute shader support.
@@ -0,0 +1,57 @@
+package image
+
+import "sync"
+
+// CustomBlendFunc blends one pair of straight-alpha color channels.
+// Both values are in the range 0-255; the result is the blended channel
+// before alpha compositing, which the renderer applies afterwards exactly
+// as for the built-in modes.
+type CustomBlendFunc func(src, dst uint8) uint8
+
+// CustomBlendBase is the first BlendMode value handed out to registered
+// custom modes. Values below it are reserved for built-in modes.
+const CustomBlendBase BlendMode = 64
+
+var (
+	customBlendMu    sync.RWMutex
+	customBlendFuncs map[BlendMode]CustomBlendFunc
+	customBlendNames map[BlendMode]string
+	nextCustomBlend  = int(CustomBlendBase)
+)
+
+// RegisterBlendFunc allocates a fresh BlendMode value for a custom blend
+// function. The name is used by BlendMode.String. It reports false when
+// the BlendMode value space is exhausted.
+// Safe for concurrent use.
+func RegisterBlendFunc(name string, fn CustomBlendFunc) (BlendMode, bool) {
+	customBlendMu.Lock()
+	defer customBlendMu.Unlock()
+	if nextCustomBlend > 255 {
+		return 0, false
+	}
+	mode := BlendMode(nextCustomBlend) //nolint:gosec // bounded to [64,255] above
+	nextCustomBlend++
+	if customBlendFuncs == nil {
+		customBlendFuncs = make(map[BlendMode]CustomBlendFunc)
+		customBlendNames = make(map[BlendMode]string)
+	}
+	customBlendFuncs[mode] = fn
+	customBlendNames[mode] = name
+	return mode, true
+}
+
+// customBlendFunc returns the registered function for a custom mode.
+func customBlendFunc(mode BlendMode) (CustomBlendFunc, bool) {
+	customBlendMu.RLock()
+	defer customBlendMu.RUnlock()
+	fn, ok := customBlendFuncs[mode]
+	return fn, ok
+}
+
+// customBlendName returns the registered name for a custom mode.
+func customBlendName(mode BlendMode) (string, bool) {
+	customBlendMu.RLock()
+	defer customBlendMu.RUnlock()
+	name, ok := customBlendNames[mode]
+	return name, ok
+}
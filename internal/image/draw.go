@@ -43,6 +43,9 @@ func (b BlendMode) String() string {
 	case BlendOverlay:
 		return "Overlay"
 	default:
+		if name, ok := customBlendName(b); ok {
+			return name
+		}
 		return unknownBlendMode
 	}
 }
@@ -203,7 +206,11 @@ func blend(srcR, srcG, srcB, srcA, dstR, dstG, dstB, dstA uint8, mode BlendMode)
 	case BlendOverlay:
 		blendedR, blendedG, blendedB = blendOverlay(srcR, srcG, srcB, dstR, dstG, dstB)
 	default:
-		blendedR, blendedG, blendedB = srcR, srcG, srcB
+		if fn, ok := customBlendFunc(mode); ok {
+			blendedR, blendedG, blendedB = fn(srcR, dstR), fn(srcG, dstG), fn(srcB, dstB)
+		} else {
+			blendedR, blendedG, blendedB = srcR, srcG, srcB
+		}
 	}
 
 	// Apply alpha blending to the blended result
@@ -18,6 +18,16 @@ const (
 	// InterpBicubic performs cubic interpolation using a 4x4 pixel neighborhood.
 	// Highest quality but slower than bilinear.
 	InterpBicubic
+
+	// InterpMitchell performs cubic interpolation with the Mitchell-Netravali
+	// filter (B=1/3, C=1/3). Slightly softer than Catmull-Rom bicubic with
+	// fewer ringing artifacts; a good default for downscaling.
+	InterpMitchell
+
+	// InterpLanczos performs Lanczos3 resampling using a 6x6 pixel
+	// neighborhood. Sharpest results for photographic content, at the
+	// highest cost.
+	InterpLanczos
 )
 
 // String returns a string representation of the interpolation mode.
@@ -29,6 +39,10 @@ func (m InterpolationMode) String() string {
 		return "Bilinear"
 	case InterpBicubic:
 		return "Bicubic"
+	case InterpMitchell:
+		return "Mitchell"
+	case InterpLanczos:
+		return "Lanczos"
 	default:
 		return "Unknown"
 	}
@@ -45,6 +59,10 @@ func Sample(img *ImageBuf, u, v float64, mode InterpolationMode) (r, g, b, a byt
 		return SampleBilinear(img, u, v)
 	case InterpBicubic:
 		return SampleBicubic(img, u, v)
+	case InterpMitchell:
+		return SampleMitchell(img, u, v)
+	case InterpLanczos:
+		return SampleLanczos(img, u, v)
 	default:
 		return 0, 0, 0, 0
 	}
@@ -146,6 +164,105 @@ func SampleBicubic(img *ImageBuf, u, v float64) (r, g, b, a byte) {
 	return r, g, b, a
 }
 
+// SampleMitchell performs cubic interpolation at normalized coordinates (u, v)
+// using the Mitchell-Netravali filter over a 4x4 pixel neighborhood.
+func SampleMitchell(img *ImageBuf, u, v float64) (r, g, b, a byte) {
+	return sampleKernel(img, u, v, 2, mitchellWeight)
+}
+
+// SampleLanczos performs Lanczos3 resampling at normalized coordinates (u, v)
+// over a 6x6 pixel neighborhood.
+func SampleLanczos(img *ImageBuf, u, v float64) (r, g, b, a byte) {
+	return sampleKernel(img, u, v, 3, lanczosWeight)
+}
+
+// sampleKernel samples with a separable filter of the given radius.
+// The per-axis weights are normalized so partially supported kernels
+// (Lanczos) do not shift brightness.
+func sampleKernel(img *ImageBuf, u, v float64, radius int, weight func(float64) float64) (r, g, b, a byte) {
+	w, h := img.Bounds()
+
+	fx := u*float64(w) - 0.5
+	fy := v*float64(h) - 0.5
+	x := int(math.Floor(fx))
+	y := int(math.Floor(fy))
+	tx := fx - float64(x)
+	ty := fy - float64(y)
+
+	size := 2 * radius
+	wx := make([]float64, size)
+	wy := make([]float64, size)
+	var sumX, sumY float64
+	for i := 0; i < size; i++ {
+		d := float64(i - radius + 1)
+		wx[i] = weight(tx - d)
+		wy[i] = weight(ty - d)
+		sumX += wx[i]
+		sumY += wy[i]
+	}
+	if sumX != 0 {
+		for i := range wx {
+			wx[i] /= sumX
+		}
+	}
+	if sumY != 0 {
+		for i := range wy {
+			wy[i] /= sumY
+		}
+	}
+
+	var rAcc, gAcc, bAcc, aAcc float64
+	for j := 0; j < size; j++ {
+		py := clamp(y+j-radius+1, 0, h-1)
+		for i := 0; i < size; i++ {
+			px := clamp(x+i-radius+1, 0, w-1)
+			pr, pg, pb, pa := img.GetRGBA(px, py)
+			wgt := wx[i] * wy[j]
+			rAcc += float64(pr) * wgt
+			gAcc += float64(pg) * wgt
+			bAcc += float64(pb) * wgt
+			aAcc += float64(pa) * wgt
+		}
+	}
+
+	r = byte(clampFloat(rAcc+0.5, 0, 255))
+	g = byte(clampFloat(gAcc+0.5, 0, 255))
+	b = byte(clampFloat(bAcc+0.5, 0, 255))
+	a = byte(clampFloat(aAcc+0.5, 0, 255))
+	return r, g, b, a
+}
+
+// mitchellWeight computes the Mitchell-Netravali cubic weight (B=C=1/3)
+// for distance t.
+func mitchellWeight(t float64) float64 {
+	const b = 1.0 / 3.0
+	const c = 1.0 / 3.0
+	absT := math.Abs(t)
+	t2 := absT * absT
+	t3 := t2 * absT
+	if absT < 1 {
+		return ((12-9*b-6*c)*t3 + (-18+12*b+6*c)*t2 + (6 - 2*b)) / 6
+	}
+	if absT < 2 {
+		return ((-b-6*c)*t3 + (6*b+30*c)*t2 + (-12*b-48*c)*absT + (8*b + 24*c)) / 6
+	}
+	return 0
+}
+
+// lanczosWeight computes the Lanczos3 windowed-sinc weight for distance t.
+func lanczosWeight(t float64) float64 {
+	const a = 3.0
+	absT := math.Abs(t)
+	if absT < 1e-8 {
+		return 1
+	}
+	if absT >= a {
+		return 0
+	}
+	pt := math.Pi * t
+	return a * math.Sin(pt) * math.Sin(pt/a) / (pt * pt)
+}
+
 // clamp clamps an integer value to [minVal, maxVal].
 //
 //nolint:unparam // minVal is always 0 currently, but function is general-purpose
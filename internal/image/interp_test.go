@@ -328,6 +328,8 @@ func TestSampleDispatch(t *testing.T) {
 		{"nearest mode", InterpNearest},
 		{"bilinear mode", InterpBilinear},
 		{"bicubic mode", InterpBicubic},
+		{"mitchell mode", InterpMitchell},
+		{"lanczos mode", InterpLanczos},
 	}
 
 	for _, tt := range tests {
@@ -372,7 +374,7 @@ func TestSampleAllFormats(t *testing.T) {
 			}
 
 			// Test each interpolation mode
-			modes := []InterpolationMode{InterpNearest, InterpBilinear, InterpBicubic}
+			modes := []InterpolationMode{InterpNearest, InterpBilinear, InterpBicubic, InterpMitchell, InterpLanczos}
 			for _, mode := range modes {
 				r, g, b, a := Sample(img, 0.5, 0.5, mode)
 
@@ -399,6 +401,8 @@ func TestInterpolationModeString(t *testing.T) {
 		{InterpNearest, "Nearest"},
 		{InterpBilinear, "Bilinear"},
 		{InterpBicubic, "Bicubic"},
+		{InterpMitchell, "Mitchell"},
+		{InterpLanczos, "Lanczos"},
 		{InterpolationMode(99), "Unknown"},
 	}
 
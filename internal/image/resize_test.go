@@ -0,0 +1,187 @@
+package image
+
+import "testing"
+
+// solidImage returns a w x h RGBA8 buffer filled with one color.
+func solidImage(t *testing.T, w, h int, r, g, b, a byte) *ImageBuf {
+	t.Helper()
+	img, err := NewImageBuf(w, h, FormatRGBA8)
+	if err != nil {
+		t.Fatalf("NewImageBuf failed: %v", err)
+	}
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			_ = img.SetRGBA(x, y, r, g, b, a)
+		}
+	}
+	return img
+}
+
+// checkerImage returns a w x h buffer alternating black and white pixels.
+func checkerImage(t *testing.T, w, h int) *ImageBuf {
+	t.Helper()
+	img, err := NewImageBuf(w, h, FormatRGBA8)
+	if err != nil {
+		t.Fatalf("NewImageBuf failed: %v", err)
+	}
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			v := byte(0)
+			if (x+y)%2 == 0 {
+				v = 255
+			}
+			_ = img.SetRGBA(x, y, v, v, v, 255)
+		}
+	}
+	return img
+}
+
+func TestResizePreservesFlatField(t *testing.T) {
+	src := solidImage(t, 16, 16, 200, 100, 50, 255)
+
+	modes := []InterpolationMode{InterpNearest, InterpBilinear, InterpBicubic, InterpMitchell, InterpLanczos}
+	for _, mode := range modes {
+		t.Run(mode.String(), func(t *testing.T) {
+			for _, size := range []int{4, 8, 32} {
+				dst := Resize(src, size, size, mode)
+				if dst == nil {
+					t.Fatalf("Resize to %d returned nil", size)
+				}
+				if w, h := dst.Bounds(); w != size || h != size {
+					t.Fatalf("dst size = %dx%d, want %dx%d", w, h, size, size)
+				}
+				// A flat field must stay flat under any filter.
+				for _, pt := range [][2]int{{0, 0}, {size / 2, size / 2}, {size - 1, size - 1}} {
+					r, g, b, a := dst.GetRGBA(pt[0], pt[1])
+					if absInt(int(r)-200) > 1 || absInt(int(g)-100) > 1 || absInt(int(b)-50) > 1 || a != 255 {
+						t.Errorf("size %d pixel %v = (%d,%d,%d,%d), want ~(200,100,50,255)",
+							size, pt, r, g, b, a)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestResizeDownscaleAverages(t *testing.T) {
+	// A fine checkerboard downscaled heavily must converge to mid-gray.
+	// Point sampling would produce pure black or white; a correct
+	// area filter averages.
+	src := checkerImage(t, 32, 32)
+
+	modes := []InterpolationMode{InterpBilinear, InterpBicubic, InterpMitchell, InterpLanczos}
+	for _, mode := range modes {
+		t.Run(mode.String(), func(t *testing.T) {
+			dst := Resize(src, 4, 4, mode)
+			if dst == nil {
+				t.Fatal("Resize returned nil")
+			}
+			r, _, _, _ := dst.GetRGBA(2, 2)
+			if absInt(int(r)-128) > 16 {
+				t.Errorf("downscaled checker center = %d, want ~128", r)
+			}
+		})
+	}
+}
+
+func TestResizeNearest(t *testing.T) {
+	src := checkerImage(t, 4, 4)
+
+	// 2x nearest upscale duplicates pixels exactly.
+	dst := Resize(src, 8, 8, InterpNearest)
+	if dst == nil {
+		t.Fatal("Resize returned nil")
+	}
+	for y := 0; y < 8; y++ {
+		for x := 0; x < 8; x++ {
+			wantR, _, _, _ := src.GetRGBA(x/2, y/2)
+			r, _, _, _ := dst.GetRGBA(x, y)
+			if r != wantR {
+				t.Fatalf("dst(%d,%d) = %d, want %d", x, y, r, wantR)
+			}
+		}
+	}
+}
+
+func TestResizeInvalidInputs(t *testing.T) {
+	src := solidImage(t, 4, 4, 255, 255, 255, 255)
+
+	if dst := Resize(nil, 4, 4, InterpBilinear); dst != nil {
+		t.Error("Resize(nil) should return nil")
+	}
+	if dst := Resize(src, 0, 4, InterpBilinear); dst != nil {
+		t.Error("Resize with zero width should return nil")
+	}
+	if dst := Resize(src, 4, -1, InterpBilinear); dst != nil {
+		t.Error("Resize with negative height should return nil")
+	}
+}
+
+func TestResizeWithMipmaps(t *testing.T) {
+	src := checkerImage(t, 64, 64)
+
+	// Heavy downscale (8x) goes through the mipmap chain.
+	dst := ResizeWithMipmaps(src, 8, 8, InterpMitchell)
+	if dst == nil {
+		t.Fatal("ResizeWithMipmaps returned nil")
+	}
+	if w, h := dst.Bounds(); w != 8 || h != 8 {
+		t.Fatalf("dst size = %dx%d, want 8x8", w, h)
+	}
+	r, _, _, _ := dst.GetRGBA(4, 4)
+	if absInt(int(r)-128) > 16 {
+		t.Errorf("heavy downscale center = %d, want ~128", r)
+	}
+
+	// Mild downscale takes the direct path and still works.
+	dst = ResizeWithMipmaps(src, 48, 48, InterpBilinear)
+	if dst == nil {
+		t.Fatal("ResizeWithMipmaps (mild) returned nil")
+	}
+	if w, h := dst.Bounds(); w != 48 || h != 48 {
+		t.Fatalf("dst size = %dx%d, want 48x48", w, h)
+	}
+}
+
+func TestSampleLanczosPixelCenter(t *testing.T) {
+	// Lanczos is an interpolating filter: sampling exactly at a pixel
+	// center on a flat row reproduces the pixel value.
+	img := solidImage(t, 8, 8, 180, 90, 45, 255)
+
+	u := (3.0 + 0.5) / 8.0
+	v := (4.0 + 0.5) / 8.0
+	r, g, b, a := SampleLanczos(img, u, v)
+	if absInt(int(r)-180) > 1 || absInt(int(g)-90) > 1 || absInt(int(b)-45) > 1 || a != 255 {
+		t.Errorf("SampleLanczos center = (%d,%d,%d,%d), want ~(180,90,45,255)", r, g, b, a)
+	}
+}
+
+func TestSampleMitchellEdgeGradient(t *testing.T) {
+	// A hard vertical edge sampled with Mitchell produces a value
+	// strictly between the two sides, without overshoot beyond [0,255].
+	img, err := NewImageBuf(8, 8, FormatRGBA8)
+	if err != nil {
+		t.Fatalf("NewImageBuf failed: %v", err)
+	}
+	for y := 0; y < 8; y++ {
+		for x := 0; x < 8; x++ {
+			v := byte(0)
+			if x >= 4 {
+				v = 255
+			}
+			_ = img.SetRGBA(x, y, v, v, v, 255)
+		}
+	}
+
+	r, _, _, _ := SampleMitchell(img, 0.5, 0.5)
+	if r < 32 || r > 224 {
+		t.Errorf("edge sample = %d, want a blended mid value", r)
+	}
+}
+
+func absInt(v int) int {
+	if v < 0 {
+		return -v
+	}
+	return v
+}
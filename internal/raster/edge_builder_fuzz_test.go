@@ -0,0 +1,93 @@
+// Copyright 2026 The gogpu Authors
+// SPDX-License-Identifier: MIT
+
+package raster
+
+import (
+	"encoding/binary"
+	"math"
+	"testing"
+)
+
+// decodeFuzzVerbs converts fuzz bytes into a verb/coord stream matching
+// the counts BuildFromPathF64 expects per verb. Coordinates are raw
+// float64 bits so degenerate values occur naturally.
+func decodeFuzzVerbs(data []byte) ([]byte, []float64) {
+	var verbs []byte
+	var coords []float64
+	readF64 := func() float64 {
+		if len(data) < 8 {
+			data = nil
+			return 0
+		}
+		v := math.Float64frombits(binary.LittleEndian.Uint64(data))
+		data = data[8:]
+		return v
+	}
+	for len(data) > 0 {
+		op := data[0] % 5
+		data = data[1:]
+		verbs = append(verbs, op)
+		n := 0
+		switch PathVerb(op) {
+		case MoveTo, LineTo:
+			n = 2
+		case QuadTo:
+			n = 4
+		case CubicTo:
+			n = 6
+		}
+		for i := 0; i < n; i++ {
+			coords = append(coords, readF64())
+		}
+	}
+	return verbs, coords
+}
+
+// FuzzBuildFromPathF64 fuzzes edge building, including the clipper, with
+// arbitrary coordinates. Building must not panic and the accumulated
+// bounds must stay ordered when edges were produced.
+func FuzzBuildFromPathF64(f *testing.F) {
+	seed := []byte{0}
+	seed = binary.LittleEndian.AppendUint64(seed, math.Float64bits(10))
+	seed = binary.LittleEndian.AppendUint64(seed, math.Float64bits(10))
+	seed = append(seed, 1)
+	seed = binary.LittleEndian.AppendUint64(seed, math.Float64bits(100))
+	seed = binary.LittleEndian.AppendUint64(seed, math.Float64bits(50))
+	seed = append(seed, 4)
+	f.Add(seed)
+	// Coordinates far outside any reasonable clip.
+	big := []byte{0}
+	big = binary.LittleEndian.AppendUint64(big, math.Float64bits(-1e12))
+	big = binary.LittleEndian.AppendUint64(big, math.Float64bits(1e12))
+	big = append(big, 3)
+	for i := 0; i < 6; i++ {
+		big = binary.LittleEndian.AppendUint64(big, math.Float64bits(1e12))
+	}
+	f.Add(big)
+	// NaN quad.
+	nan := []byte{0, 0, 0, 0, 0, 0, 0, 0, 0, 2}
+	for i := 0; i < 4; i++ {
+		nan = binary.LittleEndian.AppendUint64(nan, math.Float64bits(math.NaN()))
+	}
+	f.Add(nan)
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		verbs, coords := decodeFuzzVerbs(data)
+
+		eb := NewEdgeBuilder(2)
+		eb.SetClipRect(&Rect{MinX: 0, MinY: 0, MaxX: 256, MaxY: 256})
+		eb.BuildFromPathF64(verbs, coords)
+
+		if eb.EdgeCount() > 0 {
+			b := eb.Bounds()
+			if b.MinX > b.MaxX || b.MinY > b.MaxY {
+				t.Fatalf("inverted bounds: %+v", b)
+			}
+		}
+
+		// Reuse after Reset must behave like a fresh builder.
+		eb.Reset()
+		eb.BuildFromPathF64(verbs, coords)
+	})
+}
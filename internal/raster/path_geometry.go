@@ -5,6 +5,8 @@ package raster
 
 import (
 	"math"
+
+	"github.com/gogpu/gg/internal/detmath"
 )
 
 // Path Geometry Functions for Y-Monotonic Curve Processing
@@ -374,7 +376,7 @@ func findUnitQuadRoots(a, b, c float32) []float32 {
 		return nil
 	}
 
-	sqrtD := float32(math.Sqrt(float64(discriminant)))
+	sqrtD := float32(detmath.Sqrt(float64(discriminant)))
 	inv2a := 1.0 / (2 * a)
 
 	t1 := (-b - sqrtD) * inv2a
@@ -12,6 +12,9 @@ package stroke
 
 import (
 	"math"
+
+	"github.com/gogpu/gg/internal/detmath"
+	"github.com/gogpu/gg/internal/trace"
 )
 
 // Point represents a 2D point (internal copy to avoid import cycle).
@@ -84,7 +87,7 @@ func (v Vec2) Cross(w Vec2) float64 {
 
 // Length returns the length of the vector.
 func (v Vec2) Length() float64 {
-	return math.Sqrt(v.X*v.X + v.Y*v.Y)
+	return detmath.Sqrt(v.X*v.X + v.Y*v.Y)
 }
 
 // LengthSquared returns the squared length of the vector.
@@ -138,6 +141,15 @@ const (
 	LineJoinRound
 	// LineJoinBevel specifies a beveled join.
 	LineJoinBevel
+	// LineJoinMiterClip specifies an SVG 2 miter-clip join: past the miter
+	// limit the tip is clipped at the limit distance instead of reverting
+	// to a bevel.
+	LineJoinMiterClip
+	// LineJoinArcs specifies an SVG 2 arcs join. The expander joins
+	// flattened (straight) segments, where the spec's zero-curvature case
+	// degenerates to miter; past the miter limit it degrades to a round
+	// join, which stays bounded and smooth like the arc it approximates.
+	LineJoinArcs
 )
 
 // Stroke defines the style for stroke expansion.
@@ -175,6 +187,20 @@ const (
 	VerbClose
 )
 
+// Degenerate-geometry thresholds.
+const (
+	// minSegmentLengthSq filters segments too short to define a stable
+	// offset direction: the offset scale 0.5*width/length diverges as the
+	// segment length approaches zero, turning rounding noise into spikes.
+	minSegmentLengthSq = 1e-10
+
+	// reversalJoinRatio classifies a join as a near-180° reversal when
+	// |cross| < hypot(cross, dot) * ratio with dot < 0. Miter intersections
+	// diverge there and a bevel leaves a flat notch, so such joins fall
+	// back to a round join regardless of the requested style.
+	reversalJoinRatio = 1e-6
+)
+
 // verbCoordCount returns the number of float64 coordinates consumed by a verb.
 func verbCoordCount(v PathVerb) int {
 	switch v {
@@ -202,6 +228,9 @@ type StrokeExpander struct {
 	// Smaller values produce more accurate results but more segments.
 	tolerance float64
 
+	// quality selects flattened vs curve-preserving offset expansion.
+	quality OffsetQuality
+
 	// Build state — embedded structs, reused between Expand() calls.
 	forward  pathBuilder
 	backward pathBuilder
@@ -236,6 +265,13 @@ func NewStrokeExpander(style Stroke) *StrokeExpander {
 	}
 }
 
+// SetStyle replaces the stroke style for the next Expand call, so a single
+// expander (and its retained buffers) can serve strokes with different
+// widths, caps, and joins.
+func (e *StrokeExpander) SetStyle(style Stroke) {
+	e.style = style
+}
+
 // SetTolerance sets the curve flattening tolerance.
 func (e *StrokeExpander) SetTolerance(tolerance float64) {
 	if tolerance > 0 {
@@ -246,6 +282,13 @@ func (e *StrokeExpander) SetTolerance(tolerance float64) {
 // Expand converts a stroked path (given as SOA verb+coords) to a filled path.
 // Returns the expanded path as (verbs, coords) slices.
 func (e *StrokeExpander) Expand(verbs []PathVerb, coords []float64) ([]PathVerb, []float64) {
+	sp := trace.Start("stroke.expand")
+	sp.SetAttr("in_verbs", len(verbs))
+	defer func() {
+		sp.SetAttr("out_verbs", len(e.output.verbs))
+		sp.End()
+	}()
+
 	e.reset()
 
 	ci := 0
@@ -259,8 +302,8 @@ func (e *StrokeExpander) Expand(verbs []PathVerb, coords []float64) ([]PathVerb,
 			ci += 2
 		case VerbLineTo:
 			pt := Point{X: coords[ci], Y: coords[ci+1]}
-			if pt != e.lastPt {
-				tangent := pt.Sub(e.lastPt)
+			tangent := pt.Sub(e.lastPt)
+			if tangent.LengthSquared() > minSegmentLengthSq {
 				e.doJoin(tangent)
 				e.lastTan = tangent
 				e.doLine(tangent, pt)
@@ -282,8 +325,8 @@ func (e *StrokeExpander) Expand(verbs []PathVerb, coords []float64) ([]PathVerb,
 			}
 			ci += 6
 		case VerbClose:
-			if e.lastPt != e.startPt {
-				tangent := e.startPt.Sub(e.lastPt)
+			tangent := e.startPt.Sub(e.lastPt)
+			if tangent.LengthSquared() > minSegmentLengthSq {
 				e.doJoin(tangent)
 				e.lastTan = tangent
 				e.doLine(tangent, e.startPt)
@@ -369,6 +412,23 @@ func (e *StrokeExpander) joinWithPrevious(p0 Point, norm, tan0 Vec2) {
 	lastScale := 0.5 * e.style.Width / ab.Length()
 	lastNorm := ab.Perp().Scale(lastScale)
 
+	// Near-180° reversal: cross ≈ 0 with opposing tangents. A miter
+	// intersection diverges (division by cross) and a bevel leaves a flat
+	// notch at the turnaround, so fall back to a round join — it is the
+	// only join that stays bounded and gap-free as the angle approaches π.
+	// Cubic cusps reach this path too: after flattening, a cusp shows up
+	// as a direction reversal between adjacent polyline segments.
+	if dot < 0.0 && math.Abs(cross) <= hypot*reversalJoinRatio {
+		if cross >= 0.0 {
+			e.applyOuterRoundJoin(&e.forward, p0, lastNorm.Neg(), norm.Neg())
+			e.handleInnerJoin(&e.backward, p0, norm)
+		} else {
+			e.applyOuterRoundJoin(&e.backward, p0, lastNorm, norm)
+			e.handleInnerJoin(&e.forward, p0, norm.Neg())
+		}
+		return
+	}
+
 	switch {
 	case cross > 0.0:
 		// Left turn: forward path is outer (convex), backward is inner (concave).
@@ -379,8 +439,9 @@ func (e *StrokeExpander) joinWithPrevious(p0 Point, norm, tan0 Vec2) {
 		e.applyOuterJoin(&e.backward, p0, lastNorm, norm, ab, cd, -cross, dot, hypot)
 		e.handleInnerJoin(&e.forward, p0, norm.Neg())
 	default:
-		// Exactly parallel (cross == 0). This includes near-180-degree reversals
-		// (dot < 0) and exactly collinear (dot > 0). Just connect both sides.
+		// Exactly collinear continuation (cross == 0, dot > 0) — reversals
+		// were already diverted to the round fallback above. Just connect
+		// both sides; the offsets are identical on each side.
 		e.forward.lineTo(p0.Add(norm.Neg()))
 		e.backward.lineTo(p0.Add(norm))
 	}
@@ -425,27 +486,90 @@ func (e *StrokeExpander) applyOuterJoin(
 		e.applyOuterMiterJoin(outerPath, p0, lastNorm, norm, ab, cd, crossAbs, dot, hypot)
 	case LineJoinRound:
 		e.applyOuterRoundJoin(outerPath, p0, lastNorm, norm)
+	case LineJoinMiterClip:
+		e.applyOuterMiterClipJoin(outerPath, p0, lastNorm, norm, ab, cd, crossAbs, dot, hypot)
+	case LineJoinArcs:
+		// Straight segments have zero curvature, where SVG 2 arcs joins
+		// fall back to miter; past the limit degrade to round instead of
+		// bevel so the join stays smooth like the arc it approximates.
+		if e.miterWithinLimit(dot, hypot) {
+			e.applyOuterMiterJoin(outerPath, p0, lastNorm, norm, ab, cd, crossAbs, dot, hypot)
+		} else {
+			e.applyOuterRoundJoin(outerPath, p0, lastNorm, norm)
+		}
 	}
 }
 
+// miterWithinLimit reports whether a miter tip at this join would stay
+// within the configured miter limit.
+func (e *StrokeExpander) miterWithinLimit(dot, hypot float64) bool {
+	miterLimitSq := e.style.MiterLimit * e.style.MiterLimit
+	return 2.0*hypot < (hypot+dot)*miterLimitSq
+}
+
 // applyOuterMiterJoin applies a miter join on the outer (convex) side.
 // If the miter limit is exceeded, falls back to bevel.
 func (e *StrokeExpander) applyOuterMiterJoin(
 	outerPath *pathBuilder, p0 Point, lastNorm, norm, ab, cd Vec2,
 	crossAbs, dot, hypot float64,
 ) {
-	miterLimitSq := e.style.MiterLimit * e.style.MiterLimit
-	if 2.0*hypot < (hypot+dot)*miterLimitSq {
-		// Compute miter point: intersection of the two offset lines.
-		fpLast := p0.Add(lastNorm)
-		fpThis := p0.Add(norm)
-		h := ab.Cross(fpThis.Sub(fpLast.Vec2().ToPoint())) / crossAbs
-		miterPt := fpThis.Add(cd.Scale(-h))
-		outerPath.lineTo(miterPt)
+	if e.miterWithinLimit(dot, hypot) {
+		outerPath.lineTo(e.miterPoint(p0, lastNorm, norm, ab, cd, crossAbs))
 	}
 	outerPath.lineTo(p0.Add(norm))
 }
 
+// miterPoint computes the miter tip: the intersection of the two offset lines.
+func (e *StrokeExpander) miterPoint(p0 Point, lastNorm, norm, ab, cd Vec2, crossAbs float64) Point {
+	fpLast := p0.Add(lastNorm)
+	fpThis := p0.Add(norm)
+	h := ab.Cross(fpThis.Sub(fpLast.Vec2().ToPoint())) / crossAbs
+	return fpThis.Add(cd.Scale(-h))
+}
+
+// applyOuterMiterClipJoin applies an SVG 2 miter-clip join on the outer
+// (convex) side. Within the miter limit it is identical to miter; past the
+// limit the miter tip is clipped by a line perpendicular to the corner
+// bisector at the limit distance (MiterLimit * width/2 from the vertex),
+// instead of collapsing all the way to a bevel.
+func (e *StrokeExpander) applyOuterMiterClipJoin(
+	outerPath *pathBuilder, p0 Point, lastNorm, norm, ab, cd Vec2,
+	crossAbs, dot, hypot float64,
+) {
+	if e.miterWithinLimit(dot, hypot) {
+		outerPath.lineTo(e.miterPoint(p0, lastNorm, norm, ab, cd, crossAbs))
+		outerPath.lineTo(p0.Add(norm))
+		return
+	}
+
+	miterPt := e.miterPoint(p0, lastNorm, norm, ab, cd, crossAbs)
+	bisector := miterPt.Sub(p0)
+	blen := bisector.Length()
+	clipDist := e.style.MiterLimit * 0.5 * e.style.Width
+	if blen < 1e-10 || clipDist <= 0 {
+		outerPath.lineTo(p0.Add(norm)) // degenerate: bevel
+		return
+	}
+	m := bisector.Scale(1 / blen)
+
+	// Clip line: points x with (x - p0)·m == clipDist. Intersect it with
+	// the two offset edges running toward the miter tip.
+	abDot := ab.Dot(m)
+	cdDot := cd.Dot(m)
+	if absFloat(abDot) < 1e-10 || absFloat(cdDot) < 1e-10 {
+		outerPath.lineTo(p0.Add(norm)) // edges parallel to clip line: bevel
+		return
+	}
+	t1 := (clipDist - lastNorm.Dot(m)) / abDot
+	p1 := p0.Add(lastNorm).Add(ab.Scale(t1))
+	t2 := (clipDist - norm.Dot(m)) / cdDot
+	p2 := p0.Add(norm).Add(cd.Scale(t2))
+
+	outerPath.lineTo(p1)
+	outerPath.lineTo(p2)
+	outerPath.lineTo(p0.Add(norm))
+}
+
 // applyOuterRoundJoin applies a round join arc on the outer (convex) side.
 // The arc sweeps from lastNorm to norm around the pivot point p0.
 func (e *StrokeExpander) applyOuterRoundJoin(outerPath *pathBuilder, p0 Point, lastNorm, norm Vec2) {
@@ -476,13 +600,18 @@ func (e *StrokeExpander) doLine(tangent Vec2, p1 Point) {
 	e.lastNorm = norm // Save normal for end cap (tiny-skia pattern)
 }
 
-// doQuad handles a quadratic Bezier curve by flattening it.
+// doQuad handles a quadratic Bezier curve by flattening it, or by
+// emitting parallel offset curves when OffsetCurves quality is selected.
 func (e *StrokeExpander) doQuad(control, end Point) {
+	if e.quality == OffsetCurves {
+		e.doQuadOffset(control, end)
+		return
+	}
 	// Flatten quadratic to lines
 	points := e.flattenQuad(e.lastPt, control, end)
 	for i := 1; i < len(points); i++ {
 		tangent := points[i].Sub(points[i-1])
-		if tangent.LengthSquared() > 1e-10 {
+		if tangent.LengthSquared() > minSegmentLengthSq {
 			e.doJoin(tangent)
 			e.lastTan = tangent
 			e.doLine(tangent, points[i])
@@ -490,13 +619,18 @@ func (e *StrokeExpander) doQuad(control, end Point) {
 	}
 }
 
-// doCubic handles a cubic Bezier curve by flattening it.
+// doCubic handles a cubic Bezier curve by flattening it, or by
+// emitting parallel offset curves when OffsetCurves quality is selected.
 func (e *StrokeExpander) doCubic(c1, c2, end Point) {
+	if e.quality == OffsetCurves {
+		e.doCubicOffset(c1, c2, end)
+		return
+	}
 	// Flatten cubic to lines
 	points := e.flattenCubic(e.lastPt, c1, c2, end)
 	for i := 1; i < len(points); i++ {
 		tangent := points[i].Sub(points[i-1])
-		if tangent.LengthSquared() > 1e-10 {
+		if tangent.LengthSquared() > minSegmentLengthSq {
 			e.doJoin(tangent)
 			e.lastTan = tangent
 			e.doLine(tangent, points[i])
@@ -614,10 +748,10 @@ func (e *StrokeExpander) arcSegment(out *pathBuilder, center Point, radius, a0,
 	// Calculate control points for cubic Bezier approximation of arc
 	// Using formula from "Drawing an elliptical arc using polylines, quadratic or cubic Bezier curves"
 	da := a1 - a0
-	alpha := math.Sin(da) * (math.Sqrt(4+3*math.Tan(da/2)*math.Tan(da/2)) - 1) / 3
+	alpha := detmath.Sin(da) * (detmath.Sqrt(4+3*math.Tan(da/2)*math.Tan(da/2)) - 1) / 3
 
-	cos0, sin0 := math.Cos(a0), math.Sin(a0)
-	cos1, sin1 := math.Cos(a1), math.Sin(a1)
+	cos0, sin0 := detmath.Cos(a0), detmath.Sin(a0)
+	cos1, sin1 := detmath.Cos(a1), detmath.Sin(a1)
 
 	p1 := Point{X: center.X + radius*cos0, Y: center.Y + radius*sin0}
 	p2 := Point{X: center.X + radius*cos1, Y: center.Y + radius*sin1}
@@ -0,0 +1,106 @@
+// Copyright 2026 The gogpu Authors
+// SPDX-License-Identifier: MIT
+
+package stroke
+
+import (
+	"encoding/binary"
+	"math"
+	"testing"
+)
+
+// decodeFuzzPath converts fuzz bytes into a consistent verb/coord stream
+// (Expand trusts the caller to keep them in sync, so the harness must
+// too). Coordinates are raw float64 bits: NaN, Inf, and extreme
+// magnitudes appear naturally.
+func decodeFuzzPath(data []byte) ([]PathVerb, []float64) {
+	var verbs []PathVerb
+	var coords []float64
+	readF64 := func() float64 {
+		if len(data) < 8 {
+			data = nil
+			return 0
+		}
+		v := math.Float64frombits(binary.LittleEndian.Uint64(data))
+		data = data[8:]
+		return v
+	}
+	for len(data) > 0 {
+		op := PathVerb(data[0] % 5)
+		data = data[1:]
+		verbs = append(verbs, op)
+		n := 0
+		switch op {
+		case VerbMoveTo, VerbLineTo:
+			n = 2
+		case VerbQuadTo:
+			n = 4
+		case VerbCubicTo:
+			n = 6
+		}
+		for i := 0; i < n; i++ {
+			coords = append(coords, readF64())
+		}
+	}
+	return verbs, coords
+}
+
+// FuzzExpand fuzzes the stroke expander across widths, caps, joins, and
+// miter limits. The expander must neither panic nor emit a verb/coord
+// stream that is internally inconsistent.
+func FuzzExpand(f *testing.F) {
+	seed := []byte{2, 0} // width/style bytes
+	seed = append(seed, 0)
+	seed = binary.LittleEndian.AppendUint64(seed, math.Float64bits(10))
+	seed = binary.LittleEndian.AppendUint64(seed, math.Float64bits(10))
+	seed = append(seed, 1)
+	seed = binary.LittleEndian.AppendUint64(seed, math.Float64bits(50))
+	seed = binary.LittleEndian.AppendUint64(seed, math.Float64bits(50))
+	f.Add(seed)
+	// Degenerate: zero-length line with round caps.
+	deg := []byte{10, 1, 0}
+	deg = binary.LittleEndian.AppendUint64(deg, math.Float64bits(5))
+	deg = binary.LittleEndian.AppendUint64(deg, math.Float64bits(5))
+	deg = append(deg, 1)
+	deg = binary.LittleEndian.AppendUint64(deg, math.Float64bits(5))
+	deg = binary.LittleEndian.AppendUint64(deg, math.Float64bits(5))
+	f.Add(deg)
+	// NaN cubic.
+	nan := []byte{4, 2, 3}
+	for i := 0; i < 6; i++ {
+		nan = binary.LittleEndian.AppendUint64(nan, math.Float64bits(math.NaN()))
+	}
+	f.Add(nan)
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		style := DefaultStroke()
+		if len(data) >= 2 {
+			style.Width = float64(data[0])
+			style.Cap = LineCap(data[1] % 3)
+			style.Join = LineJoin(data[1] / 3 % 3)
+			style.MiterLimit = float64(data[1]%100) / 2
+			data = data[2:]
+		}
+		verbs, coords := decodeFuzzPath(data)
+
+		e := NewStrokeExpander(style)
+		outVerbs, outCoords := e.Expand(verbs, coords)
+
+		// The output must be self-consistent: coords consumed by the
+		// verbs equals coords produced.
+		want := 0
+		for _, v := range outVerbs {
+			switch v {
+			case VerbMoveTo, VerbLineTo:
+				want += 2
+			case VerbQuadTo:
+				want += 4
+			case VerbCubicTo:
+				want += 6
+			}
+		}
+		if want != len(outCoords) {
+			t.Fatalf("inconsistent output: verbs need %d coords, got %d", want, len(outCoords))
+		}
+	})
+}
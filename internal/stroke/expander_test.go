@@ -847,3 +847,251 @@ func TestHadInnerJoin_ZeroRadiusRoundRect(t *testing.T) {
 		t.Error("zero-radius round-rect (sharp rectangle) should have HadInnerJoin=true")
 	}
 }
+
+// assertCoordsBounded fails the test if any output coordinate is NaN, Inf,
+// or outside [-limit, limit] — the symptom of a miter spike or a division
+// by a near-zero cross product.
+func assertCoordsBounded(t *testing.T, coords []float64, limit float64) {
+	t.Helper()
+	for i, c := range coords {
+		if math.IsNaN(c) || math.IsInf(c, 0) {
+			t.Fatalf("coord[%d] = %v, want finite", i, c)
+		}
+		if c < -limit || c > limit {
+			t.Fatalf("coord[%d] = %v exceeds bound %v — join spike", i, c, limit)
+		}
+	}
+}
+
+func TestDegenerate_NearZeroSegmentsFiltered(t *testing.T) {
+	// Segments far below minSegmentLengthSq must not contribute offset
+	// geometry: 0.5*width/length would scale rounding noise into spikes.
+	p := newSOAPath().
+		moveTo(0, 0).
+		lineTo(1e-8, 0).
+		lineTo(1e-8, 1e-8).
+		lineTo(100, 0).
+		lineTo(100+1e-9, 1e-9)
+
+	for _, join := range []LineJoin{LineJoinMiter, LineJoinBevel, LineJoinRound} {
+		style := Stroke{Width: 10.0, Cap: LineCapButt, Join: join, MiterLimit: 4.0}
+		expander := NewStrokeExpander(style)
+		_, rc := expander.Expand(p.verbs, p.coords)
+		assertCoordsBounded(t, rc, 200)
+	}
+}
+
+func TestDegenerate_NearReversalMiterBounded(t *testing.T) {
+	// A 179.9999...° turn: the miter intersection diverges as cross → 0,
+	// so the join must fall back to round instead of emitting a spike.
+	p := newSOAPath().moveTo(0, 0).lineTo(100, 0).lineTo(0, 1e-5)
+
+	style := Stroke{Width: 10.0, Cap: LineCapButt, Join: LineJoinMiter, MiterLimit: 1e6}
+	expander := NewStrokeExpander(style)
+	rv, rc := expander.Expand(p.verbs, p.coords)
+
+	if len(rv) == 0 {
+		t.Fatal("near-reversal should produce output")
+	}
+	// Everything must stay within the path bbox inflated by the stroke radius.
+	assertCoordsBounded(t, rc, 120)
+}
+
+func TestDegenerate_ExactReversalRoundFallback(t *testing.T) {
+	p := newSOAPath().moveTo(0, 0).lineTo(100, 0).lineTo(0, 0)
+
+	for _, join := range []LineJoin{LineJoinMiter, LineJoinBevel, LineJoinRound} {
+		style := Stroke{Width: 10.0, Cap: LineCapButt, Join: join, MiterLimit: 4.0}
+		expander := NewStrokeExpander(style)
+		rv, rc := expander.Expand(p.verbs, p.coords)
+
+		// The turnaround must be covered by a round join arc (cubics),
+		// not left as a flat notch, for every requested join style.
+		if !hasVerb(rv, VerbCubicTo) {
+			t.Errorf("join=%d: exact reversal should emit a round-join arc", join)
+		}
+		assertCoordsBounded(t, rc, 120)
+	}
+}
+
+func TestDegenerate_CollinearContinuation(t *testing.T) {
+	// Collinear joins carry identical offsets on both sides; the expansion
+	// must stay a clean 10-unit-wide band with no join decoration.
+	p := newSOAPath().moveTo(0, 50).lineTo(40, 50).lineTo(70, 50).lineTo(100, 50)
+
+	style := Stroke{Width: 10.0, Cap: LineCapButt, Join: LineJoinMiter, MiterLimit: 4.0}
+	expander := NewStrokeExpander(style)
+	rv, rc := expander.Expand(p.verbs, p.coords)
+
+	if len(rv) == 0 {
+		t.Fatal("collinear path should produce output")
+	}
+	ci := 0
+	for _, v := range rv {
+		n := verbCoordCount(v)
+		for k := 1; k < n; k += 2 {
+			y := rc[ci+k]
+			if y < 45-1e-9 || y > 55+1e-9 {
+				t.Fatalf("offset y = %v outside the 10-wide band [45, 55]", y)
+			}
+		}
+		ci += n
+	}
+}
+
+func TestDegenerate_CubicCuspBounded(t *testing.T) {
+	// A collinear cubic whose velocity reverses mid-curve: flattening
+	// yields a polyline that doubles back, exercising the cusp path
+	// (direction reversal between adjacent flattened segments).
+	p := newSOAPath().moveTo(0, 0).cubicTo(200, 0, -100, 0, 100, 0)
+
+	for _, join := range []LineJoin{LineJoinMiter, LineJoinBevel, LineJoinRound} {
+		style := Stroke{Width: 8.0, Cap: LineCapRound, Join: join, MiterLimit: 4.0}
+		expander := NewStrokeExpander(style)
+		rv, rc := expander.Expand(p.verbs, p.coords)
+
+		if len(rv) == 0 {
+			t.Fatalf("join=%d: cusp cubic should produce output", join)
+		}
+		// Curve extrema are ~(-13, 113) in x; allow stroke radius on top.
+		assertCoordsBounded(t, rc, 150)
+	}
+}
+
+func TestDegenerate_ZigzagNeedles(t *testing.T) {
+	// Needle-thin zigzag: every join is a near-reversal. All joins must
+	// stay bounded regardless of style.
+	p := newSOAPath().moveTo(0, 0)
+	for i := 1; i <= 6; i++ {
+		x := float64(i * 20)
+		y := float64(i%2) * 1e-4
+		p.lineTo(x, y)
+		p.lineTo(x-19, y)
+	}
+
+	for _, join := range []LineJoin{LineJoinMiter, LineJoinBevel, LineJoinRound} {
+		style := Stroke{Width: 6.0, Cap: LineCapButt, Join: join, MiterLimit: 100.0}
+		expander := NewStrokeExpander(style)
+		_, rc := expander.Expand(p.verbs, p.coords)
+		assertCoordsBounded(t, rc, 200)
+	}
+}
+
+// farthestEndpointNear returns the outline endpoint farthest from origin
+// among endpoints within cutoff of it (to isolate a join's geometry from
+// the rest of the outline), along with its distance.
+func farthestEndpointNear(verbs []PathVerb, coords []float64, origin Point, cutoff float64) (Point, float64) {
+	var far Point
+	best := -1.0
+	ci := 0
+	for _, v := range verbs {
+		n := verbCoordCount(v)
+		if n >= 2 {
+			pt := Point{X: coords[ci+n-2], Y: coords[ci+n-1]}
+			if d := pt.Distance(origin); d > best && d < cutoff {
+				best = d
+				far = pt
+			}
+		}
+		ci += n
+	}
+	return far, best
+}
+
+func TestMiterClipJoin_WithinLimit(t *testing.T) {
+	// Below the miter limit, miter-clip must be identical to miter.
+	p := newSOAPath().moveTo(0, 0).lineTo(100, 0).lineTo(200, 50)
+
+	miter := NewStrokeExpander(Stroke{Width: 10.0, Cap: LineCapButt, Join: LineJoinMiter, MiterLimit: 4.0})
+	mv, mc := miter.Expand(p.verbs, p.coords)
+
+	clip := NewStrokeExpander(Stroke{Width: 10.0, Cap: LineCapButt, Join: LineJoinMiterClip, MiterLimit: 4.0})
+	cv, cc := clip.Expand(p.verbs, p.coords)
+
+	if len(mv) != len(cv) || len(mc) != len(cc) {
+		t.Fatalf("miter-clip within limit differs from miter: %d/%d verbs, %d/%d coords",
+			len(cv), len(mv), len(cc), len(mc))
+	}
+	for i := range mc {
+		if mc[i] != cc[i] {
+			t.Fatalf("coord[%d]: miter-clip %v != miter %v", i, cc[i], mc[i])
+		}
+	}
+}
+
+func TestMiterClipJoin_ClipsPastLimit(t *testing.T) {
+	// A sharp turn whose miter tip is far past the limit. SVG 2 miter-clip
+	// truncates the tip at MiterLimit*width/2 from the vertex rather than
+	// collapsing to a bevel.
+	p := newSOAPath().moveTo(0, 0).lineTo(100, 0).lineTo(0, 20)
+	pivot := Point{X: 100, Y: 0}
+	const width = 10.0
+	const limit = 2.0
+	clipDist := limit * width / 2
+
+	// Reference: an unlimited miter gives the tip direction (bisector).
+	ref := NewStrokeExpander(Stroke{Width: width, Cap: LineCapButt, Join: LineJoinMiter, MiterLimit: 1e9})
+	rv, rc := ref.Expand(p.verbs, p.coords)
+	// Cutoff 90 keeps the search near the join, excluding the far caps.
+	tip, tipDist := farthestEndpointNear(rv, rc, pivot, 90)
+	if tipDist <= clipDist {
+		t.Fatalf("test geometry too shallow: miter tip %.2f within clip distance %.2f", tipDist, clipDist)
+	}
+	m := tip.Sub(pivot).Normalize()
+
+	clip := NewStrokeExpander(Stroke{Width: width, Cap: LineCapButt, Join: LineJoinMiterClip, MiterLimit: limit})
+	cv, cc := clip.Expand(p.verbs, p.coords)
+
+	// No outline point may project past the clip line, and the clip edge
+	// itself must reach it (within float tolerance).
+	maxProj := -1e9
+	ci := 0
+	for _, v := range cv {
+		n := verbCoordCount(v)
+		if n >= 2 {
+			pt := Point{X: cc[ci+n-2], Y: cc[ci+n-1]}
+			if proj := pt.Sub(pivot).Dot(m); proj > maxProj {
+				maxProj = proj
+			}
+		}
+		ci += n
+	}
+	if maxProj > clipDist+1e-6 {
+		t.Errorf("outline projects %.4f past the vertex, clip distance is %.4f", maxProj, clipDist)
+	}
+	if maxProj < clipDist-1e-6 {
+		t.Errorf("clip edge reaches only %.4f, want the full clip distance %.4f", maxProj, clipDist)
+	}
+
+	// The clip must keep more of the corner than a bevel would.
+	bevel := NewStrokeExpander(Stroke{Width: width, Cap: LineCapButt, Join: LineJoinBevel, MiterLimit: limit})
+	bv, bc := bevel.Expand(p.verbs, p.coords)
+	_, bevelDist := farthestEndpointNear(bv, bc, pivot, 90)
+	if maxProj <= bevelDist-width {
+		t.Errorf("miter-clip (%.2f) should extend beyond the bevel edge (%.2f)", maxProj, bevelDist)
+	}
+	if len(cv) <= len(bv) {
+		t.Errorf("miter-clip should emit the two clip points (got %d verbs, bevel %d)", len(cv), len(bv))
+	}
+}
+
+func TestArcsJoin(t *testing.T) {
+	// Shallow angle: arcs behaves as miter (zero-curvature fallback).
+	shallow := newSOAPath().moveTo(0, 0).lineTo(100, 0).lineTo(200, 50)
+	arcs := NewStrokeExpander(Stroke{Width: 10.0, Cap: LineCapButt, Join: LineJoinArcs, MiterLimit: 4.0})
+	av, ac := arcs.Expand(shallow.verbs, shallow.coords)
+	miter := NewStrokeExpander(Stroke{Width: 10.0, Cap: LineCapButt, Join: LineJoinMiter, MiterLimit: 4.0})
+	mv, mc := miter.Expand(shallow.verbs, shallow.coords)
+	if len(av) != len(mv) || len(ac) != len(mc) {
+		t.Errorf("arcs join below the miter limit should match miter: %d/%d verbs", len(av), len(mv))
+	}
+
+	// Sharp angle past the limit: degrades to a round arc, not a bevel.
+	sharp := newSOAPath().moveTo(0, 0).lineTo(100, 0).lineTo(0, 20)
+	arcs2 := NewStrokeExpander(Stroke{Width: 10.0, Cap: LineCapButt, Join: LineJoinArcs, MiterLimit: 2.0})
+	sv, sc := arcs2.Expand(sharp.verbs, sharp.coords)
+	if !hasVerb(sv, VerbCubicTo) {
+		t.Error("arcs join past the miter limit should emit a round arc")
+	}
+	assertCoordsBounded(t, sc, 200)
+}
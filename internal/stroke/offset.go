@@ -0,0 +1,255 @@
+package stroke
+
+import "math"
+
+// Curve-preserving stroke offsetting (kurbo-style parallel curves).
+//
+// The default expansion flattens quadratic and cubic segments into many
+// line segments, which is ideal for scanline rasterization but bloats
+// path size for vector export and GPU upload. OffsetCurves mode instead
+// emits error-bounded offset quadratics: each curve segment is offset as
+// a parallel quadratic whose midpoint deviation from the exact offset
+// curve is checked against the expander tolerance, subdividing until the
+// bound holds. Cubics are first approximated by quadratics using the
+// standard midpoint-control construction with its analytic error bound.
+
+// OffsetQuality selects how curve segments are expanded into offsets.
+type OffsetQuality int
+
+const (
+	// OffsetFlatten flattens curves into line segments (default).
+	// Fastest for scanline rasterization; largest output paths.
+	OffsetFlatten OffsetQuality = iota
+	// OffsetCurves emits error-bounded parallel quadratic curves,
+	// keeping output paths compact for vector export and GPU upload.
+	OffsetCurves
+)
+
+// maxOffsetDepth caps offset subdivision recursion. 2^8 pieces per curve
+// segment is far beyond what the tolerance check requires for sane input;
+// the cap only guards against NaN coordinates and true cusps.
+const maxOffsetDepth = 8
+
+// SetOffsetQuality selects between flattened (line-segment) and
+// curve-preserving offset expansion for quadratic and cubic segments.
+func (e *StrokeExpander) SetOffsetQuality(q OffsetQuality) {
+	e.quality = q
+}
+
+// OffsetQuality returns the current offset quality setting.
+func (e *StrokeExpander) OffsetQuality() OffsetQuality {
+	return e.quality
+}
+
+// evalQuad evaluates a quadratic Bezier at t.
+func evalQuad(p0, p1, p2 Point, t float64) Point {
+	a := p0.Lerp(p1, t)
+	b := p1.Lerp(p2, t)
+	return a.Lerp(b, t)
+}
+
+// quadTangent returns the (unnormalized) derivative direction of a
+// quadratic at t, falling back to the chord for degenerate control points.
+func quadTangent(p0, p1, p2 Point, t float64) Vec2 {
+	d := p1.Sub(p0).Scale(1 - t).Add(p2.Sub(p1).Scale(t)).Scale(2)
+	if d.LengthSquared() > minSegmentLengthSq {
+		return d
+	}
+	return p2.Sub(p0)
+}
+
+// offsetNormal returns the offset displacement for tangent direction tan:
+// the unit perpendicular scaled by d. Returns false for a degenerate
+// tangent (cusp), where the offset direction is undefined.
+func offsetNormal(tan Vec2, d float64) (Vec2, bool) {
+	if tan.LengthSquared() <= minSegmentLengthSq {
+		return Vec2{}, false
+	}
+	return tan.Perp().Normalize().Scale(d), true
+}
+
+// doQuadOffset expands a quadratic segment as parallel offset curves.
+// Mirrors doQuad's join handling but preserves the curve representation.
+func (e *StrokeExpander) doQuadOffset(control, end Point) {
+	p0 := e.lastPt
+	startTan := control.Sub(p0)
+	if startTan.LengthSquared() <= minSegmentLengthSq {
+		startTan = end.Sub(p0)
+	}
+	endTan := end.Sub(control)
+	if endTan.LengthSquared() <= minSegmentLengthSq {
+		endTan = end.Sub(p0)
+	}
+	if startTan.LengthSquared() <= minSegmentLengthSq {
+		return // fully degenerate segment
+	}
+
+	e.doJoin(startTan)
+	radius := 0.5 * e.style.Width
+	e.offsetQuadInto(&e.forward, p0, control, end, -radius, 0)
+	e.offsetQuadInto(&e.backward, p0, control, end, radius, 0)
+
+	e.lastTan = endTan
+	e.lastPt = end
+	if norm, ok := offsetNormal(endTan, radius); ok {
+		e.lastNorm = norm
+	}
+}
+
+// doCubicOffset expands a cubic segment as parallel offset curves by
+// first approximating the cubic with error-bounded quadratics.
+func (e *StrokeExpander) doCubicOffset(c1, c2, end Point) {
+	p0 := e.lastPt
+	startTan := c1.Sub(p0)
+	if startTan.LengthSquared() <= minSegmentLengthSq {
+		startTan = c2.Sub(p0)
+	}
+	if startTan.LengthSquared() <= minSegmentLengthSq {
+		startTan = end.Sub(p0)
+	}
+	endTan := end.Sub(c2)
+	if endTan.LengthSquared() <= minSegmentLengthSq {
+		endTan = end.Sub(c1)
+	}
+	if endTan.LengthSquared() <= minSegmentLengthSq {
+		endTan = end.Sub(p0)
+	}
+	if startTan.LengthSquared() <= minSegmentLengthSq {
+		return // fully degenerate segment
+	}
+
+	e.doJoin(startTan)
+	radius := 0.5 * e.style.Width
+	e.offsetCubicInto(&e.forward, p0, c1, c2, end, -radius, 0)
+	e.offsetCubicInto(&e.backward, p0, c1, c2, end, radius, 0)
+
+	e.lastTan = endTan
+	e.lastPt = end
+	if norm, ok := offsetNormal(endTan, radius); ok {
+		e.lastNorm = norm
+	}
+}
+
+// offsetCubicInto approximates the cubic with quadratics until the
+// analytic approximation error is within half the tolerance, then offsets
+// each quadratic. The error of the midpoint-control quadratic for a cubic
+// is bounded by sqrt(3)/36 * |p3 - 3*p2 + 3*p1 - p0|.
+func (e *StrokeExpander) offsetCubicInto(out *pathBuilder, p0, p1, p2, p3 Point, d float64, depth int) {
+	const sqrt3Over36 = 0.04811252243246881
+	errVec := Vec2{
+		X: p3.X - 3*p2.X + 3*p1.X - p0.X,
+		Y: p3.Y - 3*p2.Y + 3*p1.Y - p0.Y,
+	}
+	if depth >= maxOffsetDepth || sqrt3Over36*errVec.Length() <= 0.5*e.tolerance {
+		// Midpoint-control quadratic approximation.
+		ctrl := Point{
+			X: (3*(p1.X+p2.X) - p0.X - p3.X) / 4,
+			Y: (3*(p1.Y+p2.Y) - p0.Y - p3.Y) / 4,
+		}
+		e.offsetQuadInto(out, p0, ctrl, p3, d, depth)
+		return
+	}
+
+	// Subdivide at t=0.5 (de Casteljau).
+	q0 := p0.Lerp(p1, 0.5)
+	q1 := p1.Lerp(p2, 0.5)
+	q2 := p2.Lerp(p3, 0.5)
+	r0 := q0.Lerp(q1, 0.5)
+	r1 := q1.Lerp(q2, 0.5)
+	s := r0.Lerp(r1, 0.5)
+	e.offsetCubicInto(out, p0, q0, r0, s, d, depth+1)
+	e.offsetCubicInto(out, s, r1, q2, p3, d, depth+1)
+}
+
+// offsetQuadInto appends the offset of quadratic (p0, p1, p2) at signed
+// distance d to the path builder, subdividing until the midpoint of the
+// candidate offset quadratic is within tolerance of the exact offset.
+func (e *StrokeExpander) offsetQuadInto(out *pathBuilder, p0, p1, p2 Point, d float64, depth int) {
+	t0 := quadTangent(p0, p1, p2, 0)
+	t2 := quadTangent(p0, p1, p2, 1)
+	n0, ok0 := offsetNormal(t0, d)
+	n2, ok2 := offsetNormal(t2, d)
+	if !ok0 || !ok2 {
+		if depth < maxOffsetDepth {
+			e.subdivideQuadOffset(out, p0, p1, p2, d, depth)
+		}
+		return
+	}
+
+	o0 := p0.Add(n0)
+	o2 := p2.Add(n2)
+	out.ensureAt(o0)
+
+	// Candidate control point: intersection of the offset tangent lines.
+	// The parallelism test is scale-invariant: |cross| relative to the
+	// tangent magnitudes.
+	cross := t0.Cross(t2)
+	if absFloat(cross) <= 1e-9*t0.Length()*t2.Length() {
+		// Tangents parallel: the offset of a (near-)straight quadratic is
+		// a straight segment.
+		out.quadTo(o0.Lerp(o2, 0.5), o2)
+		return
+	}
+	s := o2.Sub(o0).Cross(t2) / cross
+	oc := o0.Add(t0.Scale(s))
+
+	// A folding inner offset (radius exceeding the curvature radius) can
+	// throw the intersection far away; subdivide toward the cusp, or
+	// straighten at the depth cap instead of emitting a spike.
+	if math.IsNaN(s) || math.IsInf(s, 0) || oc.Distance(o0) > 4*o0.Distance(o2)+e.style.Width {
+		if depth < maxOffsetDepth {
+			e.subdivideQuadOffset(out, p0, p1, p2, d, depth)
+		} else {
+			out.quadTo(o0.Lerp(o2, 0.5), o2)
+		}
+		return
+	}
+
+	// Error check at the curve midpoint against the exact offset point.
+	if depth < maxOffsetDepth {
+		exactMid, ok := offsetNormal(quadTangent(p0, p1, p2, 0.5), d)
+		if !ok {
+			e.subdivideQuadOffset(out, p0, p1, p2, d, depth)
+			return
+		}
+		want := evalQuad(p0, p1, p2, 0.5).Add(exactMid)
+		got := evalQuad(o0, oc, o2, 0.5)
+		if got.Distance(want) > e.tolerance {
+			e.subdivideQuadOffset(out, p0, p1, p2, d, depth)
+			return
+		}
+	}
+	out.quadTo(oc, o2)
+}
+
+// subdivideQuadOffset splits the quadratic at t=0.5 and offsets each half.
+func (e *StrokeExpander) subdivideQuadOffset(out *pathBuilder, p0, p1, p2 Point, d float64, depth int) {
+	q0 := p0.Lerp(p1, 0.5)
+	q1 := p1.Lerp(p2, 0.5)
+	mid := q0.Lerp(q1, 0.5)
+	e.offsetQuadInto(out, p0, q0, mid, d, depth+1)
+	e.offsetQuadInto(out, mid, q1, p2, d, depth+1)
+}
+
+func absFloat(x float64) float64 {
+	if x < 0 {
+		return -x
+	}
+	return x
+}
+
+// ensureAt bridges any gap between the builder's current point and p with
+// a line. Consecutive offset pieces of a subdivided curve share endpoints
+// exactly, but cubic-to-quadratic junctions can diverge by up to the
+// approximation tolerance.
+func (b *pathBuilder) ensureAt(p Point) {
+	if b.isEmpty() {
+		b.moveTo(p)
+		return
+	}
+	dx := b.current.X - p.X
+	dy := b.current.Y - p.Y
+	if dx*dx+dy*dy > minSegmentLengthSq {
+		b.lineTo(p)
+	}
+}
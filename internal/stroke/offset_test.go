@@ -0,0 +1,132 @@
+package stroke
+
+import (
+	"math"
+	"testing"
+)
+
+// outlineEndpoints returns the endpoint of every verb in the expanded outline.
+func outlineEndpoints(verbs []PathVerb, coords []float64) []Point {
+	var pts []Point
+	ci := 0
+	for _, v := range verbs {
+		n := verbCoordCount(v)
+		if n >= 2 {
+			pts = append(pts, Point{X: coords[ci+n-2], Y: coords[ci+n-1]})
+		}
+		ci += n
+	}
+	return pts
+}
+
+func TestOffsetQuality_Default(t *testing.T) {
+	expander := NewStrokeExpander(DefaultStroke())
+	if expander.OffsetQuality() != OffsetFlatten {
+		t.Error("default offset quality should be OffsetFlatten")
+	}
+	expander.SetOffsetQuality(OffsetCurves)
+	if expander.OffsetQuality() != OffsetCurves {
+		t.Error("SetOffsetQuality(OffsetCurves) not applied")
+	}
+}
+
+func TestOffsetCurves_QuadPreserved(t *testing.T) {
+	p := newSOAPath().moveTo(0, 0).quadTo(50, 100, 100, 0)
+
+	style := Stroke{Width: 10.0, Cap: LineCapButt, Join: LineJoinMiter, MiterLimit: 4.0}
+	expander := NewStrokeExpander(style)
+	expander.SetOffsetQuality(OffsetCurves)
+	rv, rc := expander.Expand(p.verbs, p.coords)
+
+	if !hasVerb(rv, VerbQuadTo) {
+		t.Fatal("OffsetCurves should emit quadratic offset curves")
+	}
+	assertCoordsBounded(t, rc, 200)
+}
+
+func TestOffsetCurves_SmallerThanFlattened(t *testing.T) {
+	// The whole point: curve-preserving output must be much more compact
+	// than the flattened expansion for smooth curves.
+	p := newSOAPath().moveTo(0, 0).cubicTo(0, 100, 100, 100, 100, 0).
+		cubicTo(100, -100, 200, -100, 200, 0)
+
+	style := Stroke{Width: 8.0, Cap: LineCapRound, Join: LineJoinRound, MiterLimit: 4.0}
+
+	flat := NewStrokeExpander(style)
+	flat.SetTolerance(0.05)
+	fv, _ := flat.Expand(p.verbs, p.coords)
+
+	curved := NewStrokeExpander(style)
+	curved.SetTolerance(0.05)
+	curved.SetOffsetQuality(OffsetCurves)
+	cv, _ := curved.Expand(p.verbs, p.coords)
+
+	if len(cv) >= len(fv)/2 {
+		t.Errorf("curve offset produced %d verbs, flattened %d — expected at least 2x reduction", len(cv), len(fv))
+	}
+}
+
+func TestOffsetCurves_WithinTolerance(t *testing.T) {
+	// Every outline point must sit close to the true offset distance
+	// (half the stroke width) from the source quadratic.
+	p := newSOAPath().moveTo(0, 0).quadTo(50, 80, 100, 0)
+	const width = 10.0
+	const tol = 0.25
+
+	style := Stroke{Width: width, Cap: LineCapButt, Join: LineJoinMiter, MiterLimit: 4.0}
+	expander := NewStrokeExpander(style)
+	expander.SetTolerance(tol)
+	expander.SetOffsetQuality(OffsetCurves)
+	rv, rc := expander.Expand(p.verbs, p.coords)
+
+	src0 := Point{X: 0, Y: 0}
+	src1 := Point{X: 50, Y: 80}
+	src2 := Point{X: 100, Y: 0}
+
+	for _, pt := range outlineEndpoints(rv, rc) {
+		// Distance from pt to the source curve, by dense sampling.
+		best := math.Inf(1)
+		for i := 0; i <= 200; i++ {
+			d := pt.Distance(evalQuad(src0, src1, src2, float64(i)/200))
+			if d < best {
+				best = d
+			}
+		}
+		// Cap endpoints sit ON the curve ends (butt cap), interior offset
+		// points at width/2; allow the expander tolerance plus sampling slack.
+		if best > width/2+tol+0.1 {
+			t.Fatalf("outline point %v is %.3f from the curve, want <= %.3f", pt, best, width/2+tol+0.1)
+		}
+	}
+}
+
+func TestOffsetCurves_CuspStaysBounded(t *testing.T) {
+	// Collinear cubic with a velocity reversal (cusp): offsets must stay
+	// bounded, matching the flattened path's robustness.
+	p := newSOAPath().moveTo(0, 0).cubicTo(200, 0, -100, 0, 100, 0)
+
+	style := Stroke{Width: 8.0, Cap: LineCapRound, Join: LineJoinRound, MiterLimit: 4.0}
+	expander := NewStrokeExpander(style)
+	expander.SetOffsetQuality(OffsetCurves)
+	rv, rc := expander.Expand(p.verbs, p.coords)
+
+	if len(rv) == 0 {
+		t.Fatal("cusp cubic should produce output")
+	}
+	assertCoordsBounded(t, rc, 160)
+}
+
+func TestOffsetCurves_JoinsStillApplied(t *testing.T) {
+	// Curve offsetting must not bypass join handling between segments.
+	p := newSOAPath().moveTo(0, 50).lineTo(50, 50).quadTo(75, 50, 100, 25)
+
+	style := Stroke{Width: 10.0, Cap: LineCapButt, Join: LineJoinRound, MiterLimit: 4.0}
+	expander := NewStrokeExpander(style)
+	expander.SetOffsetQuality(OffsetCurves)
+	rv, rc := expander.Expand(p.verbs, p.coords)
+
+	if len(rv) == 0 {
+		t.Fatal("result should not be empty")
+	}
+	assertCoordsBounded(t, rc, 150)
+}
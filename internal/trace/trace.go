@@ -0,0 +1,75 @@
+// Package trace provides the span-based instrumentation hooks used by
+// the rendering pipeline.
+//
+// The design mirrors logger.go at the gg root: a process-wide handler
+// stored atomically, a no-op default that costs one atomic load per
+// call site, and propagation to sub-packages without import cycles.
+// The Tracer and Span interfaces are deliberately minimal so they can
+// be bridged to OpenTelemetry (trace.Tracer.Start / span.End) or any
+// in-house profiler in a few lines.
+package trace
+
+import "sync/atomic"
+
+// Span is an in-flight traced operation. Implementations receive End
+// exactly once per span; SetAttr may be called any number of times
+// before End.
+type Span interface {
+	// SetAttr attaches a key/value pair to the span.
+	SetAttr(key string, value any)
+
+	// End completes the span.
+	End()
+}
+
+// Tracer receives span events from the rendering pipeline.
+// Implementations must be safe for concurrent use: spans for different
+// contexts can start and end from different goroutines.
+type Tracer interface {
+	// StartSpan begins a span with the given name. Names are stable,
+	// dot-separated identifiers such as "gg.fill" or "gpu.dispatch".
+	StartSpan(name string) Span
+}
+
+// nopSpan discards everything. A single shared instance keeps the
+// disabled path allocation-free.
+type nopSpan struct{}
+
+func (nopSpan) SetAttr(string, any) {}
+func (nopSpan) End()                {}
+
+var theNopSpan Span = nopSpan{}
+
+// tracerPtr stores the active tracer behind a pointer so a nil tracer
+// (tracing disabled) and a live one swap atomically.
+var tracerPtr atomic.Pointer[Tracer]
+
+// SetTracer installs t as the process-wide tracer. Pass nil to disable
+// tracing. Safe for concurrent use.
+func SetTracer(t Tracer) {
+	if t == nil {
+		tracerPtr.Store(nil)
+		return
+	}
+	tracerPtr.Store(&t)
+}
+
+// Enabled reports whether a tracer is installed. Call sites that need
+// to compute expensive attributes can skip the work when it is false.
+func Enabled() bool {
+	return tracerPtr.Load() != nil
+}
+
+// Start begins a span with the given name. It never returns nil: when
+// no tracer is installed the returned span is a shared no-op.
+func Start(name string) Span {
+	p := tracerPtr.Load()
+	if p == nil {
+		return theNopSpan
+	}
+	s := (*p).StartSpan(name)
+	if s == nil {
+		return theNopSpan
+	}
+	return s
+}
@@ -0,0 +1,101 @@
+package trace
+
+import (
+	"sync"
+	"testing"
+)
+
+// recordingTracer collects spans for assertions.
+type recordingTracer struct {
+	mu    sync.Mutex
+	spans []*recordedSpan
+}
+
+type recordedSpan struct {
+	tracer *recordingTracer
+	name   string
+	attrs  map[string]any
+	ended  int
+}
+
+func (r *recordingTracer) StartSpan(name string) Span {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	s := &recordedSpan{tracer: r, name: name, attrs: map[string]any{}}
+	r.spans = append(r.spans, s)
+	return s
+}
+
+func (s *recordedSpan) SetAttr(key string, value any) {
+	s.tracer.mu.Lock()
+	defer s.tracer.mu.Unlock()
+	s.attrs[key] = value
+}
+
+func (s *recordedSpan) End() {
+	s.tracer.mu.Lock()
+	defer s.tracer.mu.Unlock()
+	s.ended++
+}
+
+// TestDisabledByDefault tests that Start is a no-op without a tracer.
+func TestDisabledByDefault(t *testing.T) {
+	if Enabled() {
+		t.Fatal("tracing should be disabled by default")
+	}
+	sp := Start("test")
+	if sp == nil {
+		t.Fatal("Start must never return nil")
+	}
+	sp.SetAttr("k", 1)
+	sp.End() // must not panic
+}
+
+// TestSetTracer tests installing and removing a tracer.
+func TestSetTracer(t *testing.T) {
+	defer SetTracer(nil)
+
+	rec := &recordingTracer{}
+	SetTracer(rec)
+	if !Enabled() {
+		t.Fatal("Enabled() should be true after SetTracer")
+	}
+
+	sp := Start("op")
+	sp.SetAttr("n", 42)
+	sp.End()
+
+	if len(rec.spans) != 1 {
+		t.Fatalf("recorded %d spans, want 1", len(rec.spans))
+	}
+	s := rec.spans[0]
+	if s.name != "op" || s.attrs["n"] != 42 || s.ended != 1 {
+		t.Errorf("span = %q attrs=%v ended=%d", s.name, s.attrs, s.ended)
+	}
+
+	SetTracer(nil)
+	if Enabled() {
+		t.Fatal("Enabled() should be false after SetTracer(nil)")
+	}
+	if sp := Start("after"); sp != theNopSpan {
+		t.Error("Start after SetTracer(nil) should return the nop span")
+	}
+	if len(rec.spans) != 1 {
+		t.Error("removed tracer still received spans")
+	}
+}
+
+// nilSpanTracer returns nil spans, which Start must tolerate.
+type nilSpanTracer struct{}
+
+func (nilSpanTracer) StartSpan(string) Span { return nil }
+
+// TestNilSpan tests that a tracer returning nil spans does not panic
+// call sites.
+func TestNilSpan(t *testing.T) {
+	defer SetTracer(nil)
+	SetTracer(nilSpanTracer{})
+	sp := Start("op")
+	sp.SetAttr("k", "v")
+	sp.End()
+}
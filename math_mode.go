@@ -0,0 +1,35 @@
+package gg
+
+import "github.com/gogpu/gg/internal/detmath"
+
+// SetDeterministicMath enables or disables deterministic math mode for
+// the whole process.
+//
+// Golden images rendered on amd64 and arm64 can differ by a few low
+// bits because the compiler contracts multiply-add expressions into
+// fused multiply-add (FMA) instructions on platforms that have them,
+// and the fused form skips an intermediate rounding step. In
+// deterministic mode the geometry pipeline (curve flattening, arcs,
+// stroke expansion, radial gradients) routes sin, cos, and sqrt
+// through software implementations with forced rounding, so results
+// are bit-identical across platforms.
+//
+// The mode is process-wide rather than per-Context because path
+// flattening and matrix construction are free functions with no
+// context to consult. CI suites that compare goldens bit-exactly
+// should enable it once at startup, or use WithDeterministicMath when
+// constructing the context:
+//
+//	gg.SetDeterministicMath(true)
+//	defer gg.SetDeterministicMath(false)
+//
+// The software functions cost roughly 2-4x the hardware ones; the
+// default (disabled) adds only an atomic load per call.
+func SetDeterministicMath(enabled bool) {
+	detmath.SetDeterministic(enabled)
+}
+
+// DeterministicMath reports whether deterministic math mode is enabled.
+func DeterministicMath() bool {
+	return detmath.Deterministic()
+}
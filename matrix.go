@@ -1,6 +1,10 @@
 package gg
 
-import "math"
+import (
+	"math"
+
+	"github.com/gogpu/gg/internal/detmath"
+)
 
 // Matrix represents a 2D affine transformation matrix.
 // It uses a 2x3 matrix in row-major order:
@@ -43,8 +47,8 @@ func Scale(x, y float64) Matrix {
 
 // Rotate creates a rotation matrix (angle in radians).
 func Rotate(angle float64) Matrix {
-	cos := math.Cos(angle)
-	sin := math.Sin(angle)
+	cos := detmath.Cos(angle)
+	sin := detmath.Sin(angle)
 	return Matrix{
 		A: cos, B: -sin, C: 0,
 		D: sin, E: cos, F: 0,
@@ -125,8 +129,8 @@ func (m Matrix) ScaleFactor() float64 {
 	// Calculate the two singular values of the 2x2 part of the matrix.
 	// For the matrix [A B; D E], singular values are sqrt of eigenvalues of A^T*A.
 	// This gives us the maximum stretch factor in any direction.
-	sx := math.Sqrt(m.A*m.A + m.D*m.D)
-	sy := math.Sqrt(m.B*m.B + m.E*m.E)
+	sx := detmath.Sqrt(m.A*m.A + m.D*m.D)
+	sy := detmath.Sqrt(m.B*m.B + m.E*m.E)
 	if sx > sy {
 		return sx
 	}
@@ -193,7 +197,7 @@ func (m Matrix) MaxScaleFactor() float64 {
 
 	sum := p + r
 	diff := p - r
-	disc := math.Sqrt(diff*diff + 4*q*q)
+	disc := detmath.Sqrt(diff*diff + 4*q*q)
 
 	// Max eigenvalue of M^T * M.
 	maxEigen := (sum + disc) / 2
@@ -201,5 +205,5 @@ func (m Matrix) MaxScaleFactor() float64 {
 	if maxEigen <= 0 {
 		return 0
 	}
-	return math.Sqrt(maxEigen)
+	return detmath.Sqrt(maxEigen)
 }
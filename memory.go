@@ -0,0 +1,156 @@
+package gg
+
+import (
+	"errors"
+	"sync/atomic"
+)
+
+// ErrMemoryBudget is returned by strict allocation paths (e.g.
+// Context.TryPushLayer) when the requested allocation would push tracked
+// memory past the budget set with SetMemoryBudget.
+var ErrMemoryBudget = errors.New("gg: memory budget exceeded")
+
+// MemoryClass identifies a tracked memory subsystem in MemoryReport.
+type MemoryClass int
+
+const (
+	// MemoryLayers tracks isolated layer pixmaps allocated by
+	// PushLayer, PushMaskLayer, and PushLayerWithFilter.
+	MemoryLayers MemoryClass = iota
+
+	// MemoryFilters tracks temporary pixmaps allocated while applying
+	// layer filters during PopLayer.
+	MemoryFilters
+
+	memoryClassCount
+)
+
+// memoryClassNames maps classes to their String() form.
+var memoryClassNames = [memoryClassCount]string{
+	MemoryLayers:  "Layers",
+	MemoryFilters: "Filters",
+}
+
+// String returns the name of the memory class.
+func (c MemoryClass) String() string {
+	if c < 0 || c >= memoryClassCount {
+		return "Unknown"
+	}
+	return memoryClassNames[c]
+}
+
+// MemoryUsage is a point-in-time byte count for one memory class.
+type MemoryUsage struct {
+	// Current is the tracked bytes currently allocated.
+	Current int64
+
+	// Peak is the highest Current observed since process start or the
+	// last ResetMemoryPeaks call.
+	Peak int64
+}
+
+// MemoryReport is a snapshot of tracked memory, indexed by MemoryClass:
+//
+//	report := gg.MemoryStats()
+//	layers := report.Classes[gg.MemoryLayers]
+type MemoryReport struct {
+	// Budget is the byte limit in effect when the snapshot was taken.
+	// 0 means unlimited.
+	Budget int64
+
+	// Classes holds per-subsystem usage.
+	Classes [memoryClassCount]MemoryUsage
+}
+
+// Total returns usage summed across all classes. The summed Peak is the
+// sum of per-class peaks, which may exceed any single instant's total.
+func (r MemoryReport) Total() MemoryUsage {
+	var total MemoryUsage
+	for _, u := range r.Classes {
+		total.Current += u.Current
+		total.Peak += u.Peak
+	}
+	return total
+}
+
+// memoryState is the process-global accounting, all atomics so the hot
+// reserve/release path never takes a lock.
+var memoryState struct {
+	budget  atomic.Int64
+	total   atomic.Int64
+	current [memoryClassCount]atomic.Int64
+	peak    [memoryClassCount]atomic.Int64
+}
+
+// SetMemoryBudget sets a process-wide byte limit for budget-aware
+// allocations. 0 (the default) disables the limit.
+//
+// The budget is best-effort: strict paths like TryPushLayer refuse
+// allocations that would exceed it, while paths whose output would change
+// without the allocation (a non-normal blend layer, a mask layer) still
+// allocate and log a warning, so rendering stays correct under pressure.
+// Mandatory allocations such as the context's own canvas are not gated.
+func SetMemoryBudget(bytes int64) {
+	if bytes < 0 {
+		bytes = 0
+	}
+	memoryState.budget.Store(bytes)
+}
+
+// MemoryBudget returns the current memory budget in bytes; 0 means
+// unlimited.
+func MemoryBudget() int64 {
+	return memoryState.budget.Load()
+}
+
+// MemoryStats returns a snapshot of tracked memory per subsystem.
+func MemoryStats() MemoryReport {
+	report := MemoryReport{Budget: memoryState.budget.Load()}
+	for i := range report.Classes {
+		report.Classes[i] = MemoryUsage{
+			Current: memoryState.current[i].Load(),
+			Peak:    memoryState.peak[i].Load(),
+		}
+	}
+	return report
+}
+
+// ResetMemoryPeaks resets each class's peak to its current usage, starting
+// a new peak-observation period (e.g. per request in a rendering server).
+func ResetMemoryPeaks() {
+	for i := range memoryState.peak {
+		memoryState.peak[i].Store(memoryState.current[i].Load())
+	}
+}
+
+// reserveMemory tracks bytes under class, failing with ErrMemoryBudget if
+// a budget is set and the allocation would exceed it. Nothing is tracked
+// on failure.
+func reserveMemory(class MemoryClass, bytes int64) error {
+	if budget := memoryState.budget.Load(); budget > 0 {
+		if memoryState.total.Load()+bytes > budget {
+			return ErrMemoryBudget
+		}
+	}
+	trackMemory(class, bytes)
+	return nil
+}
+
+// trackMemory tracks bytes under class unconditionally, for allocations
+// that must proceed regardless of the budget.
+func trackMemory(class MemoryClass, bytes int64) {
+	memoryState.total.Add(bytes)
+	now := memoryState.current[class].Add(bytes)
+	for {
+		peak := memoryState.peak[class].Load()
+		if now <= peak || memoryState.peak[class].CompareAndSwap(peak, now) {
+			return
+		}
+	}
+}
+
+// releaseMemory returns bytes tracked under class.
+func releaseMemory(class MemoryClass, bytes int64) {
+	memoryState.total.Add(-bytes)
+	memoryState.current[class].Add(-bytes)
+}
@@ -0,0 +1,132 @@
+package gg
+
+import (
+	"errors"
+	"testing"
+)
+
+// TestMemoryStatsLayers tests that layer pixmaps are tracked and released
+// with peak retention.
+func TestMemoryStatsLayers(t *testing.T) {
+	ResetMemoryPeaks()
+	base := MemoryStats().Classes[MemoryLayers]
+
+	dc := NewContext(32, 32)
+	defer dc.Close()
+
+	dc.PushLayer(BlendMultiply, 1.0)
+	const layerBytes = 32 * 32 * 4
+	if got := MemoryStats().Classes[MemoryLayers].Current; got != base.Current+layerBytes {
+		t.Errorf("current after push = %d, want %d", got, base.Current+layerBytes)
+	}
+
+	dc.PopLayer()
+	after := MemoryStats().Classes[MemoryLayers]
+	if after.Current != base.Current {
+		t.Errorf("current after pop = %d, want %d", after.Current, base.Current)
+	}
+	if after.Peak < base.Current+layerBytes {
+		t.Errorf("peak = %d, want >= %d", after.Peak, base.Current+layerBytes)
+	}
+
+	ResetMemoryPeaks()
+	if got := MemoryStats().Classes[MemoryLayers].Peak; got != after.Current {
+		t.Errorf("peak after reset = %d, want %d", got, after.Current)
+	}
+}
+
+// TestTryPushLayerBudget tests the strict budget-checked layer push.
+func TestTryPushLayerBudget(t *testing.T) {
+	defer SetMemoryBudget(0)
+
+	dc := NewContext(32, 32)
+	defer dc.Close()
+
+	// Room for exactly one 32x32 layer.
+	SetMemoryBudget(MemoryStats().Total().Current + 32*32*4)
+
+	if err := dc.TryPushLayer(BlendMultiply, 1.0); err != nil {
+		t.Fatalf("first TryPushLayer: %v", err)
+	}
+	if err := dc.TryPushLayer(BlendMultiply, 1.0); !errors.Is(err, ErrMemoryBudget) {
+		t.Fatalf("second TryPushLayer = %v, want ErrMemoryBudget", err)
+	}
+	dc.PopLayer()
+
+	// After popping, the budget has room again.
+	if err := dc.TryPushLayer(BlendMultiply, 1.0); err != nil {
+		t.Fatalf("TryPushLayer after pop: %v", err)
+	}
+	dc.PopLayer()
+}
+
+// TestPushLayerBudgetPassthrough tests that an over-budget source-over
+// layer degrades to drawing on the parent with identical output.
+func TestPushLayerBudgetPassthrough(t *testing.T) {
+	defer SetMemoryBudget(0)
+
+	dc := NewContext(16, 16)
+	defer dc.Close()
+	dc.ClearWithColor(White)
+
+	SetMemoryBudget(1) // nothing fits
+
+	before := MemoryStats().Classes[MemoryLayers].Current
+	dc.PushLayer(BlendNormal, 1.0)
+	if got := MemoryStats().Classes[MemoryLayers].Current; got != before {
+		t.Errorf("passthrough layer tracked %d bytes, want 0", got-before)
+	}
+
+	dc.SetRGBA(1, 0, 0, 1)
+	dc.DrawRectangle(4, 4, 8, 8)
+	dc.Fill()
+	dc.PopLayer()
+
+	got := dc.pixmap.GetPixel(8, 8)
+	if got.R < 0.99 || got.G > 0.01 {
+		t.Errorf("pixel after passthrough layer = %+v, want red", got)
+	}
+}
+
+// TestPushLayerBudgetIsolationKept tests that a non-normal blend layer is
+// still isolated when over budget, keeping output correct.
+func TestPushLayerBudgetIsolationKept(t *testing.T) {
+	defer SetMemoryBudget(0)
+
+	dc := NewContext(16, 16)
+	defer dc.Close()
+	dc.ClearWithColor(RGBA{R: 0.5, G: 0.5, B: 0.5, A: 1})
+
+	SetMemoryBudget(1)
+
+	dc.PushLayer(BlendMultiply, 1.0)
+	dc.ClearWithColor(RGBA{R: 0.5, G: 0.5, B: 0.5, A: 1})
+	dc.PopLayer()
+
+	// multiply: 0.5 * 0.5 = 0.25
+	got := dc.pixmap.GetPixel(8, 8)
+	if got.R < 0.2 || got.R > 0.3 {
+		t.Errorf("multiply blend R = %v, want ≈0.25", got.R)
+	}
+}
+
+// TestMemoryClassString tests class names.
+func TestMemoryClassString(t *testing.T) {
+	if got := MemoryLayers.String(); got != "Layers" {
+		t.Errorf("MemoryLayers = %q", got)
+	}
+	if got := MemoryClass(99).String(); got != "Unknown" {
+		t.Errorf("out of range = %q", got)
+	}
+}
+
+// TestMemoryReportTotal tests summing across classes.
+func TestMemoryReportTotal(t *testing.T) {
+	var r MemoryReport
+	r.Classes[MemoryLayers] = MemoryUsage{Current: 10, Peak: 30}
+	r.Classes[MemoryFilters] = MemoryUsage{Current: 5, Peak: 7}
+	total := r.Total()
+	if total.Current != 15 || total.Peak != 37 {
+		t.Errorf("Total() = %+v, want {15 37}", total)
+	}
+}
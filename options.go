@@ -14,10 +14,11 @@ type ContextOption func(*contextOptions)
 
 // contextOptions holds optional configuration for Context creation.
 type contextOptions struct {
-	renderer     Renderer
-	pixmap       *Pixmap
-	pipelineMode PipelineMode
-	deviceScale  float64
+	renderer          Renderer
+	pixmap            *Pixmap
+	pipelineMode      PipelineMode
+	deviceScale       float64
+	deterministicMath bool
 }
 
 // defaultOptions returns the default context options.
@@ -94,3 +95,19 @@ func WithDeviceScale(scale float64) ContextOption {
 		}
 	}
 }
+
+// WithDeterministicMath enables deterministic math mode so renders are
+// bit-identical across platforms (amd64, arm64). This is intended for
+// CI golden-image tests; see SetDeterministicMath for details.
+//
+// Note that the mode is process-wide: enabling it here affects every
+// Context until SetDeterministicMath(false) is called.
+//
+// Example:
+//
+//	dc := gg.NewContext(400, 400, gg.WithDeterministicMath())
+func WithDeterministicMath() ContextOption {
+	return func(o *contextOptions) {
+		o.deterministicMath = true
+	}
+}
@@ -22,6 +22,13 @@ const (
 	LineJoinRound
 	// LineJoinBevel specifies a beveled join.
 	LineJoinBevel
+	// LineJoinMiterClip specifies an SVG 2 miter-clip join: past the miter
+	// limit the tip is clipped at the limit distance instead of reverting
+	// to a bevel.
+	LineJoinMiterClip
+	// LineJoinArcs specifies an SVG 2 arcs join (approximated: miter
+	// within the limit, round past it).
+	LineJoinArcs
 )
 
 // FillRule specifies how to determine which areas are inside a path.
@@ -34,6 +41,24 @@ const (
 	FillRuleEvenOdd
 )
 
+// StrokeScaling controls how stroke width reacts to the current transform.
+type StrokeScaling uint8
+
+const (
+	// StrokeScalingNormal scales the stroke width by the current transform,
+	// so a scaled shape gets a proportionally scaled outline. This is the
+	// default and matches Cairo/Skia behavior.
+	StrokeScalingNormal StrokeScaling = iota
+	// StrokeScalingHairline always strokes one physical (device) pixel wide,
+	// ignoring both the configured line width and the transform. Useful for
+	// grid lines and wireframes that must stay crisp at any zoom.
+	StrokeScalingHairline
+	// StrokeScalingNonScaling strokes at the configured line width in device
+	// pixels regardless of the transform, like SVG's
+	// vector-effect="non-scaling-stroke". HiDPI device scaling still applies.
+	StrokeScalingNonScaling
+)
+
 // Paint represents the styling information for drawing.
 type Paint struct {
 	// solidColor stores the solid color inline (Skia fColor4f pattern).
@@ -55,6 +80,23 @@ type Paint struct {
 	// Use SetBrush() to set the brush, which also updates Pattern for compatibility.
 	Brush Brush
 
+	// strokeSolidColor stores the dedicated stroke color inline, mirroring
+	// the solidColor/isSolid scheme used for fills. Only meaningful when
+	// hasStrokeBrush is true.
+	strokeSolidColor RGBA
+
+	// strokeIsSolid is true when the dedicated stroke brush is a single
+	// solid color stored in strokeSolidColor. When true, strokeBrush is nil.
+	strokeIsSolid bool
+
+	// strokeBrush is the dedicated stroke brush for non-solid stroke paints
+	// (gradients, patterns). Only meaningful when hasStrokeBrush is true.
+	strokeBrush Brush
+
+	// hasStrokeBrush is true when a dedicated stroke brush has been set via
+	// SetStrokeBrush. When false, strokes use the fill brush.
+	hasStrokeBrush bool
+
 	// LineWidth is the width of strokes.
 	//
 	// Deprecated: Use Stroke.Width instead. Maintained for backward compatibility.
@@ -78,9 +120,21 @@ type Paint struct {
 	// FillRule is the fill rule for paths
 	FillRule FillRule
 
+	// StrokeScaling controls how stroke width reacts to the transform:
+	// scaled with it (default), hairline, or fixed in device pixels.
+	StrokeScaling StrokeScaling
+
 	// Antialias enables anti-aliasing
 	Antialias bool
 
+	// NonOverlappingAlpha rasterizes each fill or stroke to a coverage
+	// mask first and applies the paint's alpha exactly once per pixel.
+	// Self-overlapping geometry — figure-eight stroke outlines, retraced
+	// polylines, duplicated subpaths — saturates in the mask instead of
+	// compositing repeatedly, so translucent paints never darken where
+	// the shape folds over itself. Forces the software renderer.
+	NonOverlappingAlpha bool
+
 	// Stroke is the unified stroke style configuration.
 	// This is the preferred way to configure stroke properties.
 	// When Stroke is set, it takes precedence over the individual
@@ -143,16 +197,23 @@ func NewPaint() *Paint {
 // Clone creates a copy of the Paint.
 func (p *Paint) Clone() *Paint {
 	clone := &Paint{
-		solidColor: p.solidColor,
-		isSolid:    p.isSolid,
-		Pattern:    p.Pattern,
-		Brush:      p.Brush,
-		LineWidth:  p.LineWidth,
-		LineCap:    p.LineCap,
-		LineJoin:   p.LineJoin,
-		MiterLimit: p.MiterLimit,
-		FillRule:   p.FillRule,
-		Antialias:  p.Antialias,
+		solidColor:       p.solidColor,
+		isSolid:          p.isSolid,
+		Pattern:          p.Pattern,
+		Brush:            p.Brush,
+		strokeSolidColor: p.strokeSolidColor,
+		strokeIsSolid:    p.strokeIsSolid,
+		strokeBrush:      p.strokeBrush,
+		hasStrokeBrush:   p.hasStrokeBrush,
+		LineWidth:        p.LineWidth,
+		LineCap:          p.LineCap,
+		LineJoin:         p.LineJoin,
+		MiterLimit:       p.MiterLimit,
+		FillRule:         p.FillRule,
+		Antialias:        p.Antialias,
+		StrokeScaling:    p.StrokeScaling,
+
+		NonOverlappingAlpha: p.NonOverlappingAlpha,
 	}
 	if p.Stroke != nil {
 		strokeClone := p.Stroke.Clone()
@@ -225,6 +286,75 @@ func (p *Paint) IsSolid() bool {
 	return p.isSolid
 }
 
+// SetStrokeBrush sets a dedicated brush for stroke operations, independent
+// of the fill brush. Passing nil clears it, so strokes follow the fill
+// brush again (the default).
+//
+// Stroke brushes are sampled in the same coordinate space as fill brushes
+// (matching Cairo/Skia), not in stroke-local coordinates: a gradient along
+// a stroked outline lines up with the gradient of the equivalent filled
+// shape.
+func (p *Paint) SetStrokeBrush(b Brush) {
+	if b == nil {
+		p.hasStrokeBrush = false
+		p.strokeIsSolid = false
+		p.strokeBrush = nil
+		return
+	}
+	p.hasStrokeBrush = true
+	if sb, ok := b.(SolidBrush); ok {
+		p.strokeSolidColor = sb.Color
+		p.strokeIsSolid = true
+		p.strokeBrush = nil
+		return
+	}
+	p.strokeBrush = b
+	p.strokeIsSolid = false
+}
+
+// HasStrokeBrush reports whether a dedicated stroke brush is set.
+func (p *Paint) HasStrokeBrush() bool {
+	return p.hasStrokeBrush
+}
+
+// GetStrokeBrush returns the effective stroke brush: the dedicated stroke
+// brush when set, otherwise the fill brush.
+func (p *Paint) GetStrokeBrush() Brush {
+	if p.hasStrokeBrush {
+		if p.strokeIsSolid {
+			return SolidBrush{Color: p.strokeSolidColor}
+		}
+		return p.strokeBrush
+	}
+	return p.GetBrush()
+}
+
+// StrokeSolidColor returns the effective stroke color and true if strokes
+// use a single solid color. Returns (zero, false) when the effective stroke
+// brush is a gradient or pattern. This is the recommended way for renderers
+// to decide whether a stroke needs per-pixel brush sampling.
+func (p *Paint) StrokeSolidColor() (RGBA, bool) {
+	if p.hasStrokeBrush {
+		if p.strokeIsSolid {
+			return p.strokeSolidColor, true
+		}
+		return RGBA{}, false
+	}
+	return p.SolidColor()
+}
+
+// StrokeColorAt returns the stroke color at the given position, sampling
+// the dedicated stroke brush when set and the fill brush otherwise.
+func (p *Paint) StrokeColorAt(x, y float64) RGBA {
+	if p.hasStrokeBrush {
+		if p.strokeIsSolid {
+			return p.strokeSolidColor
+		}
+		return p.strokeBrush.ColorAt(x, y)
+	}
+	return p.ColorAt(x, y)
+}
+
 // GetStroke returns the effective stroke style.
 // If Stroke is set, returns a copy of it.
 // Otherwise, constructs a Stroke from the legacy fields.
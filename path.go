@@ -3,6 +3,8 @@ package gg
 import (
 	"image"
 	"math"
+
+	"github.com/gogpu/gg/internal/detmath"
 )
 
 // PathVerb represents a path construction command.
@@ -348,10 +350,10 @@ func (p *Path) Arc(cx, cy, r, angle1, angle2 float64) {
 // arcSegment adds a single arc segment (<=90 degrees).
 func (p *Path) arcSegment(cx, cy, r, a1, a2 float64) {
 	// Calculate control points for cubic Bezier approximation
-	alpha := math.Sin(a2-a1) * (math.Sqrt(4+3*math.Tan((a2-a1)/2)*math.Tan((a2-a1)/2)) - 1) / 3
+	alpha := detmath.Sin(a2-a1) * (detmath.Sqrt(4+3*math.Tan((a2-a1)/2)*math.Tan((a2-a1)/2)) - 1) / 3
 
-	cos1, sin1 := math.Cos(a1), math.Sin(a1)
-	cos2, sin2 := math.Cos(a2), math.Sin(a2)
+	cos1, sin1 := detmath.Cos(a1), detmath.Sin(a1)
+	cos2, sin2 := detmath.Cos(a2), detmath.Sin(a2)
 
 	x1 := cx + r*cos1
 	y1 := cy + r*sin1
@@ -369,6 +371,162 @@ func (p *Path) arcSegment(cx, cy, r, a1, a2 float64) {
 	p.CubicTo(c1x, c1y, c2x, c2y, x2, y2)
 }
 
+// ArcNegative adds a circular arc sweeping negatively (decreasing angle)
+// from angle1 to angle2 in radians. The mirror of Arc: use it for
+// clockwise boundaries and for carving holes with opposite winding.
+func (p *Path) ArcNegative(cx, cy, r, angle1, angle2 float64) {
+	const twoPi = 2 * math.Pi
+	for angle2 > angle1 {
+		angle2 -= twoPi
+	}
+
+	const maxAngle = math.Pi / 2
+	numSegments := int(math.Ceil((angle1 - angle2) / maxAngle))
+	angleStep := (angle2 - angle1) / float64(numSegments)
+
+	for i := 0; i < numSegments; i++ {
+		a1 := angle1 + float64(i)*angleStep
+		a2 := a1 + angleStep
+		p.arcSegment(cx, cy, r, a1, a2)
+	}
+}
+
+// EllipticalArcTo adds an elliptical arc from the current point to (x, y)
+// using SVG "A" command endpoint parameterization: radii rx and ry, the
+// ellipse's x-axis rotation in radians, and the large-arc and sweep
+// flags selecting among the four candidate arcs. Degenerate inputs
+// follow the SVG rules: zero radii draw a straight line, too-small radii
+// are scaled up uniformly, identical endpoints add nothing. Without a
+// current point the call reduces to MoveTo(x, y).
+//
+// The conversion follows W3C SVG implementation note F.6.5
+// (endpoint-to-center parameterization); each resulting segment of at
+// most 90 degrees is approximated with a cubic Bezier.
+func (p *Path) EllipticalArcTo(rx, ry, xAxisRotation float64, largeArc, sweep bool, x, y float64) {
+	if !p.HasCurrentPoint() {
+		p.MoveTo(x, y)
+		return
+	}
+	x1, y1 := p.current.X, p.current.Y
+	x2, y2 := x, y
+
+	// F.6.2: Identical endpoints — nothing to draw.
+	if x1 == x2 && y1 == y2 {
+		return
+	}
+
+	// F.6.6: Zero radius degenerates to a straight line.
+	rx = math.Abs(rx)
+	ry = math.Abs(ry)
+	if rx == 0 || ry == 0 {
+		p.LineTo(x2, y2)
+		return
+	}
+
+	cosRot := detmath.Cos(xAxisRotation)
+	sinRot := detmath.Sin(xAxisRotation)
+
+	// F.6.5.1: Midpoint in the rotated coordinate system.
+	dx := (x1 - x2) / 2.0
+	dy := (y1 - y2) / 2.0
+	x1p := cosRot*dx + sinRot*dy
+	y1p := -sinRot*dx + cosRot*dy
+
+	// F.6.6.2: Scale radii up if the endpoints are too far apart.
+	x1pSq := x1p * x1p
+	y1pSq := y1p * y1p
+	rxSq := rx * rx
+	rySq := ry * ry
+
+	lambda := x1pSq/rxSq + y1pSq/rySq
+	if lambda > 1.0 {
+		scale := detmath.Sqrt(lambda)
+		rx *= scale
+		ry *= scale
+		rxSq = rx * rx
+		rySq = ry * ry
+	}
+
+	// F.6.5.2: Center in the rotated coordinate system.
+	num := rxSq*rySq - rxSq*y1pSq - rySq*x1pSq
+	den := rxSq*y1pSq + rySq*x1pSq
+	sq := 0.0
+	if den > 0 {
+		sq = detmath.Sqrt(math.Max(0, num/den))
+	}
+	if largeArc == sweep {
+		sq = -sq
+	}
+	cxp := sq * rx * y1p / ry
+	cyp := -sq * ry * x1p / rx
+
+	// F.6.5.3: Center in the original coordinate system.
+	cx := cosRot*cxp - sinRot*cyp + (x1+x2)/2.0
+	cy := sinRot*cxp + cosRot*cyp + (y1+y2)/2.0
+
+	// F.6.5.5 & F.6.5.6: Start and sweep angles.
+	ux := (x1p - cxp) / rx
+	uy := (y1p - cyp) / ry
+	vx := (-x1p - cxp) / rx
+	vy := (-y1p - cyp) / ry
+
+	theta1 := svgAngle(1, 0, ux, uy)
+	dtheta := svgAngle(ux, uy, vx, vy)
+
+	if !sweep && dtheta > 0 {
+		dtheta -= 2 * math.Pi
+	} else if sweep && dtheta < 0 {
+		dtheta += 2 * math.Pi
+	}
+
+	// Split into segments of at most pi/2 and approximate each with a cubic.
+	numSegments := int(math.Ceil(math.Abs(dtheta) / (math.Pi / 2.0)))
+	if numSegments == 0 {
+		numSegments = 1
+	}
+	segAngle := dtheta / float64(numSegments)
+
+	for i := 0; i < numSegments; i++ {
+		a1 := theta1 + float64(i)*segAngle
+		a2 := a1 + segAngle
+		p.ellipseArcSegment(cx, cy, rx, ry, cosRot, sinRot, a1, a2)
+	}
+}
+
+// ellipseArcSegment adds a single elliptical arc segment (<=90 degrees)
+// as a cubic Bezier. The arc runs on the unit circle from angle a1 to
+// a2, scaled by (rx, ry) and rotated by (cosRot, sinRot) about (cx, cy).
+func (p *Path) ellipseArcSegment(cx, cy, rx, ry, cosRot, sinRot, a1, a2 float64) {
+	// Exact control-point constant for a circular arc of the given sweep:
+	// alpha = 4/3 * tan(sweep/4). For a 90-degree segment this is
+	// 0.5523, keeping the radial error under 3e-4 of the radius.
+	alpha := 4.0 / 3.0 * math.Tan((a2-a1)/4.0)
+
+	cos1 := detmath.Cos(a1)
+	sin1 := detmath.Sin(a1)
+	cos2 := detmath.Cos(a2)
+	sin2 := detmath.Sin(a2)
+
+	p1x := rx * cos1
+	p1y := ry * sin1
+	p2x := rx * cos2
+	p2y := ry * sin2
+
+	cp1x := p1x - alpha*rx*sin1
+	cp1y := p1y + alpha*ry*cos1
+	cp2x := p2x + alpha*rx*sin2
+	cp2y := p2y - alpha*ry*cos2
+
+	c1x := cosRot*cp1x - sinRot*cp1y + cx
+	c1y := sinRot*cp1x + cosRot*cp1y + cy
+	c2x := cosRot*cp2x - sinRot*cp2y + cx
+	c2y := sinRot*cp2x + cosRot*cp2y + cy
+	ex := cosRot*p2x - sinRot*p2y + cx
+	ey := sinRot*p2x + cosRot*p2y + cy
+
+	p.CubicTo(c1x, c1y, c2x, c2y, ex, ey)
+}
+
 // RoundedRectangle adds a rectangle with rounded corners.
 func (p *Path) RoundedRectangle(x, y, w, h, r float64) {
 	// Clamp radius to half of the smaller dimension
@@ -392,10 +550,15 @@ func (p *Path) RoundedRectangle(x, y, w, h, r float64) {
 // Clone creates a deep copy of the path.
 func (p *Path) Clone() *Path {
 	result := &Path{
-		verbs:   make([]PathVerb, len(p.verbs)),
-		coords:  make([]float64, len(p.coords)),
-		start:   p.start,
-		current: p.current,
+		verbs:       make([]PathVerb, len(p.verbs)),
+		coords:      make([]float64, len(p.coords)),
+		start:       p.start,
+		current:     p.current,
+		boundsMinX:  p.boundsMinX,
+		boundsMinY:  p.boundsMinY,
+		boundsMaxX:  p.boundsMaxX,
+		boundsMaxY:  p.boundsMaxY,
+		boundsValid: p.boundsValid,
 	}
 	copy(result.verbs, p.verbs)
 	copy(result.coords, p.coords)
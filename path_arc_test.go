@@ -0,0 +1,220 @@
+package gg
+
+import (
+	"math"
+	"testing"
+)
+
+func TestPathArcNegativeMirrorsArc(t *testing.T) {
+	pos := NewPath()
+	pos.Arc(50, 50, 30, 0, 2*math.Pi)
+	pos.Close()
+
+	neg := NewPath()
+	neg.ArcNegative(50, 50, 30, 0, -2*math.Pi)
+	neg.Close()
+
+	if d := pos.Area() + neg.Area(); math.Abs(d) > 1e-6 {
+		t.Errorf("negative arc area %v is not the mirror of %v", neg.Area(), pos.Area())
+	}
+	if !pos.IsClockwise() || neg.IsClockwise() {
+		t.Error("Arc should wind clockwise, ArcNegative counter-clockwise")
+	}
+}
+
+func TestPathArcNegativeEndpoints(t *testing.T) {
+	// A negative quarter sweep from pi/2 down to 0 runs from (50, 80) to
+	// (80, 50) through (71.2, 71.2).
+	p := NewPath()
+	p.ArcNegative(50, 50, 30, math.Pi/2, 0)
+
+	cur := p.CurrentPoint()
+	if math.Abs(cur.X-80) > 1e-9 || math.Abs(cur.Y-50) > 1e-9 {
+		t.Errorf("endpoint = (%v, %v), want (80, 50)", cur.X, cur.Y)
+	}
+
+	// Every flattened point stays on the circle.
+	for _, pt := range p.Flatten(0.01) {
+		r := math.Hypot(pt.X-50, pt.Y-50)
+		if math.Abs(r-30) > 0.1 {
+			t.Fatalf("point (%v, %v) is %v from center, want 30", pt.X, pt.Y, r)
+		}
+	}
+}
+
+func TestEllipticalArcToSemicircle(t *testing.T) {
+	onCircle := func(t *testing.T, p *Path) {
+		t.Helper()
+		for _, pt := range p.Flatten(0.01) {
+			if r := math.Hypot(pt.X-50, pt.Y-50); math.Abs(r-40) > 0.1 {
+				t.Fatalf("point (%v, %v) off the circle: radius %v", pt.X, pt.Y, r)
+			}
+		}
+	}
+
+	// Sweep flag selects which semicircle connects (10,50) to (90,50):
+	// sweep=true runs in the positive-angle direction, which from the
+	// left point passes over the top (smaller y), sweep=false below.
+	down := NewPath()
+	down.MoveTo(10, 50)
+	down.EllipticalArcTo(40, 40, 0, false, false, 90, 50)
+	onCircle(t, down)
+
+	up := NewPath()
+	up.MoveTo(10, 50)
+	up.EllipticalArcTo(40, 40, 0, false, true, 90, 50)
+	onCircle(t, up)
+
+	if box := down.BoundingBox(); box.Max.Y <= 50 {
+		t.Errorf("sweep=false semicircle should pass below y=50, bbox %v", box)
+	}
+	if box := up.BoundingBox(); box.Min.Y >= 50 {
+		t.Errorf("sweep=true semicircle should pass above y=50, bbox %v", box)
+	}
+
+	cur := up.CurrentPoint()
+	if math.Abs(cur.X-90) > 1e-9 || math.Abs(cur.Y-50) > 1e-9 {
+		t.Errorf("endpoint = (%v, %v), want (90, 50)", cur.X, cur.Y)
+	}
+}
+
+func TestEllipticalArcToLargeArcFlag(t *testing.T) {
+	// Between two points a quarter turn apart, the large arc is three
+	// quarters of the circle: roughly three times the small arc's length.
+	small := NewPath()
+	small.MoveTo(90, 50)
+	small.EllipticalArcTo(40, 40, 0, false, true, 50, 90)
+
+	large := NewPath()
+	large.MoveTo(90, 50)
+	large.EllipticalArcTo(40, 40, 0, true, true, 50, 90)
+
+	smallLen := small.Length(0.01)
+	largeLen := large.Length(0.01)
+	if ratio := largeLen / smallLen; ratio < 2.9 || ratio > 3.1 {
+		t.Errorf("large/small arc length ratio = %v, want ~3", ratio)
+	}
+}
+
+func TestEllipticalArcToDegenerateInputs(t *testing.T) {
+	// Zero radius degenerates to a straight line.
+	line := NewPath()
+	line.MoveTo(10, 10)
+	line.EllipticalArcTo(0, 40, 0, false, true, 90, 10)
+	if verbs := line.Verbs(); len(verbs) != 2 || verbs[1] != LineTo {
+		t.Errorf("zero-radius arc verbs = %v, want [MoveTo LineTo]", verbs)
+	}
+
+	// Identical endpoints add nothing.
+	same := NewPath()
+	same.MoveTo(10, 10)
+	same.EllipticalArcTo(40, 40, 0, false, true, 10, 10)
+	if same.NumVerbs() != 1 {
+		t.Errorf("identical endpoints added %d verbs, want 0", same.NumVerbs()-1)
+	}
+
+	// No current point reduces to MoveTo.
+	empty := NewPath()
+	empty.EllipticalArcTo(40, 40, 0, false, true, 30, 30)
+	if verbs := empty.Verbs(); len(verbs) != 1 || verbs[0] != MoveTo {
+		t.Errorf("arc without current point verbs = %v, want [MoveTo]", verbs)
+	}
+
+	// Radii too small for the endpoints are scaled up (F.6.6.2); the
+	// endpoint must still be hit exactly.
+	scaled := NewPath()
+	scaled.MoveTo(0, 0)
+	scaled.EllipticalArcTo(1, 1, 0, false, true, 100, 0)
+	cur := scaled.CurrentPoint()
+	if math.Abs(cur.X-100) > 1e-9 || math.Abs(cur.Y) > 1e-9 {
+		t.Errorf("endpoint after radius scaling = (%v, %v), want (100, 0)", cur.X, cur.Y)
+	}
+}
+
+func TestEllipticalArcToMatchesSVGParser(t *testing.T) {
+	// The SVG parser's A command delegates to EllipticalArcTo; both
+	// routes must produce identical geometry (degrees vs radians aside).
+	parsed, err := ParseSVGPath("M10,50 A50,40 20 1 0 80,50")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	built := NewPath()
+	built.MoveTo(10, 50)
+	built.EllipticalArcTo(50, 40, 20*math.Pi/180, true, false, 80, 50)
+
+	pv, bv := parsed.Verbs(), built.Verbs()
+	if len(pv) != len(bv) {
+		t.Fatalf("verb count differs: parsed %d, built %d", len(pv), len(bv))
+	}
+	pc, bc := parsed.Coords(), built.Coords()
+	for i := range pc {
+		// The parser converts degrees to radians at runtime; allow the
+		// one-ULP difference against the constant-folded literal.
+		if math.Abs(pc[i]-bc[i]) > 1e-9 {
+			t.Fatalf("coord %d differs: parsed %v, built %v", i, pc[i], bc[i])
+		}
+	}
+}
+
+func TestContextDrawArcNegative(t *testing.T) {
+	dc := NewContext(100, 100)
+	defer func() { _ = dc.Close() }()
+
+	dc.ClearWithColor(White)
+	dc.SetRGB(1, 0, 0)
+	dc.DrawArcNegative(50, 50, 30, 0, -2*math.Pi)
+	dc.Fill()
+
+	if c := dc.pixmap.GetPixel(50, 50); c.R < 0.9 || c.G > 0.1 {
+		t.Errorf("circle center = %+v, want red", c)
+	}
+	if c := dc.pixmap.GetPixel(5, 5); c.G < 0.9 {
+		t.Errorf("corner = %+v, want untouched white", c)
+	}
+}
+
+func TestContextDrawArcNegativePie(t *testing.T) {
+	// A negative quarter sweep from 0 to -pi/2 covers the quadrant above
+	// and right of the center (y-down coordinates).
+	dc := NewContext(100, 100)
+	defer func() { _ = dc.Close() }()
+
+	dc.ClearWithColor(White)
+	dc.SetRGB(1, 0, 0)
+	dc.MoveTo(50, 50)
+	dc.DrawArcNegative(50, 50, 40, 0, -math.Pi/2)
+	dc.ClosePath()
+	dc.Fill()
+
+	if c := dc.pixmap.GetPixel(70, 30); c.R < 0.9 || c.G > 0.1 {
+		t.Errorf("upper-right quadrant = %+v, want red", c)
+	}
+	if c := dc.pixmap.GetPixel(70, 70); c.G < 0.9 {
+		t.Errorf("lower-right quadrant = %+v, want white", c)
+	}
+}
+
+func TestContextEllipticalArcToTransformed(t *testing.T) {
+	// The arc is specified in user space; a translated context must
+	// render it at the translated position.
+	dc := NewContext(100, 100)
+	defer func() { _ = dc.Close() }()
+
+	dc.ClearWithColor(White)
+	dc.SetRGB(0, 0, 1)
+	dc.Translate(50, 50)
+	dc.MoveTo(-30, 0)
+	dc.EllipticalArcTo(30, 30, 0, false, true, 30, 0)
+	dc.ClosePath()
+	dc.Fill()
+
+	// sweep=true from the left point passes over the top: above the
+	// translated center. Blue fill has R=0; untouched white has R=1.
+	if c := dc.pixmap.GetPixel(50, 30); c.R > 0.1 || c.B < 0.9 {
+		t.Errorf("upper semicircle = %+v, want blue", c)
+	}
+	if c := dc.pixmap.GetPixel(50, 70); c.R < 0.9 {
+		t.Errorf("lower semicircle = %+v, want white", c)
+	}
+}
@@ -2,7 +2,11 @@
 
 package gg
 
-import "math"
+import (
+	"math"
+
+	"github.com/gogpu/gg/internal/detmath"
+)
 
 // PathBuilder provides a fluent interface for path construction.
 // All methods return the builder for chaining.
@@ -104,8 +108,8 @@ func (b *PathBuilder) Polygon(cx, cy, radius float64, sides int) *PathBuilder {
 
 	for i := 0; i < sides; i++ {
 		angle := startAngle + float64(i)*angleStep
-		x := cx + radius*math.Cos(angle)
-		y := cy + radius*math.Sin(angle)
+		x := cx + radius*detmath.Cos(angle)
+		y := cy + radius*detmath.Sin(angle)
 		if i == 0 {
 			b.path.MoveTo(x, y)
 		} else {
@@ -131,8 +135,8 @@ func (b *PathBuilder) Star(cx, cy, outerRadius, innerRadius float64, points int)
 		if i%2 == 1 {
 			r = innerRadius
 		}
-		x := cx + r*math.Cos(angle)
-		y := cy + r*math.Sin(angle)
+		x := cx + r*detmath.Cos(angle)
+		y := cy + r*detmath.Sin(angle)
 		if i == 0 {
 			b.path.MoveTo(x, y)
 		} else {
@@ -0,0 +1,159 @@
+// Copyright 2026 The gogpu Authors
+// SPDX-License-Identifier: MIT
+
+package gg
+
+import (
+	"encoding/binary"
+	"math"
+	"testing"
+)
+
+// buildFuzzPath interprets data as an opcode stream and constructs a path
+// from it. Coordinates are raw float64 bits, so NaN, Inf, denormals, and
+// huge magnitudes all occur naturally as the fuzzer mutates input.
+func buildFuzzPath(data []byte) *Path {
+	p := NewPath()
+	readF64 := func() float64 {
+		if len(data) < 8 {
+			data = nil
+			return 0
+		}
+		v := math.Float64frombits(binary.LittleEndian.Uint64(data))
+		data = data[8:]
+		return v
+	}
+	for len(data) > 0 {
+		op := data[0] % 5
+		data = data[1:]
+		switch op {
+		case 0:
+			p.MoveTo(readF64(), readF64())
+		case 1:
+			p.LineTo(readF64(), readF64())
+		case 2:
+			p.QuadraticTo(readF64(), readF64(), readF64(), readF64())
+		case 3:
+			p.CubicTo(readF64(), readF64(), readF64(), readF64(), readF64(), readF64())
+		case 4:
+			p.Close()
+		}
+	}
+	return p
+}
+
+// fuzzPathSeeds adds shared seed inputs covering the degenerate cases the
+// harness targets: empty paths, NaN coordinates, zero-length segments,
+// and coordinates far outside the canvas.
+func fuzzPathSeeds(f *testing.F) {
+	f.Add([]byte{})
+	f.Add([]byte{4}) // Close with no subpath
+	// MoveTo(10,10) LineTo(50,50)
+	seed := []byte{0}
+	seed = appendF64(seed, 10, 10)
+	seed = append(seed, 1)
+	seed = appendF64(seed, 50, 50)
+	f.Add(seed)
+	// MoveTo(0,0) CubicTo(NaN, NaN, 50, 50, 100, 100)
+	seed = []byte{0}
+	seed = appendF64(seed, 0, 0)
+	seed = append(seed, 3)
+	seed = appendF64(seed, math.NaN(), math.NaN(), 50, 50, 100, 100)
+	f.Add(seed)
+	// Zero-length segments: MoveTo(20,20) LineTo(20,20) Close
+	seed = []byte{0}
+	seed = appendF64(seed, 20, 20)
+	seed = append(seed, 1)
+	seed = appendF64(seed, 20, 20)
+	seed = append(seed, 4)
+	f.Add(seed)
+	// Coordinates far outside the clip: MoveTo(-1e18,1e18) LineTo(1e18,-1e18)
+	seed = []byte{0}
+	seed = appendF64(seed, -1e18, 1e18)
+	seed = append(seed, 1)
+	seed = appendF64(seed, 1e18, -1e18)
+	f.Add(seed)
+}
+
+// appendF64 appends float64 values as little-endian bits.
+func appendF64(b []byte, vals ...float64) []byte {
+	for _, v := range vals {
+		b = binary.LittleEndian.AppendUint64(b, math.Float64bits(v))
+	}
+	return b
+}
+
+// FuzzPathOps fuzzes pure path operations: bounds, cloning, flattening,
+// area, and reversal must tolerate any coordinate values without
+// panicking or hanging.
+func FuzzPathOps(f *testing.F) {
+	fuzzPathSeeds(f)
+	f.Fuzz(func(t *testing.T, data []byte) {
+		p := buildFuzzPath(data)
+		_ = p.Bounds()
+		_ = p.BoundingBox()
+		_ = p.Area()
+		_ = p.Clone()
+		_ = p.Reversed()
+		_ = p.Flatten(0.25)
+		_ = p.Contains(Point{X: 10, Y: 10})
+	})
+}
+
+// FuzzPathFill fuzzes rasterization of arbitrary paths on a small canvas
+// with both fill rules.
+func FuzzPathFill(f *testing.F) {
+	fuzzPathSeeds(f)
+	f.Fuzz(func(t *testing.T, data []byte) {
+		p := buildFuzzPath(data)
+		dc := NewContext(64, 64)
+		defer dc.Close()
+		_ = dc.FillPath(p)
+		dc.SetFillRule(FillRuleEvenOdd)
+		_ = dc.FillPath(p)
+	})
+}
+
+// FuzzPathStroke fuzzes the stroke pipeline — expander, joins, caps —
+// with widths derived from the input, including huge miter setups.
+func FuzzPathStroke(f *testing.F) {
+	fuzzPathSeeds(f)
+	f.Fuzz(func(t *testing.T, data []byte) {
+		width := 1.0
+		miter := 4.0
+		if len(data) > 2 {
+			width = float64(data[0]) * 2 // up to 510px
+			miter = float64(data[1])     // up to 255
+			data = data[2:]
+		}
+		p := buildFuzzPath(data)
+		dc := NewContext(64, 64)
+		defer dc.Close()
+		dc.SetLineWidth(width)
+		dc.SetMiterLimit(miter)
+		dc.SetLineJoin(LineJoinMiter)
+		dc.DrawPath(p)
+		_ = dc.Stroke()
+	})
+}
+
+// FuzzParseSVGPath fuzzes the SVG path-data parser. Parse errors are
+// fine; panics and runaway allocation are not. Parsed paths must also
+// survive the downstream operations a renderer performs.
+func FuzzParseSVGPath(f *testing.F) {
+	f.Add("M10 10 L 50 50")
+	f.Add("M0 0 C 10 10 20 20 30 30 z")
+	f.Add("M60 80 A 40 30 30 1 0 140 80")
+	f.Add("m10 10 q 5 5 10 0 t 10 0 10 0")
+	f.Add("M0 0 H 1e10 V -1e10 Z")
+	f.Add("M 1 2,3 4L5,6")
+	f.Add("M.5.25.75 1")
+	f.Fuzz(func(t *testing.T, d string) {
+		p, err := ParseSVGPath(d)
+		if err != nil {
+			return
+		}
+		_ = p.Bounds()
+		_ = p.Flatten(0.25)
+	})
+}
@@ -5,6 +5,8 @@ import (
 	"math"
 	"strconv"
 	"strings"
+
+	"github.com/gogpu/gg/internal/detmath"
 )
 
 // ParseSVGPath parses an SVG path data string (d attribute) into a Path.
@@ -395,143 +397,23 @@ func (p *svgParser) reflectControlPointQuad() (float64, float64) {
 	}
 }
 
-// arcToCubics converts an SVG arc to one or more cubic Bezier curves.
-// This implements the W3C SVG spec F.6.5 endpoint-to-center parameterization,
-// then approximates each arc segment (up to pi/2 radians) with a cubic Bezier.
-//
-// Reference: https://www.w3.org/TR/SVG11/implnote.html#ArcConversionEndpointToCenter
+// arcToCubics converts an SVG arc to one or more cubic Bezier curves by
+// delegating to Path.EllipticalArcTo (the W3C F.6.5 endpoint-to-center
+// parameterization). The SVG x-rotation is in degrees; the path
+// primitive takes radians.
 func (p *svgParser) arcToCubics(rx, ry, xRotDeg float64, largeArc, sweep bool, x2, y2 float64) {
-	x1 := p.cx
-	y1 := p.cy
-
-	// F.6.2: If the endpoints are identical, skip the arc.
-	if x1 == x2 && y1 == y2 {
-		return
-	}
-
-	// F.6.6: If rx or ry is zero, draw a straight line.
-	rx = math.Abs(rx)
-	ry = math.Abs(ry)
-	if rx == 0 || ry == 0 {
-		p.path.LineTo(x2, y2)
-		return
-	}
-
-	xRot := xRotDeg * math.Pi / 180.0
-	cosRot := math.Cos(xRot)
-	sinRot := math.Sin(xRot)
-
-	// F.6.5.1: Compute (x1', y1') — midpoint in rotated coordinate system.
-	dx := (x1 - x2) / 2.0
-	dy := (y1 - y2) / 2.0
-	x1p := cosRot*dx + sinRot*dy
-	y1p := -sinRot*dx + cosRot*dy
-
-	// F.6.6.2: Ensure radii are large enough.
-	x1pSq := x1p * x1p
-	y1pSq := y1p * y1p
-	rxSq := rx * rx
-	rySq := ry * ry
-
-	lambda := x1pSq/rxSq + y1pSq/rySq
-	if lambda > 1.0 {
-		scale := math.Sqrt(lambda)
-		rx *= scale
-		ry *= scale
-		rxSq = rx * rx
-		rySq = ry * ry
-	}
-
-	// F.6.5.2: Compute (cx', cy') — center in rotated coordinate system.
-	num := rxSq*rySq - rxSq*y1pSq - rySq*x1pSq
-	den := rxSq*y1pSq + rySq*x1pSq
-	sq := 0.0
-	if den > 0 {
-		sq = math.Sqrt(math.Max(0, num/den))
+	if !p.path.HasCurrentPoint() {
+		p.path.MoveTo(p.cx, p.cy)
 	}
-	if largeArc == sweep {
-		sq = -sq
-	}
-	cxp := sq * rx * y1p / ry
-	cyp := -sq * ry * x1p / rx
-
-	// F.6.5.3: Compute (cx, cy) from (cx', cy').
-	cx := cosRot*cxp - sinRot*cyp + (x1+x2)/2.0
-	cy := sinRot*cxp + cosRot*cyp + (y1+y2)/2.0
-
-	// F.6.5.5 & F.6.5.6: Compute start angle and sweep angle.
-	ux := (x1p - cxp) / rx
-	uy := (y1p - cyp) / ry
-	vx := (-x1p - cxp) / rx
-	vy := (-y1p - cyp) / ry
-
-	theta1 := svgAngle(1, 0, ux, uy)
-	dtheta := svgAngle(ux, uy, vx, vy)
-
-	if !sweep && dtheta > 0 {
-		dtheta -= 2 * math.Pi
-	} else if sweep && dtheta < 0 {
-		dtheta += 2 * math.Pi
-	}
-
-	// Split into segments of at most pi/2 and approximate each with a cubic.
-	numSegments := int(math.Ceil(math.Abs(dtheta) / (math.Pi / 2.0)))
-	if numSegments == 0 {
-		numSegments = 1
-	}
-	segAngle := dtheta / float64(numSegments)
-
-	for i := 0; i < numSegments; i++ {
-		a1 := theta1 + float64(i)*segAngle
-		a2 := a1 + segAngle
-		p.arcSegmentToCubic(cx, cy, rx, ry, cosRot, sinRot, a1, a2)
-	}
-}
-
-// arcSegmentToCubic converts a single arc segment (<=pi/2) to a cubic Bezier.
-// The arc is on the unit circle from angle a1 to a2, scaled by (rx, ry) and rotated.
-func (p *svgParser) arcSegmentToCubic(cx, cy, rx, ry, cosRot, sinRot, a1, a2 float64) {
-	// Standard cubic Bezier approximation for a circular arc.
-	// alpha = 4 * tan(sweep/4) / 3
-	halfSweep := (a2 - a1) / 2.0
-	alpha := math.Sin(halfSweep) * (math.Sqrt(4+3*math.Tan(halfSweep)*math.Tan(halfSweep)) - 1) / 3.0
-
-	cos1 := math.Cos(a1)
-	sin1 := math.Sin(a1)
-	cos2 := math.Cos(a2)
-	sin2 := math.Sin(a2)
-
-	// Endpoints and control points on the unit ellipse.
-	// P1 = (rx*cos1, ry*sin1), P2 = (rx*cos2, ry*sin2)
-	// CP1 = P1 + alpha * tangent at P1
-	// CP2 = P2 - alpha * tangent at P2
-	p1x := rx * cos1
-	p1y := ry * sin1
-	p2x := rx * cos2
-	p2y := ry * sin2
-
-	cp1x := p1x - alpha*rx*sin1
-	cp1y := p1y + alpha*ry*cos1
-	cp2x := p2x + alpha*rx*sin2
-	cp2y := p2y - alpha*ry*cos2
-
-	// Apply rotation and translation.
-	c1x := cosRot*cp1x - sinRot*cp1y + cx
-	c1y := sinRot*cp1x + cosRot*cp1y + cy
-	c2x := cosRot*cp2x - sinRot*cp2y + cx
-	c2y := sinRot*cp2x + cosRot*cp2y + cy
-	ex := cosRot*p2x - sinRot*p2y + cx
-	ey := sinRot*p2x + cosRot*p2y + cy
-
-	p.path.CubicTo(c1x, c1y, c2x, c2y, ex, ey)
+	p.path.EllipticalArcTo(rx, ry, xRotDeg*math.Pi/180.0, largeArc, sweep, x2, y2)
 }
 
 // svgAngle computes the angle between two vectors (ux, uy) and (vx, vy).
 // The result is in [-pi, pi].
 func svgAngle(ux, uy, vx, vy float64) float64 {
 	dot := ux*vx + uy*vy
-	lenU := math.Sqrt(ux*ux + uy*uy)
-	lenV := math.Sqrt(vx*vx + vy*vy)
+	lenU := detmath.Sqrt(ux*ux + uy*uy)
+	lenV := detmath.Sqrt(vx*vx + vy*vy)
 	cosA := dot / (lenU * lenV)
 	// Clamp to [-1, 1] to avoid NaN from acos.
 	cosA = math.Max(-1, math.Min(1, cosA))
@@ -0,0 +1,199 @@
+package gg
+
+import "sort"
+
+// Winding-order normalization.
+//
+// Imported geometry (SVG, fonts, CAD exports) frequently arrives with
+// inconsistent subpath winding: holes wound the same way as their outer
+// boundary, or every contour counter-clockwise. Under even-odd fill that
+// renders fine, but under non-zero fill — the PDF and SVG default — holes
+// disappear. The utilities here detect which subpaths are holes and
+// rewind contours into the canonical form non-zero fill expects: outer
+// boundaries clockwise, holes counter-clockwise (positive signed area is
+// clockwise in this package, see Area).
+
+// IsClockwise reports whether the path's overall winding is clockwise,
+// i.e. its signed area is positive. For multi-contour paths this is the
+// net orientation; use DetectHoles for per-subpath analysis.
+func (p *Path) IsClockwise() bool {
+	return p.Area() > 0
+}
+
+// Reverse reverses the direction of every subpath in place. The geometry
+// and bounds are unchanged; only the winding direction flips. See
+// Reversed for the copying variant.
+func (p *Path) Reverse() {
+	if len(p.verbs) == 0 {
+		return
+	}
+	rev := p.Reversed()
+	p.verbs = append(p.verbs[:0], rev.verbs...)
+	p.coords = append(p.coords[:0], rev.coords...)
+	p.start = rev.start
+	p.current = rev.current
+}
+
+// DetectHoles classifies each subpath of the path as boundary or hole
+// under the given fill rule, returning one flag per subpath in path
+// order (true = hole).
+//
+// Under even-odd, a subpath is a hole when it is nested inside an odd
+// number of other subpaths. Under non-zero, a subpath is a hole when the
+// accumulated winding just inside it is zero — which accounts for
+// orientation, so a same-winding nested contour (a winding-2 region) is
+// not a hole. Open subpaths are treated as implicitly closed, matching
+// fill semantics.
+func (p *Path) DetectHoles(fillRule FillRule) []bool {
+	subs := p.subpathsAsPaths()
+	if len(subs) == 0 {
+		return nil
+	}
+
+	holes := make([]bool, len(subs))
+	for i, sub := range subs {
+		pt, ok := subpathInteriorPoint(sub)
+		if !ok {
+			continue // Degenerate contour: no interior, not a hole
+		}
+		if fillRule == FillRuleEvenOdd {
+			depth := 0
+			for j, other := range subs {
+				if j != i && other.Winding(pt) != 0 {
+					depth++
+				}
+			}
+			holes[i] = depth%2 == 1
+		} else {
+			winding := 0
+			for _, other := range subs {
+				winding += other.Winding(pt)
+			}
+			holes[i] = winding == 0
+		}
+	}
+	return holes
+}
+
+// Normalize returns a copy of the path rewound into canonical non-zero
+// form: outer boundaries clockwise, holes counter-clockwise. fillRule
+// selects how the input is interpreted (see DetectHoles); the result
+// renders identically to the input under that rule, and also renders
+// correctly under non-zero fill — making it safe for PDF and SVG export,
+// which default to non-zero.
+//
+// Regions the input covers more than once (overlapping same-winding
+// contours) normalize to single coverage; self-intersecting individual
+// contours are passed through with only their orientation adjusted.
+func (p *Path) Normalize(fillRule FillRule) *Path {
+	subs := p.subpathsAsPaths()
+	result := NewPath()
+	if len(subs) == 0 {
+		return result
+	}
+
+	holes := p.DetectHoles(fillRule)
+	for i, sub := range subs {
+		wantClockwise := !holes[i]
+		if sub.IsClockwise() != wantClockwise {
+			sub = sub.Reversed()
+		}
+		appendPathReplay(result, sub)
+	}
+	return result
+}
+
+// subpathsAsPaths splits the path into one Path per subpath so each can
+// be queried independently (Area, Winding).
+func (p *Path) subpathsAsPaths() []*Path {
+	subpaths := p.collectSubpaths()
+	paths := make([]*Path, 0, len(subpaths))
+	for _, sp := range subpaths {
+		sub := NewPath()
+		ci := 0
+		for _, v := range sp.verbs {
+			n := verbCoordCount(v)
+			c := sp.coords[ci : ci+n]
+			switch v {
+			case MoveTo:
+				sub.MoveTo(c[0], c[1])
+			case LineTo:
+				sub.LineTo(c[0], c[1])
+			case QuadTo:
+				sub.QuadraticTo(c[0], c[1], c[2], c[3])
+			case CubicTo:
+				sub.CubicTo(c[0], c[1], c[2], c[3], c[4], c[5])
+			}
+			ci += n
+		}
+		if sp.closed {
+			sub.Close()
+		}
+		paths = append(paths, sub)
+	}
+	return paths
+}
+
+// appendPathReplay appends src to dst by replaying its verbs, keeping
+// dst's incremental bounds up to date (unlike a raw slice append).
+func appendPathReplay(dst, src *Path) {
+	src.Iterate(func(verb PathVerb, coords []float64) {
+		switch verb {
+		case MoveTo:
+			dst.MoveTo(coords[0], coords[1])
+		case LineTo:
+			dst.LineTo(coords[0], coords[1])
+		case QuadTo:
+			dst.QuadraticTo(coords[0], coords[1], coords[2], coords[3])
+		case CubicTo:
+			dst.CubicTo(coords[0], coords[1], coords[2], coords[3], coords[4], coords[5])
+		case Close:
+			dst.Close()
+		}
+	})
+}
+
+// subpathInteriorPoint finds a point strictly inside a single closed
+// contour: it flattens the contour, intersects it with the horizontal
+// line through the middle of its bounds, and samples just inside the
+// first crossing. Sampling near the contour's own edge (rather than at
+// the midpoint of the crossing pair) keeps the point out of contours
+// nested inside this one, which would skew the containment count.
+// Works for concave contours where a centroid would fall outside.
+// Returns false for degenerate contours with no interior.
+func subpathInteriorPoint(sub *Path) (Point, bool) {
+	pts := sub.Flatten(0.25)
+	if len(pts) < 3 {
+		return Point{}, false
+	}
+
+	bbox := sub.BoundingBox()
+	midY := (bbox.Min.Y + bbox.Max.Y) / 2
+
+	var xs []float64
+	for i := range pts {
+		p0 := pts[i]
+		p1 := pts[(i+1)%len(pts)]
+		// Half-open crossing rule so shared vertices count once.
+		if (p0.Y <= midY) == (p1.Y <= midY) {
+			continue
+		}
+		t := (midY - p0.Y) / (p1.Y - p0.Y)
+		xs = append(xs, p0.X+t*(p1.X-p0.X))
+	}
+	if len(xs) < 2 {
+		return Point{}, false
+	}
+	sort.Float64s(xs)
+	gap := xs[1] - xs[0]
+	if gap == 0 {
+		return Point{}, false
+	}
+	// A quarter of the way in, capped at one unit from the edge: inside
+	// the true curve despite flattening error, outside nested contours.
+	offset := gap * 0.25
+	if offset > 1 {
+		offset = 1
+	}
+	return Pt(xs[0]+offset, midY), true
+}
@@ -0,0 +1,210 @@
+package gg
+
+import "testing"
+
+// ccwRect appends a counter-clockwise rectangle contour (negative area).
+func ccwRect(p *Path, x, y, w, h float64) {
+	p.MoveTo(x, y)
+	p.LineTo(x, y+h)
+	p.LineTo(x+w, y+h)
+	p.LineTo(x+w, y)
+	p.Close()
+}
+
+func TestPathIsClockwise(t *testing.T) {
+	cw := NewPath()
+	cw.Rectangle(10, 10, 20, 20) // Rectangle winds clockwise (positive area)
+	if !cw.IsClockwise() {
+		t.Error("Rectangle should be clockwise")
+	}
+
+	ccw := NewPath()
+	ccwRect(ccw, 10, 10, 20, 20)
+	if ccw.IsClockwise() {
+		t.Error("reversed rectangle should be counter-clockwise")
+	}
+}
+
+func TestPathReverseInPlace(t *testing.T) {
+	p := NewPath()
+	p.MoveTo(10, 10)
+	p.QuadraticTo(30, 0, 50, 10)
+	p.LineTo(50, 50)
+	p.Close()
+
+	area := p.Area()
+	bounds := p.Bounds()
+
+	p.Reverse()
+
+	if got := p.Area(); got != -area {
+		t.Errorf("reversed area = %v, want %v", got, -area)
+	}
+	if p.Bounds() != bounds {
+		t.Errorf("Reverse changed bounds: %v -> %v", bounds, p.Bounds())
+	}
+
+	// Reversing twice restores the original direction.
+	p.Reverse()
+	if got := p.Area(); got != area {
+		t.Errorf("double-reversed area = %v, want %v", got, area)
+	}
+}
+
+func TestDetectHolesEvenOdd(t *testing.T) {
+	// Outer rect, nested rect (hole), rect nested inside the hole (island).
+	p := NewPath()
+	p.Rectangle(0, 0, 100, 100)
+	p.Rectangle(20, 20, 60, 60)
+	p.Rectangle(40, 40, 20, 20)
+
+	holes := p.DetectHoles(FillRuleEvenOdd)
+	want := []bool{false, true, false}
+	if len(holes) != len(want) {
+		t.Fatalf("got %d flags, want %d", len(holes), len(want))
+	}
+	for i := range want {
+		if holes[i] != want[i] {
+			t.Errorf("subpath %d hole = %v, want %v", i, holes[i], want[i])
+		}
+	}
+}
+
+func TestDetectHolesNonZero(t *testing.T) {
+	// Opposite-winding nested rect: a hole under non-zero.
+	withHole := NewPath()
+	withHole.Rectangle(0, 0, 100, 100)
+	ccwRect(withHole, 20, 20, 60, 60)
+
+	holes := withHole.DetectHoles(FillRuleNonZero)
+	if len(holes) != 2 || holes[0] || !holes[1] {
+		t.Errorf("opposite winding: holes = %v, want [false true]", holes)
+	}
+
+	// Same-winding nested rect: a winding-2 region, not a hole.
+	noHole := NewPath()
+	noHole.Rectangle(0, 0, 100, 100)
+	noHole.Rectangle(20, 20, 60, 60)
+
+	holes = noHole.DetectHoles(FillRuleNonZero)
+	if len(holes) != 2 || holes[0] || holes[1] {
+		t.Errorf("same winding: holes = %v, want [false false]", holes)
+	}
+}
+
+func TestDetectHolesDisjointSubpaths(t *testing.T) {
+	p := NewPath()
+	p.Circle(25, 25, 15)
+	p.Circle(75, 75, 15)
+
+	for _, rule := range []FillRule{FillRuleNonZero, FillRuleEvenOdd} {
+		for i, hole := range p.DetectHoles(rule) {
+			if hole {
+				t.Errorf("disjoint subpath %d flagged as hole under rule %v", i, rule)
+			}
+		}
+	}
+}
+
+func TestNormalizeFixesSameWindingHole(t *testing.T) {
+	// Imported-geometry failure mode: a hole wound the same way as its
+	// boundary renders solid under non-zero. Normalizing under even-odd
+	// (where it is a hole) must rewind it so non-zero shows the hole too.
+	p := NewPath()
+	p.Rectangle(10, 10, 80, 80)
+	p.Rectangle(30, 30, 40, 40) // Same winding: invisible hole under non-zero
+
+	normalized := p.Normalize(FillRuleEvenOdd)
+
+	holes := normalized.DetectHoles(FillRuleNonZero)
+	if len(holes) != 2 || holes[0] || !holes[1] {
+		t.Fatalf("normalized holes under non-zero = %v, want [false true]", holes)
+	}
+
+	// Render both rules: the hole center must be empty, the ring filled.
+	pm := NewPixmap(100, 100)
+	r := NewSoftwareRenderer(100, 100)
+	for _, rule := range []FillRule{FillRuleNonZero, FillRuleEvenOdd} {
+		pm.Clear(RGBA{})
+		paint := solidPaint(RGBA{A: 1})
+		paint.FillRule = rule
+		if err := r.Fill(pm, normalized, paint); err != nil {
+			t.Fatal(err)
+		}
+		if a := pm.GetPixel(50, 50).A; a > 0.01 {
+			t.Errorf("rule %v: hole center alpha = %v, want 0", rule, a)
+		}
+		if a := pm.GetPixel(20, 50).A; a < 0.99 {
+			t.Errorf("rule %v: ring alpha = %v, want 1", rule, a)
+		}
+	}
+}
+
+func TestNormalizePreservesNonZeroRendering(t *testing.T) {
+	// A correctly wound path must render identically before and after
+	// normalization under non-zero fill.
+	p := NewPath()
+	p.Circle(50, 50, 35)
+	ccwRect(p, 35, 35, 30, 30)
+
+	normalized := p.Normalize(FillRuleNonZero)
+
+	const size = 100
+	render := func(path *Path) []uint8 {
+		pm := NewPixmap(size, size)
+		r := NewSoftwareRenderer(size, size)
+		if err := r.Fill(pm, path, solidPaint(RGBA{A: 1})); err != nil {
+			t.Fatal(err)
+		}
+		out := make([]uint8, size*size)
+		data := pm.Data()
+		for i := range out {
+			out[i] = data[i*4+3]
+		}
+		return out
+	}
+
+	before := render(p)
+	after := render(normalized)
+	for i := range before {
+		if d := int(before[i]) - int(after[i]); d < -1 || d > 1 {
+			t.Fatalf("pixel %d changed by normalization: %d -> %d", i, before[i], after[i])
+		}
+	}
+}
+
+func TestNormalizeCanonicalOrientation(t *testing.T) {
+	// All-counter-clockwise input: boundary becomes clockwise, hole stays
+	// counter-clockwise.
+	p := NewPath()
+	ccwRect(p, 0, 0, 100, 100)
+	ccwRect(p, 25, 25, 50, 50)
+
+	normalized := p.Normalize(FillRuleEvenOdd)
+	subs := normalized.subpathsAsPaths()
+	if len(subs) != 2 {
+		t.Fatalf("got %d subpaths, want 2", len(subs))
+	}
+	if !subs[0].IsClockwise() {
+		t.Error("outer boundary should normalize to clockwise")
+	}
+	if subs[1].IsClockwise() {
+		t.Error("hole should normalize to counter-clockwise")
+	}
+}
+
+func TestNormalizeEmptyAndDegenerate(t *testing.T) {
+	if got := NewPath().Normalize(FillRuleNonZero); got.NumVerbs() != 0 {
+		t.Errorf("empty path normalized to %d verbs", got.NumVerbs())
+	}
+
+	// A zero-area contour has no interior; Normalize must pass it
+	// through without panicking.
+	line := NewPath()
+	line.MoveTo(10, 10)
+	line.LineTo(50, 10)
+	line.Close()
+	if got := line.Normalize(FillRuleNonZero); got.NumVerbs() == 0 {
+		t.Error("degenerate contour dropped by Normalize")
+	}
+}
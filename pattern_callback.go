@@ -0,0 +1,105 @@
+package gg
+
+import "math"
+
+// SpanFunc fills dest with colors for a horizontal run of pixels.
+// The run starts at pixel (x, y) and covers length pixels; dest has at
+// least length entries. Implementations should sample at pixel centers
+// (x+i+0.5, y+0.5) to match per-pixel brushes.
+type SpanFunc func(dest []RGBA, x, y, length int)
+
+// CallbackPattern is a procedural paint driven by user callbacks.
+// It implements both Brush and Pattern, and — unlike CustomBrush — also
+// Painter, so the rasterizer invokes it once per span instead of once
+// per pixel. Use it for checkerboards, noise fills, or data-driven
+// heatmaps without touching Pixmap pixels directly.
+//
+// Func is called per pixel; Span, when set, is called per span and
+// takes precedence on the rendering path. Providing only Func still
+// batches the per-pixel calls span by span.
+//
+// Example:
+//
+//	// Heatmap driven by a data grid
+//	heat := gg.NewCallbackPattern(func(x, y float64) gg.RGBA {
+//	    return colorForValue(grid.At(int(x), int(y)))
+//	})
+//	ctx.SetFillBrush(heat)
+type CallbackPattern struct {
+	// Func is the per-pixel color callback.
+	Func ColorFunc
+
+	// Span is an optional batched callback filling a whole span at once.
+	// When set, it is used instead of Func during rendering.
+	Span SpanFunc
+
+	// Name is an optional identifier for debugging and logging.
+	Name string
+}
+
+// brushMarker implements the sealed Brush interface.
+func (CallbackPattern) brushMarker() {}
+
+// NewCallbackPattern creates a CallbackPattern from a per-pixel color
+// function.
+func NewCallbackPattern(fn ColorFunc) CallbackPattern {
+	return CallbackPattern{Func: fn}
+}
+
+// NewSpanPattern creates a CallbackPattern from a span callback.
+//
+// Example:
+//
+//	// Horizontal scanline colors computed once per row
+//	scan := gg.NewSpanPattern(func(dest []gg.RGBA, x, y, length int) {
+//	    c := rowColor(y)
+//	    for i := 0; i < length; i++ {
+//	        dest[i] = c
+//	    }
+//	})
+func NewSpanPattern(fn SpanFunc) CallbackPattern {
+	return CallbackPattern{Span: fn}
+}
+
+// WithName returns a copy of the pattern with the specified name.
+func (p CallbackPattern) WithName(name string) CallbackPattern {
+	p.Name = name
+	return p
+}
+
+// ColorAt implements Brush and Pattern. Single-point sampling uses Func
+// when available and falls back to a one-pixel span.
+func (p CallbackPattern) ColorAt(x, y float64) RGBA {
+	if p.Func != nil {
+		return p.Func(x, y)
+	}
+	if p.Span != nil {
+		var buf [1]RGBA
+		p.Span(buf[:], int(math.Floor(x)), int(math.Floor(y)), 1)
+		return buf[0]
+	}
+	return Transparent
+}
+
+// PaintSpan implements Painter. The rasterizer calls it once per span;
+// PainterFromPaint picks it up automatically when the pattern is used
+// as a brush.
+func (p CallbackPattern) PaintSpan(dest []RGBA, x, y, length int) {
+	if length > len(dest) {
+		length = len(dest)
+	}
+	if p.Span != nil {
+		p.Span(dest[:length], x, y, length)
+		return
+	}
+	if p.Func == nil {
+		for i := 0; i < length; i++ {
+			dest[i] = Transparent
+		}
+		return
+	}
+	fy := float64(y) + 0.5
+	for i := 0; i < length; i++ {
+		dest[i] = p.Func(float64(x+i)+0.5, fy)
+	}
+}
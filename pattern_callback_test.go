@@ -0,0 +1,184 @@
+package gg
+
+import (
+	"testing"
+)
+
+// TestCallbackPatternColorAt tests single-point sampling.
+func TestCallbackPatternColorAt(t *testing.T) {
+	// Per-pixel callback
+	p := NewCallbackPattern(func(x, _ float64) RGBA {
+		if x > 50 {
+			return Blue
+		}
+		return Red
+	})
+	if got := p.ColorAt(100, 0); got != Blue {
+		t.Errorf("ColorAt(100, 0) = %v, want Blue", got)
+	}
+	if got := p.ColorAt(10, 0); got != Red {
+		t.Errorf("ColorAt(10, 0) = %v, want Red", got)
+	}
+
+	// Span-only pattern falls back to a one-pixel span.
+	sp := NewSpanPattern(func(dest []RGBA, x, _, length int) {
+		for i := 0; i < length; i++ {
+			if x+i >= 50 {
+				dest[i] = Green
+			} else {
+				dest[i] = White
+			}
+		}
+	})
+	if got := sp.ColorAt(60.5, 0); got != Green {
+		t.Errorf("span ColorAt(60.5, 0) = %v, want Green", got)
+	}
+	if got := sp.ColorAt(10.5, 0); got != White {
+		t.Errorf("span ColorAt(10.5, 0) = %v, want White", got)
+	}
+
+	// No callbacks at all returns transparent.
+	empty := CallbackPattern{}
+	if got := empty.ColorAt(0, 0); got != Transparent {
+		t.Errorf("empty ColorAt = %v, want Transparent", got)
+	}
+}
+
+// TestCallbackPatternInterfaces verifies the implemented interfaces.
+func TestCallbackPatternInterfaces(t *testing.T) {
+	var _ Brush = CallbackPattern{}
+	var _ Pattern = CallbackPattern{}
+	var _ Painter = CallbackPattern{}
+}
+
+// TestCallbackPatternPaintSpan tests batched span painting.
+func TestCallbackPatternPaintSpan(t *testing.T) {
+	calls := 0
+	p := NewCallbackPattern(func(x, y float64) RGBA {
+		calls++
+		// Pixel centers: x+0.5, y+0.5
+		return RGBA{R: x / 100, G: y / 100, A: 1}
+	})
+
+	dest := make([]RGBA, 8)
+	p.PaintSpan(dest, 10, 20, 8)
+
+	if calls != 8 {
+		t.Errorf("per-pixel callback invoked %d times, want 8", calls)
+	}
+	if got := dest[0].R; got != 10.5/100 {
+		t.Errorf("dest[0].R = %f, want %f", got, 10.5/100)
+	}
+	if got := dest[7].R; got != 17.5/100 {
+		t.Errorf("dest[7].R = %f, want %f", got, 17.5/100)
+	}
+	if got := dest[0].G; got != 20.5/100 {
+		t.Errorf("dest[0].G = %f, want %f", got, 20.5/100)
+	}
+}
+
+// TestCallbackPatternSpanPrecedence tests that Span wins over Func.
+func TestCallbackPatternSpanPrecedence(t *testing.T) {
+	spanCalls := 0
+	p := CallbackPattern{
+		Func: func(_, _ float64) RGBA { return Red },
+		Span: func(dest []RGBA, _, _, length int) {
+			spanCalls++
+			for i := 0; i < length; i++ {
+				dest[i] = Blue
+			}
+		},
+	}
+
+	dest := make([]RGBA, 4)
+	p.PaintSpan(dest, 0, 0, 4)
+
+	if spanCalls != 1 {
+		t.Errorf("span callback invoked %d times, want 1", spanCalls)
+	}
+	for i, c := range dest {
+		if c != Blue {
+			t.Errorf("dest[%d] = %v, want Blue (Span takes precedence)", i, c)
+		}
+	}
+}
+
+// TestCallbackPatternPaintSpanBounds tests clamping to the dest buffer.
+func TestCallbackPatternPaintSpanBounds(t *testing.T) {
+	p := NewCallbackPattern(func(_, _ float64) RGBA { return Red })
+
+	dest := make([]RGBA, 2)
+	p.PaintSpan(dest, 0, 0, 10) // must not panic
+
+	if dest[0] != Red || dest[1] != Red {
+		t.Errorf("dest = %v, want both Red", dest)
+	}
+
+	// Pattern with no callbacks fills transparent.
+	empty := CallbackPattern{}
+	dest[0], dest[1] = Red, Red
+	empty.PaintSpan(dest, 0, 0, 2)
+	if dest[0] != Transparent || dest[1] != Transparent {
+		t.Errorf("empty pattern dest = %v, want Transparent", dest)
+	}
+}
+
+// TestCallbackPatternPainterFromPaint verifies the span path is used
+// when the pattern is set as a brush.
+func TestCallbackPatternPainterFromPaint(t *testing.T) {
+	p := NewSpanPattern(func(dest []RGBA, _, _, length int) {
+		for i := 0; i < length; i++ {
+			dest[i] = Green
+		}
+	})
+
+	paint := NewPaint()
+	paint.SetBrush(p)
+
+	painter := PainterFromPaint(paint)
+	if _, ok := painter.(CallbackPattern); !ok {
+		t.Fatalf("PainterFromPaint returned %T, want CallbackPattern (span path)", painter)
+	}
+}
+
+// TestCallbackPatternWithName tests the WithName method.
+func TestCallbackPatternWithName(t *testing.T) {
+	p := NewCallbackPattern(func(_, _ float64) RGBA { return Red }).WithName("heatmap")
+	if p.Name != "heatmap" {
+		t.Errorf("Name = %q, want %q", p.Name, "heatmap")
+	}
+	if p.ColorAt(0, 0) != Red {
+		t.Error("WithName broke the color function")
+	}
+}
+
+// TestCallbackPatternFill renders a checkerboard through the context.
+func TestCallbackPatternFill(t *testing.T) {
+	dc := NewContext(100, 100)
+	defer func() { _ = dc.Close() }()
+
+	checker := NewSpanPattern(func(dest []RGBA, x, y, length int) {
+		for i := 0; i < length; i++ {
+			if ((x+i)/10+y/10)%2 == 0 {
+				dest[i] = Black
+			} else {
+				dest[i] = White
+			}
+		}
+	})
+
+	dc.ClearWithColor(Red)
+	dc.SetFillBrush(checker)
+	dc.DrawRectangle(0, 0, 100, 100)
+	dc.Fill()
+
+	// (5,5) is in an even cell, (15,5) in an odd one.
+	dark := dc.pixmap.GetPixel(5, 5)
+	if dark.R > 0.1 || dark.G > 0.1 || dark.B > 0.1 {
+		t.Errorf("even cell = %+v, want black", dark)
+	}
+	light := dc.pixmap.GetPixel(15, 5)
+	if light.R < 0.9 || light.G < 0.9 || light.B < 0.9 {
+		t.Errorf("odd cell = %+v, want white", light)
+	}
+}
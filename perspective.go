@@ -0,0 +1,276 @@
+package gg
+
+import "math"
+
+// Perspective (projective) transforms. Matrix is affine-only (2x3); a
+// Matrix3x3 adds the projective bottom row needed for card-flip and
+// free-transform effects, where parallel lines converge toward a
+// vanishing point.
+
+// Matrix3x3 represents a 2D projective transformation matrix.
+// It uses a full 3x3 matrix in row-major order:
+//
+//	| a  b  c |
+//	| d  e  f |
+//	| g  h  i |
+//
+// This represents the transformation (with perspective division):
+//
+//	w' = g*x + h*y + i
+//	x' = (a*x + b*y + c) / w'
+//	y' = (d*x + e*y + f) / w'
+//
+// When g and h are zero and i is one, the matrix is affine and equivalent
+// to a Matrix with the same first two rows.
+type Matrix3x3 struct {
+	A, B, C float64
+	D, E, F float64
+	G, H, I float64
+}
+
+// Identity3x3 returns the identity projective matrix.
+func Identity3x3() Matrix3x3 {
+	return Matrix3x3{
+		A: 1, B: 0, C: 0,
+		D: 0, E: 1, F: 0,
+		G: 0, H: 0, I: 1,
+	}
+}
+
+// Matrix3x3FromMatrix lifts an affine Matrix to a Matrix3x3 with a
+// (0, 0, 1) bottom row.
+func Matrix3x3FromMatrix(m Matrix) Matrix3x3 {
+	return Matrix3x3{
+		A: m.A, B: m.B, C: m.C,
+		D: m.D, E: m.E, F: m.F,
+		G: 0, H: 0, I: 1,
+	}
+}
+
+// Multiply returns the matrix product m * other. The resulting transform
+// applies other first, then m (same convention as Matrix.Multiply).
+func (m Matrix3x3) Multiply(other Matrix3x3) Matrix3x3 {
+	return Matrix3x3{
+		A: m.A*other.A + m.B*other.D + m.C*other.G,
+		B: m.A*other.B + m.B*other.E + m.C*other.H,
+		C: m.A*other.C + m.B*other.F + m.C*other.I,
+		D: m.D*other.A + m.E*other.D + m.F*other.G,
+		E: m.D*other.B + m.E*other.E + m.F*other.H,
+		F: m.D*other.C + m.E*other.F + m.F*other.I,
+		G: m.G*other.A + m.H*other.D + m.I*other.G,
+		H: m.G*other.B + m.H*other.E + m.I*other.H,
+		I: m.G*other.C + m.H*other.F + m.I*other.I,
+	}
+}
+
+// TransformPoint applies the projective transformation to a point,
+// including the perspective division. Points on the horizon line
+// (w' near zero) are returned unchanged to avoid infinities.
+func (m Matrix3x3) TransformPoint(p Point) Point {
+	w := m.G*p.X + m.H*p.Y + m.I
+	if math.Abs(w) < 1e-12 {
+		return p
+	}
+	return Point{
+		X: (m.A*p.X + m.B*p.Y + m.C) / w,
+		Y: (m.D*p.X + m.E*p.Y + m.F) / w,
+	}
+}
+
+// Invert returns the inverse projective transformation.
+// Returns the identity matrix if the matrix is singular.
+func (m Matrix3x3) Invert() Matrix3x3 {
+	// Cofactor expansion along the first row.
+	c00 := m.E*m.I - m.F*m.H
+	c01 := m.F*m.G - m.D*m.I
+	c02 := m.D*m.H - m.E*m.G
+	det := m.A*c00 + m.B*c01 + m.C*c02
+	if math.Abs(det) < 1e-12 {
+		return Identity3x3()
+	}
+	invDet := 1.0 / det
+	return Matrix3x3{
+		A: c00 * invDet,
+		B: (m.C*m.H - m.B*m.I) * invDet,
+		C: (m.B*m.F - m.C*m.E) * invDet,
+		D: c01 * invDet,
+		E: (m.A*m.I - m.C*m.G) * invDet,
+		F: (m.C*m.D - m.A*m.F) * invDet,
+		G: c02 * invDet,
+		H: (m.B*m.G - m.A*m.H) * invDet,
+		I: (m.A*m.E - m.B*m.D) * invDet,
+	}
+}
+
+// IsAffine reports whether the matrix has no perspective component
+// (bottom row is 0, 0, 1).
+func (m Matrix3x3) IsAffine() bool {
+	return m.G == 0 && m.H == 0 && m.I == 1
+}
+
+// Affine returns the affine Matrix equivalent and true when the matrix
+// has no perspective component, or the zero Matrix and false otherwise.
+func (m Matrix3x3) Affine() (Matrix, bool) {
+	if !m.IsAffine() {
+		return Matrix{}, false
+	}
+	return Matrix{A: m.A, B: m.B, C: m.C, D: m.D, E: m.E, F: m.F}, true
+}
+
+// squareToQuad computes the projective transform mapping the unit square
+// (0,0) (1,0) (1,1) (0,1) to the quad corners in that order, using
+// Heckbert's fundamentals-of-texture-mapping construction.
+// Returns false if the quad is degenerate.
+func squareToQuad(q [4]Point) (Matrix3x3, bool) {
+	dx1 := q[1].X - q[2].X
+	dx2 := q[3].X - q[2].X
+	dx3 := q[0].X - q[1].X + q[2].X - q[3].X
+	dy1 := q[1].Y - q[2].Y
+	dy2 := q[3].Y - q[2].Y
+	dy3 := q[0].Y - q[1].Y + q[2].Y - q[3].Y
+
+	if dx3 == 0 && dy3 == 0 {
+		// Quad is a parallelogram: plain affine mapping.
+		return Matrix3x3{
+			A: q[1].X - q[0].X, B: q[3].X - q[0].X, C: q[0].X,
+			D: q[1].Y - q[0].Y, E: q[3].Y - q[0].Y, F: q[0].Y,
+			G: 0, H: 0, I: 1,
+		}, true
+	}
+
+	det := dx1*dy2 - dx2*dy1
+	if math.Abs(det) < 1e-12 {
+		return Identity3x3(), false
+	}
+	g := (dx3*dy2 - dx2*dy3) / det
+	h := (dx1*dy3 - dx3*dy1) / det
+	return Matrix3x3{
+		A: q[1].X - q[0].X + g*q[1].X, B: q[3].X - q[0].X + h*q[3].X, C: q[0].X,
+		D: q[1].Y - q[0].Y + g*q[1].Y, E: q[3].Y - q[0].Y + h*q[3].Y, F: q[0].Y,
+		G: g, H: h, I: 1,
+	}, true
+}
+
+// QuadToQuad returns the projective transform mapping the src quad onto
+// the dst quad. Corners are given in order top-left, top-right,
+// bottom-right, bottom-left. Returns false if either quad is degenerate
+// (three collinear corners or coincident points).
+func QuadToQuad(src, dst [4]Point) (Matrix3x3, bool) {
+	toSrc, ok := squareToQuad(src)
+	if !ok {
+		return Identity3x3(), false
+	}
+	toDst, ok := squareToQuad(dst)
+	if !ok {
+		return Identity3x3(), false
+	}
+	return toDst.Multiply(toSrc.Invert()), true
+}
+
+// TransformPerspective applies a projective transformation to the path,
+// returning a new path of line segments.
+//
+// Straight lines map to straight lines under projective transforms, but
+// Bezier control points do not: transforming control points directly
+// bends curves incorrectly. Curves are therefore flattened in source
+// space (0.1 px tolerance) and the resulting polyline is transformed
+// point by point. Lines are additionally subdivided so that the
+// non-uniform parameter spacing introduced by perspective stays
+// visually linear when the result is stroked with dashes.
+func (p *Path) TransformPerspective(m Matrix3x3) *Path {
+	if affine, ok := m.Affine(); ok {
+		return p.Transform(affine)
+	}
+	const tolerance = 0.1
+	result := NewPath()
+	var cur Point
+	var start Point
+	p.Iterate(func(verb PathVerb, coords []float64) {
+		switch verb {
+		case MoveTo:
+			cur = Pt(coords[0], coords[1])
+			start = cur
+			pt := m.TransformPoint(cur)
+			result.MoveTo(pt.X, pt.Y)
+		case LineTo:
+			end := Pt(coords[0], coords[1])
+			perspectiveLine(result, m, cur, end)
+			cur = end
+		case QuadTo:
+			ctrl := Pt(coords[0], coords[1])
+			end := Pt(coords[2], coords[3])
+			flattenQuadPerspective(result, m, cur, ctrl, end, tolerance)
+			cur = end
+		case CubicTo:
+			c1 := Pt(coords[0], coords[1])
+			c2 := Pt(coords[2], coords[3])
+			end := Pt(coords[4], coords[5])
+			flattenCubicPerspective(result, m, cur, c1, c2, end, tolerance)
+			cur = end
+		case Close:
+			result.Close()
+			cur = start
+		}
+	})
+	return result
+}
+
+// perspectiveLine emits a source-space line as a small number of
+// transformed segments. The endpoints land exactly; intermediate points
+// keep dash patterns and subsequent affine operations well-behaved.
+func perspectiveLine(dst *Path, m Matrix3x3, from, to Point) {
+	const segments = 8
+	for i := 1; i <= segments; i++ {
+		t := float64(i) / segments
+		pt := m.TransformPoint(Pt(
+			from.X+(to.X-from.X)*t,
+			from.Y+(to.Y-from.Y)*t,
+		))
+		dst.LineTo(pt.X, pt.Y)
+	}
+}
+
+// flattenQuadPerspective recursively subdivides a quadratic Bezier until
+// it is flat within tolerance, then emits transformed line segments.
+func flattenQuadPerspective(dst *Path, m Matrix3x3, p0, p1, p2 Point, tol float64) {
+	// Flatness: distance from the control point to the chord midpoint.
+	mx := (p0.X + p2.X) / 2
+	my := (p0.Y + p2.Y) / 2
+	if math.Hypot(p1.X-mx, p1.Y-my) <= tol {
+		pt := m.TransformPoint(p2)
+		dst.LineTo(pt.X, pt.Y)
+		return
+	}
+	// de Casteljau split at t=0.5.
+	q0 := midpoint(p0, p1)
+	q1 := midpoint(p1, p2)
+	r := midpoint(q0, q1)
+	flattenQuadPerspective(dst, m, p0, q0, r, tol)
+	flattenQuadPerspective(dst, m, r, q1, p2, tol)
+}
+
+// flattenCubicPerspective recursively subdivides a cubic Bezier until
+// it is flat within tolerance, then emits transformed line segments.
+func flattenCubicPerspective(dst *Path, m Matrix3x3, p0, p1, p2, p3 Point, tol float64) {
+	// Flatness: max control point deviation from the chord.
+	d1 := math.Hypot(p1.X-(2*p0.X+p3.X)/3, p1.Y-(2*p0.Y+p3.Y)/3)
+	d2 := math.Hypot(p2.X-(p0.X+2*p3.X)/3, p2.Y-(p0.Y+2*p3.Y)/3)
+	if math.Max(d1, d2) <= tol {
+		pt := m.TransformPoint(p3)
+		dst.LineTo(pt.X, pt.Y)
+		return
+	}
+	// de Casteljau split at t=0.5.
+	q0 := midpoint(p0, p1)
+	q1 := midpoint(p1, p2)
+	q2 := midpoint(p2, p3)
+	r0 := midpoint(q0, q1)
+	r1 := midpoint(q1, q2)
+	s := midpoint(r0, r1)
+	flattenCubicPerspective(dst, m, p0, q0, r0, s, tol)
+	flattenCubicPerspective(dst, m, s, r1, q2, p3, tol)
+}
+
+func midpoint(a, b Point) Point {
+	return Point{X: (a.X + b.X) / 2, Y: (a.Y + b.Y) / 2}
+}
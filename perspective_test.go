@@ -0,0 +1,157 @@
+package gg
+
+import (
+	"image"
+	"image/color"
+	"testing"
+)
+
+func TestMatrix3x3FromMatrix(t *testing.T) {
+	affine := Translate(3, 4).Multiply(Rotate(0.5)).Multiply(Scale(2, 3))
+	m := Matrix3x3FromMatrix(affine)
+	if !m.IsAffine() {
+		t.Fatal("lifted affine matrix reports perspective component")
+	}
+	pts := []Point{Pt(0, 0), Pt(1, 0), Pt(-5, 7), Pt(100, -3)}
+	for _, p := range pts {
+		if got, want := m.TransformPoint(p), affine.TransformPoint(p); !pointsNear(got, want, 1e-9) {
+			t.Errorf("TransformPoint(%v) = %v, affine gives %v", p, got, want)
+		}
+	}
+}
+
+func TestMatrix3x3Invert(t *testing.T) {
+	m := Matrix3x3{
+		A: 2, B: 0.3, C: 5,
+		D: -0.1, E: 1.5, F: -2,
+		G: 0.001, H: 0.002, I: 1,
+	}
+	inv := m.Invert()
+	pts := []Point{Pt(0, 0), Pt(10, 20), Pt(-7, 3)}
+	for _, p := range pts {
+		if got := inv.TransformPoint(m.TransformPoint(p)); !pointsNear(got, p, 1e-9) {
+			t.Errorf("inverse round trip of %v gives %v", p, got)
+		}
+	}
+}
+
+func TestQuadToQuadMapsCorners(t *testing.T) {
+	src := [4]Point{Pt(0, 0), Pt(100, 0), Pt(100, 100), Pt(0, 100)}
+	dst := [4]Point{Pt(10, 20), Pt(90, 5), Pt(80, 95), Pt(25, 70)}
+	m, ok := QuadToQuad(src, dst)
+	if !ok {
+		t.Fatal("QuadToQuad failed for valid quads")
+	}
+	for i := range src {
+		if got := m.TransformPoint(src[i]); !pointsNear(got, dst[i], 1e-6) {
+			t.Errorf("corner %d maps to %v, want %v", i, got, dst[i])
+		}
+	}
+}
+
+func TestQuadToQuadDegenerate(t *testing.T) {
+	// All corners collinear: no valid homography.
+	src := [4]Point{Pt(0, 0), Pt(1, 1), Pt(2, 2), Pt(3, 3)}
+	dst := [4]Point{Pt(0, 0), Pt(1, 0), Pt(1, 1), Pt(0, 1)}
+	if _, ok := QuadToQuad(src, dst); ok {
+		t.Error("expected failure for degenerate source quad")
+	}
+}
+
+func TestTransformPerspectiveAffineFastPath(t *testing.T) {
+	p := NewPath()
+	p.MoveTo(0, 0)
+	p.QuadraticTo(50, 100, 100, 0)
+	p.Close()
+
+	affine := Translate(5, 10).Multiply(Scale(2, 2))
+	got := p.TransformPerspective(Matrix3x3FromMatrix(affine))
+	want := p.Transform(affine)
+	if got.NumVerbs() != want.NumVerbs() {
+		t.Errorf("affine fast path produced %d verbs, Transform gives %d",
+			got.NumVerbs(), want.NumVerbs())
+	}
+}
+
+func TestTransformPerspectiveEndpoints(t *testing.T) {
+	src := [4]Point{Pt(0, 0), Pt(100, 0), Pt(100, 100), Pt(0, 100)}
+	dst := [4]Point{Pt(20, 10), Pt(80, 20), Pt(90, 90), Pt(10, 80)}
+	m, ok := QuadToQuad(src, dst)
+	if !ok {
+		t.Fatal("QuadToQuad failed")
+	}
+
+	p := NewPath()
+	p.MoveTo(0, 0)
+	p.LineTo(100, 0)
+	p.CubicTo(100, 50, 100, 80, 100, 100)
+	p.LineTo(0, 100)
+	p.Close()
+
+	tp := p.TransformPerspective(m)
+	verbs := tp.Verbs()
+	coords := tp.Coords()
+	if tp.HasCurves() {
+		t.Error("perspective-transformed path should contain only lines")
+	}
+	// First point must land exactly on the transformed start corner.
+	if verbs[0] != MoveTo || !pointsNear(Pt(coords[0], coords[1]), dst[0], 1e-6) {
+		t.Errorf("start point %v, want %v", Pt(coords[0], coords[1]), dst[0])
+	}
+	// Every emitted point must stay within the destination quad's bounds.
+	for i := 0; i+1 < len(coords); i += 2 {
+		x, y := coords[i], coords[i+1]
+		if x < 9 || x > 91 || y < 9 || y > 91 {
+			t.Fatalf("point (%v, %v) escapes destination quad bounds", x, y)
+		}
+	}
+}
+
+func TestDrawImagePerspectiveQuad(t *testing.T) {
+	// Solid red source image warped onto a trapezoid.
+	src := image.NewRGBA(image.Rect(0, 0, 20, 20))
+	for y := 0; y < 20; y++ {
+		for x := 0; x < 20; x++ {
+			src.Set(x, y, color.RGBA{R: 255, A: 255})
+		}
+	}
+	img := ImageBufFromImage(src)
+
+	dc := NewContext(100, 100)
+	dc.DrawImageEx(img, DrawImageOptions{
+		DstQuad: &[4]Point{Pt(30, 20), Pt(70, 20), Pt(90, 80), Pt(10, 80)},
+	})
+
+	if got := dc.pixmap.GetPixel(50, 50); got.R < 0.9 || got.A < 0.9 {
+		t.Errorf("center of quad not painted: %+v", got)
+	}
+	if got := dc.pixmap.GetPixel(15, 25); got.A > 0.1 {
+		t.Errorf("outside quad painted: %+v", got)
+	}
+	if got := dc.pixmap.GetPixel(5, 5); got.A > 0.1 {
+		t.Errorf("far corner painted: %+v", got)
+	}
+}
+
+func TestDrawImagePerspectiveRespectsTransform(t *testing.T) {
+	src := image.NewRGBA(image.Rect(0, 0, 10, 10))
+	for y := 0; y < 10; y++ {
+		for x := 0; x < 10; x++ {
+			src.Set(x, y, color.RGBA{B: 255, A: 255})
+		}
+	}
+	img := ImageBufFromImage(src)
+
+	dc := NewContext(100, 100)
+	dc.Translate(40, 40)
+	dc.DrawImageEx(img, DrawImageOptions{
+		DstQuad: &[4]Point{Pt(0, 0), Pt(20, 0), Pt(20, 20), Pt(0, 20)},
+	})
+
+	if got := dc.pixmap.GetPixel(50, 50); got.B < 0.9 {
+		t.Errorf("translated quad not painted at (50,50): %+v", got)
+	}
+	if got := dc.pixmap.GetPixel(30, 30); got.A > 0.1 {
+		t.Errorf("untranslated position painted: %+v", got)
+	}
+}
@@ -0,0 +1,150 @@
+package gg
+
+import "image"
+
+// SubPixmap returns a new Pixmap containing a copy of the given region,
+// clipped to the pixmap bounds. Returns nil when the clipped region is
+// empty. Pixmap rows are contiguous (no stride), so the result owns its
+// own buffer rather than aliasing the parent.
+func (p *Pixmap) SubPixmap(r image.Rectangle) *Pixmap {
+	r = r.Intersect(image.Rect(0, 0, p.width, p.height))
+	if r.Empty() {
+		return nil
+	}
+
+	sub := NewPixmap(r.Dx(), r.Dy())
+	srcStride := p.width * 4
+	dstStride := sub.width * 4
+	for y := 0; y < sub.height; y++ {
+		src := (r.Min.Y+y)*srcStride + r.Min.X*4
+		dst := y * dstStride
+		copy(sub.data[dst:dst+dstStride], p.data[src:src+dstStride])
+	}
+	return sub
+}
+
+// CopyFrom blits the srcRect region of src to this pixmap at dstPoint,
+// replacing destination pixels (no blending; both buffers hold
+// premultiplied RGBA). Source and destination rectangles are clipped to
+// their respective bounds. Copying between overlapping regions of the
+// same pixmap is safe.
+//
+// Like other batch mutations, CopyFrom assigns a new generation ID.
+func (p *Pixmap) CopyFrom(src *Pixmap, srcRect image.Rectangle, dstPoint image.Point) {
+	if src == nil {
+		return
+	}
+	srcRect = srcRect.Intersect(image.Rect(0, 0, src.width, src.height))
+	if srcRect.Empty() {
+		return
+	}
+
+	// Clip the destination, shifting the source origin by the clipped amount.
+	dstRect := image.Rect(dstPoint.X, dstPoint.Y, dstPoint.X+srcRect.Dx(), dstPoint.Y+srcRect.Dy())
+	clipped := dstRect.Intersect(image.Rect(0, 0, p.width, p.height))
+	if clipped.Empty() {
+		return
+	}
+	srcX := srcRect.Min.X + (clipped.Min.X - dstRect.Min.X)
+	srcY := srcRect.Min.Y + (clipped.Min.Y - dstRect.Min.Y)
+	w, h := clipped.Dx(), clipped.Dy()
+
+	srcStride := src.width * 4
+	dstStride := p.width * 4
+	rowBytes := w * 4
+
+	// For overlapping self-copies, pick the row order that reads each
+	// source row before it is overwritten. Within a row, copy() handles
+	// overlap like memmove.
+	y0, y1, step := 0, h, 1
+	if src == p && clipped.Min.Y > srcY {
+		y0, y1, step = h-1, -1, -1
+	}
+	for y := y0; y != y1; y += step {
+		so := (srcY+y)*srcStride + srcX*4
+		do := (clipped.Min.Y+y)*dstStride + clipped.Min.X*4
+		copy(p.data[do:do+rowBytes], src.data[so:so+rowBytes])
+	}
+
+	p.genID = nextPixmapGenID.Add(1)
+}
+
+// FlipH mirrors the pixmap horizontally in place.
+func (p *Pixmap) FlipH() {
+	stride := p.width * 4
+	for y := 0; y < p.height; y++ {
+		row := p.data[y*stride : y*stride+stride]
+		for l, r := 0, (p.width-1)*4; l < r; l, r = l+4, r-4 {
+			row[l], row[r] = row[r], row[l]
+			row[l+1], row[r+1] = row[r+1], row[l+1]
+			row[l+2], row[r+2] = row[r+2], row[l+2]
+			row[l+3], row[r+3] = row[r+3], row[l+3]
+		}
+	}
+	p.genID = nextPixmapGenID.Add(1)
+}
+
+// FlipV mirrors the pixmap vertically in place using row-wise swaps.
+func (p *Pixmap) FlipV() {
+	stride := p.width * 4
+	tmp := make([]uint8, stride)
+	for top, bot := 0, p.height-1; top < bot; top, bot = top+1, bot-1 {
+		a := p.data[top*stride : top*stride+stride]
+		b := p.data[bot*stride : bot*stride+stride]
+		copy(tmp, a)
+		copy(a, b)
+		copy(b, tmp)
+	}
+	p.genID = nextPixmapGenID.Add(1)
+}
+
+// Rotate90 returns a new pixmap rotated 90 degrees clockwise.
+// The result has swapped dimensions.
+func (p *Pixmap) Rotate90() *Pixmap {
+	dst := NewPixmap(p.height, p.width)
+	srcStride := p.width * 4
+	dstStride := dst.width * 4
+	// Each source row becomes a destination column, right to left.
+	for y := 0; y < p.height; y++ {
+		src := p.data[y*srcStride : y*srcStride+srcStride]
+		dx := (p.height - 1 - y) * 4
+		for x := 0; x < p.width; x++ {
+			do := x*dstStride + dx
+			copy(dst.data[do:do+4], src[x*4:x*4+4])
+		}
+	}
+	return dst
+}
+
+// Rotate180 returns a new pixmap rotated 180 degrees.
+func (p *Pixmap) Rotate180() *Pixmap {
+	dst := NewPixmap(p.width, p.height)
+	stride := p.width * 4
+	for y := 0; y < p.height; y++ {
+		src := p.data[y*stride : y*stride+stride]
+		drow := dst.data[(p.height-1-y)*stride:]
+		for x := 0; x < p.width; x++ {
+			do := (p.width - 1 - x) * 4
+			copy(drow[do:do+4], src[x*4:x*4+4])
+		}
+	}
+	return dst
+}
+
+// Rotate270 returns a new pixmap rotated 270 degrees clockwise
+// (90 degrees counter-clockwise). The result has swapped dimensions.
+func (p *Pixmap) Rotate270() *Pixmap {
+	dst := NewPixmap(p.height, p.width)
+	srcStride := p.width * 4
+	dstStride := dst.width * 4
+	// Each source row becomes a destination column, bottom to top.
+	for y := 0; y < p.height; y++ {
+		src := p.data[y*srcStride : y*srcStride+srcStride]
+		dx := y * 4
+		for x := 0; x < p.width; x++ {
+			do := (p.width-1-x)*dstStride + dx
+			copy(dst.data[do:do+4], src[x*4:x*4+4])
+		}
+	}
+	return dst
+}
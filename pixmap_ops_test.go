@@ -0,0 +1,276 @@
+package gg
+
+import (
+	"image"
+	"testing"
+)
+
+// gradientPixmap returns a pixmap where each pixel encodes its
+// coordinates: R = x, G = y, fully opaque.
+func gradientPixmap(w, h int) *Pixmap {
+	pm := NewPixmap(w, h)
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			pm.SetPixelPremul(x, y, uint8(x), uint8(y), 0, 255)
+		}
+	}
+	return pm
+}
+
+// premulAt returns the raw premultiplied pixel at (x, y).
+func premulAt(pm *Pixmap, x, y int) [4]uint8 {
+	i := (y*pm.Width() + x) * 4
+	d := pm.Data()
+	return [4]uint8{d[i], d[i+1], d[i+2], d[i+3]}
+}
+
+func TestSubPixmap(t *testing.T) {
+	pm := gradientPixmap(10, 8)
+
+	sub := pm.SubPixmap(image.Rect(2, 3, 7, 6))
+	if sub == nil {
+		t.Fatal("SubPixmap returned nil for in-bounds rect")
+	}
+	if sub.Width() != 5 || sub.Height() != 3 {
+		t.Fatalf("sub size = %dx%d, want 5x3", sub.Width(), sub.Height())
+	}
+
+	// sub(0,0) corresponds to pm(2,3).
+	if got := premulAt(sub, 0, 0); got != [4]uint8{2, 3, 0, 255} {
+		t.Errorf("sub(0,0) = %v, want [2 3 0 255]", got)
+	}
+	if got := premulAt(sub, 4, 2); got != [4]uint8{6, 5, 0, 255} {
+		t.Errorf("sub(4,2) = %v, want [6 5 0 255]", got)
+	}
+
+	// The copy is independent of the parent.
+	sub.SetPixelPremul(0, 0, 99, 99, 99, 255)
+	if got := premulAt(pm, 2, 3); got != [4]uint8{2, 3, 0, 255} {
+		t.Errorf("parent modified through sub: %v", got)
+	}
+}
+
+func TestSubPixmapClipping(t *testing.T) {
+	pm := gradientPixmap(10, 10)
+
+	// Partially out-of-bounds rect is clipped.
+	sub := pm.SubPixmap(image.Rect(8, 8, 20, 20))
+	if sub == nil || sub.Width() != 2 || sub.Height() != 2 {
+		t.Fatalf("clipped sub = %v, want 2x2", sub)
+	}
+
+	// Fully out-of-bounds rect yields nil.
+	if sub := pm.SubPixmap(image.Rect(20, 20, 30, 30)); sub != nil {
+		t.Errorf("out-of-bounds SubPixmap = %v, want nil", sub)
+	}
+	if sub := pm.SubPixmap(image.Rect(5, 5, 5, 5)); sub != nil {
+		t.Errorf("empty-rect SubPixmap = %v, want nil", sub)
+	}
+}
+
+func TestCopyFrom(t *testing.T) {
+	src := gradientPixmap(6, 6)
+	dst := NewPixmap(10, 10)
+	dst.Clear(White)
+
+	dst.CopyFrom(src, image.Rect(1, 1, 5, 5), image.Pt(3, 4))
+
+	// dst(3,4) receives src(1,1).
+	if got := premulAt(dst, 3, 4); got != [4]uint8{1, 1, 0, 255} {
+		t.Errorf("dst(3,4) = %v, want [1 1 0 255]", got)
+	}
+	if got := premulAt(dst, 6, 7); got != [4]uint8{4, 4, 0, 255} {
+		t.Errorf("dst(6,7) = %v, want [4 4 0 255]", got)
+	}
+
+	// Outside the blit the destination is untouched.
+	if got := premulAt(dst, 2, 4); got != [4]uint8{255, 255, 255, 255} {
+		t.Errorf("dst(2,4) = %v, want white", got)
+	}
+	if got := premulAt(dst, 7, 4); got != [4]uint8{255, 255, 255, 255} {
+		t.Errorf("dst(7,4) = %v, want white", got)
+	}
+}
+
+func TestCopyFromClipping(t *testing.T) {
+	src := gradientPixmap(6, 6)
+	dst := NewPixmap(10, 10)
+	dst.Clear(Black)
+
+	// Destination point partially off the bottom-right corner.
+	dst.CopyFrom(src, image.Rect(0, 0, 6, 6), image.Pt(8, 8))
+	if got := premulAt(dst, 9, 9); got != [4]uint8{1, 1, 0, 255} {
+		t.Errorf("dst(9,9) = %v, want [1 1 0 255]", got)
+	}
+
+	// Negative destination clips the top-left of the source.
+	dst.Clear(Black)
+	dst.CopyFrom(src, image.Rect(0, 0, 6, 6), image.Pt(-2, -3))
+	if got := premulAt(dst, 0, 0); got != [4]uint8{2, 3, 0, 255} {
+		t.Errorf("dst(0,0) = %v, want [2 3 0 255]", got)
+	}
+
+	// Fully off-screen and nil source are no-ops.
+	before := dst.GenerationID()
+	dst.CopyFrom(src, image.Rect(0, 0, 6, 6), image.Pt(100, 100))
+	dst.CopyFrom(nil, image.Rect(0, 0, 6, 6), image.Pt(0, 0))
+	if dst.GenerationID() != before {
+		t.Error("no-op CopyFrom changed the generation ID")
+	}
+}
+
+func TestCopyFromSelfOverlap(t *testing.T) {
+	// Shift a region down-right by one pixel within the same pixmap.
+	pm := gradientPixmap(8, 8)
+	pm.CopyFrom(pm, image.Rect(0, 0, 7, 7), image.Pt(1, 1))
+
+	// Every shifted pixel must hold the original source value.
+	for y := 1; y < 8; y++ {
+		for x := 1; x < 8; x++ {
+			want := [4]uint8{uint8(x - 1), uint8(y - 1), 0, 255}
+			if got := premulAt(pm, x, y); got != want {
+				t.Fatalf("pm(%d,%d) = %v, want %v", x, y, got, want)
+			}
+		}
+	}
+
+	// And the opposite direction: shift up-left.
+	pm = gradientPixmap(8, 8)
+	pm.CopyFrom(pm, image.Rect(1, 1, 8, 8), image.Pt(0, 0))
+	for y := 0; y < 7; y++ {
+		for x := 0; x < 7; x++ {
+			want := [4]uint8{uint8(x + 1), uint8(y + 1), 0, 255}
+			if got := premulAt(pm, x, y); got != want {
+				t.Fatalf("pm(%d,%d) = %v, want %v", x, y, got, want)
+			}
+		}
+	}
+}
+
+func TestFlipH(t *testing.T) {
+	pm := gradientPixmap(5, 3)
+	pm.FlipH()
+
+	for y := 0; y < 3; y++ {
+		for x := 0; x < 5; x++ {
+			want := [4]uint8{uint8(4 - x), uint8(y), 0, 255}
+			if got := premulAt(pm, x, y); got != want {
+				t.Fatalf("pm(%d,%d) = %v, want %v", x, y, got, want)
+			}
+		}
+	}
+}
+
+func TestFlipV(t *testing.T) {
+	pm := gradientPixmap(3, 5)
+	pm.FlipV()
+
+	for y := 0; y < 5; y++ {
+		for x := 0; x < 3; x++ {
+			want := [4]uint8{uint8(x), uint8(4 - y), 0, 255}
+			if got := premulAt(pm, x, y); got != want {
+				t.Fatalf("pm(%d,%d) = %v, want %v", x, y, got, want)
+			}
+		}
+	}
+}
+
+func TestRotate90(t *testing.T) {
+	pm := gradientPixmap(4, 3)
+	r := pm.Rotate90()
+
+	if r.Width() != 3 || r.Height() != 4 {
+		t.Fatalf("rotated size = %dx%d, want 3x4", r.Width(), r.Height())
+	}
+	// Clockwise: src(x, y) -> dst(H-1-y, x).
+	for y := 0; y < 3; y++ {
+		for x := 0; x < 4; x++ {
+			want := [4]uint8{uint8(x), uint8(y), 0, 255}
+			if got := premulAt(r, 2-y, x); got != want {
+				t.Fatalf("dst(%d,%d) = %v, want %v", 2-y, x, got, want)
+			}
+		}
+	}
+}
+
+func TestRotate180(t *testing.T) {
+	pm := gradientPixmap(4, 3)
+	r := pm.Rotate180()
+
+	if r.Width() != 4 || r.Height() != 3 {
+		t.Fatalf("rotated size = %dx%d, want 4x3", r.Width(), r.Height())
+	}
+	for y := 0; y < 3; y++ {
+		for x := 0; x < 4; x++ {
+			want := [4]uint8{uint8(x), uint8(y), 0, 255}
+			if got := premulAt(r, 3-x, 2-y); got != want {
+				t.Fatalf("dst(%d,%d) = %v, want %v", 3-x, 2-y, got, want)
+			}
+		}
+	}
+}
+
+func TestRotate270(t *testing.T) {
+	pm := gradientPixmap(4, 3)
+	r := pm.Rotate270()
+
+	if r.Width() != 3 || r.Height() != 4 {
+		t.Fatalf("rotated size = %dx%d, want 3x4", r.Width(), r.Height())
+	}
+	// Counter-clockwise: src(x, y) -> dst(y, W-1-x).
+	for y := 0; y < 3; y++ {
+		for x := 0; x < 4; x++ {
+			want := [4]uint8{uint8(x), uint8(y), 0, 255}
+			if got := premulAt(r, y, 3-x); got != want {
+				t.Fatalf("dst(%d,%d) = %v, want %v", y, 3-x, got, want)
+			}
+		}
+	}
+}
+
+func TestRotateComposition(t *testing.T) {
+	pm := gradientPixmap(7, 5)
+
+	// Four quarter turns are the identity.
+	r := pm.Rotate90().Rotate90().Rotate90().Rotate90()
+	for y := 0; y < 5; y++ {
+		for x := 0; x < 7; x++ {
+			if premulAt(r, x, y) != premulAt(pm, x, y) {
+				t.Fatalf("4x Rotate90 differs at (%d,%d)", x, y)
+			}
+		}
+	}
+
+	// Two quarter turns equal a half turn.
+	a := pm.Rotate90().Rotate90()
+	b := pm.Rotate180()
+	for y := 0; y < 5; y++ {
+		for x := 0; x < 7; x++ {
+			if premulAt(a, x, y) != premulAt(b, x, y) {
+				t.Fatalf("2x Rotate90 != Rotate180 at (%d,%d)", x, y)
+			}
+		}
+	}
+}
+
+func TestPixmapOpsGenerationID(t *testing.T) {
+	pm := gradientPixmap(4, 4)
+
+	id := pm.GenerationID()
+	pm.FlipH()
+	if pm.GenerationID() == id {
+		t.Error("FlipH did not change generation ID")
+	}
+
+	id = pm.GenerationID()
+	pm.FlipV()
+	if pm.GenerationID() == id {
+		t.Error("FlipV did not change generation ID")
+	}
+
+	id = pm.GenerationID()
+	pm.CopyFrom(gradientPixmap(2, 2), image.Rect(0, 0, 2, 2), image.Pt(0, 0))
+	if pm.GenerationID() == id {
+		t.Error("CopyFrom did not change generation ID")
+	}
+}
@@ -1,6 +1,6 @@
 package gg
 
-import "math"
+import "github.com/gogpu/gg/internal/detmath"
 
 // Point represents a 2D point or vector.
 type Point struct {
@@ -44,7 +44,7 @@ func (p Point) Cross(q Point) float64 {
 
 // Length returns the length of the vector.
 func (p Point) Length() float64 {
-	return math.Sqrt(p.X*p.X + p.Y*p.Y)
+	return detmath.Sqrt(p.X*p.X + p.Y*p.Y)
 }
 
 // LengthSquared returns the squared length of the vector.
@@ -68,8 +68,8 @@ func (p Point) Normalize() Point {
 
 // Rotate returns the point rotated by angle radians around the origin.
 func (p Point) Rotate(angle float64) Point {
-	cos := math.Cos(angle)
-	sin := math.Sin(angle)
+	cos := detmath.Cos(angle)
+	sin := detmath.Sin(angle)
 	return Point{
 		X: p.X*cos - p.Y*sin,
 		Y: p.X*sin + p.Y*cos,
@@ -0,0 +1,34 @@
+// Copyright 2026 The gogpu Authors
+// SPDX-License-Identifier: MIT
+
+// Package present blits Pixmaps to a native window without a GPU stack.
+//
+// It targets lightweight Linux tools — monitors, debug viewers, kiosk
+// utilities — that render with the software rasterizer and just need the
+// result on screen. The only implemented transport is X11 shared memory
+// (MIT-SHM over the X wire protocol, pure Go, no cgo); when the extension
+// is unavailable (e.g. remote displays) it degrades to core-protocol
+// PutImage. The Presenter interface leaves room for a Wayland wl_shm
+// transport with the same damage semantics.
+//
+// # Usage
+//
+//	p, err := present.Open(present.Options{Width: 800, Height: 600, Title: "demo"})
+//	if err != nil { ... }
+//	defer p.Close()
+//
+//	pixmap := gg.NewPixmap(800, 600)
+//	for running {
+//	    // draw into pixmap via gg ...
+//	    if err := p.Present(pixmap, ctx.FrameDamage()); err != nil {
+//	        break
+//	    }
+//	}
+//
+// Damage rects limit the blit to changed regions; pass nil to present the
+// full pixmap.
+//
+// # Thread Safety
+//
+// A Presenter is NOT safe for concurrent use.
+package present
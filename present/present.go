@@ -0,0 +1,68 @@
+// Copyright 2026 The gogpu Authors
+// SPDX-License-Identifier: MIT
+
+package present
+
+import (
+	"errors"
+	"image"
+
+	"github.com/gogpu/gg"
+)
+
+// Common errors returned by presenters.
+var (
+	// ErrNoDisplay is returned when no supported display server is reachable.
+	ErrNoDisplay = errors.New("present: no display available")
+
+	// ErrPresenterClosed is returned when operations are attempted on a closed presenter.
+	ErrPresenterClosed = errors.New("present: presenter is closed")
+
+	// ErrWindowClosed is returned by Present after the user closed the window.
+	ErrWindowClosed = errors.New("present: window was closed")
+
+	// ErrSizeMismatch is returned when the pixmap does not match the window size.
+	ErrSizeMismatch = errors.New("present: pixmap size does not match window")
+
+	// ErrUnsupported is returned on platforms without a presentation transport.
+	ErrUnsupported = errors.New("present: not supported on this platform")
+)
+
+// Options configures a presenter window.
+type Options struct {
+	// Width and Height are the window dimensions in pixels.
+	Width  int
+	Height int
+
+	// Title is the window title. Empty uses a generic default.
+	Title string
+}
+
+// Presenter puts pixmap contents on screen.
+//
+// Presenters are NOT safe for concurrent use.
+type Presenter interface {
+	// Present blits the pixmap to the window. Damage limits the blit to
+	// the listed regions (in pixel coordinates, clamped to the window);
+	// nil or empty presents everything. The pixmap dimensions must match
+	// the window. Returns ErrWindowClosed once the user closes the window.
+	Present(pixmap *gg.Pixmap, damage []image.Rectangle) error
+
+	// Size returns the window dimensions in pixels.
+	Size() (width, height int)
+
+	// Close destroys the window and releases resources.
+	// Close is idempotent; multiple calls are safe.
+	Close() error
+}
+
+// Open connects to the platform display server and creates a window.
+// On Linux it tries X11 (honoring DISPLAY); other transports may be added.
+// Returns ErrNoDisplay when no display server is reachable and
+// ErrUnsupported on platforms without an implementation.
+func Open(opts Options) (Presenter, error) {
+	if opts.Width <= 0 || opts.Height <= 0 {
+		return nil, errors.New("present: invalid dimensions")
+	}
+	return openPlatform(opts)
+}
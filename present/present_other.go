@@ -0,0 +1,12 @@
+// Copyright 2026 The gogpu Authors
+// SPDX-License-Identifier: MIT
+
+//go:build !linux
+
+package present
+
+// openPlatform reports that no presentation transport exists here.
+// Windows and macOS presentation go through the gogpu window stack.
+func openPlatform(_ Options) (Presenter, error) {
+	return nil, ErrUnsupported
+}
@@ -0,0 +1,135 @@
+// Copyright 2026 The gogpu Authors
+// SPDX-License-Identifier: MIT
+
+package present
+
+import (
+	"bytes"
+	"image"
+	"testing"
+)
+
+// TestPutGet tests little-endian wire encoding round trips.
+func TestPutGet(t *testing.T) {
+	b := put16(nil, 0x1234)
+	if got := get16(b); got != 0x1234 {
+		t.Errorf("get16 = %#x, want 0x1234", got)
+	}
+	if !bytes.Equal(b, []byte{0x34, 0x12}) {
+		t.Errorf("put16 = %v, want little-endian", b)
+	}
+
+	b = put32(nil, 0xDEADBEEF)
+	if got := get32(b); got != 0xDEADBEEF {
+		t.Errorf("get32 = %#x, want 0xDEADBEEF", got)
+	}
+}
+
+// TestXPad tests 4-byte alignment padding.
+func TestXPad(t *testing.T) {
+	want := map[int]int{0: 0, 1: 3, 2: 2, 3: 1, 4: 0, 7: 1, 8: 0}
+	for n, p := range want {
+		if got := xpad(n); got != p {
+			t.Errorf("xpad(%d) = %d, want %d", n, got, p)
+		}
+	}
+}
+
+// xauthEntry encodes one .Xauthority entry for test fixtures.
+func xauthEntry(family uint16, address, number, name string, data []byte) []byte {
+	var b []byte
+	b = append(b, byte(family>>8), byte(family))
+	for _, f := range [][]byte{[]byte(address), []byte(number), []byte(name), data} {
+		b = append(b, byte(len(f)>>8), byte(len(f)))
+		b = append(b, f...)
+	}
+	return b
+}
+
+// TestParseXauth tests .Xauthority decoding.
+func TestParseXauth(t *testing.T) {
+	raw := xauthEntry(256, "host", "0", "MIT-MAGIC-COOKIE-1", []byte{1, 2, 3, 4})
+	raw = append(raw, xauthEntry(256, "host", "1", "MIT-MAGIC-COOKIE-1", []byte{5, 6})...)
+
+	cookies := parseXauth(raw)
+	if len(cookies) != 2 {
+		t.Fatalf("parsed %d entries, want 2", len(cookies))
+	}
+	if cookies[0].address != "host" || cookies[0].number != "0" {
+		t.Errorf("entry 0 = %+v", cookies[0])
+	}
+	if !bytes.Equal(cookies[1].data, []byte{5, 6}) {
+		t.Errorf("entry 1 data = %v, want [5 6]", cookies[1].data)
+	}
+
+	// Truncated trailing entry is ignored.
+	cookies = parseXauth(raw[:len(raw)-3])
+	if len(cookies) != 1 {
+		t.Errorf("truncated parse gave %d entries, want 1", len(cookies))
+	}
+}
+
+// TestFindXauthCookie tests display matching with wildcard fallback.
+func TestFindXauthCookie(t *testing.T) {
+	cookies := []xauthCookie{
+		{name: "OTHER-AUTH", number: "0", data: []byte{9}},
+		{name: "MIT-MAGIC-COOKIE-1", number: "", data: []byte{1}},
+		{name: "MIT-MAGIC-COOKIE-1", number: "1", data: []byte{2}},
+	}
+
+	if got := findXauthCookie(cookies, "1"); !bytes.Equal(got, []byte{2}) {
+		t.Errorf("exact match = %v, want [2]", got)
+	}
+	if got := findXauthCookie(cookies, "5"); !bytes.Equal(got, []byte{1}) {
+		t.Errorf("wildcard fallback = %v, want [1]", got)
+	}
+	if got := findXauthCookie(cookies[:1], "0"); got != nil {
+		t.Errorf("wrong auth name matched: %v", got)
+	}
+}
+
+// TestClampDamage tests damage clamping and the full-window fallback.
+func TestClampDamage(t *testing.T) {
+	full := []image.Rectangle{image.Rect(0, 0, 100, 50)}
+	if got := clampDamage(nil, 100, 50); len(got) != 1 || got[0] != full[0] {
+		t.Errorf("nil damage = %v, want %v", got, full)
+	}
+
+	damage := []image.Rectangle{
+		image.Rect(-10, -10, 20, 20),   // clipped
+		image.Rect(90, 40, 200, 200),   // clipped
+		image.Rect(150, 150, 160, 160), // fully outside
+	}
+	got := clampDamage(damage, 100, 50)
+	want := []image.Rectangle{image.Rect(0, 0, 20, 20), image.Rect(90, 40, 100, 50)}
+	if len(got) != len(want) || got[0] != want[0] || got[1] != want[1] {
+		t.Errorf("clamped = %v, want %v", got, want)
+	}
+}
+
+// TestRGBAToBGRX tests channel swizzling within a damage rect.
+func TestRGBAToBGRX(t *testing.T) {
+	const w, h = 4, 3
+	src := make([]byte, w*h*4)
+	dst := make([]byte, w*h*4)
+	// Pixel (1,1): R=10 G=20 B=30 A=40.
+	i := (1*w + 1) * 4
+	src[i], src[i+1], src[i+2], src[i+3] = 10, 20, 30, 40
+
+	rgbaToBGRX(dst, w*4, src, w*4, image.Rect(1, 1, 2, 2))
+
+	if dst[i] != 30 || dst[i+1] != 20 || dst[i+2] != 10 || dst[i+3] != 0xFF {
+		t.Errorf("converted pixel = %v, want BGRX (30 20 10 255)", dst[i:i+4])
+	}
+	// Pixels outside the rect are untouched.
+	if dst[0] != 0 {
+		t.Errorf("pixel outside rect modified: %v", dst[0:4])
+	}
+}
+
+// TestOpenInvalidDimensions tests argument validation.
+func TestOpenInvalidDimensions(t *testing.T) {
+	if _, err := Open(Options{Width: 0, Height: 10}); err == nil {
+		t.Error("Open with zero width should fail")
+	}
+}
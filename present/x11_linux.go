@@ -0,0 +1,577 @@
+// Copyright 2026 The gogpu Authors
+// SPDX-License-Identifier: MIT
+
+//go:build linux
+
+package present
+
+import (
+	"fmt"
+	"image"
+	"io"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"golang.org/x/sys/unix"
+
+	"github.com/gogpu/gg"
+)
+
+// X11 protocol constants (core opcodes and event codes used here).
+const (
+	xOpCreateWindow   = 1
+	xOpMapWindow      = 8
+	xOpInternAtom     = 16
+	xOpChangeProperty = 18
+	xOpGetInputFocus  = 43
+	xOpCreateGC       = 55
+	xOpPutImage       = 72
+	xOpQueryExtension = 98
+
+	xEventDestroyNotify = 17
+	xEventClientMessage = 33
+
+	xAtomWMName = 39
+	xAtomString = 31
+
+	xZPixmap = 2
+)
+
+// MIT-SHM extension minor opcodes.
+const (
+	shmQueryVersion = 0
+	shmAttach       = 1
+	shmPutImage     = 3
+)
+
+// x11Presenter presents pixmaps to an X11 window, preferring MIT-SHM
+// shared memory and falling back to core-protocol PutImage.
+type x11Presenter struct {
+	conn   net.Conn
+	width  int
+	height int
+
+	root      uint32
+	rootDepth byte
+	idNext    uint32
+	idInc     uint32
+	maxReqLen uint32 // in 4-byte units
+	window    uint32
+	gc        uint32
+	wmProtos  uint32 // WM_PROTOCOLS atom
+	wmDelete  uint32 // WM_DELETE_WINDOW atom
+	sequence  uint16
+
+	shmOpcode byte   // 0 when MIT-SHM is unavailable
+	shmSeg    uint32 // server-side segment resource
+	shmBuf    []byte // attached SysV segment, stride width*4
+	staging   []byte // PutImage fallback row buffer
+
+	userClosed bool
+	closed     bool
+}
+
+// openPlatform connects to the X server named by DISPLAY.
+func openPlatform(opts Options) (Presenter, error) {
+	display := os.Getenv("DISPLAY")
+	if display == "" {
+		return nil, ErrNoDisplay
+	}
+	return openX11(display, opts)
+}
+
+// openX11 dials the X server, performs the setup handshake, and creates
+// a mapped window ready for Present calls.
+func openX11(display string, opts Options) (*x11Presenter, error) {
+	num := displayNumber(display)
+	conn, err := net.DialTimeout("unix", "/tmp/.X11-unix/X"+num, 3*time.Second)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrNoDisplay, err)
+	}
+
+	p := &x11Presenter{
+		conn:   conn,
+		width:  opts.Width,
+		height: opts.Height,
+	}
+	if err := p.handshake(num); err != nil {
+		conn.Close()
+		return nil, err
+	}
+	if err := p.createWindow(opts.Title); err != nil {
+		conn.Close()
+		return nil, err
+	}
+	p.initSHM()
+	return p, nil
+}
+
+// displayNumber extracts the display number from a DISPLAY value such as
+// ":0", ":1.0", or "unix:0".
+func displayNumber(display string) string {
+	if i := strings.IndexByte(display, ':'); i >= 0 {
+		display = display[i+1:]
+	}
+	if i := strings.IndexByte(display, '.'); i >= 0 {
+		display = display[:i]
+	}
+	if display == "" {
+		return "0"
+	}
+	return display
+}
+
+// authCookie loads the MIT-MAGIC-COOKIE-1 for the display from
+// XAUTHORITY (or ~/.Xauthority). Missing files mean no auth, which is
+// fine for servers that allow local connections.
+func authCookie(displayNum string) []byte {
+	path := os.Getenv("XAUTHORITY")
+	if path == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return nil
+		}
+		path = filepath.Join(home, ".Xauthority")
+	}
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return nil
+	}
+	return findXauthCookie(parseXauth(raw), displayNum)
+}
+
+// handshake performs the connection setup and parses the pieces of the
+// setup reply the presenter needs: resource ID allocation, request size
+// limit, and the first screen's root window and depth.
+func (p *x11Presenter) handshake(displayNum string) error {
+	cookie := authCookie(displayNum)
+	authName := ""
+	if len(cookie) > 0 {
+		authName = "MIT-MAGIC-COOKIE-1"
+	}
+
+	req := []byte{'l', 0}
+	req = put16(req, 11) // protocol major
+	req = put16(req, 0)  // protocol minor
+	req = put16(req, uint16(len(authName)))
+	req = put16(req, uint16(len(cookie)))
+	req = put16(req, 0) // pad
+	req = append(req, authName...)
+	req = append(req, make([]byte, xpad(len(authName)))...)
+	req = append(req, cookie...)
+	req = append(req, make([]byte, xpad(len(cookie)))...)
+	if _, err := p.conn.Write(req); err != nil {
+		return fmt.Errorf("present: x11 setup write: %w", err)
+	}
+
+	head := make([]byte, 8)
+	if _, err := io.ReadFull(p.conn, head); err != nil {
+		return fmt.Errorf("present: x11 setup read: %w", err)
+	}
+	body := make([]byte, int(get16(head[6:]))*4)
+	if _, err := io.ReadFull(p.conn, body); err != nil {
+		return fmt.Errorf("present: x11 setup read: %w", err)
+	}
+	if head[0] != 1 {
+		reason := ""
+		if n := int(head[1]); n <= len(body) {
+			reason = string(body[:n])
+		}
+		return fmt.Errorf("present: x11 connection refused: %s", strings.TrimSpace(reason))
+	}
+
+	idBase := get32(body[4:])
+	idMask := get32(body[8:])
+	p.idNext = idBase
+	p.idInc = idMask & -idMask
+	p.maxReqLen = uint32(get16(body[18:]))
+	vendorLen := int(get16(body[16:]))
+	formats := int(body[21])
+
+	// Skip vendor string (padded) and pixmap formats to the first screen.
+	off := 32 + vendorLen + xpad(vendorLen) + formats*8
+	if off+40 > len(body) {
+		return fmt.Errorf("present: x11 setup reply truncated")
+	}
+	p.root = get32(body[off:])
+	p.rootDepth = body[off+38]
+	return nil
+}
+
+// newID allocates a resource ID from the range granted during setup.
+func (p *x11Presenter) newID() uint32 {
+	id := p.idNext
+	p.idNext += p.idInc
+	return id
+}
+
+// send writes one request and advances the sequence counter.
+func (p *x11Presenter) send(req []byte) error {
+	p.sequence++
+	_, err := p.conn.Write(req)
+	return err
+}
+
+// request assembles a request header (opcode, detail byte, length in
+// 4-byte units) followed by the body, which must be 4-byte aligned.
+func request(opcode, detail byte, body []byte) []byte {
+	req := []byte{opcode, detail}
+	req = put16(req, uint16(1+len(body)/4))
+	return append(req, body...)
+}
+
+// createWindow creates, titles, and maps the presenter's window, and
+// registers for WM_DELETE_WINDOW so Present can report user close.
+func (p *x11Presenter) createWindow(title string) error {
+	p.window = p.newID()
+	p.gc = p.newID()
+
+	var body []byte
+	body = put32(body, p.window)
+	body = put32(body, p.root)
+	body = put16(body, 0) // x
+	body = put16(body, 0) // y
+	body = put16(body, uint16(p.width))
+	body = put16(body, uint16(p.height))
+	body = put16(body, 0)          // border width
+	body = put16(body, 1)          // class InputOutput
+	body = put32(body, 0)          // visual CopyFromParent
+	body = put32(body, 0x00000802) // value mask: back-pixel | event-mask
+	body = put32(body, 0)          // back-pixel black
+	body = put32(body, 0x00028000) // events: Exposure | StructureNotify
+	if err := p.send(request(xOpCreateWindow, 0, body)); err != nil {
+		return fmt.Errorf("present: create window: %w", err)
+	}
+
+	var err error
+	if p.wmProtos, err = p.internAtom("WM_PROTOCOLS"); err != nil {
+		return err
+	}
+	if p.wmDelete, err = p.internAtom("WM_DELETE_WINDOW"); err != nil {
+		return err
+	}
+
+	var prop []byte
+	prop = put32(prop, p.window)
+	prop = put32(prop, p.wmProtos)
+	prop = put32(prop, 4) // type ATOM
+	prop = append(prop, 32, 0, 0, 0)
+	prop = put32(prop, 1)
+	prop = put32(prop, p.wmDelete)
+	if err := p.send(request(xOpChangeProperty, 0, prop)); err != nil {
+		return fmt.Errorf("present: set protocols: %w", err)
+	}
+
+	if title == "" {
+		title = "gg"
+	}
+	prop = prop[:0]
+	prop = put32(prop, p.window)
+	prop = put32(prop, xAtomWMName)
+	prop = put32(prop, xAtomString)
+	prop = append(prop, 8, 0, 0, 0)
+	prop = put32(prop, uint32(len(title)))
+	prop = append(prop, title...)
+	prop = append(prop, make([]byte, xpad(len(title)))...)
+	if err := p.send(request(xOpChangeProperty, 0, prop)); err != nil {
+		return fmt.Errorf("present: set title: %w", err)
+	}
+
+	var gcBody []byte
+	gcBody = put32(gcBody, p.gc)
+	gcBody = put32(gcBody, p.window)
+	gcBody = put32(gcBody, 0)
+	if err := p.send(request(xOpCreateGC, 0, gcBody)); err != nil {
+		return fmt.Errorf("present: create gc: %w", err)
+	}
+
+	var mapBody []byte
+	mapBody = put32(mapBody, p.window)
+	if err := p.send(request(xOpMapWindow, 0, mapBody)); err != nil {
+		return fmt.Errorf("present: map window: %w", err)
+	}
+	return nil
+}
+
+// internAtom resolves an atom name via a synchronous round trip.
+func (p *x11Presenter) internAtom(name string) (uint32, error) {
+	var body []byte
+	body = put16(body, uint16(len(name)))
+	body = put16(body, 0)
+	body = append(body, name...)
+	body = append(body, make([]byte, xpad(len(name)))...)
+	if err := p.send(request(xOpInternAtom, 0, body)); err != nil {
+		return 0, fmt.Errorf("present: intern atom: %w", err)
+	}
+	reply, err := p.readReply(p.sequence)
+	if err != nil {
+		return 0, err
+	}
+	return get32(reply[8:]), nil
+}
+
+// initSHM probes the MIT-SHM extension and, when present, attaches a
+// shared segment sized for the window. Failures are non-fatal: the
+// presenter falls back to core PutImage.
+func (p *x11Presenter) initSHM() {
+	var body []byte
+	body = put16(body, 7) // len("MIT-SHM")
+	body = put16(body, 0)
+	body = append(body, "MIT-SHM"...)
+	body = append(body, 0) // pad to 4
+	if err := p.send(request(xOpQueryExtension, 0, body)); err != nil {
+		return
+	}
+	reply, err := p.readReply(p.sequence)
+	if err != nil || reply[8] == 0 {
+		return
+	}
+	opcode := reply[9]
+
+	size := p.width * p.height * 4
+	shmid, err := unix.SysvShmGet(unix.IPC_PRIVATE, size, unix.IPC_CREAT|0o600)
+	if err != nil {
+		gg.Logger().Debug("present: shmget failed, using PutImage", "err", err)
+		return
+	}
+	buf, err := unix.SysvShmAttach(shmid, 0, 0)
+	if err != nil {
+		_, _ = unix.SysvShmCtl(shmid, unix.IPC_RMID, nil)
+		gg.Logger().Debug("present: shmat failed, using PutImage", "err", err)
+		return
+	}
+
+	seg := p.newID()
+	var attach []byte
+	attach = put32(attach, seg)
+	attach = put32(attach, uint32(shmid))
+	attach = append(attach, 0, 0, 0, 0) // read-only=false + pad
+	if err := p.send(request(opcode, shmAttach, attach)); err != nil {
+		_ = unix.SysvShmDetach(buf)
+		_, _ = unix.SysvShmCtl(shmid, unix.IPC_RMID, nil)
+		return
+	}
+	// Sync so the server has attached before the segment is marked for
+	// removal; RMID then auto-frees it once both sides detach.
+	if err := p.sync(); err != nil {
+		_ = unix.SysvShmDetach(buf)
+		_, _ = unix.SysvShmCtl(shmid, unix.IPC_RMID, nil)
+		return
+	}
+	_, _ = unix.SysvShmCtl(shmid, unix.IPC_RMID, nil)
+
+	p.shmOpcode = opcode
+	p.shmSeg = seg
+	p.shmBuf = buf
+}
+
+// sync performs a GetInputFocus round trip, the conventional X11 fence.
+func (p *x11Presenter) sync() error {
+	if err := p.send(request(xOpGetInputFocus, 0, nil)); err != nil {
+		return fmt.Errorf("present: sync: %w", err)
+	}
+	_, err := p.readReply(p.sequence)
+	return err
+}
+
+// readReply reads messages until the reply for the given sequence number
+// arrives, handling events and logging errors along the way.
+func (p *x11Presenter) readReply(seq uint16) ([]byte, error) {
+	p.conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+	defer p.conn.SetReadDeadline(time.Time{})
+	for {
+		msg, err := p.readMessage()
+		if err != nil {
+			return nil, err
+		}
+		switch msg[0] {
+		case 0: // protocol error
+			if get16(msg[2:]) == seq {
+				return nil, fmt.Errorf("present: x11 error code %d", msg[1])
+			}
+			gg.Logger().Debug("present: x11 error", "code", msg[1])
+		case 1: // reply
+			if get16(msg[2:]) == seq {
+				return msg, nil
+			}
+		default:
+			p.handleEvent(msg)
+		}
+	}
+}
+
+// readMessage reads one 32-byte message plus any reply extension.
+func (p *x11Presenter) readMessage() ([]byte, error) {
+	msg := make([]byte, 32)
+	if _, err := io.ReadFull(p.conn, msg); err != nil {
+		return nil, fmt.Errorf("present: x11 read: %w", err)
+	}
+	if msg[0] == 1 {
+		if extra := int(get32(msg[4:])) * 4; extra > 0 {
+			rest := make([]byte, extra)
+			if _, err := io.ReadFull(p.conn, rest); err != nil {
+				return nil, fmt.Errorf("present: x11 read: %w", err)
+			}
+			msg = append(msg, rest...)
+		}
+	}
+	return msg, nil
+}
+
+// handleEvent processes an event, watching for window closure. The high
+// bit of the code marks events generated by SendEvent and is ignored.
+func (p *x11Presenter) handleEvent(msg []byte) {
+	switch msg[0] &^ 0x80 {
+	case xEventClientMessage:
+		if get32(msg[8:]) == p.wmProtos && get32(msg[12:]) == p.wmDelete {
+			p.userClosed = true
+		}
+	case xEventDestroyNotify:
+		p.userClosed = true
+	}
+}
+
+// drainEvents consumes any queued events without blocking.
+func (p *x11Presenter) drainEvents() {
+	for {
+		p.conn.SetReadDeadline(time.Now())
+		msg := make([]byte, 32)
+		_, err := io.ReadFull(p.conn, msg)
+		p.conn.SetReadDeadline(time.Time{})
+		if err != nil {
+			return
+		}
+		if msg[0] == 0 {
+			gg.Logger().Debug("present: x11 error", "code", msg[1])
+			continue
+		}
+		p.handleEvent(msg)
+	}
+}
+
+// Size returns the window dimensions in pixels.
+func (p *x11Presenter) Size() (width, height int) {
+	return p.width, p.height
+}
+
+// Present blits the pixmap to the window, restricted to the damage rects.
+func (p *x11Presenter) Present(pixmap *gg.Pixmap, damage []image.Rectangle) error {
+	if p.closed {
+		return ErrPresenterClosed
+	}
+	p.drainEvents()
+	if p.userClosed {
+		return ErrWindowClosed
+	}
+	if pixmap == nil || pixmap.Width() != p.width || pixmap.Height() != p.height {
+		return ErrSizeMismatch
+	}
+
+	rects := clampDamage(damage, p.width, p.height)
+	src := pixmap.Data()
+	stride := p.width * 4
+
+	for _, r := range rects {
+		var err error
+		if p.shmBuf != nil {
+			err = p.shmPut(src, stride, r)
+		} else {
+			err = p.corePut(src, stride, r)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	// Fence the frame so the shared buffer (or socket) can be reused
+	// immediately without tearing the blit still in flight.
+	return p.sync()
+}
+
+// shmPut converts one damage rect into the shared segment and issues
+// ShmPutImage for it.
+func (p *x11Presenter) shmPut(src []byte, stride int, r image.Rectangle) error {
+	rgbaToBGRX(p.shmBuf, stride, src, stride, r)
+
+	var body []byte
+	body = put32(body, p.window)
+	body = put32(body, p.gc)
+	body = put16(body, uint16(p.width))
+	body = put16(body, uint16(p.height))
+	body = put16(body, uint16(r.Min.X))
+	body = put16(body, uint16(r.Min.Y))
+	body = put16(body, uint16(r.Dx()))
+	body = put16(body, uint16(r.Dy()))
+	body = put16(body, uint16(r.Min.X))
+	body = put16(body, uint16(r.Min.Y))
+	body = append(body, p.rootDepth, xZPixmap, 0, 0) // depth, format, send-event, pad
+	body = put32(body, p.shmSeg)
+	body = put32(body, 0) // offset
+	if err := p.send(request(p.shmOpcode, shmPutImage, body)); err != nil {
+		return fmt.Errorf("present: shm put: %w", err)
+	}
+	return nil
+}
+
+// corePut uploads one damage rect with core-protocol PutImage, chunking
+// rows to stay under the server's maximum request length.
+func (p *x11Presenter) corePut(src []byte, stride int, r image.Rectangle) error {
+	rowBytes := r.Dx() * 4
+	maxRows := (int(p.maxReqLen)*4 - 28) / rowBytes
+	if maxRows < 1 {
+		maxRows = 1
+	}
+
+	for y := r.Min.Y; y < r.Max.Y; y += maxRows {
+		rows := min(maxRows, r.Max.Y-y)
+		need := rows * rowBytes
+		if cap(p.staging) < need {
+			p.staging = make([]byte, need)
+		}
+		buf := p.staging[:need]
+		for i := 0; i < rows; i++ {
+			si := (y+i)*stride + r.Min.X*4
+			di := i * rowBytes
+			for x := 0; x < r.Dx(); x++ {
+				buf[di] = src[si+2]
+				buf[di+1] = src[si+1]
+				buf[di+2] = src[si]
+				buf[di+3] = 0xFF
+				si += 4
+				di += 4
+			}
+		}
+
+		var body []byte
+		body = put32(body, p.window)
+		body = put32(body, p.gc)
+		body = put16(body, uint16(r.Dx()))
+		body = put16(body, uint16(rows))
+		body = put16(body, uint16(r.Min.X))
+		body = put16(body, uint16(y))
+		body = append(body, 0, p.rootDepth, 0, 0) // left-pad, depth, pad
+		body = append(body, buf...)
+		if err := p.send(request(xOpPutImage, xZPixmap, body)); err != nil {
+			return fmt.Errorf("present: put image: %w", err)
+		}
+	}
+	return nil
+}
+
+// Close destroys the window connection and detaches shared memory.
+func (p *x11Presenter) Close() error {
+	if p.closed {
+		return nil
+	}
+	p.closed = true
+	if p.shmBuf != nil {
+		_ = unix.SysvShmDetach(p.shmBuf)
+		p.shmBuf = nil
+	}
+	return p.conn.Close()
+}
+
+// Verify x11Presenter implements Presenter.
+var _ Presenter = (*x11Presenter)(nil)
@@ -0,0 +1,139 @@
+// Copyright 2026 The gogpu Authors
+// SPDX-License-Identifier: MIT
+
+package present
+
+import "image"
+
+// X11 wire-format helpers. Kept free of syscalls and build tags so the
+// marshaling logic is unit-testable on every platform.
+
+// put16 appends v in X11 client byte order (little-endian).
+func put16(b []byte, v uint16) []byte {
+	return append(b, byte(v), byte(v>>8))
+}
+
+// put32 appends v in X11 client byte order (little-endian).
+func put32(b []byte, v uint32) []byte {
+	return append(b, byte(v), byte(v>>8), byte(v>>16), byte(v>>24))
+}
+
+// get16 reads a little-endian uint16.
+func get16(b []byte) uint16 {
+	return uint16(b[0]) | uint16(b[1])<<8
+}
+
+// get32 reads a little-endian uint32.
+func get32(b []byte) uint32 {
+	return uint32(b[0]) | uint32(b[1])<<8 | uint32(b[2])<<16 | uint32(b[3])<<24
+}
+
+// xpad returns the number of padding bytes that round n up to a multiple
+// of four, as required for X11 request alignment.
+func xpad(n int) int {
+	return (4 - n&3) & 3
+}
+
+// xauthCookie is one entry from an .Xauthority file.
+type xauthCookie struct {
+	family  uint16
+	address string
+	number  string
+	name    string
+	data    []byte
+}
+
+// parseXauth decodes the .Xauthority binary format: a sequence of entries,
+// each a big-endian uint16 family followed by four length-prefixed fields
+// (address, display number, auth name, auth data). Truncated trailing
+// entries are ignored, matching libXau behavior.
+func parseXauth(raw []byte) []xauthCookie {
+	var cookies []xauthCookie
+	for len(raw) >= 2 {
+		family := uint16(raw[0])<<8 | uint16(raw[1])
+		raw = raw[2:]
+		var fields [4][]byte
+		ok := true
+		for i := range fields {
+			if len(raw) < 2 {
+				ok = false
+				break
+			}
+			n := int(raw[0])<<8 | int(raw[1])
+			raw = raw[2:]
+			if len(raw) < n {
+				ok = false
+				break
+			}
+			fields[i] = raw[:n]
+			raw = raw[n:]
+		}
+		if !ok {
+			break
+		}
+		cookies = append(cookies, xauthCookie{
+			family:  family,
+			address: string(fields[0]),
+			number:  string(fields[1]),
+			name:    string(fields[2]),
+			data:    fields[3],
+		})
+	}
+	return cookies
+}
+
+// findXauthCookie selects the MIT-MAGIC-COOKIE-1 entry for the given
+// display number, preferring an exact display match but accepting entries
+// with an empty display number (wildcard). Returns nil when absent.
+func findXauthCookie(cookies []xauthCookie, display string) []byte {
+	var wildcard []byte
+	for _, c := range cookies {
+		if c.name != "MIT-MAGIC-COOKIE-1" {
+			continue
+		}
+		switch c.number {
+		case display:
+			return c.data
+		case "":
+			if wildcard == nil {
+				wildcard = c.data
+			}
+		}
+	}
+	return wildcard
+}
+
+// clampDamage intersects damage rects with the window bounds, dropping
+// empty results. Nil or empty damage yields the full window rect.
+func clampDamage(damage []image.Rectangle, width, height int) []image.Rectangle {
+	bounds := image.Rect(0, 0, width, height)
+	if len(damage) == 0 {
+		return []image.Rectangle{bounds}
+	}
+	out := damage[:0:0]
+	for _, r := range damage {
+		if r = r.Intersect(bounds); !r.Empty() {
+			out = append(out, r)
+		}
+	}
+	return out
+}
+
+// rgbaToBGRX converts a rectangular region of RGBA pixel data into the
+// 32-bit ZPixmap layout used by little-endian X servers at depth 24
+// (bytes B, G, R, X per pixel). Both buffers are row-major; srcStride and
+// dstStride are in bytes. Alpha is discarded — the window is opaque.
+func rgbaToBGRX(dst []byte, dstStride int, src []byte, srcStride int, r image.Rectangle) {
+	for y := r.Min.Y; y < r.Max.Y; y++ {
+		si := y*srcStride + r.Min.X*4
+		di := y*dstStride + r.Min.X*4
+		for x := r.Min.X; x < r.Max.X; x++ {
+			dst[di] = src[si+2]
+			dst[di+1] = src[si+1]
+			dst[di+2] = src[si]
+			dst[di+3] = 0xFF
+			si += 4
+			di += 4
+		}
+	}
+}
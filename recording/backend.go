@@ -86,7 +86,7 @@ type Backend interface {
 	FillRect(rect Rect, brush Brush)
 
 	// DrawImage draws an image from the source rectangle to the destination rectangle.
-	// The opts parameter specifies interpolation quality and alpha.
+	// The opts parameter specifies interpolation quality, alpha, and blend mode.
 	DrawImage(img image.Image, src, dst Rect, opts ImageOptions)
 
 	// DrawText draws text at the given position with the specified font face and brush.
@@ -116,6 +116,34 @@ type FileBackend interface {
 	SaveToFile(path string) error
 }
 
+// Capability identifies an optional drawing feature a Backend may support.
+// Backends declare unsupported capabilities via CapabilityBackend; playback
+// then decomposes the affected commands instead of degrading silently.
+type Capability uint8
+
+const (
+	// CapLinearGradient is support for linear gradient brushes.
+	CapLinearGradient Capability = iota
+	// CapRadialGradient is support for radial gradient brushes.
+	CapRadialGradient
+	// CapSweepGradient is support for sweep gradient brushes.
+	CapSweepGradient
+	// CapPattern is support for image pattern brushes.
+	CapPattern
+)
+
+// CapabilityBackend is an optional interface for backends that cannot handle
+// every recorded command natively. During playback, a fill or stroke whose
+// brush needs an unsupported capability is rasterized through gg and handed
+// to the backend as a DrawImage call covering the affected region. Backends
+// that do not implement this interface are assumed to support everything.
+type CapabilityBackend interface {
+	Backend
+
+	// Supports reports whether the backend can render the capability natively.
+	Supports(c Capability) bool
+}
+
 // PixmapBackend extends Backend with access to a rasterized pixmap.
 // This is implemented by the raster backend and allows direct pixel access.
 type PixmapBackend interface {
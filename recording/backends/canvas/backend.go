@@ -0,0 +1,540 @@
+// Package canvas provides an HTML Canvas 2D export backend for the
+// recording system. It converts a Recording into a JavaScript snippet of
+// Canvas 2D API calls, or a JSON command dump, so rendering issues can be
+// reproduced in a browser and compared against a reference implementation.
+//
+// # Output Formats
+//
+//   - FormatJS (default): a self-contained script that looks up a canvas
+//     element by id ("gg-canvas") and replays the drawing commands with
+//     the Canvas 2D API.
+//   - FormatJSON: a structured dump of each command with its arguments,
+//     suitable for diffing two recordings or driving a custom replayer.
+//     Replay re-renders such a dump — or a browser-captured Canvas 2D
+//     trace — into a gg.Context for server-side rasterization.
+//
+// # Limitations
+//
+// Gradient brushes translate to createLinearGradient/createRadialGradient;
+// sweep gradients use createConicGradient, which requires a recent browser.
+// Images are embedded as data URIs and drawn via an Image object, which
+// makes the JS replay asynchronous for image-bearing recordings.
+//
+// # Example
+//
+//	// Import to register the backend
+//	import _ "github.com/gogpu/gg/recording/backends/canvas"
+//
+//	backend, _ := recording.NewBackend("canvas")
+//	rec.Playback(backend)
+//	backend.(*canvas.Backend).SaveToFile("repro.js")
+package canvas
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"image"
+	"image/png"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/gogpu/gg"
+	"github.com/gogpu/gg/recording"
+	"github.com/gogpu/gg/text"
+)
+
+func init() {
+	recording.Register("canvas", func() recording.Backend {
+		return NewBackend()
+	})
+}
+
+// Format selects the output format of the canvas backend.
+type Format uint8
+
+const (
+	// FormatJS emits a JavaScript snippet of Canvas 2D API calls.
+	FormatJS Format = iota
+	// FormatJSON emits a JSON array of command objects.
+	FormatJSON
+)
+
+// Backend converts recordings to HTML Canvas 2D commands.
+// It implements recording.Backend, recording.WriterBackend,
+// and recording.FileBackend interfaces.
+type Backend struct {
+	width  int
+	height int
+	format Format
+
+	js   bytes.Buffer
+	cmds []jsonCommand
+
+	finished bool
+	output   []byte
+}
+
+// jsonCommand is one entry in the JSON command dump.
+type jsonCommand struct {
+	Op   string         `json:"op"`
+	Args map[string]any `json:"args,omitempty"`
+}
+
+// Ensure Backend implements all required interfaces.
+var (
+	_ recording.Backend       = (*Backend)(nil)
+	_ recording.WriterBackend = (*Backend)(nil)
+	_ recording.FileBackend   = (*Backend)(nil)
+)
+
+// NewBackend creates a new canvas export backend with FormatJS output.
+// The backend must be initialized with Begin before use.
+func NewBackend() *Backend {
+	return &Backend{format: FormatJS}
+}
+
+// SetFormat selects the output format. Must be called before Begin.
+func (b *Backend) SetFormat(f Format) {
+	b.format = f
+}
+
+// Begin initializes the backend for rendering at the given dimensions.
+func (b *Backend) Begin(width, height int) error {
+	if width <= 0 || height <= 0 {
+		return fmt.Errorf("canvas: invalid dimensions %dx%d", width, height)
+	}
+	b.width = width
+	b.height = height
+	b.js.Reset()
+	b.cmds = b.cmds[:0]
+	b.finished = false
+	b.output = nil
+
+	b.record("begin", map[string]any{"width": width, "height": height})
+	fmt.Fprintf(&b.js, "// gg recording replay (%dx%d)\n", width, height)
+	fmt.Fprintf(&b.js, "const canvas = document.getElementById('gg-canvas');\n")
+	fmt.Fprintf(&b.js, "canvas.width = %d;\ncanvas.height = %d;\n", width, height)
+	b.js.WriteString("const ctx = canvas.getContext('2d');\n")
+	return nil
+}
+
+// End finalizes the output.
+func (b *Backend) End() error {
+	if b.finished {
+		return nil
+	}
+	b.record("end", nil)
+	if b.format == FormatJSON {
+		data, err := json.MarshalIndent(b.cmds, "", "  ")
+		if err != nil {
+			return err
+		}
+		b.output = data
+	} else {
+		b.output = b.js.Bytes()
+	}
+	b.finished = true
+	return nil
+}
+
+// Save saves the current graphics state.
+func (b *Backend) Save() {
+	b.record("save", nil)
+	b.js.WriteString("ctx.save();\n")
+}
+
+// Restore restores the previously saved graphics state.
+func (b *Backend) Restore() {
+	b.record("restore", nil)
+	b.js.WriteString("ctx.restore();\n")
+}
+
+// SetTransform is a no-op for geometry: recorded path coordinates are
+// already transformed to world space by the Recorder. The command is
+// still dumped in JSON mode for inspection.
+func (b *Backend) SetTransform(m recording.Matrix) {
+	b.record("setTransform", map[string]any{
+		"a": m.A, "b": m.B, "c": m.C, "d": m.D, "e": m.E, "f": m.F,
+	})
+}
+
+// SetClip sets the clipping region to the given path.
+func (b *Backend) SetClip(path *gg.Path, rule recording.FillRule) {
+	if path == nil {
+		return
+	}
+	b.record("clip", map[string]any{
+		"path": pathString(path),
+		"rule": ruleString(rule),
+	})
+	b.emitPath(path)
+	fmt.Fprintf(&b.js, "ctx.clip('%s');\n", ruleString(rule))
+}
+
+// ClearClip has no Canvas 2D equivalent; clips are scoped by save/restore.
+// The command is recorded in the JSON dump for completeness.
+func (b *Backend) ClearClip() {
+	b.record("clearClip", nil)
+	b.js.WriteString("// clearClip: no Canvas 2D equivalent (use save/restore)\n")
+}
+
+// FillPath fills the given path with the brush.
+func (b *Backend) FillPath(path *gg.Path, brush recording.Brush, rule recording.FillRule) {
+	if path == nil {
+		return
+	}
+	b.record("fillPath", map[string]any{
+		"path":  pathString(path),
+		"brush": brushJSON(brush),
+		"rule":  ruleString(rule),
+	})
+	b.emitBrush(brush, "fillStyle")
+	b.emitPath(path)
+	fmt.Fprintf(&b.js, "ctx.fill('%s');\n", ruleString(rule))
+}
+
+// StrokePath strokes the given path with the brush and stroke style.
+func (b *Backend) StrokePath(path *gg.Path, brush recording.Brush, stroke recording.Stroke) {
+	if path == nil {
+		return
+	}
+	b.record("strokePath", map[string]any{
+		"path":   pathString(path),
+		"brush":  brushJSON(brush),
+		"stroke": strokeJSON(stroke),
+	})
+	b.emitBrush(brush, "strokeStyle")
+	b.emitStroke(stroke)
+	b.emitPath(path)
+	b.js.WriteString("ctx.stroke();\n")
+}
+
+// FillRect fills an axis-aligned rectangle.
+func (b *Backend) FillRect(rect recording.Rect, brush recording.Brush) {
+	b.record("fillRect", map[string]any{
+		"x": rect.MinX, "y": rect.MinY, "w": rect.Width(), "h": rect.Height(),
+		"brush": brushJSON(brush),
+	})
+	b.emitBrush(brush, "fillStyle")
+	fmt.Fprintf(&b.js, "ctx.fillRect(%s, %s, %s, %s);\n",
+		num(rect.MinX), num(rect.MinY), num(rect.Width()), num(rect.Height()))
+}
+
+// DrawImage embeds the image as a data URI and draws it asynchronously.
+func (b *Backend) DrawImage(img image.Image, src, dst recording.Rect, opts recording.ImageOptions) {
+	if img == nil || dst.IsEmpty() {
+		return
+	}
+	bounds := img.Bounds()
+	if src.Width() == 0 || src.Height() == 0 {
+		src = recording.NewRect(0, 0, float64(bounds.Dx()), float64(bounds.Dy()))
+	}
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		return
+	}
+	uri := "data:image/png;base64," + base64.StdEncoding.EncodeToString(buf.Bytes())
+
+	b.record("drawImage", map[string]any{
+		"src": map[string]any{"x": src.MinX, "y": src.MinY, "w": src.Width(), "h": src.Height()},
+		"dst": map[string]any{"x": dst.MinX, "y": dst.MinY, "w": dst.Width(), "h": dst.Height()},
+		"uri": uri,
+	})
+
+	b.js.WriteString("{\nconst img = new Image();\n")
+	if opts.Alpha > 0 && opts.Alpha < 1 {
+		fmt.Fprintf(&b.js, "img.onload = () => { ctx.save(); ctx.globalAlpha = %s; ctx.drawImage(img, %s, %s, %s, %s, %s, %s, %s, %s); ctx.restore(); };\n",
+			num(opts.Alpha),
+			num(src.MinX), num(src.MinY), num(src.Width()), num(src.Height()),
+			num(dst.MinX), num(dst.MinY), num(dst.Width()), num(dst.Height()))
+	} else {
+		fmt.Fprintf(&b.js, "img.onload = () => ctx.drawImage(img, %s, %s, %s, %s, %s, %s, %s, %s);\n",
+			num(src.MinX), num(src.MinY), num(src.Width()), num(src.Height()),
+			num(dst.MinX), num(dst.MinY), num(dst.Width()), num(dst.Height()))
+	}
+	fmt.Fprintf(&b.js, "img.src = '%s';\n}\n", uri)
+}
+
+// DrawText draws text at the baseline origin (x, y) with fillText.
+func (b *Backend) DrawText(s string, x, y float64, _ text.Face, brush recording.Brush) {
+	if s == "" {
+		return
+	}
+	b.record("drawText", map[string]any{
+		"text": s, "x": x, "y": y, "brush": brushJSON(brush),
+	})
+	b.emitBrush(brush, "fillStyle")
+	fmt.Fprintf(&b.js, "ctx.fillText(%s, %s, %s);\n", jsString(s), num(x), num(y))
+}
+
+// WriteTo writes the finished output to the given writer.
+func (b *Backend) WriteTo(w io.Writer) (int64, error) {
+	if !b.finished {
+		return 0, fmt.Errorf("canvas: WriteTo called before End")
+	}
+	n, err := w.Write(b.output)
+	return int64(n), err
+}
+
+// SaveToFile saves the finished output to a file at the given path.
+func (b *Backend) SaveToFile(path string) error {
+	if !b.finished {
+		return fmt.Errorf("canvas: SaveToFile called before End")
+	}
+	return os.WriteFile(path, b.output, 0o644)
+}
+
+// Bytes returns the finished output, or nil before End.
+func (b *Backend) Bytes() []byte {
+	return b.output
+}
+
+// --------------------------------------------------------------------------
+// JS emission helpers
+// --------------------------------------------------------------------------
+
+// emitPath emits beginPath and path segment calls for the path.
+func (b *Backend) emitPath(path *gg.Path) {
+	b.js.WriteString("ctx.beginPath();\n")
+	path.Iterate(func(verb gg.PathVerb, coords []float64) {
+		switch verb {
+		case gg.MoveTo:
+			fmt.Fprintf(&b.js, "ctx.moveTo(%s, %s);\n", num(coords[0]), num(coords[1]))
+		case gg.LineTo:
+			fmt.Fprintf(&b.js, "ctx.lineTo(%s, %s);\n", num(coords[0]), num(coords[1]))
+		case gg.QuadTo:
+			fmt.Fprintf(&b.js, "ctx.quadraticCurveTo(%s, %s, %s, %s);\n",
+				num(coords[0]), num(coords[1]), num(coords[2]), num(coords[3]))
+		case gg.CubicTo:
+			fmt.Fprintf(&b.js, "ctx.bezierCurveTo(%s, %s, %s, %s, %s, %s);\n",
+				num(coords[0]), num(coords[1]), num(coords[2]), num(coords[3]),
+				num(coords[4]), num(coords[5]))
+		case gg.Close:
+			b.js.WriteString("ctx.closePath();\n")
+		}
+	})
+}
+
+// emitBrush assigns a style (fillStyle or strokeStyle) from the brush.
+func (b *Backend) emitBrush(brush recording.Brush, styleProp string) {
+	switch br := brush.(type) {
+	case recording.SolidBrush:
+		fmt.Fprintf(&b.js, "ctx.%s = '%s';\n", styleProp, cssColor(br.Color))
+	case *recording.LinearGradientBrush:
+		fmt.Fprintf(&b.js, "{\nconst g = ctx.createLinearGradient(%s, %s, %s, %s);\n",
+			num(br.Start.X), num(br.Start.Y), num(br.End.X), num(br.End.Y))
+		emitStops(&b.js, br.Stops)
+		fmt.Fprintf(&b.js, "ctx.%s = g;\n}\n", styleProp)
+	case *recording.RadialGradientBrush:
+		fmt.Fprintf(&b.js, "{\nconst g = ctx.createRadialGradient(%s, %s, %s, %s, %s, %s);\n",
+			num(br.Focus.X), num(br.Focus.Y), num(br.StartRadius),
+			num(br.Center.X), num(br.Center.Y), num(br.EndRadius))
+		emitStops(&b.js, br.Stops)
+		fmt.Fprintf(&b.js, "ctx.%s = g;\n}\n", styleProp)
+	case *recording.SweepGradientBrush:
+		fmt.Fprintf(&b.js, "{\nconst g = ctx.createConicGradient(%s, %s, %s);\n",
+			num(br.StartAngle), num(br.Center.X), num(br.Center.Y))
+		emitStops(&b.js, br.Stops)
+		fmt.Fprintf(&b.js, "ctx.%s = g;\n}\n", styleProp)
+	default:
+		fmt.Fprintf(&b.js, "ctx.%s = '#000000';\n", styleProp)
+	}
+}
+
+// emitStops emits addColorStop calls for gradient stops.
+func emitStops(w *bytes.Buffer, stops []recording.GradientStop) {
+	for _, s := range stops {
+		fmt.Fprintf(w, "g.addColorStop(%s, '%s');\n", num(s.Offset), cssColor(s.Color))
+	}
+}
+
+// emitStroke assigns stroke state properties.
+func (b *Backend) emitStroke(stroke recording.Stroke) {
+	fmt.Fprintf(&b.js, "ctx.lineWidth = %s;\n", num(stroke.Width))
+	fmt.Fprintf(&b.js, "ctx.lineCap = '%s';\n", capString(stroke.Cap))
+	fmt.Fprintf(&b.js, "ctx.lineJoin = '%s';\n", joinString(stroke.Join))
+	if stroke.MiterLimit > 0 {
+		fmt.Fprintf(&b.js, "ctx.miterLimit = %s;\n", num(stroke.MiterLimit))
+	}
+	if len(stroke.DashPattern) > 0 {
+		parts := make([]string, len(stroke.DashPattern))
+		for i, d := range stroke.DashPattern {
+			parts[i] = num(d)
+		}
+		fmt.Fprintf(&b.js, "ctx.setLineDash([%s]);\n", strings.Join(parts, ", "))
+		fmt.Fprintf(&b.js, "ctx.lineDashOffset = %s;\n", num(stroke.DashOffset))
+	} else {
+		b.js.WriteString("ctx.setLineDash([]);\n")
+	}
+}
+
+// --------------------------------------------------------------------------
+// JSON dump helpers
+// --------------------------------------------------------------------------
+
+// record appends a command to the JSON dump when JSON output is selected.
+func (b *Backend) record(op string, args map[string]any) {
+	if b.format != FormatJSON {
+		return
+	}
+	b.cmds = append(b.cmds, jsonCommand{Op: op, Args: args})
+}
+
+// brushJSON converts a brush to a JSON-friendly map.
+func brushJSON(brush recording.Brush) map[string]any {
+	switch br := brush.(type) {
+	case recording.SolidBrush:
+		return map[string]any{"type": "solid", "color": cssColor(br.Color)}
+	case *recording.LinearGradientBrush:
+		return map[string]any{
+			"type": "linear",
+			"x0":   br.Start.X, "y0": br.Start.Y, "x1": br.End.X, "y1": br.End.Y,
+			"stops": stopsJSON(br.Stops),
+		}
+	case *recording.RadialGradientBrush:
+		return map[string]any{
+			"type": "radial",
+			"cx":   br.Center.X, "cy": br.Center.Y,
+			"r0": br.StartRadius, "r1": br.EndRadius,
+			"stops": stopsJSON(br.Stops),
+		}
+	case *recording.SweepGradientBrush:
+		return map[string]any{
+			"type": "sweep",
+			"cx":   br.Center.X, "cy": br.Center.Y,
+			"startAngle": br.StartAngle, "endAngle": br.EndAngle,
+			"stops": stopsJSON(br.Stops),
+		}
+	default:
+		return map[string]any{"type": "unknown"}
+	}
+}
+
+// stopsJSON converts gradient stops to JSON-friendly maps.
+func stopsJSON(stops []recording.GradientStop) []map[string]any {
+	out := make([]map[string]any, len(stops))
+	for i, s := range stops {
+		out[i] = map[string]any{"offset": s.Offset, "color": cssColor(s.Color)}
+	}
+	return out
+}
+
+// strokeJSON converts a stroke style to a JSON-friendly map.
+func strokeJSON(stroke recording.Stroke) map[string]any {
+	m := map[string]any{
+		"width": stroke.Width,
+		"cap":   capString(stroke.Cap),
+		"join":  joinString(stroke.Join),
+	}
+	if stroke.MiterLimit > 0 {
+		m["miterLimit"] = stroke.MiterLimit
+	}
+	if len(stroke.DashPattern) > 0 {
+		m["dash"] = stroke.DashPattern
+		m["dashOffset"] = stroke.DashOffset
+	}
+	return m
+}
+
+// pathString converts a path to SVG-style path data for compact dumps.
+func pathString(path *gg.Path) string {
+	var sb strings.Builder
+	path.Iterate(func(verb gg.PathVerb, coords []float64) {
+		switch verb {
+		case gg.MoveTo:
+			fmt.Fprintf(&sb, "M%s %s", num(coords[0]), num(coords[1]))
+		case gg.LineTo:
+			fmt.Fprintf(&sb, "L%s %s", num(coords[0]), num(coords[1]))
+		case gg.QuadTo:
+			fmt.Fprintf(&sb, "Q%s %s %s %s",
+				num(coords[0]), num(coords[1]), num(coords[2]), num(coords[3]))
+		case gg.CubicTo:
+			fmt.Fprintf(&sb, "C%s %s %s %s %s %s",
+				num(coords[0]), num(coords[1]), num(coords[2]), num(coords[3]),
+				num(coords[4]), num(coords[5]))
+		case gg.Close:
+			sb.WriteByte('Z')
+		}
+	})
+	return sb.String()
+}
+
+// --------------------------------------------------------------------------
+// Formatting
+// --------------------------------------------------------------------------
+
+// ruleString maps a fill rule to the Canvas 2D fill rule string.
+func ruleString(rule recording.FillRule) string {
+	if rule == recording.FillRuleEvenOdd {
+		return "evenodd"
+	}
+	return "nonzero"
+}
+
+// capString maps a line cap to the Canvas 2D lineCap value.
+func capString(lineCap recording.LineCap) string {
+	switch lineCap {
+	case recording.LineCapRound:
+		return "round"
+	case recording.LineCapSquare:
+		return "square"
+	default:
+		return "butt"
+	}
+}
+
+// joinString maps a line join to the Canvas 2D lineJoin value.
+func joinString(join recording.LineJoin) string {
+	switch join {
+	case recording.LineJoinRound:
+		return "round"
+	case recording.LineJoinBevel:
+		return "bevel"
+	default:
+		// Canvas 2D has no miter-clip or arcs joins; miter is the
+		// closest match.
+		return "miter"
+	}
+}
+
+// cssColor formats a color as a CSS color string.
+func cssColor(c gg.RGBA) string {
+	if c.A >= 1 {
+		return fmt.Sprintf("#%02x%02x%02x", clampByte(c.R), clampByte(c.G), clampByte(c.B))
+	}
+	return fmt.Sprintf("rgba(%d, %d, %d, %s)",
+		clampByte(c.R), clampByte(c.G), clampByte(c.B), num(c.A))
+}
+
+// clampByte converts a [0,1] component to a byte.
+func clampByte(f float64) int {
+	v := int(f*255 + 0.5)
+	if v < 0 {
+		return 0
+	}
+	if v > 255 {
+		return 255
+	}
+	return v
+}
+
+// jsString quotes a string as a JavaScript string literal.
+func jsString(s string) string {
+	data, _ := json.Marshal(s)
+	return string(data)
+}
+
+// num formats a float compactly (trailing zeros trimmed).
+func num(f float64) string {
+	s := fmt.Sprintf("%.4f", f)
+	s = strings.TrimRight(s, "0")
+	s = strings.TrimRight(s, ".")
+	if s == "" || s == "-" {
+		return "0"
+	}
+	return s
+}
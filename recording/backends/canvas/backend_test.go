@@ -0,0 +1,161 @@
+package canvas
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/gogpu/gg"
+	"github.com/gogpu/gg/recording"
+)
+
+func TestRegistration(t *testing.T) {
+	if !recording.IsRegistered("canvas") {
+		t.Fatal("canvas backend not registered")
+	}
+	backend, err := recording.NewBackend("canvas")
+	if err != nil {
+		t.Fatalf("NewBackend failed: %v", err)
+	}
+	if _, ok := backend.(*Backend); !ok {
+		t.Errorf("expected *Backend, got %T", backend)
+	}
+}
+
+func TestJSOutput(t *testing.T) {
+	rec := recording.NewRecorder(200, 100)
+	rec.SetRGB(1, 0, 0)
+	rec.DrawRectangle(10, 10, 40, 40)
+	rec.Fill()
+	rec.SetRGB(0, 0, 1)
+	rec.SetLineWidth(2)
+	rec.SetDash(4, 2)
+	rec.MoveTo(0, 0)
+	rec.LineTo(100, 100)
+	rec.Stroke()
+	r := rec.FinishRecording()
+
+	b := NewBackend()
+	if err := r.Playback(b); err != nil {
+		t.Fatalf("Playback failed: %v", err)
+	}
+
+	out := string(b.Bytes())
+	for _, want := range []string{
+		"canvas.width = 200;",
+		"canvas.height = 100;",
+		"ctx.fillStyle = '#ff0000';",
+		"ctx.beginPath();",
+		"ctx.fill('nonzero');",
+		"ctx.strokeStyle = '#0000ff';",
+		"ctx.lineWidth = 2;",
+		"ctx.setLineDash([4, 2]);",
+		"ctx.stroke();",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("JS output missing %q", want)
+		}
+	}
+}
+
+func TestJSGradient(t *testing.T) {
+	rec := recording.NewRecorder(100, 100)
+	grad := recording.NewLinearGradientBrush(0, 0, 100, 0)
+	grad.AddColorStop(0, gg.Red)
+	grad.AddColorStop(1, gg.Blue)
+	rec.SetFillStyle(grad)
+	rec.DrawRectangle(0, 0, 100, 100)
+	rec.Fill()
+	r := rec.FinishRecording()
+
+	b := NewBackend()
+	if err := r.Playback(b); err != nil {
+		t.Fatalf("Playback failed: %v", err)
+	}
+
+	out := string(b.Bytes())
+	if !strings.Contains(out, "ctx.createLinearGradient(0, 0, 100, 0)") {
+		t.Error("missing createLinearGradient call")
+	}
+	if !strings.Contains(out, "g.addColorStop(0, '#ff0000');") {
+		t.Error("missing gradient stop")
+	}
+}
+
+func TestJSText(t *testing.T) {
+	rec := recording.NewRecorder(100, 100)
+	rec.SetRGB(0, 0, 0)
+	rec.DrawString(`say "hi"`, 10, 50)
+	r := rec.FinishRecording()
+
+	b := NewBackend()
+	if err := r.Playback(b); err != nil {
+		t.Fatalf("Playback failed: %v", err)
+	}
+
+	if !strings.Contains(string(b.Bytes()), `ctx.fillText("say \"hi\"", 10, 50);`) {
+		t.Errorf("missing escaped fillText call:\n%s", b.Bytes())
+	}
+}
+
+func TestJSONOutput(t *testing.T) {
+	rec := recording.NewRecorder(100, 100)
+	rec.SetRGBA(1, 0, 0, 0.5)
+	rec.DrawCircle(50, 50, 20)
+	rec.Fill()
+	rec.DrawCircle(50, 50, 25)
+	rec.Clip()
+	r := rec.FinishRecording()
+
+	b := NewBackend()
+	b.SetFormat(FormatJSON)
+	if err := r.Playback(b); err != nil {
+		t.Fatalf("Playback failed: %v", err)
+	}
+
+	var cmds []struct {
+		Op   string         `json:"op"`
+		Args map[string]any `json:"args"`
+	}
+	if err := json.Unmarshal(b.Bytes(), &cmds); err != nil {
+		t.Fatalf("output is not valid JSON: %v", err)
+	}
+
+	ops := make(map[string]bool)
+	for _, c := range cmds {
+		ops[c.Op] = true
+	}
+	for _, want := range []string{"begin", "fillPath", "clip", "end"} {
+		if !ops[want] {
+			t.Errorf("JSON dump missing op %q", want)
+		}
+	}
+
+	// fillPath must carry path data and the translucent brush.
+	for _, c := range cmds {
+		if c.Op != "fillPath" {
+			continue
+		}
+		if path, _ := c.Args["path"].(string); !strings.HasPrefix(path, "M") {
+			t.Errorf("fillPath path data malformed: %v", c.Args["path"])
+		}
+		brush, _ := c.Args["brush"].(map[string]any)
+		if color, _ := brush["color"].(string); !strings.HasPrefix(color, "rgba(") {
+			t.Errorf("expected rgba color for translucent brush, got %v", brush["color"])
+		}
+	}
+}
+
+func TestOutputBeforeEnd(t *testing.T) {
+	b := NewBackend()
+	if err := b.Begin(10, 10); err != nil {
+		t.Fatalf("Begin failed: %v", err)
+	}
+	if b.Bytes() != nil {
+		t.Error("Bytes should be nil before End")
+	}
+	var sb strings.Builder
+	if _, err := b.WriteTo(&sb); err == nil {
+		t.Error("expected error writing before End")
+	}
+}
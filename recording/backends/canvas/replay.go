@@ -0,0 +1,663 @@
+package canvas
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"image"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/gogpu/gg"
+)
+
+// Replay parses a JSON trace of Canvas 2D calls and replays it into dc,
+// re-rendering a client-captured drawing server-side. Two entry shapes
+// are accepted, and may be mixed within one trace:
+//
+//   - This package's own FormatJSON dump: {"op": "fillPath", "args": {...}}
+//     entries with named arguments, as produced by Backend.
+//   - Browser capture traces: {"method": "lineTo", "args": [10, 20]}
+//     entries with positional arguments for Canvas 2D method calls, and
+//     {"property": "fillStyle", "value": "#f00"} entries for property
+//     assignments — the shape emitted by common canvas proxy/capture tools.
+//
+// Replay is best-effort, like the svg renderer: unknown operations,
+// malformed arguments, and unsupported values (e.g. a gradient object as
+// fillStyle in a browser trace) are skipped silently. An error is
+// returned only when the trace itself is not a JSON array of entries.
+//
+// Known fidelity limits: fillRect, strokeRect, and clearRect replace the
+// current path (Canvas 2D leaves it untouched); strokeText fills instead
+// of stroking; text ops draw with the face set on dc via SetFont and are
+// no-ops without one; drawImage in browser traces is skipped because the
+// image object is not serializable (the FormatJSON drawImage op, which
+// embeds a data URI, is replayed).
+func Replay(data []byte, dc *gg.Context) error {
+	if dc == nil {
+		return fmt.Errorf("canvas: Replay into nil context")
+	}
+	var entries []traceEntry
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return fmt.Errorf("canvas: invalid trace: %w", err)
+	}
+	rp := replayer{dc: dc}
+	for i := range entries {
+		rp.step(&entries[i])
+	}
+	return nil
+}
+
+// ReplayReader reads a JSON trace from r and replays it into dc.
+// See Replay for the accepted formats.
+func ReplayReader(r io.Reader, dc *gg.Context) error {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return fmt.Errorf("canvas: reading trace: %w", err)
+	}
+	return Replay(data, dc)
+}
+
+// traceEntry is one element of a trace array. Field presence decides the
+// interpretation: Property for assignments, otherwise Op or Method names
+// the call, with Args holding either an object (named) or array
+// (positional) argument list.
+type traceEntry struct {
+	Op       string          `json:"op"`
+	Method   string          `json:"method"`
+	Property string          `json:"property"`
+	Value    json.RawMessage `json:"value"`
+	Args     json.RawMessage `json:"args"`
+}
+
+// replayer dispatches trace entries onto a gg.Context.
+type replayer struct {
+	dc *gg.Context
+}
+
+func (r *replayer) step(e *traceEntry) {
+	if e.Property != "" {
+		var v any
+		if len(e.Value) > 0 {
+			_ = json.Unmarshal(e.Value, &v)
+		}
+		r.setProperty(e.Property, v)
+		return
+	}
+	name := e.Op
+	if name == "" {
+		name = e.Method
+	}
+	if name == "" {
+		return
+	}
+	if len(e.Args) > 0 && e.Args[0] == '{' {
+		var m map[string]any
+		if json.Unmarshal(e.Args, &m) == nil {
+			r.command(name, m)
+			return
+		}
+	}
+	var args []any
+	if len(e.Args) > 0 {
+		_ = json.Unmarshal(e.Args, &args)
+	}
+	r.call(name, args)
+}
+
+// command replays a FormatJSON dump op with named arguments.
+func (r *replayer) command(op string, m map[string]any) {
+	dc := r.dc
+	switch op {
+	case "begin", "end":
+		// Canvas setup/teardown; the target context is already sized.
+	case "save":
+		dc.Push()
+	case "restore":
+		dc.Pop()
+	case "setTransform":
+		// Dumped path coordinates are already world-space (see
+		// Backend.SetTransform); applying the matrix again would
+		// double-transform them.
+	case "clip":
+		if p := parseTracePath(argString(m, "path")); p != nil {
+			r.applyFillRule(argString(m, "rule"))
+			dc.DrawPath(p)
+			dc.Clip()
+		}
+	case "clearClip":
+		dc.ResetClip()
+	case "fillPath":
+		if p := parseTracePath(argString(m, "path")); p != nil {
+			r.applyFillRule(argString(m, "rule"))
+			r.setBrush(m["brush"], false)
+			dc.DrawPath(p)
+			_ = dc.Fill()
+		}
+	case "strokePath":
+		if p := parseTracePath(argString(m, "path")); p != nil {
+			r.setBrush(m["brush"], true)
+			r.applyStrokeStyle(m["stroke"])
+			dc.DrawPath(p)
+			_ = dc.Stroke()
+		}
+	case "fillRect":
+		r.setBrush(m["brush"], false)
+		dc.ClearPath()
+		dc.DrawRectangle(argNum(m, "x"), argNum(m, "y"), argNum(m, "w"), argNum(m, "h"))
+		_ = dc.Fill()
+	case "drawText":
+		r.setBrush(m["brush"], false)
+		dc.DrawString(argString(m, "text"), argNum(m, "x"), argNum(m, "y"))
+	case "drawImage":
+		r.drawImageURI(m)
+	}
+}
+
+// call replays a browser-trace Canvas 2D method call with positional args.
+func (r *replayer) call(name string, args []any) {
+	dc := r.dc
+	switch name {
+	case "beginPath":
+		dc.ClearPath()
+	case "closePath":
+		dc.ClosePath()
+	case "moveTo":
+		dc.MoveTo(numArg(args, 0), numArg(args, 1))
+	case "lineTo":
+		dc.LineTo(numArg(args, 0), numArg(args, 1))
+	case "quadraticCurveTo":
+		dc.QuadraticTo(numArg(args, 0), numArg(args, 1), numArg(args, 2), numArg(args, 3))
+	case "bezierCurveTo":
+		dc.CubicTo(numArg(args, 0), numArg(args, 1), numArg(args, 2), numArg(args, 3),
+			numArg(args, 4), numArg(args, 5))
+	case "rect":
+		dc.DrawRectangle(numArg(args, 0), numArg(args, 1), numArg(args, 2), numArg(args, 3))
+	case "arc":
+		r.arc(numArg(args, 0), numArg(args, 1), numArg(args, 2),
+			numArg(args, 3), numArg(args, 4), boolArg(args, 5))
+	case "arcTo":
+		r.arcTo(numArg(args, 0), numArg(args, 1), numArg(args, 2), numArg(args, 3), numArg(args, 4))
+	case "ellipse":
+		r.ellipse(numArg(args, 0), numArg(args, 1), numArg(args, 2), numArg(args, 3),
+			numArg(args, 4), numArg(args, 5), numArg(args, 6), boolArg(args, 7))
+	case "fill":
+		r.applyFillRule(strArg(args, 0))
+		_ = dc.FillPreserve()
+	case "stroke":
+		_ = dc.StrokePreserve()
+	case "clip":
+		r.applyFillRule(strArg(args, 0))
+		dc.ClipPreserve()
+	case "fillRect":
+		dc.ClearPath()
+		dc.DrawRectangle(numArg(args, 0), numArg(args, 1), numArg(args, 2), numArg(args, 3))
+		_ = dc.Fill()
+	case "strokeRect":
+		dc.ClearPath()
+		dc.DrawRectangle(numArg(args, 0), numArg(args, 1), numArg(args, 2), numArg(args, 3))
+		_ = dc.Stroke()
+	case "clearRect":
+		// Direct pixel clear; exact for axis-aligned transforms.
+		dc.FillRectCPU(numArg(args, 0), numArg(args, 1), numArg(args, 2), numArg(args, 3), gg.RGBA{})
+	case "fillText", "strokeText":
+		dc.DrawString(strArg(args, 0), numArg(args, 1), numArg(args, 2))
+	case "save":
+		dc.Push()
+	case "restore":
+		dc.Pop()
+	case "translate":
+		dc.Translate(numArg(args, 0), numArg(args, 1))
+	case "rotate":
+		dc.Rotate(numArg(args, 0))
+	case "scale":
+		dc.Scale(numArg(args, 0), numArg(args, 1))
+	case "transform":
+		dc.Transform(canvasMatrix(args))
+	case "setTransform":
+		dc.SetTransform(canvasMatrix(args))
+	case "resetTransform":
+		dc.Identity()
+	case "setLineDash":
+		if pattern, ok := argAt(args, 0).([]any); ok {
+			dc.SetDash(floats(pattern)...)
+		}
+	}
+}
+
+// setProperty replays a Canvas 2D context property assignment.
+func (r *replayer) setProperty(name string, v any) {
+	dc := r.dc
+	switch name {
+	case "fillStyle":
+		if s, ok := v.(string); ok {
+			if c, ok := parseCSSColor(s); ok {
+				dc.SetFillBrush(gg.Solid(c))
+			}
+		}
+	case "strokeStyle":
+		if s, ok := v.(string); ok {
+			if c, ok := parseCSSColor(s); ok {
+				dc.SetStrokeBrush(gg.Solid(c))
+			}
+		}
+	case "lineWidth":
+		if f, ok := v.(float64); ok && f > 0 {
+			dc.SetLineWidth(f)
+		}
+	case "lineCap":
+		if s, ok := v.(string); ok {
+			dc.SetLineCap(parseLineCap(s))
+		}
+	case "lineJoin":
+		if s, ok := v.(string); ok {
+			dc.SetLineJoin(parseLineJoin(s))
+		}
+	case "miterLimit":
+		if f, ok := v.(float64); ok && f > 0 {
+			dc.SetMiterLimit(f)
+		}
+	case "lineDashOffset":
+		if f, ok := v.(float64); ok {
+			dc.SetDashOffset(f)
+		}
+	}
+}
+
+// arc adds a circular arc, connecting from the current point with a
+// straight line as Canvas 2D does.
+func (r *replayer) arc(x, y, radius, a1, a2 float64, anticlockwise bool) {
+	dc := r.dc
+	if _, _, ok := dc.GetCurrentPoint(); ok {
+		dc.LineTo(x+radius*math.Cos(a1), y+radius*math.Sin(a1))
+	}
+	if anticlockwise {
+		dc.DrawArcNegative(x, y, radius, a1, a2)
+	} else {
+		dc.DrawArc(x, y, radius, a1, a2)
+	}
+}
+
+// arcTo adds the Canvas 2D tangent arc between the lines (p0,p1) and
+// (p1,p2), where p0 is the current point.
+func (r *replayer) arcTo(x1, y1, x2, y2, radius float64) {
+	dc := r.dc
+	dx0, dy0, ok := dc.GetCurrentPoint()
+	if !ok {
+		dc.MoveTo(x1, y1)
+		return
+	}
+	// The path's current point is in device space; arc geometry is
+	// computed in user space (same as EllipticalArcTo).
+	cur := dc.GetTransform().Invert().TransformPoint(gg.Pt(dx0, dy0))
+
+	v1x, v1y := cur.X-x1, cur.Y-y1
+	v2x, v2y := x2-x1, y2-y1
+	l1 := math.Hypot(v1x, v1y)
+	l2 := math.Hypot(v2x, v2y)
+	if radius <= 0 || l1 == 0 || l2 == 0 {
+		dc.LineTo(x1, y1)
+		return
+	}
+	v1x, v1y = v1x/l1, v1y/l1
+	v2x, v2y = v2x/l2, v2y/l2
+
+	cross := v1x*v2y - v1y*v2x
+	dot := v1x*v2x + v1y*v2y
+	if math.Abs(cross) < 1e-9 {
+		// Collinear points: degenerates to a line, per spec.
+		dc.LineTo(x1, y1)
+		return
+	}
+
+	// Distance from the corner to the tangent points and to the center.
+	half := math.Acos(math.Max(-1, math.Min(1, dot))) / 2
+	tanDist := radius / math.Tan(half)
+	bx, by := v1x+v2x, v1y+v2y
+	blen := math.Hypot(bx, by)
+	cx := x1 + bx/blen*radius/math.Sin(half)
+	cy := y1 + by/blen*radius/math.Sin(half)
+
+	t1x, t1y := x1+v1x*tanDist, y1+v1y*tanDist
+	t2x, t2y := x1+v2x*tanDist, y1+v2y*tanDist
+	dc.LineTo(t1x, t1y)
+
+	start := math.Atan2(t1y-cy, t1x-cx)
+	end := math.Atan2(t2y-cy, t2x-cx)
+	// The tangent arc spans less than pi; sweep whichever way is shorter.
+	ccw := math.Mod(end-start+4*math.Pi, 2*math.Pi)
+	if ccw <= math.Pi {
+		dc.DrawArc(cx, cy, radius, start, end)
+	} else {
+		dc.DrawArcNegative(cx, cy, radius, start, end)
+	}
+}
+
+// ellipse adds an elliptical arc via a transformed unit-circle arc,
+// mirroring Context.DrawEllipticalArc.
+func (r *replayer) ellipse(x, y, rx, ry, rotation, a1, a2 float64, anticlockwise bool) {
+	dc := r.dc
+	dc.Push()
+	dc.Translate(x, y)
+	dc.Rotate(rotation)
+	dc.Scale(rx, ry)
+	if anticlockwise {
+		dc.DrawArcNegative(0, 0, 1, a1, a2)
+	} else {
+		dc.DrawArc(0, 0, 1, a1, a2)
+	}
+	dc.Pop()
+}
+
+// drawImageURI replays a FormatJSON drawImage op: the image is decoded
+// from its embedded data URI and mapped from the src to the dst rect.
+func (r *replayer) drawImageURI(m map[string]any) {
+	uri := argString(m, "uri")
+	idx := strings.Index(uri, "base64,")
+	if idx < 0 {
+		return
+	}
+	raw, err := base64.StdEncoding.DecodeString(uri[idx+len("base64,"):])
+	if err != nil {
+		return
+	}
+	img, _, err := image.Decode(bytes.NewReader(raw))
+	if err != nil {
+		return
+	}
+	src, _ := m["src"].(map[string]any)
+	dst, _ := m["dst"].(map[string]any)
+	sw, sh := argNum(src, "w"), argNum(src, "h")
+	dw, dh := argNum(dst, "w"), argNum(dst, "h")
+	if sw <= 0 || sh <= 0 || dw <= 0 || dh <= 0 {
+		return
+	}
+	dc := r.dc
+	dc.Push()
+	dc.ClipRect(argNum(dst, "x"), argNum(dst, "y"), dw, dh)
+	dc.Translate(argNum(dst, "x"), argNum(dst, "y"))
+	dc.Scale(dw/sw, dh/sh)
+	dc.Translate(-argNum(src, "x"), -argNum(src, "y"))
+	dc.DrawImage(gg.ImageBufFromImage(img), 0, 0)
+	dc.Pop()
+}
+
+// setBrush applies a FormatJSON brush to the fill or stroke side.
+func (r *replayer) setBrush(v any, stroke bool) {
+	b := brushFromJSON(v)
+	if b == nil {
+		return
+	}
+	if stroke {
+		r.dc.SetStrokeBrush(b)
+	} else {
+		r.dc.SetFillBrush(b)
+	}
+}
+
+// applyFillRule sets the fill rule from a Canvas 2D rule string.
+func (r *replayer) applyFillRule(rule string) {
+	if rule == "evenodd" {
+		r.dc.SetFillRule(gg.FillRuleEvenOdd)
+	} else {
+		r.dc.SetFillRule(gg.FillRuleNonZero)
+	}
+}
+
+// applyStrokeStyle applies a FormatJSON stroke object (see strokeJSON).
+func (r *replayer) applyStrokeStyle(v any) {
+	m, ok := v.(map[string]any)
+	if !ok {
+		return
+	}
+	dc := r.dc
+	if w := argNum(m, "width"); w > 0 {
+		dc.SetLineWidth(w)
+	}
+	if s := argString(m, "cap"); s != "" {
+		dc.SetLineCap(parseLineCap(s))
+	}
+	if s := argString(m, "join"); s != "" {
+		dc.SetLineJoin(parseLineJoin(s))
+	}
+	if ml := argNum(m, "miterLimit"); ml > 0 {
+		dc.SetMiterLimit(ml)
+	}
+	if dash, ok := m["dash"].([]any); ok && len(dash) > 0 {
+		dc.SetDash(floats(dash)...)
+		dc.SetDashOffset(argNum(m, "dashOffset"))
+	}
+}
+
+// brushFromJSON rebuilds a gg brush from a FormatJSON brush object
+// (the inverse of brushJSON).
+func brushFromJSON(v any) gg.Brush {
+	m, ok := v.(map[string]any)
+	if !ok {
+		return nil
+	}
+	switch argString(m, "type") {
+	case "solid":
+		if c, ok := parseCSSColor(argString(m, "color")); ok {
+			return gg.Solid(c)
+		}
+	case "linear":
+		g := gg.NewLinearGradientBrush(argNum(m, "x0"), argNum(m, "y0"),
+			argNum(m, "x1"), argNum(m, "y1"))
+		forEachStop(m["stops"], func(offset float64, c gg.RGBA) {
+			g.AddColorStop(offset, c)
+		})
+		return g
+	case "radial":
+		g := gg.NewRadialGradientBrush(argNum(m, "cx"), argNum(m, "cy"),
+			argNum(m, "r0"), argNum(m, "r1"))
+		forEachStop(m["stops"], func(offset float64, c gg.RGBA) {
+			g.AddColorStop(offset, c)
+		})
+		return g
+	case "sweep":
+		g := gg.NewSweepGradientBrush(argNum(m, "cx"), argNum(m, "cy"),
+			argNum(m, "startAngle"))
+		if end := argNum(m, "endAngle"); end != 0 {
+			g.SetEndAngle(end)
+		}
+		forEachStop(m["stops"], func(offset float64, c gg.RGBA) {
+			g.AddColorStop(offset, c)
+		})
+		return g
+	}
+	return nil
+}
+
+// forEachStop iterates a FormatJSON gradient stop list.
+func forEachStop(v any, fn func(offset float64, c gg.RGBA)) {
+	stops, ok := v.([]any)
+	if !ok {
+		return
+	}
+	for _, s := range stops {
+		m, ok := s.(map[string]any)
+		if !ok {
+			continue
+		}
+		if c, ok := parseCSSColor(argString(m, "color")); ok {
+			fn(argNum(m, "offset"), c)
+		}
+	}
+}
+
+// parseTracePath parses the SVG-style path data emitted by pathString.
+func parseTracePath(d string) *gg.Path {
+	if d == "" {
+		return nil
+	}
+	p, err := gg.ParseSVGPath(d)
+	if err != nil {
+		return nil
+	}
+	return p
+}
+
+// parseLineCap maps a Canvas 2D lineCap value to a gg line cap.
+func parseLineCap(s string) gg.LineCap {
+	switch s {
+	case "round":
+		return gg.LineCapRound
+	case "square":
+		return gg.LineCapSquare
+	default:
+		return gg.LineCapButt
+	}
+}
+
+// parseLineJoin maps a Canvas 2D lineJoin value to a gg line join.
+func parseLineJoin(s string) gg.LineJoin {
+	switch s {
+	case "round":
+		return gg.LineJoinRound
+	case "bevel":
+		return gg.LineJoinBevel
+	default:
+		return gg.LineJoinMiter
+	}
+}
+
+// canvasMatrix converts positional (a, b, c, d, e, f) Canvas 2D matrix
+// arguments to a gg.Matrix. Canvas maps x' = a·x + c·y + e, gg maps
+// x' = A·x + B·y + C, so b/c and the rows swap accordingly.
+func canvasMatrix(args []any) gg.Matrix {
+	return gg.Matrix{
+		A: numArg(args, 0), B: numArg(args, 2), C: numArg(args, 4),
+		D: numArg(args, 1), E: numArg(args, 3), F: numArg(args, 5),
+	}
+}
+
+// parseCSSColor parses the CSS color strings found in traces: hex
+// (#rgb, #rgba, #rrggbb, #rrggbbaa), rgb()/rgba() functions, and the
+// basic named colors. Unsupported values report ok=false.
+func parseCSSColor(s string) (gg.RGBA, bool) {
+	s = strings.ToLower(strings.TrimSpace(s))
+	if s == "" {
+		return gg.RGBA{}, false
+	}
+	if s[0] == '#' {
+		c, err := gg.ParseHex(s)
+		return c, err == nil
+	}
+	if strings.HasPrefix(s, "rgb") {
+		return parseRGBColor(s)
+	}
+	c, ok := cssNamedColors[s]
+	return c, ok
+}
+
+// parseRGBColor parses "rgb(r, g, b)" and "rgba(r, g, b, a)" with
+// r, g, b in 0-255 and a in 0-1.
+func parseRGBColor(s string) (gg.RGBA, bool) {
+	lparen := strings.IndexByte(s, '(')
+	rparen := strings.IndexByte(s, ')')
+	if lparen < 0 || rparen < lparen {
+		return gg.RGBA{}, false
+	}
+	parts := strings.Split(s[lparen+1:rparen], ",")
+	if len(parts) != 3 && len(parts) != 4 {
+		return gg.RGBA{}, false
+	}
+	vals := make([]float64, len(parts))
+	for i, p := range parts {
+		f, err := strconv.ParseFloat(strings.TrimSpace(p), 64)
+		if err != nil {
+			return gg.RGBA{}, false
+		}
+		vals[i] = f
+	}
+	c := gg.RGBA{R: vals[0] / 255, G: vals[1] / 255, B: vals[2] / 255, A: 1}
+	if len(vals) == 4 {
+		c.A = vals[3]
+	}
+	return c, true
+}
+
+// cssNamedColors covers the CSS basic color keywords plus the values
+// commonly seen in captured traces.
+var cssNamedColors = map[string]gg.RGBA{
+	"transparent": {},
+	"black":       {A: 1},
+	"white":       {R: 1, G: 1, B: 1, A: 1},
+	"red":         {R: 1, A: 1},
+	"lime":        {G: 1, A: 1},
+	"blue":        {B: 1, A: 1},
+	"green":       {G: 0.5, A: 1},
+	"yellow":      {R: 1, G: 1, A: 1},
+	"cyan":        {G: 1, B: 1, A: 1},
+	"aqua":        {G: 1, B: 1, A: 1},
+	"magenta":     {R: 1, B: 1, A: 1},
+	"fuchsia":     {R: 1, B: 1, A: 1},
+	"gray":        {R: 0.5, G: 0.5, B: 0.5, A: 1},
+	"grey":        {R: 0.5, G: 0.5, B: 0.5, A: 1},
+	"silver":      {R: 0.75, G: 0.75, B: 0.75, A: 1},
+	"maroon":      {R: 0.5, A: 1},
+	"olive":       {R: 0.5, G: 0.5, A: 1},
+	"navy":        {B: 0.5, A: 1},
+	"teal":        {G: 0.5, B: 0.5, A: 1},
+	"purple":      {R: 0.5, B: 0.5, A: 1},
+	"orange":      {R: 1, G: 0.647, A: 1},
+}
+
+// --------------------------------------------------------------------------
+// Argument access helpers
+// --------------------------------------------------------------------------
+
+// argNum reads a named numeric argument, returning 0 when absent.
+func argNum(m map[string]any, key string) float64 {
+	f, _ := m[key].(float64)
+	return f
+}
+
+// argString reads a named string argument, returning "" when absent.
+func argString(m map[string]any, key string) string {
+	s, _ := m[key].(string)
+	return s
+}
+
+// argAt returns the i-th positional argument, or nil when out of range.
+func argAt(args []any, i int) any {
+	if i < 0 || i >= len(args) {
+		return nil
+	}
+	return args[i]
+}
+
+// numArg reads the i-th positional argument as a number, returning 0 when
+// absent or non-numeric.
+func numArg(args []any, i int) float64 {
+	f, _ := argAt(args, i).(float64)
+	return f
+}
+
+// strArg reads the i-th positional argument as a string.
+func strArg(args []any, i int) string {
+	s, _ := argAt(args, i).(string)
+	return s
+}
+
+// boolArg reads the i-th positional argument as a bool.
+func boolArg(args []any, i int) bool {
+	b, _ := argAt(args, i).(bool)
+	return b
+}
+
+// floats converts a JSON number array to a float64 slice, skipping
+// non-numeric elements.
+func floats(vals []any) []float64 {
+	out := make([]float64, 0, len(vals))
+	for _, v := range vals {
+		if f, ok := v.(float64); ok {
+			out = append(out, f)
+		}
+	}
+	return out
+}
@@ -0,0 +1,238 @@
+package canvas
+
+import (
+	"image"
+	"testing"
+
+	"github.com/gogpu/gg"
+	"github.com/gogpu/gg/recording"
+)
+
+// pixelAt returns the RGBA pixel at (x, y) of the context's image.
+func pixelAt(t *testing.T, dc *gg.Context, x, y int) (r, g, b, a uint8) {
+	t.Helper()
+	img, ok := dc.Image().(*image.RGBA)
+	if !ok {
+		t.Fatalf("expected *image.RGBA, got %T", dc.Image())
+	}
+	c := img.RGBAAt(x, y)
+	return c.R, c.G, c.B, c.A
+}
+
+func TestReplayBrowserTrace(t *testing.T) {
+	trace := `[
+		{"property": "fillStyle", "value": "#ff0000"},
+		{"method": "fillRect", "args": [20, 20, 60, 60]}
+	]`
+
+	dc := gg.NewContext(100, 100)
+	if err := Replay([]byte(trace), dc); err != nil {
+		t.Fatalf("Replay failed: %v", err)
+	}
+
+	if r, _, _, _ := pixelAt(t, dc, 50, 50); r < 200 {
+		t.Errorf("inside fillRect: expected red, got R=%d", r)
+	}
+	if _, _, _, a := pixelAt(t, dc, 5, 5); a != 0 {
+		t.Errorf("outside fillRect: expected transparent, got A=%d", a)
+	}
+}
+
+func TestReplayBrowserPathOps(t *testing.T) {
+	trace := `[
+		{"property": "fillStyle", "value": "rgb(0, 0, 255)"},
+		{"method": "beginPath"},
+		{"method": "moveTo", "args": [10, 10]},
+		{"method": "lineTo", "args": [90, 10]},
+		{"method": "lineTo", "args": [90, 90]},
+		{"method": "lineTo", "args": [10, 90]},
+		{"method": "closePath"},
+		{"method": "fill"},
+		{"property": "strokeStyle", "value": "lime"},
+		{"property": "lineWidth", "value": 6},
+		{"method": "stroke"}
+	]`
+
+	dc := gg.NewContext(100, 100)
+	if err := Replay([]byte(trace), dc); err != nil {
+		t.Fatalf("Replay failed: %v", err)
+	}
+
+	// Interior filled blue; fill() must not have consumed the path,
+	// so the stroke() afterwards paints the green border.
+	if _, _, b, _ := pixelAt(t, dc, 50, 50); b < 200 {
+		t.Errorf("interior: expected blue, got B=%d", b)
+	}
+	if _, g, _, _ := pixelAt(t, dc, 50, 10); g < 200 {
+		t.Errorf("border: expected green stroke, got G=%d", g)
+	}
+}
+
+func TestReplaySaveRestoreTransform(t *testing.T) {
+	trace := `[
+		{"method": "save"},
+		{"method": "translate", "args": [40, 40]},
+		{"property": "fillStyle", "value": "red"},
+		{"method": "fillRect", "args": [0, 0, 20, 20]},
+		{"method": "restore"},
+		{"property": "fillStyle", "value": "blue"},
+		{"method": "fillRect", "args": [0, 0, 20, 20]}
+	]`
+
+	dc := gg.NewContext(100, 100)
+	if err := Replay([]byte(trace), dc); err != nil {
+		t.Fatalf("Replay failed: %v", err)
+	}
+
+	if r, _, _, _ := pixelAt(t, dc, 50, 50); r < 200 {
+		t.Errorf("translated rect: expected red at (50,50), got R=%d", r)
+	}
+	if _, _, b, _ := pixelAt(t, dc, 10, 10); b < 200 {
+		t.Errorf("untranslated rect: expected blue at (10,10), got B=%d", b)
+	}
+}
+
+func TestReplayArcAndClip(t *testing.T) {
+	// Clip to a circle, then flood-fill: only the disc is painted.
+	trace := `[
+		{"method": "beginPath"},
+		{"method": "arc", "args": [50, 50, 25, 0, 6.2831853, false]},
+		{"method": "clip"},
+		{"property": "fillStyle", "value": "#00f"},
+		{"method": "fillRect", "args": [0, 0, 100, 100]}
+	]`
+
+	dc := gg.NewContext(100, 100)
+	if err := Replay([]byte(trace), dc); err != nil {
+		t.Fatalf("Replay failed: %v", err)
+	}
+
+	if _, _, b, _ := pixelAt(t, dc, 50, 50); b < 200 {
+		t.Errorf("disc center: expected blue, got B=%d", b)
+	}
+	if _, _, _, a := pixelAt(t, dc, 10, 10); a != 0 {
+		t.Errorf("outside disc: expected clipped, got A=%d", a)
+	}
+}
+
+func TestReplayBackendDump(t *testing.T) {
+	// Round-trip: record a drawing, dump it with the FormatJSON backend,
+	// replay the dump, and check the result matches the recording.
+	rec := recording.NewRecorder(100, 100)
+	rec.SetRGB(1, 0, 0)
+	rec.DrawRectangle(10, 10, 50, 50)
+	rec.Fill()
+	rec.SetRGB(0, 0, 1)
+	rec.SetLineWidth(4)
+	rec.MoveTo(10, 80)
+	rec.LineTo(90, 80)
+	rec.Stroke()
+	r := rec.FinishRecording()
+
+	b := NewBackend()
+	b.SetFormat(FormatJSON)
+	if err := r.Playback(b); err != nil {
+		t.Fatalf("Playback failed: %v", err)
+	}
+
+	dc := gg.NewContext(100, 100)
+	if err := Replay(b.Bytes(), dc); err != nil {
+		t.Fatalf("Replay failed: %v", err)
+	}
+
+	if pr, _, _, _ := pixelAt(t, dc, 30, 30); pr < 200 {
+		t.Errorf("filled rect: expected red, got R=%d", pr)
+	}
+	if _, _, pb, _ := pixelAt(t, dc, 50, 80); pb < 200 {
+		t.Errorf("stroked line: expected blue, got B=%d", pb)
+	}
+	if _, _, _, pa := pixelAt(t, dc, 90, 10); pa != 0 {
+		t.Errorf("background: expected transparent, got A=%d", pa)
+	}
+}
+
+func TestReplayBackendDumpGradient(t *testing.T) {
+	rec := recording.NewRecorder(100, 100)
+	grad := recording.NewLinearGradientBrush(0, 0, 100, 0)
+	grad.AddColorStop(0, gg.Red)
+	grad.AddColorStop(1, gg.Blue)
+	rec.SetFillStyle(grad)
+	rec.DrawRectangle(0, 0, 100, 100)
+	rec.Fill()
+	r := rec.FinishRecording()
+
+	b := NewBackend()
+	b.SetFormat(FormatJSON)
+	if err := r.Playback(b); err != nil {
+		t.Fatalf("Playback failed: %v", err)
+	}
+
+	dc := gg.NewContext(100, 100)
+	if err := Replay(b.Bytes(), dc); err != nil {
+		t.Fatalf("Replay failed: %v", err)
+	}
+
+	lr, _, lb, _ := pixelAt(t, dc, 5, 50)
+	rr, _, rb, _ := pixelAt(t, dc, 95, 50)
+	if lr < 200 || lb > 100 {
+		t.Errorf("gradient start: expected red, got R=%d B=%d", lr, lb)
+	}
+	if rb < 200 || rr > 100 {
+		t.Errorf("gradient end: expected blue, got R=%d B=%d", rr, rb)
+	}
+}
+
+func TestReplayUnknownEntriesIgnored(t *testing.T) {
+	trace := `[
+		{"method": "bogusOp", "args": [1, 2, 3]},
+		{"property": "imageSmoothingEnabled", "value": true},
+		{"op": "notARealOp", "args": {"x": 1}},
+		{"property": "fillStyle", "value": "red"},
+		{"method": "fillRect", "args": [0, 0, 100, 100]}
+	]`
+
+	dc := gg.NewContext(100, 100)
+	if err := Replay([]byte(trace), dc); err != nil {
+		t.Fatalf("Replay failed: %v", err)
+	}
+	if r, _, _, _ := pixelAt(t, dc, 50, 50); r < 200 {
+		t.Errorf("entries after unknown ops not replayed, got R=%d", r)
+	}
+}
+
+func TestReplayErrors(t *testing.T) {
+	dc := gg.NewContext(10, 10)
+	if err := Replay([]byte("not json"), dc); err == nil {
+		t.Error("expected error for malformed trace")
+	}
+	if err := Replay([]byte("[]"), nil); err == nil {
+		t.Error("expected error for nil context")
+	}
+}
+
+func TestParseCSSColor(t *testing.T) {
+	tests := []struct {
+		in   string
+		want gg.RGBA
+		ok   bool
+	}{
+		{"#ff0000", gg.RGBA{R: 1, A: 1}, true},
+		{"#00f", gg.RGBA{B: 1, A: 1}, true},
+		{"rgb(0, 255, 0)", gg.RGBA{G: 1, A: 1}, true},
+		{"rgba(255, 0, 0, 0.5)", gg.RGBA{R: 1, A: 0.5}, true},
+		{"Navy", gg.RGBA{B: 0.5, A: 1}, true},
+		{"transparent", gg.RGBA{}, true},
+		{"url(#grad)", gg.RGBA{}, false},
+		{"", gg.RGBA{}, false},
+	}
+	for _, tt := range tests {
+		c, gotOK := parseCSSColor(tt.in)
+		if gotOK != tt.ok {
+			t.Errorf("parseCSSColor(%q): ok=%v, want %v", tt.in, gotOK, tt.ok)
+			continue
+		}
+		if gotOK && c != tt.want {
+			t.Errorf("parseCSSColor(%q) = %+v, want %+v", tt.in, c, tt.want)
+		}
+	}
+}
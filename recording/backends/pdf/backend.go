@@ -0,0 +1,987 @@
+// Package pdf provides a PDF vector backend for the recording system.
+// It translates recorded drawing commands into a single-page PDF document
+// with true vector paths, so output stays resolution-independent.
+//
+// # Supported Features
+//
+//   - Solid color fills and strokes (RGB with alpha via ExtGState)
+//   - Linear and radial gradients (axial/radial shadings with stitching functions)
+//   - Path clipping (non-zero and even-odd)
+//   - Stroke styling (width, cap, join, miter limit, dash patterns)
+//   - Image embedding with Flate (lossless) or DCT (JPEG) compression,
+//     including soft masks for images with alpha
+//   - Text with embedded fonts, subset to the glyphs the document uses
+//     (TrueType via CIDFontType2/Identity-H with a ToUnicode CMap)
+//   - State management (Save/Restore mapped to q/Q)
+//
+// # Limitations
+//
+// Sweep (conic) gradients have no native PDF shading type below PDF 2.0;
+// they are approximated by the average of their color stops. Font
+// embedding requires TrueType (glyf) outlines; faces with CFF outlines,
+// collections, or no retrievable source bytes fall back to the standard
+// 14 fonts matched from the recorded font family.
+//
+// # Example
+//
+//	// Import to register the backend
+//	import _ "github.com/gogpu/gg/recording/backends/pdf"
+//
+//	// Create via registry
+//	backend, _ := recording.NewBackend("pdf")
+//
+//	// Playback recording
+//	rec.Playback(backend)
+//
+//	// Get output
+//	backend.(*pdf.Backend).SaveToFile("output.pdf")
+package pdf
+
+import (
+	"bytes"
+	"compress/zlib"
+	"fmt"
+	"image"
+	"image/jpeg"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/gogpu/gg"
+	"github.com/gogpu/gg/recording"
+	"github.com/gogpu/gg/text"
+)
+
+func init() {
+	recording.Register("pdf", func() recording.Backend {
+		return NewBackend()
+	})
+}
+
+// ImageEncoding selects how DrawImage embeds pixel data in the PDF.
+type ImageEncoding uint8
+
+const (
+	// EncodeFlate embeds images losslessly with Flate (zlib) compression.
+	EncodeFlate ImageEncoding = iota
+	// EncodeDCT embeds opaque images as JPEG (DCTDecode). Images with
+	// alpha always use Flate for the color data with a Flate soft mask.
+	EncodeDCT
+)
+
+// Backend renders recordings to a single-page PDF document.
+// It implements recording.Backend, recording.WriterBackend,
+// and recording.FileBackend interfaces.
+type Backend struct {
+	width  int
+	height int
+
+	content bytes.Buffer // page content stream
+	objects [][]byte     // indirect objects, index i is object number i+1
+
+	// Resource dictionaries accumulated while translating commands.
+	extGStates map[string]string // name -> dict body
+	shadings   map[string]string // name -> dict body
+	xobjects   map[string]int    // name -> object number
+	fonts      map[string]string // resource name -> base font name
+
+	// Per-font subset tracking: base font name -> set of used runes.
+	// Covers the standard-font fallback path; embedded fonts track
+	// glyph usage in embedded below.
+	subsets map[string]map[rune]bool
+
+	// Embedded, subsetted fonts keyed by face source. A nil entry caches
+	// that the source cannot be embedded (no TrueType data).
+	embedded   map[*text.FontSource]*embeddedFont
+	embedOrder []*embeddedFont
+
+	// clipDepth counts q operators emitted for clips in the current state,
+	// so ClearClip and Restore can balance them with Q.
+	clipDepth int
+	clipStack []int
+	imageEnc  ImageEncoding
+	jpegQual  int
+	nextRes   int
+	finished  bool
+	output    []byte
+}
+
+// Ensure Backend implements all required interfaces.
+var (
+	_ recording.Backend       = (*Backend)(nil)
+	_ recording.WriterBackend = (*Backend)(nil)
+	_ recording.FileBackend   = (*Backend)(nil)
+)
+
+// NewBackend creates a new PDF backend.
+// The backend must be initialized with Begin before use.
+func NewBackend() *Backend {
+	return &Backend{
+		imageEnc: EncodeFlate,
+		jpegQual: 90,
+	}
+}
+
+// SetImageEncoding selects the compression used for embedded images.
+// The default is EncodeFlate (lossless).
+func (b *Backend) SetImageEncoding(enc ImageEncoding) {
+	b.imageEnc = enc
+}
+
+// SetJPEGQuality sets the quality (1-100) used when images are embedded
+// with EncodeDCT. The default is 90.
+func (b *Backend) SetJPEGQuality(quality int) {
+	if quality < 1 {
+		quality = 1
+	}
+	if quality > 100 {
+		quality = 100
+	}
+	b.jpegQual = quality
+}
+
+// Begin initializes the backend for rendering at the given dimensions.
+// Dimensions are in PDF points (1/72 inch), one user-space unit per pixel.
+func (b *Backend) Begin(width, height int) error {
+	if width <= 0 || height <= 0 {
+		return fmt.Errorf("pdf: invalid dimensions %dx%d", width, height)
+	}
+	b.width = width
+	b.height = height
+	b.content.Reset()
+	b.objects = nil
+	b.extGStates = make(map[string]string)
+	b.shadings = make(map[string]string)
+	b.xobjects = make(map[string]int)
+	b.fonts = make(map[string]string)
+	b.subsets = make(map[string]map[rune]bool)
+	b.embedded = make(map[*text.FontSource]*embeddedFont)
+	b.embedOrder = nil
+	b.clipDepth = 0
+	b.clipStack = b.clipStack[:0]
+	b.nextRes = 0
+	b.finished = false
+	b.output = nil
+
+	// PDF origin is bottom-left with Y up; recordings use top-left with
+	// Y down. Flip the page once so all recorded coordinates map directly.
+	fmt.Fprintf(&b.content, "1 0 0 -1 0 %d cm\n", height)
+	return nil
+}
+
+// End finalizes the document. After End, WriteTo and SaveToFile can be used.
+func (b *Backend) End() error {
+	if b.finished {
+		return nil
+	}
+	// Balance any clip saves left open.
+	for b.clipDepth > 0 {
+		b.content.WriteString("Q\n")
+		b.clipDepth--
+	}
+	b.output = b.assemble()
+	b.finished = true
+	return nil
+}
+
+// Save saves the current graphics state (q operator).
+func (b *Backend) Save() {
+	b.content.WriteString("q\n")
+	b.clipStack = append(b.clipStack, b.clipDepth)
+	b.clipDepth = 0
+}
+
+// Restore restores the previously saved graphics state (Q operator).
+func (b *Backend) Restore() {
+	if len(b.clipStack) == 0 {
+		return
+	}
+	// Close clip saves opened since the matching Save.
+	for b.clipDepth > 0 {
+		b.content.WriteString("Q\n")
+		b.clipDepth--
+	}
+	b.content.WriteString("Q\n")
+	b.clipDepth = b.clipStack[len(b.clipStack)-1]
+	b.clipStack = b.clipStack[:len(b.clipStack)-1]
+}
+
+// SetTransform is a no-op for geometry: recorded path coordinates are
+// already transformed to world space by the Recorder.
+func (b *Backend) SetTransform(_ recording.Matrix) {}
+
+// SetClip intersects the clipping region with the given path.
+// PDF has no operator to widen a clip, so the clip is wrapped in a saved
+// state (q) that ClearClip and Restore balance with Q.
+func (b *Backend) SetClip(path *gg.Path, rule recording.FillRule) {
+	if path == nil {
+		return
+	}
+	b.content.WriteString("q\n")
+	b.clipDepth++
+	b.writePath(path)
+	if rule == recording.FillRuleEvenOdd {
+		b.content.WriteString("W* n\n")
+	} else {
+		b.content.WriteString("W n\n")
+	}
+}
+
+// ClearClip removes clips set since the last Save.
+func (b *Backend) ClearClip() {
+	for b.clipDepth > 0 {
+		b.content.WriteString("Q\n")
+		b.clipDepth--
+	}
+}
+
+// FillPath fills the given path with the brush.
+func (b *Backend) FillPath(path *gg.Path, brush recording.Brush, rule recording.FillRule) {
+	if path == nil {
+		return
+	}
+	if b.fillWithShading(path, brush, rule) {
+		return
+	}
+	b.setFillBrush(brush)
+	b.writePath(path)
+	if rule == recording.FillRuleEvenOdd {
+		b.content.WriteString("f*\n")
+	} else {
+		b.content.WriteString("f\n")
+	}
+}
+
+// StrokePath strokes the given path with the brush and stroke style.
+func (b *Backend) StrokePath(path *gg.Path, brush recording.Brush, stroke recording.Stroke) {
+	if path == nil {
+		return
+	}
+	b.content.WriteString("q\n")
+	b.setStrokeBrush(brush)
+	b.writeStroke(stroke)
+	b.writePath(path)
+	b.content.WriteString("S\nQ\n")
+}
+
+// FillRect fills an axis-aligned rectangle using the re operator.
+func (b *Backend) FillRect(rect recording.Rect, brush recording.Brush) {
+	if b.fillRectWithShading(rect, brush) {
+		return
+	}
+	b.setFillBrush(brush)
+	fmt.Fprintf(&b.content, "%s %s %s %s re f\n",
+		num(rect.MinX), num(rect.MinY), num(rect.Width()), num(rect.Height()))
+}
+
+// DrawImage embeds the image as an XObject and paints it into dst.
+// The src rectangle selects the image region to draw; a zero rect uses
+// the entire image.
+func (b *Backend) DrawImage(img image.Image, src, dst recording.Rect, opts recording.ImageOptions) {
+	if img == nil || dst.IsEmpty() {
+		return
+	}
+	bounds := img.Bounds()
+	srcW, srcH := src.Width(), src.Height()
+	if srcW == 0 || srcH == 0 {
+		src = recording.NewRect(0, 0, float64(bounds.Dx()), float64(bounds.Dy()))
+		srcW, srcH = src.Width(), src.Height()
+	}
+
+	name := b.embedImage(img, opts)
+	if name == "" {
+		return
+	}
+
+	b.content.WriteString("q\n")
+	if opts.Alpha > 0 && opts.Alpha < 1 {
+		gs := b.extGState(fmt.Sprintf("<< /ca %s /CA %s >>", num(opts.Alpha), num(opts.Alpha)))
+		fmt.Fprintf(&b.content, "/%s gs\n", gs)
+	}
+	// Clip to the destination so a partial source rect does not bleed.
+	fmt.Fprintf(&b.content, "%s %s %s %s re W n\n",
+		num(dst.MinX), num(dst.MinY), num(dst.Width()), num(dst.Height()))
+
+	// Image space is a unit square; scale so that the source rect maps
+	// onto the destination rect, then flip Y back (images are top-down).
+	scaleX := dst.Width() / srcW * float64(bounds.Dx())
+	scaleY := dst.Height() / srcH * float64(bounds.Dy())
+	tx := dst.MinX - src.MinX*dst.Width()/srcW
+	ty := dst.MinY - src.MinY*dst.Height()/srcH
+	fmt.Fprintf(&b.content, "%s 0 0 %s %s %s cm\n",
+		num(scaleX), num(-scaleY), num(tx), num(ty+scaleY))
+	fmt.Fprintf(&b.content, "/%s Do\nQ\n", name)
+}
+
+// DrawText draws text at the baseline origin (x, y). When the face
+// carries TrueType font data, the text is shaped to glyph IDs and drawn
+// through an embedded font that is subset to the used glyphs at End.
+// Faces without embeddable data fall back to a standard 14 font matched
+// from the recorded font family.
+func (b *Backend) DrawText(s string, x, y float64, face text.Face, brush recording.Brush) {
+	if s == "" {
+		return
+	}
+	if ef := b.embeddedFor(face); ef != nil {
+		b.drawTextEmbedded(ef, face, s, x, y, brush)
+		return
+	}
+	b.drawTextSized(s, x, y, 12, "", brush)
+}
+
+// DrawTextSized draws text with an explicit font size and family. The
+// recording Playback path calls DrawText without size information, so
+// callers replaying commands directly can use this to keep sizes.
+func (b *Backend) DrawTextSized(s string, x, y, size float64, family string, brush recording.Brush) {
+	if s == "" {
+		return
+	}
+	b.drawTextSized(s, x, y, size, family, brush)
+}
+
+func (b *Backend) drawTextSized(s string, x, y, size float64, family string, brush recording.Brush) {
+	if size <= 0 {
+		size = 12
+	}
+	base := standardFont(family)
+	res := b.fontResource(base)
+
+	// Track the subset of runes used per base font.
+	set := b.subsets[base]
+	if set == nil {
+		set = make(map[rune]bool)
+		b.subsets[base] = set
+	}
+	for _, r := range s {
+		set[r] = true
+	}
+
+	b.setFillBrush(brush)
+	// Text would be mirrored by the page flip; undo it locally with the
+	// text matrix.
+	b.content.WriteString("BT\n")
+	fmt.Fprintf(&b.content, "/%s %s Tf\n", res, num(size))
+	fmt.Fprintf(&b.content, "1 0 0 -1 %s %s Tm\n", num(x), num(y))
+	fmt.Fprintf(&b.content, "(%s) Tj\n", escapeString(s))
+	b.content.WriteString("ET\n")
+}
+
+// UsedRunes returns the sorted runes drawn with the given base font name
+// (e.g. "Helvetica"). This is the subset that a font-embedding exporter
+// would need to retain.
+func (b *Backend) UsedRunes(baseFont string) []rune {
+	set := b.subsets[baseFont]
+	if len(set) == 0 {
+		return nil
+	}
+	runes := make([]rune, 0, len(set))
+	for r := range set {
+		runes = append(runes, r)
+	}
+	sort.Slice(runes, func(i, j int) bool { return runes[i] < runes[j] })
+	return runes
+}
+
+// WriteTo writes the finished PDF to the given writer.
+func (b *Backend) WriteTo(w io.Writer) (int64, error) {
+	if !b.finished {
+		return 0, fmt.Errorf("pdf: WriteTo called before End")
+	}
+	n, err := w.Write(b.output)
+	return int64(n), err
+}
+
+// SaveToFile saves the finished PDF to a file at the given path.
+func (b *Backend) SaveToFile(path string) error {
+	if !b.finished {
+		return fmt.Errorf("pdf: SaveToFile called before End")
+	}
+	return os.WriteFile(path, b.output, 0o644)
+}
+
+// Bytes returns the finished PDF document, or nil before End.
+func (b *Backend) Bytes() []byte {
+	return b.output
+}
+
+// --------------------------------------------------------------------------
+// Brushes
+// --------------------------------------------------------------------------
+
+// setFillBrush emits non-stroking color operators for the brush.
+func (b *Backend) setFillBrush(brush recording.Brush) {
+	c := brushColor(brush)
+	fmt.Fprintf(&b.content, "%s %s %s rg\n", num(c.R), num(c.G), num(c.B))
+	b.setAlpha(c.A, false)
+}
+
+// setStrokeBrush emits stroking color operators for the brush.
+func (b *Backend) setStrokeBrush(brush recording.Brush) {
+	c := brushColor(brush)
+	fmt.Fprintf(&b.content, "%s %s %s RG\n", num(c.R), num(c.G), num(c.B))
+	b.setAlpha(c.A, true)
+}
+
+// setAlpha emits an ExtGState when alpha is not fully opaque.
+func (b *Backend) setAlpha(alpha float64, stroking bool) {
+	if alpha >= 1 {
+		return
+	}
+	if alpha < 0 {
+		alpha = 0
+	}
+	key := "/ca"
+	if stroking {
+		key = "/CA"
+	}
+	gs := b.extGState(fmt.Sprintf("<< %s %s >>", key, num(alpha)))
+	fmt.Fprintf(&b.content, "/%s gs\n", gs)
+}
+
+// brushColor resolves a representative solid color for a brush. Gradient
+// brushes are handled by shadings before this is reached; this fallback
+// averages the stops (used for sweep gradients and unknown brushes).
+func brushColor(brush recording.Brush) gg.RGBA {
+	switch br := brush.(type) {
+	case recording.SolidBrush:
+		return br.Color
+	case *recording.LinearGradientBrush:
+		return averageStops(br.Stops)
+	case *recording.RadialGradientBrush:
+		return averageStops(br.Stops)
+	case *recording.SweepGradientBrush:
+		return averageStops(br.Stops)
+	default:
+		return gg.Black
+	}
+}
+
+// averageStops returns the mean color of the gradient stops.
+func averageStops(stops []recording.GradientStop) gg.RGBA {
+	if len(stops) == 0 {
+		return gg.Black
+	}
+	var c gg.RGBA
+	for _, s := range stops {
+		c.R += s.Color.R
+		c.G += s.Color.G
+		c.B += s.Color.B
+		c.A += s.Color.A
+	}
+	n := float64(len(stops))
+	return gg.RGBA{R: c.R / n, G: c.G / n, B: c.B / n, A: c.A / n}
+}
+
+// --------------------------------------------------------------------------
+// Shadings (gradients)
+// --------------------------------------------------------------------------
+
+// fillWithShading fills the path with an axial or radial shading if the
+// brush is a linear or radial gradient. Returns false if the brush has no
+// native shading representation.
+func (b *Backend) fillWithShading(path *gg.Path, brush recording.Brush, rule recording.FillRule) bool {
+	name, ok := b.shadingFor(brush)
+	if !ok {
+		return false
+	}
+	b.content.WriteString("q\n")
+	b.writePath(path)
+	if rule == recording.FillRuleEvenOdd {
+		b.content.WriteString("W* n\n")
+	} else {
+		b.content.WriteString("W n\n")
+	}
+	fmt.Fprintf(&b.content, "/%s sh\nQ\n", name)
+	return true
+}
+
+// fillRectWithShading fills a rectangle with a shading if possible.
+func (b *Backend) fillRectWithShading(rect recording.Rect, brush recording.Brush) bool {
+	name, ok := b.shadingFor(brush)
+	if !ok {
+		return false
+	}
+	b.content.WriteString("q\n")
+	fmt.Fprintf(&b.content, "%s %s %s %s re W n\n",
+		num(rect.MinX), num(rect.MinY), num(rect.Width()), num(rect.Height()))
+	fmt.Fprintf(&b.content, "/%s sh\nQ\n", name)
+	return true
+}
+
+// shadingFor builds a shading dictionary for a gradient brush and returns
+// its resource name. Sweep gradients return false (no PDF shading type).
+func (b *Backend) shadingFor(brush recording.Brush) (string, bool) {
+	switch g := brush.(type) {
+	case *recording.LinearGradientBrush:
+		if len(g.Stops) < 2 {
+			return "", false
+		}
+		fn := stopsFunction(g.Stops)
+		dict := fmt.Sprintf(
+			"<< /ShadingType 2 /ColorSpace /DeviceRGB /Coords [%s %s %s %s] /Function %s /Extend [%s] >>",
+			num(g.Start.X), num(g.Start.Y), num(g.End.X), num(g.End.Y),
+			fn, extendPair(g.Extend))
+		return b.shading(dict), true
+	case *recording.RadialGradientBrush:
+		if len(g.Stops) < 2 {
+			return "", false
+		}
+		fn := stopsFunction(g.Stops)
+		dict := fmt.Sprintf(
+			"<< /ShadingType 3 /ColorSpace /DeviceRGB /Coords [%s %s %s %s %s %s] /Function %s /Extend [%s] >>",
+			num(g.Focus.X), num(g.Focus.Y), num(g.StartRadius),
+			num(g.Center.X), num(g.Center.Y), num(g.EndRadius),
+			fn, extendPair(g.Extend))
+		return b.shading(dict), true
+	default:
+		return "", false
+	}
+}
+
+// stopsFunction builds a PDF function for gradient stops: a single type 2
+// exponential function for two stops, or a type 3 stitching function for
+// more.
+func stopsFunction(stops []recording.GradientStop) string {
+	if len(stops) == 2 {
+		return expFunction(stops[0].Color, stops[1].Color)
+	}
+	var funcs, bounds, encode strings.Builder
+	for i := 0; i < len(stops)-1; i++ {
+		funcs.WriteString(expFunction(stops[i].Color, stops[i+1].Color))
+		funcs.WriteByte(' ')
+		if i > 0 {
+			fmt.Fprintf(&bounds, "%s ", num(stops[i].Offset))
+		}
+		encode.WriteString("0 1 ")
+	}
+	return fmt.Sprintf(
+		"<< /FunctionType 3 /Domain [0 1] /Functions [%s] /Bounds [%s] /Encode [%s] >>",
+		strings.TrimSpace(funcs.String()),
+		strings.TrimSpace(bounds.String()),
+		strings.TrimSpace(encode.String()))
+}
+
+// expFunction builds a type 2 exponential interpolation function between
+// two colors.
+func expFunction(c0, c1 gg.RGBA) string {
+	return fmt.Sprintf(
+		"<< /FunctionType 2 /Domain [0 1] /C0 [%s %s %s] /C1 [%s %s %s] /N 1 >>",
+		num(c0.R), num(c0.G), num(c0.B), num(c1.R), num(c1.G), num(c1.B))
+}
+
+// extendPair maps an ExtendMode to the shading /Extend array contents.
+// Repeat and reflect have no shading equivalent and pad instead.
+func extendPair(mode recording.ExtendMode) string {
+	_ = mode
+	return "true true"
+}
+
+// --------------------------------------------------------------------------
+// Paths and stroke state
+// --------------------------------------------------------------------------
+
+// writePath emits path construction operators for the path.
+// Quadratic curves are elevated to cubics (PDF has no quad operator).
+func (b *Backend) writePath(path *gg.Path) {
+	var curX, curY float64
+	path.Iterate(func(verb gg.PathVerb, coords []float64) {
+		switch verb {
+		case gg.MoveTo:
+			curX, curY = coords[0], coords[1]
+			fmt.Fprintf(&b.content, "%s %s m\n", num(curX), num(curY))
+		case gg.LineTo:
+			curX, curY = coords[0], coords[1]
+			fmt.Fprintf(&b.content, "%s %s l\n", num(curX), num(curY))
+		case gg.QuadTo:
+			// Degree elevation: c1 = p0 + 2/3*(q - p0), c2 = p + 2/3*(q - p).
+			qx, qy := coords[0], coords[1]
+			px, py := coords[2], coords[3]
+			c1x := curX + 2.0/3.0*(qx-curX)
+			c1y := curY + 2.0/3.0*(qy-curY)
+			c2x := px + 2.0/3.0*(qx-px)
+			c2y := py + 2.0/3.0*(qy-py)
+			fmt.Fprintf(&b.content, "%s %s %s %s %s %s c\n",
+				num(c1x), num(c1y), num(c2x), num(c2y), num(px), num(py))
+			curX, curY = px, py
+		case gg.CubicTo:
+			fmt.Fprintf(&b.content, "%s %s %s %s %s %s c\n",
+				num(coords[0]), num(coords[1]), num(coords[2]), num(coords[3]),
+				num(coords[4]), num(coords[5]))
+			curX, curY = coords[4], coords[5]
+		case gg.Close:
+			b.content.WriteString("h\n")
+		}
+	})
+}
+
+// writeStroke emits stroke state operators (width, cap, join, miter, dash).
+func (b *Backend) writeStroke(stroke recording.Stroke) {
+	fmt.Fprintf(&b.content, "%s w\n", num(stroke.Width))
+	fmt.Fprintf(&b.content, "%d J\n", pdfLineCap(stroke.Cap))
+	fmt.Fprintf(&b.content, "%d j\n", pdfLineJoin(stroke.Join))
+	if stroke.MiterLimit > 0 {
+		fmt.Fprintf(&b.content, "%s M\n", num(stroke.MiterLimit))
+	}
+	if len(stroke.DashPattern) > 0 {
+		var pat strings.Builder
+		for i, d := range stroke.DashPattern {
+			if i > 0 {
+				pat.WriteByte(' ')
+			}
+			pat.WriteString(num(d))
+		}
+		fmt.Fprintf(&b.content, "[%s] %s d\n", pat.String(), num(stroke.DashOffset))
+	} else {
+		b.content.WriteString("[] 0 d\n")
+	}
+}
+
+// pdfLineCap maps a recording.LineCap to the PDF line cap style.
+func pdfLineCap(lineCap recording.LineCap) int {
+	switch lineCap {
+	case recording.LineCapRound:
+		return 1
+	case recording.LineCapSquare:
+		return 2
+	default:
+		return 0
+	}
+}
+
+// pdfLineJoin maps a recording.LineJoin to the PDF line join style.
+func pdfLineJoin(join recording.LineJoin) int {
+	switch join {
+	case recording.LineJoinRound:
+		return 1
+	case recording.LineJoinBevel:
+		return 2
+	default:
+		// PDF has no miter-clip or arcs joins; miter is the closest match.
+		return 0
+	}
+}
+
+// --------------------------------------------------------------------------
+// Images
+// --------------------------------------------------------------------------
+
+// embedImage writes the image as an image XObject and returns its
+// resource name. Returns "" if the image is empty.
+func (b *Backend) embedImage(img image.Image, _ recording.ImageOptions) string {
+	bounds := img.Bounds()
+	w, h := bounds.Dx(), bounds.Dy()
+	if w == 0 || h == 0 {
+		return ""
+	}
+
+	rgb := make([]byte, 0, w*h*3)
+	alpha := make([]byte, 0, w*h)
+	hasAlpha := false
+	for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+		for x := bounds.Min.X; x < bounds.Max.X; x++ {
+			r, g, bl, a := img.At(x, y).RGBA()
+			if a > 0 && a < 0xffff {
+				// Un-premultiply to straight RGB for the color stream.
+				r = r * 0xffff / a
+				g = g * 0xffff / a
+				bl = bl * 0xffff / a
+			}
+			rgb = append(rgb, byte(r>>8), byte(g>>8), byte(bl>>8))
+			alpha = append(alpha, byte(a>>8))
+			if a != 0xffff {
+				hasAlpha = true
+			}
+		}
+	}
+
+	var smaskRef string
+	if hasAlpha {
+		smaskObj := b.addStreamObject(fmt.Sprintf(
+			"<< /Type /XObject /Subtype /Image /Width %d /Height %d /ColorSpace /DeviceGray /BitsPerComponent 8 /Filter /FlateDecode", w, h),
+			flate(alpha))
+		smaskRef = fmt.Sprintf(" /SMask %d 0 R", smaskObj)
+	}
+
+	var filter string
+	var data []byte
+	if b.imageEnc == EncodeDCT && !hasAlpha {
+		var buf bytes.Buffer
+		if err := jpeg.Encode(&buf, img, &jpeg.Options{Quality: b.jpegQual}); err == nil {
+			filter = "/DCTDecode"
+			data = buf.Bytes()
+		}
+	}
+	if data == nil {
+		filter = "/FlateDecode"
+		data = flate(rgb)
+	}
+
+	obj := b.addStreamObject(fmt.Sprintf(
+		"<< /Type /XObject /Subtype /Image /Width %d /Height %d /ColorSpace /DeviceRGB /BitsPerComponent 8 /Filter %s%s",
+		w, h, filter, smaskRef), data)
+
+	name := fmt.Sprintf("Im%d", len(b.xobjects)+1)
+	b.xobjects[name] = obj
+	return name
+}
+
+// flate compresses data with zlib.
+func flate(data []byte) []byte {
+	var buf bytes.Buffer
+	zw := zlib.NewWriter(&buf)
+	_, _ = zw.Write(data)
+	_ = zw.Close()
+	return buf.Bytes()
+}
+
+// --------------------------------------------------------------------------
+// Fonts
+// --------------------------------------------------------------------------
+
+// standardFont maps a recorded font family onto one of the standard 14
+// PDF base fonts. Unknown families map to Helvetica.
+func standardFont(family string) string {
+	f := strings.ToLower(family)
+	bold := strings.Contains(f, "bold")
+	italic := strings.Contains(f, "italic") || strings.Contains(f, "oblique")
+	switch {
+	case strings.Contains(f, "courier") || strings.Contains(f, "mono"):
+		switch {
+		case bold && italic:
+			return "Courier-BoldOblique"
+		case bold:
+			return "Courier-Bold"
+		case italic:
+			return "Courier-Oblique"
+		default:
+			return "Courier"
+		}
+	case strings.Contains(f, "times") || strings.Contains(f, "serif") && !strings.Contains(f, "sans"):
+		switch {
+		case bold && italic:
+			return "Times-BoldItalic"
+		case bold:
+			return "Times-Bold"
+		case italic:
+			return "Times-Italic"
+		default:
+			return "Times-Roman"
+		}
+	default:
+		switch {
+		case bold && italic:
+			return "Helvetica-BoldOblique"
+		case bold:
+			return "Helvetica-Bold"
+		case italic:
+			return "Helvetica-Oblique"
+		default:
+			return "Helvetica"
+		}
+	}
+}
+
+// fontResource returns the resource name for a base font, creating the
+// font dictionary entry on first use.
+func (b *Backend) fontResource(baseFont string) string {
+	for name, base := range b.fonts {
+		if base == baseFont {
+			return name
+		}
+	}
+	name := fmt.Sprintf("F%d", len(b.fonts)+1)
+	b.fonts[name] = baseFont
+	return name
+}
+
+// escapeString escapes a string for a PDF literal string.
+func escapeString(s string) string {
+	var sb strings.Builder
+	for _, r := range s {
+		switch r {
+		case '(', ')', '\\':
+			sb.WriteByte('\\')
+			sb.WriteRune(r)
+		case '\n':
+			sb.WriteString("\\n")
+		case '\r':
+			sb.WriteString("\\r")
+		case '\t':
+			sb.WriteString("\\t")
+		default:
+			if r < 128 {
+				sb.WriteRune(r)
+			} else {
+				// Non-ASCII runes are not representable in WinAnsi
+				// without an encoding table; substitute.
+				sb.WriteByte('?')
+			}
+		}
+	}
+	return sb.String()
+}
+
+// --------------------------------------------------------------------------
+// Resources and document assembly
+// --------------------------------------------------------------------------
+
+// extGState registers an ExtGState dictionary and returns its name.
+func (b *Backend) extGState(dict string) string {
+	for name, d := range b.extGStates {
+		if d == dict {
+			return name
+		}
+	}
+	b.nextRes++
+	name := fmt.Sprintf("GS%d", b.nextRes)
+	b.extGStates[name] = dict
+	return name
+}
+
+// shading registers a shading dictionary and returns its name.
+func (b *Backend) shading(dict string) string {
+	for name, d := range b.shadings {
+		if d == dict {
+			return name
+		}
+	}
+	name := fmt.Sprintf("Sh%d", len(b.shadings)+1)
+	b.shadings[name] = dict
+	return name
+}
+
+// addObject appends a body as the next indirect object and returns its
+// object number.
+func (b *Backend) addObject(body string) int {
+	b.objects = append(b.objects, []byte(body))
+	return len(b.objects)
+}
+
+// addStreamObject appends a stream object with the given dictionary
+// prefix (without /Length or closing >>) and stream data.
+func (b *Backend) addStreamObject(dictPrefix string, data []byte) int {
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "%s /Length %d >>\nstream\n", dictPrefix, len(data))
+	buf.Write(data)
+	buf.WriteString("\nendstream")
+	b.objects = append(b.objects, buf.Bytes())
+	return len(b.objects)
+}
+
+// assemble builds the final PDF file from accumulated objects and content.
+func (b *Backend) assemble() []byte {
+	// Page content stream (compressed).
+	compressed := flate(b.content.Bytes())
+	contentObj := b.addStreamObject("<< /Filter /FlateDecode", compressed)
+
+	// Embedded fonts: subset each used font program and build its object
+	// chain. If subsetting fails the resource name is remapped onto a
+	// standard font so the content stream stays valid.
+	embeddedRefs := make(map[string]int, len(b.embedOrder))
+	for _, ef := range b.embedOrder {
+		if len(ef.used) == 0 {
+			continue
+		}
+		obj, err := b.buildEmbeddedFont(ef)
+		if err != nil {
+			b.fonts[ef.res] = "Helvetica"
+			continue
+		}
+		embeddedRefs[ef.res] = obj
+	}
+
+	// Resources dictionary.
+	var res strings.Builder
+	res.WriteString("<< ")
+	if len(b.extGStates) > 0 {
+		res.WriteString("/ExtGState << ")
+		for _, name := range sortedKeys(b.extGStates) {
+			fmt.Fprintf(&res, "/%s %s ", name, b.extGStates[name])
+		}
+		res.WriteString(">> ")
+	}
+	if len(b.shadings) > 0 {
+		res.WriteString("/Shading << ")
+		for _, name := range sortedKeys(b.shadings) {
+			fmt.Fprintf(&res, "/%s %s ", name, b.shadings[name])
+		}
+		res.WriteString(">> ")
+	}
+	if len(b.xobjects) > 0 {
+		res.WriteString("/XObject << ")
+		for _, name := range sortedKeysInt(b.xobjects) {
+			fmt.Fprintf(&res, "/%s %d 0 R ", name, b.xobjects[name])
+		}
+		res.WriteString(">> ")
+	}
+	if len(b.fonts) > 0 || len(embeddedRefs) > 0 {
+		res.WriteString("/Font << ")
+		for _, name := range sortedKeys(b.fonts) {
+			fmt.Fprintf(&res, "/%s << /Type /Font /Subtype /Type1 /BaseFont /%s /Encoding /WinAnsiEncoding >> ",
+				name, b.fonts[name])
+		}
+		for _, name := range sortedKeysInt(embeddedRefs) {
+			fmt.Fprintf(&res, "/%s %d 0 R ", name, embeddedRefs[name])
+		}
+		res.WriteString(">> ")
+	}
+	res.WriteString(">>")
+
+	pagesObj := len(b.objects) + 2 // page references pages; assigned below
+	pageObj := b.addObject(fmt.Sprintf(
+		"<< /Type /Page /Parent %d 0 R /MediaBox [0 0 %d %d] /Contents %d 0 R /Resources %s >>",
+		pagesObj, b.width, b.height, contentObj, res.String()))
+	_ = b.addObject(fmt.Sprintf("<< /Type /Pages /Kids [%d 0 R] /Count 1 >>", pageObj))
+	catalogObj := b.addObject(fmt.Sprintf("<< /Type /Catalog /Pages %d 0 R >>", pagesObj))
+
+	var out bytes.Buffer
+	out.WriteString("%PDF-1.4\n%\xe2\xe3\xcf\xd3\n")
+	offsets := make([]int, len(b.objects))
+	for i, obj := range b.objects {
+		offsets[i] = out.Len()
+		fmt.Fprintf(&out, "%d 0 obj\n", i+1)
+		out.Write(obj)
+		out.WriteString("\nendobj\n")
+	}
+	xrefStart := out.Len()
+	fmt.Fprintf(&out, "xref\n0 %d\n", len(b.objects)+1)
+	out.WriteString("0000000000 65535 f \n")
+	for _, off := range offsets {
+		fmt.Fprintf(&out, "%010d 00000 n \n", off)
+	}
+	fmt.Fprintf(&out, "trailer\n<< /Size %d /Root %d 0 R >>\nstartxref\n%d\n%%%%EOF\n",
+		len(b.objects)+1, catalogObj, xrefStart)
+	return out.Bytes()
+}
+
+// sortedKeys returns map keys sorted alphabetically for stable output.
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// sortedKeysInt returns map keys sorted alphabetically for stable output.
+func sortedKeysInt(m map[string]int) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// num formats a float compactly for PDF output (trailing zeros trimmed).
+func num(f float64) string {
+	s := fmt.Sprintf("%.4f", f)
+	s = strings.TrimRight(s, "0")
+	s = strings.TrimRight(s, ".")
+	if s == "" || s == "-" {
+		return "0"
+	}
+	return s
+}
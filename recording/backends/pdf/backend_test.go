@@ -0,0 +1,375 @@
+package pdf
+
+import (
+	"bytes"
+	"compress/zlib"
+	"image"
+	"image/color"
+	"io"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/gogpu/gg"
+	"github.com/gogpu/gg/recording"
+)
+
+// contentStream decompresses the page content stream from a finished backend.
+func contentStream(t *testing.T, b *Backend) string {
+	t.Helper()
+	data := b.Bytes()
+	if data == nil {
+		t.Fatal("Bytes returned nil; End not called?")
+	}
+	// The content stream is the first Flate stream whose decompressed
+	// data contains the page flip cm operator.
+	rest := data
+	for {
+		idx := bytes.Index(rest, []byte("stream\n"))
+		if idx < 0 {
+			t.Fatal("no content stream found in PDF")
+		}
+		rest = rest[idx+len("stream\n"):]
+		end := bytes.Index(rest, []byte("\nendstream"))
+		if end < 0 {
+			t.Fatal("unterminated stream in PDF")
+		}
+		zr, err := zlib.NewReader(bytes.NewReader(rest[:end]))
+		if err == nil {
+			decoded, err := io.ReadAll(zr)
+			if err == nil && bytes.Contains(decoded, []byte(" cm\n")) {
+				return string(decoded)
+			}
+		}
+		rest = rest[end:]
+	}
+}
+
+func TestRegistration(t *testing.T) {
+	if !recording.IsRegistered("pdf") {
+		t.Fatal("pdf backend not registered")
+	}
+	backend, err := recording.NewBackend("pdf")
+	if err != nil {
+		t.Fatalf("NewBackend failed: %v", err)
+	}
+	if _, ok := backend.(*Backend); !ok {
+		t.Errorf("expected *Backend, got %T", backend)
+	}
+}
+
+func TestBeginValidation(t *testing.T) {
+	b := NewBackend()
+	if err := b.Begin(0, 100); err == nil {
+		t.Error("expected error for zero width")
+	}
+	if err := b.Begin(100, -1); err == nil {
+		t.Error("expected error for negative height")
+	}
+	if err := b.Begin(100, 100); err != nil {
+		t.Errorf("Begin failed: %v", err)
+	}
+}
+
+func TestDocumentStructure(t *testing.T) {
+	b := NewBackend()
+	if err := b.Begin(200, 100); err != nil {
+		t.Fatalf("Begin failed: %v", err)
+	}
+	b.FillRect(recording.NewRect(10, 10, 50, 50), recording.NewSolidBrush(gg.Red))
+	if err := b.End(); err != nil {
+		t.Fatalf("End failed: %v", err)
+	}
+
+	out := string(b.Bytes())
+	for _, want := range []string{
+		"%PDF-1.4", "/Type /Catalog", "/Type /Pages", "/Type /Page",
+		"/MediaBox [0 0 200 100]", "xref", "startxref", "%%EOF",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("PDF missing %q", want)
+		}
+	}
+}
+
+func TestFillAndStrokeOperators(t *testing.T) {
+	rec := recording.NewRecorder(100, 100)
+	rec.SetRGB(1, 0, 0)
+	rec.DrawRectangle(10, 10, 40, 40)
+	rec.Fill()
+	rec.SetRGB(0, 0, 1)
+	rec.SetLineWidth(3)
+	rec.SetDash(4, 2)
+	rec.DrawLine(0, 0, 100, 100)
+	rec.Stroke()
+	r := rec.FinishRecording()
+
+	b := NewBackend()
+	if err := r.Playback(b); err != nil {
+		t.Fatalf("Playback failed: %v", err)
+	}
+
+	content := contentStream(t, b)
+	if !strings.Contains(content, "1 0 0 rg") {
+		t.Error("missing fill color operator")
+	}
+	if !strings.Contains(content, "0 0 1 RG") {
+		t.Error("missing stroke color operator")
+	}
+	if !strings.Contains(content, "3 w") {
+		t.Error("missing line width operator")
+	}
+	if !strings.Contains(content, "[4 2] 0 d") {
+		t.Error("missing dash pattern operator")
+	}
+	if !strings.Contains(content, "S\n") {
+		t.Error("missing stroke operator")
+	}
+}
+
+func TestLinearGradientShading(t *testing.T) {
+	rec := recording.NewRecorder(100, 100)
+	grad := recording.NewLinearGradientBrush(0, 0, 100, 0)
+	grad.AddColorStop(0, gg.Red)
+	grad.AddColorStop(0.5, gg.Green)
+	grad.AddColorStop(1, gg.Blue)
+	rec.SetFillStyle(grad)
+	rec.DrawRectangle(0, 0, 100, 100)
+	rec.Fill()
+	r := rec.FinishRecording()
+
+	b := NewBackend()
+	if err := r.Playback(b); err != nil {
+		t.Fatalf("Playback failed: %v", err)
+	}
+
+	out := string(b.Bytes())
+	if !strings.Contains(out, "/ShadingType 2") {
+		t.Error("missing axial shading dictionary")
+	}
+	if !strings.Contains(out, "/FunctionType 3") {
+		t.Error("expected stitching function for 3 stops")
+	}
+	content := contentStream(t, b)
+	if !strings.Contains(content, " sh") {
+		t.Error("missing sh operator in content stream")
+	}
+}
+
+func TestRadialGradientShading(t *testing.T) {
+	rec := recording.NewRecorder(100, 100)
+	grad := recording.NewRadialGradientBrush(50, 50, 0, 40)
+	grad.AddColorStop(0, gg.White)
+	grad.AddColorStop(1, gg.Black)
+	rec.SetFillStyle(grad)
+	rec.DrawCircle(50, 50, 40)
+	rec.Fill()
+	r := rec.FinishRecording()
+
+	b := NewBackend()
+	if err := r.Playback(b); err != nil {
+		t.Fatalf("Playback failed: %v", err)
+	}
+
+	if !strings.Contains(string(b.Bytes()), "/ShadingType 3") {
+		t.Error("missing radial shading dictionary")
+	}
+}
+
+func TestClipping(t *testing.T) {
+	rec := recording.NewRecorder(100, 100)
+	rec.DrawCircle(50, 50, 30)
+	rec.Clip()
+	rec.SetRGB(0, 1, 0)
+	rec.DrawRectangle(0, 0, 100, 100)
+	rec.Fill()
+	rec.ResetClip()
+	r := rec.FinishRecording()
+
+	b := NewBackend()
+	if err := r.Playback(b); err != nil {
+		t.Fatalf("Playback failed: %v", err)
+	}
+
+	content := contentStream(t, b)
+	if !strings.Contains(content, "W n") {
+		t.Error("missing clip operator")
+	}
+}
+
+func TestImageEmbeddingFlate(t *testing.T) {
+	img := image.NewRGBA(image.Rect(0, 0, 4, 4))
+	for i := range img.Pix {
+		img.Pix[i] = 0xff
+	}
+
+	rec := recording.NewRecorder(100, 100)
+	rec.DrawImage(img, 10, 10)
+	r := rec.FinishRecording()
+
+	b := NewBackend()
+	if err := r.Playback(b); err != nil {
+		t.Fatalf("Playback failed: %v", err)
+	}
+
+	out := string(b.Bytes())
+	if !strings.Contains(out, "/Subtype /Image") {
+		t.Error("missing image XObject")
+	}
+	if !strings.Contains(out, "/FlateDecode") {
+		t.Error("expected FlateDecode filter")
+	}
+}
+
+func TestImageEmbeddingDCT(t *testing.T) {
+	img := image.NewRGBA(image.Rect(0, 0, 8, 8))
+	for i := 0; i < len(img.Pix); i += 4 {
+		img.Pix[i] = 0x80
+		img.Pix[i+3] = 0xff
+	}
+
+	rec := recording.NewRecorder(100, 100)
+	rec.DrawImage(img, 0, 0)
+	r := rec.FinishRecording()
+
+	b := NewBackend()
+	b.SetImageEncoding(EncodeDCT)
+	if err := r.Playback(b); err != nil {
+		t.Fatalf("Playback failed: %v", err)
+	}
+
+	if !strings.Contains(string(b.Bytes()), "/DCTDecode") {
+		t.Error("expected DCTDecode filter for opaque image")
+	}
+}
+
+func TestImageAlphaSoftMask(t *testing.T) {
+	img := image.NewNRGBA(image.Rect(0, 0, 4, 4))
+	img.Set(0, 0, color.NRGBA{R: 255, A: 128})
+
+	rec := recording.NewRecorder(100, 100)
+	rec.DrawImage(img, 0, 0)
+	r := rec.FinishRecording()
+
+	b := NewBackend()
+	b.SetImageEncoding(EncodeDCT) // alpha must force Flate + SMask
+	if err := r.Playback(b); err != nil {
+		t.Fatalf("Playback failed: %v", err)
+	}
+
+	out := string(b.Bytes())
+	if !strings.Contains(out, "/SMask") {
+		t.Error("missing soft mask for image with alpha")
+	}
+	if strings.Contains(out, "/DCTDecode") {
+		t.Error("image with alpha must not use DCTDecode")
+	}
+}
+
+func TestDrawTextAndSubsetTracking(t *testing.T) {
+	rec := recording.NewRecorder(200, 100)
+	rec.SetFontFamily("Helvetica")
+	rec.SetFontSize(14)
+	rec.SetRGB(0, 0, 0)
+	rec.DrawString("Hello (PDF)", 10, 50)
+	r := rec.FinishRecording()
+
+	b := NewBackend()
+	if err := r.Playback(b); err != nil {
+		t.Fatalf("Playback failed: %v", err)
+	}
+
+	out := string(b.Bytes())
+	if !strings.Contains(out, "/BaseFont /Helvetica") {
+		t.Error("missing Helvetica font dictionary")
+	}
+	content := contentStream(t, b)
+	if !strings.Contains(content, `(Hello \(PDF\)) Tj`) {
+		t.Errorf("missing escaped Tj operator, content:\n%s", content)
+	}
+
+	runes := b.UsedRunes("Helvetica")
+	if len(runes) == 0 {
+		t.Fatal("no runes tracked for subset")
+	}
+	found := false
+	for _, r := range runes {
+		if r == 'H' {
+			found = true
+		}
+	}
+	if !found {
+		t.Error("subset does not contain 'H'")
+	}
+}
+
+func TestStandardFontMapping(t *testing.T) {
+	tests := []struct {
+		family string
+		want   string
+	}{
+		{"", "Helvetica"},
+		{"Arial Bold", "Helvetica-Bold"},
+		{"Times New Roman", "Times-Roman"},
+		{"Times Bold Italic", "Times-BoldItalic"},
+		{"Courier New", "Courier"},
+		{"Menlo Mono Oblique", "Courier-Oblique"},
+	}
+	for _, tt := range tests {
+		if got := standardFont(tt.family); got != tt.want {
+			t.Errorf("standardFont(%q) = %q, want %q", tt.family, got, tt.want)
+		}
+	}
+}
+
+func TestSaveToFile(t *testing.T) {
+	b := NewBackend()
+	if err := b.Begin(50, 50); err != nil {
+		t.Fatalf("Begin failed: %v", err)
+	}
+	b.FillRect(recording.NewRect(0, 0, 50, 50), recording.NewSolidBrush(gg.Blue))
+
+	// Output before End must fail.
+	if err := b.SaveToFile(filepath.Join(t.TempDir(), "early.pdf")); err == nil {
+		t.Error("expected error saving before End")
+	}
+
+	if err := b.End(); err != nil {
+		t.Fatalf("End failed: %v", err)
+	}
+	path := filepath.Join(t.TempDir(), "out.pdf")
+	if err := b.SaveToFile(path); err != nil {
+		t.Fatalf("SaveToFile failed: %v", err)
+	}
+
+	var buf bytes.Buffer
+	n, err := b.WriteTo(&buf)
+	if err != nil {
+		t.Fatalf("WriteTo failed: %v", err)
+	}
+	if n != int64(buf.Len()) {
+		t.Errorf("WriteTo returned %d, wrote %d bytes", n, buf.Len())
+	}
+}
+
+func TestSaveRestoreBalanced(t *testing.T) {
+	rec := recording.NewRecorder(100, 100)
+	rec.Save()
+	rec.DrawCircle(50, 50, 20)
+	rec.Clip()
+	rec.SetRGB(1, 0, 0)
+	rec.DrawRectangle(0, 0, 100, 100)
+	rec.Fill()
+	rec.Restore()
+	r := rec.FinishRecording()
+
+	b := NewBackend()
+	if err := r.Playback(b); err != nil {
+		t.Fatalf("Playback failed: %v", err)
+	}
+
+	content := contentStream(t, b)
+	if strings.Count(content, "q\n") != strings.Count(content, "Q\n") {
+		t.Errorf("unbalanced q/Q operators:\n%s", content)
+	}
+}
@@ -0,0 +1,221 @@
+package pdf
+
+import (
+	"fmt"
+	"hash/fnv"
+	"sort"
+	"strings"
+	"unicode/utf16"
+
+	"github.com/gogpu/gg/recording"
+	"github.com/gogpu/gg/text"
+)
+
+// This file implements embedded, subsetted TrueType fonts. Text drawn
+// with a face whose source carries TrueType data is emitted as glyph IDs
+// through a Type0/CIDFontType2 font with Identity-H encoding; at End the
+// font program is subset to the used glyphs (see subset.go) and embedded
+// as FontFile2. Faces without usable TrueType data (CFF outlines,
+// collections, no source bytes) fall back to the standard 14 fonts.
+
+// embeddedFont tracks one font source embedded in the document.
+type embeddedFont struct {
+	res    string // font resource name in the page dictionary
+	source *text.FontSource
+	// used maps each drawn glyph to a representative rune for the
+	// ToUnicode CMap; the key set is the subset to embed.
+	used map[text.GlyphID]rune
+}
+
+// embeddedFor returns the embedding state for the face's source, creating
+// it on first use. Returns nil when the face cannot be embedded, which is
+// cached so the TrueType check runs once per source.
+func (b *Backend) embeddedFor(face text.Face) *embeddedFont {
+	if face == nil {
+		return nil
+	}
+	src := face.Source()
+	if src == nil {
+		return nil
+	}
+	if ef, ok := b.embedded[src]; ok {
+		return ef
+	}
+	var ef *embeddedFont
+	if canSubset(src.Data()) {
+		ef = &embeddedFont{
+			res:    fmt.Sprintf("EF%d", len(b.embedOrder)+1),
+			source: src,
+			used:   make(map[text.GlyphID]rune),
+		}
+		b.embedOrder = append(b.embedOrder, ef)
+	}
+	b.embedded[src] = ef
+	return ef
+}
+
+// canSubset reports whether data is a TrueType font with the tables the
+// subsetter needs.
+func canSubset(data []byte) bool {
+	tables, err := parseSfntTables(data)
+	if err != nil {
+		return false
+	}
+	for _, name := range []string{"head", "hhea", "maxp", "glyf", "loca", "hmtx"} {
+		if tables[name] == nil {
+			return false
+		}
+	}
+	return true
+}
+
+// drawTextEmbedded shapes s with the face and emits it as a glyph-ID hex
+// string through the embedded font, recording the used glyphs.
+func (b *Backend) drawTextEmbedded(ef *embeddedFont, face text.Face, s string, x, y float64, brush recording.Brush) {
+	var glyphs strings.Builder
+	for g := range face.Glyphs(s) {
+		fmt.Fprintf(&glyphs, "%04x", uint16(g.GID))
+		if _, ok := ef.used[g.GID]; !ok {
+			ef.used[g.GID] = g.Rune
+		}
+	}
+	if glyphs.Len() == 0 {
+		return
+	}
+	b.setFillBrush(brush)
+	// Text would be mirrored by the page flip; undo it locally with the
+	// text matrix.
+	b.content.WriteString("BT\n")
+	fmt.Fprintf(&b.content, "/%s %s Tf\n", ef.res, num(face.Size()))
+	fmt.Fprintf(&b.content, "1 0 0 -1 %s %s Tm\n", num(x), num(y))
+	fmt.Fprintf(&b.content, "<%s> Tj\n", glyphs.String())
+	b.content.WriteString("ET\n")
+}
+
+// buildEmbeddedFont subsets the font to its used glyphs and adds the
+// FontFile2, FontDescriptor, CIDFont, ToUnicode, and Type0 objects.
+// Returns the Type0 font object number.
+func (b *Backend) buildEmbeddedFont(ef *embeddedFont) (int, error) {
+	used := make(map[uint16]bool, len(ef.used))
+	for g := range ef.used {
+		used[uint16(g)] = true
+	}
+	sf, err := subsetTrueType(ef.source.Data(), used)
+	if err != nil {
+		return 0, err
+	}
+	scale := func(v int) int {
+		if sf.unitsPerEm == 0 {
+			return v
+		}
+		return v * 1000 / sf.unitsPerEm
+	}
+
+	fileObj := b.addStreamObject(
+		fmt.Sprintf("<< /Filter /FlateDecode /Length1 %d", len(sf.program)),
+		flate(sf.program))
+
+	baseName := subsetTag(ef.source.Name()) + "+" + pdfFontName(ef.source.Name())
+	descObj := b.addObject(fmt.Sprintf(
+		"<< /Type /FontDescriptor /FontName /%s /Flags 4 /FontBBox [%d %d %d %d] "+
+			"/ItalicAngle 0 /Ascent %d /Descent %d /CapHeight %d /StemV 80 /FontFile2 %d 0 R >>",
+		baseName, scale(sf.bbox[0]), scale(sf.bbox[1]), scale(sf.bbox[2]), scale(sf.bbox[3]),
+		scale(sf.ascent), scale(sf.descent), scale(sf.ascent), fileObj))
+
+	var w strings.Builder
+	for _, g := range sortedGlyphs(ef.used) {
+		fmt.Fprintf(&w, "%d [%d] ", g, scale(sf.widths[uint16(g)]))
+	}
+	cidObj := b.addObject(fmt.Sprintf(
+		"<< /Type /Font /Subtype /CIDFontType2 /BaseFont /%s "+
+			"/CIDSystemInfo << /Registry (Adobe) /Ordering (Identity) /Supplement 0 >> "+
+			"/FontDescriptor %d 0 R /DW 1000 /W [ %s] /CIDToGIDMap /Identity >>",
+		baseName, descObj, w.String()))
+
+	tuObj := b.addStreamObject("<< /Filter /FlateDecode", flate(toUnicodeCMap(ef.used)))
+
+	return b.addObject(fmt.Sprintf(
+		"<< /Type /Font /Subtype /Type0 /BaseFont /%s /Encoding /Identity-H "+
+			"/DescendantFonts [%d 0 R] /ToUnicode %d 0 R >>",
+		baseName, cidObj, tuObj)), nil
+}
+
+// toUnicodeCMap builds a ToUnicode CMap mapping glyph IDs to the runes
+// they were drawn for, so text extraction and search work on the output.
+func toUnicodeCMap(used map[text.GlyphID]rune) []byte {
+	var sb strings.Builder
+	sb.WriteString(`/CIDInit /ProcSet findresource begin
+12 dict begin
+begincmap
+/CIDSystemInfo << /Registry (Adobe) /Ordering (UCS) /Supplement 0 >> def
+/CMapName /Adobe-Identity-UCS def
+/CMapType 2 def
+1 begincodespacerange
+<0000> <FFFF>
+endcodespacerange
+`)
+	glyphs := sortedGlyphs(used)
+	// bfchar blocks are limited to 100 entries by the CMap spec.
+	for start := 0; start < len(glyphs); start += 100 {
+		end := start + 100
+		if end > len(glyphs) {
+			end = len(glyphs)
+		}
+		fmt.Fprintf(&sb, "%d beginbfchar\n", end-start)
+		for _, g := range glyphs[start:end] {
+			fmt.Fprintf(&sb, "<%04x> <", uint16(g))
+			for _, u := range utf16.Encode([]rune{used[g]}) {
+				fmt.Fprintf(&sb, "%04x", u)
+			}
+			sb.WriteString(">\n")
+		}
+		sb.WriteString("endbfchar\n")
+	}
+	sb.WriteString(`endcmap
+CMapName currentdict /CMap defineresource pop
+end
+end
+`)
+	return []byte(sb.String())
+}
+
+// sortedGlyphs returns the used glyph IDs in ascending order.
+func sortedGlyphs(used map[text.GlyphID]rune) []text.GlyphID {
+	glyphs := make([]text.GlyphID, 0, len(used))
+	for g := range used {
+		glyphs = append(glyphs, g)
+	}
+	sort.Slice(glyphs, func(i, j int) bool { return glyphs[i] < glyphs[j] })
+	return glyphs
+}
+
+// subsetTag derives the six-letter subset prefix for a font name.
+// Deterministic so identical inputs produce identical documents.
+func subsetTag(name string) string {
+	h := fnv.New64a()
+	h.Write([]byte(name))
+	v := h.Sum64()
+	tag := make([]byte, 6)
+	for i := range tag {
+		tag[i] = 'A' + byte(v%26)
+		v /= 26
+	}
+	return string(tag)
+}
+
+// pdfFontName sanitizes a font name for use in a PDF name object.
+func pdfFontName(name string) string {
+	var sb strings.Builder
+	for _, r := range name {
+		switch {
+		case r >= 'A' && r <= 'Z', r >= 'a' && r <= 'z', r >= '0' && r <= '9':
+			sb.WriteRune(r)
+		case r == '-':
+			sb.WriteRune(r)
+		}
+	}
+	if sb.Len() == 0 {
+		return "EmbeddedFont"
+	}
+	return sb.String()
+}
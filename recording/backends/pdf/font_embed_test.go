@@ -0,0 +1,189 @@
+package pdf
+
+import (
+	"encoding/binary"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/gogpu/gg"
+	"github.com/gogpu/gg/recording"
+	"github.com/gogpu/gg/text"
+)
+
+// loadEmbedFont loads the text package's TrueType test font.
+func loadEmbedFont(t *testing.T) ([]byte, *text.FontSource) {
+	t.Helper()
+	data, err := os.ReadFile("../../../text/testdata/goregular.ttf")
+	if err != nil {
+		t.Skipf("test font unavailable: %v", err)
+	}
+	source, err := text.NewFontSource(data)
+	if err != nil {
+		t.Fatalf("NewFontSource: %v", err)
+	}
+	return data, source
+}
+
+// usedGlyphsFor shapes s with the face and returns its glyph IDs.
+func usedGlyphsFor(face text.Face, s string) map[uint16]bool {
+	used := make(map[uint16]bool)
+	for g := range face.Glyphs(s) {
+		used[uint16(g.GID)] = true
+	}
+	return used
+}
+
+func TestSubsetTrueType(t *testing.T) {
+	data, source := loadEmbedFont(t)
+	face := source.Face(12)
+	used := usedGlyphsFor(face, "Hello")
+	if len(used) == 0 {
+		t.Fatal("no glyphs shaped for test string")
+	}
+
+	sf, err := subsetTrueType(data, used)
+	if err != nil {
+		t.Fatalf("subsetTrueType: %v", err)
+	}
+
+	// Subsetting must actually shrink the font program.
+	if len(sf.program) >= len(data) {
+		t.Errorf("subset program %d bytes, original %d: no reduction",
+			len(sf.program), len(data))
+	}
+	if sf.unitsPerEm <= 0 || sf.ascent <= 0 || sf.descent >= 0 {
+		t.Errorf("implausible metrics: upem=%d ascent=%d descent=%d",
+			sf.unitsPerEm, sf.ascent, sf.descent)
+	}
+	for g := range used {
+		if sf.widths[g] <= 0 {
+			t.Errorf("glyph %d: no advance width in subset", g)
+		}
+	}
+
+	// The subset must be a parseable TrueType program with long loca,
+	// outlines for kept glyphs, and empty outlines for dropped ones.
+	tables, err := parseSfntTables(sf.program)
+	if err != nil {
+		t.Fatalf("subset program not parseable: %v", err)
+	}
+	for _, name := range []string{"head", "hhea", "maxp", "glyf", "loca", "hmtx"} {
+		if tables[name] == nil {
+			t.Fatalf("subset missing table %q", name)
+		}
+	}
+	numGlyphs := int(binary.BigEndian.Uint16(tables["maxp"][maxpNumGlyphs:]))
+	offsets, err := parseLoca(tables["loca"], numGlyphs, true)
+	if err != nil {
+		t.Fatalf("subset loca: %v", err)
+	}
+	for g := range used {
+		if offsets[g] == offsets[g+1] {
+			t.Errorf("kept glyph %d has empty outline", g)
+		}
+	}
+	empty := 0
+	for g := 0; g < numGlyphs; g++ {
+		if offsets[g] == offsets[g+1] {
+			empty++
+		}
+	}
+	if empty < numGlyphs/2 {
+		t.Errorf("only %d of %d glyphs emptied; subsetting ineffective", empty, numGlyphs)
+	}
+}
+
+func TestSubsetTrueTypeRejectsNonTrueType(t *testing.T) {
+	if _, err := subsetTrueType([]byte("OTTO garbage"), map[uint16]bool{1: true}); err == nil {
+		t.Error("expected error for CFF-flavored data")
+	}
+	if canSubset(nil) {
+		t.Error("canSubset(nil) = true")
+	}
+}
+
+func TestDrawTextEmbedsSubsetFont(t *testing.T) {
+	_, source := loadEmbedFont(t)
+	face := source.Face(14)
+
+	b := NewBackend()
+	if err := b.Begin(200, 100); err != nil {
+		t.Fatalf("Begin: %v", err)
+	}
+	brush := recording.SolidBrush{Color: gg.RGBA{A: 1}}
+	b.DrawText("Hello", 10, 50, face, brush)
+	b.DrawText("World", 10, 80, face, brush)
+	if err := b.End(); err != nil {
+		t.Fatalf("End: %v", err)
+	}
+
+	out := string(b.Bytes())
+	for _, want := range []string{
+		"/Subtype /Type0",
+		"/Encoding /Identity-H",
+		"/Subtype /CIDFontType2",
+		"/CIDToGIDMap /Identity",
+		"/FontFile2",
+		"/ToUnicode",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("PDF missing %q", want)
+		}
+	}
+
+	// Both draws share one embedded font resource, and text is emitted
+	// as glyph-ID hex strings.
+	content := contentStream(t, b)
+	if !strings.Contains(content, "/EF1 14 Tf") {
+		t.Errorf("missing embedded font selection, content:\n%s", content)
+	}
+	if strings.Contains(out, "/EF2") {
+		t.Error("same face produced a second embedded font")
+	}
+	if !strings.Contains(content, "> Tj") || !strings.Contains(content, "<") {
+		t.Errorf("text not drawn as hex glyph string, content:\n%s", content)
+	}
+}
+
+func TestRecordedTextEmbedsFont(t *testing.T) {
+	_, source := loadEmbedFont(t)
+	face := source.Face(16)
+
+	rec := recording.NewRecorder(200, 100)
+	rec.SetFont(face)
+	rec.SetRGB(0, 0, 0)
+	rec.DrawString("Recorded", 10, 50)
+	r := rec.FinishRecording()
+
+	b := NewBackend()
+	if err := r.Playback(b); err != nil {
+		t.Fatalf("Playback failed: %v", err)
+	}
+
+	out := string(b.Bytes())
+	if !strings.Contains(out, "/Encoding /Identity-H") {
+		t.Error("recorded text did not use the embedded font path")
+	}
+	if !strings.Contains(out, "/FontFile2") {
+		t.Error("no font program embedded for recorded text")
+	}
+}
+
+func TestToUnicodeCMap(t *testing.T) {
+	cmap := string(toUnicodeCMap(map[text.GlyphID]rune{
+		43: 'H',
+		76: 'é',
+	}))
+	for _, want := range []string{
+		"begincmap",
+		"2 beginbfchar",
+		"<002b> <0048>",
+		"<004c> <00e9>",
+		"endbfchar",
+	} {
+		if !strings.Contains(cmap, want) {
+			t.Errorf("ToUnicode CMap missing %q:\n%s", want, cmap)
+		}
+	}
+}
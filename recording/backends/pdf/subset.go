@@ -0,0 +1,316 @@
+package pdf
+
+import (
+	"encoding/binary"
+	"fmt"
+	"sort"
+)
+
+// This file implements TrueType font subsetting for embedded fonts.
+// The subset keeps the original glyph IDs (required for Identity-H CID
+// encoding with /CIDToGIDMap /Identity) and empties the outlines of all
+// glyphs outside the used set plus its composite closure, so the embedded
+// font program carries only the glyph data the document actually draws.
+
+// subsetFont is the result of subsetting a TrueType font program.
+type subsetFont struct {
+	program    []byte // the subset sfnt font program
+	unitsPerEm int
+	ascent     int // hhea ascender, font units
+	descent    int // hhea descender, font units (negative)
+	bbox       [4]int
+	numGlyphs  int
+	widths     map[uint16]int // advance width per glyph, font units
+}
+
+// sfnt table parsing offsets.
+const (
+	headUnitsPerEm       = 18
+	headXMin             = 36
+	headIndexToLocFormat = 50
+	headChecksumAdjust   = 8
+	hheaAscender         = 4
+	hheaDescender        = 6
+	hheaNumHMetrics      = 34
+	maxpNumGlyphs        = 4
+)
+
+// Composite glyph component flags.
+const (
+	flagArg1And2AreWords = 0x0001
+	flagWeHaveAScale     = 0x0008
+	flagMoreComponents   = 0x0020
+	flagXAndYScale       = 0x0040
+	flagTwoByTwo         = 0x0080
+)
+
+// parseSfntTables parses a TrueType font's table directory. Returns an
+// error for CFF-flavored (OTTO) and collection (ttcf) data, which this
+// subsetter does not handle.
+func parseSfntTables(data []byte) (map[string][]byte, error) {
+	if len(data) < 12 {
+		return nil, fmt.Errorf("pdf: font data too short")
+	}
+	switch tag := binary.BigEndian.Uint32(data); tag {
+	case 0x00010000, 0x74727565: // 1.0, 'true'
+	case 0x4f54544f: // 'OTTO'
+		return nil, fmt.Errorf("pdf: CFF-flavored font not supported for subsetting")
+	case 0x74746366: // 'ttcf'
+		return nil, fmt.Errorf("pdf: font collection not supported for subsetting")
+	default:
+		return nil, fmt.Errorf("pdf: unrecognized font format %08x", tag)
+	}
+
+	numTables := int(binary.BigEndian.Uint16(data[4:]))
+	if len(data) < 12+numTables*16 {
+		return nil, fmt.Errorf("pdf: truncated table directory")
+	}
+	tables := make(map[string][]byte, numTables)
+	for i := 0; i < numTables; i++ {
+		entry := data[12+i*16:]
+		name := string(entry[:4])
+		offset := binary.BigEndian.Uint32(entry[8:])
+		length := binary.BigEndian.Uint32(entry[12:])
+		if uint64(offset)+uint64(length) > uint64(len(data)) {
+			return nil, fmt.Errorf("pdf: table %q out of bounds", name)
+		}
+		tables[name] = data[offset : offset+length]
+	}
+	return tables, nil
+}
+
+// subsetTrueType builds a subset font program keeping only the glyphs in
+// used (plus glyph 0 and composite components). Glyph IDs are preserved.
+func subsetTrueType(data []byte, used map[uint16]bool) (*subsetFont, error) {
+	tables, err := parseSfntTables(data)
+	if err != nil {
+		return nil, err
+	}
+	head, hhea, maxp := tables["head"], tables["hhea"], tables["maxp"]
+	glyf, loca, hmtx := tables["glyf"], tables["loca"], tables["hmtx"]
+	if len(head) < 54 || len(hhea) < 36 || len(maxp) < 6 || glyf == nil || loca == nil || hmtx == nil {
+		return nil, fmt.Errorf("pdf: font missing required TrueType tables")
+	}
+
+	numGlyphs := int(binary.BigEndian.Uint16(maxp[maxpNumGlyphs:]))
+	longLoca := int16(binary.BigEndian.Uint16(head[headIndexToLocFormat:])) != 0
+	offsets, err := parseLoca(loca, numGlyphs, longLoca)
+	if err != nil {
+		return nil, err
+	}
+
+	// Close the used set over composite glyph components, always keeping
+	// glyph 0 (.notdef).
+	keep := make(map[uint16]bool, len(used)+1)
+	keep[0] = true
+	stack := make([]uint16, 0, len(used)+1)
+	for g := range used {
+		if int(g) < numGlyphs {
+			keep[g] = true
+			stack = append(stack, g)
+		}
+	}
+	stack = append(stack, 0)
+	for len(stack) > 0 {
+		g := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		for _, comp := range componentGlyphs(glyf[offsets[g]:offsets[g+1]]) {
+			if int(comp) < numGlyphs && !keep[comp] {
+				keep[comp] = true
+				stack = append(stack, comp)
+			}
+		}
+	}
+
+	// Rebuild glyf with empty outlines for dropped glyphs, and a matching
+	// long-format loca.
+	newGlyf := make([]byte, 0, len(glyf)/2)
+	newLoca := make([]byte, (numGlyphs+1)*4)
+	for g := 0; g <= numGlyphs; g++ {
+		binary.BigEndian.PutUint32(newLoca[g*4:], uint32(len(newGlyf)))
+		if g == numGlyphs {
+			break
+		}
+		if keep[uint16(g)] {
+			newGlyf = append(newGlyf, glyf[offsets[g]:offsets[g+1]]...)
+			for len(newGlyf)%4 != 0 {
+				newGlyf = append(newGlyf, 0)
+			}
+		}
+	}
+
+	// head copy: long loca format, checksum adjustment recomputed below.
+	newHead := append([]byte(nil), head...)
+	binary.BigEndian.PutUint32(newHead[headChecksumAdjust:], 0)
+	binary.BigEndian.PutUint16(newHead[headIndexToLocFormat:], 1)
+
+	out := map[string][]byte{
+		"glyf": newGlyf,
+		"loca": newLoca,
+		"head": newHead,
+		"hhea": hhea,
+		"hmtx": hmtx,
+		"maxp": maxp,
+	}
+	// Hinting tables are optional but cheap to keep when present.
+	for _, name := range []string{"cvt ", "fpgm", "prep"} {
+		if t, ok := tables[name]; ok {
+			out[name] = t
+		}
+	}
+	program := assembleSfnt(out)
+
+	sf := &subsetFont{
+		program:    program,
+		unitsPerEm: int(binary.BigEndian.Uint16(head[headUnitsPerEm:])),
+		ascent:     int(int16(binary.BigEndian.Uint16(hhea[hheaAscender:]))),
+		descent:    int(int16(binary.BigEndian.Uint16(hhea[hheaDescender:]))),
+		numGlyphs:  numGlyphs,
+		widths:     make(map[uint16]int, len(keep)),
+	}
+	for i := 0; i < 4; i++ {
+		sf.bbox[i] = int(int16(binary.BigEndian.Uint16(head[headXMin+i*2:])))
+	}
+	numHMetrics := int(binary.BigEndian.Uint16(hhea[hheaNumHMetrics:]))
+	for g := range keep {
+		sf.widths[g] = advanceWidth(hmtx, numHMetrics, g)
+	}
+	return sf, nil
+}
+
+// parseLoca converts a loca table into byte offsets within glyf.
+func parseLoca(loca []byte, numGlyphs int, long bool) ([]uint32, error) {
+	offsets := make([]uint32, numGlyphs+1)
+	if long {
+		if len(loca) < (numGlyphs+1)*4 {
+			return nil, fmt.Errorf("pdf: truncated loca table")
+		}
+		for i := range offsets {
+			offsets[i] = binary.BigEndian.Uint32(loca[i*4:])
+		}
+	} else {
+		if len(loca) < (numGlyphs+1)*2 {
+			return nil, fmt.Errorf("pdf: truncated loca table")
+		}
+		for i := range offsets {
+			offsets[i] = uint32(binary.BigEndian.Uint16(loca[i*2:])) * 2
+		}
+	}
+	for i := 1; i < len(offsets); i++ {
+		if offsets[i] < offsets[i-1] {
+			return nil, fmt.Errorf("pdf: loca offsets not monotonic")
+		}
+	}
+	return offsets, nil
+}
+
+// componentGlyphs returns the component glyph IDs of a composite glyph,
+// or nil for simple and empty glyphs.
+func componentGlyphs(glyph []byte) []uint16 {
+	if len(glyph) < 10 || int16(binary.BigEndian.Uint16(glyph)) >= 0 {
+		return nil
+	}
+	var comps []uint16
+	p := 10
+	for p+4 <= len(glyph) {
+		flags := binary.BigEndian.Uint16(glyph[p:])
+		comps = append(comps, binary.BigEndian.Uint16(glyph[p+2:]))
+		p += 4
+		if flags&flagArg1And2AreWords != 0 {
+			p += 4
+		} else {
+			p += 2
+		}
+		switch {
+		case flags&flagWeHaveAScale != 0:
+			p += 2
+		case flags&flagXAndYScale != 0:
+			p += 4
+		case flags&flagTwoByTwo != 0:
+			p += 8
+		}
+		if flags&flagMoreComponents == 0 {
+			break
+		}
+	}
+	return comps
+}
+
+// advanceWidth reads a glyph's advance width from hmtx.
+func advanceWidth(hmtx []byte, numHMetrics int, gid uint16) int {
+	idx := int(gid)
+	if idx >= numHMetrics {
+		idx = numHMetrics - 1
+	}
+	if idx < 0 || idx*4+2 > len(hmtx) {
+		return 0
+	}
+	return int(binary.BigEndian.Uint16(hmtx[idx*4:]))
+}
+
+// assembleSfnt serializes tables into a TrueType font program, computing
+// table checksums and the head checksum adjustment.
+func assembleSfnt(tables map[string][]byte) []byte {
+	tags := make([]string, 0, len(tables))
+	for tag := range tables {
+		tags = append(tags, tag)
+	}
+	sort.Strings(tags)
+
+	numTables := len(tags)
+	entrySelector := 0
+	for 1<<(entrySelector+1) <= numTables {
+		entrySelector++
+	}
+	searchRange := (1 << entrySelector) * 16
+
+	size := 12 + numTables*16
+	for _, tag := range tags {
+		size += (len(tables[tag]) + 3) &^ 3
+	}
+	out := make([]byte, size)
+	binary.BigEndian.PutUint32(out, 0x00010000)
+	binary.BigEndian.PutUint16(out[4:], uint16(numTables))
+	binary.BigEndian.PutUint16(out[6:], uint16(searchRange))
+	binary.BigEndian.PutUint16(out[8:], uint16(entrySelector))
+	binary.BigEndian.PutUint16(out[10:], uint16(numTables*16-searchRange))
+
+	offset := 12 + numTables*16
+	for i, tag := range tags {
+		table := tables[tag]
+		copy(out[offset:], table)
+		entry := out[12+i*16:]
+		copy(entry, tag)
+		binary.BigEndian.PutUint32(entry[4:], sfntChecksum(out[offset:offset+(len(table)+3)&^3]))
+		binary.BigEndian.PutUint32(entry[8:], uint32(offset))
+		binary.BigEndian.PutUint32(entry[12:], uint32(len(table)))
+		offset += (len(table) + 3) &^ 3
+	}
+
+	// head.checkSumAdjustment = 0xB1B0AFBA - checksum of the whole font.
+	headOffset := 0
+	for i, tag := range tags {
+		if tag == "head" {
+			headOffset = int(binary.BigEndian.Uint32(out[12+i*16+8:]))
+		}
+	}
+	if headOffset > 0 {
+		binary.BigEndian.PutUint32(out[headOffset+headChecksumAdjust:],
+			0xB1B0AFBA-sfntChecksum(out))
+	}
+	return out
+}
+
+// sfntChecksum sums data as big-endian uint32s, zero-padding the tail.
+func sfntChecksum(data []byte) uint32 {
+	var sum uint32
+	for i := 0; i+4 <= len(data); i += 4 {
+		sum += binary.BigEndian.Uint32(data[i:])
+	}
+	if rem := len(data) % 4; rem != 0 {
+		var tail [4]byte
+		copy(tail[:], data[len(data)-rem:])
+		sum += binary.BigEndian.Uint32(tail[:])
+	}
+	return sum
+}
@@ -9,20 +9,14 @@
 //
 // # Supported Features
 //
-//   - Solid color fills and strokes
+//   - Solid, gradient (linear, radial, sweep), and pattern brushes for
+//     both fills and strokes
 //   - Path operations (fill, stroke, clip)
 //   - Transform matrix
 //   - Stroke styling (width, cap, join, dash patterns)
 //   - State management (Save/Restore)
 //   - PNG output
 //
-// # Limitations
-//
-// Gradient brushes (linear, radial, sweep) are correctly translated to gg
-// brush types, but the underlying gg.SoftwareRenderer currently only supports
-// solid colors. Gradients will render as black until the gg library implements
-// gradient support in its software renderer.
-//
 // # Example
 //
 //	// Import to register the backend
@@ -306,60 +300,11 @@ func (b *Backend) applyBrush(brush recording.Brush, fill bool) {
 		return
 	}
 
-	switch br := brush.(type) {
-	case recording.SolidBrush:
-		ggBrush := gg.Solid(br.Color)
-		if fill {
-			b.ctx.SetFillBrush(ggBrush)
-		} else {
-			b.ctx.SetStrokeBrush(ggBrush)
-		}
-
-	case *recording.LinearGradientBrush:
-		grad := gg.NewLinearGradientBrush(br.Start.X, br.Start.Y, br.End.X, br.End.Y)
-		for _, stop := range br.Stops {
-			grad.AddColorStop(stop.Offset, stop.Color)
-		}
-		grad.SetExtend(gg.ExtendMode(br.Extend))
-		if fill {
-			b.ctx.SetFillBrush(grad)
-		} else {
-			b.ctx.SetStrokeBrush(grad)
-		}
-
-	case *recording.RadialGradientBrush:
-		grad := gg.NewRadialGradientBrush(br.Center.X, br.Center.Y, br.StartRadius, br.EndRadius)
-		grad.SetFocus(br.Focus.X, br.Focus.Y)
-		for _, stop := range br.Stops {
-			grad.AddColorStop(stop.Offset, stop.Color)
-		}
-		grad.SetExtend(gg.ExtendMode(br.Extend))
-		if fill {
-			b.ctx.SetFillBrush(grad)
-		} else {
-			b.ctx.SetStrokeBrush(grad)
-		}
-
-	case *recording.SweepGradientBrush:
-		grad := gg.NewSweepGradientBrush(br.Center.X, br.Center.Y, br.StartAngle)
-		grad.SetEndAngle(br.EndAngle)
-		for _, stop := range br.Stops {
-			grad.AddColorStop(stop.Offset, stop.Color)
-		}
-		grad.SetExtend(gg.ExtendMode(br.Extend))
-		if fill {
-			b.ctx.SetFillBrush(grad)
-		} else {
-			b.ctx.SetStrokeBrush(grad)
-		}
-
-	default:
-		// Fallback to black
-		if fill {
-			b.ctx.SetFillBrush(gg.Solid(gg.Black))
-		} else {
-			b.ctx.SetStrokeBrush(gg.Solid(gg.Black))
-		}
+	ggBrush := recording.BrushToGG(brush)
+	if fill {
+		b.ctx.SetFillBrush(ggBrush)
+	} else {
+		b.ctx.SetStrokeBrush(ggBrush)
 	}
 }
 
@@ -407,6 +352,10 @@ func convertLineJoin(join recording.LineJoin) gg.LineJoin {
 		return gg.LineJoinRound
 	case recording.LineJoinBevel:
 		return gg.LineJoinBevel
+	case recording.LineJoinMiterClip:
+		return gg.LineJoinMiterClip
+	case recording.LineJoinArcs:
+		return gg.LineJoinArcs
 	default:
 		return gg.LineJoinMiter
 	}
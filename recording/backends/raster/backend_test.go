@@ -494,3 +494,40 @@ func TestBackendDashedStroke(t *testing.T) {
 		t.Error("expected non-transparent pixel on dashed line")
 	}
 }
+
+func TestBackendGradientStrokePath(t *testing.T) {
+	backend := NewBackend()
+	if err := backend.Begin(100, 100); err != nil {
+		t.Fatalf("Begin failed: %v", err)
+	}
+
+	path := gg.NewPath()
+	path.MoveTo(5, 50)
+	path.LineTo(95, 50)
+
+	brush := recording.NewLinearGradientBrush(0, 0, 100, 0).
+		AddColorStop(0, gg.Red).
+		AddColorStop(1, gg.Blue)
+	stroke := recording.Stroke{Width: 10.0, MiterLimit: 4.0}
+	backend.StrokePath(path, brush, stroke)
+
+	if err := backend.End(); err != nil {
+		t.Fatalf("End failed: %v", err)
+	}
+
+	img := backend.Image()
+	rgba, ok := img.(*image.RGBA)
+	if !ok {
+		t.Fatal("expected *image.RGBA")
+	}
+
+	// Left end reddish, right end bluish — the gradient survived playback.
+	left := rgba.RGBAAt(10, 50)
+	if left.R < 150 || left.B > 100 {
+		t.Errorf("left pixel = %v, expected reddish", left)
+	}
+	right := rgba.RGBAAt(90, 50)
+	if right.B < 150 || right.R > 100 {
+		t.Errorf("right pixel = %v, expected bluish", right)
+	}
+}
@@ -0,0 +1,337 @@
+package svg
+
+import (
+	"bytes"
+	"compress/gzip"
+	"image"
+	"io"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/gogpu/gg"
+	"github.com/gogpu/gg/recording"
+)
+
+func TestRegistration(t *testing.T) {
+	if !recording.IsRegistered("svg") {
+		t.Fatal("svg backend not registered")
+	}
+	backend, err := recording.NewBackend("svg")
+	if err != nil {
+		t.Fatalf("NewBackend failed: %v", err)
+	}
+	if _, ok := backend.(*Backend); !ok {
+		t.Errorf("expected *Backend, got %T", backend)
+	}
+}
+
+func TestDocumentStructure(t *testing.T) {
+	b := NewBackend()
+	if err := b.Begin(200, 100); err != nil {
+		t.Fatalf("Begin failed: %v", err)
+	}
+	b.FillRect(recording.NewRect(10, 10, 50, 50), recording.NewSolidBrush(gg.Red))
+	if err := b.End(); err != nil {
+		t.Fatalf("End failed: %v", err)
+	}
+
+	out := string(b.Bytes())
+	for _, want := range []string{
+		`<?xml version="1.0"`,
+		`viewBox="0 0 200 100"`,
+		`width="200" height="100"`,
+		`<rect x="10" y="10" width="50" height="50" fill="#ff0000"`,
+		"</svg>",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("SVG missing %q, got:\n%s", want, out)
+		}
+	}
+}
+
+func TestPathAndStroke(t *testing.T) {
+	rec := recording.NewRecorder(100, 100)
+	rec.SetRGB(0, 0, 1)
+	rec.SetLineWidth(2.5)
+	rec.SetLineCapGG(gg.LineCapRound)
+	rec.SetDash(5, 3)
+	rec.MoveTo(0, 0)
+	rec.LineTo(100, 100)
+	rec.QuadraticTo(50, 0, 100, 0)
+	rec.Stroke()
+	r := rec.FinishRecording()
+
+	b := NewBackend()
+	if err := r.Playback(b); err != nil {
+		t.Fatalf("Playback failed: %v", err)
+	}
+
+	out := string(b.Bytes())
+	for _, want := range []string{
+		`stroke="#0000ff"`, `stroke-width="2.5"`, `stroke-linecap="round"`,
+		`stroke-dasharray="5 3"`, "M0 0", "L100 100", "Q50 0 100 0",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("SVG missing %q", want)
+		}
+	}
+}
+
+func TestPrimitiveElements(t *testing.T) {
+	rec := recording.NewRecorder(200, 200)
+	rec.SetRGB(1, 0, 0)
+	rec.DrawCircle(100, 100, 50)
+	rec.Fill()
+	rec.Scale(2, 3)
+	rec.DrawCircle(10, 10, 5)
+	rec.Fill()
+	rec.Identity()
+	rec.SetLineWidth(2)
+	rec.DrawArc(100, 100, 40, 0, 3.141592653589793)
+	rec.Stroke()
+	rec.DrawRoundedRectangle(10, 10, 80, 40, 8)
+	rec.Fill()
+	r := rec.FinishRecording()
+
+	b := NewBackend()
+	if err := r.Playback(b); err != nil {
+		t.Fatalf("Playback failed: %v", err)
+	}
+
+	out := string(b.Bytes())
+	for _, want := range []string{
+		`<circle cx="100" cy="100" r="50" fill="#ff0000"`,
+		`<ellipse cx="20" cy="30" rx="10" ry="15"`,
+		`A40 40 0 0 1`,
+		`<rect x="10" y="10" width="80" height="40" rx="8"`,
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("SVG missing %q", want)
+		}
+	}
+	// The Bezier fallback for these shapes must not be emitted as well.
+	if strings.Contains(out, "C") && strings.Contains(out, `<path d="M150`) {
+		t.Error("SVG contains flattened circle path alongside the primitive")
+	}
+}
+
+func TestLinearGradient(t *testing.T) {
+	rec := recording.NewRecorder(100, 100)
+	grad := recording.NewLinearGradientBrush(0, 0, 100, 0)
+	grad.AddColorStop(0, gg.Red)
+	grad.AddColorStop(1, gg.Blue)
+	grad.SetExtend(recording.ExtendReflect)
+	rec.SetFillStyle(grad)
+	rec.DrawRectangle(0, 0, 100, 100)
+	rec.Fill()
+	r := rec.FinishRecording()
+
+	b := NewBackend()
+	if err := r.Playback(b); err != nil {
+		t.Fatalf("Playback failed: %v", err)
+	}
+
+	out := string(b.Bytes())
+	for _, want := range []string{
+		"<linearGradient", `spreadMethod="reflect"`,
+		`<stop offset="0" stop-color="#ff0000"/>`,
+		`<stop offset="1" stop-color="#0000ff"/>`,
+		`fill="url(#grad`,
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("SVG missing %q, got:\n%s", want, out)
+		}
+	}
+}
+
+func TestRadialGradient(t *testing.T) {
+	rec := recording.NewRecorder(100, 100)
+	grad := recording.NewRadialGradientBrush(50, 50, 0, 40)
+	grad.AddColorStop(0, gg.White)
+	grad.AddColorStop(1, gg.Black)
+	rec.SetFillStyle(grad)
+	rec.DrawCircle(50, 50, 40)
+	rec.Fill()
+	r := rec.FinishRecording()
+
+	b := NewBackend()
+	if err := r.Playback(b); err != nil {
+		t.Fatalf("Playback failed: %v", err)
+	}
+
+	out := string(b.Bytes())
+	if !strings.Contains(out, "<radialGradient") {
+		t.Error("missing radialGradient definition")
+	}
+	if !strings.Contains(out, `cx="50" cy="50" r="40"`) {
+		t.Errorf("wrong radial gradient geometry:\n%s", out)
+	}
+}
+
+func TestClipping(t *testing.T) {
+	rec := recording.NewRecorder(100, 100)
+	rec.DrawCircle(50, 50, 30)
+	rec.Clip()
+	rec.SetRGB(0, 1, 0)
+	rec.DrawRectangle(0, 0, 100, 100)
+	rec.Fill()
+	rec.ResetClip()
+	rec.SetRGB(1, 0, 0)
+	rec.DrawRectangle(0, 0, 10, 10)
+	rec.Fill()
+	r := rec.FinishRecording()
+
+	b := NewBackend()
+	if err := r.Playback(b); err != nil {
+		t.Fatalf("Playback failed: %v", err)
+	}
+
+	out := string(b.Bytes())
+	if !strings.Contains(out, "<clipPath id=") {
+		t.Error("missing clipPath definition")
+	}
+	if !strings.Contains(out, `clip-path="url(#clip`) {
+		t.Error("missing clip-path reference on clipped element")
+	}
+	// The element after ResetClip must not carry the clip attribute.
+	idx := strings.LastIndex(out, `fill="#ff0000"`)
+	if idx < 0 {
+		t.Fatal("missing post-reset fill")
+	}
+	line := out[strings.LastIndex(out[:idx], "<"):]
+	line = line[:strings.Index(line, ">")+1]
+	if strings.Contains(line, "clip-path") {
+		t.Errorf("clip not cleared after ResetClip: %s", line)
+	}
+}
+
+func TestTextAsText(t *testing.T) {
+	rec := recording.NewRecorder(200, 100)
+	rec.SetRGB(0, 0, 0)
+	rec.DrawString("a < b & c", 10, 50)
+	r := rec.FinishRecording()
+
+	b := NewBackend()
+	b.SetFontFamily("Georgia")
+	if err := r.Playback(b); err != nil {
+		t.Fatalf("Playback failed: %v", err)
+	}
+
+	out := string(b.Bytes())
+	if !strings.Contains(out, `<text x="10" y="50" font-family="Georgia"`) {
+		t.Errorf("missing text element:\n%s", out)
+	}
+	if !strings.Contains(out, "a &lt; b &amp; c") {
+		t.Error("text content not escaped")
+	}
+}
+
+func TestImageEmbedding(t *testing.T) {
+	img := image.NewRGBA(image.Rect(0, 0, 4, 4))
+	for i := range img.Pix {
+		img.Pix[i] = 0xff
+	}
+
+	rec := recording.NewRecorder(100, 100)
+	rec.DrawImage(img, 10, 20)
+	r := rec.FinishRecording()
+
+	b := NewBackend()
+	if err := r.Playback(b); err != nil {
+		t.Fatalf("Playback failed: %v", err)
+	}
+
+	out := string(b.Bytes())
+	if !strings.Contains(out, "data:image/png;base64,") {
+		t.Error("missing base64 image data URI")
+	}
+	if !strings.Contains(out, `<image x="10" y="20"`) {
+		t.Errorf("wrong image placement:\n%s", out)
+	}
+}
+
+func TestSVGZOutput(t *testing.T) {
+	b := NewBackend()
+	if err := b.Begin(50, 50); err != nil {
+		t.Fatalf("Begin failed: %v", err)
+	}
+	b.FillRect(recording.NewRect(0, 0, 50, 50), recording.NewSolidBrush(gg.Blue))
+	if err := b.End(); err != nil {
+		t.Fatalf("End failed: %v", err)
+	}
+
+	var buf bytes.Buffer
+	if _, err := b.WriteToCompressed(&buf); err != nil {
+		t.Fatalf("WriteToCompressed failed: %v", err)
+	}
+	zr, err := gzip.NewReader(&buf)
+	if err != nil {
+		t.Fatalf("output is not gzip: %v", err)
+	}
+	decoded, err := io.ReadAll(zr)
+	if err != nil {
+		t.Fatalf("decompress failed: %v", err)
+	}
+	if !bytes.Equal(decoded, b.Bytes()) {
+		t.Error("compressed output does not round-trip")
+	}
+
+	// SaveToFile with .svgz extension must compress too.
+	path := filepath.Join(t.TempDir(), "out.svgz")
+	if err := b.SaveToFile(path); err != nil {
+		t.Fatalf("SaveToFile failed: %v", err)
+	}
+}
+
+func TestOutputBeforeEnd(t *testing.T) {
+	b := NewBackend()
+	if err := b.Begin(10, 10); err != nil {
+		t.Fatalf("Begin failed: %v", err)
+	}
+	var buf bytes.Buffer
+	if _, err := b.WriteTo(&buf); err == nil {
+		t.Error("expected error writing before End")
+	}
+	if err := b.SaveToFile(filepath.Join(t.TempDir(), "x.svg")); err == nil {
+		t.Error("expected error saving before End")
+	}
+}
+
+func TestStrokeJoinMapping(t *testing.T) {
+	stroke := func(join recording.LineJoin, limit float64) string {
+		b := NewBackend()
+		if err := b.Begin(100, 100); err != nil {
+			t.Fatalf("Begin failed: %v", err)
+		}
+		p := gg.NewPath()
+		p.MoveTo(10, 10)
+		p.LineTo(90, 10)
+		p.LineTo(10, 30)
+		b.StrokePath(p, recording.NewSolidBrush(gg.Black), recording.Stroke{
+			Width: 4, Join: join, MiterLimit: limit,
+		})
+		if err := b.End(); err != nil {
+			t.Fatalf("End failed: %v", err)
+		}
+		return string(b.Bytes())
+	}
+
+	out := stroke(recording.LineJoinMiterClip, 3)
+	if !strings.Contains(out, `stroke-linejoin="miter-clip"`) {
+		t.Errorf("miter-clip join not exported, got:\n%s", out)
+	}
+	// miter-clip honors stroke-miterlimit (it sets the clip distance).
+	if !strings.Contains(out, `stroke-miterlimit="3"`) {
+		t.Errorf("miter-clip should export stroke-miterlimit, got:\n%s", out)
+	}
+
+	out = stroke(recording.LineJoinArcs, 3)
+	if !strings.Contains(out, `stroke-linejoin="arcs"`) {
+		t.Errorf("arcs join not exported, got:\n%s", out)
+	}
+	// arcs joins ignore the miter limit.
+	if strings.Contains(out, "stroke-miterlimit") {
+		t.Errorf("arcs join should not export stroke-miterlimit, got:\n%s", out)
+	}
+}
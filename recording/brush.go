@@ -289,3 +289,42 @@ func sweepGradientFromGG(g *gg.SweepGradientBrush) *SweepGradientBrush {
 		Extend:     ExtendMode(g.Extend),
 	}
 }
+
+// BrushToGG converts a recording.Brush to a gg.Brush for playback through a
+// gg-based backend. It is the inverse of BrushFromGG, so brushes survive a
+// record/playback round trip without loss.
+//
+// PatternBrush references its image by pool handle, which cannot be resolved
+// without the recording's resource pool; it converts to a black solid brush.
+// Backends that support patterns resolve the image themselves.
+func BrushToGG(b Brush) gg.Brush {
+	switch brush := b.(type) {
+	case SolidBrush:
+		return gg.Solid(brush.Color)
+	case *LinearGradientBrush:
+		g := gg.NewLinearGradientBrush(brush.Start.X, brush.Start.Y, brush.End.X, brush.End.Y)
+		for _, stop := range brush.Stops {
+			g.AddColorStop(stop.Offset, stop.Color)
+		}
+		g.SetExtend(gg.ExtendMode(brush.Extend))
+		return g
+	case *RadialGradientBrush:
+		g := gg.NewRadialGradientBrush(brush.Center.X, brush.Center.Y, brush.StartRadius, brush.EndRadius)
+		g.SetFocus(brush.Focus.X, brush.Focus.Y)
+		for _, stop := range brush.Stops {
+			g.AddColorStop(stop.Offset, stop.Color)
+		}
+		g.SetExtend(gg.ExtendMode(brush.Extend))
+		return g
+	case *SweepGradientBrush:
+		g := gg.NewSweepGradientBrush(brush.Center.X, brush.Center.Y, brush.StartAngle)
+		g.SetEndAngle(brush.EndAngle)
+		for _, stop := range brush.Stops {
+			g.AddColorStop(stop.Offset, stop.Color)
+		}
+		g.SetExtend(gg.ExtendMode(brush.Extend))
+		return g
+	default:
+		return gg.Solid(gg.Black)
+	}
+}
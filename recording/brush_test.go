@@ -404,3 +404,94 @@ func BenchmarkBrushFromGG_LinearGradient(b *testing.B) {
 		_ = BrushFromGG(brush)
 	}
 }
+
+// --- gg.Brush adapter round trips ---
+
+func TestBrushToGGSolid(t *testing.T) {
+	b := BrushToGG(NewSolidBrush(gg.Red))
+	sb, ok := b.(gg.SolidBrush)
+	if !ok {
+		t.Fatalf("BrushToGG(SolidBrush) = %T, want gg.SolidBrush", b)
+	}
+	if sb.Color != gg.Red {
+		t.Errorf("color = %v, want Red", sb.Color)
+	}
+}
+
+func TestBrushToGGLinearGradientRoundTrip(t *testing.T) {
+	orig := gg.NewLinearGradientBrush(10, 20, 110, 20).
+		AddColorStop(0, gg.Red).
+		AddColorStop(1, gg.Blue).
+		SetExtend(gg.ExtendReflect)
+
+	back := BrushToGG(BrushFromGG(orig))
+	got, ok := back.(*gg.LinearGradientBrush)
+	if !ok {
+		t.Fatalf("round trip = %T, want *gg.LinearGradientBrush", back)
+	}
+	if got.Start != orig.Start || got.End != orig.End {
+		t.Errorf("geometry = %v-%v, want %v-%v", got.Start, got.End, orig.Start, orig.End)
+	}
+	if got.Extend != orig.Extend {
+		t.Errorf("extend = %v, want %v", got.Extend, orig.Extend)
+	}
+	if len(got.Stops) != len(orig.Stops) {
+		t.Fatalf("stops = %d, want %d", len(got.Stops), len(orig.Stops))
+	}
+	for i := range got.Stops {
+		if got.Stops[i] != orig.Stops[i] {
+			t.Errorf("stop %d = %v, want %v", i, got.Stops[i], orig.Stops[i])
+		}
+	}
+}
+
+func TestBrushToGGRadialGradientRoundTrip(t *testing.T) {
+	orig := gg.NewRadialGradientBrush(50, 60, 5, 40).
+		SetFocus(45, 55).
+		AddColorStop(0, gg.White).
+		AddColorStop(1, gg.Black)
+
+	back := BrushToGG(BrushFromGG(orig))
+	got, ok := back.(*gg.RadialGradientBrush)
+	if !ok {
+		t.Fatalf("round trip = %T, want *gg.RadialGradientBrush", back)
+	}
+	if got.Center != orig.Center || got.Focus != orig.Focus {
+		t.Errorf("center/focus = %v/%v, want %v/%v", got.Center, got.Focus, orig.Center, orig.Focus)
+	}
+	if got.StartRadius != orig.StartRadius || got.EndRadius != orig.EndRadius {
+		t.Errorf("radii = %v-%v, want %v-%v", got.StartRadius, got.EndRadius, orig.StartRadius, orig.EndRadius)
+	}
+}
+
+func TestBrushToGGSweepGradientRoundTrip(t *testing.T) {
+	orig := gg.NewSweepGradientBrush(30, 30, 0.5).
+		SetEndAngle(2.5).
+		AddColorStop(0, gg.Red).
+		AddColorStop(1, gg.Green)
+
+	back := BrushToGG(BrushFromGG(orig))
+	got, ok := back.(*gg.SweepGradientBrush)
+	if !ok {
+		t.Fatalf("round trip = %T, want *gg.SweepGradientBrush", back)
+	}
+	if got.Center != orig.Center {
+		t.Errorf("center = %v, want %v", got.Center, orig.Center)
+	}
+	if math.Abs(got.StartAngle-orig.StartAngle) > 1e-12 || math.Abs(got.EndAngle-orig.EndAngle) > 1e-12 {
+		t.Errorf("angles = %v-%v, want %v-%v", got.StartAngle, got.EndAngle, orig.StartAngle, orig.EndAngle)
+	}
+}
+
+func TestBrushToGGPatternFallsBackToSolid(t *testing.T) {
+	// Pattern images live in the recording's resource pool; without it the
+	// adapter degrades to a black solid brush.
+	b := BrushToGG(NewPatternBrush(ImageRef(1)))
+	sb, ok := b.(gg.SolidBrush)
+	if !ok {
+		t.Fatalf("BrushToGG(PatternBrush) = %T, want gg.SolidBrush", b)
+	}
+	if sb.Color != gg.Black {
+		t.Errorf("fallback color = %v, want Black", sb.Color)
+	}
+}
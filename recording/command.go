@@ -46,13 +46,15 @@ const (
 	CmdClipRoundRect                    // Set rounded rectangle clipping region
 
 	// Drawing commands
-	CmdFillPath   // Fill a path
-	CmdStrokePath // Stroke a path
-	CmdFillRect   // Fill a rectangle (optimized)
-	CmdStrokeRect // Stroke a rectangle
-	CmdDrawImage  // Draw an image
-	CmdDrawText   // Draw text
-	CmdStrokeText // Stroke text outlines
+	CmdFillPath        // Fill a path
+	CmdStrokePath      // Stroke a path
+	CmdFillRect        // Fill a rectangle (optimized)
+	CmdStrokeRect      // Stroke a rectangle
+	CmdFillPrimitive   // Fill a preserved shape primitive
+	CmdStrokePrimitive // Stroke a preserved shape primitive
+	CmdDrawImage       // Draw an image
+	CmdDrawText        // Draw text
+	CmdStrokeText      // Stroke text outlines
 
 	// Style commands
 	CmdSetFillStyle   // Set fill brush
@@ -64,32 +66,40 @@ const (
 	CmdSetDash        // Set dash pattern
 	CmdSetFillRule    // Set fill rule
 	CmdSetAntiAlias   // Set anti-aliasing mode
+
+	// Semantic commands
+	CmdBeginSemantic // Open a semantic annotation group
+	CmdEndSemantic   // Close a semantic annotation group
 )
 
 // commandTypeNames maps CommandType values to their string representation.
 var commandTypeNames = [...]string{
-	CmdSave:           "Save",
-	CmdRestore:        "Restore",
-	CmdSetTransform:   "SetTransform",
-	CmdSetClip:        "SetClip",
-	CmdClearClip:      "ClearClip",
-	CmdClipRoundRect:  "ClipRoundRect",
-	CmdFillPath:       "FillPath",
-	CmdStrokePath:     "StrokePath",
-	CmdFillRect:       "FillRect",
-	CmdStrokeRect:     "StrokeRect",
-	CmdDrawImage:      "DrawImage",
-	CmdDrawText:       "DrawText",
-	CmdStrokeText:     "StrokeText",
-	CmdSetFillStyle:   "SetFillStyle",
-	CmdSetStrokeStyle: "SetStrokeStyle",
-	CmdSetLineWidth:   "SetLineWidth",
-	CmdSetLineCap:     "SetLineCap",
-	CmdSetLineJoin:    "SetLineJoin",
-	CmdSetMiterLimit:  "SetMiterLimit",
-	CmdSetDash:        "SetDash",
-	CmdSetFillRule:    "SetFillRule",
-	CmdSetAntiAlias:   "SetAntiAlias",
+	CmdSave:            "Save",
+	CmdRestore:         "Restore",
+	CmdSetTransform:    "SetTransform",
+	CmdSetClip:         "SetClip",
+	CmdClearClip:       "ClearClip",
+	CmdClipRoundRect:   "ClipRoundRect",
+	CmdFillPath:        "FillPath",
+	CmdStrokePath:      "StrokePath",
+	CmdFillRect:        "FillRect",
+	CmdStrokeRect:      "StrokeRect",
+	CmdFillPrimitive:   "FillPrimitive",
+	CmdStrokePrimitive: "StrokePrimitive",
+	CmdDrawImage:       "DrawImage",
+	CmdDrawText:        "DrawText",
+	CmdStrokeText:      "StrokeText",
+	CmdSetFillStyle:    "SetFillStyle",
+	CmdSetStrokeStyle:  "SetStrokeStyle",
+	CmdSetLineWidth:    "SetLineWidth",
+	CmdSetLineCap:      "SetLineCap",
+	CmdSetLineJoin:     "SetLineJoin",
+	CmdSetMiterLimit:   "SetMiterLimit",
+	CmdSetDash:         "SetDash",
+	CmdSetFillRule:     "SetFillRule",
+	CmdSetAntiAlias:    "SetAntiAlias",
+	CmdBeginSemantic:   "BeginSemantic",
+	CmdEndSemantic:     "EndSemantic",
 }
 
 // String returns the string representation of a CommandType.
@@ -283,6 +293,9 @@ type DrawTextCommand struct {
 	FontSize float64
 	// FontFamily is the name of the font family.
 	FontFamily string
+	// Font references the font face in the resource pool.
+	// InvalidRef when no face was set at recording time.
+	Font FontRef
 	// Brush references the text color/brush in the resource pool.
 	Brush BrushRef
 }
@@ -303,6 +316,9 @@ type StrokeTextCommand struct {
 	FontSize float64
 	// FontFamily is the name of the font family.
 	FontFamily string
+	// Font references the font face in the resource pool.
+	// InvalidRef when no face was set at recording time.
+	Font FontRef
 	// Brush references the stroke color/brush in the resource pool.
 	Brush BrushRef
 	// Stroke contains the stroke style (width, cap, join, dash).
@@ -436,6 +452,11 @@ const (
 	LineJoinRound
 	// LineJoinBevel specifies a beveled join.
 	LineJoinBevel
+	// LineJoinMiterClip specifies an SVG 2 miter-clip join (tip clipped at
+	// the miter-limit distance instead of reverting to bevel).
+	LineJoinMiterClip
+	// LineJoinArcs specifies an SVG 2 arcs join.
+	LineJoinArcs
 )
 
 // Stroke defines the style for stroking paths.
@@ -480,6 +501,8 @@ type ImageOptions struct {
 	Interpolation InterpolationMode
 	// Alpha is the opacity (0.0 to 1.0).
 	Alpha float64
+	// Blend specifies how source pixels are composited with the destination.
+	Blend BlendMode
 }
 
 // DefaultImageOptions returns ImageOptions with default settings.
@@ -487,6 +510,7 @@ func DefaultImageOptions() ImageOptions {
 	return ImageOptions{
 		Interpolation: InterpolationBilinear,
 		Alpha:         1.0,
+		Blend:         BlendNormal,
 	}
 }
 
@@ -499,3 +523,18 @@ const (
 	// InterpolationBilinear uses bilinear interpolation.
 	InterpolationBilinear
 )
+
+// BlendMode specifies how source pixels are composited with the destination.
+// The values mirror the blend modes supported by gg image drawing.
+type BlendMode uint8
+
+const (
+	// BlendNormal performs standard alpha blending (source over destination).
+	BlendNormal BlendMode = iota
+	// BlendMultiply multiplies source and destination colors.
+	BlendMultiply
+	// BlendScreen performs inverse multiply for lighter results.
+	BlendScreen
+	// BlendOverlay combines multiply and screen based on destination brightness.
+	BlendOverlay
+)
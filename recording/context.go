@@ -0,0 +1,86 @@
+package recording
+
+import "github.com/gogpu/gg"
+
+// contextRenderer is a gg.Renderer that captures every Fill and Stroke
+// dispatched by a gg.Context into a Recorder, then delegates to a real
+// renderer so the Context's pixmap stays usable. The Context hands the
+// renderer device-space paths (transforms already applied), so commands
+// are recorded under the identity transform.
+type contextRenderer struct {
+	rec      *Recorder
+	delegate gg.Renderer
+}
+
+// NewRecordingContext creates a gg.Context whose drawing operations are
+// simultaneously rasterized and captured into a recording.Recorder.
+// This lets code written against the Context API — including text
+// (recorded as filled glyph outlines) and image fills — be exported to
+// vector backends without being rewritten against the Recorder API.
+//
+// Draw through the returned Context as usual, then call FinishRecording
+// on the returned Recorder and play the Recording back to a backend:
+//
+//	dc, rec := recording.NewRecordingContext(800, 600)
+//	dc.SetRGB(1, 0, 0)
+//	dc.DrawCircle(400, 300, 50)
+//	dc.Fill()
+//	r := rec.FinishRecording()
+//
+// Because the Context applies its transform before dispatching to the
+// renderer, recorded geometry is in device space.
+func NewRecordingContext(width, height int) (*gg.Context, *Recorder) {
+	rec := NewRecorder(width, height)
+	cr := &contextRenderer{
+		rec:      rec,
+		delegate: gg.NewSoftwareRenderer(width, height),
+	}
+	dc := gg.NewContext(width, height, gg.WithRenderer(cr))
+	return dc, rec
+}
+
+// Fill records the path and fill state, then delegates rasterization.
+func (cr *contextRenderer) Fill(pixmap *gg.Pixmap, path *gg.Path, paint *gg.Paint) error {
+	cr.recordPath(path)
+	cr.rec.SetFillBrush(paint.GetBrush())
+	cr.rec.SetFillRuleGG(paint.FillRule)
+	cr.rec.Fill()
+	return cr.delegate.Fill(pixmap, path, paint)
+}
+
+// Stroke records the path and stroke state, then delegates rasterization.
+func (cr *contextRenderer) Stroke(pixmap *gg.Pixmap, path *gg.Path, paint *gg.Paint) error {
+	cr.recordPath(path)
+	cr.rec.SetStrokeBrush(paint.GetBrush())
+	cr.rec.SetLineWidth(paint.EffectiveLineWidth())
+	cr.rec.SetLineCapGG(paint.EffectiveLineCap())
+	cr.rec.SetLineJoinGG(paint.EffectiveLineJoin())
+	cr.rec.SetMiterLimit(paint.EffectiveMiterLimit())
+	if dash := paint.EffectiveDash(); dash != nil && len(dash.Array) > 0 {
+		cr.rec.SetDash(dash.Array...)
+		cr.rec.SetDashOffset(dash.Offset)
+	} else {
+		cr.rec.ClearDash()
+	}
+	cr.rec.Stroke()
+	return cr.delegate.Stroke(pixmap, path, paint)
+}
+
+// recordPath replays a device-space gg.Path into the Recorder's current path.
+func (cr *contextRenderer) recordPath(path *gg.Path) {
+	cr.rec.ClearPath()
+	path.Iterate(func(verb gg.PathVerb, coords []float64) {
+		switch verb {
+		case gg.MoveTo:
+			cr.rec.MoveTo(coords[0], coords[1])
+		case gg.LineTo:
+			cr.rec.LineTo(coords[0], coords[1])
+		case gg.QuadTo:
+			cr.rec.QuadraticTo(coords[0], coords[1], coords[2], coords[3])
+		case gg.CubicTo:
+			cr.rec.CubicTo(coords[0], coords[1], coords[2], coords[3], coords[4], coords[5])
+		case gg.Close:
+			cr.rec.ClosePath()
+		}
+	})
+}
@@ -0,0 +1,148 @@
+package recording
+
+import "testing"
+
+func TestNewRecordingContext(t *testing.T) {
+	dc, rec := NewRecordingContext(100, 100)
+	if dc == nil || rec == nil {
+		t.Fatal("NewRecordingContext returned nil")
+	}
+	defer func() { _ = dc.Close() }()
+
+	if rec.Width() != 100 || rec.Height() != 100 {
+		t.Errorf("recorder size = %dx%d, want 100x100", rec.Width(), rec.Height())
+	}
+}
+
+func TestRecordingContextCapturesFill(t *testing.T) {
+	dc, rec := NewRecordingContext(100, 100)
+	defer func() { _ = dc.Close() }()
+
+	dc.SetRGB(1, 0, 0)
+	dc.DrawRectangle(20, 20, 40, 40)
+	if err := dc.Fill(); err != nil {
+		t.Fatalf("Fill: %v", err)
+	}
+
+	r := rec.FinishRecording()
+	fills := 0
+	for _, cmd := range r.Commands() {
+		if cmd.Type() == CmdFillPath {
+			fills++
+		}
+	}
+	if fills == 0 {
+		t.Error("no CmdFillPath recorded for Context fill")
+	}
+}
+
+func TestRecordingContextCapturesStroke(t *testing.T) {
+	dc, rec := NewRecordingContext(100, 100)
+	defer func() { _ = dc.Close() }()
+
+	dc.SetRGB(0, 0, 1)
+	dc.SetLineWidth(3)
+	dc.DrawLine(10, 10, 90, 90)
+	if err := dc.Stroke(); err != nil {
+		t.Fatalf("Stroke: %v", err)
+	}
+
+	r := rec.FinishRecording()
+	strokes := 0
+	for _, cmd := range r.Commands() {
+		if cmd.Type() == CmdStrokePath {
+			strokes++
+		}
+	}
+	if strokes == 0 {
+		t.Error("no CmdStrokePath recorded for Context stroke")
+	}
+}
+
+func TestRecordingContextStillRasterizes(t *testing.T) {
+	dc, rec := NewRecordingContext(100, 100)
+	defer func() { _ = dc.Close() }()
+
+	dc.SetRGB(0, 1, 0)
+	dc.DrawRectangle(0, 0, 100, 100)
+	if err := dc.Fill(); err != nil {
+		t.Fatalf("Fill: %v", err)
+	}
+	_ = rec.FinishRecording()
+
+	// The Context's pixmap must still receive pixels — recording is
+	// in addition to rasterization, not instead of it.
+	img := dc.Image()
+	_, g, _, _ := img.At(50, 50).RGBA()
+	if g < 0x8000 {
+		t.Errorf("pixmap center green = %#x, want opaque green", g)
+	}
+}
+
+func TestRecordingContextDeviceSpaceGeometry(t *testing.T) {
+	dc, rec := NewRecordingContext(100, 100)
+	defer func() { _ = dc.Close() }()
+
+	// A translate on the Context must be baked into the recorded path,
+	// since the renderer receives device-space geometry.
+	dc.Translate(30, 0)
+	dc.SetRGB(1, 0, 0)
+	dc.DrawRectangle(0, 0, 20, 20)
+	if err := dc.Fill(); err != nil {
+		t.Fatalf("Fill: %v", err)
+	}
+
+	r := rec.FinishRecording()
+	var found bool
+	for _, cmd := range r.Commands() {
+		fc, ok := cmd.(FillPathCommand)
+		if !ok {
+			continue
+		}
+		path := r.Resources().GetPath(fc.Path)
+		if path == nil {
+			t.Fatal("recorded FillPath references missing path")
+		}
+		coords := path.Coords()
+		if len(coords) == 0 {
+			t.Fatal("recorded path has no coordinates")
+		}
+		minX, maxX := coords[0], coords[0]
+		for i := 0; i < len(coords); i += 2 {
+			if coords[i] < minX {
+				minX = coords[i]
+			}
+			if coords[i] > maxX {
+				maxX = coords[i]
+			}
+		}
+		if minX < 25 || maxX > 55 {
+			t.Errorf("recorded path x range = [%v, %v], want translated to [30, 50]", minX, maxX)
+		}
+		found = true
+	}
+	if !found {
+		t.Error("no FillPathCommand recorded")
+	}
+}
+
+func TestRecordingContextRoundTripText(t *testing.T) {
+	dc, rec := NewRecordingContext(200, 60)
+	defer func() { _ = dc.Close() }()
+
+	// Text renders through the renderer as filled glyph outlines, so a
+	// DrawString must produce at least one recorded fill.
+	dc.SetRGB(0, 0, 0)
+	dc.DrawString("Hi", 10, 40)
+
+	r := rec.FinishRecording()
+	fills := 0
+	for _, cmd := range r.Commands() {
+		if cmd.Type() == CmdFillPath {
+			fills++
+		}
+	}
+	if fills == 0 {
+		t.Skip("no glyph fills recorded (no default font available)")
+	}
+}
@@ -0,0 +1,183 @@
+package recording
+
+import (
+	"image"
+	"math"
+
+	"github.com/gogpu/gg"
+)
+
+// Command decomposition for backends with limited capabilities.
+//
+// When a CapabilityBackend reports that it cannot render a brush natively
+// (e.g., an SVG writer without sweep gradient support), Playback rasterizes
+// the affected fill or stroke through gg over the command's device-space
+// bounds and passes the result to the backend as an image. The backend only
+// needs DrawImage; everything it does support still goes through the normal
+// vector path.
+
+// brushCapability returns the capability a brush requires beyond solid
+// color fills. Solid and nil brushes require none.
+func brushCapability(brush Brush) (Capability, bool) {
+	switch brush.(type) {
+	case *LinearGradientBrush:
+		return CapLinearGradient, true
+	case *RadialGradientBrush:
+		return CapRadialGradient, true
+	case *SweepGradientBrush:
+		return CapSweepGradient, true
+	case *PatternBrush:
+		return CapPattern, true
+	default:
+		return 0, false
+	}
+}
+
+// backendSupports reports whether the backend can render the brush natively.
+// Backends that do not implement CapabilityBackend support everything.
+func backendSupports(backend Backend, brush Brush) bool {
+	required, needed := brushCapability(brush)
+	if !needed {
+		return true
+	}
+	cb, ok := backend.(CapabilityBackend)
+	if !ok {
+		return true
+	}
+	return cb.Supports(required)
+}
+
+// decomposeFillPath rasterizes a path fill whose brush the backend does not
+// support and sends the result as an image draw.
+func (r *Recording) decomposeFillPath(backend Backend, path *gg.Path, brush Brush, rule FillRule) {
+	if path == nil || brush == nil {
+		return
+	}
+	// Pad by one pixel for anti-aliased edges.
+	region := r.clampRegion(path.Bounds(), 1)
+	r.rasterizeToBackend(backend, region, func(ctx *gg.Context) {
+		ctx.SetFillBrush(BrushToGG(brush))
+		ctx.SetFillRule(decomposeFillRule(rule))
+		buildContextPath(ctx, path)
+		_ = ctx.Fill()
+	})
+}
+
+// decomposeStrokePath rasterizes a path stroke whose brush the backend does
+// not support and sends the result as an image draw.
+func (r *Recording) decomposeStrokePath(backend Backend, path *gg.Path, brush Brush, stroke Stroke) {
+	if path == nil || brush == nil {
+		return
+	}
+	// Pad by the full stroke width: half extends past the path on each
+	// side, and the extra half covers caps and moderate miter joins.
+	pad := int(math.Ceil(stroke.Width)) + 1
+	region := r.clampRegion(path.Bounds(), pad)
+	r.rasterizeToBackend(backend, region, func(ctx *gg.Context) {
+		ctx.SetStrokeBrush(BrushToGG(brush))
+		ctx.SetLineWidth(stroke.Width)
+		ctx.SetLineCap(decomposeLineCap(stroke.Cap))
+		ctx.SetLineJoin(decomposeLineJoin(stroke.Join))
+		ctx.SetMiterLimit(stroke.MiterLimit)
+		if len(stroke.DashPattern) > 0 {
+			ctx.SetDash(stroke.DashPattern...)
+			ctx.SetDashOffset(stroke.DashOffset)
+		}
+		buildContextPath(ctx, path)
+		_ = ctx.Stroke()
+	})
+}
+
+// decomposeFillRect rasterizes a rectangle fill whose brush the backend does
+// not support and sends the result as an image draw.
+func (r *Recording) decomposeFillRect(backend Backend, rect Rect, brush Brush) {
+	if brush == nil {
+		return
+	}
+	bounds := image.Rect(
+		int(math.Floor(rect.MinX)), int(math.Floor(rect.MinY)),
+		int(math.Ceil(rect.MaxX)), int(math.Ceil(rect.MaxY)),
+	)
+	region := r.clampRegion(bounds, 1)
+	r.rasterizeToBackend(backend, region, func(ctx *gg.Context) {
+		ctx.SetFillBrush(BrushToGG(brush))
+		ctx.DrawRectangle(rect.MinX, rect.MinY, rect.Width(), rect.Height())
+		_ = ctx.Fill()
+	})
+}
+
+// clampRegion pads device-space bounds and clips them to the canvas.
+func (r *Recording) clampRegion(bounds image.Rectangle, pad int) image.Rectangle {
+	return bounds.Inset(-pad).Intersect(image.Rect(0, 0, r.width, r.height))
+}
+
+// rasterizeToBackend renders draw onto a transparent gg context covering
+// region (in device space) and passes the result to the backend as an image.
+func (r *Recording) rasterizeToBackend(backend Backend, region image.Rectangle, draw func(ctx *gg.Context)) {
+	if region.Empty() {
+		return
+	}
+	ctx := gg.NewContext(region.Dx(), region.Dy())
+	ctx.Translate(-float64(region.Min.X), -float64(region.Min.Y))
+	draw(ctx)
+
+	src := NewRect(0, 0, float64(region.Dx()), float64(region.Dy()))
+	dst := NewRect(float64(region.Min.X), float64(region.Min.Y),
+		float64(region.Dx()), float64(region.Dy()))
+	backend.DrawImage(ctx.Image(), src, dst, DefaultImageOptions())
+	_ = ctx.Close()
+}
+
+// buildContextPath walks path verbs/coords and adds them to the context.
+func buildContextPath(ctx *gg.Context, path *gg.Path) {
+	path.Iterate(func(verb gg.PathVerb, coords []float64) {
+		switch verb {
+		case gg.MoveTo:
+			ctx.MoveTo(coords[0], coords[1])
+		case gg.LineTo:
+			ctx.LineTo(coords[0], coords[1])
+		case gg.QuadTo:
+			ctx.QuadraticTo(coords[0], coords[1], coords[2], coords[3])
+		case gg.CubicTo:
+			ctx.CubicTo(coords[0], coords[1], coords[2], coords[3], coords[4], coords[5])
+		case gg.Close:
+			ctx.ClosePath()
+		}
+	})
+}
+
+// decomposeFillRule converts recording.FillRule to gg.FillRule.
+func decomposeFillRule(rule FillRule) gg.FillRule {
+	if rule == FillRuleEvenOdd {
+		return gg.FillRuleEvenOdd
+	}
+	return gg.FillRuleNonZero
+}
+
+// decomposeLineCap converts recording.LineCap to gg.LineCap.
+func decomposeLineCap(lineCap LineCap) gg.LineCap {
+	switch lineCap {
+	case LineCapRound:
+		return gg.LineCapRound
+	case LineCapSquare:
+		return gg.LineCapSquare
+	default:
+		return gg.LineCapButt
+	}
+}
+
+// decomposeLineJoin converts recording.LineJoin to gg.LineJoin.
+func decomposeLineJoin(join LineJoin) gg.LineJoin {
+	switch join {
+	case LineJoinRound:
+		return gg.LineJoinRound
+	case LineJoinBevel:
+		return gg.LineJoinBevel
+	case LineJoinMiterClip:
+		return gg.LineJoinMiterClip
+	case LineJoinArcs:
+		return gg.LineJoinArcs
+	default:
+		return gg.LineJoinMiter
+	}
+}
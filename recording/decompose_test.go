@@ -0,0 +1,171 @@
+package recording
+
+import (
+	"image"
+	"testing"
+
+	"github.com/gogpu/gg"
+)
+
+// limitedBackend is a CapabilityBackend that rejects every capability and
+// records what playback hands it.
+type limitedBackend struct {
+	playbackMockBackend
+	fillPathCalls  int
+	fillRectCalls  int
+	strokeCalls    int
+	drawImageCalls int
+	images         []image.Image
+	dstRects       []Rect
+}
+
+func (m *limitedBackend) Supports(Capability) bool { return false }
+
+func (m *limitedBackend) FillPath(_ *gg.Path, _ Brush, _ FillRule) { m.fillPathCalls++ }
+func (m *limitedBackend) StrokePath(_ *gg.Path, _ Brush, _ Stroke) { m.strokeCalls++ }
+func (m *limitedBackend) FillRect(_ Rect, _ Brush)                 { m.fillRectCalls++ }
+func (m *limitedBackend) DrawImage(img image.Image, _, dst Rect, _ ImageOptions) {
+	m.drawImageCalls++
+	m.images = append(m.images, img)
+	m.dstRects = append(m.dstRects, dst)
+}
+
+func TestBrushCapability(t *testing.T) {
+	tests := []struct {
+		name   string
+		brush  Brush
+		want   Capability
+		needed bool
+	}{
+		{"nil", nil, 0, false},
+		{"solid", NewSolidBrush(gg.Red), 0, false},
+		{"linear", NewLinearGradientBrush(0, 0, 10, 0), CapLinearGradient, true},
+		{"radial", NewRadialGradientBrush(5, 5, 0, 5), CapRadialGradient, true},
+		{"sweep", NewSweepGradientBrush(5, 5, 0), CapSweepGradient, true},
+		{"pattern", NewPatternBrush(ImageRef(0)), CapPattern, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, needed := brushCapability(tt.brush)
+			if needed != tt.needed || (needed && got != tt.want) {
+				t.Errorf("brushCapability() = (%v, %v), want (%v, %v)",
+					got, needed, tt.want, tt.needed)
+			}
+		})
+	}
+}
+
+func TestBackendSupportsWithoutCapabilityInterface(t *testing.T) {
+	// Backends that do not implement CapabilityBackend support everything.
+	backend := &playbackMockBackend{}
+	grad := NewLinearGradientBrush(0, 0, 10, 0).AddColorStop(0, gg.Red)
+	if !backendSupports(backend, grad) {
+		t.Error("plain backend should be assumed to support all brushes")
+	}
+}
+
+func TestPlaybackDecomposesGradientFill(t *testing.T) {
+	rec := NewRecorder(100, 100)
+	grad := NewLinearGradientBrush(0, 0, 100, 0).
+		AddColorStop(0, gg.Red).
+		AddColorStop(1, gg.Blue)
+	rec.SetFillStyle(grad)
+	rec.DrawRectangle(10, 10, 80, 80)
+	rec.Fill()
+
+	backend := &limitedBackend{}
+	if err := rec.FinishRecording().Playback(backend); err != nil {
+		t.Fatalf("Playback failed: %v", err)
+	}
+
+	if backend.fillPathCalls != 0 {
+		t.Errorf("FillPath called %d times, want 0 (decomposed)", backend.fillPathCalls)
+	}
+	if backend.drawImageCalls != 1 {
+		t.Fatalf("DrawImage called %d times, want 1", backend.drawImageCalls)
+	}
+
+	// The rasterized region covers the rectangle (plus anti-aliasing pad).
+	dst := backend.dstRects[0]
+	if dst.MinX > 10 || dst.MinY > 10 || dst.MaxX < 90 || dst.MaxY < 90 {
+		t.Errorf("decomposed region %+v does not cover the rectangle", dst)
+	}
+
+	// The image carries the actual gradient: red on the left, blue on the right.
+	img := backend.images[0]
+	b := img.Bounds()
+	midY := (b.Min.Y + b.Max.Y) / 2
+	lr, _, lb, _ := img.At(b.Min.X+5, midY).RGBA()
+	rr, _, rb, _ := img.At(b.Max.X-5, midY).RGBA()
+	if lr <= lb {
+		t.Errorf("left pixel = (%#x, %#x), want red-dominant", lr, lb)
+	}
+	if rb <= rr {
+		t.Errorf("right pixel = (%#x, %#x), want blue-dominant", rr, rb)
+	}
+}
+
+func TestPlaybackDecomposesGradientStroke(t *testing.T) {
+	rec := NewRecorder(100, 100)
+	grad := NewLinearGradientBrush(0, 0, 100, 0).
+		AddColorStop(0, gg.Red).
+		AddColorStop(1, gg.Blue)
+	rec.SetStrokeStyle(grad)
+	rec.SetLineWidth(4)
+	rec.MoveTo(10, 50)
+	rec.LineTo(90, 50)
+	rec.Stroke()
+
+	backend := &limitedBackend{}
+	if err := rec.FinishRecording().Playback(backend); err != nil {
+		t.Fatalf("Playback failed: %v", err)
+	}
+
+	if backend.strokeCalls != 0 {
+		t.Errorf("StrokePath called %d times, want 0 (decomposed)", backend.strokeCalls)
+	}
+	if backend.drawImageCalls != 1 {
+		t.Fatalf("DrawImage called %d times, want 1", backend.drawImageCalls)
+	}
+}
+
+func TestPlaybackSolidFillStaysVector(t *testing.T) {
+	rec := NewRecorder(100, 100)
+	rec.SetRGB(1, 0, 0)
+	rec.DrawRectangle(10, 10, 80, 80)
+	rec.Fill()
+
+	backend := &limitedBackend{}
+	if err := rec.FinishRecording().Playback(backend); err != nil {
+		t.Fatalf("Playback failed: %v", err)
+	}
+
+	if backend.fillPathCalls != 1 {
+		t.Errorf("FillPath called %d times, want 1 (solid stays vector)", backend.fillPathCalls)
+	}
+	if backend.drawImageCalls != 0 {
+		t.Errorf("DrawImage called %d times, want 0", backend.drawImageCalls)
+	}
+}
+
+func TestDecomposeRegionClampedToCanvas(t *testing.T) {
+	rec := NewRecorder(50, 50)
+	grad := NewLinearGradientBrush(0, 0, 50, 0).AddColorStop(0, gg.Red)
+	rec.SetFillStyle(grad)
+	// Rectangle extends beyond the canvas on all sides.
+	rec.DrawRectangle(-20, -20, 200, 200)
+	rec.Fill()
+
+	backend := &limitedBackend{}
+	if err := rec.FinishRecording().Playback(backend); err != nil {
+		t.Fatalf("Playback failed: %v", err)
+	}
+
+	if backend.drawImageCalls != 1 {
+		t.Fatalf("DrawImage called %d times, want 1", backend.drawImageCalls)
+	}
+	dst := backend.dstRects[0]
+	if dst.MinX < 0 || dst.MinY < 0 || dst.MaxX > 50 || dst.MaxY > 50 {
+		t.Errorf("decomposed region %+v exceeds the 50x50 canvas", dst)
+	}
+}
@@ -2,6 +2,7 @@ package recording
 
 import (
 	"image"
+	"slices"
 
 	"github.com/gogpu/gg"
 	"github.com/gogpu/gg/text"
@@ -22,6 +23,14 @@ func (r FontRef) IsValid() bool {
 // Resources are stored in slices indexed by their reference types.
 // Each Add operation clones mutable resources to ensure immutability.
 //
+// Paths, brushes, and images are deduplicated by content hash: adding a
+// resource identical to one already pooled returns the existing reference
+// instead of growing the pool. Icon-heavy UIs that draw the same path with
+// the same brush every frame therefore record a single copy, and playback
+// caches keyed by reference stay warm. Hash candidates are verified
+// field-by-field before reuse, so a hash collision costs a comparison,
+// never a wrong reference.
+//
 // ResourcePool is not safe for concurrent use. If concurrent access is needed,
 // external synchronization must be provided.
 type ResourcePool struct {
@@ -29,32 +38,50 @@ type ResourcePool struct {
 	brushes []Brush
 	images  []image.Image
 	fonts   []text.Face
+
+	// Content-hash indices: hash → candidate pool indices.
+	pathIndex  map[uint64][]uint32
+	brushIndex map[uint64][]uint32
+	imageIndex map[uint64][]uint32
+
+	// fontGlyphs tracks, per pooled font, which glyphs the recording uses.
+	// Export backends consult it to subset embedded fonts.
+	fontGlyphs []map[text.GlyphID]struct{}
 }
 
 // NewResourcePool creates an empty resource pool with pre-allocated capacity.
 func NewResourcePool() *ResourcePool {
 	return &ResourcePool{
-		paths:   make([]*gg.Path, 0, 64),
-		brushes: make([]Brush, 0, 32),
-		images:  make([]image.Image, 0, 8),
-		fonts:   make([]text.Face, 0, 4),
+		paths:      make([]*gg.Path, 0, 64),
+		brushes:    make([]Brush, 0, 32),
+		images:     make([]image.Image, 0, 8),
+		fonts:      make([]text.Face, 0, 4),
+		pathIndex:  make(map[uint64][]uint32),
+		brushIndex: make(map[uint64][]uint32),
+		imageIndex: make(map[uint64][]uint32),
 	}
 }
 
 // AddPath adds a path to the pool and returns its reference.
 // The path is cloned to ensure immutability of the recording.
+// Paths identical to an already pooled path return the existing reference.
 func (p *ResourcePool) AddPath(path *gg.Path) PathRef {
-	if path == nil {
-		// Add nil as-is to preserve the reference
-		p.paths = append(p.paths, nil)
-		// #nosec G115 -- pool size is bounded by available memory, well under uint32 max
-		return PathRef(uint32(len(p.paths) - 1))
-	}
-	// Clone path to ensure immutability
-	cloned := path.Clone()
-	p.paths = append(p.paths, cloned)
+	hash := hashPath(path)
+	for _, idx := range p.pathIndex[hash] {
+		if pathsEqual(p.paths[idx], path) {
+			return PathRef(idx)
+		}
+	}
+
+	if path != nil {
+		// Clone path to ensure immutability
+		path = path.Clone()
+	}
+	p.paths = append(p.paths, path)
 	// #nosec G115 -- pool size is bounded by available memory, well under uint32 max
-	return PathRef(uint32(len(p.paths) - 1))
+	idx := uint32(len(p.paths) - 1)
+	p.pathIndex[hash] = append(p.pathIndex[hash], idx)
+	return PathRef(idx)
 }
 
 // GetPath returns the path for the given reference.
@@ -73,10 +100,20 @@ func (p *ResourcePool) PathCount() int {
 
 // AddBrush adds a brush to the pool and returns its reference.
 // Brushes are stored directly as they are typically immutable value types.
+// Brushes identical to an already pooled brush return the existing reference.
 func (p *ResourcePool) AddBrush(brush Brush) BrushRef {
+	hash := hashBrush(brush)
+	for _, idx := range p.brushIndex[hash] {
+		if brushesEqual(p.brushes[idx], brush) {
+			return BrushRef(idx)
+		}
+	}
+
 	p.brushes = append(p.brushes, brush)
 	// #nosec G115 -- pool size is bounded by available memory, well under uint32 max
-	return BrushRef(uint32(len(p.brushes) - 1))
+	idx := uint32(len(p.brushes) - 1)
+	p.brushIndex[hash] = append(p.brushIndex[hash], idx)
+	return BrushRef(idx)
 }
 
 // GetBrush returns the brush for the given reference.
@@ -95,10 +132,25 @@ func (p *ResourcePool) BrushCount() int {
 
 // AddImage adds an image to the pool and returns its reference.
 // Images are stored directly as Go's image.Image is already immutable.
+// RGBA and NRGBA images are deduplicated by pixel content; other image
+// types are stored without deduplication.
 func (p *ResourcePool) AddImage(img image.Image) ImageRef {
+	hash, hashable := hashImage(img)
+	if hashable {
+		for _, idx := range p.imageIndex[hash] {
+			if imagesEqual(p.images[idx], img) {
+				return ImageRef(idx)
+			}
+		}
+	}
+
 	p.images = append(p.images, img)
 	// #nosec G115 -- pool size is bounded by available memory, well under uint32 max
-	return ImageRef(uint32(len(p.images) - 1))
+	idx := uint32(len(p.images) - 1)
+	if hashable {
+		p.imageIndex[hash] = append(p.imageIndex[hash], idx)
+	}
+	return ImageRef(idx)
 }
 
 // GetImage returns the image for the given reference.
@@ -117,8 +169,16 @@ func (p *ResourcePool) ImageCount() int {
 
 // AddFont adds a font face to the pool and returns its reference.
 // Font faces are stored directly as they are already immutable and safe to share.
+// Adding a face already in the pool returns the existing reference.
 func (p *ResourcePool) AddFont(face text.Face) FontRef {
+	for i, f := range p.fonts {
+		if f == face {
+			// #nosec G115 -- pool size is bounded by available memory, well under uint32 max
+			return FontRef(uint32(i))
+		}
+	}
 	p.fonts = append(p.fonts, face)
+	p.fontGlyphs = append(p.fontGlyphs, nil)
 	// #nosec G115 -- pool size is bounded by available memory, well under uint32 max
 	return FontRef(uint32(len(p.fonts) - 1))
 }
@@ -137,6 +197,52 @@ func (p *ResourcePool) FontCount() int {
 	return len(p.fonts)
 }
 
+// MarkGlyphsUsed records that s is rendered with the referenced font, adding
+// the glyphs it shapes to the font's usage set. The Recorder calls this for
+// every recorded text draw; export backends read the set via UsedGlyphs to
+// subset embedded fonts.
+func (p *ResourcePool) MarkGlyphsUsed(ref FontRef, s string) {
+	if int(ref) >= len(p.fonts) || p.fonts[ref] == nil {
+		return
+	}
+	used := p.fontGlyphs[ref]
+	if used == nil {
+		used = make(map[text.GlyphID]struct{})
+		p.fontGlyphs[ref] = used
+	}
+	for g := range p.fonts[ref].Glyphs(s) {
+		used[g.GID] = struct{}{}
+	}
+}
+
+// UsedGlyphs returns the glyphs recorded for the referenced font, sorted by
+// glyph ID. Returns nil if the reference is invalid or no glyphs were marked.
+func (p *ResourcePool) UsedGlyphs(ref FontRef) []text.GlyphID {
+	if int(ref) >= len(p.fontGlyphs) || len(p.fontGlyphs[ref]) == 0 {
+		return nil
+	}
+	glyphs := make([]text.GlyphID, 0, len(p.fontGlyphs[ref]))
+	for g := range p.fontGlyphs[ref] {
+		glyphs = append(glyphs, g)
+	}
+	slices.Sort(glyphs)
+	return glyphs
+}
+
+// FontData returns the raw font bytes (TTF/OTF) for the referenced font.
+// Returns nil if the reference is invalid or the face has no retrievable
+// source. Export backends embed these bytes, subset to UsedGlyphs.
+func (p *ResourcePool) FontData(ref FontRef) []byte {
+	if int(ref) >= len(p.fonts) || p.fonts[ref] == nil {
+		return nil
+	}
+	src := p.fonts[ref].Source()
+	if src == nil {
+		return nil
+	}
+	return src.Data()
+}
+
 // Clear removes all resources from the pool.
 // This does not release the underlying memory; use NewResourcePool for that.
 func (p *ResourcePool) Clear() {
@@ -144,6 +250,10 @@ func (p *ResourcePool) Clear() {
 	p.brushes = p.brushes[:0]
 	p.images = p.images[:0]
 	p.fonts = p.fonts[:0]
+	p.fontGlyphs = p.fontGlyphs[:0]
+	clear(p.pathIndex)
+	clear(p.brushIndex)
+	clear(p.imageIndex)
 }
 
 // Clone creates a deep copy of the resource pool.
@@ -170,5 +280,36 @@ func (p *ResourcePool) Clone() *ResourcePool {
 	copy(clone.images, p.images)
 	copy(clone.fonts, p.fonts)
 
+	// Deep-copy glyph usage sets (mutable maps)
+	clone.fontGlyphs = make([]map[text.GlyphID]struct{}, len(p.fontGlyphs))
+	for i, used := range p.fontGlyphs {
+		if used == nil {
+			continue
+		}
+		m := make(map[text.GlyphID]struct{}, len(used))
+		for g := range used {
+			m[g] = struct{}{}
+		}
+		clone.fontGlyphs[i] = m
+	}
+
+	// Rebuild the content-hash indices so the clone keeps deduplicating.
+	clone.pathIndex = make(map[uint64][]uint32, len(p.pathIndex))
+	for i, path := range clone.paths {
+		h := hashPath(path)
+		clone.pathIndex[h] = append(clone.pathIndex[h], uint32(i)) // #nosec G115
+	}
+	clone.brushIndex = make(map[uint64][]uint32, len(p.brushIndex))
+	for i, brush := range clone.brushes {
+		h := hashBrush(brush)
+		clone.brushIndex[h] = append(clone.brushIndex[h], uint32(i)) // #nosec G115
+	}
+	clone.imageIndex = make(map[uint64][]uint32, len(p.imageIndex))
+	for i, img := range clone.images {
+		if h, ok := hashImage(img); ok {
+			clone.imageIndex[h] = append(clone.imageIndex[h], uint32(i)) // #nosec G115
+		}
+	}
+
 	return clone
 }
@@ -0,0 +1,262 @@
+package recording
+
+import (
+	"bytes"
+	"image"
+	"math"
+
+	"github.com/gogpu/gg"
+)
+
+// Content hashing and equality for ResourcePool deduplication.
+//
+// Resources are hashed with FNV-1a over their defining fields. The hash only
+// selects candidates; AddPath/AddBrush/AddImage always verify equality before
+// reusing a reference, so collisions are harmless.
+
+const (
+	fnvOffset64 = 14695981039346656037
+	fnvPrime64  = 1099511628211
+)
+
+func fnvByte(h uint64, b byte) uint64 {
+	return (h ^ uint64(b)) * fnvPrime64
+}
+
+func fnvBytes(h uint64, data []byte) uint64 {
+	for _, b := range data {
+		h = fnvByte(h, b)
+	}
+	return h
+}
+
+func fnvUint64(h, v uint64) uint64 {
+	for i := 0; i < 8; i++ {
+		h = fnvByte(h, byte(v))
+		v >>= 8
+	}
+	return h
+}
+
+func fnvFloat64(h uint64, f float64) uint64 {
+	return fnvUint64(h, math.Float64bits(f))
+}
+
+func fnvColor(h uint64, c gg.RGBA) uint64 {
+	h = fnvFloat64(h, c.R)
+	h = fnvFloat64(h, c.G)
+	h = fnvFloat64(h, c.B)
+	return fnvFloat64(h, c.A)
+}
+
+func fnvPoint(h uint64, pt gg.Point) uint64 {
+	h = fnvFloat64(h, pt.X)
+	return fnvFloat64(h, pt.Y)
+}
+
+func fnvStops(h uint64, stops []GradientStop) uint64 {
+	for _, s := range stops {
+		h = fnvFloat64(h, s.Offset)
+		h = fnvColor(h, s.Color)
+	}
+	return h
+}
+
+// hashPath hashes a path's verbs and coordinates. A nil path hashes to the
+// FNV offset basis so nil references also deduplicate.
+func hashPath(path *gg.Path) uint64 {
+	h := uint64(fnvOffset64)
+	if path == nil {
+		return h
+	}
+	for _, v := range path.Verbs() {
+		h = fnvByte(h, byte(v))
+	}
+	for _, c := range path.Coords() {
+		h = fnvFloat64(h, c)
+	}
+	return h
+}
+
+// pathsEqual reports whether two paths have identical verbs and coordinates.
+func pathsEqual(a, b *gg.Path) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	av, bv := a.Verbs(), b.Verbs()
+	if len(av) != len(bv) {
+		return false
+	}
+	for i := range av {
+		if av[i] != bv[i] {
+			return false
+		}
+	}
+	ac, bc := a.Coords(), b.Coords()
+	if len(ac) != len(bc) {
+		return false
+	}
+	for i := range ac {
+		if ac[i] != bc[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// Brush type tags keep hashes of different brush kinds distinct even when
+// their field bytes coincide.
+const (
+	brushTagSolid byte = iota + 1
+	brushTagLinear
+	brushTagRadial
+	brushTagSweep
+	brushTagPattern
+)
+
+// hashBrush hashes a brush's defining fields.
+func hashBrush(b Brush) uint64 {
+	h := uint64(fnvOffset64)
+	switch br := b.(type) {
+	case SolidBrush:
+		h = fnvByte(h, brushTagSolid)
+		h = fnvColor(h, br.Color)
+	case *LinearGradientBrush:
+		h = fnvByte(h, brushTagLinear)
+		h = fnvPoint(h, br.Start)
+		h = fnvPoint(h, br.End)
+		h = fnvStops(h, br.Stops)
+		h = fnvByte(h, byte(br.Extend))
+	case *RadialGradientBrush:
+		h = fnvByte(h, brushTagRadial)
+		h = fnvPoint(h, br.Center)
+		h = fnvPoint(h, br.Focus)
+		h = fnvFloat64(h, br.StartRadius)
+		h = fnvFloat64(h, br.EndRadius)
+		h = fnvStops(h, br.Stops)
+		h = fnvByte(h, byte(br.Extend))
+	case *SweepGradientBrush:
+		h = fnvByte(h, brushTagSweep)
+		h = fnvPoint(h, br.Center)
+		h = fnvFloat64(h, br.StartAngle)
+		h = fnvFloat64(h, br.EndAngle)
+		h = fnvStops(h, br.Stops)
+		h = fnvByte(h, byte(br.Extend))
+	case *PatternBrush:
+		h = fnvByte(h, brushTagPattern)
+		h = fnvUint64(h, uint64(br.Image))
+		h = fnvByte(h, byte(br.Repeat))
+		h = fnvFloat64(h, br.Transform.A)
+		h = fnvFloat64(h, br.Transform.B)
+		h = fnvFloat64(h, br.Transform.C)
+		h = fnvFloat64(h, br.Transform.D)
+		h = fnvFloat64(h, br.Transform.E)
+		h = fnvFloat64(h, br.Transform.F)
+	}
+	return h
+}
+
+// brushesEqual reports whether two brushes have identical definitions.
+func brushesEqual(a, b Brush) bool {
+	switch ba := a.(type) {
+	case SolidBrush:
+		bb, ok := b.(SolidBrush)
+		return ok && ba.Color == bb.Color
+	case *LinearGradientBrush:
+		bb, ok := b.(*LinearGradientBrush)
+		return ok && ba.Start == bb.Start && ba.End == bb.End &&
+			ba.Extend == bb.Extend && stopsEqual(ba.Stops, bb.Stops)
+	case *RadialGradientBrush:
+		bb, ok := b.(*RadialGradientBrush)
+		return ok && ba.Center == bb.Center && ba.Focus == bb.Focus &&
+			ba.StartRadius == bb.StartRadius && ba.EndRadius == bb.EndRadius &&
+			ba.Extend == bb.Extend && stopsEqual(ba.Stops, bb.Stops)
+	case *SweepGradientBrush:
+		bb, ok := b.(*SweepGradientBrush)
+		return ok && ba.Center == bb.Center && ba.StartAngle == bb.StartAngle &&
+			ba.EndAngle == bb.EndAngle && ba.Extend == bb.Extend &&
+			stopsEqual(ba.Stops, bb.Stops)
+	case *PatternBrush:
+		bb, ok := b.(*PatternBrush)
+		return ok && ba.Image == bb.Image && ba.Repeat == bb.Repeat &&
+			ba.Transform == bb.Transform
+	default:
+		return false
+	}
+}
+
+func stopsEqual(a, b []GradientStop) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// Image type tags keep RGBA and NRGBA hashes distinct for identical bytes
+// (the same bytes decode to different colors under the two models).
+const (
+	imageTagRGBA byte = iota + 1
+	imageTagNRGBA
+)
+
+// hashImage hashes an image's bounds and pixel content. Only RGBA and NRGBA
+// images are hashable; for other types it returns false and the pool stores
+// the image without deduplication.
+func hashImage(img image.Image) (uint64, bool) {
+	switch im := img.(type) {
+	case *image.RGBA:
+		return hashPixels(im.Rect, im.Pix, im.Stride, imageTagRGBA), true
+	case *image.NRGBA:
+		return hashPixels(im.Rect, im.Pix, im.Stride, imageTagNRGBA), true
+	default:
+		return 0, false
+	}
+}
+
+// hashPixels hashes image bounds plus the visible bytes of each pixel row
+// (stride padding is excluded so layout does not affect the hash).
+func hashPixels(rect image.Rectangle, pix []byte, stride int, tag byte) uint64 {
+	h := fnvByte(fnvOffset64, tag)
+	h = fnvUint64(h, uint64(int64(rect.Min.X)))
+	h = fnvUint64(h, uint64(int64(rect.Min.Y)))
+	h = fnvUint64(h, uint64(int64(rect.Max.X)))
+	h = fnvUint64(h, uint64(int64(rect.Max.Y)))
+	rowLen := rect.Dx() * 4
+	for y := 0; y < rect.Dy(); y++ {
+		h = fnvBytes(h, pix[y*stride:y*stride+rowLen])
+	}
+	return h
+}
+
+// imagesEqual reports whether two hashable images have identical bounds and
+// pixel content.
+func imagesEqual(a, b image.Image) bool {
+	switch ia := a.(type) {
+	case *image.RGBA:
+		ib, ok := b.(*image.RGBA)
+		return ok && pixelsEqual(ia.Rect, ia.Pix, ia.Stride, ib.Rect, ib.Pix, ib.Stride)
+	case *image.NRGBA:
+		ib, ok := b.(*image.NRGBA)
+		return ok && pixelsEqual(ia.Rect, ia.Pix, ia.Stride, ib.Rect, ib.Pix, ib.Stride)
+	default:
+		return false
+	}
+}
+
+func pixelsEqual(ar image.Rectangle, apix []byte, astride int, br image.Rectangle, bpix []byte, bstride int) bool {
+	if ar != br {
+		return false
+	}
+	rowLen := ar.Dx() * 4
+	for y := 0; y < ar.Dy(); y++ {
+		if !bytes.Equal(apix[y*astride:y*astride+rowLen], bpix[y*bstride:y*bstride+rowLen]) {
+			return false
+		}
+	}
+	return true
+}
@@ -3,9 +3,11 @@ package recording
 import (
 	"image"
 	"image/color"
+	"os"
 	"testing"
 
 	"github.com/gogpu/gg"
+	"github.com/gogpu/gg/text"
 )
 
 func TestNewResourcePool(t *testing.T) {
@@ -475,3 +477,238 @@ func BenchmarkResourcePool_Clone(b *testing.B) {
 		_ = pool.Clone()
 	}
 }
+
+// --- Content-hash deduplication ---
+
+func TestResourcePool_AddPath_Deduplicates(t *testing.T) {
+	pool := NewResourcePool()
+
+	icon := gg.NewPath()
+	icon.Circle(50, 50, 20)
+
+	ref1 := pool.AddPath(icon)
+	// A separately built but identical path deduplicates too.
+	icon2 := gg.NewPath()
+	icon2.Circle(50, 50, 20)
+	ref2 := pool.AddPath(icon2)
+
+	if ref1 != ref2 {
+		t.Errorf("identical paths got refs %d and %d, want shared", ref1, ref2)
+	}
+	if pool.PathCount() != 1 {
+		t.Errorf("PathCount() = %d, want 1", pool.PathCount())
+	}
+
+	// Different geometry still gets a new reference.
+	other := gg.NewPath()
+	other.Circle(50, 50, 21)
+	if ref3 := pool.AddPath(other); ref3 == ref1 {
+		t.Error("different path reused the same reference")
+	}
+	if pool.PathCount() != 2 {
+		t.Errorf("PathCount() = %d, want 2", pool.PathCount())
+	}
+}
+
+func TestResourcePool_AddPath_DeduplicatesNil(t *testing.T) {
+	pool := NewResourcePool()
+	ref1 := pool.AddPath(nil)
+	ref2 := pool.AddPath(nil)
+	if ref1 != ref2 {
+		t.Errorf("nil paths got refs %d and %d, want shared", ref1, ref2)
+	}
+	if pool.PathCount() != 1 {
+		t.Errorf("PathCount() = %d, want 1", pool.PathCount())
+	}
+}
+
+func TestResourcePool_AddBrush_Deduplicates(t *testing.T) {
+	pool := NewResourcePool()
+
+	ref1 := pool.AddBrush(NewSolidBrush(gg.Red))
+	ref2 := pool.AddBrush(NewSolidBrush(gg.Red))
+	if ref1 != ref2 {
+		t.Errorf("identical solid brushes got refs %d and %d, want shared", ref1, ref2)
+	}
+
+	// Separately built but identical gradients deduplicate.
+	g1 := NewLinearGradientBrush(0, 0, 100, 0).AddColorStop(0, gg.Red).AddColorStop(1, gg.Blue)
+	g2 := NewLinearGradientBrush(0, 0, 100, 0).AddColorStop(0, gg.Red).AddColorStop(1, gg.Blue)
+	gref1 := pool.AddBrush(g1)
+	gref2 := pool.AddBrush(g2)
+	if gref1 != gref2 {
+		t.Errorf("identical gradients got refs %d and %d, want shared", gref1, gref2)
+	}
+
+	// A differing stop produces a new reference.
+	g3 := NewLinearGradientBrush(0, 0, 100, 0).AddColorStop(0, gg.Red).AddColorStop(1, gg.Green)
+	if gref3 := pool.AddBrush(g3); gref3 == gref1 {
+		t.Error("different gradient reused the same reference")
+	}
+
+	if pool.BrushCount() != 3 {
+		t.Errorf("BrushCount() = %d, want 3", pool.BrushCount())
+	}
+}
+
+func TestResourcePool_AddImage_Deduplicates(t *testing.T) {
+	pool := NewResourcePool()
+
+	makeImg := func(c color.RGBA) *image.RGBA {
+		img := image.NewRGBA(image.Rect(0, 0, 4, 4))
+		for y := 0; y < 4; y++ {
+			for x := 0; x < 4; x++ {
+				img.Set(x, y, c)
+			}
+		}
+		return img
+	}
+
+	ref1 := pool.AddImage(makeImg(color.RGBA{R: 255, A: 255}))
+	ref2 := pool.AddImage(makeImg(color.RGBA{R: 255, A: 255}))
+	if ref1 != ref2 {
+		t.Errorf("identical images got refs %d and %d, want shared", ref1, ref2)
+	}
+	if pool.ImageCount() != 1 {
+		t.Errorf("ImageCount() = %d, want 1", pool.ImageCount())
+	}
+
+	if ref3 := pool.AddImage(makeImg(color.RGBA{G: 255, A: 255})); ref3 == ref1 {
+		t.Error("different image reused the same reference")
+	}
+}
+
+func TestResourcePool_Clear_ResetsDeduplication(t *testing.T) {
+	pool := NewResourcePool()
+
+	p := gg.NewPath()
+	p.Rectangle(0, 0, 10, 10)
+	pool.AddPath(p)
+	pool.Clear()
+
+	ref := pool.AddPath(p)
+	if ref != 0 {
+		t.Errorf("ref after Clear = %d, want 0", ref)
+	}
+	if pool.PathCount() != 1 {
+		t.Errorf("PathCount() = %d, want 1", pool.PathCount())
+	}
+}
+
+func TestResourcePool_Clone_KeepsDeduplicating(t *testing.T) {
+	pool := NewResourcePool()
+
+	p := gg.NewPath()
+	p.Rectangle(0, 0, 10, 10)
+	ref := pool.AddPath(p)
+	pool.AddBrush(NewSolidBrush(gg.Red))
+
+	clone := pool.Clone()
+	if got := clone.AddPath(p); got != ref {
+		t.Errorf("clone.AddPath = %d, want existing ref %d", got, ref)
+	}
+	if got := clone.AddBrush(NewSolidBrush(gg.Red)); got != 0 {
+		t.Errorf("clone.AddBrush = %d, want existing ref 0", got)
+	}
+	if clone.PathCount() != 1 || clone.BrushCount() != 1 {
+		t.Errorf("clone counts = %d paths, %d brushes, want 1 and 1",
+			clone.PathCount(), clone.BrushCount())
+	}
+}
+
+// loadTestFace loads a face from the text package's test fonts.
+func loadTestFace(t *testing.T, size float64) text.Face {
+	t.Helper()
+	data, err := os.ReadFile("../text/testdata/goregular.ttf")
+	if err != nil {
+		t.Skipf("test font unavailable: %v", err)
+	}
+	source, err := text.NewFontSource(data)
+	if err != nil {
+		t.Fatalf("NewFontSource: %v", err)
+	}
+	return source.Face(size)
+}
+
+func TestResourcePool_AddFont_Deduplicates(t *testing.T) {
+	pool := NewResourcePool()
+	face := loadTestFace(t, 12)
+
+	ref1 := pool.AddFont(face)
+	ref2 := pool.AddFont(face)
+	if ref1 != ref2 {
+		t.Errorf("same face got refs %d and %d, want shared", ref1, ref2)
+	}
+	if pool.FontCount() != 1 {
+		t.Errorf("FontCount() = %d, want 1", pool.FontCount())
+	}
+
+	// A different face is a separate resource.
+	other := loadTestFace(t, 24)
+	if ref3 := pool.AddFont(other); ref3 == ref1 {
+		t.Error("different face reused the same reference")
+	}
+}
+
+func TestResourcePool_GlyphUsage(t *testing.T) {
+	pool := NewResourcePool()
+	face := loadTestFace(t, 12)
+	ref := pool.AddFont(face)
+
+	if got := pool.UsedGlyphs(ref); got != nil {
+		t.Errorf("UsedGlyphs before marking = %v, want nil", got)
+	}
+
+	pool.MarkGlyphsUsed(ref, "AB")
+	pool.MarkGlyphsUsed(ref, "BA") // duplicates collapse
+
+	glyphs := pool.UsedGlyphs(ref)
+	if len(glyphs) != 2 {
+		t.Fatalf("UsedGlyphs = %v, want 2 distinct glyphs", glyphs)
+	}
+	if glyphs[0] >= glyphs[1] {
+		t.Errorf("UsedGlyphs not sorted: %v", glyphs)
+	}
+
+	// Invalid references are ignored.
+	pool.MarkGlyphsUsed(FontRef(InvalidRef), "x")
+	if got := pool.UsedGlyphs(FontRef(InvalidRef)); got != nil {
+		t.Errorf("UsedGlyphs(invalid) = %v, want nil", got)
+	}
+}
+
+func TestResourcePool_FontData(t *testing.T) {
+	pool := NewResourcePool()
+	face := loadTestFace(t, 12)
+	ref := pool.AddFont(face)
+
+	data := pool.FontData(ref)
+	if len(data) == 0 {
+		t.Fatal("FontData returned no bytes")
+	}
+	// TTF fonts start with the 0x00010000 sfnt version tag.
+	if data[0] != 0x00 || data[1] != 0x01 {
+		t.Errorf("FontData does not look like a TTF: % x", data[:4])
+	}
+
+	if got := pool.FontData(FontRef(InvalidRef)); got != nil {
+		t.Errorf("FontData(invalid) = %v, want nil", got)
+	}
+}
+
+func TestResourcePool_Clone_CopiesGlyphUsage(t *testing.T) {
+	pool := NewResourcePool()
+	face := loadTestFace(t, 12)
+	ref := pool.AddFont(face)
+	pool.MarkGlyphsUsed(ref, "A")
+
+	clone := pool.Clone()
+	clone.MarkGlyphsUsed(ref, "B")
+
+	if got := len(pool.UsedGlyphs(ref)); got != 1 {
+		t.Errorf("original glyph count = %d after mutating clone, want 1", got)
+	}
+	if got := len(clone.UsedGlyphs(ref)); got != 2 {
+		t.Errorf("clone glyph count = %d, want 2", got)
+	}
+}
@@ -0,0 +1,133 @@
+package recording
+
+import "math"
+
+// PrimitiveKind identifies a preserved high-level shape.
+type PrimitiveKind uint8
+
+const (
+	// PrimitiveCircle is a full circle (RX == RY).
+	PrimitiveCircle PrimitiveKind = iota
+
+	// PrimitiveEllipse is an axis-aligned ellipse.
+	PrimitiveEllipse
+
+	// PrimitiveArc is a circular arc from Angle1 to Angle2.
+	PrimitiveArc
+
+	// PrimitiveRoundRect is a rectangle with a uniform corner radius.
+	PrimitiveRoundRect
+)
+
+// primitiveKindNames maps PrimitiveKind values to their string representation.
+var primitiveKindNames = [...]string{
+	PrimitiveCircle:    "Circle",
+	PrimitiveEllipse:   "Ellipse",
+	PrimitiveArc:       "Arc",
+	PrimitiveRoundRect: "RoundRect",
+}
+
+// String returns the string representation of a PrimitiveKind.
+func (k PrimitiveKind) String() string {
+	if int(k) < len(primitiveKindNames) {
+		return primitiveKindNames[k]
+	}
+	return "Unknown"
+}
+
+// Primitive is a high-level shape preserved through recording so that
+// vector backends can emit native elements (SVG <circle>, arc commands)
+// instead of the flattened Bezier approximation. All coordinates are in
+// device space: the recorder bakes its transform in at record time, so
+// only transforms under which the shape stays representable (no rotation
+// or shear; uniform scale for arcs and corner radii) produce primitives.
+type Primitive struct {
+	// Kind selects which fields below are meaningful.
+	Kind PrimitiveKind
+
+	// CX, CY is the center for circles, ellipses, and arcs.
+	CX, CY float64
+
+	// RX, RY are the radii. Circles and arcs have RX == RY.
+	RX, RY float64
+
+	// Angle1, Angle2 are the arc start and end angles in radians,
+	// measured from the positive x axis toward positive y (clockwise
+	// on a y-down canvas), with Angle2 >= Angle1.
+	Angle1, Angle2 float64
+
+	// X, Y, W, H, Radius describe a rounded rectangle: top-left corner,
+	// size, and uniform corner radius.
+	X, Y, W, H float64
+	Radius     float64
+}
+
+// FillPrimitiveCommand fills a preserved shape primitive. Path carries the
+// Bezier approximation of the same shape, used as the fallback for
+// backends that do not implement PrimitiveBackend; both render identically.
+type FillPrimitiveCommand struct {
+	// Primitive is the preserved shape in device coordinates.
+	Primitive Primitive
+	// Path references the Bezier fallback path in the resource pool.
+	Path PathRef
+	// Brush references the fill brush in the resource pool.
+	Brush BrushRef
+	// Rule specifies the fill rule (non-zero or even-odd).
+	Rule FillRule
+}
+
+// Type implements Command.
+func (FillPrimitiveCommand) Type() CommandType { return CmdFillPrimitive }
+
+// StrokePrimitiveCommand strokes a preserved shape primitive. Path carries
+// the Bezier fallback for backends without primitive support.
+type StrokePrimitiveCommand struct {
+	// Primitive is the preserved shape in device coordinates.
+	Primitive Primitive
+	// Path references the Bezier fallback path in the resource pool.
+	Path PathRef
+	// Brush references the stroke brush in the resource pool.
+	Brush BrushRef
+	// Stroke contains the stroke style (width, cap, join, dash).
+	Stroke Stroke
+}
+
+// Type implements Command.
+func (StrokePrimitiveCommand) Type() CommandType { return CmdStrokePrimitive }
+
+// PrimitiveBackend is the optional interface for backends that can emit
+// high-level shapes natively (SVG <circle>, <ellipse>, arc path commands).
+// Backends that do not implement it receive the Bezier fallback path
+// through FillPath/StrokePath and render the same output. Backends whose
+// target format approximates arcs with cubics anyway (PDF) should not
+// implement it.
+type PrimitiveBackend interface {
+	// FillPrimitive fills a preserved shape with the brush.
+	FillPrimitive(p Primitive, brush Brush, rule FillRule)
+
+	// StrokePrimitive strokes a preserved shape with the brush and style.
+	StrokePrimitive(p Primitive, brush Brush, stroke Stroke)
+}
+
+// transformEllipse maps a user-space center and radii to device space.
+// It reports ok only when the current transform is axis-aligned (no
+// rotation or shear), so the result is still an axis-aligned ellipse.
+func (r *Recorder) transformEllipse(x, y, rx, ry float64) (cx, cy, drx, dry float64, ok bool) {
+	m := r.transform
+	if m.B != 0 || m.D != 0 || m.A == 0 || m.E == 0 {
+		return 0, 0, 0, 0, false
+	}
+	cx, cy = m.TransformPoint(x, y)
+	return cx, cy, math.Abs(m.A) * rx, math.Abs(m.E) * ry, true
+}
+
+// uniformScale returns the current transform's scale factor and reports
+// whether the transform is a positive uniform scale plus translation.
+// Arcs and corner radii stay circular only under such transforms.
+func (r *Recorder) uniformScale() (float64, bool) {
+	m := r.transform
+	if m.B != 0 || m.D != 0 || m.A != m.E || m.A <= 0 {
+		return 0, false
+	}
+	return m.A, true
+}
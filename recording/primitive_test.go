@@ -0,0 +1,203 @@
+package recording
+
+import (
+	"image"
+	"math"
+	"testing"
+
+	"github.com/gogpu/gg"
+	"github.com/gogpu/gg/text"
+)
+
+// primitiveMockBackend implements Backend and PrimitiveBackend, recording
+// the primitive and path calls it receives during playback.
+type primitiveMockBackend struct {
+	fills   []Primitive
+	strokes []Primitive
+	paths   int
+}
+
+func (m *primitiveMockBackend) Begin(_, _ int) error                                  { return nil }
+func (m *primitiveMockBackend) End() error                                            { return nil }
+func (m *primitiveMockBackend) Save()                                                 {}
+func (m *primitiveMockBackend) Restore()                                              {}
+func (m *primitiveMockBackend) SetTransform(_ Matrix)                                 {}
+func (m *primitiveMockBackend) SetClip(_ *gg.Path, _ FillRule)                        {}
+func (m *primitiveMockBackend) ClearClip()                                            {}
+func (m *primitiveMockBackend) FillPath(_ *gg.Path, _ Brush, _ FillRule)              { m.paths++ }
+func (m *primitiveMockBackend) StrokePath(_ *gg.Path, _ Brush, _ Stroke)              { m.paths++ }
+func (m *primitiveMockBackend) FillRect(_ Rect, _ Brush)                              {}
+func (m *primitiveMockBackend) DrawImage(_ image.Image, _, _ Rect, _ ImageOptions)    {}
+func (m *primitiveMockBackend) DrawText(_ string, _, _ float64, _ text.Face, _ Brush) {}
+
+func (m *primitiveMockBackend) FillPrimitive(p Primitive, _ Brush, _ FillRule) {
+	m.fills = append(m.fills, p)
+}
+
+func (m *primitiveMockBackend) StrokePrimitive(p Primitive, _ Brush, _ Stroke) {
+	m.strokes = append(m.strokes, p)
+}
+
+func TestCirclePrimitivePreserved(t *testing.T) {
+	rec := NewRecorder(200, 200)
+	rec.DrawCircle(100, 100, 50)
+	rec.Fill()
+	r := rec.FinishRecording()
+
+	cmds := r.Commands()
+	if len(cmds) != 1 {
+		t.Fatalf("got %d commands, want 1", len(cmds))
+	}
+	fc, ok := cmds[0].(FillPrimitiveCommand)
+	if !ok {
+		t.Fatalf("command = %T, want FillPrimitiveCommand", cmds[0])
+	}
+	p := fc.Primitive
+	if p.Kind != PrimitiveCircle || p.CX != 100 || p.CY != 100 || p.RX != 50 {
+		t.Errorf("primitive = %+v, want circle at (100,100) r=50", p)
+	}
+	// The Bezier fallback path must still be recorded.
+	fallback := r.Resources().GetPath(fc.Path)
+	if fallback == nil || fallback.NumVerbs() == 0 {
+		t.Error("fallback path missing or empty")
+	}
+}
+
+func TestCirclePrimitiveTransforms(t *testing.T) {
+	// Non-uniform scale turns a circle into an ellipse primitive.
+	rec := NewRecorder(200, 200)
+	rec.Scale(2, 3)
+	rec.DrawCircle(10, 10, 5)
+	rec.Fill()
+	cmds := rec.FinishRecording().Commands()
+	fc, ok := cmds[len(cmds)-1].(FillPrimitiveCommand)
+	if !ok {
+		t.Fatalf("scaled circle: command = %T, want FillPrimitiveCommand", cmds[len(cmds)-1])
+	}
+	p := fc.Primitive
+	if p.Kind != PrimitiveEllipse || p.CX != 20 || p.CY != 30 || p.RX != 10 || p.RY != 15 {
+		t.Errorf("primitive = %+v, want ellipse at (20,30) rx=10 ry=15", p)
+	}
+
+	// Rotation is not representable; the plain path command is used.
+	rec = NewRecorder(200, 200)
+	rec.Rotate(0.3)
+	rec.DrawCircle(10, 10, 5)
+	rec.Fill()
+	cmds = rec.FinishRecording().Commands()
+	if _, ok := cmds[len(cmds)-1].(FillPathCommand); !ok {
+		t.Errorf("rotated circle: command = %T, want FillPathCommand", cmds[len(cmds)-1])
+	}
+}
+
+func TestPrimitiveInvalidatedByPathOps(t *testing.T) {
+	// A circle appended to an existing path is not a lone primitive.
+	rec := NewRecorder(200, 200)
+	rec.MoveTo(0, 0)
+	rec.LineTo(10, 0)
+	rec.DrawCircle(100, 100, 50)
+	rec.Fill()
+	cmds := rec.FinishRecording().Commands()
+	if _, ok := cmds[0].(FillPathCommand); !ok {
+		t.Errorf("circle after path ops: command = %T, want FillPathCommand", cmds[0])
+	}
+
+	// Path ops after a shape invalidate the pending primitive.
+	rec = NewRecorder(200, 200)
+	rec.DrawCircle(100, 100, 50)
+	rec.LineTo(0, 0)
+	rec.Fill()
+	cmds = rec.FinishRecording().Commands()
+	if _, ok := cmds[0].(FillPathCommand); !ok {
+		t.Errorf("path ops after circle: command = %T, want FillPathCommand", cmds[0])
+	}
+}
+
+func TestArcPrimitive(t *testing.T) {
+	rec := NewRecorder(200, 200)
+	rec.DrawArc(100, 100, 40, 0, math.Pi/2)
+	rec.Stroke()
+	cmds := rec.FinishRecording().Commands()
+	sc, ok := cmds[0].(StrokePrimitiveCommand)
+	if !ok {
+		t.Fatalf("command = %T, want StrokePrimitiveCommand", cmds[0])
+	}
+	p := sc.Primitive
+	if p.Kind != PrimitiveArc || p.RX != 40 || p.Angle1 != 0 || p.Angle2 != math.Pi/2 {
+		t.Errorf("primitive = %+v, want arc r=40 from 0 to pi/2", p)
+	}
+
+	// A full sweep carries no angle information and records as a circle.
+	rec = NewRecorder(200, 200)
+	rec.DrawArc(100, 100, 40, 0, 2*math.Pi)
+	rec.Fill()
+	cmds = rec.FinishRecording().Commands()
+	fc, ok := cmds[0].(FillPrimitiveCommand)
+	if !ok {
+		t.Fatalf("full arc: command = %T, want FillPrimitiveCommand", cmds[0])
+	}
+	if fc.Primitive.Kind != PrimitiveCircle {
+		t.Errorf("full arc kind = %v, want Circle", fc.Primitive.Kind)
+	}
+}
+
+func TestRoundRectPrimitive(t *testing.T) {
+	rec := NewRecorder(200, 200)
+	rec.Translate(10, 20)
+	rec.DrawRoundedRectangle(0, 0, 80, 40, 100) // radius clamps to 20
+	rec.Fill()
+	cmds := rec.FinishRecording().Commands()
+	fc, ok := cmds[len(cmds)-1].(FillPrimitiveCommand)
+	if !ok {
+		t.Fatalf("command = %T, want FillPrimitiveCommand", cmds[len(cmds)-1])
+	}
+	p := fc.Primitive
+	if p.Kind != PrimitiveRoundRect || p.X != 10 || p.Y != 20 || p.W != 80 || p.H != 40 {
+		t.Errorf("primitive = %+v, want rrect at (10,20) 80x40", p)
+	}
+	if p.Radius != 20 {
+		t.Errorf("radius = %v, want clamped 20", p.Radius)
+	}
+}
+
+func TestPrimitivePreserveKeepsPending(t *testing.T) {
+	rec := NewRecorder(200, 200)
+	rec.DrawCircle(100, 100, 50)
+	rec.FillPreserve()
+	rec.Stroke()
+	cmds := rec.FinishRecording().Commands()
+	if len(cmds) != 2 {
+		t.Fatalf("got %d commands, want 2", len(cmds))
+	}
+	if _, ok := cmds[0].(FillPrimitiveCommand); !ok {
+		t.Errorf("first command = %T, want FillPrimitiveCommand", cmds[0])
+	}
+	if _, ok := cmds[1].(StrokePrimitiveCommand); !ok {
+		t.Errorf("second command = %T, want StrokePrimitiveCommand", cmds[1])
+	}
+}
+
+func TestPrimitivePlayback(t *testing.T) {
+	rec := NewRecorder(200, 200)
+	rec.DrawCircle(100, 100, 50)
+	rec.Fill()
+	rec.DrawArc(100, 100, 60, 0, math.Pi)
+	rec.Stroke()
+	r := rec.FinishRecording()
+
+	// A primitive-aware backend receives the native shapes.
+	aware := &primitiveMockBackend{}
+	if err := r.Playback(aware); err != nil {
+		t.Fatalf("Playback failed: %v", err)
+	}
+	if len(aware.fills) != 1 || len(aware.strokes) != 1 || aware.paths != 0 {
+		t.Errorf("aware backend: %d fills, %d strokes, %d paths; want 1, 1, 0",
+			len(aware.fills), len(aware.strokes), aware.paths)
+	}
+
+	// A plain backend receives the Bezier fallback through FillPath.
+	plain := &semanticMockBackend{}
+	if err := r.Playback(plain); err != nil {
+		t.Fatalf("Playback to plain backend failed: %v", err)
+	}
+}
@@ -31,6 +31,11 @@ type Recorder struct {
 	// Current path being built
 	currentPath *gg.Path
 
+	// Shape primitive the current path was built from, if the whole path
+	// is a single Draw* shape call (nil otherwise). Lets Fill/Stroke emit
+	// primitive commands so vector backends keep native arcs.
+	pendingPrimitive *Primitive
+
 	// Current state
 	fillBrush   Brush
 	strokeBrush Brush
@@ -51,6 +56,9 @@ type Recorder struct {
 
 	// State stack
 	stateStack []recorderState
+
+	// Open semantic groups (see BeginSemantic/EndSemantic)
+	semanticDepth int
 }
 
 // recorderState stores the graphics state for Save/Restore.
@@ -163,27 +171,77 @@ func (r *Recording) Playback(backend Backend) error {
 		case FillPathCommand:
 			path := r.resources.GetPath(c.Path)
 			brush := r.resources.GetBrush(c.Brush)
-			backend.FillPath(path, brush, c.Rule)
+			if backendSupports(backend, brush) {
+				backend.FillPath(path, brush, c.Rule)
+			} else {
+				r.decomposeFillPath(backend, path, brush, c.Rule)
+			}
 		case StrokePathCommand:
 			path := r.resources.GetPath(c.Path)
 			brush := r.resources.GetBrush(c.Brush)
-			backend.StrokePath(path, brush, c.Stroke)
+			if backendSupports(backend, brush) {
+				backend.StrokePath(path, brush, c.Stroke)
+			} else {
+				r.decomposeStrokePath(backend, path, brush, c.Stroke)
+			}
+		case FillPrimitiveCommand:
+			// Primitive support is optional: backends that implement
+			// PrimitiveBackend emit native shapes, all others receive
+			// the Bezier fallback path and render identically.
+			brush := r.resources.GetBrush(c.Brush)
+			if pb, ok := backend.(PrimitiveBackend); ok && backendSupports(backend, brush) {
+				pb.FillPrimitive(c.Primitive, brush, c.Rule)
+				break
+			}
+			path := r.resources.GetPath(c.Path)
+			if backendSupports(backend, brush) {
+				backend.FillPath(path, brush, c.Rule)
+			} else {
+				r.decomposeFillPath(backend, path, brush, c.Rule)
+			}
+		case StrokePrimitiveCommand:
+			brush := r.resources.GetBrush(c.Brush)
+			if pb, ok := backend.(PrimitiveBackend); ok && backendSupports(backend, brush) {
+				pb.StrokePrimitive(c.Primitive, brush, c.Stroke)
+				break
+			}
+			path := r.resources.GetPath(c.Path)
+			if backendSupports(backend, brush) {
+				backend.StrokePath(path, brush, c.Stroke)
+			} else {
+				r.decomposeStrokePath(backend, path, brush, c.Stroke)
+			}
 		case FillRectCommand:
 			brush := r.resources.GetBrush(c.Brush)
-			backend.FillRect(c.Rect, brush)
+			if backendSupports(backend, brush) {
+				backend.FillRect(c.Rect, brush)
+			} else {
+				r.decomposeFillRect(backend, c.Rect, brush)
+			}
 		case DrawImageCommand:
 			img := r.resources.GetImage(c.Image)
 			backend.DrawImage(img, c.SrcRect, c.DstRect, c.Options)
 		case DrawTextCommand:
 			brush := r.resources.GetBrush(c.Brush)
-			// Font face lookup would need additional handling
-			backend.DrawText(c.Text, c.X, c.Y, nil, brush)
+			face := r.resources.GetFont(c.Font)
+			backend.DrawText(c.Text, c.X, c.Y, face, brush)
 		case StrokeTextCommand:
 			brush := r.resources.GetBrush(c.Brush)
+			face := r.resources.GetFont(c.Font)
 			// StrokeText is recorded as a stroke text command.
 			// Backends that support text stroking can use the stroke style;
 			// others fall back to DrawText (fill) as an approximation.
-			backend.DrawText(c.Text, c.X, c.Y, nil, brush)
+			backend.DrawText(c.Text, c.X, c.Y, face, brush)
+		case BeginSemanticCommand:
+			// Semantic annotations are optional: backends that implement
+			// SemanticBackend receive them, all others render identically.
+			if sb, ok := backend.(SemanticBackend); ok {
+				sb.BeginSemantic(c.Semantics)
+			}
+		case EndSemanticCommand:
+			if sb, ok := backend.(SemanticBackend); ok {
+				sb.EndSemantic()
+			}
 		// Style commands are handled by the backend's internal state
 		// during the actual drawing operations
 		case SetFillStyleCommand, SetStrokeStyleCommand,
@@ -527,12 +585,14 @@ func (r *Recorder) AntiAlias() bool {
 func (r *Recorder) MoveTo(x, y float64) {
 	px, py := r.transform.TransformPoint(x, y)
 	r.currentPath.MoveTo(px, py)
+	r.pendingPrimitive = nil
 }
 
 // LineTo adds a line to the current path.
 func (r *Recorder) LineTo(x, y float64) {
 	px, py := r.transform.TransformPoint(x, y)
 	r.currentPath.LineTo(px, py)
+	r.pendingPrimitive = nil
 }
 
 // QuadraticTo adds a quadratic Bezier curve to the current path.
@@ -540,6 +600,7 @@ func (r *Recorder) QuadraticTo(cx, cy, x, y float64) {
 	cpx, cpy := r.transform.TransformPoint(cx, cy)
 	px, py := r.transform.TransformPoint(x, y)
 	r.currentPath.QuadraticTo(cpx, cpy, px, py)
+	r.pendingPrimitive = nil
 }
 
 // CubicTo adds a cubic Bezier curve to the current path.
@@ -548,16 +609,19 @@ func (r *Recorder) CubicTo(c1x, c1y, c2x, c2y, x, y float64) {
 	cp2x, cp2y := r.transform.TransformPoint(c2x, c2y)
 	px, py := r.transform.TransformPoint(x, y)
 	r.currentPath.CubicTo(cp1x, cp1y, cp2x, cp2y, px, py)
+	r.pendingPrimitive = nil
 }
 
 // ClosePath closes the current subpath.
 func (r *Recorder) ClosePath() {
 	r.currentPath.Close()
+	r.pendingPrimitive = nil
 }
 
 // ClearPath clears the current path.
 func (r *Recorder) ClearPath() {
 	r.currentPath.Clear()
+	r.pendingPrimitive = nil
 }
 
 // NewSubPath starts a new subpath without closing the previous one.
@@ -572,20 +636,9 @@ func (r *Recorder) NewSubPath() {
 
 // Fill fills the current path and clears it.
 func (r *Recorder) Fill() {
-	if r.currentPath.NumVerbs() == 0 {
-		return
-	}
-
-	pathRef := r.resources.AddPath(r.currentPath)
-	brushRef := r.resources.AddBrush(r.fillBrush)
-
-	r.commands = append(r.commands, FillPathCommand{
-		Path:  pathRef,
-		Brush: brushRef,
-		Rule:  r.fillRule,
-	})
-
+	r.FillPreserve()
 	r.currentPath = gg.NewPath()
+	r.pendingPrimitive = nil
 }
 
 // FillPreserve fills the current path without clearing it.
@@ -597,6 +650,16 @@ func (r *Recorder) FillPreserve() {
 	pathRef := r.resources.AddPath(r.currentPath)
 	brushRef := r.resources.AddBrush(r.fillBrush)
 
+	if prim := r.pendingPrimitive; prim != nil {
+		r.commands = append(r.commands, FillPrimitiveCommand{
+			Primitive: *prim,
+			Path:      pathRef,
+			Brush:     brushRef,
+			Rule:      r.fillRule,
+		})
+		return
+	}
+
 	r.commands = append(r.commands, FillPathCommand{
 		Path:  pathRef,
 		Brush: brushRef,
@@ -606,29 +669,9 @@ func (r *Recorder) FillPreserve() {
 
 // Stroke strokes the current path and clears it.
 func (r *Recorder) Stroke() {
-	if r.currentPath.NumVerbs() == 0 {
-		return
-	}
-
-	pathRef := r.resources.AddPath(r.currentPath)
-	brushRef := r.resources.AddBrush(r.strokeBrush)
-
-	stroke := Stroke{
-		Width:       r.lineWidth,
-		Cap:         r.lineCap,
-		Join:        r.lineJoin,
-		MiterLimit:  r.miterLimit,
-		DashPattern: r.dashPattern,
-		DashOffset:  r.dashOffset,
-	}
-
-	r.commands = append(r.commands, StrokePathCommand{
-		Path:   pathRef,
-		Brush:  brushRef,
-		Stroke: stroke,
-	})
-
+	r.StrokePreserve()
 	r.currentPath = gg.NewPath()
+	r.pendingPrimitive = nil
 }
 
 // StrokePreserve strokes the current path without clearing it.
@@ -649,6 +692,16 @@ func (r *Recorder) StrokePreserve() {
 		DashOffset:  r.dashOffset,
 	}
 
+	if prim := r.pendingPrimitive; prim != nil {
+		r.commands = append(r.commands, StrokePrimitiveCommand{
+			Primitive: *prim,
+			Path:      pathRef,
+			Brush:     brushRef,
+			Stroke:    stroke,
+		})
+		return
+	}
+
 	r.commands = append(r.commands, StrokePathCommand{
 		Path:   pathRef,
 		Brush:  brushRef,
@@ -694,6 +747,8 @@ func (r *Recorder) DrawRoundedRectangle(x, y, w, h, radius float64) {
 		radius = maxR
 	}
 
+	wasEmpty := r.currentPath.NumVerbs() == 0
+
 	r.MoveTo(x+radius, y)
 	r.LineTo(x+w-radius, y)
 	r.drawArcPath(x+w-radius, y+radius, radius, -math.Pi/2, 0)
@@ -704,6 +759,17 @@ func (r *Recorder) DrawRoundedRectangle(x, y, w, h, radius float64) {
 	r.LineTo(x, y+radius)
 	r.drawArcPath(x+radius, y+radius, radius, math.Pi, 3*math.Pi/2)
 	r.ClosePath()
+
+	if wasEmpty {
+		if s, ok := r.uniformScale(); ok {
+			px, py := r.transform.TransformPoint(x, y)
+			r.pendingPrimitive = &Primitive{
+				Kind: PrimitiveRoundRect,
+				X:    px, Y: py, W: w * s, H: h * s,
+				Radius: radius * s,
+			}
+		}
+	}
 }
 
 // DrawCircle draws a circle.
@@ -711,12 +777,18 @@ func (r *Recorder) DrawCircle(x, y, radius float64) {
 	const k = 0.5522847498307936 // 4/3 * (sqrt(2) - 1)
 	offset := radius * k
 
+	wasEmpty := r.currentPath.NumVerbs() == 0
+
 	r.MoveTo(x+radius, y)
 	r.CubicTo(x+radius, y+offset, x+offset, y+radius, x, y+radius)
 	r.CubicTo(x-offset, y+radius, x-radius, y+offset, x-radius, y)
 	r.CubicTo(x-radius, y-offset, x-offset, y-radius, x, y-radius)
 	r.CubicTo(x+offset, y-radius, x+radius, y-offset, x+radius, y)
 	r.ClosePath()
+
+	if wasEmpty {
+		r.setEllipsePrimitive(x, y, radius, radius)
+	}
 }
 
 // DrawEllipse draws an ellipse.
@@ -725,17 +797,61 @@ func (r *Recorder) DrawEllipse(x, y, rx, ry float64) {
 	ox := rx * k
 	oy := ry * k
 
+	wasEmpty := r.currentPath.NumVerbs() == 0
+
 	r.MoveTo(x+rx, y)
 	r.CubicTo(x+rx, y+oy, x+ox, y+ry, x, y+ry)
 	r.CubicTo(x-ox, y+ry, x-rx, y+oy, x-rx, y)
 	r.CubicTo(x-rx, y-oy, x-ox, y-ry, x, y-ry)
 	r.CubicTo(x+ox, y-ry, x+rx, y-oy, x+rx, y)
 	r.ClosePath()
+
+	if wasEmpty {
+		r.setEllipsePrimitive(x, y, rx, ry)
+	}
+}
+
+// setEllipsePrimitive records the just-built circle or ellipse as a
+// pending primitive when the transform keeps it axis-aligned. A circle
+// under non-uniform scale is preserved as an ellipse.
+func (r *Recorder) setEllipsePrimitive(x, y, rx, ry float64) {
+	cx, cy, drx, dry, ok := r.transformEllipse(x, y, rx, ry)
+	if !ok {
+		return
+	}
+	kind := PrimitiveEllipse
+	if drx == dry {
+		kind = PrimitiveCircle
+	}
+	r.pendingPrimitive = &Primitive{Kind: kind, CX: cx, CY: cy, RX: drx, RY: dry}
 }
 
 // DrawArc draws a circular arc.
 func (r *Recorder) DrawArc(x, y, radius, angle1, angle2 float64) {
+	wasEmpty := r.currentPath.NumVerbs() == 0
 	r.drawArcPath(x, y, radius, angle1, angle2)
+	if !wasEmpty {
+		return
+	}
+	s, ok := r.uniformScale()
+	if !ok {
+		return
+	}
+	for angle2 < angle1 {
+		angle2 += 2 * math.Pi
+	}
+	cx, cy := r.transform.TransformPoint(x, y)
+	if angle2-angle1 >= 2*math.Pi {
+		// A full sweep is a circle; start/end angles carry no information.
+		r.pendingPrimitive = &Primitive{
+			Kind: PrimitiveCircle, CX: cx, CY: cy, RX: radius * s, RY: radius * s,
+		}
+		return
+	}
+	r.pendingPrimitive = &Primitive{
+		Kind: PrimitiveArc, CX: cx, CY: cy, RX: radius * s, RY: radius * s,
+		Angle1: angle1, Angle2: angle2,
+	}
 }
 
 // drawArcPath adds arc segments to the current path.
@@ -865,6 +981,7 @@ func (r *Recorder) Clip() {
 	})
 
 	r.currentPath = gg.NewPath()
+	r.pendingPrimitive = nil
 }
 
 // ClipPreserve sets the current path as the clipping region but keeps the path.
@@ -982,6 +1099,36 @@ func (r *Recorder) DrawImageScaled(img image.Image, x, y, w, h float64) {
 	})
 }
 
+// DrawImageEx draws an image with full control over the source rectangle,
+// interpolation, opacity, and blend mode. The src rectangle is in image
+// coordinates; an empty src uses the entire image. The dst rectangle is in
+// user space and is transformed by the current matrix. Images are pooled and
+// deduplicated by content, so repeated draws embed a single copy.
+func (r *Recorder) DrawImageEx(img image.Image, src, dst Rect, opts ImageOptions) {
+	if img == nil {
+		return
+	}
+
+	if src.IsEmpty() {
+		bounds := img.Bounds()
+		src = NewRect(float64(bounds.Min.X), float64(bounds.Min.Y),
+			float64(bounds.Dx()), float64(bounds.Dy()))
+	}
+
+	imageRef := r.resources.AddImage(img)
+
+	// Transform destination rectangle
+	x1, y1 := r.transform.TransformPoint(dst.MinX, dst.MinY)
+	x2, y2 := r.transform.TransformPoint(dst.MaxX, dst.MaxY)
+
+	r.commands = append(r.commands, DrawImageCommand{
+		Image:   imageRef,
+		SrcRect: src,
+		DstRect: NewRectFromPoints(x1, y1, x2, y2),
+		Options: opts,
+	})
+}
+
 // --------------------------------------------------------------------------
 // Text
 // --------------------------------------------------------------------------
@@ -991,6 +1138,18 @@ func (r *Recorder) SetFont(face text.Face) {
 	r.fontFace = face
 }
 
+// captureFont pools the current font face and records the glyphs s uses, so
+// export backends can embed and subset the font. Returns an invalid reference
+// when no face is set.
+func (r *Recorder) captureFont(s string) FontRef {
+	if r.fontFace == nil {
+		return FontRef(InvalidRef)
+	}
+	ref := r.resources.AddFont(r.fontFace)
+	r.resources.MarkGlyphsUsed(ref, s)
+	return ref
+}
+
 // SetFontSize sets the current font size in points.
 func (r *Recorder) SetFontSize(size float64) {
 	r.fontSize = size
@@ -1007,6 +1166,7 @@ func (r *Recorder) DrawString(s string, x, y float64) {
 	px, py := r.transform.TransformPoint(x, y)
 
 	brushRef := r.resources.AddBrush(r.fillBrush)
+	fontRef := r.captureFont(s)
 
 	r.commands = append(r.commands, DrawTextCommand{
 		Text:       s,
@@ -1014,6 +1174,7 @@ func (r *Recorder) DrawString(s string, x, y float64) {
 		Y:          py,
 		FontSize:   r.fontSize,
 		FontFamily: r.fontFamily,
+		Font:       fontRef,
 		Brush:      brushRef,
 	})
 }
@@ -1032,6 +1193,7 @@ func (r *Recorder) StrokeString(s string, x, y float64) {
 	px, py := r.transform.TransformPoint(x, y)
 
 	brushRef := r.resources.AddBrush(r.strokeBrush)
+	fontRef := r.captureFont(s)
 
 	stroke := Stroke{
 		Width:       r.lineWidth,
@@ -1048,6 +1210,7 @@ func (r *Recorder) StrokeString(s string, x, y float64) {
 		Y:          py,
 		FontSize:   r.fontSize,
 		FontFamily: r.fontFamily,
+		Font:       fontRef,
 		Brush:      brushRef,
 		Stroke:     stroke,
 	})
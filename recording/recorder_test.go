@@ -371,15 +371,16 @@ func TestRecorderFillStroke(t *testing.T) {
 	hasFill := false
 	hasStroke := false
 	for _, cmd := range recording.Commands() {
-		if cmd.Type() == CmdFillPath {
+		// A lone circle is preserved as a shape primitive.
+		if cmd.Type() == CmdFillPath || cmd.Type() == CmdFillPrimitive {
 			hasFill = true
 		}
-		if cmd.Type() == CmdStrokePath {
+		if cmd.Type() == CmdStrokePath || cmd.Type() == CmdStrokePrimitive {
 			hasStroke = true
 		}
 	}
 	if !hasFill || !hasStroke {
-		t.Error("FillStroke should produce both FillPathCommand and StrokePathCommand")
+		t.Error("FillStroke should produce both a fill and a stroke command")
 	}
 }
 
@@ -567,6 +568,83 @@ func TestRecorderDrawImageScaled(t *testing.T) {
 	t.Error("DrawImageScaled should produce DrawImageCommand")
 }
 
+func TestRecorderDrawImageEx(t *testing.T) {
+	rec := NewRecorder(100, 100)
+
+	img := image.NewRGBA(image.Rect(0, 0, 50, 50))
+	opts := ImageOptions{
+		Interpolation: InterpolationNearest,
+		Alpha:         0.5,
+		Blend:         BlendMultiply,
+	}
+	rec.DrawImageEx(img, NewRect(10, 10, 20, 20), NewRect(0, 0, 80, 80), opts)
+
+	recording := rec.FinishRecording()
+
+	for _, cmd := range recording.Commands() {
+		if cmd.Type() == CmdDrawImage {
+			imgCmd := cmd.(DrawImageCommand)
+			if imgCmd.SrcRect != NewRect(10, 10, 20, 20) {
+				t.Errorf("SrcRect = %+v, want (10,10,20,20)", imgCmd.SrcRect)
+			}
+			if imgCmd.DstRect.Width() != 80 || imgCmd.DstRect.Height() != 80 {
+				t.Errorf("DstRect size = %f x %f, want 80 x 80",
+					imgCmd.DstRect.Width(), imgCmd.DstRect.Height())
+			}
+			if imgCmd.Options != opts {
+				t.Errorf("Options = %+v, want %+v", imgCmd.Options, opts)
+			}
+			return
+		}
+	}
+	t.Error("DrawImageEx should produce DrawImageCommand")
+}
+
+func TestRecorderDrawImageExEmptySrc(t *testing.T) {
+	rec := NewRecorder(100, 100)
+
+	img := image.NewRGBA(image.Rect(0, 0, 50, 40))
+	rec.DrawImageEx(img, Rect{}, NewRect(0, 0, 50, 40), DefaultImageOptions())
+
+	recording := rec.FinishRecording()
+
+	for _, cmd := range recording.Commands() {
+		if cmd.Type() == CmdDrawImage {
+			imgCmd := cmd.(DrawImageCommand)
+			if imgCmd.SrcRect.Width() != 50 || imgCmd.SrcRect.Height() != 40 {
+				t.Errorf("SrcRect size = %f x %f, want full image 50 x 40",
+					imgCmd.SrcRect.Width(), imgCmd.SrcRect.Height())
+			}
+			return
+		}
+	}
+	t.Error("DrawImageEx should produce DrawImageCommand")
+}
+
+func TestRecorderDrawImageExDeduplicates(t *testing.T) {
+	rec := NewRecorder(100, 100)
+
+	img := image.NewRGBA(image.Rect(0, 0, 16, 16))
+	rec.DrawImageEx(img, Rect{}, NewRect(0, 0, 16, 16), DefaultImageOptions())
+	rec.DrawImageEx(img, Rect{}, NewRect(32, 32, 16, 16), DefaultImageOptions())
+
+	recording := rec.FinishRecording()
+
+	if got := recording.Resources().ImageCount(); got != 1 {
+		t.Errorf("ImageCount = %d, want 1 (identical images deduplicate)", got)
+	}
+}
+
+func TestRecorderDrawImageExNil(t *testing.T) {
+	rec := NewRecorder(100, 100)
+	rec.DrawImageEx(nil, Rect{}, NewRect(0, 0, 10, 10), DefaultImageOptions())
+
+	recording := rec.FinishRecording()
+	if len(recording.Commands()) != 0 {
+		t.Error("DrawImageEx with nil image should record nothing")
+	}
+}
+
 func TestRecorderDrawString(t *testing.T) {
 	rec := NewRecorder(100, 100)
 
@@ -965,3 +1043,50 @@ func BenchmarkRecorderComplexScene(b *testing.B) {
 		_ = rec.FinishRecording()
 	}
 }
+
+func TestRecorderDrawStringCapturesFont(t *testing.T) {
+	rec := NewRecorder(100, 100)
+	face := loadTestFace(t, 12)
+	rec.SetFont(face)
+	rec.DrawString("Hi", 10, 50)
+	rec.StrokeString("Hi", 10, 80)
+
+	recording := rec.FinishRecording()
+
+	// Both draws share one pooled font.
+	if got := recording.Resources().FontCount(); got != 1 {
+		t.Errorf("FontCount = %d, want 1", got)
+	}
+
+	for _, cmd := range recording.Commands() {
+		switch c := cmd.(type) {
+		case DrawTextCommand:
+			if !c.Font.IsValid() {
+				t.Error("DrawTextCommand.Font should be valid when a face is set")
+			}
+			if glyphs := recording.Resources().UsedGlyphs(c.Font); len(glyphs) == 0 {
+				t.Error("no glyph usage recorded for DrawString")
+			}
+		case StrokeTextCommand:
+			if !c.Font.IsValid() {
+				t.Error("StrokeTextCommand.Font should be valid when a face is set")
+			}
+		}
+	}
+}
+
+func TestRecorderDrawStringWithoutFont(t *testing.T) {
+	rec := NewRecorder(100, 100)
+	rec.DrawString("Hi", 10, 50)
+
+	recording := rec.FinishRecording()
+
+	if got := recording.Resources().FontCount(); got != 0 {
+		t.Errorf("FontCount = %d, want 0", got)
+	}
+	for _, cmd := range recording.Commands() {
+		if c, ok := cmd.(DrawTextCommand); ok && c.Font.IsValid() {
+			t.Error("DrawTextCommand.Font should be invalid when no face is set")
+		}
+	}
+}
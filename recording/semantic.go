@@ -0,0 +1,199 @@
+package recording
+
+// SemanticRole classifies recorded content for accessibility consumers:
+// tagged-PDF export maps roles onto PDF structure types, and UI frameworks
+// map them onto platform accessibility roles.
+type SemanticRole uint8
+
+const (
+	// RoleNone marks a group with no specific role (pure container).
+	RoleNone SemanticRole = iota
+
+	// RoleParagraph marks body text.
+	RoleParagraph
+
+	// RoleHeading marks a heading or title.
+	RoleHeading
+
+	// RoleFigure marks an illustration, chart, or image with meaning.
+	RoleFigure
+
+	// RoleLink marks an interactive hyperlink region.
+	RoleLink
+
+	// RoleButton marks an interactive button region.
+	RoleButton
+
+	// RoleList marks a list container.
+	RoleList
+
+	// RoleListItem marks one entry of a list.
+	RoleListItem
+
+	// RoleDecoration marks purely presentational content that accessibility
+	// consumers should skip (tagged PDF: artifact).
+	RoleDecoration
+)
+
+// semanticRoleNames maps SemanticRole values to their string representation.
+var semanticRoleNames = [...]string{
+	RoleNone:       "None",
+	RoleParagraph:  "Paragraph",
+	RoleHeading:    "Heading",
+	RoleFigure:     "Figure",
+	RoleLink:       "Link",
+	RoleButton:     "Button",
+	RoleList:       "List",
+	RoleListItem:   "ListItem",
+	RoleDecoration: "Decoration",
+}
+
+// String returns the string representation of a SemanticRole.
+func (r SemanticRole) String() string {
+	if int(r) < len(semanticRoleNames) {
+		return semanticRoleNames[r]
+	}
+	return "Unknown"
+}
+
+// Semantics is an optional accessibility annotation for a group of recorded
+// commands. Bounds may be left empty; SemanticTree then derives it from the
+// draw commands inside the group.
+type Semantics struct {
+	// Role classifies the annotated content.
+	Role SemanticRole
+
+	// Label is the human-readable description (alt text, link title).
+	Label string
+
+	// Bounds is the annotated region in canvas coordinates. An empty rect
+	// means "derive from the enclosed draw commands".
+	Bounds Rect
+}
+
+// BeginSemanticCommand opens a semantic group. Groups nest; every
+// BeginSemanticCommand is balanced by an EndSemanticCommand.
+type BeginSemanticCommand struct {
+	// Semantics is the annotation carried by the group.
+	Semantics Semantics
+}
+
+// Type implements Command.
+func (BeginSemanticCommand) Type() CommandType { return CmdBeginSemantic }
+
+// EndSemanticCommand closes the innermost open semantic group.
+type EndSemanticCommand struct{}
+
+// Type implements Command.
+func (EndSemanticCommand) Type() CommandType { return CmdEndSemantic }
+
+// SemanticBackend is the optional interface for backends that consume
+// semantic annotations during playback (tagged PDF, accessibility tree
+// builders). Backends that do not implement it render the same pixels;
+// the annotations are skipped.
+type SemanticBackend interface {
+	// BeginSemantic opens a semantic group around subsequent draw calls.
+	BeginSemantic(s Semantics)
+
+	// EndSemantic closes the innermost open semantic group.
+	EndSemantic()
+}
+
+// BeginSemantic opens a semantic group around subsequent drawing calls.
+// Groups nest like Save/Restore; balance each call with EndSemantic.
+func (r *Recorder) BeginSemantic(s Semantics) {
+	r.semanticDepth++
+	r.commands = append(r.commands, BeginSemanticCommand{Semantics: s})
+}
+
+// EndSemantic closes the innermost open semantic group.
+// If no group is open, this is a no-op.
+func (r *Recorder) EndSemantic() {
+	if r.semanticDepth == 0 {
+		return
+	}
+	r.semanticDepth--
+	r.commands = append(r.commands, EndSemanticCommand{})
+}
+
+// SemanticNode is one node of a recording's semantic tree.
+type SemanticNode struct {
+	// Semantics is the group's annotation. Bounds is resolved: when the
+	// recorded annotation had no bounds, the union of the enclosed draw
+	// commands' extents is filled in.
+	Semantics Semantics
+
+	// Children are the nested semantic groups, in recording order.
+	Children []*SemanticNode
+}
+
+// SemanticTree reconstructs the nesting of semantic groups in the
+// recording, resolving empty bounds from the draw commands each group
+// encloses. Draw commands outside any group do not appear in the tree.
+func (r *Recording) SemanticTree() []*SemanticNode {
+	var roots []*SemanticNode
+	var stack []*SemanticNode
+
+	addBounds := func(b Rect) {
+		if b.IsEmpty() {
+			return
+		}
+		for _, node := range stack {
+			if node.Semantics.Bounds.IsEmpty() {
+				node.Semantics.Bounds = b
+			} else {
+				node.Semantics.Bounds = node.Semantics.Bounds.Union(b)
+			}
+		}
+	}
+
+	for _, cmd := range r.commands {
+		switch c := cmd.(type) {
+		case BeginSemanticCommand:
+			node := &SemanticNode{Semantics: c.Semantics}
+			if len(stack) > 0 {
+				parent := stack[len(stack)-1]
+				parent.Children = append(parent.Children, node)
+			} else {
+				roots = append(roots, node)
+			}
+			stack = append(stack, node)
+
+		case EndSemanticCommand:
+			if len(stack) > 0 {
+				done := stack[len(stack)-1]
+				stack = stack[:len(stack)-1]
+				// A closed group's bounds count toward its ancestors.
+				addBounds(done.Semantics.Bounds)
+			}
+
+		case FillRectCommand:
+			addBounds(c.Rect)
+		case StrokeRectCommand:
+			addBounds(c.Rect)
+		case DrawImageCommand:
+			addBounds(c.DstRect)
+		case FillPathCommand:
+			addBounds(r.pathRect(c.Path))
+		case StrokePathCommand:
+			addBounds(r.pathRect(c.Path))
+		}
+	}
+
+	return roots
+}
+
+// pathRect returns a pooled path's bounding box as a Rect.
+func (r *Recording) pathRect(ref PathRef) Rect {
+	path := r.resources.GetPath(ref)
+	if path == nil {
+		return Rect{}
+	}
+	b := path.Bounds()
+	return Rect{
+		MinX: float64(b.Min.X),
+		MinY: float64(b.Min.Y),
+		MaxX: float64(b.Max.X),
+		MaxY: float64(b.Max.Y),
+	}
+}
@@ -0,0 +1,157 @@
+package recording
+
+import (
+	"image"
+	"testing"
+
+	"github.com/gogpu/gg"
+	"github.com/gogpu/gg/text"
+)
+
+// semanticMockBackend implements Backend and SemanticBackend, recording
+// the annotation events it receives during playback.
+type semanticMockBackend struct {
+	begins []Semantics
+	ends   int
+}
+
+func (m *semanticMockBackend) Begin(_, _ int) error                                  { return nil }
+func (m *semanticMockBackend) End() error                                            { return nil }
+func (m *semanticMockBackend) Save()                                                 {}
+func (m *semanticMockBackend) Restore()                                              {}
+func (m *semanticMockBackend) SetTransform(_ Matrix)                                 {}
+func (m *semanticMockBackend) SetClip(_ *gg.Path, _ FillRule)                        {}
+func (m *semanticMockBackend) ClearClip()                                            {}
+func (m *semanticMockBackend) FillPath(_ *gg.Path, _ Brush, _ FillRule)              {}
+func (m *semanticMockBackend) StrokePath(_ *gg.Path, _ Brush, _ Stroke)              {}
+func (m *semanticMockBackend) FillRect(_ Rect, _ Brush)                              {}
+func (m *semanticMockBackend) DrawImage(_ image.Image, _, _ Rect, _ ImageOptions)    {}
+func (m *semanticMockBackend) DrawText(_ string, _, _ float64, _ text.Face, _ Brush) {}
+
+func (m *semanticMockBackend) BeginSemantic(s Semantics) { m.begins = append(m.begins, s) }
+func (m *semanticMockBackend) EndSemantic()              { m.ends++ }
+
+func TestSemanticPlayback(t *testing.T) {
+	rec := NewRecorder(100, 100)
+	rec.BeginSemantic(Semantics{Role: RoleHeading, Label: "Title"})
+	rec.DrawString("Hello", 10, 20)
+	rec.EndSemantic()
+	rec.BeginSemantic(Semantics{Role: RoleDecoration})
+	rec.FillRectangle(0, 90, 100, 10)
+	rec.EndSemantic()
+	r := rec.FinishRecording()
+
+	backend := &semanticMockBackend{}
+	if err := r.Playback(backend); err != nil {
+		t.Fatalf("Playback failed: %v", err)
+	}
+	if len(backend.begins) != 2 || backend.ends != 2 {
+		t.Fatalf("got %d begins, %d ends, want 2 and 2", len(backend.begins), backend.ends)
+	}
+	if backend.begins[0].Role != RoleHeading || backend.begins[0].Label != "Title" {
+		t.Errorf("first annotation = %+v, want Heading/Title", backend.begins[0])
+	}
+	if backend.begins[1].Role != RoleDecoration {
+		t.Errorf("second annotation role = %v, want Decoration", backend.begins[1].Role)
+	}
+}
+
+func TestSemanticPlaybackPlainBackend(t *testing.T) {
+	rec := NewRecorder(100, 100)
+	rec.BeginSemantic(Semantics{Role: RoleFigure, Label: "chart"})
+	rec.FillRectangle(10, 10, 80, 80)
+	rec.EndSemantic()
+	r := rec.FinishRecording()
+
+	// A backend without SemanticBackend replays without error; the
+	// annotations are simply skipped.
+	backend := &playbackMockBackend{}
+	if err := r.Playback(backend); err != nil {
+		t.Fatalf("Playback to plain backend failed: %v", err)
+	}
+	if !backend.beginCalled || !backend.endCalled {
+		t.Error("expected Begin and End despite semantic commands")
+	}
+}
+
+func TestSemanticTreeNesting(t *testing.T) {
+	rec := NewRecorder(200, 200)
+	rec.BeginSemantic(Semantics{Role: RoleList})
+	rec.BeginSemantic(Semantics{Role: RoleListItem, Label: "first"})
+	rec.FillRectangle(10, 10, 40, 20)
+	rec.EndSemantic()
+	rec.BeginSemantic(Semantics{Role: RoleListItem, Label: "second"})
+	rec.FillRectangle(10, 40, 40, 20)
+	rec.EndSemantic()
+	rec.EndSemantic()
+	r := rec.FinishRecording()
+
+	tree := r.SemanticTree()
+	if len(tree) != 1 {
+		t.Fatalf("got %d roots, want 1", len(tree))
+	}
+	list := tree[0]
+	if list.Semantics.Role != RoleList || len(list.Children) != 2 {
+		t.Fatalf("root = %v with %d children, want List with 2", list.Semantics.Role, len(list.Children))
+	}
+	if list.Children[0].Semantics.Label != "first" || list.Children[1].Semantics.Label != "second" {
+		t.Errorf("child labels = %q, %q", list.Children[0].Semantics.Label, list.Children[1].Semantics.Label)
+	}
+}
+
+func TestSemanticTreeDerivedBounds(t *testing.T) {
+	rec := NewRecorder(200, 200)
+	rec.BeginSemantic(Semantics{Role: RoleFigure, Label: "two boxes"})
+	rec.FillRectangle(10, 10, 40, 20)
+	rec.FillRectangle(60, 50, 20, 30)
+	rec.EndSemantic()
+	r := rec.FinishRecording()
+
+	tree := r.SemanticTree()
+	if len(tree) != 1 {
+		t.Fatalf("got %d roots, want 1", len(tree))
+	}
+	got := tree[0].Semantics.Bounds
+	want := Rect{MinX: 10, MinY: 10, MaxX: 80, MaxY: 80}
+	if got != want {
+		t.Errorf("derived bounds = %+v, want %+v", got, want)
+	}
+}
+
+func TestSemanticTreeExplicitBounds(t *testing.T) {
+	rec := NewRecorder(200, 200)
+	explicit := NewRect(0, 0, 200, 100)
+	rec.BeginSemantic(Semantics{Role: RoleLink, Label: "home", Bounds: explicit})
+	rec.FillRectangle(10, 10, 20, 20)
+	rec.EndSemantic()
+	r := rec.FinishRecording()
+
+	tree := r.SemanticTree()
+	if len(tree) != 1 {
+		t.Fatalf("got %d roots, want 1", len(tree))
+	}
+	if got := tree[0].Semantics.Bounds; got != explicit {
+		t.Errorf("bounds = %+v, want explicit %+v", got, explicit)
+	}
+}
+
+func TestSemanticUnbalancedEnd(t *testing.T) {
+	rec := NewRecorder(100, 100)
+	rec.EndSemantic() // no open group: no-op
+	rec.BeginSemantic(Semantics{Role: RoleParagraph})
+	rec.EndSemantic()
+	r := rec.FinishRecording()
+
+	if len(r.Commands()) != 2 {
+		t.Errorf("got %d commands, want 2 (stray EndSemantic dropped)", len(r.Commands()))
+	}
+}
+
+func TestSemanticRoleString(t *testing.T) {
+	if got := RoleHeading.String(); got != "Heading" {
+		t.Errorf("RoleHeading.String() = %q, want Heading", got)
+	}
+	if got := SemanticRole(200).String(); got != "Unknown" {
+		t.Errorf("invalid role String() = %q, want Unknown", got)
+	}
+}
@@ -110,6 +110,9 @@ func (r *GPURenderer) Capabilities() RendererCapabilities {
 		SupportsGradients:    false, // TODO: Phase 3
 		SupportsTextures:     false, // TODO: Phase 3
 		MaxTextureSize:       8192,  // Typical GPU limit
+		MaxClipDepth:         0,     // No limit via software fallback
+		SupportsCompute:      false, // TODO: Phase 3 (query device)
+		SupportsReadback:     true,  // Via software fallback / staging copy
 	}
 }
 
@@ -0,0 +1,85 @@
+// Copyright 2026 The gogpu Authors
+// SPDX-License-Identifier: MIT
+
+package render
+
+import (
+	"errors"
+	"image"
+)
+
+// ErrNoReadback is returned when a target's pixels cannot be read back
+// into CPU memory.
+var ErrNoReadback = errors.New("render: target does not support pixel readback")
+
+// ReadbackView is an optional interface for TextureViews that support
+// GPU-to-CPU pixel readback. Host applications (e.g. gogpu) implement it
+// on their view types to enable ReadTargetPixels on GPU-only targets.
+// Go's structural typing means no explicit declaration is needed.
+type ReadbackView interface {
+	TextureView
+
+	// ReadPixels copies the given region of the texture into CPU memory.
+	// The rectangle is in pixels with a top-left origin.
+	ReadPixels(r image.Rectangle) (*image.RGBA, error)
+}
+
+// ReadTargetPixels reads back a region of a render target as an RGBA image.
+//
+// For CPU-accessible targets (Pixels() != nil) the region is copied
+// directly. For GPU-only targets the view must implement [ReadbackView];
+// otherwise ErrNoReadback is returned.
+//
+// An empty rectangle reads the full target. The rectangle is clamped to
+// the target bounds. Readback from GPU targets stalls the pipeline — use
+// for tests, screenshots, and visual diffing, not per-frame work.
+func ReadTargetPixels(t RenderTarget, r image.Rectangle) (*image.RGBA, error) {
+	if t == nil {
+		return nil, errors.New("render: nil target")
+	}
+
+	bounds := image.Rect(0, 0, t.Width(), t.Height())
+	if r.Empty() {
+		r = bounds
+	} else {
+		r = r.Intersect(bounds)
+		if r.Empty() {
+			return nil, errors.New("render: read region outside target bounds")
+		}
+	}
+
+	if pixels := t.Pixels(); pixels != nil {
+		return copyPixelRegion(pixels, t.Stride(), r), nil
+	}
+
+	if rv, ok := t.TextureView().(ReadbackView); ok {
+		return rv.ReadPixels(r)
+	}
+	return nil, ErrNoReadback
+}
+
+// copyPixelRegion extracts a region from RGBA row-major pixel data into a
+// standalone image anchored at (0, 0).
+func copyPixelRegion(pixels []byte, stride int, r image.Rectangle) *image.RGBA {
+	const bytesPerPixel = 4
+	img := image.NewRGBA(image.Rect(0, 0, r.Dx(), r.Dy()))
+	rowLen := r.Dx() * bytesPerPixel
+	for y := 0; y < r.Dy(); y++ {
+		src := (r.Min.Y+y)*stride + r.Min.X*bytesPerPixel
+		copy(img.Pix[y*img.Stride:y*img.Stride+rowLen], pixels[src:src+rowLen])
+	}
+	return img
+}
+
+// ReadPixels reads back a region of the pixmap as a standalone image.
+// An empty rectangle reads the full target.
+func (t *PixmapTarget) ReadPixels(r image.Rectangle) (*image.RGBA, error) {
+	return ReadTargetPixels(t, r)
+}
+
+// ReadPixels reads back a region of the surface after compositing.
+// Requires the host's texture view to implement [ReadbackView]; returns
+// ErrNoReadback otherwise. An empty rectangle reads the full surface.
+func (t *SurfaceTarget) ReadPixels(r image.Rectangle) (*image.RGBA, error) {
+	return ReadTargetPixels(t, r)
+}
@@ -0,0 +1,87 @@
+// Copyright 2026 The gogpu Authors
+// SPDX-License-Identifier: MIT
+
+package render
+
+import (
+	"image"
+	"image/color"
+	"testing"
+
+	"github.com/gogpu/gputypes"
+)
+
+func TestReadTargetPixelsCPU(t *testing.T) {
+	target := NewPixmapTarget(8, 8)
+	target.Clear(color.RGBA{R: 255, A: 255})
+	target.SetPixel(5, 2, color.RGBA{B: 255, A: 255})
+
+	// Full read via the target method.
+	img, err := target.ReadPixels(image.Rectangle{})
+	if err != nil {
+		t.Fatalf("ReadPixels failed: %v", err)
+	}
+	if img.Bounds() != image.Rect(0, 0, 8, 8) {
+		t.Errorf("bounds = %v, want (0,0)-(8,8)", img.Bounds())
+	}
+	if r, _, _, _ := img.At(0, 0).RGBA(); r < 0xF000 {
+		t.Errorf("pixel (0,0) = %v, want red", img.At(0, 0))
+	}
+
+	// Region read is anchored at (0,0) and picks up the right pixels.
+	region, err := target.ReadPixels(image.Rect(4, 2, 8, 4))
+	if err != nil {
+		t.Fatalf("region ReadPixels failed: %v", err)
+	}
+	if region.Bounds() != image.Rect(0, 0, 4, 2) {
+		t.Errorf("region bounds = %v, want (0,0)-(4,2)", region.Bounds())
+	}
+	if _, _, b, _ := region.At(1, 0).RGBA(); b < 0xF000 {
+		t.Errorf("region pixel (1,0) = %v, want blue (target pixel (5,2))", region.At(1, 0))
+	}
+
+	// The copy must not alias the target.
+	target.SetPixel(0, 0, color.RGBA{G: 255, A: 255})
+	if r, _, _, _ := img.At(0, 0).RGBA(); r < 0xF000 {
+		t.Error("readback image changed after target write — shares memory")
+	}
+
+	// Out-of-bounds region is an error.
+	if _, err := target.ReadPixels(image.Rect(100, 100, 200, 200)); err == nil {
+		t.Error("expected error for out-of-bounds region")
+	}
+}
+
+// readbackTestView implements ReadbackView for GPU readback routing tests.
+type readbackTestView struct {
+	read image.Rectangle
+}
+
+func (v *readbackTestView) Destroy() {}
+
+func (v *readbackTestView) ReadPixels(r image.Rectangle) (*image.RGBA, error) {
+	v.read = r
+	return image.NewRGBA(image.Rect(0, 0, r.Dx(), r.Dy())), nil
+}
+
+func TestReadTargetPixelsGPU(t *testing.T) {
+	// GPU-only target without a readback-capable view.
+	plain := NewSurfaceTarget(32, 32, gputypes.TextureFormatBGRA8Unorm, nil)
+	if _, err := plain.ReadPixels(image.Rectangle{}); err != ErrNoReadback {
+		t.Errorf("ReadPixels without ReadbackView = %v, want ErrNoReadback", err)
+	}
+
+	// With a readback-capable view, the clamped region is delegated.
+	view := &readbackTestView{}
+	surface := NewSurfaceTarget(32, 32, gputypes.TextureFormatBGRA8Unorm, view)
+	img, err := surface.ReadPixels(image.Rect(8, 8, 100, 100))
+	if err != nil {
+		t.Fatalf("ReadPixels failed: %v", err)
+	}
+	if view.read != image.Rect(8, 8, 32, 32) {
+		t.Errorf("view read region = %v, want clamped (8,8)-(32,32)", view.read)
+	}
+	if img.Bounds().Dx() != 24 || img.Bounds().Dy() != 24 {
+		t.Errorf("image size = %v, want 24x24", img.Bounds())
+	}
+}
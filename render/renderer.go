@@ -68,6 +68,19 @@ type RendererCapabilities struct {
 
 	// MaxTextureSize is the maximum texture dimension (0 = unlimited).
 	MaxTextureSize int
+
+	// MaxClipDepth is the maximum nesting depth of clip regions
+	// (0 = unlimited).
+	MaxClipDepth int
+
+	// SupportsCompute indicates if compute-shader rasterization is
+	// available. Renderers without compute run all stages on the CPU
+	// or the raster pipeline.
+	SupportsCompute bool
+
+	// SupportsReadback indicates if rendered output can be read back
+	// into CPU memory (e.g. for screenshots or pixel tests).
+	SupportsReadback bool
 }
 
 // CapableRenderer is an optional interface for renderers that can
@@ -78,3 +91,20 @@ type CapableRenderer interface {
 	// Capabilities returns the renderer's capabilities.
 	Capabilities() RendererCapabilities
 }
+
+// QueryCapabilities returns the capabilities of a renderer.
+//
+// If the renderer implements [CapableRenderer], its reported capabilities
+// are returned. Otherwise a conservative baseline is assumed: CPU
+// rendering with antialiasing and readback, no optional features. This
+// lets callers branch on features up front instead of relying on runtime
+// fallbacks.
+func QueryCapabilities(r Renderer) RendererCapabilities {
+	if cr, ok := r.(CapableRenderer); ok {
+		return cr.Capabilities()
+	}
+	return RendererCapabilities{
+		SupportsAntialiasing: true,
+		SupportsReadback:     true,
+	}
+}
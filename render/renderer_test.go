@@ -0,0 +1,51 @@
+// Copyright 2026 The gogpu Authors
+// SPDX-License-Identifier: MIT
+
+package render
+
+import "testing"
+
+// minimalRenderer implements Renderer without CapableRenderer.
+type minimalRenderer struct{}
+
+func (minimalRenderer) Render(RenderTarget, *Scene) error { return nil }
+func (minimalRenderer) Flush() error                      { return nil }
+
+func TestQueryCapabilities(t *testing.T) {
+	// A CapableRenderer reports its own capabilities.
+	sw := NewSoftwareRenderer()
+	caps := QueryCapabilities(sw)
+	if caps.IsGPU {
+		t.Error("software renderer should not report IsGPU")
+	}
+	if !caps.SupportsReadback {
+		t.Error("software renderer should support readback")
+	}
+	if caps.SupportsCompute {
+		t.Error("software renderer should not report compute support")
+	}
+
+	// Renderers without capability reporting get the conservative baseline.
+	caps = QueryCapabilities(minimalRenderer{})
+	if caps.IsGPU || caps.SupportsCompute {
+		t.Error("baseline capabilities should not include GPU or compute")
+	}
+	if !caps.SupportsAntialiasing || !caps.SupportsReadback {
+		t.Error("baseline capabilities should include antialiasing and readback")
+	}
+}
+
+func TestGPURendererExtendedCapabilities(t *testing.T) {
+	renderer, err := NewGPURenderer(NullDeviceHandle{})
+	if err != nil {
+		t.Fatalf("NewGPURenderer failed: %v", err)
+	}
+
+	caps := renderer.Capabilities()
+	if !caps.SupportsReadback {
+		t.Error("GPU renderer should support readback via fallback")
+	}
+	if caps.MaxClipDepth != 0 {
+		t.Errorf("MaxClipDepth = %d, want 0 (unlimited)", caps.MaxClipDepth)
+	}
+}
@@ -113,6 +113,9 @@ func (r *SoftwareRenderer) Capabilities() RendererCapabilities {
 		SupportsGradients:    false, // TODO: implement
 		SupportsTextures:     false, // TODO: implement
 		MaxTextureSize:       0,     // No limit
+		MaxClipDepth:         0,     // No limit (CPU clip stack)
+		SupportsCompute:      false,
+		SupportsReadback:     true, // CPU targets are always readable
 	}
 }
 
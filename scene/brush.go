@@ -0,0 +1,49 @@
+package scene
+
+import "github.com/gogpu/gg"
+
+// Brush adapters between gg.Brush and scene.Brush.
+//
+// gg.Brush is the shared brush interface across the gg, scene, and recording
+// packages. scene.Brush is a small value type suited to the encoding's brush
+// table; for non-solid brushes it carries the originating gg.Brush in Source
+// so playback through the CPU tile renderer samples the real gradient or
+// pattern instead of a flattened color.
+
+// BrushFromGG converts a gg.Brush to a scene.Brush. Solid brushes convert to
+// BrushSolid with the color stored inline. Gradient brushes keep the gg.Brush
+// in Source and store a representative color (the gradient's first stop) for
+// consumers that cannot sample per pixel.
+func BrushFromGG(b gg.Brush) Brush {
+	switch br := b.(type) {
+	case gg.SolidBrush:
+		return SolidBrush(br.Color)
+	case *gg.LinearGradientBrush:
+		return Brush{Kind: BrushLinearGradient, Color: representativeColor(b, br.Stops), Source: b}
+	case *gg.RadialGradientBrush:
+		return Brush{Kind: BrushRadialGradient, Color: representativeColor(b, br.Stops), Source: b}
+	default:
+		// Sweep gradients, patterns, and custom brushes have no dedicated
+		// BrushKind; classify as linear gradient (the generic "sampled"
+		// kind) so playback still goes through Source.
+		return Brush{Kind: BrushLinearGradient, Color: b.ColorAt(0, 0), Source: b}
+	}
+}
+
+// ToGG converts the brush back to a gg.Brush: Source when present, otherwise
+// a solid brush with the inline color.
+func (b Brush) ToGG() gg.Brush {
+	if b.Source != nil {
+		return b.Source
+	}
+	return gg.Solid(b.Color)
+}
+
+// representativeColor picks a single color to stand in for a gradient: the
+// first stop when one exists, otherwise a sample at the origin.
+func representativeColor(b gg.Brush, stops []gg.ColorStop) gg.RGBA {
+	if len(stops) > 0 {
+		return stops[0].Color
+	}
+	return b.ColorAt(0, 0)
+}
@@ -0,0 +1,95 @@
+package scene
+
+import (
+	"testing"
+
+	"github.com/gogpu/gg"
+)
+
+func TestBrushFromGGSolid(t *testing.T) {
+	b := BrushFromGG(gg.Solid(gg.Red))
+	if b.Kind != BrushSolid {
+		t.Errorf("Kind = %v, want BrushSolid", b.Kind)
+	}
+	if b.Color != gg.Red {
+		t.Errorf("Color = %v, want Red", b.Color)
+	}
+	if b.Source != nil {
+		t.Error("Source should be nil for solid brushes")
+	}
+}
+
+func TestBrushFromGGLinearGradient(t *testing.T) {
+	grad := gg.NewLinearGradientBrush(0, 0, 100, 0).
+		AddColorStop(0, gg.Red).
+		AddColorStop(1, gg.Blue)
+
+	b := BrushFromGG(grad)
+	if b.Kind != BrushLinearGradient {
+		t.Errorf("Kind = %v, want BrushLinearGradient", b.Kind)
+	}
+	if b.Color != gg.Red {
+		t.Errorf("representative Color = %v, want first stop Red", b.Color)
+	}
+	if b.Source != grad {
+		t.Error("Source should carry the original gg.Brush")
+	}
+}
+
+func TestBrushFromGGRadialGradient(t *testing.T) {
+	grad := gg.NewRadialGradientBrush(50, 50, 0, 40).
+		AddColorStop(0, gg.White).
+		AddColorStop(1, gg.Black)
+
+	b := BrushFromGG(grad)
+	if b.Kind != BrushRadialGradient {
+		t.Errorf("Kind = %v, want BrushRadialGradient", b.Kind)
+	}
+	if b.Source != grad {
+		t.Error("Source should carry the original gg.Brush")
+	}
+}
+
+func TestBrushToGG(t *testing.T) {
+	// Solid round trip.
+	solid := SolidBrush(gg.Green).ToGG()
+	if sb, ok := solid.(gg.SolidBrush); !ok || sb.Color != gg.Green {
+		t.Errorf("SolidBrush.ToGG() = %T %v, want gg.SolidBrush Green", solid, solid)
+	}
+
+	// Gradient round trip returns the identical brush.
+	grad := gg.NewLinearGradientBrush(0, 0, 100, 0).
+		AddColorStop(0, gg.Red).
+		AddColorStop(1, gg.Blue)
+	if got := BrushFromGG(grad).ToGG(); got != gg.Brush(grad) {
+		t.Errorf("gradient ToGG() = %v, want original brush", got)
+	}
+}
+
+func TestResetFillPaintSamplesGradient(t *testing.T) {
+	grad := gg.NewLinearGradientBrush(0, 0, 100, 0).
+		AddColorStop(0, gg.Red).
+		AddColorStop(1, gg.Blue)
+
+	paint := gg.NewPaint()
+	resetFillPaint(paint, BrushFromGG(grad), FillNonZero)
+
+	if got := paint.ColorAt(0, 0); got.R < 0.99 || got.B > 0.01 {
+		t.Errorf("ColorAt(0,0) = %+v, want Red", got)
+	}
+	if got := paint.ColorAt(100, 0); got.B < 0.99 || got.R > 0.01 {
+		t.Errorf("ColorAt(100,0) = %+v, want Blue", got)
+	}
+}
+
+func TestConvertStrokePaintSamplesGradient(t *testing.T) {
+	grad := gg.NewLinearGradientBrush(0, 0, 100, 0).
+		AddColorStop(0, gg.Red).
+		AddColorStop(1, gg.Blue)
+
+	paint := convertStrokePaint(BrushFromGG(grad), DefaultStrokeStyle())
+
+	if got := paint.ColorAt(100, 0); got.B < 0.99 || got.R > 0.01 {
+		t.Errorf("stroke paint ColorAt(100,0) = %+v, want Blue", got)
+	}
+}
@@ -1,5 +1,7 @@
 package scene
 
+import "github.com/gogpu/gg/text"
+
 // SceneBuilder provides a fluent API for constructing scenes ergonomically.
 // It wraps a Scene and provides chainable methods for drawing operations,
 // transform management, and layer composition.
@@ -112,6 +114,64 @@ func (b *SceneBuilder) Image(img *Image, rect Rect) *SceneBuilder {
 	return b
 }
 
+// WithID assigns a hit-test ID to the draw commands recorded after it.
+// Scene.HitTest reports the ID of the topmost command containing a point,
+// so interactive diagrams can map hits back to application objects.
+// Passing zero clears the assignment.
+func (b *SceneBuilder) WithID(id uint64) *SceneBuilder {
+	b.scene.SetHitID(id)
+	return b
+}
+
+// DrawImage draws the srcRect portion of an image into dstRect with the
+// given sampling mode and opacity. An empty srcRect selects the whole image.
+// The builder's accumulated transform is applied on top of the src-to-dst
+// mapping. GPU backends upload the image through the texture atlas; the
+// software renderer routes through gg's DrawImageEx machinery.
+func (b *SceneBuilder) DrawImage(img *Image, srcRect, dstRect Rect, sampling ImageSampling, opacity float32) *SceneBuilder {
+	if img == nil || img.IsEmpty() || dstRect.IsEmpty() {
+		return b
+	}
+	if srcRect.IsEmpty() {
+		srcRect = img.Bounds()
+	}
+
+	// Map source pixels to dstRect: translate the source origin to zero,
+	// scale src dimensions to dst dimensions, then place at dstRect.Min.
+	scaleX := dstRect.Width() / srcRect.Width()
+	scaleY := dstRect.Height() / srcRect.Height()
+	imgTransform := b.transform.
+		Multiply(TranslateAffine(dstRect.MinX, dstRect.MinY)).
+		Multiply(ScaleAffine(scaleX, scaleY)).
+		Multiply(TranslateAffine(-srcRect.MinX, -srcRect.MinY))
+
+	b.scene.DrawImageEx(img, srcRect, imgTransform, sampling, opacity)
+	return b
+}
+
+// Text shapes a string and records it as a glyph run at baseline (x, y).
+// The builder's accumulated transform is applied. Shaping errors (nil face,
+// oversized string) silently skip the run to keep the API chainable — use
+// Scene.DrawText directly when errors must be observed.
+func (b *SceneBuilder) Text(str string, face text.Face, x, y float32, brush Brush) *SceneBuilder {
+	saved := b.scene.Transform()
+	b.scene.SetTransform(saved.Multiply(b.transform))
+	_ = b.scene.DrawText(str, face, x, y, brush)
+	b.scene.SetTransform(saved)
+	return b
+}
+
+// TextGlyphs records pre-shaped glyphs as a glyph run, skipping shaping.
+// Use when glyphs come from an external layout engine. Like Text, errors
+// silently skip the run.
+func (b *SceneBuilder) TextGlyphs(str string, glyphs []text.ShapedGlyph, face text.Face, x, y float32, brush Brush) *SceneBuilder {
+	saved := b.scene.Transform()
+	b.scene.SetTransform(saved.Multiply(b.transform))
+	_ = b.scene.DrawGlyphs(str, glyphs, face, x, y, brush)
+	b.scene.SetTransform(saved)
+	return b
+}
+
 // ---------------------------------------------------------------------------
 // Transform Operations
 // ---------------------------------------------------------------------------
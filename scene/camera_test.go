@@ -0,0 +1,143 @@
+package scene_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/gogpu/gg"
+	"github.com/gogpu/gg/scene"
+)
+
+func TestCameraZoomClamping(t *testing.T) {
+	c := scene.NewCamera()
+	if c.Zoom() != 1 {
+		t.Errorf("initial zoom = %v, want 1", c.Zoom())
+	}
+
+	c.SetZoom(1000)
+	if c.Zoom() != scene.DefaultMaxZoom {
+		t.Errorf("zoom = %v, want clamped to %v", c.Zoom(), scene.DefaultMaxZoom)
+	}
+	c.SetZoom(0)
+	if c.Zoom() != scene.DefaultMinZoom {
+		t.Errorf("zoom = %v, want clamped to %v", c.Zoom(), scene.DefaultMinZoom)
+	}
+
+	c.SetZoomLimits(0.5, 4)
+	c.SetZoom(8)
+	if c.Zoom() != 4 {
+		t.Errorf("zoom = %v, want clamped to new max 4", c.Zoom())
+	}
+
+	// Swapped limits are normalized.
+	c.SetZoomLimits(16, 2)
+	c.SetZoom(32)
+	if c.Zoom() != 16 {
+		t.Errorf("zoom = %v, want 16 after swapped limits", c.Zoom())
+	}
+}
+
+func TestCameraZoomAtKeepsPointFixed(t *testing.T) {
+	c := scene.NewCamera()
+	c.SetPan(10, 20)
+	c.SetZoom(2)
+
+	// World point under device (100, 100) before zooming.
+	a := c.Affine()
+	worldX := (100 - float64(a.C)) / float64(a.A)
+	worldY := (100 - float64(a.F)) / float64(a.E)
+
+	c.ZoomAt(2, 100, 100)
+
+	a = c.Affine()
+	devX := float64(a.A)*worldX + float64(a.C)
+	devY := float64(a.E)*worldY + float64(a.F)
+	if math.Abs(devX-100) > 1e-3 || math.Abs(devY-100) > 1e-3 {
+		t.Errorf("world point maps to (%v, %v) after ZoomAt, want (100, 100)", devX, devY)
+	}
+	if c.Zoom() != 4 {
+		t.Errorf("zoom = %v, want 4", c.Zoom())
+	}
+}
+
+func TestCameraPanAndReset(t *testing.T) {
+	c := scene.NewCamera()
+	c.Pan(5, -3)
+	c.Pan(5, 3)
+	if x, y := c.PanOffset(); x != 10 || y != 0 {
+		t.Errorf("pan = (%v, %v), want (10, 0)", x, y)
+	}
+
+	c.SetZoom(8)
+	c.Reset()
+	if c.Zoom() != 1 {
+		t.Errorf("zoom after reset = %v, want 1", c.Zoom())
+	}
+	if x, y := c.PanOffset(); x != 0 || y != 0 {
+		t.Errorf("pan after reset = (%v, %v), want (0, 0)", x, y)
+	}
+}
+
+func TestCameraFlatteningTolerance(t *testing.T) {
+	c := scene.NewCamera()
+	if got := c.FlatteningTolerance(0.25); got != 0.25 {
+		t.Errorf("tolerance at 1x = %v, want 0.25", got)
+	}
+	c.SetZoom(64)
+	if got := c.FlatteningTolerance(0.25); math.Abs(got-0.25/64) > 1e-9 {
+		t.Errorf("tolerance at 64x = %v, want %v", got, 0.25/64)
+	}
+	// Zooming out does not coarsen below the base tolerance.
+	c.SetZoom(0.5)
+	if got := c.FlatteningTolerance(0.25); got != 0.25 {
+		t.Errorf("tolerance at 0.5x = %v, want 0.25", got)
+	}
+}
+
+// TestRendererCameraZoomPan verifies that a camera on the Renderer scales
+// and offsets the whole scene without re-encoding it.
+func TestRendererCameraZoomPan(t *testing.T) {
+	gg.CloseAccelerator()
+
+	s := scene.NewScene()
+	b := scene.NewSceneBuilderFrom(s)
+	b.FillRect(10, 10, 20, 20, scene.SolidBrush(gg.Red))
+
+	r := scene.NewRenderer(200, 200)
+	defer r.Close()
+
+	cam := scene.NewCamera()
+	cam.SetZoom(2)
+	cam.Pan(40, 0)
+	r.SetCamera(cam)
+	if r.Camera() != cam {
+		t.Fatal("Camera() did not return the attached camera")
+	}
+
+	target := gg.NewPixmap(200, 200)
+	if err := r.Render(target, s); err != nil {
+		t.Fatalf("Render: %v", err)
+	}
+
+	// World rect (10,10)-(30,30) maps to device (60,20)-(100,60).
+	if c := target.GetPixel(80, 40); c.R < 0.9 {
+		t.Errorf("pixel inside zoomed rect = %+v, want red", c)
+	}
+	if c := target.GetPixel(50, 40); c.A > 0.1 {
+		t.Errorf("pixel left of zoomed rect = %+v, want transparent", c)
+	}
+	if c := target.GetPixel(15, 15); c.A > 0.1 {
+		t.Errorf("pixel at unzoomed position = %+v, want transparent", c)
+	}
+
+	// Removing the camera restores the identity view.
+	r.SetCamera(nil)
+	r.MarkAllDirty()
+	target2 := gg.NewPixmap(200, 200)
+	if err := r.Render(target2, s); err != nil {
+		t.Fatalf("Render without camera: %v", err)
+	}
+	if c := target2.GetPixel(15, 15); c.R < 0.9 {
+		t.Errorf("pixel without camera = %+v, want red", c)
+	}
+}
@@ -332,6 +332,37 @@ func (d *Decoder) Image() (imageIndex uint32, transform Affine) {
 	return imageIndex, transform
 }
 
+// ImageEx reads the current ImageEx command data.
+// Returns the image index, source rect, sampling mode, opacity, and transform.
+// Only valid when Tag() == TagImageEx.
+func (d *Decoder) ImageEx() (imageIndex uint32, srcRect Rect, sampling ImageSampling, opacity float32, transform Affine) {
+	opacity = 1.0
+	transform = IdentityAffine()
+
+	if d.drawIdx+3 <= len(d.enc.drawData) {
+		imageIndex = d.enc.drawData[d.drawIdx]
+		sampling = ImageSampling(d.enc.drawData[d.drawIdx+1])
+		opacity = math.Float32frombits(d.enc.drawData[d.drawIdx+2])
+	}
+	d.drawIdx += 3
+
+	if d.pathIdx+4 <= len(d.enc.pathData) {
+		srcRect = Rect{
+			MinX: d.enc.pathData[d.pathIdx],
+			MinY: d.enc.pathData[d.pathIdx+1],
+			MaxX: d.enc.pathData[d.pathIdx+2],
+			MaxY: d.enc.pathData[d.pathIdx+3],
+		}
+	}
+	d.pathIdx += 4
+
+	if d.transIdx < len(d.enc.transforms) {
+		transform = d.enc.transforms[d.transIdx]
+		d.transIdx++
+	}
+	return imageIndex, srcRect, sampling, opacity, transform
+}
+
 // ---------------------------------------------------------------------------
 // Brush Command Decoder
 // ---------------------------------------------------------------------------
@@ -0,0 +1,25 @@
+package scene
+
+import "github.com/gogpu/gg"
+
+// Draw replays a retained scene's commands through an immediate-mode
+// gg.Context, so retained content can be composited into an existing
+// drawing. Layers, blend modes, clips, text runs, and images are all
+// preserved; the Context's current transform is composed with the
+// scene's transforms, so a dc.Translate() before Draw positions the
+// whole scene.
+//
+// Rendering routes through the Context's normal dispatch: shapes use
+// the GPU accelerator when one is registered and fall back to CPU
+// rasterization per-shape otherwise. The Context's transform and state
+// stack are restored before Draw returns.
+//
+// Draw is a no-op (returning nil) when dc or s is nil, or when the
+// scene is empty.
+func Draw(dc *gg.Context, s *Scene) error {
+	if dc == nil || s == nil {
+		return nil
+	}
+	r := NewGPUSceneRenderer(dc)
+	return r.RenderScene(s)
+}
@@ -0,0 +1,108 @@
+package scene_test
+
+import (
+	"testing"
+
+	"github.com/gogpu/gg"
+	"github.com/gogpu/gg/scene"
+)
+
+// TestDraw_FillRect verifies that Draw replays scene content into a
+// Context while preserving the Context's pre-existing background.
+func TestDraw_FillRect(t *testing.T) {
+	gg.CloseAccelerator()
+
+	s := scene.NewScene()
+	b := scene.NewSceneBuilderFrom(s)
+	b.FillRect(20, 20, 40, 40, scene.SolidBrush(gg.Blue))
+
+	pm := gg.NewPixmap(100, 100)
+	dc := gg.NewContextForPixmap(pm)
+	defer func() { _ = dc.Close() }()
+	dc.ClearWithColor(gg.White)
+
+	if err := scene.Draw(dc, s); err != nil {
+		t.Fatalf("Draw: %v", err)
+	}
+
+	// Inside the rect: blue.
+	c := pm.GetPixel(40, 40)
+	if c.B < 0.9 || c.R > 0.1 {
+		t.Errorf("pixel inside rect = %+v, want blue", c)
+	}
+	// Outside the rect: the Context's white background must survive.
+	c = pm.GetPixel(5, 5)
+	if c.R < 0.9 || c.G < 0.9 || c.B < 0.9 {
+		t.Errorf("pixel outside rect = %+v, want white background", c)
+	}
+}
+
+// TestDraw_ComposesContextTransform verifies that a transform already on
+// the Context offsets the replayed scene.
+func TestDraw_ComposesContextTransform(t *testing.T) {
+	gg.CloseAccelerator()
+
+	s := scene.NewScene()
+	b := scene.NewSceneBuilderFrom(s)
+	b.FillRect(0, 0, 20, 20, scene.SolidBrush(gg.Red))
+
+	pm := gg.NewPixmap(100, 100)
+	dc := gg.NewContextForPixmap(pm)
+	defer func() { _ = dc.Close() }()
+	dc.ClearWithColor(gg.White)
+	dc.Translate(50, 50)
+
+	if err := scene.Draw(dc, s); err != nil {
+		t.Fatalf("Draw: %v", err)
+	}
+
+	if c := pm.GetPixel(60, 60); c.R < 0.9 || c.G > 0.1 {
+		t.Errorf("translated rect pixel = %+v, want red", c)
+	}
+	if c := pm.GetPixel(10, 10); c.R < 0.9 || c.G < 0.9 || c.B < 0.9 {
+		t.Errorf("origin pixel = %+v, want white (rect moved away)", c)
+	}
+}
+
+// TestDraw_LayerAlpha verifies that layer alpha from the scene carries
+// through the replay.
+func TestDraw_LayerAlpha(t *testing.T) {
+	gg.CloseAccelerator()
+
+	s := scene.NewScene()
+	b := scene.NewSceneBuilderFrom(s)
+	b.Layer(scene.BlendSourceOver, 0.5, nil, func(b *scene.SceneBuilder) {
+		b.FillRect(0, 0, 100, 100, scene.SolidBrush(gg.Black))
+	})
+
+	pm := gg.NewPixmap(100, 100)
+	dc := gg.NewContextForPixmap(pm)
+	defer func() { _ = dc.Close() }()
+	dc.ClearWithColor(gg.White)
+
+	if err := scene.Draw(dc, s); err != nil {
+		t.Fatalf("Draw: %v", err)
+	}
+
+	// Black at 50% over white should land near mid-gray.
+	c := pm.GetPixel(50, 50)
+	if c.R < 0.3 || c.R > 0.7 {
+		t.Errorf("layer-composited pixel = %+v, want ~mid gray", c)
+	}
+}
+
+// TestDraw_NilSafe verifies nil arguments and empty scenes are no-ops.
+func TestDraw_NilSafe(t *testing.T) {
+	dc := gg.NewContext(10, 10)
+	defer func() { _ = dc.Close() }()
+
+	if err := scene.Draw(nil, scene.NewScene()); err != nil {
+		t.Errorf("Draw(nil, s) = %v, want nil", err)
+	}
+	if err := scene.Draw(dc, nil); err != nil {
+		t.Errorf("Draw(dc, nil) = %v, want nil", err)
+	}
+	if err := scene.Draw(dc, scene.NewScene()); err != nil {
+		t.Errorf("Draw with empty scene = %v, want nil", err)
+	}
+}
@@ -191,8 +191,13 @@ const (
 // Brush represents a paint source for fill/stroke operations.
 type Brush struct {
 	Kind  BrushKind
-	Color gg.RGBA // For solid brushes
-	// Additional fields for gradients/images would go here
+	Color gg.RGBA // For solid brushes; representative color for gradients
+
+	// Source carries the originating gg.Brush for gradient and pattern
+	// brushes so scene playback can sample them losslessly. Nil for solid
+	// brushes (Color is authoritative). Use BrushFromGG / ToGG to convert;
+	// see brush.go.
+	Source gg.Brush
 }
 
 // SolidBrush creates a solid color brush.
@@ -349,6 +354,24 @@ func (a Affine) TransformPoint(x, y float32) (float32, float32) {
 	return a.A*x + a.B*y + a.C, a.D*x + a.E*y + a.F
 }
 
+// Invert returns the inverse transformation. The second result is false for
+// degenerate (non-invertible) transforms.
+func (a Affine) Invert() (Affine, bool) {
+	det := a.A*a.E - a.B*a.D
+	if det == 0 {
+		return IdentityAffine(), false
+	}
+	invDet := 1.0 / det
+	return Affine{
+		A: a.E * invDet,
+		B: -a.B * invDet,
+		C: (a.B*a.F - a.E*a.C) * invDet,
+		D: -a.D * invDet,
+		E: a.A * invDet,
+		F: (a.D*a.C - a.A*a.F) * invDet,
+	}, true
+}
+
 // IsIdentity returns true if this is the identity transformation.
 func (a Affine) IsIdentity() bool {
 	return a.A == 1 && a.B == 0 && a.C == 0 &&
@@ -432,6 +455,15 @@ type Encoding struct {
 	// Used by DamageTracker for frame-to-frame object diff.
 	commandBounds []Rect
 
+	// commandIDs tracks the user-assigned hit-test ID of each draw command,
+	// in encoding order (fill, stroke, image, and text commands). Populated
+	// by the Encode* draw methods from currentID; consumed by Scene.HitTest.
+	commandIDs []uint64
+
+	// currentID is the hit-test ID stamped onto subsequently encoded draw
+	// commands. Zero means unassigned.
+	currentID uint64
+
 	// bounds tracks cumulative bounding box
 	bounds Rect
 
@@ -468,12 +500,20 @@ func (e *Encoding) Reset() {
 	e.textData = e.textData[:0]
 	e.brushes = e.brushes[:0]
 	e.commandBounds = e.commandBounds[:0]
+	e.commandIDs = e.commandIDs[:0]
+	e.currentID = 0
 	e.bounds = EmptyRect()
 	e.pathBounds = EmptyRect()
 	e.pathCount = 0
 	e.shapeCount = 0
 }
 
+// SetCommandID sets the hit-test ID stamped onto draw commands encoded from
+// now on. Zero clears the assignment.
+func (e *Encoding) SetCommandID(id uint64) {
+	e.currentID = id
+}
+
 // EncodeAntiAlias adds an anti-aliasing state change command.
 // The value is stored as 1 uint32 in drawData (0 = disabled, 1 = enabled).
 func (e *Encoding) EncodeAntiAlias(enabled bool) {
@@ -575,6 +615,7 @@ func (e *Encoding) EncodeFill(brush Brush, style FillStyle) {
 	e.tags = append(e.tags, TagFill)
 	//nolint:gosec // brush index is bounded by slice length, overflow not possible in practice
 	e.drawData = append(e.drawData, uint32(brushIdx), uint32(style))
+	e.commandIDs = append(e.commandIDs, e.currentID)
 	e.shapeCount++
 }
 
@@ -591,6 +632,7 @@ func (e *Encoding) EncodeFillRoundRect(brush Brush, style FillStyle, rect Rect,
 	e.pathData = append(e.pathData, rect.MinX, rect.MinY, rect.MaxX, rect.MaxY, rx, ry)
 
 	e.bounds = e.bounds.Union(rect)
+	e.commandIDs = append(e.commandIDs, e.currentID)
 	e.shapeCount++
 }
 
@@ -612,6 +654,7 @@ func (e *Encoding) EncodeStroke(brush Brush, style *StrokeStyle) {
 		uint32(style.Cap),
 		uint32(style.Join),
 	)
+	e.commandIDs = append(e.commandIDs, e.currentID)
 	e.shapeCount++
 }
 
@@ -659,6 +702,17 @@ func (e *Encoding) EncodeImage(imageIndex uint32, transform Affine) {
 	e.tags = append(e.tags, TagImage)
 	e.drawData = append(e.drawData, imageIndex)
 	e.transforms = append(e.transforms, transform)
+	e.commandIDs = append(e.commandIDs, e.currentID)
+}
+
+// EncodeImageEx encodes an image reference with a source rect, sampling
+// mode, and opacity. See TagImageEx for the stream layout.
+func (e *Encoding) EncodeImageEx(imageIndex uint32, transform Affine, srcRect Rect, sampling ImageSampling, opacity float32) {
+	e.tags = append(e.tags, TagImageEx)
+	e.drawData = append(e.drawData, imageIndex, uint32(sampling), math.Float32bits(opacity))
+	e.pathData = append(e.pathData, srcRect.MinX, srcRect.MinY, srcRect.MaxX, srcRect.MaxY)
+	e.transforms = append(e.transforms, transform)
+	e.commandIDs = append(e.commandIDs, e.currentID)
 }
 
 // EncodeText encodes a pre-shaped text run as a TagText command.
@@ -705,6 +759,7 @@ func (e *Encoding) EncodeText(run GlyphRunData, glyphs []GlyphEntry, str string)
 	// Text bytes
 	copy(buf[off:], str)
 
+	e.commandIDs = append(e.commandIDs, e.currentID)
 	e.shapeCount++
 }
 
@@ -866,6 +921,11 @@ func (e *Encoding) AppendWithImages(other *Encoding, imageOffset uint32) {
 				e.drawData[drawDataStart+drawIdx] += imageOffset
 			}
 			drawIdx++
+		case TagImageEx:
+			if imageOffset > 0 && drawIdx < len(other.drawData) {
+				e.drawData[drawDataStart+drawIdx] += imageOffset
+			}
+			drawIdx += 3 // image index + sampling + opacity
 		case TagText:
 			if textOff+glyphRunDataSize <= len(other.textData) {
 				glyphCount := int(binary.LittleEndian.Uint16(other.textData[textOff+12:]))
@@ -885,6 +945,9 @@ func (e *Encoding) AppendWithImages(other *Encoding, imageOffset uint32) {
 	// Append brushes
 	e.brushes = append(e.brushes, other.brushes...)
 
+	// Append per-command hit-test IDs
+	e.commandIDs = append(e.commandIDs, other.commandIDs...)
+
 	// Union bounds
 	e.bounds = e.bounds.Union(other.bounds)
 
@@ -1019,6 +1082,13 @@ func (e *Encoding) AppendWithTranslation(other *Encoding, dx, dy float32, imageO
 			}
 			drawIdx++ // image index only; transform is in transforms stream
 
+		case TagImageEx:
+			if imageOffset > 0 && drawIdx < len(other.drawData) {
+				e.drawData[drawDataStart+drawIdx] += imageOffset
+			}
+			drawIdx += 3 // image index + sampling + opacity
+			pathIdx += 4 // source rect is in image space — no coordinate offset
+
 		case TagText:
 			// Text data is in the textData stream. Handled in a second pass below.
 
@@ -1043,6 +1113,7 @@ func (e *Encoding) AppendWithTranslation(other *Encoding, dx, dy float32, imageO
 	e.transforms = append(e.transforms, other.transforms...)
 
 	e.brushes = append(e.brushes, other.brushes...)
+	e.commandIDs = append(e.commandIDs, other.commandIDs...)
 
 	ob := other.bounds
 	ob.MinX += dx
@@ -1106,6 +1177,10 @@ func (e *Encoding) Clone() *Encoding {
 	clone.brushes = make([]Brush, len(e.brushes))
 	copy(clone.brushes, e.brushes)
 
+	clone.commandIDs = make([]uint64, len(e.commandIDs))
+	copy(clone.commandIDs, e.commandIDs)
+	clone.currentID = e.currentID
+
 	clone.bounds = e.bounds
 	clone.pathBounds = e.pathBounds
 	clone.pathCount = e.pathCount
@@ -0,0 +1,229 @@
+package scene
+
+import (
+	"context"
+	"math"
+
+	"github.com/gogpu/gg"
+)
+
+// BackendFeature identifies a rendering feature a scene may require from
+// the selected backend.
+type BackendFeature uint32
+
+// Backend feature flags. Combined into a FeatureSet bitmask.
+const (
+	// FeatureLayerBlendModes is required when a layer uses a blend mode
+	// other than normal/source-over.
+	FeatureLayerBlendModes BackendFeature = 1 << iota
+
+	// FeatureLayerAlpha is required when a layer has opacity below 1.
+	FeatureLayerAlpha
+
+	// FeatureImages is required when the scene draws images.
+	FeatureImages
+
+	// FeatureText is required when the scene draws glyph runs.
+	FeatureText
+
+	// FeatureClipping is required when the scene uses clip regions.
+	FeatureClipping
+)
+
+// String returns a human-readable name for the feature.
+func (f BackendFeature) String() string {
+	switch f {
+	case FeatureLayerBlendModes:
+		return "layer-blend-modes"
+	case FeatureLayerAlpha:
+		return "layer-alpha"
+	case FeatureImages:
+		return "images"
+	case FeatureText:
+		return "text"
+	case FeatureClipping:
+		return "clipping"
+	default:
+		return unknownStr
+	}
+}
+
+// FeatureSet is a bitmask of BackendFeature flags.
+type FeatureSet uint32
+
+// Has reports whether the set contains the feature.
+func (fs FeatureSet) Has(f BackendFeature) bool {
+	return fs&FeatureSet(f) != 0
+}
+
+// with returns the set with the feature added.
+func (fs FeatureSet) with(f BackendFeature) FeatureSet {
+	return fs | FeatureSet(f)
+}
+
+// allFeatures lists every feature flag for iteration.
+var allFeatures = []BackendFeature{
+	FeatureLayerBlendModes,
+	FeatureLayerAlpha,
+	FeatureImages,
+	FeatureText,
+	FeatureClipping,
+}
+
+// TileRendererFeatures is the feature set of the CPU tile renderer: it
+// handles images, text, and clipping, but composites layers without blend
+// modes or layer alpha (see executeEncodingOnTile).
+const TileRendererFeatures = FeatureSet(FeatureImages) |
+	FeatureSet(FeatureText) |
+	FeatureSet(FeatureClipping)
+
+// AnalyzeEncoding returns the backend features the encoding requires.
+func AnalyzeEncoding(enc *Encoding) FeatureSet {
+	var fs FeatureSet
+	if enc == nil {
+		return fs
+	}
+
+	drawIdx := 0
+	for _, tag := range enc.tags {
+		switch tag {
+		case TagPushLayer:
+			if drawIdx+2 <= len(enc.drawData) {
+				blendMode := BlendMode(enc.drawData[drawIdx])
+				alpha := math.Float32frombits(enc.drawData[drawIdx+1])
+				if blendMode != BlendNormal && blendMode != BlendSourceOver {
+					fs = fs.with(FeatureLayerBlendModes)
+				}
+				if alpha < 1.0 {
+					fs = fs.with(FeatureLayerAlpha)
+				}
+			}
+			drawIdx += 2
+		case TagSetAntiAlias:
+			drawIdx++
+		case TagFill, TagFillRoundRect:
+			drawIdx += 2
+		case TagStroke:
+			drawIdx += 5
+		case TagImage:
+			fs = fs.with(FeatureImages)
+			drawIdx++
+		case TagImageEx:
+			fs = fs.with(FeatureImages)
+			drawIdx += 3
+		case TagText:
+			fs = fs.with(FeatureText)
+		case TagBeginClip:
+			fs = fs.with(FeatureClipping)
+		}
+	}
+	return fs
+}
+
+// DowngradeRecord documents one automatic feature downgrade.
+type DowngradeRecord struct {
+	// Feature is the feature the selected backend lacks.
+	Feature BackendFeature
+
+	// Route names the rendering path the scene was downgraded to.
+	Route string
+}
+
+// FallbackRenderer coordinates between the selected rendering backend and
+// the layered CPU fallback.
+//
+// Before each render the scene's encoding is analyzed for required
+// features (AnalyzeEncoding) and compared against the features the
+// selected backend supports. Scenes within the supported set render
+// through the plain Renderer. When a required feature is missing — e.g.
+// layer blend modes on the CPU tile renderer — the scene is automatically
+// split into top-level layers that are rasterized on the CPU and
+// composited back with full blend support (HybridRenderer), and the
+// downgrade is recorded and logged via gg.Logger.
+type FallbackRenderer struct {
+	supported FeatureSet
+
+	base   *Renderer
+	hybrid *HybridRenderer
+
+	downgrades []DowngradeRecord
+}
+
+// NewFallbackRenderer creates a fallback coordinator for the given
+// dimensions. The supported feature set defaults to TileRendererFeatures;
+// callers that route the plain path to a more capable backend can widen it
+// with SetSupportedFeatures. Options are forwarded to the underlying
+// renderers. Returns nil if dimensions are invalid.
+func NewFallbackRenderer(width, height int, opts ...RendererOption) *FallbackRenderer {
+	base := NewRenderer(width, height, opts...)
+	hybrid := NewHybridRenderer(width, height, opts...)
+	if base == nil || hybrid == nil {
+		return nil
+	}
+	return &FallbackRenderer{
+		supported: TileRendererFeatures,
+		base:      base,
+		hybrid:    hybrid,
+	}
+}
+
+// SetSupportedFeatures overrides the feature set assumed for the selected
+// backend.
+func (f *FallbackRenderer) SetSupportedFeatures(fs FeatureSet) {
+	f.supported = fs
+}
+
+// SupportedFeatures returns the feature set assumed for the selected
+// backend.
+func (f *FallbackRenderer) SupportedFeatures() FeatureSet {
+	return f.supported
+}
+
+// Downgrades returns the downgrades recorded since the last call to
+// ClearDowngrades, in render order.
+func (f *FallbackRenderer) Downgrades() []DowngradeRecord {
+	return f.downgrades
+}
+
+// ClearDowngrades resets the downgrade log.
+func (f *FallbackRenderer) ClearDowngrades() {
+	f.downgrades = f.downgrades[:0]
+}
+
+// Close releases resources held by the underlying renderers.
+func (f *FallbackRenderer) Close() {
+	f.base.Close()
+	f.hybrid.Close()
+}
+
+// Render renders the scene to the target, downgrading automatically when
+// the scene requires features the selected backend lacks.
+func (f *FallbackRenderer) Render(target *gg.Pixmap, s *Scene) error {
+	return f.RenderWithContext(context.Background(), target, s)
+}
+
+// RenderWithContext renders with cancellation support.
+func (f *FallbackRenderer) RenderWithContext(ctx context.Context, target *gg.Pixmap, s *Scene) error {
+	if target == nil || s == nil {
+		return nil
+	}
+
+	required := AnalyzeEncoding(s.Encoding())
+	missing := required &^ f.supported
+	if missing == 0 {
+		return f.base.RenderWithContext(ctx, target, s)
+	}
+
+	const route = "cpu-hybrid"
+	for _, feat := range allFeatures {
+		if !missing.Has(feat) {
+			continue
+		}
+		f.downgrades = append(f.downgrades, DowngradeRecord{Feature: feat, Route: route})
+		gg.Logger().Info("scene: backend lacks feature, downgrading",
+			"feature", feat.String(),
+			"route", route,
+		)
+	}
+	return f.hybrid.RenderWithContext(ctx, target, s)
+}
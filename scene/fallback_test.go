@@ -0,0 +1,176 @@
+package scene
+
+import (
+	"testing"
+
+	"github.com/gogpu/gg"
+)
+
+func TestBackendFeatureString(t *testing.T) {
+	tests := []struct {
+		feature BackendFeature
+		want    string
+	}{
+		{FeatureLayerBlendModes, "layer-blend-modes"},
+		{FeatureLayerAlpha, "layer-alpha"},
+		{FeatureImages, "images"},
+		{FeatureText, "text"},
+		{FeatureClipping, "clipping"},
+		{BackendFeature(1 << 30), unknownStr},
+	}
+	for _, tt := range tests {
+		if got := tt.feature.String(); got != tt.want {
+			t.Errorf("String() = %q, want %q", got, tt.want)
+		}
+	}
+}
+
+func TestAnalyzeEncodingPlainScene(t *testing.T) {
+	s := NewScene()
+	s.Fill(FillNonZero, IdentityAffine(), SolidBrush(gg.RGBA{R: 1, A: 1}), fullRect())
+
+	if fs := AnalyzeEncoding(s.Encoding()); fs != 0 {
+		t.Errorf("plain fill requires features %#x, want none", fs)
+	}
+
+	if fs := AnalyzeEncoding(nil); fs != 0 {
+		t.Errorf("nil encoding requires features %#x, want none", fs)
+	}
+}
+
+func TestAnalyzeEncodingLayerFeatures(t *testing.T) {
+	s := NewScene()
+	s.Fill(FillNonZero, IdentityAffine(), SolidBrush(gg.RGBA{R: 1, A: 1}), fullRect())
+	s.PushLayer(BlendMultiply, 0.5, nil)
+	s.Fill(FillNonZero, IdentityAffine(), SolidBrush(gg.RGBA{B: 1, A: 1}), fullRect())
+	s.PopLayer()
+
+	fs := AnalyzeEncoding(s.Encoding())
+	if !fs.Has(FeatureLayerBlendModes) {
+		t.Error("multiply layer should require FeatureLayerBlendModes")
+	}
+	if !fs.Has(FeatureLayerAlpha) {
+		t.Error("half-alpha layer should require FeatureLayerAlpha")
+	}
+	if fs.Has(FeatureClipping) || fs.Has(FeatureImages) || fs.Has(FeatureText) {
+		t.Errorf("unexpected features in %#x", fs)
+	}
+
+	// Normal full-alpha layers require nothing extra.
+	s2 := NewScene()
+	s2.PushLayer(BlendNormal, 1.0, nil)
+	s2.Fill(FillNonZero, IdentityAffine(), SolidBrush(gg.RGBA{R: 1, A: 1}), fullRect())
+	s2.PopLayer()
+	if fs := AnalyzeEncoding(s2.Encoding()); fs != 0 {
+		t.Errorf("normal layer requires features %#x, want none", fs)
+	}
+}
+
+func TestAnalyzeEncodingClipping(t *testing.T) {
+	enc := NewEncoding()
+	enc.EncodeBeginClip()
+	enc.EncodeEndClip()
+
+	fs := AnalyzeEncoding(enc)
+	if !fs.Has(FeatureClipping) {
+		t.Error("clip markers should require FeatureClipping")
+	}
+}
+
+func TestFallbackRendererNoDowngrade(t *testing.T) {
+	f := NewFallbackRenderer(64, 64)
+	if f == nil {
+		t.Fatal("NewFallbackRenderer returned nil")
+	}
+	defer f.Close()
+
+	if NewFallbackRenderer(0, 64) != nil {
+		t.Error("expected nil for zero width")
+	}
+
+	s := NewScene()
+	s.Fill(FillNonZero, IdentityAffine(), SolidBrush(gg.RGBA{R: 1, A: 1}), fullRect())
+
+	target := gg.NewPixmap(64, 64)
+	if err := f.Render(target, s); err != nil {
+		t.Fatalf("Render failed: %v", err)
+	}
+
+	if len(f.Downgrades()) != 0 {
+		t.Errorf("plain scene recorded %d downgrades, want 0", len(f.Downgrades()))
+	}
+
+	px := target.GetPixel(32, 32)
+	if px.R < 0.95 || px.A < 0.95 {
+		t.Errorf("center pixel = %+v, want opaque red", px)
+	}
+}
+
+func TestFallbackRendererDowngradesBlendedLayer(t *testing.T) {
+	// Red base with a multiply-blended blue layer: the tile renderer
+	// lacks layer blend modes, so the coordinator must downgrade to the
+	// hybrid path, which produces near-black output.
+	s := NewScene()
+	s.Fill(FillNonZero, IdentityAffine(), SolidBrush(gg.RGBA{R: 1, A: 1}), fullRect())
+	s.PushLayer(BlendMultiply, 1.0, nil)
+	s.Fill(FillNonZero, IdentityAffine(), SolidBrush(gg.RGBA{B: 1, A: 1}), fullRect())
+	s.PopLayer()
+
+	f := NewFallbackRenderer(64, 64)
+	if f == nil {
+		t.Fatal("NewFallbackRenderer returned nil")
+	}
+	defer f.Close()
+
+	target := gg.NewPixmap(64, 64)
+	if err := f.Render(target, s); err != nil {
+		t.Fatalf("Render failed: %v", err)
+	}
+
+	downgrades := f.Downgrades()
+	if len(downgrades) != 1 {
+		t.Fatalf("recorded %d downgrades, want 1", len(downgrades))
+	}
+	if downgrades[0].Feature != FeatureLayerBlendModes {
+		t.Errorf("downgrade feature = %v, want FeatureLayerBlendModes", downgrades[0].Feature)
+	}
+	if downgrades[0].Route == "" {
+		t.Error("downgrade route should be recorded")
+	}
+
+	px := target.GetPixel(32, 32)
+	if px.R > 0.05 || px.G > 0.05 || px.B > 0.05 {
+		t.Errorf("center pixel = %+v, want near black (multiply applied)", px)
+	}
+
+	f.ClearDowngrades()
+	if len(f.Downgrades()) != 0 {
+		t.Error("ClearDowngrades did not reset the log")
+	}
+}
+
+func TestFallbackRendererWidenedFeatures(t *testing.T) {
+	// When the backend claims full blend support, the coordinator must
+	// not downgrade even for blended layers.
+	s := NewScene()
+	s.PushLayer(BlendMultiply, 1.0, nil)
+	s.Fill(FillNonZero, IdentityAffine(), SolidBrush(gg.RGBA{B: 1, A: 1}), fullRect())
+	s.PopLayer()
+
+	f := NewFallbackRenderer(64, 64)
+	if f == nil {
+		t.Fatal("NewFallbackRenderer returned nil")
+	}
+	defer f.Close()
+
+	f.SetSupportedFeatures(f.SupportedFeatures() | FeatureSet(FeatureLayerBlendModes))
+
+	target := gg.NewPixmap(64, 64)
+	if err := f.Render(target, s); err != nil {
+		t.Fatalf("Render failed: %v", err)
+	}
+
+	if len(f.Downgrades()) != 0 {
+		t.Errorf("recorded %d downgrades after widening, want 0", len(f.Downgrades()))
+	}
+}
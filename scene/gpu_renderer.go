@@ -2,6 +2,7 @@ package scene
 
 import (
 	"image"
+	"math"
 
 	"github.com/gogpu/gg"
 	"github.com/gogpu/gg/text"
@@ -35,6 +36,14 @@ func NewGPUSceneRenderer(dc *gg.Context) *GPUSceneRenderer {
 	return &GPUSceneRenderer{dc: dc}
 }
 
+// SetPixelSnapping enables or disables pixel snapping on the underlying
+// gg.Context. When enabled, axis-aligned scene fills and strokes replayed
+// through the context snap to the device pixel grid, keeping 1px UI lines
+// crisp on the GPU path. See gg.Context.SetPixelSnapping.
+func (r *GPUSceneRenderer) SetPixelSnapping(enabled bool) {
+	r.dc.SetPixelSnapping(enabled)
+}
+
 // RenderScene decodes scene commands and renders them through the gg.Context.
 // The decoder walks the binary encoding tag-by-tag, building paths and
 // dispatching fill/stroke calls that route through the GPU accelerator.
@@ -70,7 +79,20 @@ func (r *GPUSceneRenderer) RenderScene(scene *Scene) error { //nolint:gocyclo,cy
 	// dc.Push()/Pop() to avoid interfering with clip Push/Pop nesting.
 	baseMatrix := dc.GetTransform()
 
+	// Consecutive fills of the same shape and solid brush are accumulated
+	// into a single instanced draw instead of one strip set per shape.
+	var batch shapeInstanceBatch
+
 	for dec.Next() {
+		// Any tag other than path building or a batchable fill establishes
+		// a draw-order or state boundary: dispatch pending instances first.
+		switch dec.Tag() {
+		case TagBeginPath, TagMoveTo, TagLineTo, TagQuadTo, TagCubicTo,
+			TagClosePath, TagEndPath, TagFill:
+		default:
+			batch.flush(dc)
+		}
+
 		switch dec.Tag() {
 		case TagTransform:
 			a := dec.Transform()
@@ -117,6 +139,13 @@ func (r *GPUSceneRenderer) RenderScene(scene *Scene) error { //nolint:gocyclo,cy
 
 		case TagFill:
 			brush, style := dec.Fill()
+			shape := gg.DetectShape(path)
+			if brush.Kind == BrushSolid && style != FillEvenOdd && instanceableShape(shape.Kind) {
+				batch.add(dc, shape, brush)
+				path.Clear()
+				continue
+			}
+			batch.flush(dc)
 			applySceneBrush(dc, brush)
 			if style == FillEvenOdd {
 				dc.SetFillRule(gg.FillRuleEvenOdd)
@@ -207,11 +236,18 @@ func (r *GPUSceneRenderer) RenderScene(scene *Scene) error { //nolint:gocyclo,cy
 			imageIndex, imgTransform := dec.Image()
 			r.resolveImage(scene, imageIndex, imgTransform)
 
+		case TagImageEx:
+			imageIndex, srcRect, sampling, opacity, imgTransform := dec.ImageEx()
+			r.resolveImageEx(scene, imageIndex, srcRect, sampling, opacity, imgTransform)
+
 		default:
 			// Unknown tags are skipped by the decoder advancing tagIdx.
 		}
 	}
 
+	// Dispatch any trailing instance batch before restoring state.
+	batch.flush(dc)
+
 	// Restore the original matrix. Unlike the previous Push/Pop approach,
 	// SetTransform is a direct replacement so we restore explicitly.
 	dc.SetTransform(baseMatrix)
@@ -219,6 +255,73 @@ func (r *GPUSceneRenderer) RenderScene(scene *Scene) error { //nolint:gocyclo,cy
 	return nil
 }
 
+// shapeInstanceBatch accumulates consecutive fills of one detected shape
+// with one solid brush, so GPUSceneRenderer can dispatch them through
+// dc.FillShapeInstanced as a single instanced draw. The shape is stored
+// canonically (centered at the origin); each instance contributes a
+// translation to its encoded center. Transform changes flush the batch,
+// so all instances share the Context transform active when they were added.
+type shapeInstanceBatch struct {
+	active     bool
+	shape      gg.DetectedShape // canonical: centered at the origin
+	brush      Brush
+	transforms []gg.Matrix
+}
+
+// instanceableShape reports whether a shape kind can be rendered as an
+// instanced SDF draw.
+func instanceableShape(kind gg.ShapeKind) bool {
+	switch kind {
+	case gg.ShapeCircle, gg.ShapeEllipse, gg.ShapeRect, gg.ShapeRRect:
+		return true
+	default:
+		return false
+	}
+}
+
+// add appends one instance to the batch, flushing first if the shape
+// geometry or brush does not match the pending batch.
+func (b *shapeInstanceBatch) add(dc *gg.Context, shape gg.DetectedShape, brush Brush) {
+	canonical := shape
+	canonical.CenterX = 0
+	canonical.CenterY = 0
+
+	if b.active && !b.matches(canonical, brush) {
+		b.flush(dc)
+	}
+	if !b.active {
+		b.active = true
+		b.shape = canonical
+		b.brush = brush
+	}
+	b.transforms = append(b.transforms, gg.Translate(shape.CenterX, shape.CenterY))
+}
+
+// matches reports whether a canonical shape and brush continue the batch.
+func (b *shapeInstanceBatch) matches(canonical gg.DetectedShape, brush Brush) bool {
+	const eps = 1e-6
+	return b.shape.Kind == canonical.Kind &&
+		math.Abs(b.shape.RadiusX-canonical.RadiusX) < eps &&
+		math.Abs(b.shape.RadiusY-canonical.RadiusY) < eps &&
+		math.Abs(b.shape.Width-canonical.Width) < eps &&
+		math.Abs(b.shape.Height-canonical.Height) < eps &&
+		math.Abs(b.shape.CornerRadius-canonical.CornerRadius) < eps &&
+		b.brush.Kind == brush.Kind &&
+		b.brush.Color == brush.Color
+}
+
+// flush dispatches accumulated instances and resets the batch.
+func (b *shapeInstanceBatch) flush(dc *gg.Context) {
+	if !b.active {
+		return
+	}
+	applySceneBrush(dc, b.brush)
+	dc.SetFillRule(gg.FillRuleNonZero)
+	_ = dc.FillShapeInstanced(b.shape, b.transforms)
+	b.active = false
+	b.transforms = b.transforms[:0]
+}
+
 // resolveText resolves a TagText glyph run. Prefers DrawShapedGlyphs (no re-shaping)
 // with DrawString as fallback. Produces hinted, atlas-batched, DPI-aware text.
 func (r *GPUSceneRenderer) resolveText(scene *Scene, run GlyphRunData, glyphs []GlyphEntry, str string, brush Brush) {
@@ -294,6 +397,62 @@ func (r *GPUSceneRenderer) resolveImage(scene *Scene, imageIndex uint32, transfo
 	})
 }
 
+// resolveImageEx renders a TagImageEx command through dc.DrawImageEx,
+// forwarding the source rect, sampling mode, and opacity. The transform maps
+// source pixel coordinates to canvas coordinates, so the destination origin
+// is the transformed srcRect origin and the destination size is the source
+// rect scaled by the transform's axes.
+func (r *GPUSceneRenderer) resolveImageEx(scene *Scene, imageIndex uint32, srcRect Rect, sampling ImageSampling, opacity float32, transform Affine) {
+	images := scene.Images()
+	if int(imageIndex) >= len(images) {
+		return
+	}
+	img := images[imageIndex]
+	if img == nil || img.Width <= 0 || img.Height <= 0 || len(img.Data) < img.Width*img.Height*4 {
+		return
+	}
+	if opacity <= 0 {
+		return
+	}
+
+	if srcRect.IsEmpty() {
+		srcRect = img.Bounds()
+	}
+
+	rgba := &image.RGBA{
+		Pix:    img.Data,
+		Stride: img.Width * 4,
+		Rect:   image.Rect(0, 0, img.Width, img.Height),
+	}
+	buf := gg.ImageBufFromImage(rgba)
+
+	dstX, dstY := transform.TransformPoint(srcRect.MinX, srcRect.MinY)
+	dstW := float64(transform.A) * float64(srcRect.Width())
+	dstH := float64(transform.E) * float64(srcRect.Height())
+	if dstW < 0 {
+		dstW = -dstW
+	}
+	if dstH < 0 {
+		dstH = -dstH
+	}
+
+	src := image.Rect(int(srcRect.MinX), int(srcRect.MinY), int(srcRect.MaxX+0.5), int(srcRect.MaxY+0.5))
+	interp := gg.InterpBilinear
+	if sampling == SamplingNearest {
+		interp = gg.InterpNearest
+	}
+
+	r.dc.DrawImageEx(buf, gg.DrawImageOptions{
+		X:             float64(dstX),
+		Y:             float64(dstY),
+		DstWidth:      dstW,
+		DstHeight:     dstH,
+		SrcRect:       &src,
+		Interpolation: interp,
+		Opacity:       float64(opacity),
+	})
+}
+
 // applySceneBrush sets the gg.Context color from a scene.Brush.
 func applySceneBrush(dc *gg.Context, brush Brush) {
 	if brush.Kind == BrushSolid {
@@ -0,0 +1,462 @@
+package scene
+
+import "math"
+
+// HitResult describes the topmost draw command whose geometry contains a
+// hit-tested point.
+type HitResult struct {
+	// ID is the user-assigned hit-test ID (SceneBuilder.WithID /
+	// Scene.SetHitID). Zero when the command was recorded without one.
+	ID uint64
+
+	// Index is the draw command's position in encoding order (the n-th
+	// fill, stroke, image, or text command in the scene).
+	Index int
+
+	// Tag is the command type that was hit.
+	Tag Tag
+}
+
+// HitTest returns the topmost draw command containing the point (x, y) in
+// canvas coordinates, walking the scene's encoding the same way the tile
+// renderer does: paths are transformed by the active TagTransform, clip
+// regions restrict hits to their interior, and fills honor their fill rule.
+// The second result is false when nothing contains the point.
+//
+// Later commands draw on top of earlier ones, so the last containing
+// command wins — no separate picking structure or z-sorting is needed.
+//
+//nolint:gocyclo,cyclop,gocognit,funlen // command interpreter mirroring the render walk
+func (s *Scene) HitTest(x, y float32) (HitResult, bool) {
+	enc := s.Encoding()
+	dec := NewDecoder(enc)
+
+	currentTransform := IdentityAffine()
+	currentPath := NewPath()
+	pathActive := false
+	var clips []*Path
+
+	cmdIdx := -1 // incremented per draw command, indexes enc.commandIDs
+	var result HitResult
+	found := false
+
+	record := func(tag Tag) {
+		result = HitResult{Index: cmdIdx, Tag: tag}
+		if cmdIdx < len(enc.commandIDs) {
+			result.ID = enc.commandIDs[cmdIdx]
+		}
+		found = true
+	}
+	inClips := func() bool {
+		for _, clip := range clips {
+			if !clip.Contains(x, y) {
+				return false
+			}
+		}
+		return true
+	}
+
+	for dec.Next() {
+		switch dec.Tag() {
+		case TagTransform:
+			currentTransform = dec.Transform()
+
+		case TagBeginPath:
+			currentPath.Reset()
+			pathActive = true
+
+		case TagMoveTo:
+			px, py := dec.MoveTo()
+			if pathActive {
+				tx, ty := currentTransform.TransformPoint(px, py)
+				currentPath.MoveTo(tx, ty)
+			}
+
+		case TagLineTo:
+			px, py := dec.LineTo()
+			if pathActive {
+				tx, ty := currentTransform.TransformPoint(px, py)
+				currentPath.LineTo(tx, ty)
+			}
+
+		case TagQuadTo:
+			cx, cy, px, py := dec.QuadTo()
+			if pathActive {
+				tcx, tcy := currentTransform.TransformPoint(cx, cy)
+				tx, ty := currentTransform.TransformPoint(px, py)
+				currentPath.QuadTo(tcx, tcy, tx, ty)
+			}
+
+		case TagCubicTo:
+			c1x, c1y, c2x, c2y, px, py := dec.CubicTo()
+			if pathActive {
+				tc1x, tc1y := currentTransform.TransformPoint(c1x, c1y)
+				tc2x, tc2y := currentTransform.TransformPoint(c2x, c2y)
+				tx, ty := currentTransform.TransformPoint(px, py)
+				currentPath.CubicTo(tc1x, tc1y, tc2x, tc2y, tx, ty)
+			}
+
+		case TagClosePath:
+			if pathActive {
+				currentPath.Close()
+			}
+
+		case TagEndPath:
+			// Path complete, consumed by the next draw/clip command.
+
+		case TagFill:
+			_, style := dec.Fill()
+			cmdIdx++
+			if pathActive && inClips() && pathContains(currentPath, x, y, style) {
+				record(TagFill)
+			}
+			pathActive = false
+
+		case TagFillRoundRect:
+			_, _, rect, rx, ry := dec.FillRoundRect()
+			cmdIdx++
+			if inClips() && roundRectContains(rect, rx, ry, currentTransform, x, y) {
+				record(TagFillRoundRect)
+			}
+
+		case TagStroke:
+			_, style := dec.Stroke()
+			cmdIdx++
+			if pathActive && inClips() && strokeContains(currentPath, style, currentTransform, x, y) {
+				record(TagStroke)
+			}
+			pathActive = false
+
+		case TagImage:
+			imageIndex, transform := dec.Image()
+			cmdIdx++
+			if inClips() && imageContains(transform, x, y, s.registeredImageBounds(imageIndex)) {
+				record(TagImage)
+			}
+
+		case TagImageEx:
+			_, srcRect, _, opacity, transform := dec.ImageEx()
+			cmdIdx++
+			if opacity > 0 && inClips() && imageContains(transform, x, y, srcRect) {
+				record(TagImageEx)
+			}
+
+		case TagText:
+			run, glyphs, _, _ := dec.Text()
+			cmdIdx++
+			if inClips() && s.textRunContains(run, glyphs, currentTransform, x, y) {
+				record(TagText)
+			}
+
+		case TagBeginClip:
+			if pathActive && !currentPath.IsEmpty() {
+				clips = append(clips, currentPath.Clone())
+			} else {
+				// Degenerate clip excludes everything inside it.
+				clips = append(clips, NewPath())
+			}
+			pathActive = false
+
+		case TagEndClip:
+			if len(clips) > 0 {
+				clips = clips[:len(clips)-1]
+			}
+
+		case TagPushLayer:
+			dec.PushLayer()
+
+		case TagSetAntiAlias:
+			dec.AntiAlias()
+
+		case TagBrush:
+			dec.Brush()
+		}
+	}
+
+	return result, found
+}
+
+// registeredImageBounds returns the full bounds of the registered image, or
+// an empty rect for an out-of-range index.
+func (s *Scene) registeredImageBounds(imageIndex uint32) Rect {
+	if int(imageIndex) >= len(s.imageRegistry) {
+		return EmptyRect()
+	}
+	img := s.imageRegistry[imageIndex]
+	if img == nil {
+		return EmptyRect()
+	}
+	return img.Bounds()
+}
+
+// pathContains tests point containment honoring the fill rule: non-zero
+// winding for FillNonZero, crossing parity for FillEvenOdd.
+func pathContains(p *Path, x, y float32, style FillStyle) bool {
+	if style == FillEvenOdd {
+		_, crossings := pathWinding(p, x, y)
+		return crossings%2 != 0
+	}
+	return p.Contains(x, y)
+}
+
+// pathWinding computes both the non-zero winding number and the raw
+// crossing count of a horizontal ray from (x, y), flattening curves with
+// the same subdivision Path.Contains uses.
+func pathWinding(p *Path, x, y float32) (winding, crossings int) {
+	add := func(w int) {
+		winding += w
+		if w != 0 {
+			crossings++
+		}
+	}
+
+	var curX, curY, startX, startY float32
+	pointIdx := 0
+	pts := p.Points()
+
+	flattenTo := func(nextX, nextY float32, steps int, at func(t float32) (float32, float32)) {
+		prevX, prevY := curX, curY
+		for i := 1; i <= steps; i++ {
+			sx, sy := at(float32(i) / float32(steps))
+			add(windingSegment(prevX, prevY, sx, sy, x, y))
+			prevX, prevY = sx, sy
+		}
+		curX, curY = nextX, nextY
+	}
+
+	for _, verb := range p.Verbs() {
+		switch verb {
+		case MoveTo:
+			if curX != startX || curY != startY {
+				add(windingSegment(curX, curY, startX, startY, x, y))
+			}
+			startX, startY = pts[pointIdx], pts[pointIdx+1]
+			curX, curY = startX, startY
+			pointIdx += 2
+
+		case LineTo:
+			nx, ny := pts[pointIdx], pts[pointIdx+1]
+			add(windingSegment(curX, curY, nx, ny, x, y))
+			curX, curY = nx, ny
+			pointIdx += 2
+
+		case QuadTo:
+			cx, cy := pts[pointIdx], pts[pointIdx+1]
+			nx, ny := pts[pointIdx+2], pts[pointIdx+3]
+			x0, y0 := curX, curY
+			flattenTo(nx, ny, 4, func(t float32) (float32, float32) {
+				mt := 1 - t
+				return mt*mt*x0 + 2*mt*t*cx + t*t*nx,
+					mt*mt*y0 + 2*mt*t*cy + t*t*ny
+			})
+			pointIdx += 4
+
+		case CubicTo:
+			c1x, c1y := pts[pointIdx], pts[pointIdx+1]
+			c2x, c2y := pts[pointIdx+2], pts[pointIdx+3]
+			nx, ny := pts[pointIdx+4], pts[pointIdx+5]
+			x0, y0 := curX, curY
+			flattenTo(nx, ny, 8, func(t float32) (float32, float32) {
+				mt := 1 - t
+				return mt*mt*mt*x0 + 3*mt*mt*t*c1x + 3*mt*t*t*c2x + t*t*t*nx,
+					mt*mt*mt*y0 + 3*mt*mt*t*c1y + 3*mt*t*t*c2y + t*t*t*ny
+			})
+			pointIdx += 6
+
+		case Close:
+			if curX != startX || curY != startY {
+				add(windingSegment(curX, curY, startX, startY, x, y))
+			}
+			curX, curY = startX, startY
+		}
+	}
+
+	if curX != startX || curY != startY {
+		add(windingSegment(curX, curY, startX, startY, x, y))
+	}
+	return winding, crossings
+}
+
+// roundRectContains maps the point into the rect's local space (the SDF rect
+// is encoded untransformed with a preceding TagTransform) and runs the same
+// signed-distance test the tile renderer shades with.
+func roundRectContains(rect Rect, rx, ry float32, transform Affine, x, y float32) bool {
+	if !transform.IsIdentity() {
+		inv, ok := transform.Invert()
+		if !ok {
+			return false
+		}
+		x, y = inv.TransformPoint(x, y)
+	}
+	return NewRoundRectShape(rect, rx, ry).Contains(x, y)
+}
+
+// strokeContains tests whether the point lies within half the stroke width
+// of the (already transformed) path outline. The encoded width is in local
+// space, so it is scaled by the transform's average axis scale.
+func strokeContains(p *Path, style *StrokeStyle, transform Affine, x, y float32) bool {
+	if style == nil {
+		style = DefaultStrokeStyle()
+	}
+	halfWidth := style.Width / 2
+	if scale := averageScale(transform); scale > 0 {
+		halfWidth *= scale
+	}
+	if halfWidth <= 0 {
+		return false
+	}
+
+	// Quick reject against bounds inflated by the stroke radius.
+	b := p.Bounds()
+	if !b.IsEmpty() &&
+		(x < b.MinX-halfWidth || x > b.MaxX+halfWidth ||
+			y < b.MinY-halfWidth || y > b.MaxY+halfWidth) {
+		return false
+	}
+
+	maxDistSq := halfWidth * halfWidth
+	var curX, curY, startX, startY float32
+	pointIdx := 0
+	pts := p.Points()
+	hit := false
+
+	segment := func(x1, y1, x2, y2 float32) {
+		if !hit && segmentDistSq(x1, y1, x2, y2, x, y) <= maxDistSq {
+			hit = true
+		}
+	}
+
+	for _, verb := range p.Verbs() {
+		switch verb {
+		case MoveTo:
+			startX, startY = pts[pointIdx], pts[pointIdx+1]
+			curX, curY = startX, startY
+			pointIdx += 2
+		case LineTo:
+			nx, ny := pts[pointIdx], pts[pointIdx+1]
+			segment(curX, curY, nx, ny)
+			curX, curY = nx, ny
+			pointIdx += 2
+		case QuadTo:
+			cx, cy := pts[pointIdx], pts[pointIdx+1]
+			nx, ny := pts[pointIdx+2], pts[pointIdx+3]
+			x0, y0 := curX, curY
+			prevX, prevY := x0, y0
+			for i := 1; i <= 4; i++ {
+				t := float32(i) / 4
+				mt := 1 - t
+				sx := mt*mt*x0 + 2*mt*t*cx + t*t*nx
+				sy := mt*mt*y0 + 2*mt*t*cy + t*t*ny
+				segment(prevX, prevY, sx, sy)
+				prevX, prevY = sx, sy
+			}
+			curX, curY = nx, ny
+			pointIdx += 4
+		case CubicTo:
+			c1x, c1y := pts[pointIdx], pts[pointIdx+1]
+			c2x, c2y := pts[pointIdx+2], pts[pointIdx+3]
+			nx, ny := pts[pointIdx+4], pts[pointIdx+5]
+			x0, y0 := curX, curY
+			prevX, prevY := x0, y0
+			for i := 1; i <= 8; i++ {
+				t := float32(i) / 8
+				mt := 1 - t
+				sx := mt*mt*mt*x0 + 3*mt*mt*t*c1x + 3*mt*t*t*c2x + t*t*t*nx
+				sy := mt*mt*mt*y0 + 3*mt*mt*t*c1y + 3*mt*t*t*c2y + t*t*t*ny
+				segment(prevX, prevY, sx, sy)
+				prevX, prevY = sx, sy
+			}
+			curX, curY = nx, ny
+			pointIdx += 6
+		case Close:
+			segment(curX, curY, startX, startY)
+			curX, curY = startX, startY
+		}
+		if hit {
+			return true
+		}
+	}
+	return hit
+}
+
+// segmentDistSq returns the squared distance from point (px, py) to the
+// segment (x1, y1)-(x2, y2).
+func segmentDistSq(x1, y1, x2, y2, px, py float32) float32 {
+	dx, dy := x2-x1, y2-y1
+	lenSq := dx*dx + dy*dy
+	t := float32(0)
+	if lenSq > 0 {
+		t = ((px-x1)*dx + (py-y1)*dy) / lenSq
+		if t < 0 {
+			t = 0
+		} else if t > 1 {
+			t = 1
+		}
+	}
+	cx, cy := x1+t*dx, y1+t*dy
+	ex, ey := px-cx, py-cy
+	return ex*ex + ey*ey
+}
+
+// averageScale approximates a transform's uniform scale factor as the mean
+// of its axis lengths.
+func averageScale(t Affine) float32 {
+	sx := float32(math.Hypot(float64(t.A), float64(t.D)))
+	sy := float32(math.Hypot(float64(t.B), float64(t.E)))
+	return (sx + sy) / 2
+}
+
+// imageContains inverse-maps the point through the image transform (source
+// pixels to canvas) and tests it against the source rect.
+func imageContains(transform Affine, x, y float32, srcRect Rect) bool {
+	if srcRect.IsEmpty() {
+		return false
+	}
+	inv, ok := transform.Invert()
+	if !ok {
+		return false
+	}
+	lx, ly := inv.TransformPoint(x, y)
+	return lx >= srcRect.MinX && lx < srcRect.MaxX &&
+		ly >= srcRect.MinY && ly < srcRect.MaxY
+}
+
+// textRunContains tests the point against the run's bounds: horizontal
+// extent from the shaped glyph positions plus the trailing glyph's advance
+// (approximated by the font size when the face is unavailable), vertical
+// extent from the face metrics.
+func (s *Scene) textRunContains(run GlyphRunData, glyphs []GlyphEntry, transform Affine, x, y float32) bool {
+	if len(glyphs) == 0 {
+		return false
+	}
+
+	minGX, maxGX := glyphs[0].X, glyphs[0].X
+	for _, g := range glyphs {
+		if g.X < minGX {
+			minGX = g.X
+		}
+		if g.X > maxGX {
+			maxGX = g.X
+		}
+	}
+
+	ascent := run.FontSize
+	descent := run.FontSize * 0.25
+	trailing := run.FontSize
+	if source, ok := s.fontRegistry[run.FontSourceID]; ok && source != nil {
+		metrics := source.Face(float64(run.FontSize)).Metrics()
+		ascent = float32(metrics.Ascent)
+		descent = float32(metrics.Descent)
+	}
+
+	bounds := Rect{
+		MinX: run.OriginX + minGX,
+		MinY: run.OriginY - ascent,
+		MaxX: run.OriginX + maxGX + trailing,
+		MaxY: run.OriginY + descent,
+	}
+	if !transform.IsIdentity() {
+		bounds = transformBounds(bounds, transform)
+	}
+	return x >= bounds.MinX && x < bounds.MaxX && y >= bounds.MinY && y < bounds.MaxY
+}
@@ -0,0 +1,135 @@
+package scene
+
+import (
+	"testing"
+
+	"github.com/gogpu/gg"
+)
+
+func TestHitTestTopmostWithIDs(t *testing.T) {
+	s := NewSceneBuilder().
+		WithID(1).
+		FillRect(0, 0, 40, 40, SolidBrush(gg.RGBA{R: 1, A: 1})).
+		WithID(2).
+		FillCircle(30, 30, 10, SolidBrush(gg.RGBA{B: 1, A: 1})).
+		Build()
+
+	// The circle draws after the rect, so it wins where they overlap.
+	hit, ok := s.HitTest(30, 30)
+	if !ok {
+		t.Fatal("HitTest missed the overlapping shapes")
+	}
+	if hit.ID != 2 || hit.Tag != TagFill {
+		t.Errorf("hit = %+v, want ID 2 (topmost circle)", hit)
+	}
+
+	hit, ok = s.HitTest(5, 5)
+	if !ok || hit.ID != 1 {
+		t.Errorf("hit = %+v ok=%v at (5,5), want ID 1 (rect only)", hit, ok)
+	}
+	if hit.Index != 0 {
+		t.Errorf("hit.Index = %d, want 0 (first draw command)", hit.Index)
+	}
+
+	if _, ok := s.HitTest(60, 60); ok {
+		t.Error("HitTest reported a hit outside all shapes")
+	}
+}
+
+func TestHitTestRespectsTransform(t *testing.T) {
+	s := NewSceneBuilder().
+		Translate(50, 0).
+		WithID(7).
+		FillRect(0, 0, 10, 10, SolidBrush(gg.Black)).
+		Build()
+
+	if hit, ok := s.HitTest(55, 5); !ok || hit.ID != 7 {
+		t.Errorf("hit = %+v ok=%v inside translated rect, want ID 7", hit, ok)
+	}
+	if _, ok := s.HitTest(5, 5); ok {
+		t.Error("HitTest hit the rect's untranslated position")
+	}
+}
+
+func TestHitTestRespectsClip(t *testing.T) {
+	s := NewSceneBuilder().
+		Clip(NewRectShape(0, 0, 20, 20), func(b *SceneBuilder) {
+			b.WithID(3).FillRect(0, 0, 40, 40, SolidBrush(gg.Black))
+		}).
+		Build()
+
+	if hit, ok := s.HitTest(10, 10); !ok || hit.ID != 3 {
+		t.Errorf("hit = %+v ok=%v inside clip, want ID 3", hit, ok)
+	}
+	// The fill extends to (40,40) but the clip cuts it at (20,20).
+	if _, ok := s.HitTest(30, 10); ok {
+		t.Error("HitTest hit clipped-away geometry")
+	}
+}
+
+func TestHitTestEvenOddRule(t *testing.T) {
+	// Two nested rects wound the same way: even-odd punches a hole,
+	// non-zero does not.
+	ring := NewPath().
+		Rectangle(0, 0, 40, 40).
+		Rectangle(10, 10, 20, 20)
+
+	evenOdd := NewScene()
+	evenOdd.Fill(FillEvenOdd, IdentityAffine(), SolidBrush(gg.Black), NewPathShape(ring))
+	if _, ok := evenOdd.HitTest(20, 20); ok {
+		t.Error("even-odd fill hit inside the hole")
+	}
+	if _, ok := evenOdd.HitTest(5, 20); ok == false {
+		t.Error("even-odd fill missed the ring body")
+	}
+
+	nonZero := NewScene()
+	nonZero.Fill(FillNonZero, IdentityAffine(), SolidBrush(gg.Black), NewPathShape(ring.Clone()))
+	if _, ok := nonZero.HitTest(20, 20); !ok {
+		t.Error("non-zero fill missed inside the nested rects")
+	}
+}
+
+func TestHitTestStroke(t *testing.T) {
+	s := NewSceneBuilder().
+		WithID(4).
+		DrawLine(0, 20, 40, 20, SolidBrush(gg.Black), 4).
+		Build()
+
+	if hit, ok := s.HitTest(20, 21); !ok || hit.ID != 4 || hit.Tag != TagStroke {
+		t.Errorf("hit = %+v ok=%v on the stroke, want ID 4", hit, ok)
+	}
+	if _, ok := s.HitTest(20, 30); ok {
+		t.Error("HitTest hit far from the stroke outline")
+	}
+}
+
+func TestHitTestRoundRect(t *testing.T) {
+	s := NewSceneBuilder().
+		WithID(5).
+		FillRoundRect(10, 10, 20, 20, 8, 8, SolidBrush(gg.Black)).
+		Build()
+
+	if hit, ok := s.HitTest(20, 20); !ok || hit.ID != 5 || hit.Tag != TagFillRoundRect {
+		t.Errorf("hit = %+v ok=%v at round-rect center, want ID 5", hit, ok)
+	}
+	// The sharp corner is rounded away at radius 8.
+	if _, ok := s.HitTest(10.5, 10.5); ok {
+		t.Error("HitTest hit the rounded-away corner")
+	}
+}
+
+func TestHitTestImage(t *testing.T) {
+	img := solidTestImage(8, 8, 255, 0, 0, 255)
+
+	s := NewScene()
+	s.SetHitID(9)
+	s.DrawImage(img, TranslateAffine(100, 100))
+
+	if hit, ok := s.HitTest(104, 104); !ok || hit.ID != 9 || hit.Tag != TagImage {
+		t.Errorf("hit = %+v ok=%v inside image, want ID 9", hit, ok)
+	}
+	if _, ok := s.HitTest(120, 120); ok {
+		t.Error("HitTest hit outside the image")
+	}
+}
@@ -0,0 +1,374 @@
+package scene
+
+import (
+	"context"
+	"encoding/binary"
+	"math"
+
+	"github.com/gogpu/gg"
+	intimage "github.com/gogpu/gg/internal/image"
+)
+
+// HybridRenderer rasterizes scene content on the CPU but composites
+// top-level layers with their blend modes and alpha on the GPU when an
+// accelerator is registered.
+//
+// The scene's flattened encoding is split at top-level PushLayer/PopLayer
+// boundaries into segments. Each segment is rasterized into its own pixmap
+// by the multi-threaded tile renderer (the same parallel core Filler path
+// Renderer uses), then the segments are composited in order onto the
+// target. Compositing goes through gg.Context's image-draw pipeline, which
+// batches onto the GPU when an accelerator is available and falls back to
+// per-pixel CPU blending otherwise.
+//
+// This benefits scenes with complex path content but simple layer
+// structure: path rasterization stays on the CPU where the tile renderer
+// scales across cores, while full blend-mode compositing — which the CPU
+// tile renderer does not implement — is handled per layer.
+//
+// Scenes without top-level layers (or with clips spanning a layer
+// boundary, which cannot be split) render through the plain Renderer
+// unchanged.
+type HybridRenderer struct {
+	width  int
+	height int
+
+	// base is the CPU tile renderer used to rasterize each segment.
+	base *Renderer
+}
+
+// NewHybridRenderer creates a hybrid renderer for the given dimensions.
+// Options are forwarded to the underlying CPU tile renderer.
+// Returns nil if dimensions are invalid.
+func NewHybridRenderer(width, height int, opts ...RendererOption) *HybridRenderer {
+	base := NewRenderer(width, height, opts...)
+	if base == nil {
+		return nil
+	}
+	return &HybridRenderer{
+		width:  width,
+		height: height,
+		base:   base,
+	}
+}
+
+// Close releases resources held by the renderer.
+func (h *HybridRenderer) Close() {
+	h.base.Close()
+}
+
+// Width returns the renderer width in pixels.
+func (h *HybridRenderer) Width() int { return h.width }
+
+// Height returns the renderer height in pixels.
+func (h *HybridRenderer) Height() int { return h.height }
+
+// Render renders the scene to the target pixmap.
+func (h *HybridRenderer) Render(target *gg.Pixmap, s *Scene) error {
+	return h.RenderWithContext(context.Background(), target, s)
+}
+
+// RenderWithContext renders the scene with cancellation support.
+//
+// If the scene has top-level layers, each is rasterized on the CPU into
+// its own pixmap and composited onto the target with its blend mode and
+// alpha. Otherwise rendering is delegated to the plain Renderer.
+func (h *HybridRenderer) RenderWithContext(ctx context.Context, target *gg.Pixmap, s *Scene) error {
+	if target == nil || s == nil {
+		return nil
+	}
+
+	enc := s.Encoding()
+	segments := splitTopLevelLayers(enc)
+	if segments == nil {
+		return h.base.RenderWithContext(ctx, target, s)
+	}
+
+	images := s.Images()
+	h.base.fontRegistry = s.FontRegistry()
+
+	for _, seg := range segments {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+		}
+
+		layerPM := gg.NewPixmap(h.width, h.height)
+		if err := h.renderSegment(ctx, seg.enc, layerPM, images); err != nil {
+			return err
+		}
+		compositePixmap(target, layerPM, seg.blend, seg.alpha)
+	}
+	return nil
+}
+
+// renderSegment rasterizes one segment encoding into pm using the parallel
+// tile renderer.
+func (h *HybridRenderer) renderSegment(ctx context.Context, enc *Encoding, pm *gg.Pixmap, images []*Image) error {
+	h.base.dirty.MarkAll()
+	tiles := h.base.tileGrid.AllTiles()
+	if err := h.base.renderTilesWithContext(ctx, tiles, enc, pm, images); err != nil {
+		return err
+	}
+	h.base.compositeTiles(tiles, pm)
+	return nil
+}
+
+// compositePixmap composites src onto dst with the given blend mode and
+// layer alpha.
+//
+// Blend modes supported by the gg image pipeline go through
+// gg.Context.DrawImageEx, which uses the GPU accelerator when one is
+// registered. All other modes use per-pixel CPU blending, which covers the
+// full scene blend range.
+func compositePixmap(dst, src *gg.Pixmap, mode BlendMode, alpha float32) {
+	if ggMode, ok := toImageBlendMode(mode); ok && gg.Accelerator() != nil {
+		if dc := gg.NewContextForPixmap(dst); dc != nil {
+			defer func() { _ = dc.Close() }()
+			img, err := intimage.FromRaw(src.Data(), src.Width(), src.Height(),
+				intimage.FormatRGBA8, src.Width()*4)
+			if err == nil {
+				dc.DrawImageEx(img, gg.DrawImageOptions{
+					Interpolation: gg.InterpNearest,
+					Opacity:       float64(alpha),
+					BlendMode:     ggMode,
+				})
+				return
+			}
+		}
+	}
+
+	blendPixmap(dst, src, mode, alpha)
+}
+
+// toImageBlendMode maps a scene blend mode to the gg image pipeline's
+// blend mode, reporting whether the pipeline supports it.
+func toImageBlendMode(mode BlendMode) (intimage.BlendMode, bool) {
+	switch mode {
+	case BlendNormal, BlendSourceOver:
+		return intimage.BlendNormal, true
+	case BlendMultiply:
+		return intimage.BlendMultiply, true
+	case BlendScreen:
+		return intimage.BlendScreen, true
+	case BlendOverlay:
+		return intimage.BlendOverlay, true
+	default:
+		return intimage.BlendNormal, false
+	}
+}
+
+// blendPixmap composites src onto dst per pixel using the scene blend
+// function for mode. Both pixmaps hold premultiplied RGBA8, matching the
+// convention of the internal blend functions. The layer alpha scales the
+// source before blending.
+func blendPixmap(dst, src *gg.Pixmap, mode BlendMode, alpha float32) {
+	fn := mode.GetBlendFunc()
+	d := dst.Data()
+	s := src.Data()
+
+	n := len(d)
+	if len(s) < n {
+		n = len(s)
+	}
+
+	scaled := alpha < 1.0
+	aScale := uint32(math.Round(float64(alpha) * 255))
+
+	for i := 0; i+3 < n; i += 4 {
+		sr, sg, sb, sa := s[i], s[i+1], s[i+2], s[i+3]
+		if scaled {
+			// Premultiplied: layer alpha scales all four channels.
+			sr = uint8((uint32(sr)*aScale + 127) / 255)
+			sg = uint8((uint32(sg)*aScale + 127) / 255)
+			sb = uint8((uint32(sb)*aScale + 127) / 255)
+			sa = uint8((uint32(sa)*aScale + 127) / 255)
+		}
+		d[i], d[i+1], d[i+2], d[i+3] = fn(sr, sg, sb, sa, d[i], d[i+1], d[i+2], d[i+3])
+	}
+}
+
+// layerSegment is one top-level compositing unit of a split encoding:
+// either base content (BlendNormal, alpha 1) or the body of a top-level
+// layer with its blend mode and alpha.
+type layerSegment struct {
+	blend BlendMode
+	alpha float32
+	enc   *Encoding
+}
+
+// streamCursor tracks the consumption position in each encoding stream
+// while walking the tag list. The per-tag advance amounts mirror the data
+// layouts documented in tag.go.
+type streamCursor struct {
+	path  int // float32s consumed from pathData
+	draw  int // uint32s consumed from drawData
+	text  int // bytes consumed from textData
+	trans int // entries consumed from transforms
+}
+
+// splitTopLevelLayers splits a flattened encoding into compositing
+// segments at top-level PushLayer/PopLayer boundaries.
+//
+// Each segment's encoding shares the original's brush table and slices its
+// other streams, prefixed with the transform and anti-alias state active
+// at the segment start so segments replay independently.
+//
+// Returns nil when splitting is not possible or not useful: the encoding
+// has no top-level layers, or a clip is open at a layer boundary (the
+// clip's begin/end markers would land in different segments).
+func splitTopLevelLayers(enc *Encoding) []layerSegment {
+	if enc == nil || len(enc.tags) == 0 {
+		return nil
+	}
+
+	var (
+		cur        streamCursor
+		segments   []layerSegment
+		segStart   streamCursor
+		segStartT  = 0 // tag index where the current segment begins
+		segBlend   = BlendNormal
+		segAlpha   = float32(1.0)
+		layerDepth = 0
+		clipDepth  = 0
+		sawLayer   = false
+	)
+
+	// State active at the walk position, snapshotted per segment start.
+	type replayState struct {
+		transform    Affine
+		hasTransform bool
+		aa           uint32
+		hasAA        bool
+	}
+	var state, segState replayState
+
+	flush := func(endTag int, end streamCursor) {
+		if segStartT >= endTag {
+			return
+		}
+		sub := &Encoding{
+			brushes: enc.brushes,
+			bounds:  enc.bounds,
+		}
+		// Prologue restores state carried across the segment boundary.
+		// Stream order matters: TagTransform consumes transforms,
+		// TagSetAntiAlias consumes drawData.
+		if segState.hasTransform {
+			sub.tags = append(sub.tags, TagTransform)
+			sub.transforms = append(sub.transforms, segState.transform)
+		}
+		if segState.hasAA {
+			sub.tags = append(sub.tags, TagSetAntiAlias)
+			sub.drawData = append(sub.drawData, segState.aa)
+		}
+		sub.tags = append(sub.tags, enc.tags[segStartT:endTag]...)
+		sub.pathData = append(sub.pathData, enc.pathData[segStart.path:end.path]...)
+		sub.drawData = append(sub.drawData, enc.drawData[segStart.draw:end.draw]...)
+		sub.textData = append(sub.textData, enc.textData[segStart.text:end.text]...)
+		sub.transforms = append(sub.transforms, enc.transforms[segStart.trans:end.trans]...)
+		segments = append(segments, layerSegment{blend: segBlend, alpha: segAlpha, enc: sub})
+	}
+
+	for i, tag := range enc.tags {
+		switch tag {
+		case TagTransform:
+			if cur.trans < len(enc.transforms) {
+				state.transform = enc.transforms[cur.trans]
+				state.hasTransform = true
+			}
+			cur.trans++
+
+		case TagSetAntiAlias:
+			if cur.draw < len(enc.drawData) {
+				state.aa = enc.drawData[cur.draw]
+				state.hasAA = true
+			}
+			cur.draw++
+
+		case TagMoveTo, TagLineTo:
+			cur.path += 2
+		case TagQuadTo:
+			cur.path += 4
+		case TagCubicTo:
+			cur.path += 6
+		case TagBrush:
+			cur.path += 4
+
+		case TagFill:
+			cur.draw += 2
+		case TagFillRoundRect:
+			cur.path += 6
+			cur.draw += 2
+		case TagStroke:
+			cur.draw += 5
+
+		case TagImage:
+			cur.draw++
+			cur.trans++
+
+		case TagImageEx:
+			cur.draw += 3
+			cur.path += 4
+			cur.trans++
+
+		case TagText:
+			if cur.text+glyphRunDataSize <= len(enc.textData) {
+				glyphCount := int(binary.LittleEndian.Uint16(enc.textData[cur.text+12:]))
+				textLen := int(binary.LittleEndian.Uint16(enc.textData[cur.text+28:]))
+				cur.text += glyphRunDataSize + glyphCount*glyphEntrySize + textLen
+			}
+
+		case TagBeginClip:
+			clipDepth++
+		case TagEndClip:
+			clipDepth--
+
+		case TagPushLayer:
+			if layerDepth == 0 {
+				if clipDepth > 0 {
+					return nil // clip spans the layer boundary
+				}
+				flush(i, cur)
+			}
+			var blendVal, alphaBits uint32 = uint32(BlendNormal), math.Float32bits(1.0)
+			if cur.draw+2 <= len(enc.drawData) {
+				blendVal = enc.drawData[cur.draw]
+				alphaBits = enc.drawData[cur.draw+1]
+			}
+			cur.draw += 2
+			if layerDepth == 0 {
+				sawLayer = true
+				segBlend = BlendMode(blendVal)
+				segAlpha = math.Float32frombits(alphaBits)
+				segStart = cur
+				segStartT = i + 1
+				segState = state
+			}
+			layerDepth++
+
+		case TagPopLayer:
+			if layerDepth > 0 {
+				layerDepth--
+				if layerDepth == 0 {
+					if clipDepth > 0 {
+						return nil
+					}
+					flush(i, cur)
+					segBlend = BlendNormal
+					segAlpha = 1.0
+					segStart = cur
+					segStartT = i + 1
+					segState = state
+				}
+			}
+		}
+	}
+
+	if !sawLayer {
+		return nil
+	}
+	flush(len(enc.tags), cur)
+	return segments
+}
@@ -0,0 +1,213 @@
+package scene
+
+import (
+	"math"
+	"testing"
+
+	"github.com/gogpu/gg"
+)
+
+// fullRect returns a rect shape covering the whole 64x64 test canvas.
+func fullRect() *RectShape {
+	return NewRectShape(0, 0, 64, 64)
+}
+
+func TestNewHybridRenderer(t *testing.T) {
+	h := NewHybridRenderer(64, 64)
+	if h == nil {
+		t.Fatal("NewHybridRenderer returned nil")
+	}
+	defer h.Close()
+
+	if h.Width() != 64 || h.Height() != 64 {
+		t.Errorf("dimensions = %dx%d, want 64x64", h.Width(), h.Height())
+	}
+
+	if NewHybridRenderer(0, 64) != nil {
+		t.Error("expected nil for zero width")
+	}
+}
+
+func TestSplitTopLevelLayers(t *testing.T) {
+	s := NewScene()
+	s.Fill(FillNonZero, IdentityAffine(), SolidBrush(gg.RGBA{R: 1, A: 1}), fullRect())
+	s.PushLayer(BlendMultiply, 0.5, nil)
+	s.Fill(FillNonZero, IdentityAffine(), SolidBrush(gg.RGBA{B: 1, A: 1}), fullRect())
+	s.PopLayer()
+	s.Fill(FillNonZero, IdentityAffine(), SolidBrush(gg.RGBA{G: 1, A: 1}), fullRect())
+
+	segments := splitTopLevelLayers(s.Encoding())
+	if len(segments) != 3 {
+		t.Fatalf("segments = %d, want 3 (base, layer, base)", len(segments))
+	}
+
+	wantBlend := []BlendMode{BlendNormal, BlendMultiply, BlendNormal}
+	wantAlpha := []float32{1.0, 0.5, 1.0}
+	for i, seg := range segments {
+		if seg.blend != wantBlend[i] {
+			t.Errorf("segment %d blend = %v, want %v", i, seg.blend, wantBlend[i])
+		}
+		if seg.alpha != wantAlpha[i] {
+			t.Errorf("segment %d alpha = %v, want %v", i, seg.alpha, wantAlpha[i])
+		}
+
+		// Each segment carries exactly one fill and decodes cleanly.
+		fills := 0
+		dec := NewDecoder(seg.enc)
+		for dec.Next() {
+			switch dec.Tag() {
+			case TagFill:
+				dec.Fill()
+				fills++
+			case TagMoveTo:
+				dec.MoveTo()
+			case TagLineTo:
+				dec.LineTo()
+			case TagQuadTo:
+				dec.QuadTo()
+			case TagCubicTo:
+				dec.CubicTo()
+			case TagTransform:
+				dec.Transform()
+			case TagSetAntiAlias:
+				dec.AntiAlias()
+			}
+		}
+		if fills != 1 {
+			t.Errorf("segment %d has %d fills, want 1", i, fills)
+		}
+	}
+}
+
+func TestSplitTopLevelLayersNoLayers(t *testing.T) {
+	s := NewScene()
+	s.Fill(FillNonZero, IdentityAffine(), SolidBrush(gg.RGBA{R: 1, A: 1}), fullRect())
+
+	if segments := splitTopLevelLayers(s.Encoding()); segments != nil {
+		t.Errorf("expected nil for scene without layers, got %d segments", len(segments))
+	}
+
+	if segments := splitTopLevelLayers(nil); segments != nil {
+		t.Error("expected nil for nil encoding")
+	}
+}
+
+func TestSplitTopLevelLayersClipAcrossBoundary(t *testing.T) {
+	// A clip opened before the layer and closed inside it cannot be
+	// split: its begin/end markers would land in different segments.
+	enc := NewEncoding()
+	enc.EncodeBeginClip()
+	enc.EncodePushLayer(BlendMultiply, 1.0)
+	enc.EncodeEndClip()
+	enc.EncodePopLayer()
+
+	if segments := splitTopLevelLayers(enc); segments != nil {
+		t.Errorf("expected nil for clip spanning layer boundary, got %d segments", len(segments))
+	}
+}
+
+func TestHybridRendererAppliesBlendMode(t *testing.T) {
+	// Red base, multiply-blended blue layer: multiply of disjoint
+	// channels is black. The plain CPU renderer ignores the blend mode
+	// and would leave blue.
+	s := NewScene()
+	s.Fill(FillNonZero, IdentityAffine(), SolidBrush(gg.RGBA{R: 1, A: 1}), fullRect())
+	s.PushLayer(BlendMultiply, 1.0, nil)
+	s.Fill(FillNonZero, IdentityAffine(), SolidBrush(gg.RGBA{B: 1, A: 1}), fullRect())
+	s.PopLayer()
+
+	h := NewHybridRenderer(64, 64)
+	if h == nil {
+		t.Fatal("NewHybridRenderer returned nil")
+	}
+	defer h.Close()
+
+	target := gg.NewPixmap(64, 64)
+	if err := h.Render(target, s); err != nil {
+		t.Fatalf("Render failed: %v", err)
+	}
+
+	px := target.GetPixel(32, 32)
+	if px.R > 0.05 || px.G > 0.05 || px.B > 0.05 {
+		t.Errorf("center pixel = %+v, want near black (multiply of red and blue)", px)
+	}
+	if px.A < 0.95 {
+		t.Errorf("center alpha = %v, want opaque", px.A)
+	}
+}
+
+func TestHybridRendererAppliesLayerAlpha(t *testing.T) {
+	// White base with a half-alpha red layer: expect pink.
+	s := NewScene()
+	s.Fill(FillNonZero, IdentityAffine(), SolidBrush(gg.RGBA{R: 1, G: 1, B: 1, A: 1}), fullRect())
+	s.PushLayer(BlendNormal, 0.5, nil)
+	s.Fill(FillNonZero, IdentityAffine(), SolidBrush(gg.RGBA{R: 1, A: 1}), fullRect())
+	s.PopLayer()
+
+	h := NewHybridRenderer(64, 64)
+	if h == nil {
+		t.Fatal("NewHybridRenderer returned nil")
+	}
+	defer h.Close()
+
+	target := gg.NewPixmap(64, 64)
+	if err := h.Render(target, s); err != nil {
+		t.Fatalf("Render failed: %v", err)
+	}
+
+	px := target.GetPixel(32, 32)
+	if math.Abs(float64(px.R)-1.0) > 0.05 {
+		t.Errorf("R = %v, want 1.0", px.R)
+	}
+	if math.Abs(float64(px.G)-0.5) > 0.05 || math.Abs(float64(px.B)-0.5) > 0.05 {
+		t.Errorf("G, B = %v, %v, want ~0.5 (half-alpha red over white)", px.G, px.B)
+	}
+}
+
+func TestHybridRendererMatchesPlainRenderer(t *testing.T) {
+	// With only normal full-alpha layers, the hybrid split must produce
+	// the same output as the plain CPU renderer.
+	build := func() *Scene {
+		s := NewScene()
+		s.Fill(FillNonZero, IdentityAffine(), SolidBrush(gg.RGBA{R: 0.2, G: 0.4, B: 0.8, A: 1}), fullRect())
+		s.PushLayer(BlendNormal, 1.0, nil)
+		s.Fill(FillNonZero, IdentityAffine(), SolidBrush(gg.RGBA{R: 1, A: 1}), NewCircleShape(32, 32, 16))
+		s.PopLayer()
+		return s
+	}
+
+	h := NewHybridRenderer(64, 64)
+	if h == nil {
+		t.Fatal("NewHybridRenderer returned nil")
+	}
+	defer h.Close()
+
+	r := NewRenderer(64, 64)
+	if r == nil {
+		t.Fatal("NewRenderer returned nil")
+	}
+	defer r.Close()
+
+	hybridPM := gg.NewPixmap(64, 64)
+	if err := h.Render(hybridPM, build()); err != nil {
+		t.Fatalf("hybrid Render failed: %v", err)
+	}
+
+	plainPM := gg.NewPixmap(64, 64)
+	if err := r.Render(plainPM, build()); err != nil {
+		t.Fatalf("plain Render failed: %v", err)
+	}
+
+	hd := hybridPM.Data()
+	pd := plainPM.Data()
+	diff := 0
+	for i := range hd {
+		delta := int(hd[i]) - int(pd[i])
+		if delta < -2 || delta > 2 {
+			diff++
+		}
+	}
+	if diff > 0 {
+		t.Errorf("%d bytes differ between hybrid and plain renderer output", diff)
+	}
+}
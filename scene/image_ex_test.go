@@ -0,0 +1,172 @@
+package scene
+
+import (
+	"testing"
+
+	"github.com/gogpu/gg"
+)
+
+// solidTestImage builds a w×h straight-alpha RGBA image filled with one color.
+func solidTestImage(w, h int, r, g, b, a byte) *Image {
+	img := NewImage(w, h)
+	img.Data = make([]byte, w*h*4)
+	for i := 0; i < len(img.Data); i += 4 {
+		img.Data[i] = r
+		img.Data[i+1] = g
+		img.Data[i+2] = b
+		img.Data[i+3] = a
+	}
+	return img
+}
+
+func TestDrawImageExRoundTrip(t *testing.T) {
+	img := solidTestImage(8, 8, 255, 0, 0, 255)
+	src := Rect{MinX: 2, MinY: 2, MaxX: 6, MaxY: 6}
+
+	s := NewScene()
+	s.DrawImageEx(img, src, TranslateAffine(10, 20), SamplingNearest, 0.5)
+
+	dec := NewDecoder(s.Encoding())
+	found := false
+	for dec.Next() {
+		if dec.Tag() != TagImageEx {
+			continue
+		}
+		idx, srcRect, sampling, opacity, tr := dec.ImageEx()
+		if idx != 0 {
+			t.Errorf("image index = %d, want 0", idx)
+		}
+		if srcRect != src {
+			t.Errorf("srcRect = %+v, want %+v", srcRect, src)
+		}
+		if sampling != SamplingNearest {
+			t.Errorf("sampling = %v, want Nearest", sampling)
+		}
+		if opacity != 0.5 {
+			t.Errorf("opacity = %v, want 0.5", opacity)
+		}
+		if tr.C != 10 || tr.F != 20 {
+			t.Errorf("transform = %+v, want translate(10, 20)", tr)
+		}
+		found = true
+	}
+	if !found {
+		t.Fatal("no TagImageEx command encoded")
+	}
+
+	// Bounds cover the translated source rect, not the whole image.
+	bounds := s.Encoding().Bounds()
+	want := Rect{MinX: 12, MinY: 22, MaxX: 16, MaxY: 26}
+	if bounds != want {
+		t.Errorf("bounds = %+v, want %+v", bounds, want)
+	}
+}
+
+func TestSceneBuilderDrawImage(t *testing.T) {
+	img := solidTestImage(4, 4, 0, 255, 0, 255)
+	src := Rect{MinX: 2, MinY: 2, MaxX: 4, MaxY: 4}
+	dst := Rect{MinX: 10, MinY: 10, MaxX: 30, MaxY: 30}
+
+	s := NewSceneBuilder().
+		DrawImage(img, src, dst, SamplingLinear, 1.0).
+		Build()
+
+	dec := NewDecoder(s.Encoding())
+	found := false
+	for dec.Next() {
+		if dec.Tag() != TagImageEx {
+			continue
+		}
+		_, _, _, _, tr := dec.ImageEx()
+		// The transform maps the source rect corners onto dstRect.
+		if x, y := tr.TransformPoint(2, 2); x != 10 || y != 10 {
+			t.Errorf("src min maps to (%v, %v), want (10, 10)", x, y)
+		}
+		if x, y := tr.TransformPoint(4, 4); x != 30 || y != 30 {
+			t.Errorf("src max maps to (%v, %v), want (30, 30)", x, y)
+		}
+		found = true
+	}
+	if !found {
+		t.Fatal("builder DrawImage encoded no TagImageEx command")
+	}
+}
+
+func TestRendererImageExSubRect(t *testing.T) {
+	// 2x2 image: top-left red, top-right green, bottom-left blue, bottom-right white.
+	img := NewImage(2, 2)
+	img.Data = []byte{
+		255, 0, 0, 255, 0, 255, 0, 255,
+		0, 0, 255, 255, 255, 255, 255, 255,
+	}
+
+	// Scale the green pixel (src (1,0)-(2,1)) up to 16x16 at the origin.
+	s := NewScene()
+	s.DrawImageEx(img,
+		Rect{MinX: 1, MinY: 0, MaxX: 2, MaxY: 1},
+		TranslateAffine(-16, 0).Multiply(ScaleAffine(16, 16)),
+		SamplingNearest, 1.0)
+
+	r := NewRenderer(32, 32)
+	defer r.Close()
+	target := gg.NewPixmap(32, 32)
+	if err := r.Render(target, s); err != nil {
+		t.Fatalf("Render failed: %v", err)
+	}
+
+	data := target.Data()
+	stride := 32 * 4
+	center := 8*stride + 8*4
+	if data[center+1] != 255 || data[center] != 0 {
+		t.Errorf("pixel (8,8) = %v, want pure green from src rect", data[center:center+4])
+	}
+	outside := 24*stride + 24*4
+	if data[outside+3] != 0 {
+		t.Errorf("pixel (24,24) alpha = %d, want 0 (outside dst rect)", data[outside+3])
+	}
+}
+
+func TestRendererImageExOpacity(t *testing.T) {
+	img := solidTestImage(8, 8, 255, 0, 0, 255)
+
+	s := NewScene()
+	s.DrawImageEx(img, Rect{}, ScaleAffine(2, 2), SamplingNearest, 0.5)
+
+	r := NewRenderer(16, 16)
+	defer r.Close()
+	target := gg.NewPixmap(16, 16)
+	if err := r.Render(target, s); err != nil {
+		t.Fatalf("Render failed: %v", err)
+	}
+
+	a := target.Data()[8*16*4+8*4+3]
+	if a < 120 || a > 136 {
+		t.Errorf("center alpha = %d, want ~128 for opacity 0.5", a)
+	}
+}
+
+func TestAppendWithImagesShiftsImageExIndex(t *testing.T) {
+	other := NewEncoding()
+	other.EncodeImageEx(0, IdentityAffine(),
+		Rect{MinX: 1, MinY: 1, MaxX: 3, MaxY: 3}, SamplingNearest, 1.0)
+
+	e := NewEncoding()
+	e.AppendWithImages(other, 5)
+
+	dec := NewDecoder(e)
+	for dec.Next() {
+		if dec.Tag() != TagImageEx {
+			continue
+		}
+		idx, srcRect, _, _, _ := dec.ImageEx()
+		if idx != 5 {
+			t.Errorf("image index = %d, want 5 after offset", idx)
+		}
+		want := Rect{MinX: 1, MinY: 1, MaxX: 3, MaxY: 3}
+		if srcRect != want {
+			t.Errorf("srcRect = %+v, want %+v (must survive append untouched)", srcRect, want)
+		}
+		return
+	}
+	t.Fatal("appended encoding lost the TagImageEx command")
+}
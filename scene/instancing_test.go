@@ -0,0 +1,143 @@
+package scene_test
+
+import (
+	"sync/atomic"
+	"testing"
+
+	"github.com/gogpu/gg"
+	"github.com/gogpu/gg/scene"
+)
+
+// instancedGPUTracker is a mock accelerator that counts instanced and
+// per-shape fill dispatches from scene replay.
+type instancedGPUTracker struct {
+	instancedCalls atomic.Int32
+	instances      atomic.Int32
+	shapeCalls     atomic.Int32
+}
+
+func (t *instancedGPUTracker) Name() string { return "instanced-gpu-tracker" }
+func (t *instancedGPUTracker) Init() error  { return nil }
+func (t *instancedGPUTracker) Close()       {}
+func (t *instancedGPUTracker) CanAccelerate(op gg.AcceleratedOp) bool {
+	return op&(gg.AccelFill|gg.AccelCircleSDF|gg.AccelRRectSDF) != 0
+}
+func (t *instancedGPUTracker) Flush(_ gg.GPURenderTarget) error { return nil }
+
+func (t *instancedGPUTracker) FillPath(_ gg.GPURenderTarget, _ *gg.Path, _ *gg.Paint) error {
+	return gg.ErrFallbackToCPU
+}
+
+func (t *instancedGPUTracker) StrokePath(_ gg.GPURenderTarget, _ *gg.Path, _ *gg.Paint) error {
+	return gg.ErrFallbackToCPU
+}
+
+func (t *instancedGPUTracker) FillShape(_ gg.GPURenderTarget, _ gg.DetectedShape, _ *gg.Paint) error {
+	t.shapeCalls.Add(1)
+	return gg.ErrFallbackToCPU
+}
+
+func (t *instancedGPUTracker) StrokeShape(_ gg.GPURenderTarget, _ gg.DetectedShape, _ *gg.Paint) error {
+	return gg.ErrFallbackToCPU
+}
+
+func (t *instancedGPUTracker) FillShapeInstanced(_ gg.GPURenderTarget, _ gg.DetectedShape, transforms []gg.Matrix, _ *gg.Paint) error {
+	t.instancedCalls.Add(1)
+	t.instances.Add(int32(len(transforms)))
+	return nil
+}
+
+// TestSceneRenderer_InstancedBatching verifies that repeated identical
+// circles replay as a single instanced dispatch instead of one draw each.
+func TestSceneRenderer_InstancedBatching(t *testing.T) {
+	tracker := &instancedGPUTracker{}
+	if err := gg.RegisterAccelerator(tracker); err != nil {
+		t.Fatalf("RegisterAccelerator: %v", err)
+	}
+	defer gg.CloseAccelerator()
+
+	s := scene.NewScene()
+	b := scene.NewSceneBuilderFrom(s)
+	const count = 50
+	for i := 0; i < count; i++ {
+		b.FillCircle(float32(10+i*4), 100, 3, scene.SolidBrush(gg.Blue))
+	}
+
+	pm := gg.NewPixmap(300, 200)
+	dc := gg.NewContextForPixmap(pm)
+	defer func() { _ = dc.Close() }()
+
+	gpuR := scene.NewGPUSceneRenderer(dc)
+	if err := gpuR.RenderScene(s); err != nil {
+		t.Fatalf("RenderScene: %v", err)
+	}
+
+	if got := tracker.instancedCalls.Load(); got != 1 {
+		t.Errorf("instanced dispatches = %d, want 1 for %d identical circles", got, count)
+	}
+	if got := tracker.instances.Load(); got != count {
+		t.Errorf("instances dispatched = %d, want %d", got, count)
+	}
+}
+
+// TestSceneRenderer_InstancedBatchSplitsOnBrushChange verifies that a
+// brush change starts a new batch.
+func TestSceneRenderer_InstancedBatchSplitsOnBrushChange(t *testing.T) {
+	tracker := &instancedGPUTracker{}
+	if err := gg.RegisterAccelerator(tracker); err != nil {
+		t.Fatalf("RegisterAccelerator: %v", err)
+	}
+	defer gg.CloseAccelerator()
+
+	s := scene.NewScene()
+	b := scene.NewSceneBuilderFrom(s)
+	b.FillCircle(20, 20, 5, scene.SolidBrush(gg.Blue))
+	b.FillCircle(40, 20, 5, scene.SolidBrush(gg.Blue))
+	b.FillCircle(60, 20, 5, scene.SolidBrush(gg.Red))
+	b.FillCircle(80, 20, 5, scene.SolidBrush(gg.Red))
+
+	pm := gg.NewPixmap(100, 40)
+	dc := gg.NewContextForPixmap(pm)
+	defer func() { _ = dc.Close() }()
+
+	gpuR := scene.NewGPUSceneRenderer(dc)
+	if err := gpuR.RenderScene(s); err != nil {
+		t.Fatalf("RenderScene: %v", err)
+	}
+
+	if got := tracker.instancedCalls.Load(); got != 2 {
+		t.Errorf("instanced dispatches = %d, want 2 (one per brush)", got)
+	}
+	if got := tracker.instances.Load(); got != 4 {
+		t.Errorf("instances dispatched = %d, want 4", got)
+	}
+}
+
+// TestSceneRenderer_InstancedCPUFallbackPixels verifies batched replay
+// still produces correct pixels without an accelerator.
+func TestSceneRenderer_InstancedCPUFallbackPixels(t *testing.T) {
+	gg.CloseAccelerator()
+
+	s := scene.NewScene()
+	b := scene.NewSceneBuilderFrom(s)
+	b.FillCircle(25, 25, 10, scene.SolidBrush(gg.Red))
+	b.FillCircle(75, 25, 10, scene.SolidBrush(gg.Red))
+
+	pm := gg.NewPixmap(100, 50)
+	dc := gg.NewContextForPixmap(pm)
+	defer func() { _ = dc.Close() }()
+
+	gpuR := scene.NewGPUSceneRenderer(dc)
+	if err := gpuR.RenderScene(s); err != nil {
+		t.Fatalf("RenderScene: %v", err)
+	}
+
+	for _, pt := range [][2]int{{25, 25}, {75, 25}} {
+		if c := pm.GetPixel(pt[0], pt[1]); c.R < 0.9 {
+			t.Errorf("circle center %v = %+v, want red", pt, c)
+		}
+	}
+	if c := pm.GetPixel(50, 25); c.A > 0.1 {
+		t.Errorf("pixel between circles = %+v, want transparent", c)
+	}
+}
@@ -0,0 +1,261 @@
+package scene
+
+import (
+	"image"
+	"sort"
+
+	"github.com/gogpu/gg"
+)
+
+// Node wraps a retained child Scene with mutable presentation properties:
+// an opacity multiplier, a hidden flag, and a z-index. Toggling a property
+// does not touch the child scene's encoding — the content stays recorded —
+// so UI trees can show, hide, fade, and restack elements between frames
+// without re-recording draw commands.
+//
+// Nodes are created and owned by a NodeTree; property setters feed the
+// tree's damage tracking so a toggle only invalidates the affected tiles.
+type Node struct {
+	id      uint64
+	tree    *NodeTree
+	content *Scene
+	opacity float32
+	hidden  bool
+	z       int
+
+	// Accessibility annotation (see SetSemantics); never affects rendering.
+	semantics Semantics
+}
+
+// ID returns the node's stable identifier within its tree.
+func (n *Node) ID() uint64 {
+	return n.id
+}
+
+// Content returns the node's retained scene.
+func (n *Node) Content() *Scene {
+	return n.content
+}
+
+// SetOpacity sets the node's opacity multiplier (clamped to [0, 1]).
+// Opacity below 1 composites the node through a layer with that alpha.
+func (n *Node) SetOpacity(opacity float32) {
+	opacity = clampAlpha(opacity)
+	if n.opacity == opacity {
+		return
+	}
+	n.opacity = opacity
+	// Same bounds, different appearance — the bounds diff alone won't see it.
+	n.tree.markDamage(n)
+}
+
+// Opacity returns the node's opacity multiplier.
+func (n *Node) Opacity() float32 {
+	return n.opacity
+}
+
+// SetHidden toggles the node's visibility. Hidden nodes are excluded from
+// composition; the damage tracker picks up the disappearance (or
+// reappearance) from the node's bounds leaving (or entering) the frame.
+func (n *Node) SetHidden(hidden bool) {
+	n.hidden = hidden
+}
+
+// Hidden returns true if the node is excluded from composition.
+func (n *Node) Hidden() bool {
+	return n.hidden
+}
+
+// SetZ sets the node's z-index. Nodes are composed back-to-front by
+// ascending z; nodes with equal z keep their insertion order.
+func (n *Node) SetZ(z int) {
+	if n.z == z {
+		return
+	}
+	n.z = z
+	// Restacking changes appearance wherever this node overlaps others.
+	n.tree.markDamage(n)
+}
+
+// Z returns the node's z-index.
+func (n *Node) Z() int {
+	return n.z
+}
+
+// bounds returns the node's content bounds in pixel coordinates.
+func (n *Node) bounds() image.Rectangle {
+	return n.content.Bounds().ImageRect()
+}
+
+// NodeTree composes retained scene nodes into a single scene each frame and
+// tracks the minimal damage caused by property toggles between frames.
+// It is the retained-UI counterpart of Scene.Append: node content is
+// recorded once, then recomposed (a stream copy, no re-tessellation) with
+// the current opacity, visibility, and stacking applied.
+type NodeTree struct {
+	nodes   []*Node
+	nextID  uint64
+	tracker *DamageTracker
+	pending image.Rectangle
+}
+
+// NewNodeTree creates an empty node tree.
+func NewNodeTree() *NodeTree {
+	return &NodeTree{
+		nextID:  1,
+		tracker: NewDamageTracker(),
+	}
+}
+
+// Add wraps a retained scene in a new node at z-index 0, fully opaque and
+// visible. The tree does not copy the scene; mutating it later is allowed
+// and is picked up on the next Compose.
+func (t *NodeTree) Add(content *Scene) *Node {
+	if content == nil {
+		content = NewScene()
+	}
+	n := &Node{
+		id:      t.nextID,
+		tree:    t,
+		content: content,
+		opacity: 1,
+	}
+	t.nextID++
+	t.nodes = append(t.nodes, n)
+	return n
+}
+
+// Remove detaches a node from the tree. Returns false if the node is not
+// part of this tree. The damage tracker records the removal on the next
+// frame from the node's bounds leaving the object set.
+func (t *NodeTree) Remove(n *Node) bool {
+	for i, candidate := range t.nodes {
+		if candidate == n {
+			t.nodes = append(t.nodes[:i], t.nodes[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+// Len returns the number of nodes in the tree, including hidden ones.
+func (t *NodeTree) Len() int {
+	return len(t.nodes)
+}
+
+// Compose flattens the visible nodes into a single scene, back-to-front by
+// z-index. Nodes with opacity below 1 are wrapped in a compositing layer
+// carrying that alpha — rendered natively by the GPU and hybrid paths, and
+// routed through the hybrid path by FallbackRenderer on backends without
+// FeatureLayerAlpha.
+func (t *NodeTree) Compose() *Scene {
+	composed := NewScene()
+	for _, n := range t.sorted() {
+		if n.hidden || n.opacity == 0 || n.content.IsEmpty() {
+			continue
+		}
+		if n.opacity < 1 {
+			composed.PushLayer(BlendNormal, n.opacity, nil)
+			composed.Append(n.content)
+			composed.PopLayer()
+		} else {
+			composed.Append(n.content)
+		}
+	}
+	return composed
+}
+
+// TaggedBounds returns the visible nodes' bounds keyed by stable node IDs,
+// for DamageTracker.ComputeDamage. Hidden nodes are omitted, so a
+// visibility toggle damages exactly the node's own bounds.
+func (t *NodeTree) TaggedBounds() []TaggedBounds {
+	objects := make([]TaggedBounds, 0, len(t.nodes))
+	for _, n := range t.nodes {
+		if n.hidden || n.opacity == 0 {
+			continue
+		}
+		objects = append(objects, TaggedBounds{ID: n.id, Rect: n.bounds()})
+	}
+	return objects
+}
+
+// Render composes the tree and renders it through the given renderer,
+// invalidating only the tiles touched by node changes since the previous
+// frame. Returns the damage rect in pixels, or the zero rect when nothing
+// changed (the caller can skip upload and present entirely).
+func (t *NodeTree) Render(r *Renderer, target *gg.Pixmap) (image.Rectangle, error) {
+	composed := t.Compose()
+
+	damage := t.tracker.ComputeDamage(t.TaggedBounds())
+	damage = damage.Union(t.pending)
+	t.pending = image.Rectangle{}
+
+	if t.tracker.IsFirstRender() {
+		t.tracker.MarkRendered()
+		r.MarkAllDirty()
+		err := r.RenderDirty(target, composed, nil)
+		return composed.Encoding().Bounds().ImageRect(), err
+	}
+
+	if damage.Empty() {
+		return image.Rectangle{}, nil
+	}
+
+	// Tile compositing is source-over and never erases the target, so a
+	// hidden node would leave its old pixels behind. Clear the damaged
+	// region (expanded to tile boundaries, matching what gets recomposited)
+	// before the dirty tiles are redrawn.
+	damage = alignToTiles(damage, r.tileSize).Intersect(image.Rect(0, 0, r.Width(), r.Height()))
+	clearPixmapRegion(target, damage)
+
+	r.MarkDirty(damage.Min.X, damage.Min.Y, damage.Dx(), damage.Dy())
+	err := r.RenderDirty(target, composed, nil)
+	return damage, err
+}
+
+// alignToTiles expands a pixel rect outward to tile boundaries.
+func alignToTiles(r image.Rectangle, tileSize int) image.Rectangle {
+	if r.Empty() || tileSize <= 0 {
+		return r
+	}
+	return image.Rect(
+		(r.Min.X/tileSize)*tileSize,
+		(r.Min.Y/tileSize)*tileSize,
+		((r.Max.X+tileSize-1)/tileSize)*tileSize,
+		((r.Max.Y+tileSize-1)/tileSize)*tileSize,
+	)
+}
+
+// clearPixmapRegion zeroes the pixels of target inside r.
+func clearPixmapRegion(target *gg.Pixmap, r image.Rectangle) {
+	r = r.Intersect(image.Rect(0, 0, target.Width(), target.Height()))
+	if r.Empty() {
+		return
+	}
+	data := target.Data()
+	stride := target.Width() * 4
+	for y := r.Min.Y; y < r.Max.Y; y++ {
+		row := data[y*stride+r.Min.X*4 : y*stride+r.Max.X*4]
+		clear(row)
+	}
+}
+
+// markDamage records a property change whose damage the bounds diff cannot
+// detect (opacity and z changes leave the bounds unchanged).
+func (t *NodeTree) markDamage(n *Node) {
+	if n.hidden {
+		return
+	}
+	t.pending = t.pending.Union(n.bounds())
+}
+
+// sorted returns the nodes back-to-front by z-index, preserving insertion
+// order among equal z values.
+func (t *NodeTree) sorted() []*Node {
+	ordered := make([]*Node, len(t.nodes))
+	copy(ordered, t.nodes)
+	sort.SliceStable(ordered, func(i, j int) bool {
+		return ordered[i].z < ordered[j].z
+	})
+	return ordered
+}
@@ -0,0 +1,181 @@
+package scene
+
+import (
+	"image"
+	"testing"
+
+	"github.com/gogpu/gg"
+)
+
+// rectScene builds a retained scene with one solid rect fill.
+func rectScene(x, y, w, h float32, color gg.RGBA) *Scene {
+	s := NewScene()
+	s.Fill(FillNonZero, IdentityAffine(), SolidBrush(color), NewRectShape(x, y, w, h))
+	return s
+}
+
+// pixelAt returns the RGBA bytes of pixel (x, y) in a 64-wide pixmap.
+func pixelAt(pm *gg.Pixmap, x, y int) []byte {
+	off := (y*pm.Width() + x) * 4
+	return pm.Data()[off : off+4]
+}
+
+func TestNodeTreeComposeZOrder(t *testing.T) {
+	tree := NewNodeTree()
+	blue := tree.Add(rectScene(0, 0, 32, 32, gg.RGBA{B: 1, A: 1}))
+	red := tree.Add(rectScene(0, 0, 32, 32, gg.RGBA{R: 1, A: 1}))
+	blue.SetZ(1)
+
+	r := NewRenderer(64, 64)
+	defer r.Close()
+	target := gg.NewPixmap(64, 64)
+	if _, err := tree.Render(r, target); err != nil {
+		t.Fatalf("Render failed: %v", err)
+	}
+
+	// Blue (z=1) stacks above red (z=0) despite earlier insertion.
+	if px := pixelAt(target, 16, 16); px[2] != 255 || px[0] != 0 {
+		t.Errorf("overlap pixel = %v, want blue on top", px)
+	}
+
+	// Restacking swaps the winner without touching node content.
+	red.SetZ(2)
+	if _, err := tree.Render(r, target); err != nil {
+		t.Fatalf("Render after restack failed: %v", err)
+	}
+	if px := pixelAt(target, 16, 16); px[0] != 255 {
+		t.Errorf("overlap pixel = %v, want red after restack", px)
+	}
+}
+
+func TestNodeTreeHiddenToggle(t *testing.T) {
+	tree := NewNodeTree()
+	// Base lives in tile (1,1); the overlay lives in tile (0,0).
+	tree.Add(rectScene(72, 72, 16, 16, gg.RGBA{R: 1, A: 1}))
+	overlay := tree.Add(rectScene(40, 40, 16, 16, gg.RGBA{B: 1, A: 1}))
+
+	r := NewRenderer(128, 128)
+	defer r.Close()
+	target := gg.NewPixmap(128, 128)
+	if _, err := tree.Render(r, target); err != nil {
+		t.Fatalf("first Render failed: %v", err)
+	}
+	if px := pixelAt(target, 48, 48); px[2] != 255 {
+		t.Fatalf("overlay pixel = %v, want blue before hide", px)
+	}
+
+	// Unchanged frame produces no damage.
+	damage, err := tree.Render(r, target)
+	if err != nil {
+		t.Fatalf("unchanged Render failed: %v", err)
+	}
+	if !damage.Empty() {
+		t.Errorf("damage = %v for unchanged tree, want empty", damage)
+	}
+
+	// Hiding the overlay invalidates only its own tile, not the base's.
+	overlay.SetHidden(true)
+	damage, err = tree.Render(r, target)
+	if err != nil {
+		t.Fatalf("Render after hide failed: %v", err)
+	}
+	want := image.Rect(0, 0, 64, 64)
+	if damage != want {
+		t.Errorf("damage = %v after hide, want tile-aligned %v", damage, want)
+	}
+	if px := pixelAt(target, 48, 48); px[3] != 0 {
+		t.Errorf("overlay pixel = %v after hide, want transparent", px)
+	}
+	if px := pixelAt(target, 80, 80); px[0] != 255 {
+		t.Errorf("base pixel = %v after hide, want red untouched", px)
+	}
+
+	// Showing it again redraws the same region.
+	overlay.SetHidden(false)
+	damage, err = tree.Render(r, target)
+	if err != nil {
+		t.Fatalf("Render after show failed: %v", err)
+	}
+	if damage != want {
+		t.Errorf("damage = %v after show, want %v", damage, want)
+	}
+	if px := pixelAt(target, 48, 48); px[2] != 255 {
+		t.Errorf("overlay pixel = %v after show, want blue", px)
+	}
+}
+
+func TestNodeOpacityComposition(t *testing.T) {
+	tree := NewNodeTree()
+	node := tree.Add(rectScene(0, 0, 32, 32, gg.RGBA{R: 1, A: 1}))
+	node.SetOpacity(0.5)
+
+	enc := tree.Compose().Encoding()
+	dec := NewDecoder(enc)
+	found := false
+	for dec.Next() {
+		if dec.Tag() != TagPushLayer {
+			continue
+		}
+		blend, alpha := dec.PushLayer()
+		if blend != BlendNormal || alpha != 0.5 {
+			t.Errorf("layer = (%v, %v), want (Normal, 0.5)", blend, alpha)
+		}
+		found = true
+	}
+	if !found {
+		t.Fatal("node opacity did not compose a layer")
+	}
+
+	// A fully opaque node composes without a wrapping layer.
+	node.SetOpacity(1)
+	dec = NewDecoder(tree.Compose().Encoding())
+	for dec.Next() {
+		if dec.Tag() == TagPushLayer {
+			t.Fatal("opaque node composed a redundant layer")
+		}
+	}
+}
+
+func TestNodeOpacityChangeMarksDamage(t *testing.T) {
+	tree := NewNodeTree()
+	node := tree.Add(rectScene(8, 8, 16, 16, gg.RGBA{R: 1, A: 1}))
+
+	r := NewRenderer(64, 64)
+	defer r.Close()
+	target := gg.NewPixmap(64, 64)
+	if _, err := tree.Render(r, target); err != nil {
+		t.Fatalf("first Render failed: %v", err)
+	}
+
+	// Opacity changes leave the bounds unchanged — the tree must still
+	// report the node's region as damaged.
+	node.SetOpacity(0.25)
+	damage, err := tree.Render(r, target)
+	if err != nil {
+		t.Fatalf("Render after opacity change failed: %v", err)
+	}
+	if want := image.Rect(8, 8, 24, 24); damage.Intersect(want) != want {
+		t.Errorf("damage = %v after opacity change, want it to cover %v", damage, want)
+	}
+}
+
+func TestNodeTreeRemove(t *testing.T) {
+	tree := NewNodeTree()
+	a := tree.Add(rectScene(0, 0, 8, 8, gg.RGBA{R: 1, A: 1}))
+	b := tree.Add(rectScene(8, 0, 8, 8, gg.RGBA{B: 1, A: 1}))
+
+	if !tree.Remove(a) {
+		t.Error("Remove returned false for a tree member")
+	}
+	if tree.Remove(a) {
+		t.Error("Remove returned true for an already-removed node")
+	}
+	if tree.Len() != 1 {
+		t.Errorf("Len = %d after removal, want 1", tree.Len())
+	}
+
+	objects := tree.TaggedBounds()
+	if len(objects) != 1 || objects[0].ID != b.ID() {
+		t.Errorf("TaggedBounds = %+v, want only node %d", objects, b.ID())
+	}
+}
@@ -0,0 +1,100 @@
+package scene_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gogpu/gg"
+	"github.com/gogpu/gg/scene"
+)
+
+func TestRenderWithDeadlineCompletes(t *testing.T) {
+	gg.CloseAccelerator()
+
+	s := scene.NewScene()
+	b := scene.NewSceneBuilderFrom(s)
+	b.FillRect(0, 0, 200, 200, scene.SolidBrush(gg.Red))
+
+	r := scene.NewRenderer(200, 200)
+	defer r.Close()
+
+	target := gg.NewPixmap(200, 200)
+
+	// A generous budget renders everything in one call.
+	remaining, err := r.RenderWithDeadline(target, s, time.Second)
+	if err != nil {
+		t.Fatalf("RenderWithDeadline: %v", err)
+	}
+	if remaining != 0 {
+		t.Errorf("remaining = %d, want 0 with a generous budget", remaining)
+	}
+	if c := target.GetPixel(100, 100); c.R < 0.9 {
+		t.Errorf("center pixel = %+v, want red", c)
+	}
+
+	// Nothing left dirty: a second call is a no-op.
+	remaining, err = r.RenderWithDeadline(target, s, time.Second)
+	if err != nil {
+		t.Fatalf("RenderWithDeadline (second): %v", err)
+	}
+	if remaining != 0 {
+		t.Errorf("remaining after full render = %d, want 0", remaining)
+	}
+}
+
+func TestRenderWithDeadlineProgressive(t *testing.T) {
+	gg.CloseAccelerator()
+
+	s := scene.NewScene()
+	b := scene.NewSceneBuilderFrom(s)
+	b.FillRect(0, 0, 512, 512, scene.SolidBrush(gg.Blue))
+
+	r := scene.NewRenderer(512, 512)
+	defer r.Close()
+
+	target := gg.NewPixmap(512, 512)
+
+	// A zero budget still makes forward progress (at least one batch),
+	// and leftover tiles stay dirty for the next call.
+	remaining, err := r.RenderWithDeadline(target, s, 0)
+	if err != nil {
+		t.Fatalf("RenderWithDeadline: %v", err)
+	}
+	total := r.TileCount()
+	if remaining <= 0 || remaining >= total {
+		t.Fatalf("remaining = %d, want partial progress out of %d tiles", remaining, total)
+	}
+
+	// The center tile has highest priority, so it renders first.
+	if c := target.GetPixel(256, 256); c.B < 0.9 {
+		t.Errorf("center pixel = %+v, want blue after first slice", c)
+	}
+
+	// Repeated calls drain the remaining tiles.
+	for i := 0; i < total && remaining > 0; i++ {
+		remaining, err = r.RenderWithDeadline(target, s, 0)
+		if err != nil {
+			t.Fatalf("RenderWithDeadline (drain): %v", err)
+		}
+	}
+	if remaining != 0 {
+		t.Fatalf("remaining = %d after draining, want 0", remaining)
+	}
+	for _, pt := range [][2]int{{5, 5}, {500, 5}, {5, 500}, {500, 500}} {
+		if c := target.GetPixel(pt[0], pt[1]); c.B < 0.9 {
+			t.Errorf("corner pixel %v = %+v, want blue after drain", pt, c)
+		}
+	}
+}
+
+func TestRenderWithDeadlineNilArgs(t *testing.T) {
+	r := scene.NewRenderer(64, 64)
+	defer r.Close()
+
+	if remaining, err := r.RenderWithDeadline(nil, scene.NewScene(), time.Millisecond); err != nil || remaining != 0 {
+		t.Errorf("nil target = (%d, %v), want (0, nil)", remaining, err)
+	}
+	if remaining, err := r.RenderWithDeadline(gg.NewPixmap(64, 64), nil, time.Millisecond); err != nil || remaining != 0 {
+		t.Errorf("nil scene = (%d, %v), want (0, nil)", remaining, err)
+	}
+}
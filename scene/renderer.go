@@ -4,6 +4,7 @@ import (
 	"context"
 	"image"
 	"runtime"
+	"sort"
 	"sync"
 	"time"
 
@@ -153,6 +154,12 @@ type Renderer struct {
 	// Layer caching
 	cache *LayerCache
 
+	// Optional zoom/pan view transform applied to the whole scene.
+	camera *Camera
+
+	// Optional per-tile post-processing hook (see SetTileHook).
+	tileHook TileHook
+
 	// Per-tile resource pool (SoftwareRenderer + Pixmap reuse)
 	pool tilePool
 
@@ -195,6 +202,17 @@ type RenderStats struct {
 	FPS       float64
 }
 
+// TileHook is a per-tile post-processing callback invoked after a tile
+// has been rasterized and before it is composited to the target. It
+// receives the tile's premultiplied RGBA buffer (tightly packed, stride
+// rect.Dx()*4) and the tile's pixel rect in canvas space. Modifications
+// to the buffer are composited to the target, so hooks can implement
+// custom effects (scanlines, palettization) without forking the renderer.
+//
+// Hooks run on worker goroutines, concurrently on distinct tiles; they
+// must not retain the buffer past the call.
+type TileHook func(rgba []byte, rect image.Rectangle)
+
 // RendererOption configures a Renderer.
 type RendererOption func(*Renderer)
 
@@ -224,6 +242,14 @@ func WithTileSize(size int) RendererOption {
 	}
 }
 
+// WithTileHook registers a per-tile post-processing hook.
+// See SetTileHook.
+func WithTileHook(hook TileHook) RendererOption {
+	return func(r *Renderer) {
+		r.tileHook = hook
+	}
+}
+
 // WithCache sets a custom layer cache.
 // If nil, a default cache is created.
 func WithCache(cache *LayerCache) RendererOption {
@@ -267,6 +293,49 @@ func NewRenderer(width, height int, opts ...RendererOption) *Renderer {
 	return r
 }
 
+// SetCamera attaches a zoom/pan camera applied to the whole scene before
+// rasterization. Pass nil to remove the camera. Changing camera state does
+// not mark tiles dirty — callers should MarkAllDirty (or use RenderWithContext
+// style full renders) after zooming or panning.
+func (r *Renderer) SetCamera(c *Camera) {
+	r.camera = c
+}
+
+// Camera returns the attached camera, or nil if none is set.
+func (r *Renderer) Camera() *Camera {
+	return r.camera
+}
+
+// SetTileHook registers a per-tile post-processing hook, or removes it
+// when hook is nil. The hook only runs on the tile-based CPU pipeline,
+// so registering one disables the whole-frame GPU fast path. Do not
+// change the hook while a render is in progress.
+func (r *Renderer) SetTileHook(hook TileHook) {
+	r.tileHook = hook
+}
+
+// TileHook returns the registered tile hook, or nil if none is set.
+func (r *Renderer) TileHook() TileHook {
+	return r.tileHook
+}
+
+// invokeTileHook runs the registered hook on a freshly rasterized tile.
+func (r *Renderer) invokeTileHook(tile *parallel.Tile) {
+	if r.tileHook == nil {
+		return
+	}
+	x, y, w, h := tile.Bounds()
+	r.tileHook(tile.Data[:w*h*4], image.Rect(x, y, x+w, y+h))
+}
+
+// cameraAffine returns the camera view transform, or identity without a camera.
+func (r *Renderer) cameraAffine() Affine {
+	if r.camera == nil {
+		return IdentityAffine()
+	}
+	return r.camera.Affine()
+}
+
 // renderGPU renders the scene through the GPU accelerator via GPUSceneRenderer.
 // Creates a temporary gg.Context backed by the target pixmap, renders the scene
 // through it (GPU shapes → FlushGPU → readback to pixmap), returns nil on success.
@@ -277,6 +346,16 @@ func (r *Renderer) renderGPU(target *gg.Pixmap, scene *Scene) error {
 	}
 	defer func() { _ = dc.Close() }()
 
+	// The camera becomes the context's base matrix; GPUSceneRenderer
+	// composes scene transforms with it.
+	if r.camera != nil {
+		a := r.camera.Affine()
+		dc.SetTransform(gg.Matrix{
+			A: float64(a.A), B: float64(a.B), C: float64(a.C),
+			D: float64(a.D), E: float64(a.E), F: float64(a.F),
+		})
+	}
+
 	gpuR := NewGPUSceneRenderer(dc)
 	if err := gpuR.RenderScene(scene); err != nil {
 		return err
@@ -312,7 +391,9 @@ func (r *Renderer) RenderWithContext(ctx context.Context, target *gg.Pixmap, sce
 	// GPU fast path: if a GPU accelerator is registered, render through
 	// GPUSceneRenderer which decodes scene commands into gg.Context GPU calls.
 	// The gg.Context handles GPU→CPU fallback automatically per-shape.
-	if gg.Accelerator() != nil {
+	// A registered tile hook requires per-tile buffers, which the
+	// whole-frame GPU path does not produce.
+	if gg.Accelerator() != nil && r.tileHook == nil {
 		if err := r.renderGPU(target, scene); err == nil {
 			return nil
 		}
@@ -518,6 +599,91 @@ func (r *Renderer) RenderDirtyWithContext(ctx context.Context, target *gg.Pixmap
 	return nil
 }
 
+// RenderWithDeadline renders dirty tiles in center-out priority order until
+// the time budget is exhausted, so interactive apps can hold a frame rate
+// while a complex scene progressively refines. Tiles that did not make the
+// deadline stay dirty and are picked up by the next call.
+//
+// Returns the number of tiles still dirty. A result of 0 means the target
+// is fully up to date. At least one batch of tiles is rendered per call
+// regardless of budget, guaranteeing forward progress.
+//
+// This always uses the CPU tile pipeline: the per-tile granularity that
+// makes the render interruptible does not exist on the GPU fast path.
+func (r *Renderer) RenderWithDeadline(target *gg.Pixmap, scene *Scene, budget time.Duration) (int, error) {
+	if target == nil || scene == nil {
+		return 0, nil
+	}
+
+	startTotal := time.Now()
+	deadline := startTotal.Add(budget)
+
+	startEncode := time.Now()
+	enc := scene.Encoding()
+	images := scene.Images()
+	r.fontRegistry = scene.FontRegistry()
+	encodeTime := time.Since(startEncode)
+
+	coords := r.dirty.GetAndClear()
+	if len(coords) == 0 {
+		return 0, nil
+	}
+
+	// Center-out priority: the middle of the viewport refines first.
+	cx := float64(r.width) / 2
+	cy := float64(r.height) / 2
+	sort.Slice(coords, func(i, j int) bool {
+		return tileCenterDistSq(coords[i], cx, cy) < tileCenterDistSq(coords[j], cx, cy)
+	})
+
+	startRaster := time.Now()
+	rendered := make([]*parallel.Tile, 0, len(coords))
+	next := 0
+	for next < len(coords) {
+		// First batch always runs; later batches only within budget.
+		if next > 0 && !time.Now().Before(deadline) {
+			break
+		}
+		batchEnd := min(next+r.workers, len(coords))
+		batch := make([]*parallel.Tile, 0, batchEnd-next)
+		for _, coord := range coords[next:batchEnd] {
+			if tile := r.tileGrid.TileAt(coord[0], coord[1]); tile != nil {
+				batch = append(batch, tile)
+			}
+		}
+		r.workerPool.ExecuteIndexed(len(batch), func(i int) {
+			r.renderTile(batch[i], enc, target, images)
+		})
+		rendered = append(rendered, batch...)
+		next = batchEnd
+	}
+	rasterTime := time.Since(startRaster)
+
+	startComposite := time.Now()
+	r.compositeTiles(rendered, target)
+	compositeTime := time.Since(startComposite)
+
+	// Everything we did not get to stays dirty for the next call.
+	for _, coord := range coords[next:] {
+		r.dirty.Mark(coord[0], coord[1])
+	}
+
+	r.updateStats(r.tileGrid.TileCount(), len(coords), len(rendered),
+		encodeTime, rasterTime, compositeTime, time.Since(startTotal))
+
+	return len(coords) - next, nil
+}
+
+// tileCenterDistSq returns the squared distance from a tile's center to
+// the point (cx, cy) in pixels.
+func tileCenterDistSq(coord [2]int, cx, cy float64) float64 {
+	tx := float64(coord[0]*parallel.TileWidth) + float64(parallel.TileWidth)/2
+	ty := float64(coord[1]*parallel.TileHeight) + float64(parallel.TileHeight)/2
+	dx := tx - cx
+	dy := ty - cy
+	return dx*dx + dy*dy
+}
+
 // renderTilesWithContext renders the scene encoding to the specified tiles in parallel
 // with cancellation support.
 func (r *Renderer) renderTilesWithContext(ctx context.Context, tiles []*parallel.Tile, enc *Encoding, target *gg.Pixmap, images []*Image) error {
@@ -574,11 +740,12 @@ func (r *Renderer) renderTile(tile *parallel.Tile, enc *Encoding, _ *gg.Pixmap,
 		MaxY: float32(tileY + tileH),
 	}
 
-	// Check if encoding bounds intersect tile
-	encBounds := enc.Bounds()
+	// Check if encoding bounds intersect tile (in camera/view space)
+	encBounds := transformRectAffine(r.cameraAffine(), enc.Bounds())
 	if !rectIntersects(encBounds, tileBounds) {
 		// Clear tile if nothing to render
 		clear(tile.Data)
+		r.invokeTileHook(tile)
 		return
 	}
 
@@ -603,6 +770,9 @@ func (r *Renderer) renderTile(tile *parallel.Tile, enc *Encoding, _ *gg.Pixmap,
 	r.pool.putPixmap(pm)
 	r.pool.putRenderer(sr)
 
+	// Post-process the rasterized tile before compositing.
+	r.invokeTileHook(tile)
+
 	tile.Dirty = true
 }
 
@@ -624,7 +794,11 @@ func (r *Renderer) executeEncodingOnTile(dec *Decoder, tile *parallel.Tile, pm *
 	defer r.pool.putScenePath(currentPath)
 	pathActive := false // true between TagBeginPath and fill/stroke/clip consume
 
-	currentTransform := IdentityAffine()
+	// The camera view transform is composed in front of every scene
+	// transform, so curve flattening happens at display resolution and
+	// content stays crisp at any zoom.
+	cameraTransform := r.cameraAffine()
+	currentTransform := cameraTransform
 
 	tileX, tileY, _, _ := tile.Bounds()
 
@@ -646,7 +820,7 @@ func (r *Renderer) executeEncodingOnTile(dec *Decoder, tile *parallel.Tile, pm *
 	for dec.Next() {
 		switch dec.Tag() {
 		case TagTransform:
-			currentTransform = dec.Transform()
+			currentTransform = cameraTransform.Multiply(dec.Transform())
 
 		case TagBeginPath:
 			currentPath.Reset()
@@ -773,7 +947,19 @@ func (r *Renderer) executeEncodingOnTile(dec *Decoder, tile *parallel.Tile, pm *
 			if int(imageIndex) < len(images) {
 				img := images[imageIndex]
 				if img != nil && len(img.Data) >= img.Width*img.Height*4 {
-					blitImageToTile(img, imgTransform, tileX, tileY, activePM)
+					blitImageToTile(img, cameraTransform.Multiply(imgTransform), tileX, tileY, activePM)
+				}
+			}
+
+		case TagImageEx:
+			imageIndex, srcRect, _, opacity, imgTransform := dec.ImageEx()
+			if int(imageIndex) < len(images) {
+				img := images[imageIndex]
+				if img != nil && len(img.Data) >= img.Width*img.Height*4 {
+					if srcRect.IsEmpty() {
+						srcRect = img.Bounds()
+					}
+					blitImageRegionToTile(img, cameraTransform.Multiply(imgTransform), srcRect, opacity, tileX, tileY, activePM)
 				}
 			}
 
@@ -1079,9 +1265,21 @@ func clampByte(v float32) byte {
 //
 // Image.Data is treated as straight-alpha RGBA; the pixmap uses premultiplied
 // alpha. Source pixels are premultiplied during compositing.
+func blitImageToTile(img *Image, transform Affine, tileX, tileY int, pm *gg.Pixmap) {
+	blitImageRegionToTile(img, transform, img.Bounds(), 1.0, tileX, tileY, pm)
+}
+
+// blitImageRegionToTile is the generalized blit behind blitImageToTile and
+// TagImageEx: only source pixels inside srcRect are drawn, and opacity scales
+// the source alpha before compositing. The tile path always samples nearest —
+// matching the blit quality used for plain TagImage — while the gg
+// DrawImageEx path honors the requested interpolation mode.
 //
 //nolint:gosec // G115: Integer conversions are bounded by image/tile dimensions
-func blitImageToTile(img *Image, transform Affine, tileX, tileY int, pm *gg.Pixmap) {
+func blitImageRegionToTile(img *Image, transform Affine, srcRect Rect, opacity float32, tileX, tileY int, pm *gg.Pixmap) {
+	if opacity <= 0 {
+		return
+	}
 	tileW := pm.Width()
 	tileH := pm.Height()
 	pmData := pm.Data()
@@ -1108,12 +1306,22 @@ func blitImageToTile(img *Image, transform Affine, tileX, tileY int, pm *gg.Pixm
 		F: (transform.D*transform.C - transform.A*transform.F) * invDet,
 	}
 
-	// Compute the image bounding box in canvas space to limit iteration.
+	// Clamp the source rect to the image; the rect limits both the canvas
+	// bounding box and which source pixels may be sampled.
+	srcMinX := max(int(srcRect.MinX), 0)
+	srcMinY := max(int(srcRect.MinY), 0)
+	srcMaxX := min(int(srcRect.MaxX+0.5), imgW)
+	srcMaxY := min(int(srcRect.MaxY+0.5), imgH)
+	if srcMinX >= srcMaxX || srcMinY >= srcMaxY {
+		return
+	}
+
+	// Compute the source rect's bounding box in canvas space to limit iteration.
 	corners := [4][2]float32{
-		{0, 0},
-		{float32(imgW), 0},
-		{float32(imgW), float32(imgH)},
-		{0, float32(imgH)},
+		{srcRect.MinX, srcRect.MinY},
+		{srcRect.MaxX, srcRect.MinY},
+		{srcRect.MaxX, srcRect.MaxY},
+		{srcRect.MinX, srcRect.MaxY},
 	}
 	bboxMinX, bboxMinY := corners[0][0], corners[0][1]
 	bboxMaxX, bboxMaxY := bboxMinX, bboxMinY
@@ -1141,12 +1349,15 @@ func blitImageToTile(img *Image, transform Affine, tileX, tileY int, pm *gg.Pixm
 			srcX, srcY := inv.TransformPoint(canvasX, canvasY)
 			ix := int(srcX)
 			iy := int(srcY)
-			if ix < 0 || iy < 0 || ix >= imgW || iy >= imgH {
+			if ix < srcMinX || iy < srcMinY || ix >= srcMaxX || iy >= srcMaxY {
 				continue
 			}
 
 			srcOff := iy*imgStride + ix*4
 			sa := imgData[srcOff+3]
+			if opacity < 1 {
+				sa = uint8(float32(sa)*opacity + 0.5)
+			}
 			if sa == 0 {
 				continue
 			}
@@ -1221,9 +1432,7 @@ func convertPathInto(scenePath *Path, tileOffsetX, tileOffsetY int, p *gg.Path)
 // resetFillPaint resets a Paint for fill use with the given brush and style.
 // This avoids allocating a new Paint per fill command.
 func resetFillPaint(paint *gg.Paint, brush Brush, style FillStyle) {
-	if brush.Kind == BrushSolid {
-		paint.SetBrush(gg.Solid(brush.Color))
-	}
+	paint.SetBrush(brush.ToGG())
 	if style == FillEvenOdd {
 		paint.FillRule = gg.FillRuleEvenOdd
 	} else {
@@ -1266,9 +1475,7 @@ func convertStrokePaint(brush Brush, style *StrokeStyle) *gg.Paint {
 	}
 
 	paint := gg.NewPaint()
-	if brush.Kind == BrushSolid {
-		paint.SetBrush(gg.Solid(brush.Color))
-	}
+	paint.SetBrush(brush.ToGG())
 
 	// Build a gg.Stroke using the non-deprecated API
 	s := gg.Stroke{
@@ -1498,6 +1705,30 @@ func (r *Renderer) Close() {
 // Utility Functions
 // ---------------------------------------------------------------------------
 
+// transformRectAffine returns the axis-aligned bounding box of a rect
+// after applying an affine transform.
+func transformRectAffine(a Affine, r Rect) Rect {
+	if r.IsEmpty() {
+		return r
+	}
+	corners := [4][2]float32{
+		{r.MinX, r.MinY},
+		{r.MaxX, r.MinY},
+		{r.MaxX, r.MaxY},
+		{r.MinX, r.MaxY},
+	}
+	x, y := a.TransformPoint(corners[0][0], corners[0][1])
+	out := Rect{MinX: x, MinY: y, MaxX: x, MaxY: y}
+	for _, c := range corners[1:] {
+		x, y = a.TransformPoint(c[0], c[1])
+		out.MinX = min32(out.MinX, x)
+		out.MinY = min32(out.MinY, y)
+		out.MaxX = max32(out.MaxX, x)
+		out.MaxY = max32(out.MaxY, y)
+	}
+	return out
+}
+
 // rectIntersects returns true if two rectangles intersect.
 func rectIntersects(a, b Rect) bool {
 	if a.IsEmpty() || b.IsEmpty() {
@@ -1,6 +1,9 @@
 package scene
 
-import "github.com/gogpu/gg/text"
+import (
+	"github.com/gogpu/gg/internal/trace"
+	"github.com/gogpu/gg/text"
+)
 
 // Scene is the main retained mode container for accumulating drawing operations.
 // It builds an Encoding that can be efficiently rendered or cached.
@@ -58,6 +61,10 @@ type Scene struct {
 	// pathPool reuses Path objects to avoid per-shape allocations in Fill/Stroke.
 	// Each shape.ToPath() creates a new Path; pooling eliminates this overhead.
 	pathPool PathPool
+
+	// hitID is the user-assigned hit-test ID stamped onto subsequent draw
+	// commands (SceneBuilder.WithID). Zero means unassigned.
+	hitID uint64
 }
 
 // NewScene creates a new empty scene.
@@ -90,6 +97,7 @@ func (s *Scene) Reset() {
 	s.lastEncodedAA = true
 	s.imageRegistry = s.imageRegistry[:0]
 	clear(s.fontRegistry)
+	s.hitID = 0
 }
 
 // SetAntiAlias enables or disables anti-aliasing for subsequent fill and stroke
@@ -120,11 +128,27 @@ func (s *Scene) emitAntiAliasIfNeeded(enc *Encoding) {
 	}
 }
 
+// SetHitID sets the hit-test ID assigned to subsequent draw commands.
+// HitTest reports this ID for the topmost command containing a point.
+// Zero (the default) means unassigned. See SceneBuilder.WithID for the
+// chainable form.
+func (s *Scene) SetHitID(id uint64) {
+	s.hitID = id
+	s.currentEncoding().SetCommandID(id)
+}
+
+// HitID returns the hit-test ID currently assigned to new draw commands.
+func (s *Scene) HitID() uint64 {
+	return s.hitID
+}
+
 // Fill fills a shape with the given style, transform, and brush.
 func (s *Scene) Fill(style FillStyle, transform Affine, brush Brush, shape Shape) {
 	if shape == nil {
 		return
 	}
+	sp := trace.Start("scene.fill")
+	defer sp.End()
 
 	// Combine with current transform
 	combinedTransform := s.currentTransform.Multiply(transform)
@@ -191,6 +215,8 @@ func (s *Scene) Stroke(style *StrokeStyle, transform Affine, brush Brush, shape
 	if shape == nil {
 		return
 	}
+	sp := trace.Start("scene.stroke")
+	defer sp.End()
 	if style == nil {
 		style = DefaultStrokeStyle()
 	}
@@ -280,6 +306,64 @@ func (s *Scene) DrawImage(img *Image, transform Affine) {
 	s.version++
 }
 
+// ImageSampling selects the filter used when a scene image is scaled.
+type ImageSampling uint32
+
+const (
+	// SamplingLinear interpolates between neighboring source pixels.
+	// This is the default and matches gg's bilinear image filtering.
+	SamplingLinear ImageSampling = iota
+
+	// SamplingNearest selects the closest source pixel (pixel-art scaling).
+	SamplingNearest
+)
+
+// String returns a human-readable name for the sampling mode.
+func (m ImageSampling) String() string {
+	switch m {
+	case SamplingLinear:
+		return "Linear"
+	case SamplingNearest:
+		return "Nearest"
+	default:
+		return unknownStr
+	}
+}
+
+// DrawImageEx draws a sub-rectangle of an image with sampling options.
+// The transform maps source pixel coordinates to canvas coordinates, as in
+// DrawImage; srcRect restricts which source pixels are drawn. An empty
+// srcRect selects the whole image. Opacity is clamped to [0, 1] and applied
+// on top of the image's own alpha channel.
+func (s *Scene) DrawImageEx(img *Image, srcRect Rect, transform Affine, sampling ImageSampling, opacity float32) {
+	if img == nil || img.IsEmpty() {
+		return
+	}
+	if srcRect.IsEmpty() {
+		srcRect = img.Bounds()
+	}
+
+	imageIdx := s.registerImage(img)
+	combinedTransform := s.currentTransform.Multiply(transform)
+
+	enc := s.currentEncoding()
+	//nolint:gosec // image index is bounded by slice length
+	enc.EncodeImageEx(uint32(imageIdx), combinedTransform, srcRect, sampling, clampAlpha(opacity))
+
+	// Only the source rect contributes to bounds.
+	imgBounds := srcRect
+	if !combinedTransform.IsIdentity() {
+		imgBounds = transformBounds(imgBounds, combinedTransform)
+	}
+	s.bounds = s.bounds.Union(imgBounds)
+	enc.UpdateBounds(imgBounds)
+	enc.RecordCommandBounds(imgBounds)
+
+	s.layerStack.Top().UpdateBounds(imgBounds)
+
+	s.version++
+}
+
 // PushLayer pushes a new compositing layer.
 // All subsequent drawing operations will be rendered to this layer.
 // Call PopLayer to composite the layer with the content below.
@@ -300,6 +384,7 @@ func (s *Scene) PushLayer(blend BlendMode, alpha float32, clip Shape) {
 	} else {
 		layer.Encoding.Reset()
 	}
+	layer.Encoding.SetCommandID(s.hitID)
 
 	// Encode push layer in parent
 	parentEnc := s.currentEncoding()
@@ -0,0 +1,117 @@
+package scene
+
+// SemanticRole classifies a node's content for accessibility consumers.
+// The scene package defines its own role enum (mirroring the recording
+// package, like Brush and FillStyle) so retained trees carry annotations
+// without a cross-package dependency.
+type SemanticRole uint8
+
+const (
+	// RoleNone marks an unannotated or purely structural node.
+	RoleNone SemanticRole = iota
+
+	// RoleParagraph marks body text.
+	RoleParagraph
+
+	// RoleHeading marks a heading or title.
+	RoleHeading
+
+	// RoleFigure marks an illustration, chart, or image with meaning.
+	RoleFigure
+
+	// RoleLink marks an interactive hyperlink region.
+	RoleLink
+
+	// RoleButton marks an interactive button region.
+	RoleButton
+
+	// RoleList marks a list container.
+	RoleList
+
+	// RoleListItem marks one entry of a list.
+	RoleListItem
+
+	// RoleDecoration marks purely presentational content that accessibility
+	// consumers should skip.
+	RoleDecoration
+)
+
+// semanticRoleNames maps SemanticRole values to their string representation.
+var semanticRoleNames = [...]string{
+	RoleNone:       "None",
+	RoleParagraph:  "Paragraph",
+	RoleHeading:    "Heading",
+	RoleFigure:     "Figure",
+	RoleLink:       "Link",
+	RoleButton:     "Button",
+	RoleList:       "List",
+	RoleListItem:   "ListItem",
+	RoleDecoration: "Decoration",
+}
+
+// String returns the string representation of a SemanticRole.
+func (r SemanticRole) String() string {
+	if int(r) < len(semanticRoleNames) {
+		return semanticRoleNames[r]
+	}
+	return unknownStr
+}
+
+// Semantics is an optional accessibility annotation for a scene node.
+type Semantics struct {
+	// Role classifies the node's content.
+	Role SemanticRole
+
+	// Label is the human-readable description (alt text, link title).
+	Label string
+
+	// Bounds is the annotated region in canvas coordinates. An empty rect
+	// means "use the node's content bounds".
+	Bounds Rect
+}
+
+// NodeSemantics is one entry of a tree's accessibility listing: a node's
+// annotation with its bounds resolved and its stable node ID attached.
+type NodeSemantics struct {
+	// NodeID is the annotated node's stable identifier within the tree.
+	NodeID uint64
+
+	// Semantics is the annotation; Bounds falls back to the node's content
+	// bounds when the annotation left it empty.
+	Semantics Semantics
+}
+
+// SetSemantics attaches an accessibility annotation to the node.
+// Annotations do not affect rendering; a zero-value Semantics (RoleNone,
+// no label) detaches the annotation.
+func (n *Node) SetSemantics(s Semantics) {
+	n.semantics = s
+}
+
+// Semantics returns the node's accessibility annotation. The zero value
+// means the node is unannotated.
+func (n *Node) Semantics() Semantics {
+	return n.semantics
+}
+
+// SemanticNodes returns the annotations of the visible annotated nodes,
+// back-to-front by z-index with empty bounds resolved to content bounds.
+// UI frameworks can build an accessibility tree from this listing using
+// the same draw data the frame was composed from.
+func (t *NodeTree) SemanticNodes() []NodeSemantics {
+	var result []NodeSemantics
+	for _, n := range t.sorted() {
+		if n.hidden || n.opacity == 0 {
+			continue
+		}
+		s := n.semantics
+		if s.Role == RoleNone && s.Label == "" {
+			continue
+		}
+		if s.Bounds.IsEmpty() {
+			s.Bounds = n.content.Bounds()
+		}
+		result = append(result, NodeSemantics{NodeID: n.id, Semantics: s})
+	}
+	return result
+}
@@ -0,0 +1,61 @@
+package scene
+
+import (
+	"testing"
+
+	"github.com/gogpu/gg"
+)
+
+func TestNodeSemantics(t *testing.T) {
+	tree := NewNodeTree()
+	button := tree.Add(rectScene(10, 10, 30, 20, gg.RGBA{B: 1, A: 1}))
+	button.SetSemantics(Semantics{Role: RoleButton, Label: "OK"})
+	tree.Add(rectScene(0, 0, 100, 100, gg.RGBA{R: 1, A: 1})) // unannotated background
+
+	nodes := tree.SemanticNodes()
+	if len(nodes) != 1 {
+		t.Fatalf("got %d semantic nodes, want 1 (unannotated node skipped)", len(nodes))
+	}
+	got := nodes[0]
+	if got.NodeID != button.ID() {
+		t.Errorf("NodeID = %d, want %d", got.NodeID, button.ID())
+	}
+	if got.Semantics.Role != RoleButton || got.Semantics.Label != "OK" {
+		t.Errorf("semantics = %+v, want Button/OK", got.Semantics)
+	}
+	// Empty annotation bounds resolve to the node's content bounds.
+	if got.Semantics.Bounds != button.Content().Bounds() {
+		t.Errorf("bounds = %+v, want content bounds %+v", got.Semantics.Bounds, button.Content().Bounds())
+	}
+}
+
+func TestSemanticNodesSkipHidden(t *testing.T) {
+	tree := NewNodeTree()
+	n := tree.Add(rectScene(0, 0, 20, 20, gg.RGBA{G: 1, A: 1}))
+	n.SetSemantics(Semantics{Role: RoleFigure, Label: "icon"})
+
+	if got := len(tree.SemanticNodes()); got != 1 {
+		t.Fatalf("got %d semantic nodes before hiding, want 1", got)
+	}
+	n.SetHidden(true)
+	if got := len(tree.SemanticNodes()); got != 0 {
+		t.Errorf("got %d semantic nodes after hiding, want 0", got)
+	}
+}
+
+func TestSemanticNodesZOrder(t *testing.T) {
+	tree := NewNodeTree()
+	top := tree.Add(rectScene(0, 0, 20, 20, gg.RGBA{R: 1, A: 1}))
+	top.SetZ(1)
+	top.SetSemantics(Semantics{Role: RoleHeading, Label: "top"})
+	back := tree.Add(rectScene(0, 0, 40, 40, gg.RGBA{B: 1, A: 1}))
+	back.SetSemantics(Semantics{Role: RoleParagraph, Label: "back"})
+
+	nodes := tree.SemanticNodes()
+	if len(nodes) != 2 {
+		t.Fatalf("got %d semantic nodes, want 2", len(nodes))
+	}
+	if nodes[0].Semantics.Label != "back" || nodes[1].Semantics.Label != "top" {
+		t.Errorf("order = %q, %q, want back-to-front", nodes[0].Semantics.Label, nodes[1].Semantics.Label)
+	}
+}
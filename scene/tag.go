@@ -102,6 +102,13 @@ const (
 	// Data: 1 uint32 for image index, 6 float32 for transform
 	TagImage Tag = 0x51
 
+	// TagImageEx references an image resource with sampling options.
+	// Data: 3 uint32 in drawData [image index, sampling mode, opacity bits];
+	//       4 float32 in pathData [srcMinX, srcMinY, srcMaxX, srcMaxY];
+	//       1 entry in the transforms stream mapping source pixels to canvas.
+	// An empty source rect selects the whole image.
+	TagImageEx Tag = 0x52
+
 	// TagText encodes a pre-shaped text run as a late-bound glyph reference.
 	// Data: textData stream containing GlyphRunData header + GlyphEntry array.
 	// Resolution to pixels (atlas, outline, text op) is deferred to render time.
@@ -150,6 +157,8 @@ func (t Tag) String() string {
 		return "Brush"
 	case TagImage:
 		return "Image"
+	case TagImageEx:
+		return "ImageEx"
 	case TagText:
 		return "Text"
 	default:
@@ -193,6 +202,8 @@ func (t Tag) DataSize() int {
 		return 6 // minX, minY, maxX, maxY, radiusX, radiusY
 	case TagBrush:
 		return 4 // RGBA
+	case TagImageEx:
+		return 4 // source rect: minX, minY, maxX, maxY
 	default:
 		return 0
 	}
@@ -547,6 +547,31 @@ func (s *Scene) encodeTextRun(str string, glyphs []text.ShapedGlyph, face text.F
 
 	enc.EncodeText(run, entries, str)
 
+	// Track run bounds so the tile renderer doesn't cull text-only scenes:
+	// horizontal extent from shaped positions plus the last glyph's advance,
+	// vertical extent from the face's ascent/descent.
+	minGX := glyphs[0].X
+	for _, g := range glyphs {
+		if g.X < minGX {
+			minGX = g.X
+		}
+	}
+	last := glyphs[len(glyphs)-1]
+	metrics := face.Metrics()
+	runBounds := Rect{
+		MinX: x + float32(minGX),
+		MinY: y - float32(metrics.Ascent),
+		MaxX: x + float32(last.X+last.XAdvance),
+		MaxY: y + float32(metrics.Descent),
+	}
+	if !combinedTransform.IsIdentity() {
+		runBounds = transformBounds(runBounds, combinedTransform)
+	}
+	s.bounds = s.bounds.Union(runBounds)
+	enc.UpdateBounds(runBounds)
+	enc.RecordCommandBounds(runBounds)
+	s.layerStack.Top().UpdateBounds(runBounds)
+
 	s.version++
 	return nil
 }
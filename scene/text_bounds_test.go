@@ -0,0 +1,144 @@
+package scene
+
+import (
+	"os"
+	"testing"
+
+	"github.com/gogpu/gg"
+	"github.com/gogpu/gg/text"
+)
+
+// loadTestFace loads the shared goregular test font at the given size.
+func loadTestFace(t *testing.T, size float64) text.Face {
+	t.Helper()
+	data, err := os.ReadFile("../text/testdata/goregular.ttf")
+	if err != nil {
+		t.Skipf("test font unavailable: %v", err)
+	}
+	source, err := text.NewFontSource(data)
+	if err != nil {
+		t.Fatalf("NewFontSource failed: %v", err)
+	}
+	return source.Face(size)
+}
+
+func TestSceneDrawTextUpdatesBounds(t *testing.T) {
+	face := loadTestFace(t, 24)
+
+	s := NewScene()
+	if err := s.DrawText("Hello", face, 10, 40, SolidBrush(gg.Black)); err != nil {
+		t.Fatalf("DrawText failed: %v", err)
+	}
+
+	bounds := s.Encoding().Bounds()
+	if bounds.IsEmpty() {
+		t.Fatal("encoding bounds empty after DrawText — text would be culled by tile renderer")
+	}
+	if bounds.MinX > 10 || bounds.MaxX < 20 {
+		t.Errorf("bounds %+v do not cover the run's horizontal extent", bounds)
+	}
+	// Ascent reaches above the baseline at y=40, descent below it.
+	if bounds.MinY >= 40 || bounds.MaxY <= 40 {
+		t.Errorf("bounds %+v do not straddle the baseline y=40", bounds)
+	}
+
+	if s.Bounds().IsEmpty() {
+		t.Error("scene bounds empty after DrawText")
+	}
+}
+
+func TestRendererDrawsTextOnlyScene(t *testing.T) {
+	face := loadTestFace(t, 32)
+
+	s := NewScene()
+	if err := s.DrawText("Hi", face, 4, 44, SolidBrush(gg.Black)); err != nil {
+		t.Fatalf("DrawText failed: %v", err)
+	}
+
+	r := NewRenderer(64, 64)
+	if r == nil {
+		t.Fatal("NewRenderer returned nil")
+	}
+	defer r.Close()
+
+	target := gg.NewPixmap(64, 64)
+	if err := r.Render(target, s); err != nil {
+		t.Fatalf("Render failed: %v", err)
+	}
+
+	covered := 0
+	data := target.Data()
+	for i := 3; i < len(data); i += 4 {
+		if data[i] != 0 {
+			covered++
+		}
+	}
+	if covered == 0 {
+		t.Error("text-only scene rendered no pixels")
+	}
+}
+
+func TestSceneBuilderText(t *testing.T) {
+	face := loadTestFace(t, 16)
+
+	s := NewSceneBuilder().
+		Translate(100, 0).
+		Text("A", face, 0, 20, SolidBrush(gg.Black)).
+		Build()
+
+	enc := s.Encoding()
+	texts := 0
+	sawTransform := false
+	dec := NewDecoder(enc)
+	for dec.Next() {
+		switch dec.Tag() {
+		case TagText:
+			dec.Text()
+			texts++
+		case TagTransform:
+			tr := dec.Transform()
+			if tr.C == 100 {
+				sawTransform = true
+			}
+		}
+	}
+	if texts != 1 {
+		t.Fatalf("text runs = %d, want 1", texts)
+	}
+	if !sawTransform {
+		t.Error("builder transform was not encoded with the text run")
+	}
+
+	// The builder restores the scene transform after the run.
+	if !s.Transform().IsIdentity() {
+		t.Error("scene transform not restored after builder Text")
+	}
+}
+
+func TestSceneBuilderTextGlyphs(t *testing.T) {
+	face := loadTestFace(t, 16)
+	shaped := text.Shape("AB", face)
+	if len(shaped) == 0 {
+		t.Fatal("shaping produced no glyphs")
+	}
+
+	s := NewSceneBuilder().
+		TextGlyphs("AB", shaped, face, 5, 20, SolidBrush(gg.Black)).
+		Build()
+
+	dec := NewDecoder(s.Encoding())
+	found := false
+	for dec.Next() {
+		if dec.Tag() == TagText {
+			run, glyphs, str, _ := dec.Text()
+			if str != "AB" || int(run.GlyphCount) != len(shaped) || len(glyphs) != len(shaped) {
+				t.Errorf("run = %+v with %d glyphs %q, want %d glyphs \"AB\"",
+					run, len(glyphs), str, len(shaped))
+			}
+			found = true
+		}
+	}
+	if !found {
+		t.Fatal("no TagText command encoded")
+	}
+}
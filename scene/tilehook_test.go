@@ -0,0 +1,107 @@
+package scene
+
+import (
+	"image"
+	"sync"
+	"testing"
+
+	"github.com/gogpu/gg"
+)
+
+func TestRenderer_TileHookInvoked(t *testing.T) {
+	r := NewRenderer(200, 150)
+	if r == nil {
+		t.Fatal("NewRenderer returned nil")
+	}
+	defer r.Close()
+
+	var mu sync.Mutex
+	var rects []image.Rectangle
+	r.SetTileHook(func(rgba []byte, rect image.Rectangle) {
+		if len(rgba) != rect.Dx()*rect.Dy()*4 {
+			t.Errorf("buffer length %d, want %d for %v", len(rgba), rect.Dx()*rect.Dy()*4, rect)
+		}
+		mu.Lock()
+		rects = append(rects, rect)
+		mu.Unlock()
+	})
+
+	target := gg.NewPixmap(200, 150)
+	scene := NewScene()
+	scene.Fill(FillNonZero, IdentityAffine(),
+		SolidBrush(gg.RGBA{R: 1, A: 1}), NewRectShape(20, 20, 100, 80))
+
+	if err := r.Render(target, scene); err != nil {
+		t.Fatalf("Render() returned error: %v", err)
+	}
+
+	if len(rects) != r.TileCount() {
+		t.Errorf("hook ran on %d tiles, want %d", len(rects), r.TileCount())
+	}
+	// The tile rects must exactly cover the canvas.
+	area := 0
+	canvas := image.Rect(0, 0, 200, 150)
+	for _, rect := range rects {
+		if !rect.In(canvas) {
+			t.Errorf("tile rect %v outside canvas %v", rect, canvas)
+		}
+		area += rect.Dx() * rect.Dy()
+	}
+	if area != 200*150 {
+		t.Errorf("tile rects cover %d pixels, want %d", area, 200*150)
+	}
+}
+
+func TestRenderer_TileHookModifiesOutput(t *testing.T) {
+	// A hook that overwrites every tile with opaque green must show up
+	// in the composited target, regardless of scene content.
+	r := NewRenderer(128, 128, WithTileHook(func(rgba []byte, _ image.Rectangle) {
+		for i := 0; i < len(rgba); i += 4 {
+			rgba[i] = 0
+			rgba[i+1] = 255
+			rgba[i+2] = 0
+			rgba[i+3] = 255
+		}
+	}))
+	if r == nil {
+		t.Fatal("NewRenderer returned nil")
+	}
+	defer r.Close()
+
+	target := gg.NewPixmap(128, 128)
+	scene := NewScene()
+	scene.Fill(FillNonZero, IdentityAffine(),
+		SolidBrush(gg.RGBA{R: 1, A: 1}), NewRectShape(0, 0, 128, 128))
+
+	if err := r.Render(target, scene); err != nil {
+		t.Fatalf("Render() returned error: %v", err)
+	}
+
+	c := target.GetPixel(64, 64)
+	if c.G < 0.99 || c.R > 0.01 || c.A < 0.99 {
+		t.Errorf("center pixel = %+v, want opaque green from hook", c)
+	}
+}
+
+func TestRenderer_SetTileHook(t *testing.T) {
+	r := NewRenderer(64, 64)
+	if r == nil {
+		t.Fatal("NewRenderer returned nil")
+	}
+	defer r.Close()
+
+	if r.TileHook() != nil {
+		t.Error("TileHook() should default to nil")
+	}
+	r.SetTileHook(func(_ []byte, _ image.Rectangle) {})
+	if r.TileHook() == nil {
+		t.Error("TileHook() should return the registered hook")
+	}
+
+	// A nil hook removes post-processing; rendering still works.
+	r.SetTileHook(nil)
+	target := gg.NewPixmap(64, 64)
+	if err := r.Render(target, NewScene()); err != nil {
+		t.Errorf("Render() returned error: %v", err)
+	}
+}
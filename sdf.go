@@ -1,6 +1,10 @@
 package gg
 
-import "math"
+import (
+	"math"
+
+	"github.com/gogpu/gg/internal/detmath"
+)
 
 // sdfAntialiasWidth controls the smoothstep transition width in pixels.
 // A value of 0.7 produces smooth anti-aliasing at standard DPI.
@@ -78,7 +82,7 @@ func sdfRRect(px, py, cx, cy, halfW, halfH, cornerRadius float64) float64 {
 
 	// Outside the corner region: max(dx, dy) gives the distance to the edge.
 	// Inside the corner region: the Euclidean distance to the corner circle.
-	outside := math.Sqrt(math.Max(dx, 0)*math.Max(dx, 0) + math.Max(dy, 0)*math.Max(dy, 0))
+	outside := detmath.Sqrt(math.Max(dx, 0)*math.Max(dx, 0) + math.Max(dy, 0)*math.Max(dy, 0))
 	inside := math.Min(math.Max(dx, dy), 0)
 
 	return outside + inside - cornerRadius
@@ -1,6 +1,10 @@
 package gg
 
-import "math"
+import (
+	"math"
+
+	"github.com/gogpu/gg/internal/detmath"
+)
 
 const (
 	// sdfMinSize is the minimum shape dimension (px) for CPU SDF rendering.
@@ -197,7 +201,7 @@ func (a *SDFAccelerator) fillEllipseSDF(target GPURenderTarget, shape DetectedSh
 			// Scale to unit circle space.
 			dx := (float64(px) + 0.5 - cx) / rx
 			dy := (float64(py) + 0.5 - cy) / ry
-			dist := math.Sqrt(dx*dx+dy*dy)*math.Min(rx, ry) - math.Min(rx, ry)
+			dist := detmath.Sqrt(dx*dx+dy*dy)*math.Min(rx, ry) - math.Min(rx, ry)
 			coverage := smoothstepCoverage(dist)
 			if coverage > 0 {
 				blendPixel(target, px, py, color, coverage, paint)
@@ -224,7 +228,7 @@ func (a *SDFAccelerator) strokeEllipseSDF(target GPURenderTarget, shape Detected
 		for px := minX; px <= maxX; px++ {
 			dx := (float64(px) + 0.5 - cx) / rx
 			dy := (float64(py) + 0.5 - cy) / ry
-			dist := math.Sqrt(dx*dx+dy*dy)*math.Min(rx, ry) - math.Min(rx, ry)
+			dist := detmath.Sqrt(dx*dx+dy*dy)*math.Min(rx, ry) - math.Min(rx, ry)
 			sdf := math.Abs(dist) - halfW
 			coverage := smoothstepCoverage(sdf)
 			if coverage > 0 {
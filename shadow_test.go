@@ -0,0 +1,121 @@
+package gg
+
+import "testing"
+
+func TestShadowFillOffset(t *testing.T) {
+	dc := NewContext(100, 100)
+	dc.SetShadow(10, 10, 0, RGBA2(0, 0, 0, 1))
+	dc.SetRGB(1, 0, 0)
+	dc.DrawRectangle(20, 20, 30, 30)
+	if err := dc.Fill(); err != nil {
+		t.Fatalf("Fill failed: %v", err)
+	}
+
+	// Shape covers [20,50); shadow covers [30,60) and is overdrawn by the
+	// shape where they overlap. (55, 55) is shadow-only.
+	if got := dc.pixmap.GetPixel(35, 35); got.R < 0.9 {
+		t.Errorf("shape not painted over shadow: %+v", got)
+	}
+	if got := dc.pixmap.GetPixel(55, 55); got.A < 0.9 || got.R > 0.1 {
+		t.Errorf("shadow not painted at offset: %+v", got)
+	}
+	if got := dc.pixmap.GetPixel(10, 10); got.A > 0.1 {
+		t.Errorf("pixel outside shape and shadow painted: %+v", got)
+	}
+}
+
+func TestShadowBlurSoftensEdge(t *testing.T) {
+	dc := NewContext(100, 100)
+	dc.SetShadow(0, 0, 10, RGBA2(0, 0, 0, 1))
+	dc.SetRGB(1, 1, 1)
+	dc.DrawRectangle(40, 40, 20, 20)
+	if err := dc.Fill(); err != nil {
+		t.Fatalf("Fill failed: %v", err)
+	}
+
+	// Blur spreads shadow alpha beyond the shape: a few pixels outside
+	// the rect there must be partial coverage, fading with distance.
+	near := dc.pixmap.GetPixel(63, 50).A
+	far := dc.pixmap.GetPixel(75, 50).A
+	if near <= 0.05 {
+		t.Errorf("no blurred shadow near shape edge: alpha %v", near)
+	}
+	if far >= near {
+		t.Errorf("shadow alpha does not fade with distance: near %v, far %v", near, far)
+	}
+}
+
+func TestShadowColorAndOpacity(t *testing.T) {
+	dc := NewContext(100, 100)
+	dc.SetShadow(15, 0, 0, RGBA2(0, 0, 1, 0.5))
+	dc.SetRGB(1, 0, 0)
+	dc.DrawRectangle(20, 40, 20, 20)
+	if err := dc.Fill(); err != nil {
+		t.Fatalf("Fill failed: %v", err)
+	}
+
+	// Shadow-only region: half-transparent blue.
+	got := dc.pixmap.GetPixel(45, 50)
+	if got.B < 0.9 || got.A < 0.4 || got.A > 0.6 {
+		t.Errorf("shadow color/opacity wrong: %+v", got)
+	}
+}
+
+func TestShadowStroke(t *testing.T) {
+	dc := NewContext(100, 100)
+	dc.SetShadow(0, 20, 0, RGBA2(0, 0, 0, 1))
+	dc.SetRGB(0, 1, 0)
+	dc.SetLineWidth(4)
+	dc.MoveTo(20, 30)
+	dc.LineTo(80, 30)
+	if err := dc.Stroke(); err != nil {
+		t.Fatalf("Stroke failed: %v", err)
+	}
+
+	if got := dc.pixmap.GetPixel(50, 30); got.G < 0.9 {
+		t.Errorf("stroke not painted: %+v", got)
+	}
+	if got := dc.pixmap.GetPixel(50, 50); got.A < 0.9 {
+		t.Errorf("stroke shadow not painted at offset: %+v", got)
+	}
+}
+
+func TestClearShadow(t *testing.T) {
+	dc := NewContext(100, 100)
+	dc.SetShadow(10, 10, 0, RGBA2(0, 0, 0, 1))
+	dc.ClearShadow()
+	if dc.HasShadow() {
+		t.Fatal("shadow still active after ClearShadow")
+	}
+	dc.SetRGB(1, 0, 0)
+	dc.DrawRectangle(20, 20, 30, 30)
+	_ = dc.Fill()
+	if got := dc.pixmap.GetPixel(55, 55); got.A > 0.1 {
+		t.Errorf("shadow painted after ClearShadow: %+v", got)
+	}
+}
+
+func TestShadowTransparentColorDisables(t *testing.T) {
+	dc := NewContext(10, 10)
+	dc.SetShadow(1, 1, 0, RGBA2(0, 0, 0, 0))
+	if dc.HasShadow() {
+		t.Error("fully transparent shadow color should disable the shadow")
+	}
+}
+
+func TestShadowSnapshotAndReset(t *testing.T) {
+	dc := NewContext(10, 10)
+	dc.SetShadow(3, 4, 5, RGBA2(0, 0, 0, 0.8))
+	snap := dc.SaveState()
+
+	dc.ClearShadow()
+	dc.RestoreState(snap)
+	if !dc.HasShadow() {
+		t.Error("shadow not restored from snapshot")
+	}
+
+	dc.Reset()
+	if dc.HasShadow() {
+		t.Error("shadow survived Reset")
+	}
+}
@@ -1,6 +1,10 @@
 package gg
 
-import "math"
+import (
+	"math"
+
+	"github.com/gogpu/gg/internal/detmath"
+)
 
 // DrawRegularPolygon draws a regular polygon with n sides.
 // When rotation=0, odd-sided polygons (triangle, pentagon) have a vertex
@@ -15,8 +19,8 @@ func (c *Context) DrawRegularPolygon(n int, x, y, r, rotation float64) {
 	}
 	for i := 0; i < n; i++ {
 		a := rotation + angle*float64(i)
-		px := x + r*math.Cos(a)
-		py := y + r*math.Sin(a)
+		px := x + r*detmath.Cos(a)
+		py := y + r*detmath.Sin(a)
 		if i == 0 {
 			c.MoveTo(px, py)
 		} else {
@@ -6,6 +6,8 @@ import (
 
 	"github.com/gogpu/gg/internal/raster"
 	"github.com/gogpu/gg/internal/stroke"
+
+	"github.com/gogpu/gg/internal/detmath"
 )
 
 // SoftwareRenderer is a CPU-based scanline rasterizer using analytic anti-aliasing.
@@ -25,6 +27,12 @@ type SoftwareRenderer struct {
 	// Used to adjust curve flattening tolerance for sharper rendering on Retina.
 	deviceScale float32
 
+	// flattenTolerance overrides the base curve flattening tolerance in
+	// device pixels (0 = defaultFlattenTolerance). The effective value
+	// handed to the edge builders additionally divides by deviceScale on
+	// HiDPI, matching SetDeviceScale.
+	flattenTolerance float32
+
 	// rasterizerMode is set by Context before calling Fill/Stroke
 	// to support forced algorithm selection (RasterizerSparseStrips, etc.).
 	// Reset to RasterizerAuto after each call.
@@ -46,6 +54,10 @@ type SoftwareRenderer struct {
 	// Matches Skia fOuter.reset() pattern — zero per-stroke allocation.
 	scratchStrokePath *Path
 
+	// arena holds reusable stroke-pipeline scratch buffers and the
+	// allocation counters exposed via Stats().
+	arena geometryArena
+
 	// Clip bounds for scanline/tile skipping (ADR-052 Layer A).
 	// When hasClip is true, both the AnalyticFiller and CoverageFiller
 	// skip scanlines/tiles outside the clip rectangle at zero per-pixel cost.
@@ -53,6 +65,10 @@ type SoftwareRenderer struct {
 	hasClip                                  bool
 }
 
+// defaultFlattenTolerance is the base curve flattening tolerance in device
+// pixels used when no override is set (matches the EdgeBuilder default).
+const defaultFlattenTolerance = 0.1
+
 // NewSoftwareRenderer creates a new software renderer with analytic anti-aliasing.
 func NewSoftwareRenderer(width, height int) *SoftwareRenderer {
 	eb := raster.NewEdgeBuilder(2) // 4x AA (Skia default), max coord 8191px
@@ -72,9 +88,7 @@ func (r *SoftwareRenderer) Resize(width, height int) {
 	r.width = width
 	r.height = height
 	eb := raster.NewEdgeBuilder(2) // 4x AA (Skia default), max coord 8191px
-	if r.deviceScale > 1.0 {
-		eb.SetFlattenTolerance(0.1 / r.deviceScale)
-	}
+	eb.SetFlattenTolerance(r.effectiveFlattenTolerance())
 	r.edgeBuilder = eb
 	r.analyticFiller = raster.NewAnalyticFiller(width, height)
 	// Reset lazy no-AA resources so they pick up new dimensions.
@@ -113,8 +127,50 @@ func (r *SoftwareRenderer) SetDeviceScale(scale float32) {
 		scale = 1.0
 	}
 	r.deviceScale = scale
-	if scale > 1.0 {
-		r.edgeBuilder.SetFlattenTolerance(0.1 / scale)
+	r.applyFlattenTolerance()
+}
+
+// SetFlattenTolerance sets the base curve flattening tolerance in device
+// pixels — the maximum deviation between a curve and its line-segment
+// approximation. Lower values produce smoother curves at the cost of more
+// segments; 0 restores the default (0.1 px). On HiDPI the effective
+// tolerance is additionally divided by the device scale, matching
+// SetDeviceScale.
+func (r *SoftwareRenderer) SetFlattenTolerance(tolerance float64) {
+	r.flattenTolerance = float32(tolerance)
+	r.applyFlattenTolerance()
+}
+
+// FlattenTolerance returns the base curve flattening tolerance in device
+// pixels (the default when no override is set).
+func (r *SoftwareRenderer) FlattenTolerance() float64 {
+	if r.flattenTolerance <= 0 {
+		return defaultFlattenTolerance
+	}
+	return float64(r.flattenTolerance)
+}
+
+// effectiveFlattenTolerance returns the tolerance handed to the edge
+// builders: the base tolerance divided by the device scale on HiDPI
+// (femtovg pattern: tol = baseTol / scale).
+func (r *SoftwareRenderer) effectiveFlattenTolerance() float32 {
+	tol := r.flattenTolerance
+	if tol <= 0 {
+		tol = defaultFlattenTolerance
+	}
+	if r.deviceScale > 1.0 {
+		tol /= r.deviceScale
+	}
+	return tol
+}
+
+// applyFlattenTolerance pushes the effective tolerance to the existing
+// edge builders. Lazily created builders pick it up at creation.
+func (r *SoftwareRenderer) applyFlattenTolerance() {
+	tol := r.effectiveFlattenTolerance()
+	r.edgeBuilder.SetFlattenTolerance(tol)
+	if r.noAAEdgeBuilder != nil {
+		r.noAAEdgeBuilder.SetFlattenTolerance(tol)
 	}
 }
 
@@ -203,7 +259,7 @@ func adaptiveThreshold(bboxArea float64) int {
 	if bboxArea <= 0 {
 		return maxElementThreshold
 	}
-	threshold := int(2048.0 / math.Sqrt(bboxArea))
+	threshold := int(2048.0 / detmath.Sqrt(bboxArea))
 	if threshold < minElementThreshold {
 		return minElementThreshold
 	}
@@ -294,6 +350,17 @@ func shouldUseTileRasterizer(p *Path) bool {
 func (r *SoftwareRenderer) fillWithCoverageFiller(
 	pixmap *Pixmap, p *Path, paint *Paint, filler CoverageFiller,
 ) {
+	// Thread the flattening tolerance override to fillers that support it.
+	// Zero keeps the filler's own default (fillers use a coarser default
+	// than the scanline path), so only an explicit override is forwarded.
+	if ts, ok := filler.(FlattenToleranceSetter); ok {
+		var tol float32
+		if r.flattenTolerance > 0 {
+			tol = r.effectiveFlattenTolerance()
+		}
+		ts.SetFlattenTolerance(tol)
+	}
+
 	fillRule := FillRuleNonZero
 	if paint.FillRule == FillRuleEvenOdd {
 		fillRule = FillRuleEvenOdd
@@ -440,6 +507,19 @@ func applyMaskCoverage(maskFn func(x, y int) uint8, px, py int, coverage uint8)
 // When rasterizerMode is set (via Context.SetRasterizerMode), the forced
 // algorithm is used instead of auto-selection.
 func (r *SoftwareRenderer) Fill(pixmap *Pixmap, p *Path, paint *Paint) error {
+	r.arena.stats.Fills++
+
+	// Layer-coverage mode: rasterize to a mask first, apply alpha once.
+	if paint.NonOverlappingAlpha {
+		return r.fillNonOverlapping(pixmap, p, paint)
+	}
+
+	// Rect fast path: recognized axis-aligned rectangles with solid paint
+	// bypass edge building entirely (memset/row-copy spans).
+	if r.tryFillRectFast(pixmap, p, paint) {
+		return nil
+	}
+
 	// Non-AA path: completely separate code path (Skia/tiny-skia pattern).
 	// Integer scanline, binary coverage, no CoverageFiller/AnalyticFiller.
 	if !r.antiAlias {
@@ -547,9 +627,7 @@ func (r *SoftwareRenderer) fillNoAA(pixmap *Pixmap, p *Path, paint *Paint) error
 	// Lazy-init the no-AA edge builder and filler.
 	if r.noAAEdgeBuilder == nil {
 		r.noAAEdgeBuilder = raster.NewEdgeBuilder(0) // aaShift=0: no sub-pixel
-		if r.deviceScale > 1.0 {
-			r.noAAEdgeBuilder.SetFlattenTolerance(0.1 / r.deviceScale)
-		}
+		r.noAAEdgeBuilder.SetFlattenTolerance(r.effectiveFlattenTolerance())
 	}
 	if r.noAAFiller == nil {
 		r.noAAFiller = raster.NewNoAAFiller(r.width, r.height)
@@ -899,6 +977,51 @@ func (r *SoftwareRenderer) blendAlphaRunsFromCoreRunsPaint(pixmap *Pixmap, y int
 // Strokes are expanded to fill paths and rendered with the Fill method,
 // which provides analytic anti-aliased results.
 func (r *SoftwareRenderer) Stroke(pixmap *Pixmap, p *Path, paint *Paint) error {
+	// Install the dedicated stroke brush (if any) as the sampling brush for
+	// the expanded fill, restoring the fill brush afterwards. This lets
+	// gradient and pattern stroke brushes flow through the same per-pixel
+	// ColorAt sampling that fills use.
+	if paint.hasStrokeBrush {
+		savedColor, savedSolid, savedBrush := paint.solidColor, paint.isSolid, paint.Brush
+		paint.solidColor, paint.isSolid, paint.Brush = paint.strokeSolidColor, paint.strokeIsSolid, paint.strokeBrush
+		defer func() {
+			paint.solidColor, paint.isSolid, paint.Brush = savedColor, savedSolid, savedBrush
+		}()
+	}
+
+	outline := r.strokeOutline(p, paint)
+
+	// Route stroke fills through AnalyticFiller (Skia AAA scanline).
+	// Stroke-expanded multi-contour outlines (e.g., closed path → 4 contours)
+	// require per-scanline winding tracking that the tile-based SparseStripsFiller
+	// does not support (Vello's strip pipeline uses per-strip fill_gap flags).
+	// This matches Skia Ganesh which routes strokes through scanline renderers,
+	// not tile rasterizers. Single-contour strokes work with either filler after
+	// the expander.go fix (#347), but multi-contour needs scanline.
+	prevMode := r.rasterizerMode
+	r.rasterizerMode = RasterizerAnalytic
+	err := r.Fill(pixmap, outline, paint)
+	r.rasterizerMode = prevMode
+	return err
+}
+
+// strokeOutline expands a path into its stroke outline using the paint's
+// stroke parameters (width, caps, joins, dashes, stroke scaling). The
+// result is the renderer's scratch path, valid until the next stroke.
+func (r *SoftwareRenderer) strokeOutline(p *Path, paint *Paint) *Path {
+	r.arena.stats.Strokes++
+	if r.scratchStrokePath == nil {
+		r.scratchStrokePath = NewPath()
+	}
+	return expandStrokeOutline(&r.arena, r.scratchStrokePath, p, paint,
+		r.deviceScale, float64(r.effectiveFlattenTolerance()))
+}
+
+// expandStrokeOutline is the stroke expansion core shared by the
+// renderer's stroke pipeline and Context.ClipStroke: dash the path,
+// expand it with the paint's stroke parameters, and write the outline
+// into dst (which is reset first).
+func expandStrokeOutline(arena *geometryArena, dst *Path, p *Path, paint *Paint, deviceScale float32, tolerance float64) *Path {
 	// Get effective line width
 	width := paint.EffectiveLineWidth()
 
@@ -909,22 +1032,39 @@ func (r *SoftwareRenderer) Stroke(pixmap *Pixmap, p *Path, paint *Paint) error {
 	}
 
 	// Apply dash pattern if set
-	// Scale dash pattern by transform scale (Cairo/Skia convention)
+	// Scale dash pattern by transform scale (Cairo/Skia convention).
+	// Hairline and non-scaling strokes keep the dash pattern in device
+	// pixels, so the transform does not stretch it.
 	pathToDraw := p
 	if paint.IsDashed() {
 		dash := paint.EffectiveDash()
-		if transformScale > 1.0 {
+		if paint.StrokeScaling == StrokeScalingNormal && transformScale > 1.0 {
 			dash = dash.Scale(transformScale)
 		}
-		pathToDraw = dashPath(p, dash)
+		pathToDraw = dashPathInto(arena.dashPathScratch(), p, dash)
 	}
 
-	// Convert gg.PathVerb to stroke.PathVerb (same layout, just cast)
-	strokeVerbs := convertVerbsToStroke(pathToDraw.Verbs())
+	// Convert gg.PathVerb to stroke.PathVerb (same layout, just cast),
+	// reusing the arena's verb buffer.
+	strokeVerbs := arena.strokeVerbBuf(len(pathToDraw.Verbs()))
+	for i, v := range pathToDraw.Verbs() {
+		strokeVerbs[i] = stroke.PathVerb(v)
+	}
 
 	// Create stroke style from paint
 	// Scale line width by transform scale (path coordinates are already transformed)
-	effectiveWidth := width * transformScale
+	var effectiveWidth float64
+	switch paint.StrokeScaling {
+	case StrokeScalingHairline:
+		// One physical pixel, independent of line width and transform.
+		effectiveWidth = 1.0
+	case StrokeScalingNonScaling:
+		// Line width in device pixels: ignore the user transform but keep
+		// the HiDPI device scale so hairlines stay proportional on retina.
+		effectiveWidth = width * float64(deviceScale)
+	default:
+		effectiveWidth = width * transformScale
+	}
 	if effectiveWidth < 1.0 {
 		effectiveWidth = 1.0 // Minimum 1px stroke for visibility
 	}
@@ -938,46 +1078,19 @@ func (r *SoftwareRenderer) Stroke(pixmap *Pixmap, p *Path, paint *Paint) error {
 		strokeStyle.MiterLimit = 4.0 // Default
 	}
 
-	// Create stroke expander with tight tolerance for smooth curves.
-	// 0.1 px base tolerance; on HiDPI, divide by deviceScale for finer curves.
-	expander := stroke.NewStrokeExpander(strokeStyle)
-	strokeTol := float64(0.1)
-	if r.deviceScale > 1.0 {
-		strokeTol = 0.1 / float64(r.deviceScale)
-	}
-	expander.SetTolerance(strokeTol)
+	// Reuse the arena's stroke expander with tight tolerance for smooth
+	// curves. Uses the caller's flattening tolerance (0.1 px base,
+	// divided by deviceScale on HiDPI) so stroke outlines match fill
+	// smoothness.
+	expander := arena.strokeExpander(strokeStyle)
+	expander.SetTolerance(tolerance)
 
 	// Expand stroke to fill path (SOA: verb+coords in, verb+coords out)
 	outVerbs, outCoords := expander.Expand(strokeVerbs, pathToDraw.Coords())
 
-	// Convert back to gg.Path (reuse scratch to avoid per-stroke allocation).
-	if r.scratchStrokePath == nil {
-		r.scratchStrokePath = NewPath()
-	}
-	strokeResultToPath(r.scratchStrokePath, outVerbs, outCoords)
-
-	// Route stroke fills through AnalyticFiller (Skia AAA scanline).
-	// Stroke-expanded multi-contour outlines (e.g., closed path → 4 contours)
-	// require per-scanline winding tracking that the tile-based SparseStripsFiller
-	// does not support (Vello's strip pipeline uses per-strip fill_gap flags).
-	// This matches Skia Ganesh which routes strokes through scanline renderers,
-	// not tile rasterizers. Single-contour strokes work with either filler after
-	// the expander.go fix (#347), but multi-contour needs scanline.
-	prevMode := r.rasterizerMode
-	r.rasterizerMode = RasterizerAnalytic
-	err := r.Fill(pixmap, r.scratchStrokePath, paint)
-	r.rasterizerMode = prevMode
-	return err
-}
-
-// convertVerbsToStroke converts gg.PathVerb slice to stroke.PathVerb slice.
-// Both types have identical byte values, so this is a simple cast.
-func convertVerbsToStroke(verbs []PathVerb) []stroke.PathVerb {
-	result := make([]stroke.PathVerb, len(verbs))
-	for i, v := range verbs {
-		result[i] = stroke.PathVerb(v)
-	}
-	return result
+	// Convert back to gg.Path (reuse dst to avoid per-stroke allocation).
+	strokeResultToPath(dst, outVerbs, outCoords)
+	return dst
 }
 
 // strokeResultToPath converts stroke output (verbs+coords) into dst Path.
@@ -1028,6 +1141,10 @@ func convertLineJoin(join LineJoin) stroke.LineJoin {
 		return stroke.LineJoinRound
 	case LineJoinBevel:
 		return stroke.LineJoinBevel
+	case LineJoinMiterClip:
+		return stroke.LineJoinMiterClip
+	case LineJoinArcs:
+		return stroke.LineJoinArcs
 	default:
 		return stroke.LineJoinMiter
 	}
@@ -1036,6 +1153,14 @@ func convertLineJoin(join LineJoin) stroke.LineJoin {
 // dashPath converts a path to a dashed path using the given dash pattern.
 // This walks along the path and outputs only the "dash" portions, skipping gaps.
 func dashPath(p *Path, dash *Dash) *Path {
+	return dashPathInto(NewPath(), p, dash)
+}
+
+// dashPathInto is dashPath writing into a caller-provided (already reset)
+// result path, so the stroke pipeline can reuse its arena scratch instead
+// of allocating per dashed stroke. Returns p unchanged when the dash
+// pattern is empty.
+func dashPathInto(result *Path, p *Path, dash *Dash) *Path {
 	if dash == nil || !dash.IsDashed() {
 		return p
 	}
@@ -1045,8 +1170,6 @@ func dashPath(p *Path, dash *Dash) *Path {
 		return p
 	}
 
-	result := NewPath()
-
 	// State for walking along the path
 	var (
 		currentX, currentY float64 // current position
@@ -1135,7 +1258,7 @@ func dashLine(result *Path, currentX, currentY *float64, x, y float64,
 	pattern []float64, patternIdx *int, patternPos *float64, inDash *bool) {
 	dx := x - *currentX
 	dy := y - *currentY
-	segmentLen := math.Sqrt(dx*dx + dy*dy)
+	segmentLen := detmath.Sqrt(dx*dx + dy*dy)
 
 	if segmentLen < 1e-10 {
 		return
@@ -1242,7 +1365,7 @@ func flattenQuadRecForDash(x0, y0, cx, cy, x1, y1, tolerance float64, points *[]
 	my := (y0 + y1) / 2
 	dx := cx - mx
 	dy := cy - my
-	dist := math.Sqrt(dx*dx + dy*dy)
+	dist := detmath.Sqrt(dx*dx + dy*dy)
 
 	if dist < tolerance {
 		*points = append(*points, x1, y1)
@@ -1308,10 +1431,10 @@ func flattenCubicRecForDash(x0, y0, c1x, c1y, c2x, c2y, x1, y1, tolerance float6
 func pointLineDistance(px, py, x0, y0, x1, y1 float64) float64 {
 	dx := x1 - x0
 	dy := y1 - y0
-	length := math.Sqrt(dx*dx + dy*dy)
+	length := detmath.Sqrt(dx*dx + dy*dy)
 	if length < 1e-10 {
 		// Line is a point, return distance to that point
-		return math.Sqrt((px-x0)*(px-x0) + (py-y0)*(py-y0))
+		return detmath.Sqrt((px-x0)*(px-x0) + (py-y0)*(py-y0))
 	}
 	// Cross product gives area of parallelogram, divide by base for height
 	return math.Abs((py-y0)*dx-(px-x0)*dy) / length
@@ -0,0 +1,85 @@
+package gg
+
+import (
+	"github.com/gogpu/gg/internal/stroke"
+)
+
+// geometryArena groups the renderer's reusable per-frame geometry scratch
+// space. The edge builders and fillers already retain their edge and strip
+// buffers across calls; the arena extends the same pattern to the stroke
+// pipeline, which previously allocated a verb buffer, a stroke expander,
+// and (for dashed strokes) a dash output path on every Stroke call.
+//
+// Buffers are truncated but keep their backing arrays between uses, so an
+// animation loop that strokes the same scene every frame reaches a steady
+// state with zero geometry allocations per frame.
+type geometryArena struct {
+	// strokeVerbs backs the gg→stroke verb conversion in strokeOutline.
+	strokeVerbs []stroke.PathVerb
+
+	// expander is the reusable stroke expander; its style is replaced per
+	// stroke via SetStyle while its internal path builders are retained.
+	expander *stroke.StrokeExpander
+
+	// dashScratch receives dashed-stroke output (dashPathInto).
+	dashScratch *Path
+
+	stats RendererStats
+}
+
+// RendererStats counts geometry scratch activity in a SoftwareRenderer,
+// for verifying that animation workloads run allocation-free once warm.
+type RendererStats struct {
+	// Fills and Strokes count rasterization calls served.
+	Fills   uint64
+	Strokes uint64
+
+	// ArenaGrows counts scratch buffer allocations, including growth of
+	// retained buffers. A warmed-up renderer should show this stop rising
+	// while Fills/Strokes keep counting.
+	ArenaGrows uint64
+}
+
+// strokeVerbBuf returns a scratch []stroke.PathVerb of length n, growing
+// the retained buffer only when the capacity is insufficient.
+func (a *geometryArena) strokeVerbBuf(n int) []stroke.PathVerb {
+	if cap(a.strokeVerbs) < n {
+		a.strokeVerbs = make([]stroke.PathVerb, n)
+		a.stats.ArenaGrows++
+	}
+	return a.strokeVerbs[:n]
+}
+
+// strokeExpander returns the reusable expander configured with style.
+func (a *geometryArena) strokeExpander(style stroke.Stroke) *stroke.StrokeExpander {
+	if a.expander == nil {
+		a.expander = stroke.NewStrokeExpander(style)
+		a.stats.ArenaGrows++
+		return a.expander
+	}
+	a.expander.SetStyle(style)
+	return a.expander
+}
+
+// dashPathScratch returns the reusable dash output path, reset and ready
+// for dashPathInto.
+func (a *geometryArena) dashPathScratch() *Path {
+	if a.dashScratch == nil {
+		a.dashScratch = NewPath()
+		a.stats.ArenaGrows++
+	} else {
+		a.dashScratch.Reset()
+	}
+	return a.dashScratch
+}
+
+// Stats returns a snapshot of the renderer's geometry scratch counters.
+func (r *SoftwareRenderer) Stats() RendererStats {
+	return r.arena.stats
+}
+
+// ResetStats zeroes the renderer's geometry scratch counters, starting a
+// new observation period (e.g. after warm-up frames in a benchmark).
+func (r *SoftwareRenderer) ResetStats() {
+	r.arena.stats = RendererStats{}
+}
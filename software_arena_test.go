@@ -0,0 +1,142 @@
+package gg
+
+import (
+	"testing"
+)
+
+// arenaStrokePaint returns a solid paint with the given stroke width.
+func arenaStrokePaint(width float64) *Paint {
+	paint := NewPaint()
+	paint.SetBrush(SolidBrush{Color: Red})
+	paint.LineWidth = width
+	return paint
+}
+
+// TestRendererStatsCounts tests that Fill and Stroke calls are counted.
+func TestRendererStatsCounts(t *testing.T) {
+	r := NewSoftwareRenderer(64, 64)
+	pm := NewPixmap(64, 64)
+
+	p := NewPath()
+	p.MoveTo(10, 10)
+	p.LineTo(50, 10)
+	p.LineTo(50, 50)
+	p.Close()
+
+	if err := r.Fill(pm, p, arenaStrokePaint(2)); err != nil {
+		t.Fatalf("Fill: %v", err)
+	}
+	if err := r.Stroke(pm, p, arenaStrokePaint(2)); err != nil {
+		t.Fatalf("Stroke: %v", err)
+	}
+
+	stats := r.Stats()
+	if stats.Strokes != 1 {
+		t.Errorf("Strokes = %d, want 1", stats.Strokes)
+	}
+	// Stroke rasterizes its expanded outline through Fill, so both calls count.
+	if stats.Fills != 2 {
+		t.Errorf("Fills = %d, want 2", stats.Fills)
+	}
+
+	r.ResetStats()
+	if got := r.Stats(); got != (RendererStats{}) {
+		t.Errorf("stats after reset = %+v, want zero", got)
+	}
+}
+
+// TestStrokeArenaReuse tests that repeated strokes stop growing the arena
+// after the first (warm-up) call.
+func TestStrokeArenaReuse(t *testing.T) {
+	r := NewSoftwareRenderer(64, 64)
+	pm := NewPixmap(64, 64)
+
+	p := NewPath()
+	p.MoveTo(5, 32)
+	p.QuadraticTo(32, 5, 59, 32)
+	paint := arenaStrokePaint(3)
+
+	if err := r.Stroke(pm, p, paint); err != nil {
+		t.Fatalf("warm-up Stroke: %v", err)
+	}
+	warm := r.Stats().ArenaGrows
+
+	for i := 0; i < 10; i++ {
+		if err := r.Stroke(pm, p, paint); err != nil {
+			t.Fatalf("Stroke %d: %v", i, err)
+		}
+	}
+	stats := r.Stats()
+	if stats.ArenaGrows != warm {
+		t.Errorf("ArenaGrows rose from %d to %d across warm strokes", warm, stats.ArenaGrows)
+	}
+	if stats.Strokes != 11 {
+		t.Errorf("Strokes = %d, want 11", stats.Strokes)
+	}
+}
+
+// TestDashArenaReuse tests that dashed strokes reuse the dash scratch path
+// and still produce dashes.
+func TestDashArenaReuse(t *testing.T) {
+	r := NewSoftwareRenderer(64, 64)
+
+	p := NewPath()
+	p.MoveTo(4, 32)
+	p.LineTo(60, 32)
+	paint := arenaStrokePaint(2)
+	paint.Stroke = &Stroke{Width: 2, Dash: NewDash(6, 4)}
+
+	render := func() *Pixmap {
+		pm := NewPixmap(64, 64)
+		if err := r.Stroke(pm, p, paint); err != nil {
+			t.Fatalf("Stroke: %v", err)
+		}
+		return pm
+	}
+
+	first := render()
+	warm := r.Stats().ArenaGrows
+	second := render()
+	if got := r.Stats().ArenaGrows; got != warm {
+		t.Errorf("ArenaGrows rose from %d to %d on second dashed stroke", warm, got)
+	}
+
+	// Dashes present (gap pixel transparent, dash pixel opaque) and the
+	// reused scratch produced identical output.
+	if a := first.GetPixel(6, 32).A; a < 0.5 {
+		t.Errorf("dash segment alpha = %v, want opaque", a)
+	}
+	for x := 0; x < 64; x++ {
+		if first.GetPixel(x, 32) != second.GetPixel(x, 32) {
+			t.Fatalf("pixel (%d,32) differs between identical dashed strokes", x)
+		}
+	}
+}
+
+// TestStrokeOutlineStyleSwitch tests that the reused expander honors
+// per-stroke style changes.
+func TestStrokeOutlineStyleSwitch(t *testing.T) {
+	r := NewSoftwareRenderer(64, 64)
+	pm := NewPixmap(64, 64)
+
+	p := NewPath()
+	p.MoveTo(10, 32)
+	p.LineTo(54, 32)
+
+	if err := r.Stroke(pm, p, arenaStrokePaint(2)); err != nil {
+		t.Fatalf("thin Stroke: %v", err)
+	}
+	thin := pm.GetPixel(32, 36).A
+
+	if err := r.Stroke(pm, p, arenaStrokePaint(12)); err != nil {
+		t.Fatalf("wide Stroke: %v", err)
+	}
+	wide := pm.GetPixel(32, 36).A
+
+	if thin > 0.1 {
+		t.Errorf("2px stroke covers (32,36) with alpha %v, want ~0", thin)
+	}
+	if wide < 0.9 {
+		t.Errorf("12px stroke misses (32,36), alpha %v, want ~1", wide)
+	}
+}
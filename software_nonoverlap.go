@@ -0,0 +1,83 @@
+package gg
+
+import "image"
+
+// Non-overlapping alpha rendering (Paint.NonOverlappingAlpha).
+//
+// Normally coverage is blended into the pixmap as the rasterizer emits
+// it. When geometry folds over itself — a figure-eight stroke outline, a
+// retraced polyline, duplicated subpaths — a backend is free to visit a
+// pixel more than once, and each visit would composite the translucent
+// paint again, darkening the overlap. The layer-coverage mode below
+// removes that freedom: the path is rasterized into an A8 mask first,
+// overlapping coverage saturates in the mask, and the paint's alpha is
+// applied exactly once per pixel when the mask is blended.
+
+// fillNonOverlapping rasterizes p to a coverage mask and composites the
+// mask in a single pass. Overlap saturates at full coverage instead of
+// blending repeatedly, so the result never exceeds the paint's own
+// alpha regardless of how the geometry self-overlaps or which
+// rasterizer backend produced the coverage. Strokes route through Fill
+// after outline expansion and get the same guarantee.
+//
+// The per-pixel clip and mask chain matches the regular blend path:
+// clip mask (or legacy closure) first, then the alpha mask, then
+// premultiplied source-over.
+func (r *SoftwareRenderer) fillNonOverlapping(pixmap *Pixmap, p *Path, paint *Paint) error {
+	// The mask only needs to span the path, grown one pixel for AA
+	// bleed and clamped to the canvas.
+	b := p.Bounds().Inset(-1).Intersect(image.Rect(0, 0, r.width, r.height))
+	if b.Empty() {
+		return nil
+	}
+	w := b.Dx()
+	mask := make([]uint8, w*b.Dy())
+
+	r.FillSpans(p, paint.FillRule, func(y, x0, length int, coverage uint8) {
+		if y < b.Min.Y || y >= b.Max.Y {
+			return
+		}
+		x, end := x0, x0+length
+		if x < b.Min.X {
+			x = b.Min.X
+		}
+		if end > b.Max.X {
+			end = b.Max.X
+		}
+		row := (y-b.Min.Y)*w - b.Min.X
+		for ; x < end; x++ {
+			if coverage > mask[row+x] {
+				mask[row+x] = coverage
+			}
+		}
+	})
+
+	clipFn := paint.ClipCoverage
+	maskFn := paint.MaskCoverage
+	clipMask := paint.ClipMask
+	clipMaskW := paint.ClipMaskW
+	clipMaskX := paint.ClipMaskX
+	clipMaskY := paint.ClipMaskY
+	color, isSolid := solidColorFromPaint(paint)
+
+	for y := b.Min.Y; y < b.Max.Y; y++ {
+		row := (y-b.Min.Y)*w - b.Min.X
+		for x := b.Min.X; x < b.Max.X; x++ {
+			coverage := mask[row+x]
+			if coverage == 0 {
+				continue
+			}
+			coverage = applyClipCoverageFromMaskOrFn(clipMask, clipMaskW, clipMaskX, clipMaskY, clipFn, x, y, coverage)
+			coverage = applyMaskCoverage(maskFn, x, y, coverage)
+			if coverage == 0 {
+				continue
+			}
+			if isSolid {
+				r.blendCoverageSolid(pixmap, x, y, coverage, color)
+			} else {
+				r.blendCoveragePaint(pixmap, x, y, coverage, paint)
+			}
+		}
+	}
+	return nil
+}
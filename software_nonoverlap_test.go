@@ -0,0 +1,97 @@
+package gg
+
+import (
+	"math"
+	"testing"
+)
+
+// TestNonOverlappingAlphaMatchesBaseline tests that the mask-based path
+// produces the same result as the regular blend path for geometry that
+// does not self-overlap.
+func TestNonOverlappingAlphaMatchesBaseline(t *testing.T) {
+	render := func(nonOverlapping bool) *Context {
+		dc := NewContext(64, 64)
+		dc.ClearWithColor(RGB(1, 1, 1))
+		dc.SetNonOverlappingAlpha(nonOverlapping)
+		dc.SetRGBA(0.2, 0.4, 0.8, 0.5)
+		dc.DrawCircle(32, 32, 20)
+		if err := dc.Fill(); err != nil {
+			t.Fatalf("Fill: %v", err)
+		}
+		return dc
+	}
+
+	base := render(false)
+	defer base.Close()
+	masked := render(true)
+	defer masked.Close()
+
+	for y := 0; y < 64; y++ {
+		for x := 0; x < 64; x++ {
+			want := base.pixmap.GetPixel(x, y)
+			got := masked.pixmap.GetPixel(x, y)
+			if math.Abs(got.R-want.R) > 0.01 || math.Abs(got.G-want.G) > 0.01 ||
+				math.Abs(got.B-want.B) > 0.01 || math.Abs(got.A-want.A) > 0.01 {
+				t.Fatalf("pixel (%d,%d): got %+v, want %+v", x, y, got, want)
+			}
+		}
+	}
+}
+
+// TestNonOverlappingAlphaStroke tests that a translucent self-overlapping
+// stroke never exceeds the paint's own alpha in layer-coverage mode.
+func TestNonOverlappingAlphaStroke(t *testing.T) {
+	const paintAlpha = 0.5
+	dc := NewContext(64, 64)
+	defer dc.Close()
+	dc.SetNonOverlappingAlpha(true)
+	dc.SetRGBA(1, 0, 0, paintAlpha)
+	dc.SetLineWidth(8)
+	// Figure-eight: the expanded outline folds over itself at the crossing.
+	dc.MoveTo(10, 10)
+	dc.CubicTo(54, 10, 10, 54, 54, 54)
+	dc.CubicTo(10, 54, 54, 10, 10, 10)
+	dc.ClosePath()
+	if err := dc.Stroke(); err != nil {
+		t.Fatalf("Stroke: %v", err)
+	}
+
+	for y := 0; y < 64; y++ {
+		for x := 0; x < 64; x++ {
+			if a := dc.pixmap.GetPixel(x, y).A; a > paintAlpha+0.01 {
+				t.Fatalf("pixel (%d,%d) alpha %.3f exceeds paint alpha %.3f", x, y, a, paintAlpha)
+			}
+		}
+	}
+}
+
+// TestNonOverlappingAlphaRespectsClip tests that the single-pass blend
+// still applies the clip mask.
+func TestNonOverlappingAlphaRespectsClip(t *testing.T) {
+	dc := NewContext(64, 64)
+	defer dc.Close()
+	dc.SetNonOverlappingAlpha(true)
+	dc.DrawCircle(32, 32, 10)
+	dc.Clip()
+	dc.SetRGBA(0, 1, 0, 1)
+	dc.DrawRectangle(0, 0, 64, 64)
+	if err := dc.Fill(); err != nil {
+		t.Fatalf("Fill: %v", err)
+	}
+
+	if a := dc.pixmap.GetPixel(32, 32).A; a < 0.99 {
+		t.Errorf("inside clip: alpha %.3f, want 1", a)
+	}
+	if a := dc.pixmap.GetPixel(2, 2).A; a > 0.01 {
+		t.Errorf("outside clip: alpha %.3f, want 0", a)
+	}
+}
+
+// TestNonOverlappingAlphaClone tests that Clone preserves the flag.
+func TestNonOverlappingAlphaClone(t *testing.T) {
+	p := NewPaint()
+	p.NonOverlappingAlpha = true
+	if !p.Clone().NonOverlappingAlpha {
+		t.Error("Clone dropped NonOverlappingAlpha")
+	}
+}
@@ -0,0 +1,182 @@
+package gg
+
+import (
+	"math"
+	"testing"
+)
+
+// solidPaint returns a fill paint with the given solid color.
+func solidPaint(c RGBA) *Paint {
+	paint := NewPaint()
+	paint.SetBrush(SolidBrush{Color: c})
+	return paint
+}
+
+// renderRectBoth fills the same path on two pixmaps: once with the fast path
+// eligible (RasterizerAuto, no registered CoverageFiller matters here) and
+// once with the analytic filler forced as reference.
+func renderRectBoth(t *testing.T, p *Path, paint *Paint, bg RGBA) (fast, ref *Pixmap) {
+	t.Helper()
+	const size = 60
+
+	fast = NewPixmap(size, size)
+	fast.Clear(bg)
+	rFast := NewSoftwareRenderer(size, size)
+	if err := rFast.Fill(fast, p, paint); err != nil {
+		t.Fatal(err)
+	}
+
+	ref = NewPixmap(size, size)
+	ref.Clear(bg)
+	rRef := NewSoftwareRenderer(size, size)
+	rRef.SetRasterizerMode(RasterizerAnalytic)
+	if err := rRef.Fill(ref, p, paint); err != nil {
+		t.Fatal(err)
+	}
+	return fast, ref
+}
+
+func TestRectFastPathOpaqueMatchesAnalytic(t *testing.T) {
+	p := NewPath()
+	p.Rectangle(10, 12, 30, 25)
+
+	fast, ref := renderRectBoth(t, p, solidPaint(RGBA{R: 0.2, G: 0.4, B: 0.8, A: 1}), White)
+
+	a, b := fast.Data(), ref.Data()
+	for i := range a {
+		if a[i] != b[i] {
+			t.Fatalf("fast path diverges from analytic filler at byte %d: %d vs %d", i, a[i], b[i])
+		}
+	}
+}
+
+func TestRectFastPathTranslucentMatchesAnalytic(t *testing.T) {
+	p := NewPath()
+	p.Rectangle(5, 5, 40, 40)
+
+	fast, ref := renderRectBoth(t, p, solidPaint(RGBA{R: 1, G: 0, B: 0, A: 0.4}), White)
+
+	// The fast path blends in 8-bit integer space; allow 1 LSB of rounding
+	// difference against the float blend of the analytic path.
+	a, b := fast.Data(), ref.Data()
+	for i := range a {
+		d := int(a[i]) - int(b[i])
+		if d < -1 || d > 1 {
+			t.Fatalf("translucent blend off by %d at byte %d: %d vs %d", d, i, a[i], b[i])
+		}
+	}
+}
+
+func TestRectFastPathRefusals(t *testing.T) {
+	r := NewSoftwareRenderer(60, 60)
+	pm := NewPixmap(60, 60)
+	solid := solidPaint(RGBA{A: 1})
+
+	rect := NewPath()
+	rect.Rectangle(10, 10, 20, 20)
+
+	// Fractional edges with AA need the analytic fringe.
+	frac := NewPath()
+	frac.Rectangle(10.3, 10.3, 20.4, 20.4)
+	if r.tryFillRectFast(pm, frac, solid) {
+		t.Error("fractional rect with AA should not take the fast path")
+	}
+
+	// Non-rect shapes are not handled.
+	circle := NewPath()
+	circle.Circle(30, 30, 10)
+	if r.tryFillRectFast(pm, circle, solid) {
+		t.Error("circle should not take the fast path")
+	}
+
+	// Gradient paints need per-pixel color sampling.
+	grad := NewPaint()
+	grad.SetBrush(NewLinearGradientBrush(0, 0, 60, 0).
+		AddColorStop(0, RGBA{R: 1, A: 1}).
+		AddColorStop(1, RGBA{B: 1, A: 1}))
+	if r.tryFillRectFast(pm, rect, grad) {
+		t.Error("gradient paint should not take the fast path")
+	}
+
+	// Forced rasterizer modes must exercise their filler.
+	r.SetRasterizerMode(RasterizerAnalytic)
+	if r.tryFillRectFast(pm, rect, solid) {
+		t.Error("forced rasterizer mode should not take the fast path")
+	}
+	r.SetRasterizerMode(RasterizerAuto)
+
+	// Per-pixel clip coverage cannot be applied to whole-row writes.
+	clipped := solidPaint(RGBA{A: 1})
+	clipped.ClipCoverage = func(x, y float64) byte { return 128 }
+	if r.tryFillRectFast(pm, rect, clipped) {
+		t.Error("paint with clip coverage should not take the fast path")
+	}
+
+	// Eligible case sanity check: integer rect, solid color, auto mode.
+	if !r.tryFillRectFast(pm, rect, solid) {
+		t.Error("integer rect with solid paint should take the fast path")
+	}
+}
+
+func TestRectFastPathNoAAFractionalRect(t *testing.T) {
+	// Without AA, fractional edges round to the pixels whose centers fall
+	// inside the rect: [10.4, 30.6) x [10.4, 30.6) covers [10, 31) x [10, 31).
+	pm := NewPixmap(60, 60)
+	pm.Clear(White)
+	r := NewSoftwareRenderer(60, 60)
+	r.SetAntiAlias(false)
+
+	p := NewPath()
+	p.Rectangle(10.4, 10.4, 20.2, 20.2)
+	if err := r.Fill(pm, p, solidPaint(RGBA{A: 1})); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, x := range []int{10, 30} {
+		if c := pm.GetPixel(x, 15); c.R > 0.05 {
+			t.Errorf("column x=%d R = %v, want filled", x, c.R)
+		}
+	}
+	for _, x := range []int{9, 31} {
+		if c := pm.GetPixel(x, 15); c.R < 0.95 {
+			t.Errorf("column x=%d R = %v, want background", x, c.R)
+		}
+	}
+}
+
+func TestRectFastPathRespectsClipBounds(t *testing.T) {
+	pm := NewPixmap(60, 60)
+	pm.Clear(White)
+	r := NewSoftwareRenderer(60, 60)
+	r.SetClipBounds(20, 20, 40, 40)
+
+	p := NewPath()
+	p.Rectangle(10, 10, 40, 40)
+	if err := r.Fill(pm, p, solidPaint(RGBA{A: 1})); err != nil {
+		t.Fatal(err)
+	}
+
+	if c := pm.GetPixel(30, 30); c.R > 0.05 {
+		t.Errorf("pixel inside clip R = %v, want filled", c.R)
+	}
+	for _, pt := range [][2]int{{15, 15}, {45, 30}, {30, 45}} {
+		if c := pm.GetPixel(pt[0], pt[1]); c.R < 0.95 {
+			t.Errorf("pixel (%d,%d) outside clip R = %v, want untouched", pt[0], pt[1], c.R)
+		}
+	}
+}
+
+func TestRectFastPathTransparentColorIsNoOp(t *testing.T) {
+	pm := NewPixmap(60, 60)
+	pm.Clear(White)
+	r := NewSoftwareRenderer(60, 60)
+
+	p := NewPath()
+	p.Rectangle(10, 10, 20, 20)
+	if !r.tryFillRectFast(pm, p, solidPaint(RGBA{R: 1, A: 0})) {
+		t.Fatal("fully transparent solid rect should be handled by the fast path")
+	}
+	if c := pm.GetPixel(15, 15); math.Abs(c.R-1) > 1e-9 {
+		t.Errorf("transparent fill changed destination: R = %v", c.R)
+	}
+}
@@ -1,6 +1,10 @@
 package gg
 
-import "math"
+import (
+	"math"
+
+	"github.com/gogpu/gg/internal/detmath"
+)
 
 // Polynomial root solvers for quadratic and cubic equations.
 // These are used for curve operations like finding extrema and intersections.
@@ -50,7 +54,7 @@ func solveQuadraticNormal(sc0, sc1 float64) []float64 {
 	// Two distinct roots
 	// Use numerically stable formula to avoid cancellation
 	// See: https://math.stackexchange.com/questions/866331
-	root1 := -0.5 * (sc1 + math.Copysign(math.Sqrt(arg), sc1))
+	root1 := -0.5 * (sc1 + math.Copysign(detmath.Sqrt(arg), sc1))
 	root2 := sc0 / root1
 
 	if !isFinite(root2) {
@@ -132,25 +136,25 @@ func SolveCubic(a, b, c, d float64) []float64 {
 
 	if disc < 0.0 {
 		// One real root
-		sq := math.Sqrt(-0.25 * disc)
+		sq := detmath.Sqrt(-0.25 * disc)
 		r := -0.5 * de
 		t1 := math.Cbrt(r+sq) + math.Cbrt(r-sq)
 		return []float64{t1 - c2}
 	} else if disc == 0.0 {
 		// Two real roots (one is a double root)
-		t1 := math.Copysign(math.Sqrt(-d0), de)
+		t1 := math.Copysign(detmath.Sqrt(-d0), de)
 		return []float64{t1 - c2, -2.0*t1 - c2}
 	}
 
 	// Three distinct real roots
-	th := math.Atan2(math.Sqrt(disc), -de) * oneThird
+	th := math.Atan2(detmath.Sqrt(disc), -de) * oneThird
 	thSin, thCos := math.Sincos(th)
 
 	r0 := thCos
-	ss3 := thSin * math.Sqrt(3.0)
+	ss3 := thSin * detmath.Sqrt(3.0)
 	r1 := 0.5 * (-thCos + ss3)
 	r2 := 0.5 * (-thCos - ss3)
-	t := 2.0 * math.Sqrt(-d0)
+	t := 2.0 * detmath.Sqrt(-d0)
 
 	return []float64{
 		t*r0 - c2,
@@ -0,0 +1,142 @@
+package gg
+
+import (
+	"unsafe"
+
+	"github.com/gogpu/gg/internal/raster"
+)
+
+// Run-length span output.
+//
+// The core rasterizer produces coverage internally as run-length spans
+// (raster.AlphaRuns, the tiny-skia RLE scanline layout). FillSpans exposes
+// those spans through a callback so custom spanners — A8 masks, hit-count
+// maps, GPU span buffers, non-RGBA pixel formats — can consume the
+// rasterizer's output directly instead of forking it or round-tripping
+// through a Pixmap.
+
+// CoverageSpanFunc consumes one horizontal run of constant coverage produced by the
+// rasterizer. y is the scanline, x the starting column, length the run
+// length in pixels, and coverage the anti-aliased coverage (0–255) shared by
+// every pixel of the run. Spans arrive top to bottom, left to right within a
+// scanline, never overlap, and zero-coverage gaps are not reported.
+type CoverageSpanFunc func(y, x, length int, coverage uint8)
+
+// FillSpans rasterizes the path and streams the resulting coverage spans to
+// fn instead of blending into a pixmap. The renderer's dimensions bound the
+// span grid, and its anti-alias state selects the filler: analytic AA
+// produces fractional edge coverage, non-AA produces binary (255) spans.
+// Clip bounds set via SetClipBounds apply to the analytic path as scanline
+// skipping, matching Fill.
+func (r *SoftwareRenderer) FillSpans(p *Path, fillRule FillRule, fn CoverageSpanFunc) {
+	if !r.antiAlias {
+		r.fillSpansNoAA(p, fillRule, fn)
+		return
+	}
+
+	r.edgeBuilder.Reset()
+	r.analyticFiller.Reset()
+
+	// Same canvas clip as Fill: prevents FDot6→FDot16 overflow (RAST-010).
+	clipMargin := float32(2)
+	clipRect := raster.Rect{
+		MinX: -clipMargin,
+		MinY: -clipMargin,
+		MaxX: float32(r.width) + clipMargin,
+		MaxY: float32(r.height) + clipMargin,
+	}
+	r.edgeBuilder.SetClipRect(&clipRect)
+
+	r.edgeBuilder.SetFlattenCurves(true)
+	defer r.edgeBuilder.SetFlattenCurves(false)
+
+	verbs := p.Verbs()
+	if len(verbs) > 0 {
+		verbBytes := unsafe.Slice((*byte)(unsafe.Pointer(unsafe.SliceData(verbs))), len(verbs))
+		r.edgeBuilder.BuildFromPathF64(verbBytes, p.Coords())
+	}
+	if r.edgeBuilder.IsEmpty() {
+		return
+	}
+
+	coreFillRule := raster.FillRuleNonZero
+	if fillRule == FillRuleEvenOdd {
+		coreFillRule = raster.FillRuleEvenOdd
+	}
+
+	r.analyticFiller.Fill(r.edgeBuilder, coreFillRule, func(y int, runs *raster.AlphaRuns) {
+		emitRuns(y, runs, fn)
+	})
+}
+
+// fillSpansNoAA emits binary-coverage spans from the NoAAFiller.
+func (r *SoftwareRenderer) fillSpansNoAA(p *Path, fillRule FillRule, fn CoverageSpanFunc) {
+	if r.noAAEdgeBuilder == nil {
+		r.noAAEdgeBuilder = raster.NewEdgeBuilder(0)
+		r.noAAEdgeBuilder.SetFlattenTolerance(r.effectiveFlattenTolerance())
+	}
+	if r.noAAFiller == nil {
+		r.noAAFiller = raster.NewNoAAFiller(r.width, r.height)
+	}
+
+	r.noAAEdgeBuilder.Reset()
+
+	clipMargin := float32(2)
+	clipRect := raster.Rect{
+		MinX: -clipMargin,
+		MinY: -clipMargin,
+		MaxX: float32(r.width) + clipMargin,
+		MaxY: float32(r.height) + clipMargin,
+	}
+	r.noAAEdgeBuilder.SetClipRect(&clipRect)
+
+	r.noAAEdgeBuilder.SetFlattenCurves(true)
+	defer r.noAAEdgeBuilder.SetFlattenCurves(false)
+
+	verbs := p.Verbs()
+	if len(verbs) > 0 {
+		verbBytes := unsafe.Slice((*byte)(unsafe.Pointer(unsafe.SliceData(verbs))), len(verbs))
+		r.noAAEdgeBuilder.BuildFromPathF64(verbBytes, p.Coords())
+	}
+	if r.noAAEdgeBuilder.IsEmpty() {
+		return
+	}
+
+	coreFillRule := raster.FillRuleNonZero
+	if fillRule == FillRuleEvenOdd {
+		coreFillRule = raster.FillRuleEvenOdd
+	}
+
+	r.noAAFiller.Fill(r.noAAEdgeBuilder, coreFillRule, func(y, left, spanWidth int) {
+		fn(y, left, spanWidth, 255)
+	})
+}
+
+// emitRuns streams one scanline's alpha runs to fn, skipping zero-coverage
+// gaps and coalescing adjacent runs that share a coverage value (breakRun
+// splits can leave equal neighbors).
+func emitRuns(y int, runs *raster.AlphaRuns, fn CoverageSpanFunc) {
+	pendingX, pendingLen := 0, 0
+	var pendingAlpha uint8
+	for run := range runs.IterRuns() {
+		if run.Alpha == pendingAlpha && pendingLen > 0 && run.X == pendingX+pendingLen {
+			pendingLen += run.Count
+			continue
+		}
+		if pendingLen > 0 && pendingAlpha > 0 {
+			fn(y, pendingX, pendingLen, pendingAlpha)
+		}
+		pendingX, pendingLen, pendingAlpha = run.X, run.Count, run.Alpha
+	}
+	if pendingLen > 0 && pendingAlpha > 0 {
+		fn(y, pendingX, pendingLen, pendingAlpha)
+	}
+}
+
+// FillSpans rasterizes p into coverage spans for a width×height grid using a
+// temporary software renderer. For repeated rasterization, create a
+// SoftwareRenderer once and call its FillSpans method to reuse the edge
+// builder and filler buffers across calls.
+func FillSpans(p *Path, width, height int, fillRule FillRule, fn CoverageSpanFunc) {
+	NewSoftwareRenderer(width, height).FillSpans(p, fillRule, fn)
+}
@@ -0,0 +1,143 @@
+package gg
+
+import "testing"
+
+type recordedSpan struct {
+	y, x, length int
+	coverage     uint8
+}
+
+// collectSpans gathers every span the rasterizer emits for a path.
+func collectSpans(p *Path, width, height int, fillRule FillRule) []recordedSpan {
+	var spans []recordedSpan
+	FillSpans(p, width, height, fillRule, func(y, x, length int, coverage uint8) {
+		spans = append(spans, recordedSpan{y, x, length, coverage})
+	})
+	return spans
+}
+
+func TestFillSpansIntegerRect(t *testing.T) {
+	p := NewPath()
+	p.Rectangle(10, 10, 20, 5)
+
+	spans := collectSpans(p, 60, 60, FillRuleNonZero)
+	if len(spans) != 5 {
+		t.Fatalf("got %d spans for a 5-row rect, want 5: %+v", len(spans), spans)
+	}
+	for i, s := range spans {
+		want := recordedSpan{y: 10 + i, x: 10, length: 20, coverage: 255}
+		if s != want {
+			t.Errorf("span %d = %+v, want %+v", i, s, want)
+		}
+	}
+}
+
+func TestFillSpansOrderingAndNoOverlap(t *testing.T) {
+	p := NewPath()
+	p.Circle(30, 30, 20)
+
+	spans := collectSpans(p, 60, 60, FillRuleNonZero)
+	if len(spans) == 0 {
+		t.Fatal("no spans emitted for a circle")
+	}
+	for i := 1; i < len(spans); i++ {
+		prev, cur := spans[i-1], spans[i]
+		if cur.y < prev.y {
+			t.Fatalf("spans not top-to-bottom: %+v after %+v", cur, prev)
+		}
+		if cur.y == prev.y {
+			if cur.x < prev.x+prev.length {
+				t.Fatalf("spans overlap on scanline %d: %+v after %+v", cur.y, cur, prev)
+			}
+			if cur.x == prev.x+prev.length && cur.coverage == prev.coverage {
+				t.Fatalf("adjacent spans not coalesced: %+v after %+v", cur, prev)
+			}
+		}
+	}
+	for _, s := range spans {
+		if s.coverage == 0 {
+			t.Fatalf("zero-coverage span emitted: %+v", s)
+		}
+		if s.length <= 0 {
+			t.Fatalf("non-positive span length: %+v", s)
+		}
+	}
+}
+
+func TestFillSpansMatchesFillCoverage(t *testing.T) {
+	// Rendering opaque black onto a transparent pixmap leaves the coverage
+	// in the alpha channel; spans must reconstruct the same coverage map.
+	const size = 60
+	p := NewPath()
+	p.Circle(30, 30, 18)
+
+	pm := NewPixmap(size, size)
+	r := NewSoftwareRenderer(size, size)
+	if err := r.Fill(pm, p, solidPaint(RGBA{A: 1})); err != nil {
+		t.Fatal(err)
+	}
+
+	var fromSpans [size * size]uint8
+	r.FillSpans(p, FillRuleNonZero, func(y, x, length int, coverage uint8) {
+		for i := 0; i < length; i++ {
+			fromSpans[y*size+x+i] = coverage
+		}
+	})
+
+	data := pm.Data()
+	for i := 0; i < size*size; i++ {
+		got := int(fromSpans[i])
+		want := int(data[i*4+3])
+		if d := got - want; d < -1 || d > 1 {
+			t.Fatalf("coverage mismatch at pixel %d: spans %d, fill %d", i, got, want)
+		}
+	}
+}
+
+func TestFillSpansEvenOdd(t *testing.T) {
+	// Nested rects under even-odd leave a hole: the middle scanlines must
+	// split into two spans.
+	p := NewPath()
+	p.Rectangle(10, 10, 40, 40)
+	p.Rectangle(20, 20, 20, 20)
+
+	var middle []recordedSpan
+	FillSpans(p, 60, 60, FillRuleEvenOdd, func(y, x, length int, coverage uint8) {
+		if y == 30 {
+			middle = append(middle, recordedSpan{y, x, length, coverage})
+		}
+	})
+
+	if len(middle) != 2 {
+		t.Fatalf("got %d spans on the hole scanline, want 2: %+v", len(middle), middle)
+	}
+	left, right := middle[0], middle[1]
+	if left.x != 10 || left.length != 10 || right.x != 40 || right.length != 10 {
+		t.Errorf("hole spans = %+v and %+v, want [10,20) and [40,50)", left, right)
+	}
+}
+
+func TestFillSpansNoAABinaryCoverage(t *testing.T) {
+	r := NewSoftwareRenderer(60, 60)
+	r.SetAntiAlias(false)
+
+	p := NewPath()
+	p.Circle(30, 30, 15)
+
+	count := 0
+	r.FillSpans(p, FillRuleNonZero, func(y, x, length int, coverage uint8) {
+		count++
+		if coverage != 255 {
+			t.Fatalf("non-AA span has coverage %d, want 255", coverage)
+		}
+	})
+	if count == 0 {
+		t.Fatal("no spans emitted without anti-aliasing")
+	}
+}
+
+func TestFillSpansEmptyPath(t *testing.T) {
+	if spans := collectSpans(NewPath(), 60, 60, FillRuleNonZero); len(spans) != 0 {
+		t.Errorf("empty path emitted %d spans", len(spans))
+	}
+}
@@ -0,0 +1,188 @@
+package gg
+
+import "testing"
+
+// --- Paint stroke brush tests ---
+
+func TestPaintStrokeBrushFallsBackToFill(t *testing.T) {
+	p := NewPaint()
+	grad := NewLinearGradientBrush(0, 0, 100, 0).
+		AddColorStop(0, Red).
+		AddColorStop(1, Blue)
+	p.SetBrush(grad)
+
+	if p.HasStrokeBrush() {
+		t.Error("HasStrokeBrush() = true before SetStrokeBrush")
+	}
+	if got := p.StrokeColorAt(0, 0); !colorsEqual(got, Red, gradientEpsilon) {
+		t.Errorf("StrokeColorAt(0,0) = %+v, want Red (fill brush fallback)", got)
+	}
+	if _, solid := p.StrokeSolidColor(); solid {
+		t.Error("StrokeSolidColor() solid = true for gradient fill brush fallback")
+	}
+}
+
+func TestPaintStrokeBrushIndependentOfFill(t *testing.T) {
+	p := NewPaint()
+	p.SetBrush(Solid(Red))
+	grad := NewLinearGradientBrush(0, 0, 100, 0).
+		AddColorStop(0, Green).
+		AddColorStop(1, Blue)
+	p.SetStrokeBrush(grad)
+
+	// Fill side unchanged.
+	if c, ok := p.SolidColor(); !ok || c != Red {
+		t.Errorf("SolidColor() = %v, %v, want Red, true", c, ok)
+	}
+	// Stroke side samples the gradient.
+	if !p.HasStrokeBrush() {
+		t.Error("HasStrokeBrush() = false after SetStrokeBrush")
+	}
+	if _, solid := p.StrokeSolidColor(); solid {
+		t.Error("StrokeSolidColor() solid = true for gradient stroke brush")
+	}
+	if got := p.StrokeColorAt(100, 0); !colorsEqual(got, Blue, gradientEpsilon) {
+		t.Errorf("StrokeColorAt(100,0) = %+v, want Blue", got)
+	}
+
+	// Clearing restores the fill fallback.
+	p.SetStrokeBrush(nil)
+	if p.HasStrokeBrush() {
+		t.Error("HasStrokeBrush() = true after SetStrokeBrush(nil)")
+	}
+	if c, solid := p.StrokeSolidColor(); !solid || c != Red {
+		t.Errorf("StrokeSolidColor() after clear = %v, %v, want Red, true", c, solid)
+	}
+}
+
+func TestPaintStrokeBrushSolidInline(t *testing.T) {
+	p := NewPaint()
+	p.SetBrush(Solid(Red))
+	p.SetStrokeBrush(Solid(Blue))
+
+	if c, solid := p.StrokeSolidColor(); !solid || c != Blue {
+		t.Errorf("StrokeSolidColor() = %v, %v, want Blue, true", c, solid)
+	}
+	if got := p.GetStrokeBrush().ColorAt(0, 0); got != Blue {
+		t.Errorf("GetStrokeBrush().ColorAt = %v, want Blue", got)
+	}
+	if c, ok := p.SolidColor(); !ok || c != Red {
+		t.Errorf("SolidColor() = %v, %v, want Red, true", c, ok)
+	}
+}
+
+func TestPaintCloneCopiesStrokeBrush(t *testing.T) {
+	p := NewPaint()
+	p.SetStrokeBrush(Solid(Cyan))
+
+	clone := p.Clone()
+	if c, solid := clone.StrokeSolidColor(); !solid || c != Cyan {
+		t.Errorf("clone StrokeSolidColor() = %v, %v, want Cyan, true", c, solid)
+	}
+}
+
+// --- Context stroke brush rendering tests ---
+
+func TestContextStrokeLinearGradient(t *testing.T) {
+	dc := NewContext(100, 100)
+	defer func() { _ = dc.Close() }()
+
+	dc.ClearWithColor(White)
+	grad := NewLinearGradientBrush(0, 0, 100, 0).
+		AddColorStop(0, Red).
+		AddColorStop(1, Blue)
+	dc.SetStrokeBrush(grad)
+	dc.SetLineWidth(10)
+	dc.MoveTo(5, 50)
+	dc.LineTo(95, 50)
+	if err := dc.Stroke(); err != nil {
+		t.Fatal(err)
+	}
+
+	// Left end reddish, right end bluish.
+	left := dc.pixmap.GetPixel(10, 50)
+	if left.R < 0.5 || left.B > 0.5 {
+		t.Errorf("left end = %+v, want reddish", left)
+	}
+	right := dc.pixmap.GetPixel(90, 50)
+	if right.B < 0.5 || right.R > 0.5 {
+		t.Errorf("right end = %+v, want bluish", right)
+	}
+}
+
+func TestContextStrokeAndFillDifferentBrushes(t *testing.T) {
+	dc := NewContext(100, 100)
+	defer func() { _ = dc.Close() }()
+
+	dc.ClearWithColor(White)
+	dc.SetFillBrush(Solid(Blue))
+	dc.SetStrokeBrush(Solid(Red))
+	dc.SetLineWidth(6)
+	dc.DrawCircle(50, 50, 30)
+	if err := dc.FillPreserve(); err != nil {
+		t.Fatal(err)
+	}
+	if err := dc.Stroke(); err != nil {
+		t.Fatal(err)
+	}
+
+	// Interior filled blue, outline stroked red.
+	center := dc.pixmap.GetPixel(50, 50)
+	if center.B < 0.9 || center.R > 0.1 {
+		t.Errorf("circle center = %+v, want blue fill", center)
+	}
+	edge := dc.pixmap.GetPixel(80, 50)
+	if edge.R < 0.9 || edge.B > 0.1 {
+		t.Errorf("circle edge = %+v, want red stroke", edge)
+	}
+}
+
+func TestContextSetRGBClearsStrokeBrush(t *testing.T) {
+	dc := NewContext(100, 100)
+	defer func() { _ = dc.Close() }()
+
+	dc.ClearWithColor(White)
+	grad := NewLinearGradientBrush(0, 0, 100, 0).
+		AddColorStop(0, Red).
+		AddColorStop(1, Blue)
+	dc.SetStrokeBrush(grad)
+	dc.SetRGB(0, 1, 0)
+	dc.SetLineWidth(8)
+	dc.MoveTo(10, 50)
+	dc.LineTo(90, 50)
+	if err := dc.Stroke(); err != nil {
+		t.Fatal(err)
+	}
+
+	// SetRGB sets the color for all operations — the gradient is gone.
+	p := dc.pixmap.GetPixel(50, 50)
+	if p.G < 0.9 || p.R > 0.1 || p.B > 0.1 {
+		t.Errorf("stroke after SetRGB = %+v, want green", p)
+	}
+}
+
+func TestContextStrokeRadialGradientDashed(t *testing.T) {
+	// Dashed strokes go through dashPath before expansion; the gradient
+	// must still be sampled in user space along the full line.
+	dc := NewContext(100, 100)
+	defer func() { _ = dc.Close() }()
+
+	dc.ClearWithColor(White)
+	grad := NewRadialGradientBrush(50, 50, 0, 60)
+	grad.AddColorStop(0, Red)
+	grad.AddColorStop(1, Blue)
+	dc.SetStrokeBrush(grad)
+	dc.SetLineWidth(10)
+	dc.SetDash(12, 4)
+	dc.MoveTo(5, 50)
+	dc.LineTo(95, 50)
+	if err := dc.Stroke(); err != nil {
+		t.Fatal(err)
+	}
+
+	// The dash over the center samples the gradient center.
+	center := dc.pixmap.GetPixel(50, 50)
+	if center.R < 0.5 {
+		t.Errorf("dash at gradient center = %+v, want reddish", center)
+	}
+}
@@ -0,0 +1,67 @@
+package gg
+
+import (
+	"math"
+	"testing"
+)
+
+// strokedWidth draws a vertical line under a 4x scale transform with the
+// given line width and scaling mode, then measures the painted width by
+// summing alpha coverage along a horizontal scanline (robust to AA edges).
+func strokedWidth(t *testing.T, lineWidth float64, mode StrokeScaling) float64 {
+	t.Helper()
+	dc := NewContext(100, 100)
+	dc.Scale(4, 4)
+	dc.SetRGB(1, 0, 0)
+	dc.SetLineWidth(lineWidth)
+	dc.SetStrokeScaling(mode)
+	dc.MoveTo(12.5, 0)
+	dc.LineTo(12.5, 25)
+	if err := dc.Stroke(); err != nil {
+		t.Fatalf("Stroke failed: %v", err)
+	}
+	var sum float64
+	for x := 0; x < 100; x++ {
+		sum += dc.pixmap.GetPixel(x, 50).A
+	}
+	return sum
+}
+
+func TestStrokeScalingNormal(t *testing.T) {
+	// 2px line width under 4x scale: ~8 device pixels.
+	got := strokedWidth(t, 2, StrokeScalingNormal)
+	if math.Abs(got-8) > 1 {
+		t.Errorf("normal stroke coverage = %.2f, want ~8", got)
+	}
+}
+
+func TestStrokeScalingHairline(t *testing.T) {
+	// Hairline ignores both line width and transform: ~1 device pixel.
+	got := strokedWidth(t, 10, StrokeScalingHairline)
+	if math.Abs(got-1) > 0.6 {
+		t.Errorf("hairline stroke coverage = %.2f, want ~1", got)
+	}
+}
+
+func TestStrokeScalingNonScaling(t *testing.T) {
+	// Non-scaling keeps the 3px line width in device pixels despite 4x scale.
+	got := strokedWidth(t, 3, StrokeScalingNonScaling)
+	if math.Abs(got-3) > 1 {
+		t.Errorf("non-scaling stroke coverage = %.2f, want ~3", got)
+	}
+}
+
+func TestStrokeScalingClone(t *testing.T) {
+	p := NewPaint()
+	p.StrokeScaling = StrokeScalingHairline
+	if p.Clone().StrokeScaling != StrokeScalingHairline {
+		t.Error("Clone did not copy StrokeScaling")
+	}
+}
+
+func TestStrokeScalingDefault(t *testing.T) {
+	dc := NewContext(10, 10)
+	if dc.GetStrokeScaling() != StrokeScalingNormal {
+		t.Errorf("default stroke scaling = %v, want StrokeScalingNormal", dc.GetStrokeScaling())
+	}
+}
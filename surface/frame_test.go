@@ -0,0 +1,160 @@
+// Copyright 2026 The gogpu Authors
+// SPDX-License-Identifier: MIT
+
+package surface
+
+import (
+	"image"
+	"image/color"
+	"testing"
+)
+
+// frameMockBackend implements GPUBackend and records calls.
+type frameMockBackend struct {
+	flushCalls int
+}
+
+func (b *frameMockBackend) Clear(_ color.Color)                                   {}
+func (b *frameMockBackend) Fill(_ *Path, _ FillStyle)                             {}
+func (b *frameMockBackend) Stroke(_ *Path, _ StrokeStyle)                         {}
+func (b *frameMockBackend) DrawImage(_ image.Image, _ Point, _ *DrawImageOptions) {}
+func (b *frameMockBackend) Flush() error                                          { b.flushCalls++; return nil }
+func (b *frameMockBackend) Readback() (*image.RGBA, error)                        { return nil, nil }
+func (b *frameMockBackend) Close() error                                          { return nil }
+
+// batchingMockBackend additionally implements FrameGPUBackend.
+type batchingMockBackend struct {
+	frameMockBackend
+	beginCalls int
+	endCalls   int
+}
+
+func (b *batchingMockBackend) FrameBegin() error { b.beginCalls++; return nil }
+func (b *batchingMockBackend) FrameEnd() error   { b.endCalls++; return nil }
+
+// TestImageSurfaceFrameBoundaries verifies frame calls are safe no-ops.
+func TestImageSurfaceFrameBoundaries(t *testing.T) {
+	s := NewImageSurface(10, 10)
+	defer s.Close()
+
+	if err := s.FrameBegin(); err != nil {
+		t.Errorf("FrameBegin: %v", err)
+	}
+	s.Clear(color.White)
+	if err := s.FrameEnd(); err != nil {
+		t.Errorf("FrameEnd: %v", err)
+	}
+}
+
+// TestGPUSurfaceFrameForwarding verifies frame calls reach a batching backend.
+func TestGPUSurfaceFrameForwarding(t *testing.T) {
+	backend := &batchingMockBackend{}
+	s, err := NewGPUSurface(10, 10, backend)
+	if err != nil {
+		t.Fatalf("NewGPUSurface: %v", err)
+	}
+	defer s.Close()
+
+	_ = s.FrameBegin()
+	_ = s.FrameEnd()
+
+	if backend.beginCalls != 1 || backend.endCalls != 1 {
+		t.Errorf("frame calls = (%d, %d), want (1, 1)",
+			backend.beginCalls, backend.endCalls)
+	}
+	if backend.flushCalls != 0 {
+		t.Errorf("Flush called %d times, want 0 (backend batches frames)",
+			backend.flushCalls)
+	}
+}
+
+// TestGPUSurfaceFrameEndFallsBackToFlush verifies the synchronization point
+// for backends without frame batching.
+func TestGPUSurfaceFrameEndFallsBackToFlush(t *testing.T) {
+	backend := &frameMockBackend{}
+	s, err := NewGPUSurface(10, 10, backend)
+	if err != nil {
+		t.Fatalf("NewGPUSurface: %v", err)
+	}
+	defer s.Close()
+
+	_ = s.FrameBegin()
+	_ = s.FrameEnd()
+
+	if backend.flushCalls != 1 {
+		t.Errorf("Flush called %d times, want 1 (FrameEnd fallback)", backend.flushCalls)
+	}
+}
+
+// TestGPUSurfaceFrameAfterClose verifies frame calls on a closed surface.
+func TestGPUSurfaceFrameAfterClose(t *testing.T) {
+	backend := &batchingMockBackend{}
+	s, err := NewGPUSurface(10, 10, backend)
+	if err != nil {
+		t.Fatalf("NewGPUSurface: %v", err)
+	}
+	_ = s.Close()
+
+	if err := s.FrameBegin(); err != nil {
+		t.Errorf("FrameBegin after Close: %v", err)
+	}
+	if err := s.FrameEnd(); err != nil {
+		t.Errorf("FrameEnd after Close: %v", err)
+	}
+	if backend.beginCalls != 0 || backend.endCalls != 0 {
+		t.Error("frame calls should not reach the backend after Close")
+	}
+}
+
+// resizingMockBackend additionally implements ResizeGPUBackend.
+type resizingMockBackend struct {
+	frameMockBackend
+	resizeW, resizeH int
+	preserve         bool
+}
+
+func (b *resizingMockBackend) Resize(w, h int, preserve bool) error {
+	b.resizeW, b.resizeH, b.preserve = w, h, preserve
+	return nil
+}
+
+// TestGPUSurfaceResizeForwarding verifies resize reaches the backend.
+func TestGPUSurfaceResizeForwarding(t *testing.T) {
+	backend := &resizingMockBackend{}
+	s, err := NewGPUSurface(10, 10, backend)
+	if err != nil {
+		t.Fatalf("NewGPUSurface: %v", err)
+	}
+	defer s.Close()
+
+	if err := s.Resize(32, 24, true); err != nil {
+		t.Fatalf("Resize: %v", err)
+	}
+	if backend.resizeW != 32 || backend.resizeH != 24 || !backend.preserve {
+		t.Errorf("backend got (%d, %d, %v), want (32, 24, true)",
+			backend.resizeW, backend.resizeH, backend.preserve)
+	}
+	if s.Width() != 32 || s.Height() != 24 {
+		t.Errorf("surface size = %dx%d, want 32x24", s.Width(), s.Height())
+	}
+	if !s.Capabilities().SupportsResize {
+		t.Error("resizable backend should report resize support")
+	}
+}
+
+// TestGPUSurfaceResizeUnsupported verifies non-resizable backends refuse.
+func TestGPUSurfaceResizeUnsupported(t *testing.T) {
+	backend := &frameMockBackend{}
+	s, err := NewGPUSurface(10, 10, backend)
+	if err != nil {
+		t.Fatalf("NewGPUSurface: %v", err)
+	}
+	defer s.Close()
+
+	if err := s.Resize(20, 20, false); err != ErrResizeUnsupported {
+		t.Errorf("Resize: err = %v, want ErrResizeUnsupported", err)
+	}
+	if s.Capabilities().SupportsResize {
+		t.Error("non-resizable backend should not report resize support")
+	}
+}
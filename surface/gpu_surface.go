@@ -69,6 +69,33 @@ type GPUBackend interface {
 	Close() error
 }
 
+// FrameGPUBackend is an optional interface for GPU backends that batch
+// command submission per frame. When the backend implements it, the
+// surface's FrameBegin/FrameEnd calls are forwarded so the backend can
+// accumulate commands and submit them once per frame; otherwise FrameEnd
+// falls back to Flush.
+type FrameGPUBackend interface {
+	GPUBackend
+
+	// FrameBegin marks the start of a frame.
+	FrameBegin() error
+
+	// FrameEnd submits the frame's batched commands and waits as needed.
+	FrameEnd() error
+}
+
+// ResizeGPUBackend is an optional interface for GPU backends that can
+// recreate their render target at a new size, typically by allocating a new
+// texture and copying the old contents into it.
+type ResizeGPUBackend interface {
+	GPUBackend
+
+	// Resize recreates the render target at the new size. When
+	// preserveContent is true, the old contents are copied into the
+	// top-left corner of the new target.
+	Resize(width, height int, preserveContent bool) error
+}
+
 // NewGPUSurface creates a new GPU surface with the given backend.
 // Returns an error if backend is nil.
 func NewGPUSurface(width, height int, backend GPUBackend) (*GPUSurface, error) {
@@ -140,6 +167,52 @@ func (s *GPUSurface) Flush() error {
 	return s.backend.Flush()
 }
 
+// FrameBegin marks the start of a frame.
+// Forwarded to backends that implement FrameGPUBackend; otherwise a no-op.
+func (s *GPUSurface) FrameBegin() error {
+	if s.closed || s.backend == nil {
+		return nil
+	}
+	if fb, ok := s.backend.(FrameGPUBackend); ok {
+		return fb.FrameBegin()
+	}
+	return nil
+}
+
+// FrameEnd marks the end of a frame and submits batched commands.
+// Backends without frame support still get a synchronization point via Flush.
+func (s *GPUSurface) FrameEnd() error {
+	if s.closed || s.backend == nil {
+		return nil
+	}
+	if fb, ok := s.backend.(FrameGPUBackend); ok {
+		return fb.FrameEnd()
+	}
+	return s.backend.Flush()
+}
+
+// Resize changes the surface dimensions.
+// The resize is forwarded to backends that implement ResizeGPUBackend;
+// others return ErrResizeUnsupported.
+func (s *GPUSurface) Resize(width, height int, preserveContent bool) error {
+	if s.closed || s.backend == nil {
+		return errors.New("surface: surface is closed")
+	}
+	if width <= 0 || height <= 0 {
+		return ErrInvalidSize
+	}
+	rb, ok := s.backend.(ResizeGPUBackend)
+	if !ok {
+		return ErrResizeUnsupported
+	}
+	if err := rb.Resize(width, height, preserveContent); err != nil {
+		return err
+	}
+	s.width = width
+	s.height = height
+	return nil
+}
+
 // Snapshot returns the current surface contents as an image.
 // This performs a GPU readback, which may be slow.
 func (s *GPUSurface) Snapshot() *image.RGBA {
@@ -177,9 +250,10 @@ func (s *GPUSurface) Backend() GPUBackend {
 
 // Capabilities returns the surface capabilities.
 func (s *GPUSurface) Capabilities() Capabilities {
+	_, resizable := s.backend.(ResizeGPUBackend)
 	return Capabilities{
 		SupportsSubSurface: false, // Depends on backend
-		SupportsResize:     true,  // Most GPU surfaces support resize
+		SupportsResize:     resizable,
 		SupportsClipping:   true,  // GPU shaders support clipping
 		SupportsBlendModes: true,  // GPU supports blend modes
 		SupportsAntialias:  true,  // GPU supports MSAA or analytical AA
@@ -191,3 +265,4 @@ func (s *GPUSurface) Capabilities() Capabilities {
 // Verify GPUSurface implements Surface interface.
 var _ Surface = (*GPUSurface)(nil)
 var _ CapableSurface = (*GPUSurface)(nil)
+var _ ResizableSurface = (*GPUSurface)(nil)
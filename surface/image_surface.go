@@ -4,6 +4,7 @@
 package surface
 
 import (
+	"errors"
 	"image"
 	"image/color"
 	"image/draw"
@@ -11,6 +12,40 @@ import (
 	"github.com/gogpu/gg/internal/raster"
 )
 
+// PixelFormat describes the channel order of a user-provided pixel buffer.
+// All formats are 8 bits per channel, 4 bytes per pixel.
+type PixelFormat uint8
+
+const (
+	// FormatRGBA stores pixels as R, G, B, A bytes (image.RGBA layout).
+	FormatRGBA PixelFormat = iota
+
+	// FormatBGRA stores pixels as B, G, R, A bytes.
+	// Common on Windows and in video frame buffers.
+	FormatBGRA
+)
+
+var (
+	// ErrInvalidSize is returned when surface dimensions are not positive.
+	ErrInvalidSize = errors.New("surface: dimensions must be positive")
+
+	// ErrInvalidStride is returned when a buffer stride is smaller than one
+	// row of pixels (width*4 bytes).
+	ErrInvalidStride = errors.New("surface: stride must be at least width*4 bytes")
+
+	// ErrBufferTooSmall is returned when a pixel buffer cannot hold the
+	// requested dimensions at the given stride.
+	ErrBufferTooSmall = errors.New("surface: pixel buffer too small for dimensions and stride")
+
+	// ErrUnsupportedFormat is returned for pixel formats the surface cannot
+	// render into.
+	ErrUnsupportedFormat = errors.New("surface: unsupported pixel format")
+
+	// ErrResizeUnsupported is returned when a surface cannot change size,
+	// e.g. because its pixel memory is owned by the caller.
+	ErrResizeUnsupported = errors.New("surface: resize not supported")
+)
+
 // ImageSurface is a CPU-based surface that renders to an *image.RGBA.
 //
 // It uses raster.AnalyticFiller for high-quality anti-aliased rendering.
@@ -38,6 +73,16 @@ type ImageSurface struct {
 	// edgeBuilder converts paths to edges
 	edgeBuilder *raster.EdgeBuilder
 
+	// bgra indicates the pixel buffer stores channels in B, G, R, A order.
+	// Set only for surfaces created over external FormatBGRA buffers.
+	bgra bool
+
+	// external indicates the pixel memory is owned by the caller
+	// (NewImageSurfaceFromImage, NewImageSurfaceForData). External surfaces
+	// cannot be resized: reallocating would silently detach the caller's
+	// memory.
+	external bool
+
 	// closed tracks if Close has been called
 	closed bool
 }
@@ -71,11 +116,50 @@ func NewImageSurfaceFromImage(img *image.RGBA) *ImageSurface {
 		width:       width,
 		height:      height,
 		img:         img,
+		external:    true,
 		filler:      raster.NewAnalyticFiller(width, height),
 		edgeBuilder: raster.NewEdgeBuilder(2),
 	}
 }
 
+// NewImageSurfaceForData creates a surface that renders directly into a
+// caller-owned pixel buffer without copying. This allows gg to draw into
+// memory owned by another system: shared memory segments, video frame
+// buffers, or Cgo-owned allocations.
+//
+// stride is the distance between rows in bytes and may exceed width*4 for
+// padded layouts; the padding bytes are never touched. The caller keeps
+// ownership of pix: it must stay valid for the life of the surface, and
+// drawing operations write into it immediately (no Flush is required).
+func NewImageSurfaceForData(pix []byte, width, height, stride int, format PixelFormat) (*ImageSurface, error) {
+	if width <= 0 || height <= 0 {
+		return nil, ErrInvalidSize
+	}
+	if stride < width*4 {
+		return nil, ErrInvalidStride
+	}
+	if len(pix) < (height-1)*stride+width*4 {
+		return nil, ErrBufferTooSmall
+	}
+	if format != FormatRGBA && format != FormatBGRA {
+		return nil, ErrUnsupportedFormat
+	}
+
+	return &ImageSurface{
+		width:  width,
+		height: height,
+		img: &image.RGBA{
+			Pix:    pix,
+			Stride: stride,
+			Rect:   image.Rect(0, 0, width, height),
+		},
+		bgra:        format == FormatBGRA,
+		external:    true,
+		filler:      raster.NewAnalyticFiller(width, height),
+		edgeBuilder: raster.NewEdgeBuilder(2),
+	}, nil
+}
+
 // Width returns the surface width.
 func (s *ImageSurface) Width() int {
 	return s.width
@@ -101,6 +185,11 @@ func (s *ImageSurface) Clear(c color.Color) {
 		A: uint8(a >> 8),
 	}
 
+	if s.bgra {
+		// The uniform fill writes bytes in RGBA order; swap the channels so
+		// the BGRA buffer receives them in its own order.
+		rgba.R, rgba.B = rgba.B, rgba.R
+	}
 	draw.Draw(s.img, s.img.Bounds(), &image.Uniform{rgba}, image.Point{}, draw.Src)
 }
 
@@ -222,6 +311,54 @@ func (s *ImageSurface) Flush() error {
 	return nil
 }
 
+// FrameBegin marks the start of a frame.
+// ImageSurface renders immediately, so frames need no setup.
+func (s *ImageSurface) FrameBegin() error {
+	return nil
+}
+
+// FrameEnd marks the end of a frame.
+// Equivalent to Flush for ImageSurface.
+func (s *ImageSurface) FrameEnd() error {
+	return s.Flush()
+}
+
+// Resize changes the surface dimensions by reallocating the backing image.
+// When preserveContent is true the old pixels are blitted into the top-left
+// corner of the new surface; otherwise the surface starts transparent.
+// Surfaces created over caller-owned memory return ErrResizeUnsupported.
+func (s *ImageSurface) Resize(width, height int, preserveContent bool) error {
+	if s.closed {
+		return errors.New("surface: surface is closed")
+	}
+	if width <= 0 || height <= 0 {
+		return ErrInvalidSize
+	}
+	if s.external {
+		return ErrResizeUnsupported
+	}
+	if width == s.width && height == s.height {
+		return nil
+	}
+
+	newImg := image.NewRGBA(image.Rect(0, 0, width, height))
+	if preserveContent {
+		// Blit the overlapping region row by row.
+		copyW := min(width, s.width) * 4
+		for y := 0; y < min(height, s.height); y++ {
+			copy(newImg.Pix[y*newImg.Stride:y*newImg.Stride+copyW],
+				s.img.Pix[y*s.img.Stride:y*s.img.Stride+copyW])
+		}
+	}
+
+	s.img = newImg
+	s.width = width
+	s.height = height
+	s.filler = raster.NewAnalyticFiller(width, height)
+	s.edgeBuilder = raster.NewEdgeBuilder(2)
+	return nil
+}
+
 // Snapshot returns a copy of the current surface contents.
 func (s *ImageSurface) Snapshot() *image.RGBA {
 	if s.closed {
@@ -229,7 +366,16 @@ func (s *ImageSurface) Snapshot() *image.RGBA {
 	}
 
 	result := image.NewRGBA(image.Rect(0, 0, s.width, s.height))
-	copy(result.Pix, s.img.Pix)
+	rowLen := s.width * 4
+	for y := 0; y < s.height; y++ {
+		copy(result.Pix[y*result.Stride:y*result.Stride+rowLen],
+			s.img.Pix[y*s.img.Stride:y*s.img.Stride+rowLen])
+	}
+	if s.bgra {
+		for i := 0; i < len(result.Pix); i += 4 {
+			result.Pix[i], result.Pix[i+2] = result.Pix[i+2], result.Pix[i]
+		}
+	}
 	return result
 }
 
@@ -247,6 +393,9 @@ func (s *ImageSurface) Close() error {
 
 // Image returns the underlying image.RGBA.
 // This is a direct reference, not a copy.
+// For surfaces created over FormatBGRA buffers the pixel bytes are in
+// B, G, R, A order despite the *image.RGBA type; use Snapshot for a
+// correctly ordered copy.
 func (s *ImageSurface) Image() *image.RGBA {
 	return s.img
 }
@@ -255,7 +404,7 @@ func (s *ImageSurface) Image() *image.RGBA {
 func (s *ImageSurface) Capabilities() Capabilities {
 	return Capabilities{
 		SupportsSubSurface: false,
-		SupportsResize:     false,
+		SupportsResize:     !s.external,
 		SupportsClipping:   false,
 		SupportsBlendModes: false,
 		SupportsAntialias:  true,
@@ -299,11 +448,17 @@ func (s *ImageSurface) blendPixelAlpha(x, y int, src color.RGBA, alpha uint8) {
 
 	idx := s.img.PixOffset(x, y)
 
+	// Channel byte offsets; BGRA buffers store red and blue swapped.
+	ri, bi := 0, 2
+	if s.bgra {
+		ri, bi = 2, 0
+	}
+
 	if alpha == 255 && src.A == 255 {
 		// Fully opaque - direct write
-		s.img.Pix[idx+0] = src.R
+		s.img.Pix[idx+ri] = src.R
 		s.img.Pix[idx+1] = src.G
-		s.img.Pix[idx+2] = src.B
+		s.img.Pix[idx+bi] = src.B
 		s.img.Pix[idx+3] = src.A
 		return
 	}
@@ -313,9 +468,9 @@ func (s *ImageSurface) blendPixelAlpha(x, y int, src color.RGBA, alpha uint8) {
 	srcA := uint32(src.A) * uint32(alpha) / 255
 	invSrcA := 255 - srcA
 
-	dstR := uint32(s.img.Pix[idx+0])
+	dstR := uint32(s.img.Pix[idx+ri])
 	dstG := uint32(s.img.Pix[idx+1])
-	dstB := uint32(s.img.Pix[idx+2])
+	dstB := uint32(s.img.Pix[idx+bi])
 	dstA := uint32(s.img.Pix[idx+3])
 
 	outA := srcA + dstA*invSrcA/255
@@ -328,11 +483,11 @@ func (s *ImageSurface) blendPixelAlpha(x, y int, src color.RGBA, alpha uint8) {
 	outB := (uint32(src.B)*srcA + dstB*dstA*invSrcA/255) / outA
 
 	//nolint:gosec // G115: safe - values are clamped to [0, 255]
-	s.img.Pix[idx+0] = uint8(outR)
+	s.img.Pix[idx+ri] = uint8(outR)
 	//nolint:gosec // G115: safe
 	s.img.Pix[idx+1] = uint8(outG)
 	//nolint:gosec // G115: safe
-	s.img.Pix[idx+2] = uint8(outB)
+	s.img.Pix[idx+bi] = uint8(outB)
 	//nolint:gosec // G115: safe
 	s.img.Pix[idx+3] = uint8(outA)
 }
@@ -607,3 +762,4 @@ func sqrtf32(x float32) float32 {
 // Verify ImageSurface implements Surface interface.
 var _ Surface = (*ImageSurface)(nil)
 var _ CapableSurface = (*ImageSurface)(nil)
+var _ ResizableSurface = (*ImageSurface)(nil)
@@ -321,3 +321,215 @@ func BenchmarkImageSurfaceClear(b *testing.B) {
 		s.Clear(color.RGBA{128, 128, 128, 255})
 	}
 }
+
+// TestNewImageSurfaceForData tests zero-copy rendering into a user buffer.
+func TestNewImageSurfaceForData(t *testing.T) {
+	const w, h = 10, 10
+	pix := make([]byte, w*h*4)
+
+	s, err := NewImageSurfaceForData(pix, w, h, w*4, FormatRGBA)
+	if err != nil {
+		t.Fatalf("NewImageSurfaceForData: %v", err)
+	}
+	defer s.Close()
+
+	s.Clear(color.RGBA{255, 0, 0, 255})
+
+	// The caller's buffer received the pixels directly, no copy.
+	idx := (5*w + 5) * 4
+	if pix[idx] != 255 || pix[idx+1] != 0 || pix[idx+2] != 0 || pix[idx+3] != 255 {
+		t.Errorf("buffer pixel = %v, want (255, 0, 0, 255)", pix[idx:idx+4])
+	}
+}
+
+// TestNewImageSurfaceForDataStride tests padded row strides.
+func TestNewImageSurfaceForDataStride(t *testing.T) {
+	const w, h, stride = 4, 4, 32 // 16 bytes of row data, 16 bytes padding
+	pix := make([]byte, h*stride)
+	for i := range pix {
+		pix[i] = 0xAB // sentinel to detect padding writes
+	}
+
+	s, err := NewImageSurfaceForData(pix, w, h, stride, FormatRGBA)
+	if err != nil {
+		t.Fatalf("NewImageSurfaceForData: %v", err)
+	}
+	defer s.Close()
+
+	s.Clear(color.RGBA{0, 255, 0, 255})
+
+	// Row data written at the stride offset.
+	idx := 2*stride + 2*4
+	if pix[idx+1] != 255 {
+		t.Errorf("pixel at (2,2) = %v, want green", pix[idx:idx+4])
+	}
+	// Padding bytes untouched.
+	for y := 0; y < h; y++ {
+		for i := y*stride + w*4; i < (y+1)*stride; i++ {
+			if pix[i] != 0xAB {
+				t.Fatalf("padding byte %d modified", i)
+			}
+		}
+	}
+
+	// Snapshot respects the stride.
+	img := s.Snapshot()
+	if c := img.RGBAAt(2, 2); c.G != 255 {
+		t.Errorf("Snapshot pixel = %v, want green", c)
+	}
+}
+
+// TestNewImageSurfaceForDataBGRA tests BGRA channel ordering.
+func TestNewImageSurfaceForDataBGRA(t *testing.T) {
+	const w, h = 8, 8
+	pix := make([]byte, w*h*4)
+
+	s, err := NewImageSurfaceForData(pix, w, h, w*4, FormatBGRA)
+	if err != nil {
+		t.Fatalf("NewImageSurfaceForData: %v", err)
+	}
+	defer s.Close()
+
+	s.Clear(color.RGBA{255, 0, 0, 255}) // red
+
+	// The buffer stores B, G, R, A: red lands in byte 2.
+	if pix[0] != 0 || pix[2] != 255 {
+		t.Errorf("BGRA bytes = %v, want (0, 0, 255, 255)", pix[:4])
+	}
+
+	// A filled path also writes in BGRA order.
+	path := NewPath()
+	path.Rectangle(2, 2, 4, 4)
+	s.Fill(path, FillStyle{Color: color.RGBA{0, 0, 255, 255}}) // blue
+	idx := (4*w + 4) * 4
+	if pix[idx] != 255 || pix[idx+2] != 0 {
+		t.Errorf("BGRA fill bytes = %v, want blue in byte 0", pix[idx:idx+4])
+	}
+
+	// Snapshot converts back to RGBA order.
+	img := s.Snapshot()
+	if c := img.RGBAAt(4, 4); c.B != 255 || c.R != 0 {
+		t.Errorf("Snapshot pixel = %v, want blue", c)
+	}
+	if c := img.RGBAAt(0, 0); c.R != 255 || c.B != 0 {
+		t.Errorf("Snapshot pixel = %v, want red", c)
+	}
+}
+
+// TestNewImageSurfaceForDataErrors tests argument validation.
+func TestNewImageSurfaceForDataErrors(t *testing.T) {
+	pix := make([]byte, 80) // one row short of 5x5 at stride 20
+
+	if _, err := NewImageSurfaceForData(pix, 0, 5, 20, FormatRGBA); err != ErrInvalidSize {
+		t.Errorf("zero width: err = %v, want ErrInvalidSize", err)
+	}
+	if _, err := NewImageSurfaceForData(pix, 5, 5, 10, FormatRGBA); err != ErrInvalidStride {
+		t.Errorf("short stride: err = %v, want ErrInvalidStride", err)
+	}
+	if _, err := NewImageSurfaceForData(pix, 5, 5, 20, FormatRGBA); err != ErrBufferTooSmall {
+		t.Errorf("short buffer: err = %v, want ErrBufferTooSmall", err)
+	}
+	big := make([]byte, 5*5*4)
+	if _, err := NewImageSurfaceForData(big, 5, 5, 20, PixelFormat(99)); err != ErrUnsupportedFormat {
+		t.Errorf("bad format: err = %v, want ErrUnsupportedFormat", err)
+	}
+}
+
+// TestImageSurfaceResizePreservesContent tests resize with content blit.
+func TestImageSurfaceResizePreservesContent(t *testing.T) {
+	s := NewImageSurface(10, 10)
+	defer s.Close()
+
+	s.Clear(color.RGBA{255, 0, 0, 255})
+
+	if err := s.Resize(20, 20, true); err != nil {
+		t.Fatalf("Resize: %v", err)
+	}
+	if s.Width() != 20 || s.Height() != 20 {
+		t.Errorf("size = %dx%d, want 20x20", s.Width(), s.Height())
+	}
+
+	img := s.Snapshot()
+	// Old content blitted to the top-left corner.
+	if c := img.RGBAAt(5, 5); c.R != 255 {
+		t.Errorf("preserved pixel = %v, want red", c)
+	}
+	// New area is transparent.
+	if c := img.RGBAAt(15, 15); c.A != 0 {
+		t.Errorf("new pixel = %v, want transparent", c)
+	}
+
+	// The resized surface still renders correctly.
+	path := NewPath()
+	path.Rectangle(12, 12, 6, 6)
+	s.Fill(path, FillStyle{Color: color.RGBA{0, 255, 0, 255}})
+	if c := s.Snapshot().RGBAAt(15, 15); c.G != 255 {
+		t.Errorf("post-resize fill pixel = %v, want green", c)
+	}
+}
+
+// TestImageSurfaceResizeDiscardsContent tests resize without preservation.
+func TestImageSurfaceResizeDiscardsContent(t *testing.T) {
+	s := NewImageSurface(10, 10)
+	defer s.Close()
+
+	s.Clear(color.RGBA{255, 0, 0, 255})
+
+	if err := s.Resize(8, 8, false); err != nil {
+		t.Fatalf("Resize: %v", err)
+	}
+	if c := s.Snapshot().RGBAAt(4, 4); c.A != 0 {
+		t.Errorf("pixel = %v, want transparent after discarding resize", c)
+	}
+}
+
+// TestImageSurfaceResizeShrinkCrops tests that shrinking crops content.
+func TestImageSurfaceResizeShrinkCrops(t *testing.T) {
+	s := NewImageSurface(10, 10)
+	defer s.Close()
+
+	s.Clear(color.RGBA{0, 0, 255, 255})
+
+	if err := s.Resize(5, 5, true); err != nil {
+		t.Fatalf("Resize: %v", err)
+	}
+	if s.Width() != 5 || s.Height() != 5 {
+		t.Errorf("size = %dx%d, want 5x5", s.Width(), s.Height())
+	}
+	if c := s.Snapshot().RGBAAt(4, 4); c.B != 255 {
+		t.Errorf("pixel = %v, want blue", c)
+	}
+}
+
+// TestImageSurfaceResizeExternalBuffer tests that external surfaces refuse.
+func TestImageSurfaceResizeExternalBuffer(t *testing.T) {
+	pix := make([]byte, 10*10*4)
+	s, err := NewImageSurfaceForData(pix, 10, 10, 40, FormatRGBA)
+	if err != nil {
+		t.Fatalf("NewImageSurfaceForData: %v", err)
+	}
+	defer s.Close()
+
+	if err := s.Resize(20, 20, true); err != ErrResizeUnsupported {
+		t.Errorf("Resize on external buffer: err = %v, want ErrResizeUnsupported", err)
+	}
+	if s.Capabilities().SupportsResize {
+		t.Error("external surface should not report resize support")
+	}
+
+	fromImg := NewImageSurfaceFromImage(image.NewRGBA(image.Rect(0, 0, 10, 10)))
+	defer fromImg.Close()
+	if err := fromImg.Resize(20, 20, true); err != ErrResizeUnsupported {
+		t.Errorf("Resize on FromImage surface: err = %v, want ErrResizeUnsupported", err)
+	}
+}
+
+// TestImageSurfaceResizeInvalid tests argument validation.
+func TestImageSurfaceResizeInvalid(t *testing.T) {
+	s := NewImageSurface(10, 10)
+	defer s.Close()
+
+	if err := s.Resize(0, 10, false); err != ErrInvalidSize {
+		t.Errorf("Resize(0, 10): err = %v, want ErrInvalidSize", err)
+	}
+}
@@ -53,6 +53,18 @@ type Surface interface {
 	// Returns an error if flushing fails.
 	Flush() error
 
+	// FrameBegin marks the start of a frame. Drawing between FrameBegin and
+	// FrameEnd may be batched by the implementation; GPU surfaces typically
+	// defer command submission until the frame ends. For CPU surfaces this
+	// is a no-op. Frames do not nest.
+	FrameBegin() error
+
+	// FrameEnd marks the end of a frame and submits any batched work.
+	// When FrameEnd returns, all drawing since FrameBegin is complete, so it
+	// serves as a well-defined synchronization point (at least as strong as
+	// Flush) without requiring a Snapshot readback.
+	FrameEnd() error
+
 	// Snapshot returns the current surface contents as an RGBA image.
 	// The returned image is a copy; modifications to it do not affect the surface.
 	// This may be slow for GPU surfaces as it requires readback.
@@ -73,13 +85,17 @@ type SubSurface interface {
 	CreateSubSurface(bounds image.Rectangle) (Surface, error)
 }
 
-// ResizableSurface is an optional interface for surfaces that support resizing.
+// ResizableSurface is an optional interface for surfaces that support
+// changing dimensions in place, so window resizes do not require destroying
+// and reconstructing all rendering state.
 type ResizableSurface interface {
 	Surface
 
-	// Resize changes the surface dimensions.
-	// Existing content may be discarded or preserved depending on implementation.
-	Resize(width, height int) error
+	// Resize changes the surface dimensions. When preserveContent is true,
+	// the existing content is copied into the top-left corner of the resized
+	// surface (cropped if the surface shrinks); otherwise the new content is
+	// undefined and should be cleared before use.
+	Resize(width, height int, preserveContent bool) error
 }
 
 // ClippableSurface is an optional interface for surfaces with clipping support.
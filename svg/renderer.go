@@ -293,6 +293,10 @@ func applyStrokeAttrs(dc *gg.Context, a *Attrs, state *renderState) {
 		dc.SetLineJoin(gg.LineJoinRound)
 	case "bevel":
 		dc.SetLineJoin(gg.LineJoinBevel)
+	case "miter-clip":
+		dc.SetLineJoin(gg.LineJoinMiterClip)
+	case "arcs":
+		dc.SetLineJoin(gg.LineJoinArcs)
 	default:
 		dc.SetLineJoin(gg.LineJoinMiter)
 	}
@@ -0,0 +1,36 @@
+// Copyright 2026 The gogpu Authors
+// SPDX-License-Identifier: MIT
+
+// Package term renders Pixmaps into terminals that support inline
+// graphics, for server-side debugging of rendering output over SSH.
+//
+// Two protocols are implemented:
+//
+//   - Kitty graphics protocol: lossless, pixels are transmitted as
+//     base64-encoded RGBA. Supported by kitty, WezTerm, and Konsole.
+//   - Sixel: pixels are quantized to a 256-color palette and run-length
+//     encoded. Supported by xterm (-ti 340), mlterm, foot, and others.
+//
+// DetectProtocol picks one from the environment; both encoders are also
+// exported directly for callers that know their terminal.
+//
+// # Usage
+//
+// One-shot dump of a pixmap:
+//
+//	r := term.NewRenderer(os.Stdout, term.Options{MaxWidth: 800})
+//	r.Present(pixmap)
+//
+// As a drawing surface (frames are emitted on FrameEnd):
+//
+//	s, err := term.NewSurface(os.Stdout, 400, 300)
+//	s.FrameBegin()
+//	s.Fill(path, style)
+//	s.FrameEnd() // encodes and writes the frame
+//
+// # Output Size
+//
+// Terminal cells are coarse; Options.MaxWidth/MaxHeight bound the
+// transmitted image and frames are box-downscaled to fit. Defaults keep
+// output below typical terminal widths.
+package term
@@ -0,0 +1,59 @@
+// Copyright 2026 The gogpu Authors
+// SPDX-License-Identifier: MIT
+
+package term
+
+import (
+	"encoding/base64"
+	"fmt"
+	"image"
+	"io"
+)
+
+// kittyChunkSize is the maximum base64 payload per escape sequence, as
+// required by the kitty graphics protocol.
+const kittyChunkSize = 4096
+
+// WriteKitty encodes img with the kitty graphics protocol: raw RGBA
+// pixels, base64-encoded and split into 4096-byte chunks, transmitted
+// and displayed at the cursor (a=T). Lossless — no quantization.
+func WriteKitty(w io.Writer, img *image.RGBA) error {
+	b := img.Bounds()
+	width, height := b.Dx(), b.Dy()
+	if width == 0 || height == 0 {
+		return nil
+	}
+
+	// Compact to dense rows; the protocol has no stride parameter.
+	pix := img.Pix
+	if img.Stride != width*4 || !b.Eq(img.Rect) {
+		pix = make([]byte, width*height*4)
+		for y := 0; y < height; y++ {
+			src := img.PixOffset(b.Min.X, b.Min.Y+y)
+			copy(pix[y*width*4:], img.Pix[src:src+width*4])
+		}
+	}
+
+	payload := base64.StdEncoding.EncodeToString(pix)
+	first := true
+	for len(payload) > 0 {
+		n := min(kittyChunkSize, len(payload))
+		chunk := payload[:n]
+		payload = payload[n:]
+		more := 0
+		if len(payload) > 0 {
+			more = 1
+		}
+		var err error
+		if first {
+			_, err = fmt.Fprintf(w, "\x1b_Ga=T,f=32,s=%d,v=%d,m=%d;%s\x1b\\", width, height, more, chunk)
+			first = false
+		} else {
+			_, err = fmt.Fprintf(w, "\x1b_Gm=%d;%s\x1b\\", more, chunk)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
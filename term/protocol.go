@@ -0,0 +1,61 @@
+// Copyright 2026 The gogpu Authors
+// SPDX-License-Identifier: MIT
+
+package term
+
+import (
+	"os"
+	"strings"
+)
+
+// Protocol identifies a terminal graphics protocol.
+type Protocol uint8
+
+const (
+	// ProtocolNone means no inline graphics support was detected.
+	ProtocolNone Protocol = iota
+
+	// ProtocolSixel is the DEC sixel raster format.
+	ProtocolSixel
+
+	// ProtocolKitty is the kitty graphics protocol.
+	ProtocolKitty
+)
+
+// String returns the protocol name.
+func (p Protocol) String() string {
+	switch p {
+	case ProtocolSixel:
+		return "sixel"
+	case ProtocolKitty:
+		return "kitty"
+	default:
+		return "none"
+	}
+}
+
+// DetectProtocol guesses the best supported protocol from the
+// environment. Kitty is preferred (lossless); sixel terminals are
+// recognized by TERM name. Detection is heuristic — terminals cannot be
+// queried without a round trip — so callers with better knowledge should
+// select a protocol explicitly.
+func DetectProtocol() Protocol {
+	return detectProtocol(os.Getenv("TERM"), os.Getenv("TERM_PROGRAM"), os.Getenv("KITTY_WINDOW_ID"))
+}
+
+// detectProtocol is the testable core of DetectProtocol.
+func detectProtocol(term, termProgram, kittyWindowID string) Protocol {
+	if kittyWindowID != "" {
+		return ProtocolKitty
+	}
+	switch {
+	case strings.Contains(term, "kitty"), termProgram == "WezTerm":
+		return ProtocolKitty
+	}
+	for _, name := range []string{"mlterm", "foot", "yaft", "st-sixel", "xterm-sixel", "contour"} {
+		if strings.Contains(term, name) {
+			return ProtocolSixel
+		}
+	}
+	return ProtocolNone
+}
@@ -0,0 +1,136 @@
+// Copyright 2026 The gogpu Authors
+// SPDX-License-Identifier: MIT
+
+package term
+
+import (
+	"errors"
+	"image"
+	"io"
+
+	"github.com/gogpu/gg"
+)
+
+// ErrNoProtocol is returned when no graphics protocol is available.
+var ErrNoProtocol = errors.New("term: terminal does not support inline graphics")
+
+// Options configures a Renderer.
+type Options struct {
+	// Protocol selects the encoding. ProtocolNone (the zero value) means
+	// auto-detect from the environment.
+	Protocol Protocol
+
+	// MaxWidth and MaxHeight bound the transmitted image in pixels;
+	// larger frames are box-downscaled preserving aspect ratio.
+	// Zero uses the defaults (800x480).
+	MaxWidth  int
+	MaxHeight int
+}
+
+// defaults for Options.MaxWidth/MaxHeight.
+const (
+	defaultMaxWidth  = 800
+	defaultMaxHeight = 480
+)
+
+// Renderer writes pixmap frames to a terminal.
+//
+// Renderer is NOT safe for concurrent use.
+type Renderer struct {
+	w        io.Writer
+	protocol Protocol
+	maxW     int
+	maxH     int
+}
+
+// NewRenderer creates a Renderer writing to w (typically os.Stdout).
+// With Options.Protocol unset the protocol is detected from the
+// environment; Present fails with ErrNoProtocol when none is found.
+func NewRenderer(w io.Writer, opts Options) *Renderer {
+	protocol := opts.Protocol
+	if protocol == ProtocolNone {
+		protocol = DetectProtocol()
+	}
+	maxW, maxH := opts.MaxWidth, opts.MaxHeight
+	if maxW <= 0 {
+		maxW = defaultMaxWidth
+	}
+	if maxH <= 0 {
+		maxH = defaultMaxHeight
+	}
+	return &Renderer{w: w, protocol: protocol, maxW: maxW, maxH: maxH}
+}
+
+// Protocol returns the protocol the renderer encodes with.
+func (r *Renderer) Protocol() Protocol {
+	return r.protocol
+}
+
+// Present encodes the pixmap and writes it at the cursor position.
+func (r *Renderer) Present(pixmap *gg.Pixmap) error {
+	if pixmap == nil {
+		return nil
+	}
+	return r.PresentImage(pixmap.ImageView())
+}
+
+// PresentImage encodes an RGBA image and writes it at the cursor position.
+func (r *Renderer) PresentImage(img *image.RGBA) error {
+	if img == nil {
+		return nil
+	}
+	img = downscale(img, r.maxW, r.maxH)
+	switch r.protocol {
+	case ProtocolKitty:
+		return WriteKitty(r.w, img)
+	case ProtocolSixel:
+		return WriteSixel(r.w, img)
+	default:
+		return ErrNoProtocol
+	}
+}
+
+// downscale box-filters img to fit within maxW x maxH, preserving aspect
+// ratio. Images already within bounds are returned unchanged.
+func downscale(img *image.RGBA, maxW, maxH int) *image.RGBA {
+	b := img.Bounds()
+	w, h := b.Dx(), b.Dy()
+	if w <= maxW && h <= maxH {
+		return img
+	}
+
+	// Fit ratio without floating-point drift at the edges.
+	dstW, dstH := maxW, h*maxW/w
+	if dstH > maxH {
+		dstW, dstH = w*maxH/h, maxH
+	}
+	dstW, dstH = max(dstW, 1), max(dstH, 1)
+
+	dst := image.NewRGBA(image.Rect(0, 0, dstW, dstH))
+	for dy := 0; dy < dstH; dy++ {
+		sy0 := dy * h / dstH
+		sy1 := max((dy+1)*h/dstH, sy0+1)
+		for dx := 0; dx < dstW; dx++ {
+			sx0 := dx * w / dstW
+			sx1 := max((dx+1)*w/dstW, sx0+1)
+			var sr, sg, sb, sa, n int
+			for sy := sy0; sy < sy1; sy++ {
+				pi := img.PixOffset(b.Min.X+sx0, b.Min.Y+sy)
+				for sx := sx0; sx < sx1; sx++ {
+					sr += int(img.Pix[pi])
+					sg += int(img.Pix[pi+1])
+					sb += int(img.Pix[pi+2])
+					sa += int(img.Pix[pi+3])
+					pi += 4
+					n++
+				}
+			}
+			di := dst.PixOffset(dx, dy)
+			dst.Pix[di] = byte(sr / n)
+			dst.Pix[di+1] = byte(sg / n)
+			dst.Pix[di+2] = byte(sb / n)
+			dst.Pix[di+3] = byte(sa / n)
+		}
+	}
+	return dst
+}
@@ -0,0 +1,134 @@
+// Copyright 2026 The gogpu Authors
+// SPDX-License-Identifier: MIT
+
+package term
+
+import (
+	"fmt"
+	"image"
+	"io"
+	"strconv"
+)
+
+// sixelLevels is the number of quantization levels per channel. Six
+// levels give a 216-color uniform cube, comfortably inside the 256-entry
+// sixel palette and good enough for debug output.
+const sixelLevels = 6
+
+// WriteSixel encodes img as a sixel image. Pixels are quantized to a
+// uniform 216-color cube; pixels with alpha below 50% are left
+// transparent. The caller is responsible for any downscaling.
+func WriteSixel(w io.Writer, img *image.RGBA) error {
+	b := img.Bounds()
+	width, height := b.Dx(), b.Dy()
+	if width == 0 || height == 0 {
+		return nil
+	}
+
+	// Quantization pre-pass: palette index per pixel, -1 for transparent.
+	indices := make([]int16, width*height)
+	var used [sixelLevels * sixelLevels * sixelLevels]bool
+	for y := 0; y < height; y++ {
+		row := img.Pix[(y+b.Min.Y-img.Rect.Min.Y)*img.Stride:]
+		for x := 0; x < width; x++ {
+			pi := (x + b.Min.X - img.Rect.Min.X) * 4
+			if row[pi+3] < 128 {
+				indices[y*width+x] = -1
+				continue
+			}
+			idx := quantize(row[pi], row[pi+1], row[pi+2])
+			indices[y*width+x] = int16(idx)
+			used[idx] = true
+		}
+	}
+
+	var out []byte
+	// DCS q with P2=1: zero bits keep the background (transparency).
+	out = append(out, "\x1bP0;1;0q"...)
+	out = append(out, fmt.Sprintf("\"1;1;%d;%d", width, height)...)
+
+	// Palette definitions, RGB in the 0-100 scale sixel uses.
+	for idx, u := range used {
+		if !u {
+			continue
+		}
+		r := (idx / (sixelLevels * sixelLevels)) * 100 / (sixelLevels - 1)
+		g := (idx / sixelLevels % sixelLevels) * 100 / (sixelLevels - 1)
+		bb := (idx % sixelLevels) * 100 / (sixelLevels - 1)
+		out = append(out, fmt.Sprintf("#%d;2;%d;%d;%d", idx, r, g, bb)...)
+	}
+
+	// Six-row bands: one pass per color present in the band.
+	for y0 := 0; y0 < height; y0 += 6 {
+		var bandUsed [sixelLevels * sixelLevels * sixelLevels]bool
+		rows := min(6, height-y0)
+		for dy := 0; dy < rows; dy++ {
+			for x := 0; x < width; x++ {
+				if idx := indices[(y0+dy)*width+x]; idx >= 0 {
+					bandUsed[idx] = true
+				}
+			}
+		}
+		for idx, u := range bandUsed {
+			if !u {
+				continue
+			}
+			out = append(out, '#')
+			out = strconv.AppendInt(out, int64(idx), 10)
+			out = appendSixelRow(out, indices, width, y0, rows, int16(idx))
+			out = append(out, '$') // carriage return within the band
+		}
+		out = append(out, '-') // next band
+	}
+
+	out = append(out, "\x1b\\"...)
+	_, err := w.Write(out)
+	return err
+}
+
+// quantize maps 8-bit RGB to a uniform-cube palette index.
+func quantize(r, g, b byte) int {
+	qr := (int(r)*(sixelLevels-1) + 127) / 255
+	qg := (int(g)*(sixelLevels-1) + 127) / 255
+	qb := (int(b)*(sixelLevels-1) + 127) / 255
+	return qr*sixelLevels*sixelLevels + qg*sixelLevels + qb
+}
+
+// appendSixelRow emits one color pass over a band as run-length encoded
+// sixel characters. Each character carries a vertical bitmask of the up
+// to six rows starting at y0.
+func appendSixelRow(out []byte, indices []int16, width, y0, rows int, color int16) []byte {
+	last := byte(0)
+	run := 0
+	flush := func() []byte {
+		if run == 0 {
+			return out
+		}
+		if run > 3 {
+			out = append(out, '!')
+			out = strconv.AppendInt(out, int64(run), 10)
+			out = append(out, last)
+		} else {
+			for i := 0; i < run; i++ {
+				out = append(out, last)
+			}
+		}
+		run = 0
+		return out
+	}
+	for x := 0; x < width; x++ {
+		bits := byte(0)
+		for dy := 0; dy < rows; dy++ {
+			if indices[(y0+dy)*width+x] == color {
+				bits |= 1 << dy
+			}
+		}
+		ch := 63 + bits
+		if run > 0 && ch != last {
+			out = flush()
+		}
+		last = ch
+		run++
+	}
+	return flush()
+}
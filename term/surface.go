@@ -0,0 +1,51 @@
+// Copyright 2026 The gogpu Authors
+// SPDX-License-Identifier: MIT
+
+package term
+
+import (
+	"io"
+
+	"github.com/gogpu/gg/surface"
+)
+
+// Surface is a drawing surface that presents frames to a terminal.
+// It renders with the software rasterizer via surface.ImageSurface and
+// emits the frame on FrameEnd (and Flush).
+type Surface struct {
+	*surface.ImageSurface
+	renderer *Renderer
+}
+
+// NewSurface creates a terminal surface of the given pixel size writing
+// to w (typically os.Stdout). Returns ErrNoProtocol when the terminal
+// supports no graphics protocol and none was forced in opts.
+func NewSurface(w io.Writer, width, height int, opts Options) (*Surface, error) {
+	r := NewRenderer(w, opts)
+	if r.Protocol() == ProtocolNone {
+		return nil, ErrNoProtocol
+	}
+	return &Surface{
+		ImageSurface: surface.NewImageSurface(width, height),
+		renderer:     r,
+	}, nil
+}
+
+// FrameEnd completes the frame and writes it to the terminal.
+func (s *Surface) FrameEnd() error {
+	if err := s.ImageSurface.FrameEnd(); err != nil {
+		return err
+	}
+	return s.renderer.PresentImage(s.Image())
+}
+
+// Flush writes the current contents to the terminal.
+func (s *Surface) Flush() error {
+	if err := s.ImageSurface.Flush(); err != nil {
+		return err
+	}
+	return s.renderer.PresentImage(s.Image())
+}
+
+// Verify Surface implements surface.Surface.
+var _ surface.Surface = (*Surface)(nil)
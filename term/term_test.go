@@ -0,0 +1,239 @@
+// Copyright 2026 The gogpu Authors
+// SPDX-License-Identifier: MIT
+
+package term
+
+import (
+	"bytes"
+	"encoding/base64"
+	"image"
+	"image/color"
+	"strings"
+	"testing"
+
+	"github.com/gogpu/gg/surface"
+)
+
+// TestDetectProtocol tests environment-based protocol detection.
+func TestDetectProtocol(t *testing.T) {
+	tests := []struct {
+		term, program, kittyID string
+		want                   Protocol
+	}{
+		{"xterm-kitty", "", "", ProtocolKitty},
+		{"xterm-256color", "", "7", ProtocolKitty},
+		{"xterm-256color", "WezTerm", "", ProtocolKitty},
+		{"foot", "", "", ProtocolSixel},
+		{"mlterm", "", "", ProtocolSixel},
+		{"xterm-256color", "", "", ProtocolNone},
+		{"", "", "", ProtocolNone},
+	}
+	for _, tt := range tests {
+		if got := detectProtocol(tt.term, tt.program, tt.kittyID); got != tt.want {
+			t.Errorf("detectProtocol(%q, %q, %q) = %v, want %v",
+				tt.term, tt.program, tt.kittyID, got, tt.want)
+		}
+	}
+}
+
+// TestQuantize tests palette index mapping at the cube corners.
+func TestQuantize(t *testing.T) {
+	if got := quantize(0, 0, 0); got != 0 {
+		t.Errorf("quantize(black) = %d, want 0", got)
+	}
+	if got, want := quantize(255, 255, 255), sixelLevels*sixelLevels*sixelLevels-1; got != want {
+		t.Errorf("quantize(white) = %d, want %d", got, want)
+	}
+	if got, want := quantize(255, 0, 0), (sixelLevels-1)*sixelLevels*sixelLevels; got != want {
+		t.Errorf("quantize(red) = %d, want %d", got, want)
+	}
+}
+
+// TestWriteSixelStructure tests the DCS framing and palette output.
+func TestWriteSixelStructure(t *testing.T) {
+	img := image.NewRGBA(image.Rect(0, 0, 4, 4))
+	for y := 0; y < 4; y++ {
+		for x := 0; x < 2; x++ {
+			img.SetRGBA(x, y, color.RGBA{255, 0, 0, 255})
+		}
+		// Right half stays transparent.
+	}
+
+	var buf bytes.Buffer
+	if err := WriteSixel(&buf, img); err != nil {
+		t.Fatalf("WriteSixel: %v", err)
+	}
+	out := buf.String()
+
+	if !strings.HasPrefix(out, "\x1bP0;1;0q") {
+		t.Errorf("missing DCS header: %q", out[:min(16, len(out))])
+	}
+	if !strings.HasSuffix(out, "\x1b\\") {
+		t.Error("missing string terminator")
+	}
+	if !strings.Contains(out, "\"1;1;4;4") {
+		t.Error("missing raster attributes for 4x4")
+	}
+	// Red maps to the cube corner (5,0,0) -> index 180, defined at 100% red.
+	if !strings.Contains(out, "#180;2;100;0;0") {
+		t.Errorf("missing red palette entry: %q", out)
+	}
+}
+
+// TestWriteSixelEmpty tests degenerate input.
+func TestWriteSixelEmpty(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WriteSixel(&buf, image.NewRGBA(image.Rectangle{})); err != nil {
+		t.Fatalf("WriteSixel: %v", err)
+	}
+	if buf.Len() != 0 {
+		t.Errorf("empty image produced %d bytes", buf.Len())
+	}
+}
+
+// decodeKitty extracts and concatenates the base64 payloads of a kitty
+// escape stream.
+func decodeKitty(t *testing.T, out string) []byte {
+	t.Helper()
+	var b64 strings.Builder
+	for _, part := range strings.Split(out, "\x1b\\") {
+		if i := strings.IndexByte(part, ';'); i >= 0 {
+			b64.WriteString(part[i+1:])
+		}
+	}
+	raw, err := base64.StdEncoding.DecodeString(b64.String())
+	if err != nil {
+		t.Fatalf("payload is not valid base64: %v", err)
+	}
+	return raw
+}
+
+// TestWriteKittyRoundTrip tests that pixels survive encoding unchanged.
+func TestWriteKittyRoundTrip(t *testing.T) {
+	img := image.NewRGBA(image.Rect(0, 0, 3, 2))
+	img.SetRGBA(1, 1, color.RGBA{10, 20, 30, 40})
+
+	var buf bytes.Buffer
+	if err := WriteKitty(&buf, img); err != nil {
+		t.Fatalf("WriteKitty: %v", err)
+	}
+	out := buf.String()
+
+	if !strings.Contains(out, "a=T,f=32,s=3,v=2,m=0;") {
+		t.Errorf("missing control data: %q", out)
+	}
+	raw := decodeKitty(t, out)
+	if !bytes.Equal(raw, img.Pix) {
+		t.Error("decoded pixels differ from source")
+	}
+}
+
+// TestWriteKittyChunking tests payload splitting over 4096 bytes.
+func TestWriteKittyChunking(t *testing.T) {
+	img := image.NewRGBA(image.Rect(0, 0, 40, 40)) // 6400 raw -> >4096 base64
+
+	var buf bytes.Buffer
+	if err := WriteKitty(&buf, img); err != nil {
+		t.Fatalf("WriteKitty: %v", err)
+	}
+	out := buf.String()
+
+	if !strings.Contains(out, "m=1;") {
+		t.Error("first chunk should announce continuation (m=1)")
+	}
+	if !strings.Contains(out, "\x1b_Gm=0;") {
+		t.Error("last chunk should end the stream (m=0)")
+	}
+	if raw := decodeKitty(t, out); !bytes.Equal(raw, img.Pix) {
+		t.Error("decoded pixels differ from source")
+	}
+}
+
+// TestDownscale tests box filtering and aspect preservation.
+func TestDownscale(t *testing.T) {
+	img := image.NewRGBA(image.Rect(0, 0, 4, 4))
+	for y := 0; y < 4; y++ {
+		for x := 0; x < 4; x++ {
+			if x < 2 {
+				img.SetRGBA(x, y, color.RGBA{200, 0, 0, 255})
+			} else {
+				img.SetRGBA(x, y, color.RGBA{0, 0, 200, 255})
+			}
+		}
+	}
+
+	small := downscale(img, 2, 2)
+	if small.Bounds().Dx() != 2 || small.Bounds().Dy() != 2 {
+		t.Fatalf("bounds = %v, want 2x2", small.Bounds())
+	}
+	if c := small.RGBAAt(0, 0); c.R != 200 || c.B != 0 {
+		t.Errorf("left pixel = %v, want pure red", c)
+	}
+	if c := small.RGBAAt(1, 1); c.B != 200 || c.R != 0 {
+		t.Errorf("right pixel = %v, want pure blue", c)
+	}
+
+	// Within bounds: returned unchanged.
+	if got := downscale(img, 10, 10); got != img {
+		t.Error("image within bounds should be returned as-is")
+	}
+
+	// Aspect ratio preserved for wide images.
+	wide := image.NewRGBA(image.Rect(0, 0, 100, 50))
+	got := downscale(wide, 10, 10)
+	if got.Bounds().Dx() != 10 || got.Bounds().Dy() != 5 {
+		t.Errorf("wide downscale bounds = %v, want 10x5", got.Bounds())
+	}
+}
+
+// TestRendererNoProtocol tests the error path without a terminal.
+func TestRendererNoProtocol(t *testing.T) {
+	t.Setenv("TERM", "dumb")
+	t.Setenv("TERM_PROGRAM", "")
+	t.Setenv("KITTY_WINDOW_ID", "")
+
+	r := NewRenderer(&bytes.Buffer{}, Options{})
+	if err := r.PresentImage(image.NewRGBA(image.Rect(0, 0, 1, 1))); err != ErrNoProtocol {
+		t.Errorf("PresentImage: err = %v, want ErrNoProtocol", err)
+	}
+}
+
+// TestSurfacePresentsOnFrameEnd tests the surface wrapper.
+func TestSurfacePresentsOnFrameEnd(t *testing.T) {
+	var buf bytes.Buffer
+	s, err := NewSurface(&buf, 8, 8, Options{Protocol: ProtocolKitty})
+	if err != nil {
+		t.Fatalf("NewSurface: %v", err)
+	}
+	defer s.Close()
+
+	if err := s.FrameBegin(); err != nil {
+		t.Fatalf("FrameBegin: %v", err)
+	}
+	s.Clear(color.RGBA{0, 255, 0, 255})
+	if err := s.FrameEnd(); err != nil {
+		t.Fatalf("FrameEnd: %v", err)
+	}
+
+	if !strings.Contains(buf.String(), "\x1b_G") {
+		t.Error("FrameEnd did not emit a kitty frame")
+	}
+	raw := decodeKitty(t, buf.String())
+	if len(raw) != 8*8*4 || raw[1] != 255 {
+		t.Errorf("frame pixels wrong: len=%d first=%v", len(raw), raw[:4])
+	}
+}
+
+// TestNewSurfaceNoProtocol tests surface creation without graphics support.
+func TestNewSurfaceNoProtocol(t *testing.T) {
+	t.Setenv("TERM", "dumb")
+	t.Setenv("TERM_PROGRAM", "")
+	t.Setenv("KITTY_WINDOW_ID", "")
+
+	if _, err := NewSurface(&bytes.Buffer{}, 8, 8, Options{}); err != ErrNoProtocol {
+		t.Errorf("NewSurface: err = %v, want ErrNoProtocol", err)
+	}
+}
+
+// Verify the wrapper still satisfies the optional interfaces it inherits.
+var _ surface.CapableSurface = (*Surface)(nil)
@@ -0,0 +1,113 @@
+// Copyright 2026 The gogpu Authors
+// SPDX-License-Identifier: MIT
+
+// Package corpus loads the rendering test-scene corpus: a tiger-style
+// multi-path illustration, stroke torture tests, and W3C-style path
+// conformance scenes. The scenes stress the tessellator, stroker, and
+// fill rules; golden-image tests over them catch regressions across
+// backends.
+//
+// The SVG sources are embedded, so any package (or external backend) can
+// load the corpus without knowing where it lives on disk:
+//
+//	doc, err := corpus.Load("tiger")
+//	img, err := corpus.Render("stroke-torture", 400, 400)
+//	paths, err := corpus.Paths("w3c-paths")
+package corpus
+
+import (
+	"embed"
+	"fmt"
+	"image"
+	"sort"
+	"strings"
+
+	"github.com/gogpu/gg"
+	"github.com/gogpu/gg/svg"
+)
+
+//go:embed testdata/*.svg
+var corpusFS embed.FS
+
+// Names returns the corpus scene names, sorted.
+func Names() []string {
+	entries, err := corpusFS.ReadDir("testdata")
+	if err != nil {
+		return nil
+	}
+	var names []string
+	for _, e := range entries {
+		if name, ok := strings.CutSuffix(e.Name(), ".svg"); ok {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Load parses a corpus scene into an SVG document.
+func Load(name string) (*svg.Document, error) {
+	data, err := corpusFS.ReadFile("testdata/" + name + ".svg")
+	if err != nil {
+		return nil, fmt.Errorf("corpus: unknown scene %q", name)
+	}
+	doc, err := svg.Parse(data)
+	if err != nil {
+		return nil, fmt.Errorf("corpus: parse %s: %w", name, err)
+	}
+	return doc, nil
+}
+
+// Render rasterizes a corpus scene at the given size with the software
+// renderer.
+func Render(name string, width, height int) (*image.RGBA, error) {
+	doc, err := Load(name)
+	if err != nil {
+		return nil, err
+	}
+	return doc.Render(width, height), nil
+}
+
+// RenderTo draws a corpus scene into an existing context, so backends
+// under test can rasterize the corpus through their own pipeline.
+func RenderTo(dc *gg.Context, name string, x, y, width, height float64) error {
+	doc, err := Load(name)
+	if err != nil {
+		return err
+	}
+	doc.RenderTo(dc, x, y, width, height)
+	return nil
+}
+
+// Paths extracts every <path> element of a corpus scene as a parsed
+// gg.Path, in document order. Useful for feeding the stroker or
+// tessellator directly, without SVG presentation attributes.
+func Paths(name string) ([]*gg.Path, error) {
+	doc, err := Load(name)
+	if err != nil {
+		return nil, err
+	}
+	var paths []*gg.Path
+	var walk func(elements []svg.Element) error
+	walk = func(elements []svg.Element) error {
+		for _, el := range elements {
+			switch e := el.(type) {
+			case *svg.PathElement:
+				p, err := gg.ParseSVGPath(e.D)
+				if err != nil {
+					return fmt.Errorf("corpus: path in %s: %w", name, err)
+				}
+				paths = append(paths, p)
+			case *svg.GroupElement:
+				if err := walk(e.Children); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	}
+	if err := walk(doc.Elements); err != nil {
+		return nil, err
+	}
+	return paths, nil
+}
@@ -0,0 +1,141 @@
+// Copyright 2026 The gogpu Authors
+// SPDX-License-Identifier: MIT
+
+package corpus
+
+import (
+	"flag"
+	"image"
+	"image/png"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// update regenerates the golden images instead of comparing:
+//
+//	go test ./tests/corpus -run Golden -update
+var update = flag.Bool("update", false, "rewrite golden images")
+
+const goldenSize = 400
+
+// TestNames tests that the corpus lists its scenes.
+func TestNames(t *testing.T) {
+	names := Names()
+	want := []string{"stroke-torture", "tiger", "w3c-paths"}
+	if len(names) != len(want) {
+		t.Fatalf("Names() = %v, want %v", names, want)
+	}
+	for i := range want {
+		if names[i] != want[i] {
+			t.Errorf("Names()[%d] = %q, want %q", i, names[i], want[i])
+		}
+	}
+}
+
+// TestLoadUnknown tests the error path for a missing scene.
+func TestLoadUnknown(t *testing.T) {
+	if _, err := Load("nope"); err == nil {
+		t.Error("Load of unknown scene should fail")
+	}
+}
+
+// TestPaths tests path extraction from the corpus documents.
+func TestPaths(t *testing.T) {
+	for _, name := range Names() {
+		paths, err := Paths(name)
+		if err != nil {
+			t.Fatalf("Paths(%s): %v", name, err)
+		}
+		if len(paths) == 0 {
+			t.Errorf("Paths(%s) returned no paths", name)
+		}
+		for i, p := range paths {
+			if p.NumVerbs() == 0 {
+				t.Errorf("Paths(%s)[%d] is empty", name, i)
+			}
+		}
+	}
+}
+
+// TestGolden renders every corpus scene and compares against the golden
+// images. A small tolerance absorbs per-platform floating-point noise;
+// structural regressions exceed it by orders of magnitude.
+func TestGolden(t *testing.T) {
+	for _, name := range Names() {
+		t.Run(name, func(t *testing.T) {
+			img, err := Render(name, goldenSize, goldenSize)
+			if err != nil {
+				t.Fatalf("Render: %v", err)
+			}
+
+			goldenPath := filepath.Join("testdata", "golden", name+".png")
+			if *update {
+				writeGolden(t, goldenPath, img)
+				return
+			}
+
+			golden := readGolden(t, goldenPath)
+			if got, want := img.Bounds(), golden.Bounds(); got != want {
+				t.Fatalf("bounds = %v, golden %v", got, want)
+			}
+			badPixels := 0
+			for i := 0; i < len(img.Pix); i += 4 {
+				for c := 0; c < 4; c++ {
+					d := int(img.Pix[i+c]) - int(golden.Pix[i+c])
+					if d > 8 || d < -8 {
+						badPixels++
+						break
+					}
+				}
+			}
+			total := goldenSize * goldenSize
+			if badPixels*1000 > total*5 { // more than 0.5% of pixels
+				t.Errorf("%d of %d pixels differ from golden (run with -update to regenerate)",
+					badPixels, total)
+			}
+		})
+	}
+}
+
+// writeGolden saves a golden image, creating the directory if needed.
+func writeGolden(t *testing.T, path string, img *image.RGBA) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	f, err := os.Create(path)
+	if err != nil {
+		t.Fatalf("create golden: %v", err)
+	}
+	defer f.Close()
+	if err := png.Encode(f, img); err != nil {
+		t.Fatalf("encode golden: %v", err)
+	}
+	t.Logf("wrote %s", path)
+}
+
+// readGolden loads a golden image as RGBA.
+func readGolden(t *testing.T, path string) *image.RGBA {
+	t.Helper()
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatalf("open golden (run with -update to generate): %v", err)
+	}
+	defer f.Close()
+	decoded, err := png.Decode(f)
+	if err != nil {
+		t.Fatalf("decode golden: %v", err)
+	}
+	if rgba, ok := decoded.(*image.RGBA); ok {
+		return rgba
+	}
+	b := decoded.Bounds()
+	rgba := image.NewRGBA(b)
+	for y := b.Min.Y; y < b.Max.Y; y++ {
+		for x := b.Min.X; x < b.Max.X; x++ {
+			rgba.Set(x, y, decoded.At(x, y))
+		}
+	}
+	return rgba
+}
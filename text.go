@@ -60,6 +60,92 @@ func (c *Context) Font() text.Face {
 	return c.face
 }
 
+// SetTextGamma sets the mask gamma for polarity-aware text coverage
+// correction (Skia SkMaskGamma pattern). Glyph masks are blended in
+// non-linear sRGB space, which makes light text on dark backgrounds
+// look thinner than dark-on-light at the same size. With gamma > 1 the
+// edge coverage of light text is boosted and dark text slightly
+// thinned so both polarities read with the same weight; 1.4 is a
+// typical value. Zero or 1 (the default) disables the correction.
+//
+// Applies to the CPU bitmap text path (translation and uniform-scale
+// CTMs). Vector outline text composites through the regular fill
+// pipeline and is unaffected.
+func (c *Context) SetTextGamma(gamma float64) {
+	c.textGamma = gamma
+}
+
+// SetTextContrast adds a luminance-independent edge contrast boost in
+// [0, 1] to glyph mask coverage. Zero (the default) disables it.
+func (c *Context) SetTextContrast(contrast float64) {
+	c.textContrast = contrast
+}
+
+// SetStemDarkening toggles coverage boosting for small text so thin
+// stems don't dissolve into fractional coverage (FreeType stem
+// darkening). The boost ramps up below 24 device pixels per em and has
+// no effect at larger sizes. Default: off.
+func (c *Context) SetStemDarkening(enabled bool) {
+	c.stemDarkening = enabled
+}
+
+// textRunCache memoizes string measurements for the context's current
+// face, so per-frame text (damage tracking measures every DrawString)
+// doesn't re-shape strings it has already seen. The cache is tied to one
+// face and discarded when the face changes; it is bounded and cleared
+// wholesale when full, which is cheaper than LRU bookkeeping for the
+// small, highly repetitive string sets of HUD/dashboard overlays.
+type textRunCache struct {
+	face text.Face
+	runs map[string]textRunSize
+}
+
+// textRunSize is a cached text.Measure result.
+type textRunSize struct {
+	w, h float64
+}
+
+// maxTextRunCacheEntries bounds the per-context measurement cache.
+const maxTextRunCacheEntries = 256
+
+// measureCached returns text.Measure(s, c.face) through the per-context
+// run cache.
+func (c *Context) measureCached(s string) (w, h float64) {
+	if c.face == nil {
+		return 0, 0
+	}
+	if c.textRuns.face != c.face {
+		c.textRuns.face = c.face
+		if c.textRuns.runs != nil {
+			clear(c.textRuns.runs)
+		}
+	}
+	if m, ok := c.textRuns.runs[s]; ok {
+		return m.w, m.h
+	}
+	w, h = text.Measure(s, c.face)
+	if c.textRuns.runs == nil {
+		c.textRuns.runs = make(map[string]textRunSize, 64)
+	} else if len(c.textRuns.runs) >= maxTextRunCacheEntries {
+		clear(c.textRuns.runs)
+	}
+	c.textRuns.runs[s] = textRunSize{w: w, h: h}
+	return w, h
+}
+
+// textDrawOptions packages the context's gamma, contrast, and stem
+// darkening settings for the bitmap text path, or nil when all are off.
+func (c *Context) textDrawOptions() *text.DrawOptions {
+	if (c.textGamma == 0 || c.textGamma == 1) && c.textContrast == 0 && !c.stemDarkening {
+		return nil
+	}
+	return &text.DrawOptions{
+		Gamma:         c.textGamma,
+		Contrast:      c.textContrast,
+		StemDarkening: c.stemDarkening,
+	}
+}
+
 // DrawString draws text at position (x, y) where y is the baseline.
 // If no font has been set with SetFont, this function does nothing.
 //
@@ -82,7 +168,7 @@ func (c *Context) DrawString(s string, x, y float64) {
 	// Track damage for partial present (ADR-021 Phase 5).
 	// Text bounding box: baseline at y, ascent above, descent below.
 	if c.damageTrackingEnabled {
-		w, _ := text.Measure(s, c.face)
+		w, _ := c.measureCached(s)
 		m := c.face.Metrics()
 		c.trackDamage(image.Rect(
 			int(x), int(y-m.Ascent),
@@ -434,10 +520,7 @@ func (c *Context) DrawStringAnchored(s string, x, y, ax, ay float64) {
 //
 // If no font has been set, returns (0, 0).
 func (c *Context) MeasureString(s string) (w, h float64) {
-	if c.face == nil {
-		return 0, 0
-	}
-	return text.Measure(s, c.face)
+	return c.measureCached(s)
 }
 
 // LoadFontFace loads a font from a file and sets it as the current font.
@@ -612,7 +695,7 @@ func (c *Context) drawStringBitmap(s string, x, y float64) {
 			face = source.Face(c.face.Size() * c.deviceScale)
 		}
 	}
-	text.Draw(c.pixmap, s, face, p.X, p.Y, c.currentColor())
+	text.DrawWithOptions(c.pixmap, s, face, p.X, p.Y, c.currentColor(), c.textDrawOptions())
 }
 
 // drawStringScaled renders text via bitmap rasterization at the device pixel size.
@@ -628,7 +711,7 @@ func (c *Context) drawStringScaled(s string, x, y float64, deviceSize float64) {
 	deviceFace := source.Face(deviceSize * c.deviceScale)
 	p := c.totalMatrix().TransformPoint(Pt(x, y))
 	c.flushGPUAccelerator()
-	text.Draw(c.pixmap, s, deviceFace, p.X, p.Y, c.currentColor())
+	text.DrawWithOptions(c.pixmap, s, deviceFace, p.X, p.Y, c.currentColor(), c.textDrawOptions())
 }
 
 // drawStringCPUAliased renders text with binary (non-anti-aliased) coverage on CPU.
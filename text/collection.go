@@ -0,0 +1,128 @@
+package text
+
+import (
+	"encoding/binary"
+	"fmt"
+	"os"
+	"sync"
+)
+
+// FontCollection represents a TrueType/OpenType collection (.ttc/.otc)
+// holding several fonts that share table data (e.g. macOS Helvetica.ttc,
+// Windows msyh.ttc). Single-font data is accepted too and behaves as a
+// collection of one, so callers can load any font file through the same
+// path.
+//
+// FontCollection is safe for concurrent use. Sources are created lazily
+// and cached; Close releases all of them.
+type FontCollection struct {
+	data     []byte
+	numFonts int
+	opts     []SourceOption
+
+	mu      sync.Mutex
+	sources []*FontSource
+}
+
+// NewFontCollection creates a FontCollection from font data. The data may
+// be a TTC/OTC collection, a single TTF/OTF, or a WOFF/WOFF2 container
+// wrapping a single font. The options are applied to every FontSource the
+// collection creates.
+func NewFontCollection(data []byte, opts ...SourceOption) (*FontCollection, error) {
+	if len(data) == 0 {
+		return nil, ErrEmptyFontData
+	}
+
+	// Unwrap webfont containers first so the ttcf sniff below sees sfnt
+	// bytes (WOFF2 can legally wrap a collection, WOFF 1.0 cannot).
+	switch {
+	case isWOFF(data):
+		decoded, err := decodeWOFF(data)
+		if err != nil {
+			return nil, err
+		}
+		data = decoded
+	case isWOFF2(data):
+		decoded, err := decodeWOFF2(data)
+		if err != nil {
+			return nil, err
+		}
+		data = decoded
+	}
+
+	numFonts := 1
+	if len(data) >= 12 && string(data[:4]) == "ttcf" {
+		n := binary.BigEndian.Uint32(data[8:12])
+		if n == 0 || n > 0xffff {
+			return nil, fmt.Errorf("text: collection: implausible font count %d", n)
+		}
+		numFonts = int(n)
+	}
+
+	dataCopy := make([]byte, len(data))
+	copy(dataCopy, data)
+	return &FontCollection{
+		data:     dataCopy,
+		numFonts: numFonts,
+		opts:     opts,
+		sources:  make([]*FontSource, numFonts),
+	}, nil
+}
+
+// NewFontCollectionFromFile loads a FontCollection from a font file path.
+func NewFontCollectionFromFile(path string, opts ...SourceOption) (*FontCollection, error) {
+	// #nosec G304 -- Font file path is provided by the user
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("text: failed to read font file: %w", err)
+	}
+	return NewFontCollection(data, opts...)
+}
+
+// NumFonts returns the number of fonts in the collection (1 for single
+// font files).
+func (c *FontCollection) NumFonts() int {
+	return c.numFonts
+}
+
+// Source returns the FontSource for the font at the given collection
+// index. Sources are created on first access and cached, so repeated
+// calls return the same *FontSource.
+func (c *FontCollection) Source(i int) (*FontSource, error) {
+	if i < 0 || i >= c.numFonts {
+		return nil, fmt.Errorf("text: collection: index %d out of range [0, %d)", i, c.numFonts)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.sources[i] != nil {
+		return c.sources[i], nil
+	}
+
+	opts := make([]SourceOption, 0, len(c.opts)+1)
+	opts = append(opts, c.opts...)
+	opts = append(opts, WithCollectionIndex(i))
+	source, err := NewFontSource(c.data, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("text: collection: font %d: %w", i, err)
+	}
+	c.sources[i] = source
+	return source, nil
+}
+
+// Close releases every FontSource the collection has created.
+func (c *FontCollection) Close() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	var firstErr error
+	for i, s := range c.sources {
+		if s == nil {
+			continue
+		}
+		if err := s.Close(); err != nil && firstErr == nil {
+			firstErr = err
+		}
+		c.sources[i] = nil
+	}
+	return firstErr
+}
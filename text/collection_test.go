@@ -0,0 +1,160 @@
+package text
+
+import (
+	"bytes"
+	"encoding/binary"
+	"os"
+	"testing"
+)
+
+// encodeTTC packs complete sfnt blobs into a TTC container. Each font is
+// embedded verbatim with its directory offsets rebased to the file start.
+func encodeTTC(t *testing.T, fonts ...[]byte) []byte {
+	t.Helper()
+	var out bytes.Buffer
+	out.WriteString("ttcf")
+	_ = binary.Write(&out, binary.BigEndian, uint32(0x00010000))
+	_ = binary.Write(&out, binary.BigEndian, uint32(len(fonts)))
+	offsetsAt := out.Len()
+	out.Write(make([]byte, len(fonts)*4))
+
+	for i, font := range fonts {
+		base := out.Len()
+		binary.BigEndian.PutUint32(out.Bytes()[offsetsAt+i*4:], uint32(base))
+
+		rebased := make([]byte, len(font))
+		copy(rebased, font)
+		numTables := int(binary.BigEndian.Uint16(rebased[4:6]))
+		for j := 0; j < numTables; j++ {
+			entry := rebased[12+j*16:]
+			offset := binary.BigEndian.Uint32(entry[8:12])
+			binary.BigEndian.PutUint32(entry[8:12], offset+uint32(base))
+		}
+		out.Write(rebased)
+	}
+	return out.Bytes()
+}
+
+func TestFontCollectionTTC(t *testing.T) {
+	regular, err := os.ReadFile("testdata/goregular.ttf")
+	if err != nil {
+		t.Fatalf("test font unavailable: %v", err)
+	}
+	noto, err := os.ReadFile("testdata/notoserif_autohint_shaping.ttf")
+	if err != nil {
+		t.Fatalf("test font unavailable: %v", err)
+	}
+
+	ttc := encodeTTC(t, regular, noto)
+	coll, err := NewFontCollection(ttc)
+	if err != nil {
+		t.Fatalf("NewFontCollection failed: %v", err)
+	}
+	defer func() { _ = coll.Close() }()
+
+	if got := coll.NumFonts(); got != 2 {
+		t.Fatalf("NumFonts = %d, want 2", got)
+	}
+
+	refRegular, err := NewFontSource(regular)
+	if err != nil {
+		t.Fatalf("NewFontSource(regular) failed: %v", err)
+	}
+	defer func() { _ = refRegular.Close() }()
+	refNoto, err := NewFontSource(noto)
+	if err != nil {
+		t.Fatalf("NewFontSource(noto) failed: %v", err)
+	}
+	defer func() { _ = refNoto.Close() }()
+
+	s0, err := coll.Source(0)
+	if err != nil {
+		t.Fatalf("Source(0) failed: %v", err)
+	}
+	s1, err := coll.Source(1)
+	if err != nil {
+		t.Fatalf("Source(1) failed: %v", err)
+	}
+
+	if got, want := s0.Parsed().NumGlyphs(), refRegular.Parsed().NumGlyphs(); got != want {
+		t.Errorf("font 0 NumGlyphs = %d, want %d", got, want)
+	}
+	if got, want := s1.Parsed().NumGlyphs(), refNoto.Parsed().NumGlyphs(); got != want {
+		t.Errorf("font 1 NumGlyphs = %d, want %d", got, want)
+	}
+	if s0.Parsed().NumGlyphs() == s1.Parsed().NumGlyphs() {
+		t.Error("test fonts should have different glyph counts")
+	}
+}
+
+func TestFontCollectionCachesSources(t *testing.T) {
+	data, err := os.ReadFile("testdata/goregular.ttf")
+	if err != nil {
+		t.Fatalf("test font unavailable: %v", err)
+	}
+	coll, err := NewFontCollection(encodeTTC(t, data, data))
+	if err != nil {
+		t.Fatalf("NewFontCollection failed: %v", err)
+	}
+	defer func() { _ = coll.Close() }()
+
+	first, err := coll.Source(0)
+	if err != nil {
+		t.Fatalf("Source(0) failed: %v", err)
+	}
+	second, err := coll.Source(0)
+	if err != nil {
+		t.Fatalf("Source(0) again failed: %v", err)
+	}
+	if first != second {
+		t.Error("Source(0) should return the cached *FontSource")
+	}
+}
+
+func TestFontCollectionSingleFont(t *testing.T) {
+	data, err := os.ReadFile("testdata/goregular.ttf")
+	if err != nil {
+		t.Fatalf("test font unavailable: %v", err)
+	}
+	coll, err := NewFontCollection(data)
+	if err != nil {
+		t.Fatalf("NewFontCollection(single font) failed: %v", err)
+	}
+	defer func() { _ = coll.Close() }()
+
+	if got := coll.NumFonts(); got != 1 {
+		t.Fatalf("NumFonts = %d, want 1", got)
+	}
+	source, err := coll.Source(0)
+	if err != nil {
+		t.Fatalf("Source(0) failed: %v", err)
+	}
+	if source.Parsed().NumGlyphs() == 0 {
+		t.Error("single-font collection produced empty font")
+	}
+}
+
+func TestFontCollectionIndexOutOfRange(t *testing.T) {
+	data, err := os.ReadFile("testdata/goregular.ttf")
+	if err != nil {
+		t.Fatalf("test font unavailable: %v", err)
+	}
+	coll, err := NewFontCollection(data)
+	if err != nil {
+		t.Fatalf("NewFontCollection failed: %v", err)
+	}
+	defer func() { _ = coll.Close() }()
+
+	if _, err := coll.Source(-1); err == nil {
+		t.Error("Source(-1) should fail")
+	}
+	if _, err := coll.Source(1); err == nil {
+		t.Error("Source(1) should fail for a single font")
+	}
+}
+
+func TestFontCollectionEmptyData(t *testing.T) {
+	if _, err := NewFontCollection(nil); err == nil {
+		t.Error("empty data should fail")
+	}
+}
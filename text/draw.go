@@ -5,12 +5,20 @@ import (
 	"image/color"
 	"image/draw"
 	"math"
+	"sync"
 )
 
 // Draw renders text to a destination image.
 // Position (x, y) is the baseline origin.
 // Supports sourceFace, MultiFace, and FilteredFace.
 func Draw(dst draw.Image, text string, face Face, x, y float64, col color.Color) {
+	DrawWithOptions(dst, text, face, x, y, col, nil)
+}
+
+// DrawWithOptions renders text like Draw with additional compositing
+// options: gamma/contrast-corrected glyph masks and stem darkening at
+// small sizes. A nil opts is equivalent to Draw.
+func DrawWithOptions(dst draw.Image, text string, face Face, x, y float64, col color.Color, opts *DrawOptions) {
 	if text == "" || face == nil {
 		return
 	}
@@ -22,11 +30,11 @@ func Draw(dst draw.Image, text string, face Face, x, y float64, col color.Color)
 
 	switch f := face.(type) {
 	case *sourceFace:
-		drawSourceFace(dst, text, f, x, y, col)
+		drawSourceFace(dst, text, f, x, y, col, opts)
 	case *MultiFace:
-		drawMultiFace(dst, text, f, x, y, col)
+		drawMultiFace(dst, text, f, x, y, col, opts)
 	case *FilteredFace:
-		drawFilteredFace(dst, text, f, x, y, col)
+		drawFilteredFace(dst, text, f, x, y, col, opts)
 	}
 }
 
@@ -72,9 +80,10 @@ func drawGlyphs(
 	x, y float64,
 	col color.Color,
 	rasterize glyphRasterizeFunc,
+	opts *DrawOptions,
 ) {
 	if vars := sf.Variations(); len(vars) > 0 {
-		drawGlyphsVariable(dst, sf, text, x, y, col, vars, rasterModeAA)
+		drawGlyphsVariable(dst, sf, text, x, y, col, vars, rasterModeAA, opts)
 		return
 	}
 
@@ -94,8 +103,12 @@ func drawGlyphs(
 		}
 	}
 
-	rast := NewGlyphMaskRasterizer()
-	src := image.NewUniform(col)
+	scratch := getDrawScratch()
+	defer putDrawScratch(scratch)
+	rast := scratch.rast
+	scratch.src.C = col
+	src := &scratch.src
+	lut := opts.maskLUT(ppem, col)
 
 	advanceX := 0.0
 	for glyph := range sf.Glyphs(text) {
@@ -121,8 +134,12 @@ func drawGlyphs(
 			continue
 		}
 
-		maskImg := &image.Alpha{
-			Pix:    result.Mask,
+		maskPix := result.Mask
+		if lut != nil {
+			maskPix = remapMask(maskPix, lut)
+		}
+		scratch.mask = image.Alpha{
+			Pix:    maskPix,
 			Stride: result.Width,
 			Rect:   image.Rect(0, 0, result.Width, result.Height),
 		}
@@ -131,13 +148,43 @@ func drawGlyphs(
 		dstY := int(intY) - int(math.Round(float64(result.BearingY)))
 
 		destRect := image.Rect(dstX, dstY, dstX+result.Width, dstY+result.Height)
-		draw.DrawMask(dst, destRect, src, image.Point{}, maskImg, image.Point{}, draw.Over)
+		draw.DrawMask(dst, destRect, src, image.Point{}, &scratch.mask, image.Point{}, draw.Over)
 
 		// Advance cursor using hinted advance when TT hinting is active.
 		advanceX += hintedOrRawAdvance(ttCache, glyph, ppem)
 	}
 }
 
+// drawScratch bundles the allocation-heavy state of one drawGlyphs call —
+// the glyph rasterizer and the source/mask image headers handed to
+// draw.DrawMask — so repeated draws (e.g. a per-frame FPS counter) reuse
+// it through a pool instead of reallocating.
+type drawScratch struct {
+	rast *GlyphMaskRasterizer
+	src  image.Uniform
+	mask image.Alpha
+}
+
+// drawScratchPool recycles drawScratch values across Draw calls.
+var drawScratchPool = sync.Pool{
+	New: func() any {
+		return &drawScratch{rast: NewGlyphMaskRasterizer()}
+	},
+}
+
+// getDrawScratch fetches a scratch bundle from the pool.
+func getDrawScratch() *drawScratch {
+	return drawScratchPool.Get().(*drawScratch) //nolint:forcetypeassert // pool only holds *drawScratch
+}
+
+// putDrawScratch returns a scratch bundle to the pool, dropping references
+// to caller-owned pixel data.
+func putDrawScratch(s *drawScratch) {
+	s.src.C = nil
+	s.mask = image.Alpha{}
+	drawScratchPool.Put(s)
+}
+
 // hintedOrRawAdvance returns the TT hinted advance for a glyph if available,
 // otherwise falls back to the unhinted advance from the Glyphs() iterator.
 //
@@ -177,6 +224,7 @@ func drawGlyphsVariable(
 	col color.Color,
 	variations []FontVariation,
 	mode glyphRasterMode,
+	opts *DrawOptions,
 ) {
 	source := sf.source
 	parsed := source.Parsed()
@@ -189,6 +237,7 @@ func drawGlyphsVariable(
 	rast := NewGlyphMaskRasterizer()
 	src := image.NewUniform(col)
 	extractor := &OutlineExtractor{}
+	lut := opts.maskLUT(ppem, col)
 
 	advanceX := 0.0
 	for _, r := range text {
@@ -238,8 +287,12 @@ func drawGlyphsVariable(
 			continue
 		}
 
+		maskPix := result.Mask
+		if lut != nil {
+			maskPix = remapMask(maskPix, lut)
+		}
 		maskImg := &image.Alpha{
-			Pix:    result.Mask,
+			Pix:    maskPix,
 			Stride: result.Width,
 			Rect:   image.Rect(0, 0, result.Width, result.Height),
 		}
@@ -288,12 +341,12 @@ func rasterizeAliasedGlyph(
 // at 12px). The Glyphs() iterator now returns fractional X positions from
 // HintingNone advances (ADR-039), while outline rasterization still uses
 // the face's configured hinting for crisp stems.
-func drawSourceFace(dst draw.Image, text string, sf *sourceFace, x, y float64, col color.Color) {
-	drawGlyphs(dst, sf, text, x, y, col, rasterizeHintedGlyph)
+func drawSourceFace(dst draw.Image, text string, sf *sourceFace, x, y float64, col color.Color, opts *DrawOptions) {
+	drawGlyphs(dst, sf, text, x, y, col, rasterizeHintedGlyph, opts)
 }
 
 // drawMultiFace renders text using a MultiFace, selecting the appropriate font for each rune.
-func drawMultiFace(dst draw.Image, text string, mf *MultiFace, x, y float64, col color.Color) {
+func drawMultiFace(dst draw.Image, text string, mf *MultiFace, x, y float64, col color.Color, opts *DrawOptions) {
 	currentX := x
 
 	// Tabs already expanded to spaces by Draw() via expandTabs().
@@ -320,12 +373,12 @@ func drawMultiFace(dst draw.Image, text string, mf *MultiFace, x, y float64, col
 		// Render based on face type
 		switch f := faceToUse.(type) {
 		case *sourceFace:
-			drawSourceFace(dst, runeStr, f, currentX, y, col)
+			drawSourceFace(dst, runeStr, f, currentX, y, col, opts)
 		case *FilteredFace:
-			drawFilteredFace(dst, runeStr, f, currentX, y, col)
+			drawFilteredFace(dst, runeStr, f, currentX, y, col, opts)
 		case *MultiFace:
 			// Nested MultiFace (rare but possible)
-			drawMultiFace(dst, runeStr, f, currentX, y, col)
+			drawMultiFace(dst, runeStr, f, currentX, y, col, opts)
 		}
 
 		currentX += advance
@@ -333,7 +386,7 @@ func drawMultiFace(dst draw.Image, text string, mf *MultiFace, x, y float64, col
 }
 
 // drawFilteredFace renders text using a FilteredFace.
-func drawFilteredFace(dst draw.Image, text string, ff *FilteredFace, x, y float64, col color.Color) {
+func drawFilteredFace(dst draw.Image, text string, ff *FilteredFace, x, y float64, col color.Color, opts *DrawOptions) {
 	// FilteredFace wraps another face - extract and use it
 	// Only render runes that pass the filter
 	currentX := x
@@ -352,11 +405,11 @@ func drawFilteredFace(dst draw.Image, text string, ff *FilteredFace, x, y float6
 		// Render using the underlying face
 		switch f := ff.face.(type) {
 		case *sourceFace:
-			drawSourceFace(dst, runeStr, f, currentX, y, col)
+			drawSourceFace(dst, runeStr, f, currentX, y, col, opts)
 		case *FilteredFace:
-			drawFilteredFace(dst, runeStr, f, currentX, y, col)
+			drawFilteredFace(dst, runeStr, f, currentX, y, col, opts)
 		case *MultiFace:
-			drawMultiFace(dst, runeStr, f, currentX, y, col)
+			drawMultiFace(dst, runeStr, f, currentX, y, col, opts)
 		}
 
 		currentX += advance
@@ -426,9 +479,27 @@ func Measure(text string, face Face) (width, height float64) {
 	return width, height
 }
 
-// DrawOptions provides advanced options for text drawing.
-// Reserved for future enhancements.
+// DrawOptions provides advanced options for text drawing, consumed by
+// DrawWithOptions. The zero value applies no correction.
 type DrawOptions struct {
 	// Color for the text (default: black)
 	Color color.Color
+
+	// Gamma is the mask gamma for polarity-aware coverage correction
+	// (Skia SkMaskGamma pattern). Values above 1 boost the edge
+	// coverage of light-on-dark text and thin dark-on-light text so
+	// both polarities read with the same weight; 1.4 is a typical
+	// choice. Zero or 1 disables gamma correction.
+	Gamma float64
+
+	// Contrast adds a luminance-independent edge contrast boost in
+	// [0, 1], lifting mid-range coverage (Skia apply_contrast). Zero
+	// disables the boost.
+	Contrast float64
+
+	// StemDarkening boosts glyph coverage at small pixel sizes so thin
+	// stems don't dissolve into fractional coverage (FreeType stem
+	// darkening). The boost ramps up below 24 ppem and has no effect
+	// at larger sizes.
+	StemDarkening bool
 }
@@ -28,9 +28,9 @@ func DrawAliased(dst draw.Image, text string, face Face, x, y float64, col color
 	text = expandTabs(text)
 
 	if vars := sf.Variations(); len(vars) > 0 {
-		drawGlyphsVariable(dst, sf, text, x, y, col, vars, rasterModeAliased)
+		drawGlyphsVariable(dst, sf, text, x, y, col, vars, rasterModeAliased, nil)
 		return
 	}
 
-	drawGlyphs(dst, sf, text, x, y, col, rasterizeAliasedGlyph)
+	drawGlyphs(dst, sf, text, x, y, col, rasterizeAliasedGlyph, nil)
 }
@@ -52,6 +52,18 @@ type Face interface {
 	// Returns nil for faces created without variations.
 	Variations() []FontVariation
 
+	// WithFeatures returns a face derived from this one with the given
+	// OpenType features applied on top of its existing features. A feature
+	// with the same tag replaces the existing entry, so features can be
+	// both enabled and disabled relative to the base face:
+	//
+	//	tabular := face.WithFeatures(text.TabularNums)
+	//	caps := face.WithFeatures(text.SmallCaps, text.NewFontFeature("ss01", 1))
+	//
+	// The receiver is not modified; derived faces are cheap to create and
+	// share the underlying font source.
+	WithFeatures(features ...FontFeature) Face
+
 	// private prevents external implementation
 	private()
 }
@@ -270,5 +282,33 @@ func (f *sourceFace) Variations() []FontVariation {
 	return f.config.variations
 }
 
+// WithFeatures implements Face.WithFeatures.
+func (f *sourceFace) WithFeatures(features ...FontFeature) Face {
+	derived := *f
+	derived.config.features = mergeFeatures(f.config.features, features)
+	return &derived
+}
+
+// mergeFeatures appends extra features to base, replacing entries with the
+// same tag. Neither input slice is modified.
+func mergeFeatures(base, extra []FontFeature) []FontFeature {
+	merged := make([]FontFeature, len(base), len(base)+len(extra))
+	copy(merged, base)
+	for _, ft := range extra {
+		replaced := false
+		for i := range merged {
+			if merged[i].Tag == ft.Tag {
+				merged[i] = ft
+				replaced = true
+				break
+			}
+		}
+		if !replaced {
+			merged = append(merged, ft)
+		}
+	}
+	return merged
+}
+
 // private implements the Face interface.
 func (f *sourceFace) private() {}
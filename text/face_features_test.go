@@ -0,0 +1,84 @@
+package text
+
+import (
+	"os"
+	"testing"
+)
+
+func TestWithFeaturesDerivesFace(t *testing.T) {
+	face := layoutTestFace(t)
+	if len(face.Features()) != 0 {
+		t.Fatalf("base face has unexpected features: %v", face.Features())
+	}
+
+	derived := face.WithFeatures(TabularNums)
+	if len(derived.Features()) != 1 || derived.Features()[0] != TabularNums {
+		t.Errorf("derived features = %v, want [TabularNums]", derived.Features())
+	}
+
+	// The base face is unchanged, and both share size and source.
+	if len(face.Features()) != 0 {
+		t.Errorf("base face mutated: %v", face.Features())
+	}
+	if derived.Size() != face.Size() || derived.Source() != face.Source() {
+		t.Error("derived face does not share size/source with base")
+	}
+}
+
+func TestWithFeaturesReplacesSameTag(t *testing.T) {
+	face := layoutTestFace(t).WithFeatures(NoLigatures)
+
+	// Re-enabling liga replaces the disable entry rather than stacking.
+	enabled := face.WithFeatures(NewFontFeature("liga", 1))
+	features := enabled.Features()
+	if len(features) != 1 {
+		t.Fatalf("features = %v, want single liga entry", features)
+	}
+	if features[0].Value != 1 {
+		t.Errorf("liga value = %d, want 1 (re-enabled)", features[0].Value)
+	}
+}
+
+func TestWithFeaturesAffectsShaping(t *testing.T) {
+	data, err := os.ReadFile("testdata/notoserif_autohint_shaping.ttf")
+	if err != nil {
+		t.Skipf("test font unavailable: %v", err)
+	}
+	source, err := NewFontSource(data)
+	if err != nil {
+		t.Fatalf("NewFontSource failed: %v", err)
+	}
+	defer func() { _ = source.Close() }()
+
+	base := source.Face(16.0)
+	noLiga := base.WithFeatures(NoLigatures, NoDLigatures)
+
+	withLiga := Shape("ffi", base)
+	without := Shape("ffi", noLiga)
+	if len(without) <= len(withLiga) {
+		t.Errorf("disabling ligatures did not increase glyph count: %d vs %d",
+			len(without), len(withLiga))
+	}
+}
+
+func TestWithFeaturesOnWrappers(t *testing.T) {
+	base := layoutTestFace(t)
+
+	filtered := NewFilteredFace(base, UnicodeRange{Start: 0, End: 0x10FFFF})
+	fd := filtered.WithFeatures(SmallCaps)
+	if len(fd.Features()) != 1 {
+		t.Errorf("FilteredFace derived features = %v", fd.Features())
+	}
+	if _, ok := fd.(*FilteredFace); !ok {
+		t.Errorf("FilteredFace.WithFeatures returned %T, want *FilteredFace", fd)
+	}
+
+	multi, err := NewMultiFace(base)
+	if err != nil {
+		t.Fatalf("NewMultiFace failed: %v", err)
+	}
+	md := multi.WithFeatures(OldstyleNums)
+	if len(md.Features()) != 1 {
+		t.Errorf("MultiFace derived features = %v", md.Features())
+	}
+}
@@ -152,6 +152,15 @@ func (f *FilteredFace) Variations() []FontVariation {
 // private implements the Face interface.
 func (f *FilteredFace) private() {}
 
+// WithFeatures implements Face.WithFeatures. The feature change applies to
+// the wrapped face; the Unicode range filter is preserved.
+func (f *FilteredFace) WithFeatures(features ...FontFeature) Face {
+	return &FilteredFace{
+		face:   f.face.WithFeatures(features...),
+		ranges: f.ranges,
+	}
+}
+
 // inRanges reports whether the rune is in any of the allowed ranges.
 // If no ranges are specified, returns true (no filtering).
 func (f *FilteredFace) inRanges(r rune) bool {
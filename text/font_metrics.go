@@ -66,6 +66,8 @@ type os2Metrics struct {
 	sCapHeight     int16  // version >= 2
 	usWinAscent    uint16 // fallback if sTypo is zero
 	usWinDescent   uint16 // fallback if sTypo is zero
+	yStrikeoutSize int16  // strikethrough thickness
+	yStrikeoutPos  int16  // strikethrough position above baseline
 }
 
 // parseOS2Table parses the OS/2 table for font metrics.
@@ -74,6 +76,8 @@ type os2Metrics struct {
 //
 //	offset 0:   uint16  version
 //	offset 2:   int16   xAvgCharWidth
+//	offset 26:  int16   yStrikeoutSize
+//	offset 28:  int16   yStrikeoutPosition
 //	offset 68:  int16   sTypoAscender
 //	offset 70:  int16   sTypoDescender
 //	offset 72:  int16   sTypoLineGap
@@ -94,6 +98,8 @@ func parseOS2Table(data []byte) (os2Metrics, bool) {
 		sTypoLineGap:   int16(binary.BigEndian.Uint16(data[72:74])),
 		usWinAscent:    binary.BigEndian.Uint16(data[74:76]),
 		usWinDescent:   binary.BigEndian.Uint16(data[76:78]),
+		yStrikeoutSize: int16(binary.BigEndian.Uint16(data[26:28])),
+		yStrikeoutPos:  int16(binary.BigEndian.Uint16(data[28:30])),
 	}
 
 	// sxHeight and sCapHeight are only in version 2+.
@@ -144,6 +150,23 @@ func computeFontMetrics(hhea hheaMetrics, os2 os2Metrics, upem int, ppem float64
 	}
 }
 
+// parsePostDecoration extracts the underline metrics from the post table.
+//
+// Layout (relevant prefix):
+//
+//	offset 0:   uint32  version
+//	offset 4:   Fixed   italicAngle
+//	offset 8:   int16   underlinePosition
+//	offset 10:  int16   underlineThickness
+func parsePostDecoration(data []byte) (position, thickness int16, ok bool) {
+	if len(data) < 12 {
+		return 0, 0, false
+	}
+	position = int16(binary.BigEndian.Uint16(data[8:10]))
+	thickness = int16(binary.BigEndian.Uint16(data[10:12]))
+	return position, thickness, true
+}
+
 // hmtxAdvance returns the advance width in font units for the given glyph ID.
 // Glyphs beyond numHMetrics use the last advance width (monospace tail).
 //
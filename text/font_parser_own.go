@@ -6,6 +6,7 @@
 // Implements:
 //   - ParsedFont              (core interface)
 //   - VariableAdvanceProvider  (HVAR-based variable font advance)
+//   - VerticalMetricsProvider  (vhea/vmtx vertical writing metrics)
 //   - RawFontDataProvider      (raw bytes for auto-hinter and TT interpreter)
 //
 // Lazy initialization: table directory is parsed eagerly (cheap), individual
@@ -87,6 +88,18 @@ type ownParsedFont struct {
 	numHMetrics int      // from hhea.numberOfHMetrics
 	hmtxParsed  bool     // true if hmtx was parsed successfully
 
+	vmtxOnce    sync.Once
+	vmtxAdv     []uint16    // advance heights from vmtx
+	vmtxTSB     []int16     // top side bearings from vmtx
+	numVMetrics int         // from vhea.numOfLongVerMetrics
+	vhea        hheaMetrics // from vhea (same layout as hhea)
+	vmtxParsed  bool        // true if vhea and vmtx were parsed successfully
+
+	decoOnce      sync.Once
+	underlinePos  int16 // from post.underlinePosition
+	underlineSize int16 // from post.underlineThickness
+	decoParsed    bool  // true if post or OS/2 provided decoration metrics
+
 	nameOnce   sync.Once
 	familyName string
 	fullName   string
@@ -268,6 +281,56 @@ func (f *ownParsedFont) GlyphAdvanceVar(glyphIndex uint16, ppem float64, variati
 	return baseAdvance + scaledDelta
 }
 
+// --- VerticalMetricsProvider ---
+
+// GlyphVerticalAdvance implements VerticalMetricsProvider.
+// Returns the advance height in pixels from the vmtx table, or ok = false
+// when the font has no vertical metrics.
+func (f *ownParsedFont) GlyphVerticalAdvance(glyphIndex uint16, ppem float64) (float64, bool) {
+	f.ensureVmtx()
+	if !f.vmtxParsed || f.upem == 0 {
+		return 0, false
+	}
+	advFU := hmtxAdvance(f.vmtxAdv, f.numVMetrics, glyphIndex)
+	return float64(advFU) * ppem / float64(f.upem), true
+}
+
+// VerticalMetrics implements VerticalMetricsProvider.
+// Returns vertical ascent, descent and line gap in pixels from the vhea
+// table, or ok = false when the font has no vertical metrics.
+func (f *ownParsedFont) VerticalMetrics(ppem float64) (FontMetrics, bool) {
+	f.ensureVmtx()
+	if !f.vmtxParsed || f.upem == 0 {
+		return FontMetrics{}, false
+	}
+	scale := ppem / float64(f.upem)
+	return FontMetrics{
+		Ascent:  float64(f.vhea.ascent) * scale,
+		Descent: float64(f.vhea.descent) * scale,
+		LineGap: float64(f.vhea.lineGap) * scale,
+	}, true
+}
+
+// --- DecorationMetricsProvider ---
+
+// DecorationMetrics implements DecorationMetricsProvider.
+// Underline metrics come from the post table, strikethrough metrics from
+// OS/2. Returns ok = false when neither table provides decoration data.
+func (f *ownParsedFont) DecorationMetrics(ppem float64) (DecorationMetrics, bool) {
+	f.ensureDecoration()
+	f.ensureMetrics()
+	if f.upem == 0 || (!f.decoParsed && !f.os2OK) {
+		return DecorationMetrics{}, false
+	}
+	scale := ppem / float64(f.upem)
+	return DecorationMetrics{
+		UnderlinePosition:  float64(f.underlinePos) * scale,
+		UnderlineThickness: float64(f.underlineSize) * scale,
+		StrikeoutPosition:  float64(f.os2.yStrikeoutPos) * scale,
+		StrikeoutThickness: float64(f.os2.yStrikeoutSize) * scale,
+	}, true
+}
+
 // --- Lazy initialization helpers ---
 
 // ensureCmap lazily parses the cmap table.
@@ -310,6 +373,49 @@ func (f *ownParsedFont) ensureHmtx() {
 	})
 }
 
+// ensureVmtx lazily parses the vhea and vmtx tables for vertical metrics.
+// The vhea table shares its layout with hhea and the vmtx record layout
+// matches hmtx (advance + side bearing), so both parsers are reused.
+func (f *ownParsedFont) ensureVmtx() {
+	f.vmtxOnce.Do(func() {
+		vheaData, ok := f.tables["vhea"]
+		if !ok {
+			return
+		}
+		vhea, ok := parseHheaTable(vheaData)
+		if !ok || vhea.numberOfHMetrics == 0 {
+			return
+		}
+
+		vmtxData, ok := f.tables["vmtx"]
+		if !ok {
+			return
+		}
+
+		advances, tsbs, err := parseHmtx(vmtxData, vhea.numberOfHMetrics, f.numGlyphs)
+		if err != nil {
+			return
+		}
+
+		f.vmtxAdv = advances
+		f.vmtxTSB = tsbs
+		f.numVMetrics = vhea.numberOfHMetrics
+		f.vhea = vhea
+		f.vmtxParsed = true
+	})
+}
+
+// ensureDecoration lazily parses the post table underline metrics.
+func (f *ownParsedFont) ensureDecoration() {
+	f.decoOnce.Do(func() {
+		postData, ok := f.tables["post"]
+		if !ok {
+			return
+		}
+		f.underlinePos, f.underlineSize, f.decoParsed = parsePostDecoration(postData)
+	})
+}
+
 // ensureName lazily parses the name table.
 func (f *ownParsedFont) ensureName() {
 	f.nameOnce.Do(func() {
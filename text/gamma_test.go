@@ -0,0 +1,141 @@
+package text
+
+import (
+	"image"
+	"image/color"
+	"testing"
+)
+
+// TestCoverageLUTIdentity tests that identity parameters yield no table.
+func TestCoverageLUTIdentity(t *testing.T) {
+	if coverageLUT(1, 0, 0.5) != nil {
+		t.Error("gamma 1, contrast 0 should return nil (identity)")
+	}
+	if coverageLUT(0, 0, 0.5) != nil {
+		t.Error("gamma 0 should be treated as disabled")
+	}
+}
+
+// TestCoverageLUTPolarity tests that light text is boosted and dark
+// text thinned, with the endpoints always preserved.
+func TestCoverageLUTPolarity(t *testing.T) {
+	light := coverageLUT(1.4, 0, 1)
+	dark := coverageLUT(1.4, 0, 0)
+
+	if light[128] <= 128 {
+		t.Errorf("light text mid coverage = %d, want boost above 128", light[128])
+	}
+	if dark[128] >= 128 {
+		t.Errorf("dark text mid coverage = %d, want thinning below 128", dark[128])
+	}
+	for _, lut := range []*[256]uint8{light, dark} {
+		if lut[0] != 0 || lut[255] != 255 {
+			t.Errorf("endpoints not preserved: lut[0]=%d lut[255]=%d", lut[0], lut[255])
+		}
+	}
+}
+
+// TestCoverageLUTMonotonic tests that the remap never inverts coverage order.
+func TestCoverageLUTMonotonic(t *testing.T) {
+	lut := coverageLUT(1.4, 0.5, 0.25)
+	for i := 1; i < 256; i++ {
+		if lut[i] < lut[i-1] {
+			t.Fatalf("lut not monotonic at %d: %d < %d", i, lut[i], lut[i-1])
+		}
+	}
+}
+
+// TestStemDarkenBoost tests the size ramp of the darkening boost.
+func TestStemDarkenBoost(t *testing.T) {
+	if got := stemDarkenBoost(32); got != 0 {
+		t.Errorf("boost at 32ppem = %v, want 0", got)
+	}
+	if got := stemDarkenBoost(8); got != stemDarkenMaxBoost {
+		t.Errorf("boost at 8ppem = %v, want %v", got, stemDarkenMaxBoost)
+	}
+	mid := stemDarkenBoost(16)
+	if mid <= 0 || mid >= stemDarkenMaxBoost {
+		t.Errorf("boost at 16ppem = %v, want between 0 and %v", mid, stemDarkenMaxBoost)
+	}
+	if stemDarkenBoost(12) <= stemDarkenBoost(20) {
+		t.Error("boost should grow as size shrinks")
+	}
+}
+
+// TestMaskLUTStemDarkening tests that stem darkening only produces a
+// table below the ppem threshold.
+func TestMaskLUTStemDarkening(t *testing.T) {
+	opts := &DrawOptions{StemDarkening: true}
+	if opts.maskLUT(48, color.Black) != nil {
+		t.Error("stem darkening at 48ppem should be identity")
+	}
+	if opts.maskLUT(12, color.Black) == nil {
+		t.Error("stem darkening at 12ppem should produce a table")
+	}
+	if (*DrawOptions)(nil).maskLUT(12, color.Black) != nil {
+		t.Error("nil options should be identity")
+	}
+}
+
+// coverageSum totals the alpha channel of an image, a proxy for the
+// total ink coverage of rendered text.
+func coverageSum(img *image.RGBA) int {
+	sum := 0
+	b := img.Bounds()
+	for y := b.Min.Y; y < b.Max.Y; y++ {
+		for x := b.Min.X; x < b.Max.X; x++ {
+			sum += int(img.RGBAAt(x, y).A)
+		}
+	}
+	return sum
+}
+
+// TestDrawWithOptionsGamma tests that gamma correction boosts the
+// coverage of light text relative to the uncorrected draw.
+func TestDrawWithOptionsGamma(t *testing.T) {
+	source, err := NewFontSourceFromFile("testdata/goregular.ttf")
+	if err != nil {
+		t.Fatalf("load font: %v", err)
+	}
+	defer func() { _ = source.Close() }()
+	face := source.Face(14)
+
+	plain := image.NewRGBA(image.Rect(0, 0, 200, 40))
+	Draw(plain, "gamma", face, 10, 28, color.White)
+
+	corrected := image.NewRGBA(image.Rect(0, 0, 200, 40))
+	DrawWithOptions(corrected, "gamma", face, 10, 28, color.White, &DrawOptions{Gamma: 1.4})
+
+	if coverageSum(corrected) <= coverageSum(plain) {
+		t.Error("gamma correction should boost light text coverage")
+	}
+}
+
+// TestDrawWithOptionsStemDarkening tests that stem darkening adds
+// coverage at small sizes and leaves large sizes untouched.
+func TestDrawWithOptionsStemDarkening(t *testing.T) {
+	source, err := NewFontSourceFromFile("testdata/goregular.ttf")
+	if err != nil {
+		t.Fatalf("load font: %v", err)
+	}
+	defer func() { _ = source.Close() }()
+	opts := &DrawOptions{StemDarkening: true}
+
+	small := source.Face(10)
+	plain := image.NewRGBA(image.Rect(0, 0, 200, 40))
+	Draw(plain, "stems", small, 10, 28, color.Black)
+	darkened := image.NewRGBA(image.Rect(0, 0, 200, 40))
+	DrawWithOptions(darkened, "stems", small, 10, 28, color.Black, opts)
+	if coverageSum(darkened) <= coverageSum(plain) {
+		t.Error("stem darkening at 10px should add coverage")
+	}
+
+	large := source.Face(48)
+	plainL := image.NewRGBA(image.Rect(0, 0, 300, 80))
+	Draw(plainL, "stems", large, 10, 60, color.Black)
+	darkenedL := image.NewRGBA(image.Rect(0, 0, 300, 80))
+	DrawWithOptions(darkenedL, "stems", large, 10, 60, color.Black, opts)
+	if coverageSum(darkenedL) != coverageSum(plainL) {
+		t.Error("stem darkening at 48px should be a no-op")
+	}
+}
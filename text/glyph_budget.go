@@ -0,0 +1,205 @@
+package text
+
+import (
+	"sync"
+	"sync/atomic"
+
+	"github.com/gogpu/gg/internal/cache"
+)
+
+// GlyphCacheBudget bounds a per-FontSource glyph outline cache.
+// Zero fields mean "use the default" for MaxEntries and "unlimited" for
+// MaxBytes. A budget with both fields zero still caps the cache at the
+// default entry count.
+type GlyphCacheBudget struct {
+	// MaxEntries is the maximum number of cached outlines.
+	// Default: 4096 (same as GlyphCacheConfig.MaxEntries).
+	MaxEntries int
+
+	// MaxBytes caps the estimated memory of cached outlines. When an
+	// insertion pushes the cache over this limit, least recently used
+	// entries are evicted until it fits. 0 means no byte limit.
+	MaxBytes int64
+}
+
+// GlyphEvictionFunc is called for each outline evicted from a budgeted
+// glyph cache, with the estimated bytes released. Long-running servers
+// can use it to monitor cache churn. The callback runs outside cache
+// locks but must still be fast — it is invoked on the rendering path.
+type GlyphEvictionFunc func(key OutlineCacheKey, bytes int64)
+
+// outlineCache is the lookup interface shared by GlyphCache and the
+// budgeted per-source cache, so the glyph renderer can use either.
+type outlineCache interface {
+	GetOrCreate(key OutlineCacheKey, create func() *GlyphOutline) *GlyphOutline
+}
+
+// budgetedGlyphCache is a per-FontSource outline cache with byte
+// accounting, built on the generic sharded LRU from internal/cache.
+type budgetedGlyphCache struct {
+	cache   *cache.ShardedCache[OutlineCacheKey, *GlyphOutline]
+	budget  GlyphCacheBudget
+	bytes   atomic.Int64
+	onEvict GlyphEvictionFunc
+}
+
+// outlineCacheKeyHasher distributes cache keys across shards.
+// Same mixing as GlyphCache.getShard.
+func outlineCacheKeyHasher(key OutlineCacheKey) uint64 {
+	h := key.FontID
+	h = h*31 + uint64(key.GID)
+	h = h*31 + uint64(int64(key.Size)) //#nosec G115 -- hash only, value can be negative
+	h = h*31 + uint64(key.Hinting)     //#nosec G115 -- Hinting is a small enum value
+	h = h*31 + key.VariationHash
+	return h
+}
+
+// newBudgetedGlyphCache creates a budgeted cache. onEvict may be nil.
+func newBudgetedGlyphCache(budget GlyphCacheBudget, onEvict GlyphEvictionFunc) *budgetedGlyphCache {
+	maxEntries := budget.MaxEntries
+	if maxEntries <= 0 {
+		maxEntries = DefaultGlyphCacheConfig().MaxEntries
+	}
+	perShard := (maxEntries + cache.DefaultShardCount - 1) / cache.DefaultShardCount
+
+	b := &budgetedGlyphCache{
+		cache:   cache.NewSharded[OutlineCacheKey, *GlyphOutline](perShard, outlineCacheKeyHasher),
+		budget:  budget,
+		onEvict: onEvict,
+	}
+	b.cache.SetOnEvict(func(key OutlineCacheKey, outline *GlyphOutline) {
+		released := outlineMemoryBytes(outline)
+		b.bytes.Add(-released)
+		if b.onEvict != nil {
+			b.onEvict(key, released)
+		}
+	})
+	return b
+}
+
+// GetOrCreate implements outlineCache. It tracks the estimated bytes of
+// newly created outlines and enforces the byte budget by evicting LRU
+// entries.
+func (b *budgetedGlyphCache) GetOrCreate(key OutlineCacheKey, create func() *GlyphOutline) *GlyphOutline {
+	created := false
+	outline := b.cache.GetOrCreate(key, func() *GlyphOutline {
+		created = true
+		return create()
+	})
+	if !created {
+		return outline
+	}
+
+	b.bytes.Add(outlineMemoryBytes(outline))
+
+	// Enforce the byte budget. Evicting one entry at a time lets the
+	// eviction hook keep the byte count exact.
+	if b.budget.MaxBytes > 0 {
+		for b.bytes.Load() > b.budget.MaxBytes && b.cache.Len() > 1 {
+			if b.cache.EvictOldest(1) == 0 {
+				break
+			}
+		}
+	}
+	return outline
+}
+
+// stats returns a snapshot of this cache.
+func (b *budgetedGlyphCache) stats() CacheReport {
+	s := b.cache.Stats()
+	return CacheReport{
+		Hits:      s.Hits,
+		Misses:    s.Misses,
+		HitRate:   s.HitRate,
+		Evictions: s.Evictions,
+		Entries:   b.cache.Len(),
+		Bytes:     b.bytes.Load(),
+	}
+}
+
+// outlineMemoryBytes estimates the heap footprint of a cached outline.
+// The estimate covers the GlyphOutline struct and its segment slice;
+// exact allocator overhead is not modeled.
+func outlineMemoryBytes(o *GlyphOutline) int64 {
+	if o == nil {
+		return 0
+	}
+	const outlineHeader = 64 // GlyphOutline struct incl. slice header
+	const segmentBytes = 32  // OutlineSegment: op + 3 points + padding
+	return outlineHeader + int64(o.SegmentCount())*segmentBytes
+}
+
+// CacheReport is a snapshot of glyph cache statistics.
+type CacheReport struct {
+	// Hits and Misses are cumulative lookup counts.
+	Hits   uint64
+	Misses uint64
+
+	// HitRate is Hits / (Hits + Misses), 0.0 to 1.0.
+	HitRate float64
+
+	// Evictions is the cumulative number of evicted entries.
+	Evictions uint64
+
+	// Entries is the current number of cached outlines.
+	Entries int
+
+	// Bytes is the estimated memory held by cached outlines. Only
+	// budgeted per-source caches account bytes exactly; the global
+	// glyph cache contributes an estimate.
+	Bytes int64
+}
+
+// budgetedCacheRegistry tracks active per-source caches so that
+// CacheStats can aggregate them.
+var (
+	budgetedCachesMu sync.Mutex
+	budgetedCaches   = make(map[*budgetedGlyphCache]struct{})
+)
+
+func registerBudgetedCache(b *budgetedGlyphCache) {
+	budgetedCachesMu.Lock()
+	budgetedCaches[b] = struct{}{}
+	budgetedCachesMu.Unlock()
+}
+
+func unregisterBudgetedCache(b *budgetedGlyphCache) {
+	if b == nil {
+		return
+	}
+	budgetedCachesMu.Lock()
+	delete(budgetedCaches, b)
+	budgetedCachesMu.Unlock()
+}
+
+// CacheStats returns aggregate glyph cache statistics: the global glyph
+// cache plus every per-source budgeted cache currently active. Servers
+// can poll this to monitor text memory alongside SetGlyphCacheBudget.
+func CacheStats() CacheReport {
+	global := GetGlobalGlyphCache()
+	hits, misses, evictions, _ := global.Stats()
+
+	report := CacheReport{
+		Hits:      hits,
+		Misses:    misses,
+		Evictions: evictions,
+		Entries:   global.Len(),
+		Bytes:     global.MemoryBytes(),
+	}
+
+	budgetedCachesMu.Lock()
+	for b := range budgetedCaches {
+		s := b.stats()
+		report.Hits += s.Hits
+		report.Misses += s.Misses
+		report.Evictions += s.Evictions
+		report.Entries += s.Entries
+		report.Bytes += s.Bytes
+	}
+	budgetedCachesMu.Unlock()
+
+	if total := report.Hits + report.Misses; total > 0 {
+		report.HitRate = float64(report.Hits) / float64(total)
+	}
+	return report
+}
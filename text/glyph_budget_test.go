@@ -0,0 +1,225 @@
+package text
+
+import (
+	"os"
+	"testing"
+)
+
+// budgetTestOutline returns an outline with the given number of line segments.
+func budgetTestOutline(segments int) *GlyphOutline {
+	o := &GlyphOutline{Segments: make([]OutlineSegment, segments)}
+	for i := range o.Segments {
+		op := OutlineOpLineTo
+		if i == 0 {
+			op = OutlineOpMoveTo
+		}
+		o.Segments[i] = OutlineSegment{
+			Op:     op,
+			Points: [3]OutlinePoint{{X: float32(i), Y: float32(i)}},
+		}
+	}
+	return o
+}
+
+func budgetTestKey(gid uint16) OutlineCacheKey {
+	return OutlineCacheKey{FontID: 1, GID: GlyphID(gid), Size: 16, Hinting: HintingNone}
+}
+
+func TestBudgetedGlyphCache_EntryLimit(t *testing.T) {
+	evictions := 0
+	var evictedBytes int64
+	b := newBudgetedGlyphCache(
+		GlyphCacheBudget{MaxEntries: 16},
+		func(key OutlineCacheKey, bytes int64) {
+			evictions++
+			evictedBytes += bytes
+		},
+	)
+
+	const inserted = 100
+	for i := 0; i < inserted; i++ {
+		b.GetOrCreate(budgetTestKey(uint16(i)), func() *GlyphOutline {
+			return budgetTestOutline(4)
+		})
+	}
+
+	s := b.stats()
+	if s.Entries > 16 {
+		t.Errorf("entries = %d, want <= 16", s.Entries)
+	}
+	if evictions != inserted-s.Entries {
+		t.Errorf("eviction callbacks = %d, want %d", evictions, inserted-s.Entries)
+	}
+
+	// The byte counter must match the surviving entries exactly.
+	perOutline := outlineMemoryBytes(budgetTestOutline(4))
+	if want := int64(s.Entries) * perOutline; s.Bytes != want {
+		t.Errorf("bytes = %d, want %d", s.Bytes, want)
+	}
+	if want := int64(evictions) * perOutline; evictedBytes != want {
+		t.Errorf("evicted bytes = %d, want %d", evictedBytes, want)
+	}
+}
+
+func TestBudgetedGlyphCache_ByteBudget(t *testing.T) {
+	perOutline := outlineMemoryBytes(budgetTestOutline(8))
+	budget := GlyphCacheBudget{MaxBytes: 5 * perOutline}
+	b := newBudgetedGlyphCache(budget, nil)
+
+	for i := 0; i < 50; i++ {
+		b.GetOrCreate(budgetTestKey(uint16(i)), func() *GlyphOutline {
+			return budgetTestOutline(8)
+		})
+		if bytes := b.bytes.Load(); bytes > budget.MaxBytes {
+			t.Fatalf("after insert %d: bytes = %d exceeds budget %d", i, bytes, budget.MaxBytes)
+		}
+	}
+
+	s := b.stats()
+	if s.Entries == 0 {
+		t.Error("byte budget evicted every entry")
+	}
+	if s.Evictions == 0 {
+		t.Error("expected evictions under byte budget")
+	}
+}
+
+func TestBudgetedGlyphCache_HitMiss(t *testing.T) {
+	b := newBudgetedGlyphCache(GlyphCacheBudget{}, nil)
+	key := budgetTestKey(7)
+
+	created := 0
+	for i := 0; i < 3; i++ {
+		b.GetOrCreate(key, func() *GlyphOutline {
+			created++
+			return budgetTestOutline(2)
+		})
+	}
+
+	if created != 1 {
+		t.Errorf("create called %d times, want 1", created)
+	}
+	s := b.stats()
+	if s.Misses != 1 || s.Hits != 2 {
+		t.Errorf("hits/misses = %d/%d, want 2/1", s.Hits, s.Misses)
+	}
+	if s.HitRate <= 0.6 || s.HitRate >= 0.7 {
+		t.Errorf("hit rate = %f, want ~0.667", s.HitRate)
+	}
+}
+
+func TestOutlineMemoryBytes(t *testing.T) {
+	if got := outlineMemoryBytes(nil); got != 0 {
+		t.Errorf("nil outline bytes = %d, want 0", got)
+	}
+	small := outlineMemoryBytes(budgetTestOutline(1))
+	large := outlineMemoryBytes(budgetTestOutline(10))
+	if small <= 0 || large <= small {
+		t.Errorf("estimates not monotonic: %d segments -> %d, 10 -> %d", 1, small, large)
+	}
+}
+
+func TestFontSource_GlyphCacheBudget(t *testing.T) {
+	data, err := os.ReadFile("testdata/goregular.ttf")
+	if err != nil {
+		t.Skipf("test font not available: %v", err)
+	}
+	source, err := NewFontSource(data)
+	if err != nil {
+		t.Fatalf("NewFontSource failed: %v", err)
+	}
+	defer source.Close()
+
+	if _, ok := source.GlyphCacheStats(); ok {
+		t.Error("GlyphCacheStats should report ok=false before a budget is set")
+	}
+
+	var evicted int
+	source.SetGlyphCacheBudget(GlyphCacheBudget{MaxEntries: 64}, func(OutlineCacheKey, int64) {
+		evicted++
+	})
+
+	face := source.Face(16)
+	run := &ShapedRun{
+		Glyphs: []ShapedGlyph{
+			{GID: GlyphID(source.Parsed().GlyphIndex('A'))},
+			{GID: GlyphID(source.Parsed().GlyphIndex('B'))},
+		},
+		Face: face,
+		Size: 16,
+	}
+
+	r := NewGlyphRenderer()
+	outlines := r.RenderRun(run, DefaultRenderParams())
+	if len(outlines) != 2 {
+		t.Fatalf("RenderRun returned %d outlines, want 2", len(outlines))
+	}
+
+	s, ok := source.GlyphCacheStats()
+	if !ok {
+		t.Fatal("GlyphCacheStats should report ok=true after SetGlyphCacheBudget")
+	}
+	if s.Entries != 2 {
+		t.Errorf("per-source entries = %d, want 2", s.Entries)
+	}
+	if s.Bytes <= 0 {
+		t.Errorf("per-source bytes = %d, want > 0", s.Bytes)
+	}
+	if s.Misses != 2 {
+		t.Errorf("per-source misses = %d, want 2", s.Misses)
+	}
+
+	// A second render hits the per-source cache.
+	r.RenderRun(run, DefaultRenderParams())
+	s, _ = source.GlyphCacheStats()
+	if s.Hits != 2 {
+		t.Errorf("per-source hits = %d, want 2", s.Hits)
+	}
+	if evicted != 0 {
+		t.Errorf("unexpected evictions: %d", evicted)
+	}
+
+	// Clearing the budget returns the source to the global cache.
+	source.ClearGlyphCacheBudget()
+	if _, ok := source.GlyphCacheStats(); ok {
+		t.Error("GlyphCacheStats should report ok=false after ClearGlyphCacheBudget")
+	}
+}
+
+func TestCacheStats_Aggregates(t *testing.T) {
+	data, err := os.ReadFile("testdata/goregular.ttf")
+	if err != nil {
+		t.Skipf("test font not available: %v", err)
+	}
+	source, err := NewFontSource(data)
+	if err != nil {
+		t.Fatalf("NewFontSource failed: %v", err)
+	}
+	defer source.Close()
+
+	source.SetGlyphCacheBudget(GlyphCacheBudget{MaxEntries: 64}, nil)
+
+	face := source.Face(16)
+	run := &ShapedRun{
+		Glyphs: []ShapedGlyph{{GID: GlyphID(source.Parsed().GlyphIndex('X'))}},
+		Face:   face,
+		Size:   16,
+	}
+	NewGlyphRenderer().RenderRun(run, DefaultRenderParams())
+
+	perSource, _ := source.GlyphCacheStats()
+	report := CacheStats()
+
+	if report.Entries < perSource.Entries {
+		t.Errorf("aggregate entries %d < per-source entries %d", report.Entries, perSource.Entries)
+	}
+	if report.Bytes < perSource.Bytes {
+		t.Errorf("aggregate bytes %d < per-source bytes %d", report.Bytes, perSource.Bytes)
+	}
+	if report.Misses < perSource.Misses {
+		t.Errorf("aggregate misses %d < per-source misses %d", report.Misses, perSource.Misses)
+	}
+	if report.HitRate < 0 || report.HitRate > 1 {
+		t.Errorf("hit rate = %f, want within [0, 1]", report.HitRate)
+	}
+}
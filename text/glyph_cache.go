@@ -347,6 +347,20 @@ func (c *GlyphCache) Len() int {
 	return total
 }
 
+// MemoryBytes returns the estimated memory held by cached outlines.
+func (c *GlyphCache) MemoryBytes() int64 {
+	var total int64
+	for i := 0; i < numShards; i++ {
+		shard := c.shards[i]
+		shard.mu.RLock()
+		for _, entry := range shard.entries {
+			total += outlineMemoryBytes(entry.outline)
+		}
+		shard.mu.RUnlock()
+	}
+	return total
+}
+
 // Stats returns cache statistics.
 func (c *GlyphCache) Stats() (hits, misses, evictions, insertions uint64) {
 	return c.stats.Hits.Load(),
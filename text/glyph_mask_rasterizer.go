@@ -29,6 +29,17 @@ type GlyphMaskRasterizer struct {
 	// Reusable path buffer to avoid allocations per glyph.
 	pathVerbs  []raster.PathVerb
 	pathPoints []float32
+
+	// Reusable per-glyph rasterization state: edge builders (indexed by
+	// aaShift), the mask buffer, and the result struct. Because these are
+	// reused, a returned GlyphMaskResult — including its Mask bytes — is
+	// only valid until the next Rasterize* call on the same rasterizer.
+	// Callers that keep mask data must copy it first (the glyph mask
+	// atlas copies on Put).
+	edgeBuilders [3]*raster.EdgeBuilder
+	maskBuf      []byte
+	result       GlyphMaskResult
+	scratchPath  glyphPath
 }
 
 // NewGlyphMaskRasterizer creates a new glyph mask rasterizer.
@@ -41,6 +52,9 @@ func NewGlyphMaskRasterizer() *GlyphMaskRasterizer {
 }
 
 // GlyphMaskResult holds the output of rasterizing a single glyph.
+// The result and its Mask alias rasterizer-owned scratch buffers and are
+// valid only until the next Rasterize* call on the same rasterizer; copy
+// the Mask to retain it.
 type GlyphMaskResult struct {
 	// Mask is the R8 alpha buffer (1 byte per pixel, row-major).
 	Mask []byte
@@ -292,16 +306,22 @@ func (r *GlyphMaskRasterizer) rasterizeOutlineCore(
 		return nil, nil //nolint:nilnil // no path segments = nothing to rasterize
 	}
 
-	// Build edges and fill to alpha buffer.
-	eb := raster.NewEdgeBuilder(aaShift)
-	eb.SetFlattenCurves(true)
-	eb.BuildFromPath(&glyphPath{verbs: r.pathVerbs, points: r.pathPoints}, raster.IdentityTransform{})
+	// Build edges and fill to alpha buffer, reusing the per-shift edge
+	// builder and mask buffer across glyphs.
+	eb := r.edgeBuilder(aaShift)
+	r.scratchPath.verbs = r.pathVerbs
+	r.scratchPath.points = r.pathPoints
+	eb.BuildFromPath(&r.scratchPath, raster.IdentityTransform{})
 
 	if eb.IsEmpty() {
 		return nil, nil //nolint:nilnil // no edges produced = nothing to rasterize
 	}
 
-	mask := make([]byte, maskW*maskH)
+	if cap(r.maskBuf) < maskW*maskH {
+		r.maskBuf = make([]byte, maskW*maskH)
+	}
+	mask := r.maskBuf[:maskW*maskH]
+	clear(mask)
 	fill(eb, maskW, maskH, raster.FillRuleNonZero, mask)
 
 	// Compute bearings: offset from glyph origin to mask top-left.
@@ -312,13 +332,33 @@ func (r *GlyphMaskRasterizer) rasterizeOutlineCore(
 	bearingX := float32(pixMinX) - float32(subpixelX)
 	bearingY := float32(-pixMinY) + float32(subpixelY)
 
-	return &GlyphMaskResult{
+	r.result = GlyphMaskResult{
 		Mask:     mask,
 		Width:    maskW,
 		Height:   maskH,
 		BearingX: bearingX,
 		BearingY: bearingY,
-	}, nil
+	}
+	return &r.result, nil
+}
+
+// edgeBuilder returns the reusable edge builder for aaShift, reset and
+// configured for flattened curves.
+func (r *GlyphMaskRasterizer) edgeBuilder(aaShift int) *raster.EdgeBuilder {
+	if aaShift < 0 || aaShift >= len(r.edgeBuilders) {
+		eb := raster.NewEdgeBuilder(aaShift)
+		eb.SetFlattenCurves(true)
+		return eb
+	}
+	eb := r.edgeBuilders[aaShift]
+	if eb == nil {
+		eb = raster.NewEdgeBuilder(aaShift)
+		eb.SetFlattenCurves(true)
+		r.edgeBuilders[aaShift] = eb
+		return eb
+	}
+	eb.Reset()
+	return eb
 }
 
 // buildOutlinePath converts glyph outline segments into the reusable path
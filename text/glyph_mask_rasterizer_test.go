@@ -154,22 +154,27 @@ func TestGlyphMaskRasterizer_SubpixelOffset(t *testing.T) {
 	if err != nil {
 		t.Fatalf("RasterizeOutline(0) error = %v", err)
 	}
+	if result0 == nil {
+		t.Fatal("RasterizeOutline(0) result is nil")
+	}
+	// Results alias rasterizer scratch; snapshot before the next call.
+	snap0 := *result0
+	snap0.Mask = append([]byte(nil), result0.Mask...)
 
 	result05, err := r.RasterizeOutline(outline, 0.5, 0)
 	if err != nil {
 		t.Fatalf("RasterizeOutline(0.5) error = %v", err)
 	}
-
-	if result0 == nil || result05 == nil {
-		t.Fatal("one of the results is nil")
+	if result05 == nil {
+		t.Fatal("RasterizeOutline(0.5) result is nil")
 	}
 
 	// The two masks should differ — either in bearings, dimensions, or pixel content.
-	differs := result0.BearingX != result05.BearingX ||
-		result0.BearingY != result05.BearingY ||
-		result0.Width != result05.Width ||
-		result0.Height != result05.Height ||
-		!masksEqual(result0.Mask, result05.Mask)
+	differs := snap0.BearingX != result05.BearingX ||
+		snap0.BearingY != result05.BearingY ||
+		snap0.Width != result05.Width ||
+		snap0.Height != result05.Height ||
+		!masksEqual(snap0.Mask, result05.Mask)
 
 	if !differs {
 		t.Error("subpixel=0 and subpixel=0.5 produced identical masks — subpixel positioning not working")
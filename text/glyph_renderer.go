@@ -22,6 +22,11 @@ type RenderParams struct {
 	// Variations holds font variation settings for variable fonts (ADR-054).
 	// nil for static fonts. When set, gvar deltas are applied to glyph outlines.
 	Variations []FontVariation
+
+	// Rotated renders glyphs rotated 90 degrees clockwise about their
+	// pen position. Set for horizontal-script runs in vertical layout
+	// (ShapedRun.Rotated).
+	Rotated bool
 }
 
 // DefaultRenderParams returns default rendering parameters.
@@ -114,6 +119,18 @@ func (r *GlyphRenderer) RenderGlyph(
 	font ParsedFont,
 	size float64,
 	params RenderParams,
+) *GlyphOutline {
+	return r.renderGlyphCached(glyph, font, size, params, r.Cache())
+}
+
+// renderGlyphCached is RenderGlyph with an explicit outline cache, so
+// per-source budgeted caches can be substituted for the renderer's own.
+func (r *GlyphRenderer) renderGlyphCached(
+	glyph *ShapedGlyph,
+	font ParsedFont,
+	size float64,
+	params RenderParams,
+	oc outlineCache,
 ) *GlyphOutline {
 	if glyph == nil || font == nil {
 		return nil
@@ -135,7 +152,7 @@ func (r *GlyphRenderer) RenderGlyph(
 	}
 
 	// Get or create outline
-	outline := r.cache.GetOrCreate(key, func() *GlyphOutline {
+	outline := oc.GetOrCreate(key, func() *GlyphOutline {
 		o, err := r.extractor.ExtractOutlineHintedVar(font, glyph.GID, size, HintingNone, params.Variations)
 		if err != nil {
 			return nil
@@ -165,9 +182,20 @@ func (r *GlyphRenderer) RenderGlyphs(
 		return nil
 	}
 
+	return r.renderGlyphsCached(glyphs, font, size, params, r.Cache())
+}
+
+// renderGlyphsCached is RenderGlyphs with an explicit outline cache.
+func (r *GlyphRenderer) renderGlyphsCached(
+	glyphs []ShapedGlyph,
+	font ParsedFont,
+	size float64,
+	params RenderParams,
+	oc outlineCache,
+) []*GlyphOutline {
 	outlines := make([]*GlyphOutline, len(glyphs))
 	for i := range glyphs {
-		outlines[i] = r.RenderGlyph(&glyphs[i], font, size, params)
+		outlines[i] = r.renderGlyphCached(&glyphs[i], font, size, params, oc)
 	}
 
 	return outlines
@@ -189,7 +217,19 @@ func (r *GlyphRenderer) RenderRun(run *ShapedRun, params RenderParams) []*GlyphO
 		params.Variations = run.Face.Variations()
 	}
 
-	return r.RenderGlyphs(run.Glyphs, font, run.Size, params)
+	// Vertical layout marks horizontal-script runs for rotation.
+	if run.Rotated {
+		params.Rotated = true
+	}
+
+	// A source with a glyph cache budget caches its outlines separately
+	// (FontSource.SetGlyphCacheBudget).
+	var oc outlineCache = r.Cache()
+	if bc := run.Face.Source().budgetedCache(); bc != nil {
+		oc = bc
+	}
+
+	return r.renderGlyphsCached(run.Glyphs, font, run.Size, params, oc)
 }
 
 // RenderLayout renders a complete layout to outlines.
@@ -251,6 +291,18 @@ func (r *GlyphRenderer) transformOutline(
 		Tx: float32(glyph.X),
 		Ty: float32(glyph.Y),
 	}
+	if params.Rotated {
+		// 90 degrees clockwise about the pen position: the Y-flip and
+		// rotation compose to (x, y) -> (y + gX, x + gY).
+		glyphTransform = &AffineTransform{
+			A:  0,
+			B:  1,
+			C:  1,
+			D:  0,
+			Tx: float32(glyph.X),
+			Ty: float32(glyph.Y),
+		}
+	}
 
 	// Combine with user transform
 	var finalTransform *AffineTransform
@@ -0,0 +1,204 @@
+package text
+
+// HitPosition is the result of mapping a pixel position to a character
+// boundary in a laid-out string.
+type HitPosition struct {
+	// Line is the index of the hit line in Layout.Lines.
+	Line int
+
+	// Index is the insertion position in source text units — the cluster
+	// value of the boundary the caret should sit at, in logical order.
+	// A hit past the midpoint of a glyph resolves to the boundary after
+	// its cluster (standard caret snapping).
+	Index int
+}
+
+// HitTest maps a pixel position within the layout to the closest character
+// boundary. Coordinates are relative to the layout origin (the same space
+// as Line.Y and glyph X positions). Positions outside the layout clamp to
+// the nearest line and the nearest boundary on that line, so dragging a
+// selection past the edges behaves as editors expect.
+//
+// Bidi runs are handled per run: for a right-to-left run the left half of
+// a glyph maps to the boundary after its cluster in logical order.
+// Ligature clusters hit-test as a unit — the result snaps to the cluster
+// boundary, never inside it.
+func (l *Layout) HitTest(x, y float64) HitPosition {
+	if len(l.Lines) == 0 {
+		return HitPosition{}
+	}
+
+	// Pick the line whose vertical extent contains y; clamp outside hits
+	// to the first/last line.
+	li := len(l.Lines) - 1
+	for i := range l.Lines {
+		if y < l.Lines[i].Y+l.Lines[i].Descent {
+			li = i
+			break
+		}
+	}
+
+	line := &l.Lines[li]
+	if len(line.Glyphs) == 0 {
+		return HitPosition{Line: li, Index: firstClusterInLine(line)}
+	}
+	return HitPosition{Line: li, Index: line.hitTestX(x)}
+}
+
+// hitTestX maps an x position to an insertion index within the line.
+func (line *Line) hitTestX(x float64) int {
+	for ri := range line.Runs {
+		run := &line.Runs[ri]
+		rtl := run.Direction == DirectionRTL
+		for gi := range run.Glyphs {
+			g := &run.Glyphs[gi]
+			if x >= g.X+g.XAdvance {
+				continue
+			}
+			if x < g.X {
+				// Before the visually-first glyph: caret at its leading
+				// visual edge.
+				if rtl {
+					return line.nextClusterBoundary(g.Cluster)
+				}
+				return g.Cluster
+			}
+			trailing := g.XAdvance > 0 && (x-g.X) > g.XAdvance/2
+			if trailing != rtl {
+				return line.nextClusterBoundary(g.Cluster)
+			}
+			return g.Cluster
+		}
+	}
+
+	// Past the visually-last glyph: caret at its trailing visual edge.
+	run := &line.Runs[len(line.Runs)-1]
+	g := &run.Glyphs[len(run.Glyphs)-1]
+	if run.Direction == DirectionRTL {
+		return g.Cluster
+	}
+	return line.nextClusterBoundary(g.Cluster)
+}
+
+// CaretRect returns the caret rectangle for the character boundary at the
+// given insertion index on the given line — the inverse of HitTest. The
+// rectangle has zero width: Min.X == Max.X is the caret x position, and
+// the vertical extent spans the line's ascent to its descent. Indices
+// inside a ligature cluster snap to the cluster's leading edge; indices
+// past the line's text clamp to the line end. Returns the zero Rect if
+// the line index is out of range.
+func (l *Layout) CaretRect(lineIdx, index int) Rect {
+	if lineIdx < 0 || lineIdx >= len(l.Lines) {
+		return Rect{}
+	}
+	line := &l.Lines[lineIdx]
+
+	x := 0.0
+	if len(line.Glyphs) > 0 {
+		x = line.caretX(index)
+	}
+	return Rect{
+		MinX: x,
+		MinY: line.Y - line.Ascent,
+		MaxX: x,
+		MaxY: line.Y + line.Descent,
+	}
+}
+
+// caretX returns the x position of the caret at the given insertion index.
+// The caret sits at the leading edge of the glyph whose cluster covers the
+// index, or at the trailing edge of the logically-last glyph when the
+// index is past every cluster on the line.
+func (line *Line) caretX(index int) float64 {
+	// Find the glyph with the largest cluster <= index (the cluster
+	// covering the index), tracking run direction for edge selection.
+	var best *ShapedGlyph
+	bestRTL := false
+	var last *ShapedGlyph
+	lastRTL := false
+	lastCluster := -1
+
+	for ri := range line.Runs {
+		run := &line.Runs[ri]
+		rtl := run.Direction == DirectionRTL
+		for gi := range run.Glyphs {
+			g := &run.Glyphs[gi]
+			if g.Cluster <= index && (best == nil || g.Cluster > best.Cluster) {
+				best = g
+				bestRTL = rtl
+			}
+			if g.Cluster > lastCluster {
+				lastCluster = g.Cluster
+				last = g
+				lastRTL = rtl
+			}
+		}
+	}
+
+	// Index past the line's text: trailing edge of the logically-last glyph.
+	if last != nil && index > lastCluster && line.nextClusterBoundary(lastCluster) <= index {
+		if lastRTL {
+			return last.X
+		}
+		return last.X + last.XAdvance
+	}
+
+	if best == nil {
+		// Index precedes every cluster: leading edge of the logically-first
+		// glyph, which is the smallest cluster on the line.
+		first := &line.Glyphs[0]
+		firstRTL := false
+		for ri := range line.Runs {
+			run := &line.Runs[ri]
+			for gi := range run.Glyphs {
+				g := &run.Glyphs[gi]
+				if g.Cluster < first.Cluster {
+					first = g
+					firstRTL = run.Direction == DirectionRTL
+				}
+			}
+		}
+		if firstRTL {
+			return first.X + first.XAdvance
+		}
+		return first.X
+	}
+
+	if bestRTL {
+		return best.X + best.XAdvance
+	}
+	return best.X
+}
+
+// nextClusterBoundary returns the smallest cluster value on the line
+// strictly greater than cluster — the next character boundary in logical
+// order. Falls back to cluster+1 when the cluster is the last on the line
+// (single source unit assumed at line end).
+func (line *Line) nextClusterBoundary(cluster int) int {
+	next := -1
+	for i := range line.Glyphs {
+		c := line.Glyphs[i].Cluster
+		if c > cluster && (next < 0 || c < next) {
+			next = c
+		}
+	}
+	if next < 0 {
+		return cluster + 1
+	}
+	return next
+}
+
+// firstClusterInLine returns the smallest cluster on the line, or 0 for
+// empty lines.
+func firstClusterInLine(line *Line) int {
+	first := -1
+	for i := range line.Glyphs {
+		if first < 0 || line.Glyphs[i].Cluster < first {
+			first = line.Glyphs[i].Cluster
+		}
+	}
+	if first < 0 {
+		return 0
+	}
+	return first
+}
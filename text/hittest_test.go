@@ -0,0 +1,161 @@
+package text
+
+import (
+	"testing"
+)
+
+func TestHitTestEmptyLayout(t *testing.T) {
+	l := &Layout{}
+	if got := l.HitTest(10, 10); got != (HitPosition{}) {
+		t.Errorf("empty layout hit = %+v, want zero", got)
+	}
+}
+
+func TestHitTestSingleLine(t *testing.T) {
+	face := layoutTestFace(t)
+	layout := LayoutText("Hello", face, DefaultLayoutOptions())
+	if len(layout.Lines) != 1 {
+		t.Fatalf("want 1 line, got %d", len(layout.Lines))
+	}
+	line := &layout.Lines[0]
+
+	// Far left: caret before the first character.
+	if got := layout.HitTest(-5, line.Y); got.Index != 0 || got.Line != 0 {
+		t.Errorf("left-of-line hit = %+v, want index 0", got)
+	}
+
+	// Far right: caret after the last character.
+	got := layout.HitTest(line.Width+100, line.Y)
+	if got.Index != 5 {
+		t.Errorf("right-of-line hit = %+v, want index 5", got)
+	}
+
+	// Leading half of the first glyph: index 0; trailing half: index 1.
+	g := &line.Glyphs[0]
+	if got := layout.HitTest(g.X+g.XAdvance*0.25, line.Y); got.Index != 0 {
+		t.Errorf("leading-half hit = %+v, want index 0", got)
+	}
+	if got := layout.HitTest(g.X+g.XAdvance*0.75, line.Y); got.Index != 1 {
+		t.Errorf("trailing-half hit = %+v, want index 1", got)
+	}
+}
+
+func TestHitTestClampsToLines(t *testing.T) {
+	face := layoutTestFace(t)
+	layout := LayoutText("one\ntwo\nthree", face, DefaultLayoutOptions())
+	if len(layout.Lines) != 3 {
+		t.Fatalf("want 3 lines, got %d", len(layout.Lines))
+	}
+
+	// Above the first line clamps to line 0.
+	if got := layout.HitTest(0, -100); got.Line != 0 {
+		t.Errorf("above-layout hit line = %d, want 0", got.Line)
+	}
+	// Below the last line clamps to the last line.
+	if got := layout.HitTest(0, layout.Height+100); got.Line != 2 {
+		t.Errorf("below-layout hit line = %d, want 2", got.Line)
+	}
+	// A y within the second line's extent selects it.
+	if got := layout.HitTest(0, layout.Lines[1].Y); got.Line != 1 {
+		t.Errorf("mid-layout hit line = %d, want 1", got.Line)
+	}
+}
+
+func TestCaretRectRoundTrip(t *testing.T) {
+	face := layoutTestFace(t)
+	layout := LayoutText("Hello", face, DefaultLayoutOptions())
+	line := &layout.Lines[0]
+
+	// Every boundary on the line round-trips: CaretRect(HitTest(x)) lands
+	// back at the glyph edge the index refers to.
+	for i := 0; i <= 5; i++ {
+		r := layout.CaretRect(0, i)
+		if r.MinX != r.MaxX {
+			t.Errorf("caret %d not zero-width: %+v", i, r)
+		}
+		if r.MinY >= r.MaxY {
+			t.Errorf("caret %d has no vertical extent: %+v", i, r)
+		}
+
+		// Hitting just right of the caret resolves back to index i
+		// (except at the line end, where there is no glyph to the right).
+		if i < 5 {
+			got := layout.HitTest(r.MinX+0.5, line.Y)
+			if got.Index != i {
+				t.Errorf("round-trip at boundary %d: hit index %d", i, got.Index)
+			}
+		}
+	}
+
+	// Caret at the end of the line sits at the line width.
+	end := layout.CaretRect(0, 5)
+	if d := end.MinX - line.Width; d < -0.5 || d > 0.5 {
+		t.Errorf("end caret x = %v, want ~line width %v", end.MinX, line.Width)
+	}
+}
+
+func TestCaretRectCarets(t *testing.T) {
+	face := layoutTestFace(t)
+	layout := LayoutText("abc", face, DefaultLayoutOptions())
+	line := &layout.Lines[0]
+
+	// Carets increase monotonically for LTR text.
+	prev := -1.0
+	for i := 0; i <= 3; i++ {
+		r := layout.CaretRect(0, i)
+		if r.MinX <= prev {
+			t.Errorf("caret %d x = %v not increasing (prev %v)", i, r.MinX, prev)
+		}
+		prev = r.MinX
+	}
+
+	// Vertical extent matches the line.
+	r := layout.CaretRect(0, 0)
+	if r.MinY != line.Y-line.Ascent || r.MaxY != line.Y+line.Descent {
+		t.Errorf("caret vertical extent [%v, %v], want [%v, %v]",
+			r.MinY, r.MaxY, line.Y-line.Ascent, line.Y+line.Descent)
+	}
+
+	// Out-of-range line index returns the zero Rect.
+	if got := layout.CaretRect(7, 0); got != (Rect{}) {
+		t.Errorf("out-of-range line caret = %+v, want zero", got)
+	}
+}
+
+func TestHitTestRTLRun(t *testing.T) {
+	// Build a synthetic RTL line directly: three glyphs laid out visually
+	// left-to-right but in reverse logical order (clusters 2, 1, 0), as a
+	// bidi reordering pass produces.
+	line := Line{
+		Runs: []ShapedRun{{
+			Glyphs: []ShapedGlyph{
+				{Cluster: 2, X: 0, XAdvance: 10},
+				{Cluster: 1, X: 10, XAdvance: 10},
+				{Cluster: 0, X: 20, XAdvance: 10},
+			},
+			Advance:   30,
+			Direction: DirectionRTL,
+		}},
+		Ascent:  10,
+		Descent: 4,
+	}
+	line.Glyphs = line.Runs[0].Glyphs
+	layout := &Layout{Lines: []Line{line}, Width: 30, Height: 14}
+
+	// Left half of the leftmost glyph (cluster 2) is logically after it.
+	if got := layout.HitTest(2, 0); got.Index != 3 {
+		t.Errorf("RTL left-half hit = %+v, want index 3", got)
+	}
+	// Right half of the leftmost glyph: boundary before cluster 2.
+	if got := layout.HitTest(8, 0); got.Index != 2 {
+		t.Errorf("RTL right-half hit = %+v, want index 2", got)
+	}
+	// Caret at index 0 sits at the right edge (logical start of RTL text).
+	if r := layout.CaretRect(0, 0); r.MinX != 30 {
+		t.Errorf("RTL start caret x = %v, want 30", r.MinX)
+	}
+	// Caret at index 3 sits at the left edge (logical end).
+	if r := layout.CaretRect(0, 3); r.MinX != 0 {
+		t.Errorf("RTL end caret x = %v, want 0", r.MinX)
+	}
+}
@@ -51,6 +51,11 @@ type LayoutOptions struct {
 
 	// Direction is the base text direction (LTR or RTL).
 	// Used for paragraph-level direction when no strong directional text is present.
+	//
+	// DirectionTTB selects vertical writing mode: paragraphs become
+	// columns laid out right to left, CJK glyphs stay upright using
+	// vhea/vmtx metrics, and horizontal-script runs are rotated 90
+	// degrees clockwise. See layoutTextVertical.
 	Direction Direction
 
 	// WrapMode specifies how text is wrapped when it exceeds MaxWidth.
@@ -91,6 +96,11 @@ type Line struct {
 
 	// Y is the baseline Y position of this line within the layout.
 	Y float64
+
+	// X is the baseline X position of this line within the layout.
+	// Horizontal layouts leave it 0 (glyph X positions are absolute);
+	// vertical layouts use it for the column's central axis.
+	X float64
 }
 
 // Height returns the total height of the line (ascent + descent).
@@ -148,6 +158,12 @@ func LayoutTextWithContext(ctx context.Context, text string, face Face, opts Lay
 		opts.LineSpacing = 1.0
 	}
 
+	// Vertical writing mode uses a dedicated pipeline (columns instead
+	// of lines, vmtx advances, glyph rotation for horizontal scripts).
+	if opts.Direction.IsVertical() {
+		return layoutTextVertical(ctx, text, face, opts)
+	}
+
 	// Get font metrics for line height calculation
 	metrics := face.Metrics()
 
@@ -0,0 +1,267 @@
+package text
+
+import "context"
+
+// Vertical writing mode (CJK tategaki).
+//
+// Paragraphs become columns laid out right to left. CJK glyphs stay
+// upright and advance downward using vmtx advance heights (falling back
+// to the em height when the font has no vertical metrics). Runs of
+// horizontal scripts (Latin, digits) are rotated 90 degrees clockwise and
+// advance by their horizontal widths, matching common CJK typesetting
+// practice for embedded Latin text.
+//
+// The GSUB features 'vert' and 'vrt2' are applied during shaping so that
+// fonts substitute vertical forms (rotated punctuation, brackets).
+
+// verticalGlyph pairs a shaped glyph with its vertical flow advance
+// during column construction.
+type verticalGlyph struct {
+	glyph   ShapedGlyph
+	rotated bool
+	advance float64 // advance along the flow (downward) axis
+}
+
+// layoutTextVertical lays out text in vertical writing mode. Called from
+// LayoutTextWithContext when opts.Direction is vertical.
+//
+// In the resulting Layout, glyph X and Y positions are absolute: Line.X
+// records each column's central axis and Line.Y is 0. Line.Width holds
+// the column's flow length (its height on screen), and opts.MaxWidth
+// limits that flow length (column wrapping).
+func layoutTextVertical(ctx context.Context, text string, face Face, opts LayoutOptions) (*Layout, error) {
+	metrics := face.Metrics()
+	size := face.Size()
+
+	// Shape with vertical substitution features enabled.
+	vface := face.WithFeatures(VerticalForms, VerticalRotation)
+
+	// Vertical metrics provider (vhea/vmtx), when the font has one.
+	var vmp VerticalMetricsProvider
+	if source := vface.Source(); source != nil {
+		vmp, _ = source.Parsed().(VerticalMetricsProvider)
+	}
+
+	// Cross-axis column extents: right (ascent) and left (descent) of the
+	// central baseline. Prefer vhea; fall back to horizontal metrics.
+	colAscent, colDescent := metrics.Ascent, metrics.Descent
+	if vmp != nil {
+		if vm, ok := vmp.VerticalMetrics(size); ok {
+			colAscent = vm.Ascent
+			colDescent = -vm.Descent // FontMetrics stores descent negative
+		}
+	}
+
+	// Fallback flow advance for upright glyphs without vmtx: the em box.
+	fallbackAdvance := metrics.Ascent + metrics.Descent
+
+	paragraphs := splitParagraphs(text)
+	columns := make([]Line, 0, len(paragraphs))
+
+	for i, para := range paragraphs {
+		// Check for cancellation periodically (every 8 paragraphs)
+		if i%8 == 0 && i > 0 {
+			select {
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			default:
+			}
+		}
+
+		glyphs := shapeVerticalParagraph(para, vface, vmp, size, fallbackAdvance)
+		cols := buildColumns(glyphs, vface, opts, metrics)
+		columns = append(columns, cols...)
+	}
+
+	layout := &Layout{Lines: columns}
+	if len(columns) == 0 {
+		return layout, nil
+	}
+
+	// Position columns right to left, mirroring the horizontal baseline
+	// spacing formula (descent + gap + ascent between adjacent columns).
+	gap := metrics.LineGap * opts.LineSpacing
+	n := float64(len(columns))
+	layout.Width = n*(colAscent+colDescent) + (n-1)*gap
+
+	x := layout.Width - colAscent
+	for i := range columns {
+		col := &columns[i]
+		col.X = x
+		col.Ascent = colAscent
+		col.Descent = colDescent
+		for j := range col.Glyphs {
+			col.Glyphs[j].X += x
+		}
+		for j := range col.Runs {
+			for k := range col.Runs[j].Glyphs {
+				col.Runs[j].Glyphs[k].X += x
+			}
+		}
+
+		// Alignment along the flow axis (AlignLeft = top).
+		offset := applyVerticalAlignment(col, opts.Alignment, opts.MaxWidth)
+
+		if col.Width+offset > layout.Height {
+			layout.Height = col.Width + offset
+		}
+		x -= colAscent + colDescent + gap
+	}
+
+	return layout, nil
+}
+
+// shapeVerticalParagraph shapes one paragraph and computes per-glyph flow
+// advances. CJK glyphs stay upright and use vmtx advance heights;
+// horizontal-script glyphs are marked rotated and advance by their
+// horizontal widths.
+func shapeVerticalParagraph(para string, face Face, vmp VerticalMetricsProvider, size, fallbackAdvance float64) []verticalGlyph {
+	if para == "" {
+		return nil
+	}
+
+	segmenter := NewBuiltinSegmenterWithDirection(DirectionLTR)
+	segments := segmenter.Segment(para)
+
+	glyphs := make([]verticalGlyph, 0, len(para))
+	for _, seg := range segments {
+		shaped := Shape(seg.Text, face)
+		for _, g := range shaped {
+			g.Cluster += seg.Start
+
+			vg := verticalGlyph{glyph: g, rotated: !g.IsCJK}
+			if vg.rotated {
+				vg.advance = g.XAdvance
+			} else {
+				var ok bool
+				if vmp != nil {
+					vg.advance, ok = vmp.GlyphVerticalAdvance(uint16(g.GID), size)
+				}
+				if !ok {
+					vg.advance = fallbackAdvance
+				}
+			}
+			glyphs = append(glyphs, vg)
+		}
+	}
+	return glyphs
+}
+
+// buildColumns wraps a vertical glyph stream into one or more columns.
+// opts.MaxWidth limits the flow length of a column; like the horizontal
+// placeholder heuristic, every glyph boundary is a break opportunity.
+func buildColumns(glyphs []verticalGlyph, face Face, opts LayoutOptions, metrics Metrics) []Line {
+	if len(glyphs) == 0 {
+		// Empty paragraph still produces a column (for column width).
+		return []Line{{
+			Ascent:  metrics.Ascent,
+			Descent: metrics.Descent,
+		}}
+	}
+
+	wrap := opts.MaxWidth > 0 && opts.WrapMode != WrapNone
+
+	columns := make([]Line, 0, 1)
+	start := 0
+	var flow float64
+
+	for i, vg := range glyphs {
+		if wrap && i > start && flow+vg.advance > opts.MaxWidth {
+			columns = append(columns, buildColumn(glyphs[start:i], face, metrics))
+			start = i
+			flow = 0
+		}
+		flow += vg.advance
+	}
+	columns = append(columns, buildColumn(glyphs[start:], face, metrics))
+
+	return columns
+}
+
+// buildColumn positions glyphs within a single column and groups
+// consecutive glyphs of the same orientation into runs.
+//
+// Upright glyphs are centered on the column axis (X = -width/2) with the
+// horizontal baseline placed an ascent below the cell top. Rotated glyphs
+// keep their baseline on the axis; the pen position is the cell top.
+func buildColumn(glyphs []verticalGlyph, face Face, metrics Metrics) Line {
+	line := Line{
+		Glyphs:  make([]ShapedGlyph, 0, len(glyphs)),
+		Ascent:  metrics.Ascent,
+		Descent: metrics.Descent,
+	}
+
+	var y float64
+	var run *ShapedRun
+
+	for _, vg := range glyphs {
+		g := vg.glyph
+		g.YAdvance = vg.advance
+		if vg.rotated {
+			g.X = 0
+			g.Y = y
+		} else {
+			g.X = -g.XAdvance / 2
+			g.Y = y + metrics.Ascent
+		}
+		y += vg.advance
+
+		if run == nil || run.Rotated != vg.rotated {
+			line.Runs = append(line.Runs, ShapedRun{
+				Direction: DirectionTTB,
+				Rotated:   vg.rotated,
+				Ascent:    metrics.Ascent,
+				Descent:   metrics.Descent,
+				Face:      face,
+				Size:      face.Size(),
+			})
+			run = &line.Runs[len(line.Runs)-1]
+		}
+		run.Glyphs = append(run.Glyphs, g)
+		run.Advance += vg.advance
+
+		line.Glyphs = append(line.Glyphs, g)
+	}
+
+	line.Width = y
+	return line
+}
+
+// applyVerticalAlignment adjusts glyph flow positions within the column
+// height (AlignLeft = top, AlignRight = bottom) and returns the applied
+// offset.
+func applyVerticalAlignment(col *Line, alignment Alignment, maxHeight float64) float64 {
+	if len(col.Glyphs) == 0 {
+		return 0
+	}
+
+	container := maxHeight
+	if container <= 0 {
+		container = col.Width
+	}
+
+	var offset float64
+	switch alignment {
+	case AlignCenter:
+		offset = (container - col.Width) / 2
+	case AlignRight:
+		offset = container - col.Width
+	default:
+		// AlignLeft and AlignJustify: flush to the top.
+		return 0
+	}
+
+	if offset <= 0 {
+		return 0
+	}
+
+	for i := range col.Glyphs {
+		col.Glyphs[i].Y += offset
+	}
+	for i := range col.Runs {
+		for j := range col.Runs[i].Glyphs {
+			col.Runs[i].Glyphs[j].Y += offset
+		}
+	}
+	return offset
+}
@@ -0,0 +1,179 @@
+package text
+
+import (
+	"encoding/binary"
+	"math"
+	"testing"
+)
+
+func verticalLayoutOptions() LayoutOptions {
+	opts := DefaultLayoutOptions()
+	opts.Direction = DirectionTTB
+	return opts
+}
+
+func TestLayoutVerticalLatinRotated(t *testing.T) {
+	face := layoutTestFace(t)
+	layout := LayoutText("Hi", face, verticalLayoutOptions())
+
+	if len(layout.Lines) != 1 {
+		t.Fatalf("want 1 column, got %d", len(layout.Lines))
+	}
+	col := &layout.Lines[0]
+	if len(col.Runs) != 1 || !col.Runs[0].Rotated {
+		t.Fatalf("Latin text should produce one rotated run, got %+v", col.Runs)
+	}
+	if col.Runs[0].Direction != DirectionTTB {
+		t.Errorf("run direction = %v, want TTB", col.Runs[0].Direction)
+	}
+
+	if len(col.Glyphs) != 2 {
+		t.Fatalf("want 2 glyphs, got %d", len(col.Glyphs))
+	}
+	g0, g1 := &col.Glyphs[0], &col.Glyphs[1]
+	if g0.YAdvance != g0.XAdvance || g0.YAdvance <= 0 {
+		t.Errorf("rotated glyph YAdvance = %v, want horizontal advance %v", g0.YAdvance, g0.XAdvance)
+	}
+	if math.Abs(g1.Y-(g0.Y+g0.YAdvance)) > 1e-9 {
+		t.Errorf("glyph 1 Y = %v, want %v", g1.Y, g0.Y+g0.YAdvance)
+	}
+	if math.Abs(col.Width-(g0.YAdvance+g1.YAdvance)) > 1e-9 {
+		t.Errorf("column flow length = %v, want %v", col.Width, g0.YAdvance+g1.YAdvance)
+	}
+	if layout.Height != col.Width {
+		t.Errorf("layout height = %v, want %v", layout.Height, col.Width)
+	}
+}
+
+func TestLayoutVerticalCJKUpright(t *testing.T) {
+	face := layoutTestFace(t)
+	metrics := face.Metrics()
+	layout := LayoutText("日本", face, verticalLayoutOptions())
+
+	if len(layout.Lines) != 1 {
+		t.Fatalf("want 1 column, got %d", len(layout.Lines))
+	}
+	col := &layout.Lines[0]
+	if len(col.Runs) != 1 || col.Runs[0].Rotated {
+		t.Fatalf("CJK text should produce one upright run, got %+v", col.Runs)
+	}
+
+	// The test font has no vmtx, so upright glyphs fall back to the em
+	// box (ascent + descent) for their vertical advance.
+	want := metrics.Ascent + metrics.Descent
+	for i := range col.Glyphs {
+		if math.Abs(col.Glyphs[i].YAdvance-want) > 1e-9 {
+			t.Errorf("glyph %d YAdvance = %v, want %v", i, col.Glyphs[i].YAdvance, want)
+		}
+	}
+
+	// First glyph's horizontal baseline sits an ascent below the cell top.
+	if math.Abs(col.Glyphs[0].Y-(metrics.Ascent)) > 1e-9 {
+		t.Errorf("glyph 0 Y = %v, want %v", col.Glyphs[0].Y, metrics.Ascent)
+	}
+}
+
+func TestLayoutVerticalColumnsRightToLeft(t *testing.T) {
+	face := layoutTestFace(t)
+	layout := LayoutText("one\ntwo", face, verticalLayoutOptions())
+
+	if len(layout.Lines) != 2 {
+		t.Fatalf("want 2 columns, got %d", len(layout.Lines))
+	}
+	first, second := &layout.Lines[0], &layout.Lines[1]
+	if first.X <= second.X {
+		t.Errorf("first column X = %v should be right of second column X = %v", first.X, second.X)
+	}
+	if layout.Width <= first.Ascent+first.Descent {
+		t.Errorf("layout width = %v, want more than one column width %v",
+			layout.Width, first.Ascent+first.Descent)
+	}
+
+	// Glyph X positions are absolute: each column's glyphs sit near its axis.
+	for _, g := range first.Glyphs {
+		if math.Abs(g.X-first.X) > first.Ascent+first.Descent {
+			t.Errorf("glyph X = %v too far from column axis %v", g.X, first.X)
+		}
+	}
+}
+
+func TestLayoutVerticalWrap(t *testing.T) {
+	face := layoutTestFace(t)
+	opts := verticalLayoutOptions()
+	opts.MaxWidth = face.Size() * 2 // only a couple of glyphs per column
+
+	layout := LayoutText("wrapping", face, opts)
+	if len(layout.Lines) < 2 {
+		t.Fatalf("want multiple columns from wrapping, got %d", len(layout.Lines))
+	}
+	for i := range layout.Lines {
+		col := &layout.Lines[i]
+		if len(col.Glyphs) > 1 && col.Width > opts.MaxWidth+1e-9 {
+			t.Errorf("column %d flow length %v exceeds MaxWidth %v", i, col.Width, opts.MaxWidth)
+		}
+	}
+}
+
+func TestLayoutVerticalEmpty(t *testing.T) {
+	face := layoutTestFace(t)
+	layout := LayoutText("", face, verticalLayoutOptions())
+	if len(layout.Lines) != 0 {
+		t.Errorf("empty text should produce no columns, got %d", len(layout.Lines))
+	}
+}
+
+// buildVerticalTables constructs minimal vhea and vmtx tables for the
+// provider tests: ascent/descent/lineGap plus per-glyph advance heights.
+func buildVerticalTables(ascent, descent, lineGap int16, advances []uint16) (vhea, vmtx []byte) {
+	vhea = make([]byte, 36)
+	binary.BigEndian.PutUint16(vhea[4:6], uint16(ascent))          // #nosec G115
+	binary.BigEndian.PutUint16(vhea[6:8], uint16(descent))         // #nosec G115
+	binary.BigEndian.PutUint16(vhea[8:10], uint16(lineGap))        // #nosec G115
+	binary.BigEndian.PutUint16(vhea[34:36], uint16(len(advances))) // #nosec G115
+
+	vmtx = make([]byte, len(advances)*4)
+	for i, adv := range advances {
+		binary.BigEndian.PutUint16(vmtx[i*4:], adv)
+	}
+	return vhea, vmtx
+}
+
+func TestGlyphVerticalAdvance(t *testing.T) {
+	vhea, vmtx := buildVerticalTables(500, -500, 0, []uint16{1000, 800})
+	font := &ownParsedFont{
+		tables:    map[string][]byte{"vhea": vhea, "vmtx": vmtx},
+		upem:      1000,
+		numGlyphs: 2,
+	}
+
+	adv, ok := font.GlyphVerticalAdvance(0, 16)
+	if !ok || math.Abs(adv-16) > 1e-9 {
+		t.Errorf("GlyphVerticalAdvance(0) = %v, %v; want 16, true", adv, ok)
+	}
+	adv, ok = font.GlyphVerticalAdvance(1, 16)
+	if !ok || math.Abs(adv-12.8) > 1e-9 {
+		t.Errorf("GlyphVerticalAdvance(1) = %v, %v; want 12.8, true", adv, ok)
+	}
+
+	vm, ok := font.VerticalMetrics(500)
+	if !ok {
+		t.Fatal("VerticalMetrics should be available")
+	}
+	if math.Abs(vm.Ascent-250) > 1e-9 || math.Abs(vm.Descent+250) > 1e-9 {
+		t.Errorf("VerticalMetrics = %+v, want ascent 250 descent -250", vm)
+	}
+}
+
+func TestGlyphVerticalAdvanceMissingTables(t *testing.T) {
+	font := &ownParsedFont{
+		tables:    map[string][]byte{},
+		upem:      1000,
+		numGlyphs: 1,
+	}
+	if _, ok := font.GlyphVerticalAdvance(0, 16); ok {
+		t.Error("GlyphVerticalAdvance should report ok = false without vhea/vmtx")
+	}
+	if _, ok := font.VerticalMetrics(16); ok {
+		t.Error("VerticalMetrics should report ok = false without vhea/vmtx")
+	}
+}
@@ -108,6 +108,11 @@ type Atlas struct {
 	// index is the atlas index in the manager.
 	index int
 
+	// sealed marks an atlas adopted via Preload: its glyphs serve cache
+	// hits but no new glyphs are allocated into it (the shelf-packed
+	// layout is not compatible with the grid allocator).
+	sealed bool
+
 	// glyphSize is the size of each glyph cell.
 	glyphSize int
 }
@@ -169,7 +174,7 @@ func (a *Atlas) copyMSDF(msdf *MSDF, x, y int) {
 
 // IsFull returns true if no more glyphs can be added.
 func (a *Atlas) IsFull() bool {
-	return a.allocator.IsFull()
+	return a.sealed || a.allocator.IsFull()
 }
 
 // GlyphCount returns the number of glyphs in this atlas.
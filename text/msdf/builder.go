@@ -0,0 +1,264 @@
+package msdf
+
+import (
+	"fmt"
+
+	"github.com/gogpu/gg/text"
+)
+
+// BuiltPage is one finished atlas page produced by an AtlasBuilder.
+type BuiltPage struct {
+	// Data is the RGB pixel data (3 bytes per pixel).
+	Data []byte
+
+	// Size is width = height of the page.
+	Size int
+}
+
+// BuiltAtlas is the result of a batch atlas build: one or more packed pages
+// plus the region table describing where each glyph landed. A BuiltAtlas can
+// be installed into an [AtlasManager] via Preload and persisted with
+// [DiskCache].
+type BuiltAtlas struct {
+	// Config is the atlas configuration the pages were built with.
+	Config AtlasConfig
+
+	// FontChecksum versions the atlas against the source font data.
+	// See [FontChecksum]. Zero if the builder was fed glyphs from
+	// multiple fonts or the checksum is unknown.
+	FontChecksum uint64
+
+	// Pages are the packed atlas pages, indexed by Region.AtlasIndex.
+	Pages []BuiltPage
+
+	// Regions maps each glyph to its location within Pages.
+	Regions map[GlyphKey]Region
+}
+
+// builderEntry is a queued glyph awaiting Build.
+type builderEntry struct {
+	key     GlyphKey
+	outline *text.GlyphOutline
+}
+
+// AtlasBuilder batch-generates glyph MSDFs into shelf-packed atlas pages.
+//
+// Unlike [AtlasManager], which generates glyphs one miss at a time into
+// grid-allocated atlases, the builder collects a whole glyph set up front
+// (e.g. the ASCII range of a font) and packs it in one pass using
+// [ShelfAllocator]. The resulting [BuiltAtlas] is self-contained and
+// serializable, which enables disk caching of per-font atlases.
+//
+// Usage:
+//
+//	builder, _ := msdf.NewAtlasBuilder(cfg)
+//	for _, g := range glyphs {
+//	    builder.Add(g.Key, g.Outline)
+//	}
+//	built, err := builder.Build()
+//
+// AtlasBuilder is not safe for concurrent use.
+type AtlasBuilder struct {
+	config    AtlasConfig
+	generator *Generator
+	entries   []builderEntry
+	seen      map[GlyphKey]struct{}
+}
+
+// NewAtlasBuilder creates a builder with the given atlas configuration.
+func NewAtlasBuilder(config AtlasConfig) (*AtlasBuilder, error) {
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+
+	genConfig := DefaultConfig()
+	genConfig.Size = config.GlyphSize
+
+	return &AtlasBuilder{
+		config:    config,
+		generator: NewGenerator(genConfig),
+		seen:      make(map[GlyphKey]struct{}),
+	}, nil
+}
+
+// Generator returns the MSDF generator used by this builder, allowing
+// callers to adjust generation parameters (e.g. Range) before Build.
+func (b *AtlasBuilder) Generator() *Generator {
+	return b.generator
+}
+
+// Add queues a glyph for the next Build. Duplicate keys and nil or empty
+// outlines are ignored.
+func (b *AtlasBuilder) Add(key GlyphKey, outline *text.GlyphOutline) {
+	if outline == nil || outline.IsEmpty() {
+		return
+	}
+	if _, ok := b.seen[key]; ok {
+		return
+	}
+	b.seen[key] = struct{}{}
+	b.entries = append(b.entries, builderEntry{key: key, outline: outline})
+}
+
+// Len returns the number of glyphs queued for Build.
+func (b *AtlasBuilder) Len() int {
+	return len(b.entries)
+}
+
+// Build generates MSDFs for all queued glyphs and packs them into
+// shelf-allocated pages. The queue is consumed; the builder can be reused
+// for another batch afterwards.
+func (b *AtlasBuilder) Build() (*BuiltAtlas, error) {
+	built := &BuiltAtlas{
+		Config:  b.config,
+		Regions: make(map[GlyphKey]Region, len(b.entries)),
+	}
+
+	size := b.config.Size
+	glyphSize := b.config.GlyphSize
+	var shelf *ShelfAllocator
+
+	for _, entry := range b.entries {
+		msdf, err := b.generator.Generate(entry.outline)
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate MSDF for key %v: %w", entry.key, err)
+		}
+		msdf = MedianFilter(msdf)
+		ErrorCorrection(msdf, msdfErrorCorrectionThreshold)
+
+		// Allocate a cell, opening a new page when the current one is full.
+		var x, y int
+		var ok bool
+		if shelf != nil {
+			x, y, ok = shelf.AllocateFixed(glyphSize)
+		}
+		if !ok {
+			if len(built.Pages) >= b.config.MaxAtlases {
+				return nil, &AtlasFullError{MaxAtlases: b.config.MaxAtlases}
+			}
+			shelf = NewShelfAllocator(size, size, b.config.Padding)
+			built.Pages = append(built.Pages, BuiltPage{
+				Data: make([]byte, size*size*3),
+				Size: size,
+			})
+			x, y, ok = shelf.AllocateFixed(glyphSize)
+			if !ok {
+				return nil, ErrAllocationFailed
+			}
+		}
+
+		page := &built.Pages[len(built.Pages)-1]
+		copyMSDFInto(page.Data, size, glyphSize, msdf, x, y)
+
+		built.Regions[entry.key] = makeRegion(
+			len(built.Pages)-1, x, y, glyphSize, size, msdf)
+	}
+
+	b.entries = b.entries[:0]
+	b.seen = make(map[GlyphKey]struct{})
+
+	return built, nil
+}
+
+// makeRegion computes the Region for a glyph cell, applying the half-texel
+// UV inset and the corresponding planeBounds inset (see AtlasManager.Get
+// for the rationale).
+func makeRegion(pageIndex, x, y, glyphSize, atlasSize int, msdf *MSDF) Region {
+	planeMinX, planeMinY := msdf.PixelToOutline(0, 0)
+	planeMaxX, planeMaxY := msdf.PixelToOutline(float64(msdf.Width), float64(msdf.Height))
+
+	size := float32(atlasSize)
+	halfTexel := float32(0.5) / size
+	halfPixelInOutline := 0.5 / msdf.Scale
+
+	return Region{
+		AtlasIndex: pageIndex,
+		X:          x,
+		Y:          y,
+		Width:      glyphSize,
+		Height:     glyphSize,
+		U0:         float32(x)/size + halfTexel,
+		V0:         float32(y)/size + halfTexel,
+		U1:         float32(x+glyphSize)/size - halfTexel,
+		V1:         float32(y+glyphSize)/size - halfTexel,
+		PlaneMinX:  float32(planeMinX + halfPixelInOutline),
+		PlaneMinY:  float32(planeMinY + halfPixelInOutline),
+		PlaneMaxX:  float32(planeMaxX - halfPixelInOutline),
+		PlaneMaxY:  float32(planeMaxY - halfPixelInOutline),
+	}
+}
+
+// copyMSDFInto copies MSDF data into raw page data at the given position,
+// scaling to the cell size with nearest-neighbor (same as Atlas.copyMSDF).
+func copyMSDFInto(data []byte, pageSize, cellSize int, msdf *MSDF, x, y int) {
+	if msdf == nil {
+		return
+	}
+	for dy := 0; dy < cellSize; dy++ {
+		srcY := dy * msdf.Height / cellSize
+		if srcY >= msdf.Height {
+			srcY = msdf.Height - 1
+		}
+		for dx := 0; dx < cellSize; dx++ {
+			srcX := dx * msdf.Width / cellSize
+			if srcX >= msdf.Width {
+				srcX = msdf.Width - 1
+			}
+			r, g, b := msdf.GetPixel(srcX, srcY)
+			dstX := x + dx
+			dstY := y + dy
+			if dstX >= 0 && dstX < pageSize && dstY >= 0 && dstY < pageSize {
+				offset := (dstY*pageSize + dstX) * 3
+				data[offset] = r
+				data[offset+1] = g
+				data[offset+2] = b
+			}
+		}
+	}
+}
+
+// Preload installs prebuilt atlas pages into the manager. The pages are
+// adopted as sealed atlases: their glyphs serve cache hits but no new
+// glyphs are allocated into them. Regions for keys already in the manager
+// are kept, not overwritten. The built pages must match the manager's
+// atlas size.
+func (m *AtlasManager) Preload(built *BuiltAtlas) error {
+	if built == nil || len(built.Pages) == 0 {
+		return nil
+	}
+	if built.Config.Size != m.config.Size {
+		return &AtlasConfigError{Field: "Size", Reason: "preloaded pages must match manager atlas size"}
+	}
+	for _, page := range built.Pages {
+		if len(page.Data) != page.Size*page.Size*3 {
+			return &AtlasConfigError{Field: "Size", Reason: "page data length does not match page size"}
+		}
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if len(m.atlases)+len(built.Pages) > m.config.MaxAtlases {
+		return &AtlasFullError{MaxAtlases: m.config.MaxAtlases}
+	}
+
+	base := len(m.atlases)
+	for i, page := range built.Pages {
+		atlas := newAtlas(base+i, m.config.Size, m.config.GlyphSize, m.config.Padding)
+		copy(atlas.Data, page.Data)
+		atlas.sealed = true
+		atlas.dirty = true
+		m.atlases = append(m.atlases, atlas)
+	}
+
+	for key, region := range built.Regions {
+		if _, ok := m.lookup[key]; ok {
+			continue
+		}
+		region.AtlasIndex += base
+		m.lookup[key] = region
+		m.atlases[region.AtlasIndex].regions[key] = region
+	}
+
+	return nil
+}
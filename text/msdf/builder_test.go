@@ -0,0 +1,265 @@
+package msdf
+
+import (
+	"testing"
+)
+
+func builderTestConfig() AtlasConfig {
+	return AtlasConfig{
+		Size:       128,
+		GlyphSize:  32,
+		Padding:    2,
+		MaxAtlases: 4,
+	}
+}
+
+func TestAtlasBuilder_Build(t *testing.T) {
+	builder, err := NewAtlasBuilder(builderTestConfig())
+	if err != nil {
+		t.Fatalf("NewAtlasBuilder failed: %v", err)
+	}
+
+	outline := createTestOutline()
+	const n = 5
+	for i := 0; i < n; i++ {
+		builder.Add(GlyphKey{FontID: 1, GlyphID: uint16(65 + i), Size: 32}, outline)
+	}
+	if builder.Len() != n {
+		t.Fatalf("Len() = %d, want %d", builder.Len(), n)
+	}
+
+	built, err := builder.Build()
+	if err != nil {
+		t.Fatalf("Build failed: %v", err)
+	}
+	if len(built.Pages) != 1 {
+		t.Errorf("page count = %d, want 1", len(built.Pages))
+	}
+	if len(built.Regions) != n {
+		t.Errorf("region count = %d, want %d", len(built.Regions), n)
+	}
+	if builder.Len() != 0 {
+		t.Errorf("Len() after Build = %d, want 0 (queue consumed)", builder.Len())
+	}
+
+	page := built.Pages[0]
+	if len(page.Data) != page.Size*page.Size*3 {
+		t.Errorf("page data length = %d, want %d", len(page.Data), page.Size*page.Size*3)
+	}
+
+	for key, region := range built.Regions {
+		if region.AtlasIndex != 0 {
+			t.Errorf("key %v: AtlasIndex = %d, want 0", key, region.AtlasIndex)
+		}
+		if region.U0 <= 0 || region.U1 > 1 || region.V0 <= 0 || region.V1 > 1 {
+			t.Errorf("key %v: UVs out of range: (%v,%v)-(%v,%v)",
+				key, region.U0, region.V0, region.U1, region.V1)
+		}
+		if region.U1 <= region.U0 || region.V1 <= region.V0 {
+			t.Errorf("key %v: degenerate UV rect", key)
+		}
+		if region.PlaneMaxX <= region.PlaneMinX || region.PlaneMaxY <= region.PlaneMinY {
+			t.Errorf("key %v: degenerate plane bounds", key)
+		}
+	}
+}
+
+func TestAtlasBuilder_MultiplePages(t *testing.T) {
+	// 128px page with 32px cells + 2px padding holds 3x3 cells per page
+	// under shelf packing, so 10 glyphs need a second page.
+	builder, err := NewAtlasBuilder(builderTestConfig())
+	if err != nil {
+		t.Fatalf("NewAtlasBuilder failed: %v", err)
+	}
+
+	outline := createTestOutline()
+	for i := 0; i < 10; i++ {
+		builder.Add(GlyphKey{FontID: 1, GlyphID: uint16(i), Size: 32}, outline)
+	}
+
+	built, err := builder.Build()
+	if err != nil {
+		t.Fatalf("Build failed: %v", err)
+	}
+	if len(built.Pages) < 2 {
+		t.Errorf("page count = %d, want >= 2", len(built.Pages))
+	}
+	if len(built.Regions) != 10 {
+		t.Errorf("region count = %d, want 10", len(built.Regions))
+	}
+
+	// Every region must reference an existing page.
+	for key, region := range built.Regions {
+		if region.AtlasIndex < 0 || region.AtlasIndex >= len(built.Pages) {
+			t.Errorf("key %v: AtlasIndex %d out of range [0,%d)", key, region.AtlasIndex, len(built.Pages))
+		}
+	}
+}
+
+func TestAtlasBuilder_DedupAndSkip(t *testing.T) {
+	builder, err := NewAtlasBuilder(builderTestConfig())
+	if err != nil {
+		t.Fatalf("NewAtlasBuilder failed: %v", err)
+	}
+
+	key := GlyphKey{FontID: 1, GlyphID: 65, Size: 32}
+	outline := createTestOutline()
+	builder.Add(key, outline)
+	builder.Add(key, outline)                                    // duplicate
+	builder.Add(GlyphKey{FontID: 1, GlyphID: 66, Size: 32}, nil) // nil outline
+
+	if builder.Len() != 1 {
+		t.Errorf("Len() = %d, want 1 (duplicate and nil outline ignored)", builder.Len())
+	}
+}
+
+func TestAtlasBuilder_Empty(t *testing.T) {
+	builder, err := NewAtlasBuilder(builderTestConfig())
+	if err != nil {
+		t.Fatalf("NewAtlasBuilder failed: %v", err)
+	}
+
+	built, err := builder.Build()
+	if err != nil {
+		t.Fatalf("Build of empty queue failed: %v", err)
+	}
+	if len(built.Pages) != 0 || len(built.Regions) != 0 {
+		t.Errorf("empty build produced %d pages, %d regions", len(built.Pages), len(built.Regions))
+	}
+}
+
+func TestAtlasBuilder_TooManyGlyphs(t *testing.T) {
+	cfg := builderTestConfig()
+	cfg.MaxAtlases = 1
+	builder, err := NewAtlasBuilder(cfg)
+	if err != nil {
+		t.Fatalf("NewAtlasBuilder failed: %v", err)
+	}
+
+	outline := createTestOutline()
+	for i := 0; i < 20; i++ {
+		builder.Add(GlyphKey{FontID: 1, GlyphID: uint16(i), Size: 32}, outline)
+	}
+
+	if _, err := builder.Build(); err == nil {
+		t.Error("Build should fail when glyphs exceed MaxAtlases capacity")
+	}
+}
+
+func TestAtlasBuilder_InvalidConfig(t *testing.T) {
+	cfg := builderTestConfig()
+	cfg.Size = 100 // not power of 2
+	if _, err := NewAtlasBuilder(cfg); err == nil {
+		t.Error("NewAtlasBuilder should reject invalid config")
+	}
+}
+
+func TestAtlasManager_Preload(t *testing.T) {
+	cfg := builderTestConfig()
+	builder, err := NewAtlasBuilder(cfg)
+	if err != nil {
+		t.Fatalf("NewAtlasBuilder failed: %v", err)
+	}
+	outline := createTestOutline()
+	keys := make([]GlyphKey, 3)
+	for i := range keys {
+		keys[i] = GlyphKey{FontID: 7, GlyphID: uint16(65 + i), Size: 32}
+		builder.Add(keys[i], outline)
+	}
+	built, err := builder.Build()
+	if err != nil {
+		t.Fatalf("Build failed: %v", err)
+	}
+
+	manager, err := NewAtlasManager(cfg)
+	if err != nil {
+		t.Fatalf("NewAtlasManager failed: %v", err)
+	}
+	if err := manager.Preload(built); err != nil {
+		t.Fatalf("Preload failed: %v", err)
+	}
+
+	if manager.AtlasCount() != len(built.Pages) {
+		t.Errorf("AtlasCount = %d, want %d", manager.AtlasCount(), len(built.Pages))
+	}
+	if manager.GlyphCount() != len(keys) {
+		t.Errorf("GlyphCount = %d, want %d", manager.GlyphCount(), len(keys))
+	}
+
+	// Preloaded glyphs serve as cache hits without regeneration.
+	for _, key := range keys {
+		if !manager.HasGlyph(key) {
+			t.Errorf("key %v missing after Preload", key)
+		}
+		region, err := manager.Get(key, outline)
+		if err != nil {
+			t.Fatalf("Get after Preload failed: %v", err)
+		}
+		if region != built.Regions[key] {
+			t.Errorf("key %v: region changed after Preload", key)
+		}
+	}
+	hits, misses, _ := manager.Stats()
+	if hits != uint64(len(keys)) || misses != 0 {
+		t.Errorf("stats after preloaded Gets: hits=%d misses=%d, want %d/0", hits, misses, len(keys))
+	}
+
+	// Preloaded pages need GPU upload.
+	if dirty := manager.DirtyAtlases(); len(dirty) != len(built.Pages) {
+		t.Errorf("DirtyAtlases = %v, want %d dirty pages", dirty, len(built.Pages))
+	}
+}
+
+func TestAtlasManager_PreloadSealed(t *testing.T) {
+	cfg := builderTestConfig()
+	builder, err := NewAtlasBuilder(cfg)
+	if err != nil {
+		t.Fatalf("NewAtlasBuilder failed: %v", err)
+	}
+	outline := createTestOutline()
+	builder.Add(GlyphKey{FontID: 7, GlyphID: 65, Size: 32}, outline)
+	built, err := builder.Build()
+	if err != nil {
+		t.Fatalf("Build failed: %v", err)
+	}
+
+	manager, err := NewAtlasManager(cfg)
+	if err != nil {
+		t.Fatalf("NewAtlasManager failed: %v", err)
+	}
+	if err := manager.Preload(built); err != nil {
+		t.Fatalf("Preload failed: %v", err)
+	}
+
+	// A new glyph must go into a fresh atlas, not a sealed preloaded page.
+	region, err := manager.Get(GlyphKey{FontID: 7, GlyphID: 99, Size: 32}, outline)
+	if err != nil {
+		t.Fatalf("Get of new glyph failed: %v", err)
+	}
+	if region.AtlasIndex < len(built.Pages) {
+		t.Errorf("new glyph landed in sealed page %d", region.AtlasIndex)
+	}
+}
+
+func TestAtlasManager_PreloadSizeMismatch(t *testing.T) {
+	cfg := builderTestConfig()
+	builderCfg := cfg
+	builderCfg.Size = 256
+	builder, err := NewAtlasBuilder(builderCfg)
+	if err != nil {
+		t.Fatalf("NewAtlasBuilder failed: %v", err)
+	}
+	builder.Add(GlyphKey{FontID: 1, GlyphID: 65, Size: 32}, createTestOutline())
+	built, err := builder.Build()
+	if err != nil {
+		t.Fatalf("Build failed: %v", err)
+	}
+
+	manager, err := NewAtlasManager(cfg)
+	if err != nil {
+		t.Fatalf("NewAtlasManager failed: %v", err)
+	}
+	if err := manager.Preload(built); err == nil {
+		t.Error("Preload should reject pages with a different atlas size")
+	}
+}
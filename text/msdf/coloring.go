@@ -0,0 +1,143 @@
+package msdf
+
+import "math"
+
+// ColoringMode selects the edge coloring strategy used by the generator.
+type ColoringMode int
+
+const (
+	// ColoringSimple splits each contour at its corners and cycles through
+	// the three channel colors per contour. This is the default and matches
+	// the original behavior of [AssignColors].
+	ColoringSimple ColoringMode = iota
+
+	// ColoringVoronoi additionally considers the geometric distance between
+	// segments when picking colors: each segment gets the color whose
+	// existing users are farthest away. Segments that are spatially close —
+	// thin strokes, counters next to stems — end up on different channels,
+	// which prevents the median from collapsing them at small texture sizes.
+	ColoringVoronoi
+)
+
+// coloredSegment tracks an already-colored run of edges and its sample
+// points, used to measure distances to candidate segments.
+type coloredSegment struct {
+	color   EdgeColor
+	samples []Point
+}
+
+// AssignColorsByDistance assigns edge colors using a Voronoi-style
+// strategy: contours are split at corners as in [AssignColors], but each
+// segment's color is chosen to maximize the distance to the nearest
+// segment already using that color. Corner edges still receive the union
+// of their adjacent segment colors so corners stay sharp.
+func AssignColorsByDistance(shape *Shape, angleThreshold float64) {
+	var placed []coloredSegment
+
+	for _, contour := range shape.Contours {
+		n := len(contour.Edges)
+		if n == 0 {
+			continue
+		}
+		if n == 1 {
+			// Single edge gets all colors
+			contour.Edges[0].Color = ColorWhite
+			continue
+		}
+
+		corners := detectCorners(contour, angleThreshold)
+
+		if len(corners) == 0 {
+			// Smooth contour (e.g. a counter or the dot of an 'i'): one
+			// segment, colored away from its nearest neighbors.
+			samples := edgeMidpoints(contour, 0, n-1)
+			color := pickDistantColor(samples, placed)
+			for i := range contour.Edges {
+				contour.Edges[i].Color = color
+			}
+			placed = append(placed, coloredSegment{color: color, samples: samples})
+			continue
+		}
+
+		// Color the corner-delimited segments.
+		for i := 0; i < len(corners); i++ {
+			start := corners[i]
+			end := corners[(i+1)%len(corners)]
+			if end <= start {
+				end += n
+			}
+
+			samples := edgeMidpoints(contour, start+1, end)
+			color := pickDistantColor(samples, placed)
+			for j := start + 1; j <= end; j++ {
+				contour.Edges[j%n].Color = color
+			}
+			placed = append(placed, coloredSegment{color: color, samples: samples})
+		}
+
+		// Corner edges get the union of adjacent colors (to preserve
+		// sharpness), same as AssignColors.
+		for _, cornerIdx := range corners {
+			prevColor := contour.Edges[cornerIdx].Color
+			nextColor := contour.Edges[(cornerIdx+1)%n].Color
+
+			if prevColor == nextColor {
+				contour.Edges[cornerIdx].Color = ColorWhite
+			} else {
+				contour.Edges[cornerIdx].Color = prevColor | nextColor
+			}
+		}
+	}
+}
+
+// edgeMidpoints returns the midpoints of contour edges [start, end]
+// (indices taken modulo the edge count).
+func edgeMidpoints(contour *Contour, start, end int) []Point {
+	n := len(contour.Edges)
+	samples := make([]Point, 0, end-start+1)
+	for i := start; i <= end; i++ {
+		samples = append(samples, contour.Edges[i%n].PointAt(0.5))
+	}
+	return samples
+}
+
+// pickDistantColor returns the channel color whose nearest already-placed
+// segment is farthest from the candidate samples. Unused colors win
+// immediately (infinite distance), so the cycle order is preserved until
+// all three colors are in play.
+func pickDistantColor(samples []Point, placed []coloredSegment) EdgeColor {
+	colors := [3]EdgeColor{ColorCyan, ColorMagenta, ColorYellow}
+
+	best := colors[0]
+	bestDist := -1.0
+	for _, color := range colors {
+		dist := math.Inf(1)
+		for _, seg := range placed {
+			if seg.color != color {
+				continue
+			}
+			if d := segmentDistance(samples, seg.samples); d < dist {
+				dist = d
+			}
+		}
+		if dist > bestDist {
+			best = color
+			bestDist = dist
+		}
+	}
+	return best
+}
+
+// segmentDistance returns the minimum pairwise distance between two
+// sample point sets.
+func segmentDistance(a, b []Point) float64 {
+	dist := math.Inf(1)
+	for _, p := range a {
+		for _, q := range b {
+			if d := math.Hypot(p.X-q.X, p.Y-q.Y); d < dist {
+				dist = d
+			}
+		}
+	}
+	return dist
+}
@@ -0,0 +1,135 @@
+package msdf
+
+import (
+	"math"
+	"testing"
+
+	"github.com/gogpu/gg/text"
+)
+
+// squareOutlineAt returns a closed square outline with the given origin
+// and side length.
+func squareOutlineAt(x, y, side float64) *text.GlyphOutline {
+	return &text.GlyphOutline{
+		Segments: []text.OutlineSegment{
+			{Op: text.OutlineOpMoveTo, Points: [3]text.OutlinePoint{{X: float32(x), Y: float32(y)}}},
+			{Op: text.OutlineOpLineTo, Points: [3]text.OutlinePoint{{X: float32(x + side), Y: float32(y)}}},
+			{Op: text.OutlineOpLineTo, Points: [3]text.OutlinePoint{{X: float32(x + side), Y: float32(y + side)}}},
+			{Op: text.OutlineOpLineTo, Points: [3]text.OutlinePoint{{X: float32(x), Y: float32(y + side)}}},
+			{Op: text.OutlineOpLineTo, Points: [3]text.OutlinePoint{{X: float32(x), Y: float32(y)}}},
+		},
+	}
+}
+
+func TestAssignColorsByDistance_Square(t *testing.T) {
+	shape := FromOutline(squareOutlineAt(0, 0, 100))
+	AssignColorsByDistance(shape, math.Pi/3)
+
+	edges := shape.Contours[0].Edges
+	for i, edge := range edges {
+		if edge.Color == ColorBlack {
+			t.Errorf("edge %d has no color", i)
+		}
+		// Every edge of a square ends in a corner, so every edge carries
+		// the union of two channel colors.
+		channels := boolToInt(edge.Color.HasRed()) +
+			boolToInt(edge.Color.HasGreen()) +
+			boolToInt(edge.Color.HasBlue())
+		if channels < 2 {
+			t.Errorf("corner edge %d color %v has %d channels, want >= 2", i, edge.Color, channels)
+		}
+	}
+}
+
+func TestAssignColorsByDistance_SmoothContour(t *testing.T) {
+	// A circle-like quad contour has no corners and forms one segment.
+	outline := &text.GlyphOutline{
+		Segments: []text.OutlineSegment{
+			{Op: text.OutlineOpMoveTo, Points: [3]text.OutlinePoint{{X: 50, Y: 0}}},
+			{Op: text.OutlineOpQuadTo, Points: [3]text.OutlinePoint{{X: 100, Y: 0}, {X: 100, Y: 50}}},
+			{Op: text.OutlineOpQuadTo, Points: [3]text.OutlinePoint{{X: 100, Y: 100}, {X: 50, Y: 100}}},
+			{Op: text.OutlineOpQuadTo, Points: [3]text.OutlinePoint{{X: 0, Y: 100}, {X: 0, Y: 50}}},
+			{Op: text.OutlineOpQuadTo, Points: [3]text.OutlinePoint{{X: 0, Y: 0}, {X: 50, Y: 0}}},
+		},
+	}
+	shape := FromOutline(outline)
+	AssignColorsByDistance(shape, math.Pi/3)
+
+	edges := shape.Contours[0].Edges
+	first := edges[0].Color
+	if first == ColorBlack {
+		t.Fatal("smooth contour got no color")
+	}
+	for i, edge := range edges {
+		if edge.Color != first {
+			t.Errorf("smooth contour edge %d color = %v, want uniform %v", i, edge.Color, first)
+		}
+	}
+}
+
+func TestAssignColorsByDistance_NearbyContoursDiffer(t *testing.T) {
+	// Two smooth contours close together (like the dot and stem of an 'i')
+	// must not share a color, or the median collapses the gap between them.
+	circle := func(cx, cy, r float32) []text.OutlineSegment {
+		return []text.OutlineSegment{
+			{Op: text.OutlineOpMoveTo, Points: [3]text.OutlinePoint{{X: cx + r, Y: cy}}},
+			{Op: text.OutlineOpQuadTo, Points: [3]text.OutlinePoint{{X: cx + r, Y: cy + r}, {X: cx, Y: cy + r}}},
+			{Op: text.OutlineOpQuadTo, Points: [3]text.OutlinePoint{{X: cx - r, Y: cy + r}, {X: cx - r, Y: cy}}},
+			{Op: text.OutlineOpQuadTo, Points: [3]text.OutlinePoint{{X: cx - r, Y: cy - r}, {X: cx, Y: cy - r}}},
+			{Op: text.OutlineOpQuadTo, Points: [3]text.OutlinePoint{{X: cx + r, Y: cy - r}, {X: cx + r, Y: cy}}},
+		}
+	}
+	outline := &text.GlyphOutline{
+		Segments: append(circle(50, 50, 20), circle(50, 100, 20)...),
+	}
+	shape := FromOutline(outline)
+	if len(shape.Contours) != 2 {
+		t.Fatalf("contour count = %d, want 2", len(shape.Contours))
+	}
+
+	AssignColorsByDistance(shape, math.Pi/3)
+
+	c0 := shape.Contours[0].Edges[0].Color
+	c1 := shape.Contours[1].Edges[0].Color
+	if c0 == c1 {
+		t.Errorf("nearby smooth contours share color %v", c0)
+	}
+}
+
+func TestGenerator_VoronoiColoring(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.Coloring = ColoringVoronoi
+
+	gen := NewGenerator(cfg)
+	msdf, err := gen.Generate(createTestOutline())
+	if err != nil {
+		t.Fatalf("Generate with Voronoi coloring failed: %v", err)
+	}
+	if msdf.Width != cfg.Size || msdf.Height != cfg.Size {
+		t.Errorf("MSDF size = %dx%d, want %dx%d", msdf.Width, msdf.Height, cfg.Size, cfg.Size)
+	}
+
+	// The field must contain both inside and outside texels.
+	inside, outside := 0, 0
+	for y := 0; y < msdf.Height; y++ {
+		for x := 0; x < msdf.Width; x++ {
+			r, g, b := msdf.GetPixel(x, y)
+			if median3Byte(r, g, b) > 127 {
+				inside++
+			} else {
+				outside++
+			}
+		}
+	}
+	if inside == 0 || outside == 0 {
+		t.Errorf("degenerate field: %d inside, %d outside texels", inside, outside)
+	}
+}
+
+func TestConfig_ColoringValidation(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.Coloring = ColoringMode(99)
+	if err := cfg.Validate(); err == nil {
+		t.Error("Validate should reject unknown coloring mode")
+	}
+}
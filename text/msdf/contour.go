@@ -251,24 +251,7 @@ func assignContourColors(contour *Contour, angleThreshold float64) {
 	}
 
 	// Detect corners (sharp angle changes)
-	corners := make([]int, 0)
-	for i := 0; i < n; i++ {
-		// Get the direction at the end of this edge and start of next
-		prevEdge := &contour.Edges[i]
-		nextEdge := &contour.Edges[(i+1)%n]
-
-		// Direction leaving this edge
-		dirOut := prevEdge.DirectionAt(1).Normalized()
-		// Direction entering next edge
-		dirIn := nextEdge.DirectionAt(0).Normalized()
-
-		// Angle between them
-		angle := AngleBetween(dirOut, dirIn)
-
-		if angle > angleThreshold {
-			corners = append(corners, i)
-		}
-	}
+	corners := detectCorners(contour, angleThreshold)
 
 	if len(corners) == 0 {
 		// No corners - use simple alternating colors
@@ -316,6 +299,26 @@ func assignContourColors(contour *Contour, angleThreshold float64) {
 	}
 }
 
+// detectCorners returns the indices of edges whose end forms a corner:
+// the direction leaving edge i and the direction entering edge i+1 differ
+// by more than angleThreshold.
+func detectCorners(contour *Contour, angleThreshold float64) []int {
+	n := len(contour.Edges)
+	corners := make([]int, 0)
+	for i := 0; i < n; i++ {
+		prevEdge := &contour.Edges[i]
+		nextEdge := &contour.Edges[(i+1)%n]
+
+		dirOut := prevEdge.DirectionAt(1).Normalized()
+		dirIn := nextEdge.DirectionAt(0).Normalized()
+
+		if AngleBetween(dirOut, dirIn) > angleThreshold {
+			corners = append(corners, i)
+		}
+	}
+	return corners
+}
+
 // SwitchColor returns the next color in the cycle.
 // Used for edge coloring algorithm.
 func SwitchColor(current EdgeColor, seed int) EdgeColor {
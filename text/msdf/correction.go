@@ -0,0 +1,130 @@
+package msdf
+
+import "math"
+
+// clashThresholdFactor scales the per-channel discontinuity threshold for
+// clash detection. Slightly above 1 so that legitimate one-texel edge
+// transitions (exactly one distance unit apart) are not flagged.
+const clashThresholdFactor = 1.001
+
+// CorrectClashes implements msdfgen-style error correction: it detects
+// adjacent texel pairs whose channels cross the 0.5 isoline in opposite
+// directions ("clashes") and flattens the offending texel to its median.
+//
+// Clashes appear where two differently-colored edges pass through
+// neighboring texels — typically at corners and thin features at small
+// texture sizes — and render as spikes or notches because bilinear
+// interpolation between the conflicting channels produces a false edge.
+// Flattening the texel to the plain signed distance removes the artifact
+// at the cost of a locally rounded corner.
+//
+// The threshold is the minimum per-channel difference (in [0, 1] channel
+// units) for a conflicting pair to count as discontinuous; use
+// [Config.ClashCorrection] to apply this automatically from Generate with
+// a threshold derived from the distance range.
+func CorrectClashes(msdf *MSDF, threshold float64) {
+	if msdf == nil {
+		return
+	}
+
+	w, h := msdf.Width, msdf.Height
+
+	// Collect first, then fix: correcting in place while scanning would
+	// let one flattened texel mask or trigger clashes at its neighbors.
+	type texel struct{ x, y int }
+	var clashes []texel
+
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			if (x > 0 && pixelClash(msdf, x, y, x-1, y, threshold)) ||
+				(x < w-1 && pixelClash(msdf, x, y, x+1, y, threshold)) ||
+				(y > 0 && pixelClash(msdf, x, y, x, y-1, threshold)) ||
+				(y < h-1 && pixelClash(msdf, x, y, x, y+1, threshold)) {
+				clashes = append(clashes, texel{x, y})
+			}
+		}
+	}
+
+	for _, c := range clashes {
+		r, g, b := msdf.GetPixel(c.x, c.y)
+		med := median3Byte(r, g, b)
+		msdf.SetPixel(c.x, c.y, med, med, med)
+	}
+}
+
+// pixelClash reports whether the texel at (ax, ay) clashes with its
+// neighbor at (bx, by): at least two channels cross the 0.5 isoline in
+// opposite directions between the two texels, indicating that different
+// edge colors meet there without a true shape edge.
+//
+// Out of a clashing pair only the texel farther from the isoline is
+// flagged, so the texel that actually carries the edge is preserved.
+func pixelClash(msdf *MSDF, ax, ay, bx, by int, threshold float64) bool {
+	ar, ag, ab := pixelChannels(msdf, ax, ay)
+	br, bg, bb := pixelChannels(msdf, bx, by)
+
+	// Only consider pairs that are both inside or both outside the shape
+	// (median > 0.5 means inside); an inside/outside pair is a real edge.
+	aIn := boolToInt(ar > 0.5)+boolToInt(ag > 0.5)+boolToInt(ab > 0.5) >= 2
+	bIn := boolToInt(br > 0.5)+boolToInt(bg > 0.5)+boolToInt(bb > 0.5) >= 2
+	if aIn != bIn {
+		return false
+	}
+
+	// If either texel has all channels on the same side, there is no
+	// channel conflict to resolve.
+	if (ar > 0.5 && ag > 0.5 && ab > 0.5) || (ar < 0.5 && ag < 0.5 && ab < 0.5) ||
+		(br > 0.5 && bg > 0.5 && bb > 0.5) || (br < 0.5 && bg < 0.5 && bb < 0.5) {
+		return false
+	}
+
+	// Identify the two channels that cross 0.5 in opposite directions
+	// between the texels; the remaining channel is the tie-breaker.
+	var aa, ba, ab2, bb2, ac, bc float64
+	switch {
+	case crossesOpposite(ar, br):
+		aa, ba = ar, br
+		switch {
+		case crossesOpposite(ag, bg):
+			ab2, bb2 = ag, bg
+			ac, bc = ab, bb
+		case crossesOpposite(ab, bb):
+			ab2, bb2 = ab, bb
+			ac, bc = ag, bg
+		default:
+			return false // at least two channels must conflict
+		}
+	case crossesOpposite(ag, bg) && crossesOpposite(ab, bb):
+		aa, ba = ag, bg
+		ab2, bb2 = ab, bb
+		ac, bc = ar, br
+	default:
+		return false
+	}
+
+	// The conflicting channels must be genuinely discontinuous, and only
+	// the texel farther from the isoline is flagged.
+	return math.Abs(aa-ba) >= threshold &&
+		math.Abs(ab2-bb2) >= threshold &&
+		math.Abs(ac-0.5) >= math.Abs(bc-0.5)
+}
+
+// crossesOpposite reports whether a channel is on strictly opposite sides
+// of the 0.5 isoline in the two texels.
+func crossesOpposite(a, b float64) bool {
+	return (a > 0.5) != (b > 0.5) && (a < 0.5) != (b < 0.5)
+}
+
+// pixelChannels returns the texel channels normalized to [0, 1].
+func pixelChannels(msdf *MSDF, x, y int) (r, g, b float64) {
+	pr, pg, pb := msdf.GetPixel(x, y)
+	return float64(pr) / 255, float64(pg) / 255, float64(pb) / 255
+}
+
+// boolToInt returns 1 for true, 0 for false.
+func boolToInt(b bool) int {
+	if b {
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,99 @@
+package msdf
+
+import "testing"
+
+// newTestMSDF creates a w x h field filled with a uniform channel value.
+func newTestMSDF(w, h int, fill byte) *MSDF {
+	m := &MSDF{
+		Data:   make([]byte, w*h*3),
+		Width:  w,
+		Height: h,
+		Scale:  1.0,
+	}
+	for i := range m.Data {
+		m.Data[i] = fill
+	}
+	return m
+}
+
+func TestCorrectClashes_FlattensClash(t *testing.T) {
+	// Two adjacent inside texels with conflicting channels: in both, two
+	// channels sit on opposite sides of 0.5 and swap between the texels.
+	// Bilinear interpolation between them would produce a false edge.
+	m := newTestMSDF(4, 4, 200)
+	m.SetPixel(1, 1, 255, 40, 200) // red high, green low
+	m.SetPixel(2, 1, 40, 255, 200) // red low, green high — clash
+
+	CorrectClashes(m, 0.1)
+
+	// The texel farther from the isoline is flattened to its median.
+	r, g, b := m.GetPixel(1, 1)
+	if r != g || g != b {
+		t.Errorf("clashing texel (1,1) not flattened: %d,%d,%d", r, g, b)
+	}
+	if r != 200 {
+		t.Errorf("flattened value = %d, want median 200", r)
+	}
+
+	// An untouched uniform texel keeps its channels.
+	r, g, b = m.GetPixel(0, 3)
+	if r != 200 || g != 200 || b != 200 {
+		t.Errorf("unrelated texel modified: %d,%d,%d", r, g, b)
+	}
+}
+
+func TestCorrectClashes_IgnoresRealEdge(t *testing.T) {
+	// An inside texel next to an outside texel is a real shape edge, not
+	// a clash, and must be left alone.
+	m := newTestMSDF(4, 4, 200)
+	m.SetPixel(1, 1, 255, 40, 200) // inside (median 200)
+	m.SetPixel(2, 1, 40, 100, 60)  // outside (median 60)
+
+	CorrectClashes(m, 0.1)
+
+	r, g, b := m.GetPixel(1, 1)
+	if r != 255 || g != 40 || b != 200 {
+		t.Errorf("edge texel modified: %d,%d,%d", r, g, b)
+	}
+}
+
+func TestCorrectClashes_ThresholdFiltersSmallSteps(t *testing.T) {
+	// Conflicting channels that differ by less than the threshold are
+	// legitimate gradients, not discontinuities.
+	m := newTestMSDF(4, 4, 200)
+	m.SetPixel(1, 1, 140, 115, 200) // small opposite steps around 0.5
+	m.SetPixel(2, 1, 115, 140, 200)
+
+	CorrectClashes(m, 0.5)
+
+	r, g, b := m.GetPixel(1, 1)
+	if r != 140 || g != 115 || b != 200 {
+		t.Errorf("sub-threshold texel modified: %d,%d,%d", r, g, b)
+	}
+}
+
+func TestCorrectClashes_NilSafe(t *testing.T) {
+	CorrectClashes(nil, 0.1) // must not panic
+}
+
+func TestGenerator_ClashCorrection(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.ClashCorrection = true
+
+	gen := NewGenerator(cfg)
+	msdf, err := gen.Generate(createTestOutline())
+	if err != nil {
+		t.Fatalf("Generate with clash correction failed: %v", err)
+	}
+
+	// Clash correction must not disturb the overall field: the square's
+	// center stays inside, the corners of the texture stay outside.
+	r, g, b := msdf.GetPixel(msdf.Width/2, msdf.Height/2)
+	if median3Byte(r, g, b) <= 127 {
+		t.Error("center texel is not inside after clash correction")
+	}
+	r, g, b = msdf.GetPixel(0, 0)
+	if median3Byte(r, g, b) > 127 {
+		t.Error("corner texel is not outside after clash correction")
+	}
+}
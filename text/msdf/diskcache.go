@@ -0,0 +1,297 @@
+package msdf
+
+import (
+	"encoding/binary"
+	"fmt"
+	"hash/fnv"
+	"math"
+	"os"
+	"path/filepath"
+)
+
+// diskCacheMagic identifies MSDF atlas cache files. The trailing digit is
+// the format version; bump it when the serialization layout changes so
+// stale files from older builds read as cache misses.
+const diskCacheMagic = "MSDFATL1"
+
+// FontChecksum computes a stable checksum over raw font file bytes, used
+// to version disk-cached atlases. A font update (even with an unchanged
+// name) produces a different checksum and invalidates its cached atlas.
+func FontChecksum(data []byte) uint64 {
+	h := fnv.New64a()
+	_, _ = h.Write(data)
+	return h.Sum64()
+}
+
+// DiskCache persists built atlases to a directory so that per-font MSDF
+// generation happens once per font version instead of once per process.
+//
+// Each [BuiltAtlas] is stored in its own file, named by the font checksum
+// and the atlas geometry, so different fonts and different configurations
+// never collide. Corrupt or stale files are treated as cache misses.
+//
+// DiskCache is safe for concurrent use by multiple processes: writes go
+// to a temporary file first and are installed with an atomic rename.
+type DiskCache struct {
+	dir string
+}
+
+// NewDiskCache creates a disk cache rooted at dir, creating the directory
+// if needed.
+func NewDiskCache(dir string) (*DiskCache, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("msdf: failed to create cache directory: %w", err)
+	}
+	return &DiskCache{dir: dir}, nil
+}
+
+// Dir returns the cache directory.
+func (c *DiskCache) Dir() string {
+	return c.dir
+}
+
+// path returns the cache file path for a font checksum and atlas geometry.
+func (c *DiskCache) path(checksum uint64, config AtlasConfig) string {
+	name := fmt.Sprintf("%016x-s%d-g%d.msdfatlas", checksum, config.Size, config.GlyphSize)
+	return filepath.Join(c.dir, name)
+}
+
+// Store writes a built atlas to the cache, keyed by its FontChecksum.
+// Atlases without a checksum (multi-font or unknown origin) are not
+// persisted and Store is a no-op.
+func (c *DiskCache) Store(built *BuiltAtlas) error {
+	if built == nil || built.FontChecksum == 0 || len(built.Pages) == 0 {
+		return nil
+	}
+
+	data := encodeBuiltAtlas(built)
+
+	// Write-then-rename so concurrent readers never see a partial file.
+	tmp, err := os.CreateTemp(c.dir, "msdfatlas-*.tmp")
+	if err != nil {
+		return fmt.Errorf("msdf: failed to create cache file: %w", err)
+	}
+	tmpName := tmp.Name()
+	if _, err := tmp.Write(data); err != nil {
+		_ = tmp.Close()
+		_ = os.Remove(tmpName)
+		return fmt.Errorf("msdf: failed to write cache file: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		_ = os.Remove(tmpName)
+		return fmt.Errorf("msdf: failed to close cache file: %w", err)
+	}
+	if err := os.Rename(tmpName, c.path(built.FontChecksum, built.Config)); err != nil {
+		_ = os.Remove(tmpName)
+		return fmt.Errorf("msdf: failed to install cache file: %w", err)
+	}
+	return nil
+}
+
+// Load reads the cached atlas for a font checksum and atlas geometry.
+// Returns [ErrCacheMiss] if no matching entry exists or the stored file
+// is corrupt or from an incompatible format version.
+func (c *DiskCache) Load(checksum uint64, config AtlasConfig) (*BuiltAtlas, error) {
+	data, err := os.ReadFile(c.path(checksum, config))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, ErrCacheMiss
+		}
+		return nil, fmt.Errorf("msdf: failed to read cache file: %w", err)
+	}
+
+	built, ok := decodeBuiltAtlas(data)
+	if !ok || built.FontChecksum != checksum ||
+		built.Config.Size != config.Size || built.Config.GlyphSize != config.GlyphSize {
+		return nil, ErrCacheMiss
+	}
+	return built, nil
+}
+
+// Remove deletes the cached atlas for a font checksum and atlas geometry.
+// Removing a missing entry is not an error.
+func (c *DiskCache) Remove(checksum uint64, config AtlasConfig) error {
+	err := os.Remove(c.path(checksum, config))
+	if err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("msdf: failed to remove cache file: %w", err)
+	}
+	return nil
+}
+
+// encodeBuiltAtlas serializes a built atlas.
+//
+// Layout (little-endian):
+//
+//	[8]byte  magic "MSDFATL1"
+//	uint64   font checksum
+//	uint32   config.Size
+//	uint32   config.GlyphSize
+//	uint32   config.Padding
+//	uint32   config.MaxAtlases
+//	uint32   page count
+//	  per page:  uint32 size, size*size*3 bytes RGB data
+//	uint32   region count
+//	  per region: uint64 FontID, uint16 GlyphID, uint16 Size,
+//	              uint64 VariationHash, uint32 AtlasIndex,
+//	              uint32 X, Y, Width, Height,
+//	              float32 U0, V0, U1, V1,
+//	              float32 PlaneMinX, PlaneMinY, PlaneMaxX, PlaneMaxY
+func encodeBuiltAtlas(built *BuiltAtlas) []byte {
+	buf := make([]byte, 0, 64)
+	buf = append(buf, diskCacheMagic...)
+	buf = binary.LittleEndian.AppendUint64(buf, built.FontChecksum)
+	buf = binary.LittleEndian.AppendUint32(buf, uint32(built.Config.Size))       //nolint:gosec // validated config
+	buf = binary.LittleEndian.AppendUint32(buf, uint32(built.Config.GlyphSize))  //nolint:gosec // validated config
+	buf = binary.LittleEndian.AppendUint32(buf, uint32(built.Config.Padding))    //nolint:gosec // validated config
+	buf = binary.LittleEndian.AppendUint32(buf, uint32(built.Config.MaxAtlases)) //nolint:gosec // validated config
+
+	buf = binary.LittleEndian.AppendUint32(buf, uint32(len(built.Pages))) //nolint:gosec // bounded by MaxAtlases
+	for _, page := range built.Pages {
+		buf = binary.LittleEndian.AppendUint32(buf, uint32(page.Size)) //nolint:gosec // validated config
+		buf = append(buf, page.Data...)
+	}
+
+	buf = binary.LittleEndian.AppendUint32(buf, uint32(len(built.Regions))) //nolint:gosec // bounded by atlas capacity
+	for key, region := range built.Regions {
+		buf = binary.LittleEndian.AppendUint64(buf, key.FontID)
+		buf = binary.LittleEndian.AppendUint16(buf, key.GlyphID)
+		buf = binary.LittleEndian.AppendUint16(buf, uint16(key.Size)) //nolint:gosec // round-trips via int16
+		buf = binary.LittleEndian.AppendUint64(buf, key.VariationHash)
+		buf = binary.LittleEndian.AppendUint32(buf, uint32(region.AtlasIndex)) //nolint:gosec // page index
+		buf = binary.LittleEndian.AppendUint32(buf, uint32(region.X))          //nolint:gosec // atlas coordinate
+		buf = binary.LittleEndian.AppendUint32(buf, uint32(region.Y))          //nolint:gosec // atlas coordinate
+		buf = binary.LittleEndian.AppendUint32(buf, uint32(region.Width))      //nolint:gosec // atlas coordinate
+		buf = binary.LittleEndian.AppendUint32(buf, uint32(region.Height))     //nolint:gosec // atlas coordinate
+		for _, f := range [8]float32{
+			region.U0, region.V0, region.U1, region.V1,
+			region.PlaneMinX, region.PlaneMinY, region.PlaneMaxX, region.PlaneMaxY,
+		} {
+			buf = binary.LittleEndian.AppendUint32(buf, math.Float32bits(f))
+		}
+	}
+	return buf
+}
+
+// decodeBuiltAtlas deserializes a built atlas. Returns ok = false for any
+// truncated, corrupt, or unrecognized input.
+func decodeBuiltAtlas(data []byte) (*BuiltAtlas, bool) {
+	r := cacheReader{data: data}
+
+	magic := r.bytes(8)
+	if string(magic) != diskCacheMagic {
+		return nil, false
+	}
+
+	built := &BuiltAtlas{}
+	built.FontChecksum = r.uint64()
+	built.Config.Size = int(r.uint32())
+	built.Config.GlyphSize = int(r.uint32())
+	built.Config.Padding = int(r.uint32())
+	built.Config.MaxAtlases = int(r.uint32())
+	if r.failed || built.Config.Validate() != nil {
+		return nil, false
+	}
+
+	pageCount := int(r.uint32())
+	if r.failed || pageCount > built.Config.MaxAtlases {
+		return nil, false
+	}
+	for i := 0; i < pageCount; i++ {
+		size := int(r.uint32())
+		if r.failed || size != built.Config.Size {
+			return nil, false
+		}
+		pageData := r.bytes(size * size * 3)
+		if r.failed {
+			return nil, false
+		}
+		built.Pages = append(built.Pages, BuiltPage{
+			Data: append([]byte(nil), pageData...),
+			Size: size,
+		})
+	}
+
+	regionCount := int(r.uint32())
+	if r.failed {
+		return nil, false
+	}
+	built.Regions = make(map[GlyphKey]Region, regionCount)
+	for i := 0; i < regionCount; i++ {
+		var key GlyphKey
+		var region Region
+		key.FontID = r.uint64()
+		key.GlyphID = r.uint16()
+		key.Size = int16(r.uint16()) //nolint:gosec // round-trips via uint16
+		key.VariationHash = r.uint64()
+		region.AtlasIndex = int(r.uint32())
+		region.X = int(r.uint32())
+		region.Y = int(r.uint32())
+		region.Width = int(r.uint32())
+		region.Height = int(r.uint32())
+		region.U0 = r.float32()
+		region.V0 = r.float32()
+		region.U1 = r.float32()
+		region.V1 = r.float32()
+		region.PlaneMinX = r.float32()
+		region.PlaneMinY = r.float32()
+		region.PlaneMaxX = r.float32()
+		region.PlaneMaxY = r.float32()
+		if r.failed || region.AtlasIndex < 0 || region.AtlasIndex >= pageCount {
+			return nil, false
+		}
+		built.Regions[key] = region
+	}
+
+	// Trailing bytes indicate a malformed file.
+	if r.offset != len(r.data) {
+		return nil, false
+	}
+	return built, true
+}
+
+// cacheReader is a bounds-checked sequential reader over cache file data.
+// After any out-of-range read, failed is set and subsequent reads return
+// zero values.
+type cacheReader struct {
+	data   []byte
+	offset int
+	failed bool
+}
+
+func (r *cacheReader) bytes(n int) []byte {
+	if r.failed || n < 0 || r.offset+n > len(r.data) {
+		r.failed = true
+		return nil
+	}
+	b := r.data[r.offset : r.offset+n]
+	r.offset += n
+	return b
+}
+
+func (r *cacheReader) uint16() uint16 {
+	b := r.bytes(2)
+	if b == nil {
+		return 0
+	}
+	return binary.LittleEndian.Uint16(b)
+}
+
+func (r *cacheReader) uint32() uint32 {
+	b := r.bytes(4)
+	if b == nil {
+		return 0
+	}
+	return binary.LittleEndian.Uint32(b)
+}
+
+func (r *cacheReader) uint64() uint64 {
+	b := r.bytes(8)
+	if b == nil {
+		return 0
+	}
+	return binary.LittleEndian.Uint64(b)
+}
+
+func (r *cacheReader) float32() float32 {
+	return math.Float32frombits(r.uint32())
+}
@@ -0,0 +1,196 @@
+package msdf
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// buildTestAtlas builds a small atlas with a few glyphs for cache tests.
+func buildTestAtlas(t *testing.T, checksum uint64) *BuiltAtlas {
+	t.Helper()
+	builder, err := NewAtlasBuilder(builderTestConfig())
+	if err != nil {
+		t.Fatalf("NewAtlasBuilder failed: %v", err)
+	}
+	outline := createTestOutline()
+	for i := 0; i < 3; i++ {
+		builder.Add(GlyphKey{FontID: 42, GlyphID: uint16(65 + i), Size: 32}, outline)
+	}
+	built, err := builder.Build()
+	if err != nil {
+		t.Fatalf("Build failed: %v", err)
+	}
+	built.FontChecksum = checksum
+	return built
+}
+
+func TestFontChecksum(t *testing.T) {
+	a := FontChecksum([]byte("font data A"))
+	b := FontChecksum([]byte("font data B"))
+	if a == b {
+		t.Error("different data produced the same checksum")
+	}
+	if a != FontChecksum([]byte("font data A")) {
+		t.Error("checksum is not stable for identical data")
+	}
+}
+
+func TestDiskCache_RoundTrip(t *testing.T) {
+	cache, err := NewDiskCache(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewDiskCache failed: %v", err)
+	}
+
+	const checksum = 0xdeadbeef
+	built := buildTestAtlas(t, checksum)
+	if err := cache.Store(built); err != nil {
+		t.Fatalf("Store failed: %v", err)
+	}
+
+	loaded, err := cache.Load(checksum, built.Config)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+
+	if loaded.FontChecksum != checksum {
+		t.Errorf("FontChecksum = %#x, want %#x", loaded.FontChecksum, checksum)
+	}
+	if loaded.Config != built.Config {
+		t.Errorf("Config = %+v, want %+v", loaded.Config, built.Config)
+	}
+	if len(loaded.Pages) != len(built.Pages) {
+		t.Fatalf("page count = %d, want %d", len(loaded.Pages), len(built.Pages))
+	}
+	for i := range built.Pages {
+		if loaded.Pages[i].Size != built.Pages[i].Size {
+			t.Errorf("page %d size = %d, want %d", i, loaded.Pages[i].Size, built.Pages[i].Size)
+		}
+		if !bytes.Equal(loaded.Pages[i].Data, built.Pages[i].Data) {
+			t.Errorf("page %d data differs after round trip", i)
+		}
+	}
+	if len(loaded.Regions) != len(built.Regions) {
+		t.Fatalf("region count = %d, want %d", len(loaded.Regions), len(built.Regions))
+	}
+	for key, want := range built.Regions {
+		got, ok := loaded.Regions[key]
+		if !ok {
+			t.Errorf("key %v missing after round trip", key)
+			continue
+		}
+		if got != want {
+			t.Errorf("key %v: region = %+v, want %+v", key, got, want)
+		}
+	}
+}
+
+func TestDiskCache_Miss(t *testing.T) {
+	cache, err := NewDiskCache(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewDiskCache failed: %v", err)
+	}
+
+	_, err = cache.Load(0x1234, builderTestConfig())
+	if !errors.Is(err, ErrCacheMiss) {
+		t.Errorf("Load of absent entry = %v, want ErrCacheMiss", err)
+	}
+}
+
+func TestDiskCache_ChecksumVersioning(t *testing.T) {
+	cache, err := NewDiskCache(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewDiskCache failed: %v", err)
+	}
+
+	built := buildTestAtlas(t, 0x1111)
+	if err := cache.Store(built); err != nil {
+		t.Fatalf("Store failed: %v", err)
+	}
+
+	// A different font checksum (e.g. an updated font file) must miss.
+	if _, err := cache.Load(0x2222, built.Config); !errors.Is(err, ErrCacheMiss) {
+		t.Errorf("Load with different checksum = %v, want ErrCacheMiss", err)
+	}
+
+	// A different atlas geometry must also miss.
+	otherCfg := built.Config
+	otherCfg.GlyphSize = 64
+	if _, err := cache.Load(0x1111, otherCfg); !errors.Is(err, ErrCacheMiss) {
+		t.Errorf("Load with different config = %v, want ErrCacheMiss", err)
+	}
+}
+
+func TestDiskCache_CorruptFile(t *testing.T) {
+	dir := t.TempDir()
+	cache, err := NewDiskCache(dir)
+	if err != nil {
+		t.Fatalf("NewDiskCache failed: %v", err)
+	}
+
+	built := buildTestAtlas(t, 0x3333)
+	if err := cache.Store(built); err != nil {
+		t.Fatalf("Store failed: %v", err)
+	}
+
+	// Truncate the cache file; Load must report a miss, not garbage.
+	entries, err := os.ReadDir(dir)
+	if err != nil || len(entries) != 1 {
+		t.Fatalf("expected one cache file, got %d (err=%v)", len(entries), err)
+	}
+	path := filepath.Join(dir, entries[0].Name())
+	if err := os.Truncate(path, 100); err != nil {
+		t.Fatalf("Truncate failed: %v", err)
+	}
+
+	if _, err := cache.Load(0x3333, built.Config); !errors.Is(err, ErrCacheMiss) {
+		t.Errorf("Load of corrupt file = %v, want ErrCacheMiss", err)
+	}
+}
+
+func TestDiskCache_SkipsUnversionedAtlas(t *testing.T) {
+	dir := t.TempDir()
+	cache, err := NewDiskCache(dir)
+	if err != nil {
+		t.Fatalf("NewDiskCache failed: %v", err)
+	}
+
+	// Zero checksum means the atlas cannot be versioned; it must not be
+	// written to disk.
+	built := buildTestAtlas(t, 0)
+	if err := cache.Store(built); err != nil {
+		t.Fatalf("Store failed: %v", err)
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatalf("ReadDir failed: %v", err)
+	}
+	if len(entries) != 0 {
+		t.Errorf("unversioned atlas was persisted: %d files", len(entries))
+	}
+}
+
+func TestDiskCache_Remove(t *testing.T) {
+	cache, err := NewDiskCache(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewDiskCache failed: %v", err)
+	}
+
+	built := buildTestAtlas(t, 0x4444)
+	if err := cache.Store(built); err != nil {
+		t.Fatalf("Store failed: %v", err)
+	}
+	if err := cache.Remove(0x4444, built.Config); err != nil {
+		t.Fatalf("Remove failed: %v", err)
+	}
+	if _, err := cache.Load(0x4444, built.Config); !errors.Is(err, ErrCacheMiss) {
+		t.Errorf("Load after Remove = %v, want ErrCacheMiss", err)
+	}
+
+	// Removing an absent entry is not an error.
+	if err := cache.Remove(0x4444, built.Config); err != nil {
+		t.Errorf("Remove of absent entry failed: %v", err)
+	}
+}
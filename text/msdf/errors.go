@@ -9,4 +9,8 @@ var (
 
 	// ErrLengthMismatch is returned when keys and outlines have different lengths.
 	ErrLengthMismatch = errors.New("msdf: keys and outlines must have same length")
+
+	// ErrCacheMiss is returned by DiskCache.Load when no usable cached
+	// atlas exists for the requested font checksum and configuration.
+	ErrCacheMiss = errors.New("msdf: atlas not found in disk cache")
 )
@@ -53,7 +53,11 @@ func (g *Generator) Generate(outline *text.GlyphOutline) (*MSDF, error) {
 	}
 
 	// Assign colors to edges based on corners
-	AssignColors(shape, g.config.AngleThreshold)
+	if g.config.Coloring == ColoringVoronoi {
+		AssignColorsByDistance(shape, g.config.AngleThreshold)
+	} else {
+		AssignColors(shape, g.config.AngleThreshold)
+	}
 
 	// Calculate scaling and translation
 	shapeBounds := shape.Bounds
@@ -92,6 +96,14 @@ func (g *Generator) Generate(outline *text.GlyphOutline) (*MSDF, error) {
 	// Generate the distance field
 	g.generateDistanceField(msdf, shape)
 
+	// Flatten clashing texels (conflicting channels between neighbors).
+	// The channel-unit threshold corresponds to one texel of distance:
+	// distanceToPixel maps [-Range, +Range] pixels onto [0, 1], so one
+	// pixel step equals 1/(2*Range) channel units.
+	if g.config.ClashCorrection {
+		CorrectClashes(msdf, clashThresholdFactor/(2*g.config.Range))
+	}
+
 	return msdf, nil
 }
 
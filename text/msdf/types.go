@@ -26,6 +26,18 @@ type Config struct {
 	// Used in edge coloring algorithm.
 	// Default: 1.001
 	EdgeThreshold float64
+
+	// Coloring selects the edge coloring strategy.
+	// ColoringVoronoi keeps spatially close segments on different channels,
+	// which helps glyphs with thin features at small texture sizes.
+	// Default: ColoringSimple
+	Coloring ColoringMode
+
+	// ClashCorrection enables msdfgen-style error correction after
+	// generation: texels where conflicting channels cross the 0.5 isoline
+	// between neighbors are flattened to their median. See [CorrectClashes].
+	// Default: false
+	ClashCorrection bool
 }
 
 // DefaultConfig returns the default MSDF configuration.
@@ -56,6 +68,9 @@ func (c *Config) Validate() error {
 	if c.EdgeThreshold < 1 {
 		return &ConfigError{Field: "EdgeThreshold", Reason: "must be at least 1.0"}
 	}
+	if c.Coloring != ColoringSimple && c.Coloring != ColoringVoronoi {
+		return &ConfigError{Field: "Coloring", Reason: "unknown coloring mode"}
+	}
 	return nil
 }
 
@@ -172,6 +172,16 @@ func (m *MultiFace) Variations() []FontVariation {
 // private implements the Face interface.
 func (m *MultiFace) private() {}
 
+// WithFeatures implements Face.WithFeatures. The features apply to every
+// face in the fallback chain.
+func (m *MultiFace) WithFeatures(features ...FontFeature) Face {
+	faces := make([]Face, len(m.faces))
+	for i, face := range m.faces {
+		faces[i] = face.WithFeatures(features...)
+	}
+	return &MultiFace{faces: faces, direction: m.direction}
+}
+
 // faceForRune returns the first face that has the glyph for the rune.
 // If no face has the glyph, returns the first face as fallback.
 func (m *MultiFace) faceForRune(r rune) Face {
@@ -28,16 +28,17 @@ func newMockFace(size float64, direction Direction, glyphs map[rune]float64) *mo
 	}
 }
 
-func (m *mockFace) Metrics() Metrics            { return m.metrics }
-func (m *mockFace) Direction() Direction        { return m.direction }
-func (m *mockFace) Source() *FontSource         { return nil }
-func (m *mockFace) Size() float64               { return m.size }
-func (m *mockFace) Features() []FontFeature     { return nil }
-func (m *mockFace) Language() string            { return "en" }
-func (m *mockFace) Variations() []FontVariation { return nil }
-func (m *mockFace) private()                    {}
-func (m *mockFace) HasGlyph(r rune) bool        { _, ok := m.glyphs[r]; return ok }
-func (m *mockFace) Advance(text string) float64 { panic("not implemented") }
+func (m *mockFace) Metrics() Metrics                          { return m.metrics }
+func (m *mockFace) Direction() Direction                      { return m.direction }
+func (m *mockFace) Source() *FontSource                       { return nil }
+func (m *mockFace) Size() float64                             { return m.size }
+func (m *mockFace) Features() []FontFeature                   { return nil }
+func (m *mockFace) Language() string                          { return "en" }
+func (m *mockFace) Variations() []FontVariation               { return nil }
+func (m *mockFace) WithFeatures(features ...FontFeature) Face { return m }
+func (m *mockFace) private()                                  {}
+func (m *mockFace) HasGlyph(r rune) bool                      { _, ok := m.glyphs[r]; return ok }
+func (m *mockFace) Advance(text string) float64               { panic("not implemented") }
 func (m *mockFace) Glyphs(text string) iter.Seq[Glyph] {
 	return func(yield func(Glyph) bool) {
 		x := 0.0
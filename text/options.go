@@ -66,6 +66,17 @@ var (
 	// Digits have varying heights and descenders (3, 4, 5, 7, 9 descend),
 	// matching the visual rhythm of body text.
 	OldstyleNums = FontFeature{Tag: [4]byte{'o', 'n', 'u', 'm'}, Value: 1}
+
+	// VerticalForms enables vertical alternates ('vert').
+	// CJK fonts substitute forms designed for vertical writing, such as
+	// rotated punctuation and brackets. Applied automatically by vertical
+	// layout (LayoutOptions.Direction = DirectionTTB).
+	VerticalForms = FontFeature{Tag: [4]byte{'v', 'e', 'r', 't'}, Value: 1}
+
+	// VerticalRotation enables vertical alternates and rotation ('vrt2').
+	// A superset of 'vert' used by fonts that provide pre-rotated forms.
+	// Applied automatically by vertical layout.
+	VerticalRotation = FontFeature{Tag: [4]byte{'v', 'r', 't', '2'}, Value: 1}
 )
 
 // FontVariation sets a font variation axis value for variable fonts.
@@ -57,6 +57,56 @@ type VariableAdvanceProvider interface {
 	GlyphAdvanceVar(glyphIndex uint16, ppem float64, variations []FontVariation) float64
 }
 
+// VerticalMetricsProvider is an optional interface that ParsedFont
+// implementations may implement to provide vertical writing metrics from
+// the vhea and vmtx tables. Fonts without these tables (most Latin fonts)
+// report ok = false and callers fall back to horizontal metrics.
+//
+// See: [VariableAdvanceProvider] for a similar optional-interface pattern.
+type VerticalMetricsProvider interface {
+	// GlyphVerticalAdvance returns the vertical advance for a glyph in
+	// pixels (from vmtx) and whether vertical metrics are available.
+	GlyphVerticalAdvance(glyphIndex uint16, ppem float64) (float64, bool)
+
+	// VerticalMetrics returns the vertical typographic metrics (from
+	// vhea) and whether they are available. Only Ascent, Descent and
+	// LineGap are populated; in vertical layout they describe extents
+	// perpendicular to the flow (right and left of the central baseline).
+	VerticalMetrics(ppem float64) (FontMetrics, bool)
+}
+
+// DecorationMetrics holds font-designed positions for text decorations,
+// scaled to pixels. Positions follow the font convention: positive values
+// are above the baseline, negative below (UnderlinePosition is therefore
+// typically negative). Zero fields mean the font does not specify that
+// metric and callers should fall back to heuristics.
+type DecorationMetrics struct {
+	// UnderlinePosition is the distance from the baseline to the center
+	// of the underline (from post; typically negative).
+	UnderlinePosition float64
+
+	// UnderlineThickness is the underline stroke thickness (from post).
+	UnderlineThickness float64
+
+	// StrikeoutPosition is the distance from the baseline to the center
+	// of the strikethrough (from OS/2; typically positive).
+	StrikeoutPosition float64
+
+	// StrikeoutThickness is the strikethrough stroke thickness (from OS/2).
+	StrikeoutThickness float64
+}
+
+// DecorationMetricsProvider is an optional interface that ParsedFont
+// implementations may implement to expose underline and strikethrough
+// metrics from the post and OS/2 tables.
+//
+// See: [VariableAdvanceProvider] for a similar optional-interface pattern.
+type DecorationMetricsProvider interface {
+	// DecorationMetrics returns the decoration metrics at the given size
+	// and whether any of the source tables were present.
+	DecorationMetrics(ppem float64) (DecorationMetrics, bool)
+}
+
 // FontMetrics holds font-level metrics at a specific size.
 type FontMetrics struct {
 	// Ascent is the distance from the baseline to the top of the font (positive).
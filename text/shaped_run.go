@@ -19,6 +19,11 @@ type ShapedRun struct {
 	// Direction is the text direction for this run.
 	Direction Direction
 
+	// Rotated indicates the run should be drawn rotated 90 degrees
+	// clockwise. Set by vertical layout for horizontal-script (e.g.
+	// Latin) runs embedded in vertical CJK text.
+	Rotated bool
+
 	// Face is the font face used for this run.
 	Face Face
 
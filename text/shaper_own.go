@@ -346,6 +346,8 @@ func isGSUBFeature(tag [4]byte) bool {
 		{'s', 'w', 's', 'h'}: true, // Swash
 		{'s', 'a', 'l', 't'}: true, // Stylistic alternates
 		{'c', 'a', 'l', 't'}: true, // Contextual alternates
+		{'v', 'e', 'r', 't'}: true, // Vertical alternates
+		{'v', 'r', 't', '2'}: true, // Vertical alternates and rotation
 	}
 	return gsubFeatures[tag]
 }
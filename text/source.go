@@ -33,11 +33,18 @@ type FontSource struct {
 	// glyphCache    *Cache[glyphKey, *GlyphImage]
 	// hasGlyphCache *runeToBoolMap
 
+	// glyphCache is the optional budgeted outline cache for this source.
+	// Nil until SetGlyphCacheBudget enables it; renderers then prefer it
+	// over the global glyph cache.
+	glyphCache *budgetedGlyphCache
+
 	// Configuration
 	config sourceConfig
 }
 
-// NewFontSource creates a FontSource from font data (TTF or OTF).
+// NewFontSource creates a FontSource from font data (TTF, OTF, WOFF, or
+// WOFF2 — webfont containers are decompressed transparently). For TTC/OTC
+// collections, pass [WithCollectionIndex] or use [NewFontCollection].
 // The data slice is copied internally and can be reused after this call.
 //
 // Options can be used to configure caching and parser backend.
@@ -46,6 +53,23 @@ func NewFontSource(data []byte, opts ...SourceOption) (*FontSource, error) {
 		return nil, ErrEmptyFontData
 	}
 
+	// Transparently unwrap webfont containers so .woff/.woff2 assets load
+	// like any TTF/OTF.
+	switch {
+	case isWOFF(data):
+		decoded, err := decodeWOFF(data)
+		if err != nil {
+			return nil, err
+		}
+		data = decoded
+	case isWOFF2(data):
+		decoded, err := decodeWOFF2(data)
+		if err != nil {
+			return nil, err
+		}
+		data = decoded
+	}
+
 	// Apply options first to get parser name
 	config := defaultSourceConfig()
 	for _, opt := range opts {
@@ -136,6 +160,68 @@ func (s *FontSource) Parsed() ParsedFont {
 	return s.parsed
 }
 
+// Data returns the raw font bytes (TTF/OTF; webfont containers are already
+// decompressed). This is used by export backends that embed or subset fonts.
+// The returned slice is the source's internal copy and must not be modified.
+func (s *FontSource) Data() []byte {
+	s.copyCheck()
+	return s.data
+}
+
+// SetGlyphCacheBudget enables a dedicated, budgeted glyph outline cache
+// for this source. Renderers then cache this source's outlines there
+// instead of in the global glyph cache, so its memory can be bounded
+// independently. Zero budget fields use the defaults (see
+// [GlyphCacheBudget]). onEvict may be nil.
+//
+// Calling it again replaces the cache (previous entries are dropped).
+// Use [FontSource.ClearGlyphCacheBudget] to return to the global cache.
+func (s *FontSource) SetGlyphCacheBudget(budget GlyphCacheBudget, onEvict GlyphEvictionFunc) {
+	s.copyCheck()
+
+	s.mu.Lock()
+	old := s.glyphCache
+	s.glyphCache = newBudgetedGlyphCache(budget, onEvict)
+	registerBudgetedCache(s.glyphCache)
+	s.mu.Unlock()
+
+	unregisterBudgetedCache(old)
+}
+
+// ClearGlyphCacheBudget removes the per-source glyph cache; the source's
+// outlines are cached in the global glyph cache again.
+func (s *FontSource) ClearGlyphCacheBudget() {
+	s.copyCheck()
+
+	s.mu.Lock()
+	old := s.glyphCache
+	s.glyphCache = nil
+	s.mu.Unlock()
+
+	unregisterBudgetedCache(old)
+}
+
+// GlyphCacheStats returns statistics for this source's budgeted glyph
+// cache. ok is false when no budget is set (see [FontSource.SetGlyphCacheBudget]).
+func (s *FontSource) GlyphCacheStats() (stats CacheReport, ok bool) {
+	s.copyCheck()
+
+	if b := s.budgetedCache(); b != nil {
+		return b.stats(), true
+	}
+	return CacheReport{}, false
+}
+
+// budgetedCache returns the per-source cache, or nil when disabled.
+func (s *FontSource) budgetedCache() *budgetedGlyphCache {
+	if s == nil {
+		return nil
+	}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.glyphCache
+}
+
 // Close releases resources associated with the FontSource.
 // All faces created from this source become invalid after Close.
 func (s *FontSource) Close() error {
@@ -148,6 +234,10 @@ func (s *FontSource) Close() error {
 	s.data = nil
 	s.parsed = nil
 
+	// Drop the budgeted glyph cache, if any
+	unregisterBudgetedCache(s.glyphCache)
+	s.glyphCache = nil
+
 	// Clear caches (when implemented in TASK-044)
 
 	return nil
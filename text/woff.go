@@ -0,0 +1,144 @@
+package text
+
+import (
+	"bytes"
+	"compress/zlib"
+	"encoding/binary"
+	"fmt"
+	"io"
+)
+
+// WOFF (Web Open Font Format 1.0) container decoding. A WOFF file wraps a
+// standard sfnt font with a per-table zlib compression layer; decoding
+// rebuilds the original TTF/OTF byte stream so the normal parser can
+// consume it. See https://www.w3.org/TR/WOFF/.
+
+// isWOFF reports whether data starts with the WOFF 1.0 signature.
+func isWOFF(data []byte) bool {
+	return len(data) >= 4 && string(data[:4]) == "wOFF"
+}
+
+// isWOFF2 reports whether data starts with the WOFF 2.0 signature.
+func isWOFF2(data []byte) bool {
+	return len(data) >= 4 && string(data[:4]) == "wOF2"
+}
+
+// woffHeaderSize is the fixed WOFF 1.0 header size.
+const woffHeaderSize = 44
+
+// woffDirEntrySize is the size of one WOFF 1.0 table directory entry.
+const woffDirEntrySize = 20
+
+// decodeWOFF converts a WOFF 1.0 container to sfnt (TTF/OTF) bytes.
+func decodeWOFF(data []byte) ([]byte, error) {
+	if len(data) < woffHeaderSize {
+		return nil, fmt.Errorf("text: WOFF: truncated header (%d bytes)", len(data))
+	}
+
+	flavor := binary.BigEndian.Uint32(data[4:8])
+	numTables := int(binary.BigEndian.Uint16(data[12:14]))
+	if numTables == 0 {
+		return nil, fmt.Errorf("text: WOFF: no tables")
+	}
+
+	dirEnd := woffHeaderSize + numTables*woffDirEntrySize
+	if len(data) < dirEnd {
+		return nil, fmt.Errorf("text: WOFF: truncated table directory")
+	}
+
+	type woffTable struct {
+		tag      uint32
+		checksum uint32
+		payload  []byte
+	}
+	tables := make([]woffTable, numTables)
+
+	for i := 0; i < numTables; i++ {
+		entry := data[woffHeaderSize+i*woffDirEntrySize:]
+		tag := binary.BigEndian.Uint32(entry[0:4])
+		offset := binary.BigEndian.Uint32(entry[4:8])
+		compLength := binary.BigEndian.Uint32(entry[8:12])
+		origLength := binary.BigEndian.Uint32(entry[12:16])
+		checksum := binary.BigEndian.Uint32(entry[16:20])
+
+		end := uint64(offset) + uint64(compLength)
+		if end > uint64(len(data)) {
+			return nil, fmt.Errorf("text: WOFF: table %d overruns file", i)
+		}
+		comp := data[offset:end]
+
+		var payload []byte
+		if compLength == origLength {
+			payload = comp // stored uncompressed
+		} else {
+			zr, err := zlib.NewReader(bytes.NewReader(comp))
+			if err != nil {
+				return nil, fmt.Errorf("text: WOFF: table %d: %w", i, err)
+			}
+			payload, err = io.ReadAll(io.LimitReader(zr, int64(origLength)+1))
+			_ = zr.Close()
+			if err != nil {
+				return nil, fmt.Errorf("text: WOFF: table %d: %w", i, err)
+			}
+			if uint32(len(payload)) != origLength {
+				return nil, fmt.Errorf("text: WOFF: table %d: decompressed %d bytes, expected %d",
+					i, len(payload), origLength)
+			}
+		}
+		tables[i] = woffTable{tag: tag, checksum: checksum, payload: payload}
+	}
+
+	// Rebuild the sfnt: offset table, directory, then 4-byte aligned table
+	// data. The WOFF directory is required to be sorted by tag, so the
+	// rebuilt directory keeps its order.
+	sfntTables := make([]sfntTable, numTables)
+	for i, t := range tables {
+		sfntTables[i] = sfntTable{tag: t.tag, checksum: t.checksum, payload: t.payload}
+	}
+	return buildSFNT(flavor, sfntTables), nil
+}
+
+// sfntTable is one table to serialize into a rebuilt sfnt stream.
+type sfntTable struct {
+	tag      uint32
+	checksum uint32
+	payload  []byte
+}
+
+// buildSFNT serializes tables into a standard sfnt byte stream with the
+// given flavor (0x00010000 for TrueType, 'OTTO' for CFF).
+func buildSFNT(flavor uint32, tables []sfntTable) []byte {
+	numTables := len(tables)
+
+	// searchRange/entrySelector/rangeShift per the sfnt spec.
+	entrySelector := 0
+	for 1<<(entrySelector+1) <= numTables {
+		entrySelector++
+	}
+	searchRange := (1 << entrySelector) * 16
+	rangeShift := numTables*16 - searchRange
+
+	size := 12 + numTables*16
+	for _, t := range tables {
+		size += (len(t.payload) + 3) &^ 3
+	}
+	out := make([]byte, size)
+
+	binary.BigEndian.PutUint32(out[0:4], flavor)
+	binary.BigEndian.PutUint16(out[4:6], uint16(numTables))      // #nosec G115 -- sfnt caps tables at 2^16
+	binary.BigEndian.PutUint16(out[6:8], uint16(searchRange))    // #nosec G115
+	binary.BigEndian.PutUint16(out[8:10], uint16(entrySelector)) // #nosec G115
+	binary.BigEndian.PutUint16(out[10:12], uint16(rangeShift))   // #nosec G115
+
+	offset := 12 + numTables*16
+	for i, t := range tables {
+		entry := out[12+i*16:]
+		binary.BigEndian.PutUint32(entry[0:4], t.tag)
+		binary.BigEndian.PutUint32(entry[4:8], t.checksum)
+		binary.BigEndian.PutUint32(entry[8:12], uint32(offset))          // #nosec G115
+		binary.BigEndian.PutUint32(entry[12:16], uint32(len(t.payload))) // #nosec G115
+		copy(out[offset:], t.payload)
+		offset += (len(t.payload) + 3) &^ 3
+	}
+	return out
+}
@@ -0,0 +1,753 @@
+package text
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+
+	"github.com/andybalholm/brotli"
+)
+
+// WOFF2 (Web Open Font Format 2.0) container decoding. WOFF2 compresses
+// all tables in a single brotli stream and additionally stores glyf/loca
+// in a transformed representation that must be reconstructed. See
+// https://www.w3.org/TR/WOFF2/.
+
+// woff2HeaderSize is the fixed WOFF2 header size.
+const woff2HeaderSize = 48
+
+// woff2KnownTags is the WOFF2 known-table-tags array: directory entries
+// reference these by index instead of storing a 4-byte tag. Index 63
+// (0x3F) means an explicit tag follows.
+var woff2KnownTags = [63]string{
+	"cmap", "head", "hhea", "hmtx", "maxp", "name", "OS/2", "post",
+	"cvt ", "fpgm", "glyf", "loca", "prep", "CFF ", "VORG", "EBDT",
+	"EBLC", "gasp", "hdmx", "kern", "LTSH", "PCLT", "VDMX", "vhea",
+	"vmtx", "BASE", "GDEF", "GPOS", "GSUB", "EBSC", "JSTF", "MATH",
+	"CBDT", "CBLC", "COLR", "CPAL", "SVG ", "sbix", "acnt", "avar",
+	"bdat", "bloc", "bsln", "cvar", "fdsc", "feat", "fmtx", "fvar",
+	"gvar", "hsty", "just", "lcar", "mort", "morx", "opbd", "prop",
+	"trak", "Zapf", "Silf", "Glat", "Gloc", "Feat", "Sill",
+}
+
+// woff2Table is one entry of the decoded WOFF2 table directory.
+type woff2Table struct {
+	tag             string
+	transform       uint8 // transform version (0-3)
+	origLength      uint32
+	transformLength uint32 // length in the decompressed stream
+}
+
+// transformed reports whether the table uses a non-null transform.
+// For glyf and loca, version 0 is the transformed representation and
+// version 3 is null; for every other table version 0 is null.
+func (t *woff2Table) transformed() bool {
+	if t.tag == "glyf" || t.tag == "loca" {
+		return t.transform == 0
+	}
+	return t.transform != 0
+}
+
+// streamLength returns the table's length within the decompressed stream.
+func (t *woff2Table) streamLength() uint32 {
+	if t.transformed() {
+		return t.transformLength
+	}
+	return t.origLength
+}
+
+// decodeWOFF2 converts a WOFF2 container to sfnt (TTF/OTF) bytes.
+func decodeWOFF2(data []byte) ([]byte, error) {
+	if len(data) < woff2HeaderSize {
+		return nil, fmt.Errorf("text: WOFF2: truncated header (%d bytes)", len(data))
+	}
+
+	flavor := binary.BigEndian.Uint32(data[4:8])
+	if flavor == 0x74746366 { // 'ttcf'
+		return nil, fmt.Errorf("text: WOFF2: collection flavor not supported")
+	}
+	numTables := int(binary.BigEndian.Uint16(data[12:14]))
+	if numTables == 0 {
+		return nil, fmt.Errorf("text: WOFF2: no tables")
+	}
+	totalCompressedSize := binary.BigEndian.Uint32(data[20:24])
+
+	// Parse the variable-length table directory.
+	r := &byteReader{data: data, pos: woff2HeaderSize}
+	tables := make([]woff2Table, numTables)
+	for i := range tables {
+		flags, err := r.u8()
+		if err != nil {
+			return nil, fmt.Errorf("text: WOFF2: table directory: %w", err)
+		}
+		t := woff2Table{transform: flags >> 6}
+		if tagIdx := flags & 0x3f; tagIdx == 0x3f {
+			rawTag, err := r.u32()
+			if err != nil {
+				return nil, fmt.Errorf("text: WOFF2: table directory: %w", err)
+			}
+			t.tag = string([]byte{byte(rawTag >> 24), byte(rawTag >> 16), byte(rawTag >> 8), byte(rawTag)})
+		} else {
+			t.tag = woff2KnownTags[tagIdx]
+		}
+		if t.origLength, err = r.uintBase128(); err != nil {
+			return nil, fmt.Errorf("text: WOFF2: table %q: %w", t.tag, err)
+		}
+		if t.transformed() {
+			if t.transformLength, err = r.uintBase128(); err != nil {
+				return nil, fmt.Errorf("text: WOFF2: table %q: %w", t.tag, err)
+			}
+		}
+		tables[i] = t
+	}
+
+	// Decompress the single brotli stream holding all tables.
+	compEnd := uint64(r.pos) + uint64(totalCompressedSize)
+	if compEnd > uint64(len(data)) {
+		return nil, fmt.Errorf("text: WOFF2: compressed data overruns file")
+	}
+	var streamLen uint64
+	for i := range tables {
+		streamLen += uint64(tables[i].streamLength())
+	}
+	stream, err := io.ReadAll(io.LimitReader(
+		brotli.NewReader(bytes.NewReader(data[r.pos:compEnd])), int64(streamLen)+1)) // #nosec G115
+	if err != nil {
+		return nil, fmt.Errorf("text: WOFF2: brotli: %w", err)
+	}
+	if uint64(len(stream)) != streamLen {
+		return nil, fmt.Errorf("text: WOFF2: decompressed %d bytes, expected %d", len(stream), streamLen)
+	}
+
+	// Slice the stream into per-table payloads and reconstruct transformed
+	// tables. glyf reconstruction also produces loca, which the spec
+	// requires to be transformed alongside glyf with transformLength 0.
+	payloads := make(map[string][]byte, numTables)
+	var indexFormat uint16
+	offset := 0
+	for i := range tables {
+		t := &tables[i]
+		raw := stream[offset : offset+int(t.streamLength())]
+		offset += int(t.streamLength())
+
+		switch {
+		case t.tag == "glyf" && t.transformed():
+			glyf, loca, format, err := reconstructGlyf(raw)
+			if err != nil {
+				return nil, err
+			}
+			payloads["glyf"] = glyf
+			payloads["loca"] = loca
+			indexFormat = format
+		case t.tag == "loca" && t.transformed():
+			if payloads["loca"] == nil {
+				return nil, fmt.Errorf("text: WOFF2: transformed loca without glyf")
+			}
+			// Already produced by glyf reconstruction.
+		case t.tag == "hmtx" && t.transformed():
+			hmtx, err := reconstructHmtx(raw, payloads)
+			if err != nil {
+				return nil, err
+			}
+			payloads["hmtx"] = hmtx
+		case t.transformed():
+			return nil, fmt.Errorf("text: WOFF2: unsupported transform %d for table %q", t.transform, t.tag)
+		default:
+			payloads[t.tag] = raw
+		}
+	}
+
+	// Patch head.indexToLocFormat to match the reconstructed loca.
+	if head := payloads["head"]; len(head) >= 52 && payloads["glyf"] != nil {
+		patched := make([]byte, len(head))
+		copy(patched, head)
+		binary.BigEndian.PutUint16(patched[50:52], indexFormat)
+		payloads["head"] = patched
+	}
+
+	out := make([]sfntTable, 0, numTables)
+	for i := range tables {
+		payload, ok := payloads[tables[i].tag]
+		if !ok {
+			continue
+		}
+		out = append(out, sfntTable{tag: binary.BigEndian.Uint32([]byte(tables[i].tag)), payload: payload})
+	}
+	return buildSFNT(flavor, out), nil
+}
+
+// reconstructGlyf rebuilds the glyf and loca tables from the WOFF2
+// transformed glyf representation, returning (glyf, loca, indexFormat).
+func reconstructGlyf(data []byte) (glyf, loca []byte, indexFormat uint16, err error) {
+	r := &byteReader{data: data}
+	if _, err = r.u32(); err != nil { // reserved/version
+		return nil, nil, 0, fmt.Errorf("text: WOFF2: glyf: %w", err)
+	}
+	numGlyphs, err := r.u16()
+	if err != nil {
+		return nil, nil, 0, fmt.Errorf("text: WOFF2: glyf: %w", err)
+	}
+	indexFormat, err = r.u16()
+	if err != nil {
+		return nil, nil, 0, fmt.Errorf("text: WOFF2: glyf: %w", err)
+	}
+
+	var sizes [7]uint32
+	for i := range sizes {
+		if sizes[i], err = r.u32(); err != nil {
+			return nil, nil, 0, fmt.Errorf("text: WOFF2: glyf: %w", err)
+		}
+	}
+
+	streams := make([]*byteReader, 7)
+	pos := r.pos
+	for i, size := range sizes {
+		if uint64(pos)+uint64(size) > uint64(len(data)) {
+			return nil, nil, 0, fmt.Errorf("text: WOFF2: glyf: stream %d overruns table", i)
+		}
+		streams[i] = &byteReader{data: data[pos : pos+int(size)]}
+		pos += int(size)
+	}
+	nContourStream := streams[0]
+	nPointsStream := streams[1]
+	flagStream := streams[2]
+	glyphStream := streams[3]
+	compositeStream := streams[4]
+	bboxStream := streams[5]
+	instructionStream := streams[6]
+
+	// The bbox stream starts with a bitmap marking which glyphs carry an
+	// explicit bounding box.
+	bitmapLen := ((int(numGlyphs) + 31) >> 5) << 2
+	if len(bboxStream.data) < bitmapLen {
+		return nil, nil, 0, fmt.Errorf("text: WOFF2: glyf: bbox bitmap truncated")
+	}
+	bboxBitmap := bboxStream.data[:bitmapLen]
+	bboxStream.pos = bitmapLen
+
+	offsets := make([]uint32, numGlyphs+1)
+	var glyfOut []byte
+
+	for gi := 0; gi < int(numGlyphs); gi++ {
+		offsets[gi] = uint32(len(glyfOut)) // #nosec G115
+		hasBBox := bboxBitmap[gi>>3]&(0x80>>(gi&7)) != 0
+
+		nContours, err := nContourStream.u16()
+		if err != nil {
+			return nil, nil, 0, fmt.Errorf("text: WOFF2: glyf: glyph %d: %w", gi, err)
+		}
+
+		switch int16(nContours) { // #nosec G115 -- spec-defined reinterpretation
+		case 0: // empty glyph
+			if hasBBox {
+				return nil, nil, 0, fmt.Errorf("text: WOFF2: glyf: empty glyph %d with bbox", gi)
+			}
+		case -1: // composite glyph
+			body, err := reconstructComposite(compositeStream, glyphStream, instructionStream)
+			if err != nil {
+				return nil, nil, 0, fmt.Errorf("text: WOFF2: glyf: glyph %d: %w", gi, err)
+			}
+			if !hasBBox {
+				return nil, nil, 0, fmt.Errorf("text: WOFF2: glyf: composite glyph %d without bbox", gi)
+			}
+			bbox, err := bboxStream.bytes(8)
+			if err != nil {
+				return nil, nil, 0, fmt.Errorf("text: WOFF2: glyf: glyph %d bbox: %w", gi, err)
+			}
+			glyfOut = append(glyfOut, 0xff, 0xff) // numberOfContours = -1
+			glyfOut = append(glyfOut, bbox...)
+			glyfOut = append(glyfOut, body...)
+		default: // simple glyph
+			body, err := reconstructSimple(int(nContours), hasBBox,
+				nPointsStream, flagStream, glyphStream, bboxStream, instructionStream)
+			if err != nil {
+				return nil, nil, 0, fmt.Errorf("text: WOFF2: glyf: glyph %d: %w", gi, err)
+			}
+			glyfOut = append(glyfOut, body...)
+		}
+
+		// Keep every glyph 2-byte aligned (short loca stores offset/2).
+		if len(glyfOut)&1 != 0 {
+			glyfOut = append(glyfOut, 0)
+		}
+	}
+	offsets[numGlyphs] = uint32(len(glyfOut)) // #nosec G115
+
+	loca = buildLoca(offsets, indexFormat)
+	return glyfOut, loca, indexFormat, nil
+}
+
+// reconstructSimple rebuilds one simple glyph record.
+func reconstructSimple(nContours int, hasBBox bool,
+	nPointsStream, flagStream, glyphStream, bboxStream, instructionStream *byteReader,
+) ([]byte, error) {
+	endPts := make([]uint16, nContours)
+	totalPoints := 0
+	for i := 0; i < nContours; i++ {
+		n, err := nPointsStream.read255U16()
+		if err != nil {
+			return nil, err
+		}
+		totalPoints += int(n)
+		if totalPoints > 0xffff {
+			return nil, fmt.Errorf("point count overflow")
+		}
+		endPts[i] = uint16(totalPoints - 1) // #nosec G115 -- bounds checked above
+	}
+
+	type point struct {
+		x, y    int
+		onCurve bool
+	}
+	points := make([]point, totalPoints)
+	x, y := 0, 0
+	for i := range points {
+		flag, err := flagStream.u8()
+		if err != nil {
+			return nil, err
+		}
+		dx, dy, err := glyphStream.triplet(flag & 0x7f)
+		if err != nil {
+			return nil, err
+		}
+		x += dx
+		y += dy
+		points[i] = point{x: x, y: y, onCurve: flag&0x80 == 0}
+	}
+
+	instrLen, err := glyphStream.read255U16()
+	if err != nil {
+		return nil, err
+	}
+	instructions, err := instructionStream.bytes(int(instrLen))
+	if err != nil {
+		return nil, err
+	}
+
+	// Bounding box: explicit from the bbox stream, or computed from points.
+	var xMin, yMin, xMax, yMax int
+	if hasBBox {
+		bbox, err := bboxStream.bytes(8)
+		if err != nil {
+			return nil, err
+		}
+		xMin = int(int16(binary.BigEndian.Uint16(bbox[0:2])))
+		yMin = int(int16(binary.BigEndian.Uint16(bbox[2:4])))
+		xMax = int(int16(binary.BigEndian.Uint16(bbox[4:6])))
+		yMax = int(int16(binary.BigEndian.Uint16(bbox[6:8])))
+	} else if totalPoints > 0 {
+		xMin, yMin = points[0].x, points[0].y
+		xMax, yMax = points[0].x, points[0].y
+		for _, p := range points[1:] {
+			xMin, xMax = min(xMin, p.x), max(xMax, p.x)
+			yMin, yMax = min(yMin, p.y), max(yMax, p.y)
+		}
+	}
+
+	// Serialize the standard simple-glyph record. Flags are written one
+	// per point (no repeat compression — larger but always valid).
+	var out []byte
+	out = appendUint16(out, uint16(nContours)) // #nosec G115
+	out = appendInt16(out, xMin)
+	out = appendInt16(out, yMin)
+	out = appendInt16(out, xMax)
+	out = appendInt16(out, yMax)
+	for _, e := range endPts {
+		out = appendUint16(out, e)
+	}
+	out = appendUint16(out, instrLen)
+	out = append(out, instructions...)
+
+	const (
+		flagOnCurve = 0x01
+		flagXShort  = 0x02
+		flagYShort  = 0x04
+		flagXSame   = 0x10
+		flagYSame   = 0x20
+	)
+	prevX, prevY := 0, 0
+	flags := make([]byte, totalPoints)
+	for i, p := range points {
+		dx, dy := p.x-prevX, p.y-prevY
+		prevX, prevY = p.x, p.y
+		var f byte
+		if p.onCurve {
+			f |= flagOnCurve
+		}
+		switch {
+		case dx == 0:
+			f |= flagXSame
+		case dx >= -255 && dx <= 255:
+			f |= flagXShort
+			if dx > 0 {
+				f |= flagXSame // positive sign when short
+			}
+		}
+		switch {
+		case dy == 0:
+			f |= flagYSame
+		case dy >= -255 && dy <= 255:
+			f |= flagYShort
+			if dy > 0 {
+				f |= flagYSame
+			}
+		}
+		flags[i] = f
+	}
+	out = append(out, flags...)
+
+	prevX = 0
+	for _, p := range points {
+		dx := p.x - prevX
+		prevX = p.x
+		switch {
+		case dx == 0:
+		case dx >= -255 && dx <= 255:
+			out = append(out, byte(abs(dx)))
+		default:
+			out = appendInt16(out, dx)
+		}
+	}
+	prevY = 0
+	for _, p := range points {
+		dy := p.y - prevY
+		prevY = p.y
+		switch {
+		case dy == 0:
+		case dy >= -255 && dy <= 255:
+			out = append(out, byte(abs(dy)))
+		default:
+			out = appendInt16(out, dy)
+		}
+	}
+	return out, nil
+}
+
+// reconstructComposite copies one composite glyph's component records from
+// the composite stream (the records use the standard glyf encoding) and
+// its instructions from the instruction stream.
+func reconstructComposite(compositeStream, glyphStream, instructionStream *byteReader) ([]byte, error) {
+	const (
+		argsAreWords   = 0x0001
+		weHaveScale    = 0x0008
+		moreComponents = 0x0020
+		weHaveXYScale  = 0x0040
+		weHave2x2      = 0x0080
+		weHaveInstr    = 0x0100
+	)
+
+	var out []byte
+	haveInstructions := false
+	for {
+		flags, err := compositeStream.u16()
+		if err != nil {
+			return nil, err
+		}
+		size := 4 // flags + glyphIndex
+		if flags&argsAreWords != 0 {
+			size += 4
+		} else {
+			size += 2
+		}
+		switch {
+		case flags&weHaveScale != 0:
+			size += 2
+		case flags&weHaveXYScale != 0:
+			size += 4
+		case flags&weHave2x2 != 0:
+			size += 8
+		}
+		compositeStream.pos -= 2
+		record, err := compositeStream.bytes(size)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, record...)
+		if flags&weHaveInstr != 0 {
+			haveInstructions = true
+		}
+		if flags&moreComponents == 0 {
+			break
+		}
+	}
+
+	if haveInstructions {
+		instrLen, err := glyphStream.read255U16()
+		if err != nil {
+			return nil, err
+		}
+		instructions, err := instructionStream.bytes(int(instrLen))
+		if err != nil {
+			return nil, err
+		}
+		out = appendUint16(out, instrLen)
+		out = append(out, instructions...)
+	}
+	return out, nil
+}
+
+// reconstructHmtx rebuilds a transformed hmtx table (transform version 1),
+// which omits leftSideBearing arrays that equal the glyph xMin values.
+// Requires the already-reconstructed glyf/loca plus hhea and maxp.
+func reconstructHmtx(data []byte, payloads map[string][]byte) ([]byte, error) {
+	hhea, maxp := payloads["hhea"], payloads["maxp"]
+	glyf, loca := payloads["glyf"], payloads["loca"]
+	head := payloads["head"]
+	if len(hhea) < 36 || len(maxp) < 6 || glyf == nil || loca == nil || len(head) < 52 {
+		return nil, fmt.Errorf("text: WOFF2: hmtx: missing dependency tables")
+	}
+	numGlyphs := int(binary.BigEndian.Uint16(maxp[4:6]))
+	numHMetrics := int(binary.BigEndian.Uint16(hhea[34:36]))
+	if numHMetrics < 1 || numHMetrics > numGlyphs {
+		return nil, fmt.Errorf("text: WOFF2: hmtx: invalid numberOfHMetrics %d", numHMetrics)
+	}
+	longLoca := binary.BigEndian.Uint16(head[50:52]) != 0
+
+	r := &byteReader{data: data}
+	flags, err := r.u8()
+	if err != nil {
+		return nil, fmt.Errorf("text: WOFF2: hmtx: %w", err)
+	}
+	lsbOmitted := flags&0x01 != 0
+	leftSideBearingOmitted := flags&0x02 != 0
+
+	xMin := func(gi int) (int16, error) {
+		var start, end uint32
+		if longLoca {
+			start = binary.BigEndian.Uint32(loca[gi*4:])
+			end = binary.BigEndian.Uint32(loca[gi*4+4:])
+		} else {
+			start = uint32(binary.BigEndian.Uint16(loca[gi*2:])) * 2
+			end = uint32(binary.BigEndian.Uint16(loca[gi*2+2:])) * 2
+		}
+		if start == end {
+			return 0, nil // empty glyph
+		}
+		if uint64(start)+4 > uint64(len(glyf)) {
+			return 0, fmt.Errorf("text: WOFF2: hmtx: glyph %d out of range", gi)
+		}
+		return int16(binary.BigEndian.Uint16(glyf[start+2:])), nil // #nosec G115
+	}
+
+	out := make([]byte, 0, numHMetrics*4+(numGlyphs-numHMetrics)*2)
+	for gi := 0; gi < numHMetrics; gi++ {
+		advance, err := r.u16()
+		if err != nil {
+			return nil, fmt.Errorf("text: WOFF2: hmtx: %w", err)
+		}
+		out = appendUint16(out, advance)
+		var lsb int16
+		if lsbOmitted {
+			if lsb, err = xMin(gi); err != nil {
+				return nil, err
+			}
+		} else {
+			v, err := r.u16()
+			if err != nil {
+				return nil, fmt.Errorf("text: WOFF2: hmtx: %w", err)
+			}
+			lsb = int16(v) // #nosec G115
+		}
+		out = appendInt16(out, int(lsb))
+	}
+	for gi := numHMetrics; gi < numGlyphs; gi++ {
+		var lsb int16
+		if leftSideBearingOmitted {
+			var err error
+			if lsb, err = xMin(gi); err != nil {
+				return nil, err
+			}
+		} else {
+			v, err := r.u16()
+			if err != nil {
+				return nil, fmt.Errorf("text: WOFF2: hmtx: %w", err)
+			}
+			lsb = int16(v) // #nosec G115
+		}
+		out = appendInt16(out, int(lsb))
+	}
+	return out, nil
+}
+
+// buildLoca serializes glyph offsets in short (format 0) or long (format 1)
+// loca encoding.
+func buildLoca(offsets []uint32, indexFormat uint16) []byte {
+	if indexFormat == 0 {
+		out := make([]byte, len(offsets)*2)
+		for i, off := range offsets {
+			binary.BigEndian.PutUint16(out[i*2:], uint16(off/2)) // #nosec G115
+		}
+		return out
+	}
+	out := make([]byte, len(offsets)*4)
+	for i, off := range offsets {
+		binary.BigEndian.PutUint32(out[i*4:], off)
+	}
+	return out
+}
+
+// byteReader is a bounds-checked big-endian reader over a byte slice with
+// the WOFF2 variable-length integer encodings.
+type byteReader struct {
+	data []byte
+	pos  int
+}
+
+func (r *byteReader) u8() (uint8, error) {
+	if r.pos >= len(r.data) {
+		return 0, fmt.Errorf("unexpected end of data")
+	}
+	v := r.data[r.pos]
+	r.pos++
+	return v, nil
+}
+
+func (r *byteReader) u16() (uint16, error) {
+	if r.pos+2 > len(r.data) {
+		return 0, fmt.Errorf("unexpected end of data")
+	}
+	v := binary.BigEndian.Uint16(r.data[r.pos:])
+	r.pos += 2
+	return v, nil
+}
+
+func (r *byteReader) u32() (uint32, error) {
+	if r.pos+4 > len(r.data) {
+		return 0, fmt.Errorf("unexpected end of data")
+	}
+	v := binary.BigEndian.Uint32(r.data[r.pos:])
+	r.pos += 4
+	return v, nil
+}
+
+func (r *byteReader) bytes(n int) ([]byte, error) {
+	if n < 0 || r.pos+n > len(r.data) {
+		return nil, fmt.Errorf("unexpected end of data")
+	}
+	v := r.data[r.pos : r.pos+n]
+	r.pos += n
+	return v, nil
+}
+
+// uintBase128 reads a WOFF2 UIntBase128: 1-5 bytes, 7 bits each, MSB set
+// on continuation bytes. Leading zero bytes are forbidden.
+func (r *byteReader) uintBase128() (uint32, error) {
+	var v uint32
+	for i := 0; i < 5; i++ {
+		b, err := r.u8()
+		if err != nil {
+			return 0, err
+		}
+		if i == 0 && b == 0x80 {
+			return 0, fmt.Errorf("UIntBase128: leading zero")
+		}
+		if v&0xfe000000 != 0 {
+			return 0, fmt.Errorf("UIntBase128: overflow")
+		}
+		v = v<<7 | uint32(b&0x7f)
+		if b&0x80 == 0 {
+			return v, nil
+		}
+	}
+	return 0, fmt.Errorf("UIntBase128: exceeds 5 bytes")
+}
+
+// read255U16 reads a WOFF2 255UInt16 variable-length integer.
+func (r *byteReader) read255U16() (uint16, error) {
+	const (
+		wordCode         = 253
+		oneMoreByteCode2 = 254
+		oneMoreByteCode1 = 255
+		lowestUCode      = 253
+	)
+	b, err := r.u8()
+	if err != nil {
+		return 0, err
+	}
+	switch b {
+	case wordCode:
+		return r.u16()
+	case oneMoreByteCode1:
+		v, err := r.u8()
+		return lowestUCode + uint16(v), err
+	case oneMoreByteCode2:
+		v, err := r.u8()
+		return lowestUCode*2 + uint16(v), err
+	default:
+		return uint16(b), nil
+	}
+}
+
+// triplet decodes one point delta from the glyph stream using the WOFF2
+// triplet encoding. index is the low 7 bits of the point's flag byte.
+func (r *byteReader) triplet(index uint8) (dx, dy int, err error) {
+	withSign := func(flag uint8, v int) int {
+		if flag&1 != 0 {
+			return v
+		}
+		return -v
+	}
+
+	switch {
+	case index < 10:
+		data, err := r.bytes(1)
+		if err != nil {
+			return 0, 0, err
+		}
+		return 0, withSign(index, int(index&14)<<7|int(data[0])), nil
+	case index < 20:
+		data, err := r.bytes(1)
+		if err != nil {
+			return 0, 0, err
+		}
+		return withSign(index, int((index-10)&14)<<7|int(data[0])), 0, nil
+	case index < 84:
+		data, err := r.bytes(1)
+		if err != nil {
+			return 0, 0, err
+		}
+		b0 := int(index - 20)
+		b1 := int(data[0])
+		return withSign(index, 1+(b0&0x30)+(b1>>4)),
+			withSign(index>>1, 1+((b0&0x0c)<<2)+(b1&0x0f)), nil
+	case index < 120:
+		data, err := r.bytes(2)
+		if err != nil {
+			return 0, 0, err
+		}
+		b0 := int(index - 84)
+		return withSign(index, 1+(b0/12)<<8+int(data[0])),
+			withSign(index>>1, 1+(b0%12>>2)<<8+int(data[1])), nil
+	case index < 124:
+		data, err := r.bytes(3)
+		if err != nil {
+			return 0, 0, err
+		}
+		b2 := int(data[1])
+		return withSign(index, int(data[0])<<4|b2>>4),
+			withSign(index>>1, (b2&0x0f)<<8|int(data[2])), nil
+	default:
+		data, err := r.bytes(4)
+		if err != nil {
+			return 0, 0, err
+		}
+		return withSign(index, int(data[0])<<8|int(data[1])),
+			withSign(index>>1, int(data[2])<<8|int(data[3])), nil
+	}
+}
+
+func appendUint16(b []byte, v uint16) []byte {
+	return append(b, byte(v>>8), byte(v))
+}
+
+func appendInt16(b []byte, v int) []byte {
+	return append(b, byte(uint16(int16(v))>>8), byte(uint16(int16(v)))) // #nosec G115
+}
+
+func abs(v int) int {
+	if v < 0 {
+		return -v
+	}
+	return v
+}
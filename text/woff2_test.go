@@ -0,0 +1,269 @@
+package text
+
+import (
+	"bytes"
+	"encoding/binary"
+	"os"
+	"testing"
+
+	"github.com/andybalholm/brotli"
+)
+
+// woff2KnownTagIndex returns the known-tags index for a tag, or -1.
+func woff2KnownTagIndex(tag string) int {
+	for i, known := range woff2KnownTags {
+		if known == tag {
+			return i
+		}
+	}
+	return -1
+}
+
+// encodeWOFF2 wraps sfnt data in a WOFF2 container using null transforms
+// for every table (transform version 3 for glyf/loca, 0 otherwise).
+func encodeWOFF2(t *testing.T, sfnt []byte) []byte {
+	t.Helper()
+	flavor, tags, payloads := parseSFNTTables(t, sfnt)
+
+	var dir bytes.Buffer
+	var stream bytes.Buffer
+	for i, tag := range tags {
+		flags := byte(0)
+		if tag == "glyf" || tag == "loca" {
+			flags = 3 << 6 // null transform for glyf/loca is version 3
+		}
+		if idx := woff2KnownTagIndex(tag); idx >= 0 {
+			dir.WriteByte(flags | byte(idx))
+		} else {
+			dir.WriteByte(flags | 0x3f)
+			dir.WriteString(tag)
+		}
+		writeUIntBase128(&dir, uint32(len(payloads[i])))
+		stream.Write(payloads[i])
+	}
+
+	var comp bytes.Buffer
+	bw := brotli.NewWriter(&comp)
+	_, _ = bw.Write(stream.Bytes())
+	_ = bw.Close()
+
+	header := make([]byte, woff2HeaderSize)
+	copy(header[0:4], "wOF2")
+	binary.BigEndian.PutUint32(header[4:8], flavor)
+	binary.BigEndian.PutUint16(header[12:14], uint16(len(tags)))
+	binary.BigEndian.PutUint32(header[16:20], uint32(len(sfnt)))
+	binary.BigEndian.PutUint32(header[20:24], uint32(comp.Len()))
+
+	out := append(header, dir.Bytes()...)
+	out = append(out, comp.Bytes()...)
+	binary.BigEndian.PutUint32(out[8:12], uint32(len(out)))
+	return out
+}
+
+// writeUIntBase128 appends a WOFF2 UIntBase128 encoding of v.
+func writeUIntBase128(buf *bytes.Buffer, v uint32) {
+	var tmp [5]byte
+	n := 0
+	for {
+		tmp[n] = byte(v & 0x7f)
+		v >>= 7
+		n++
+		if v == 0 {
+			break
+		}
+	}
+	for i := n - 1; i >= 0; i-- {
+		b := tmp[i]
+		if i > 0 {
+			b |= 0x80
+		}
+		buf.WriteByte(b)
+	}
+}
+
+func TestWOFF2RoundTrip(t *testing.T) {
+	sfnt, err := os.ReadFile("testdata/goregular.ttf")
+	if err != nil {
+		t.Fatalf("test font unavailable: %v", err)
+	}
+
+	woff2 := encodeWOFF2(t, sfnt)
+	if !isWOFF2(woff2) {
+		t.Fatal("encoded data is not recognized as WOFF2")
+	}
+
+	source, err := NewFontSource(woff2)
+	if err != nil {
+		t.Fatalf("NewFontSource(woff2) failed: %v", err)
+	}
+	defer func() { _ = source.Close() }()
+
+	ref, err := NewFontSource(sfnt)
+	if err != nil {
+		t.Fatalf("NewFontSource(sfnt) failed: %v", err)
+	}
+	defer func() { _ = ref.Close() }()
+
+	if got, want := source.Parsed().NumGlyphs(), ref.Parsed().NumGlyphs(); got != want {
+		t.Errorf("NumGlyphs = %d, want %d", got, want)
+	}
+	if got, want := source.Face(16).Advance("Hello"), ref.Face(16).Advance("Hello"); got != want {
+		t.Errorf("Advance = %v, want %v", got, want)
+	}
+}
+
+func TestWOFF2Read255U16(t *testing.T) {
+	cases := []struct {
+		in   []byte
+		want uint16
+	}{
+		{[]byte{0}, 0},
+		{[]byte{200}, 200},
+		{[]byte{252}, 252},
+		{[]byte{255, 0}, 253},     // oneMoreByteCode1: 253 + b
+		{[]byte{255, 10}, 263},    //
+		{[]byte{254, 0}, 506},     // oneMoreByteCode2: 506 + b
+		{[]byte{254, 10}, 516},    //
+		{[]byte{253, 1, 44}, 300}, // wordCode: explicit u16
+	}
+	for _, tc := range cases {
+		r := &byteReader{data: tc.in}
+		got, err := r.read255U16()
+		if err != nil {
+			t.Fatalf("read255U16(%v): %v", tc.in, err)
+		}
+		if got != tc.want {
+			t.Errorf("read255U16(%v) = %d, want %d", tc.in, got, tc.want)
+		}
+	}
+}
+
+func TestWOFF2UIntBase128(t *testing.T) {
+	for _, want := range []uint32{0, 1, 127, 128, 0x3fff, 0x4000, 0xffffffff} {
+		var buf bytes.Buffer
+		writeUIntBase128(&buf, want)
+		r := &byteReader{data: buf.Bytes()}
+		got, err := r.uintBase128()
+		if err != nil {
+			t.Fatalf("uintBase128(%d): %v", want, err)
+		}
+		if got != want {
+			t.Errorf("uintBase128 round trip = %d, want %d", got, want)
+		}
+	}
+
+	// Leading zero byte is forbidden by the spec.
+	r := &byteReader{data: []byte{0x80, 0x01}}
+	if _, err := r.uintBase128(); err == nil {
+		t.Error("leading-zero UIntBase128 should fail")
+	}
+}
+
+func TestWOFF2Triplet(t *testing.T) {
+	cases := []struct {
+		index  uint8
+		data   []byte
+		dx, dy int
+	}{
+		{0, []byte{50}, 0, -50},                 // y-only, negative
+		{1, []byte{50}, 0, 50},                  // y-only, positive
+		{8, []byte{5}, 0, -1029},                // y-only with high bits: (8&14)<<7 + 5
+		{10, []byte{50}, -50, 0},                // x-only, negative
+		{11, []byte{50}, 50, 0},                 // x-only, positive
+		{127, []byte{0, 100, 0, 80}, 100, 80},   // 4-byte, both positive
+		{124, []byte{0, 100, 0, 80}, -100, -80}, // 4-byte, both negative
+		{125, []byte{0, 100, 0, 80}, 100, -80},  // 4-byte, +x -y
+	}
+	for _, tc := range cases {
+		r := &byteReader{data: tc.data}
+		dx, dy, err := r.triplet(tc.index)
+		if err != nil {
+			t.Fatalf("triplet(%d): %v", tc.index, err)
+		}
+		if dx != tc.dx || dy != tc.dy {
+			t.Errorf("triplet(%d, %v) = (%d, %d), want (%d, %d)",
+				tc.index, tc.data, dx, dy, tc.dx, tc.dy)
+		}
+	}
+}
+
+func TestWOFF2ReconstructGlyf(t *testing.T) {
+	// Hand-built transformed glyf: glyph 0 is a triangle
+	// (0,0)-(100,0)-(50,80), glyph 1 is empty. All points use the 4-byte
+	// triplet encoding (indices 124-127) and computed bounding boxes.
+	var buf bytes.Buffer
+	writeU32 := func(v uint32) { _ = binary.Write(&buf, binary.BigEndian, v) }
+	writeU16 := func(v uint16) { _ = binary.Write(&buf, binary.BigEndian, v) }
+
+	nContours := []byte{0, 1, 0, 0}      // glyph 0: 1 contour; glyph 1: 0
+	nPoints := []byte{3}                 // one contour, 3 points
+	flagsStream := []byte{127, 127, 126} // on-curve, triplet indices
+	glyphStream := []byte{
+		0, 0, 0, 0, // (0,0): dx=+0, dy=+0
+		0, 100, 0, 0, // (100,0): dx=+100, dy=+0
+		0, 50, 0, 80, // (50,80): dx=-50, dy=+80
+		0, // instruction length (255UInt16)
+	}
+	bbox := make([]byte, 4) // bitmap for 2 glyphs, no explicit boxes
+
+	writeU32(0) // version
+	writeU16(2) // numGlyphs
+	writeU16(0) // indexFormat (short loca)
+	writeU32(uint32(len(nContours)))
+	writeU32(uint32(len(nPoints)))
+	writeU32(uint32(len(flagsStream)))
+	writeU32(uint32(len(glyphStream)))
+	writeU32(0) // composite
+	writeU32(uint32(len(bbox)))
+	writeU32(0) // instructions
+	buf.Write(nContours)
+	buf.Write(nPoints)
+	buf.Write(flagsStream)
+	buf.Write(glyphStream)
+	buf.Write(bbox)
+
+	glyf, loca, indexFormat, err := reconstructGlyf(buf.Bytes())
+	if err != nil {
+		t.Fatalf("reconstructGlyf failed: %v", err)
+	}
+	if indexFormat != 0 {
+		t.Errorf("indexFormat = %d, want 0", indexFormat)
+	}
+
+	// Glyph 0 header: numberOfContours=1, computed bbox (0,0,100,80).
+	if got := int16(binary.BigEndian.Uint16(glyf[0:2])); got != 1 {
+		t.Errorf("numberOfContours = %d, want 1", got)
+	}
+	wantBBox := []int16{0, 0, 100, 80}
+	for i, want := range wantBBox {
+		if got := int16(binary.BigEndian.Uint16(glyf[2+i*2:])); got != want {
+			t.Errorf("bbox[%d] = %d, want %d", i, got, want)
+		}
+	}
+	if got := binary.BigEndian.Uint16(glyf[10:12]); got != 2 {
+		t.Errorf("endPtsOfContours[0] = %d, want 2", got)
+	}
+
+	// loca (short): glyph 1 is empty, so its start equals its end.
+	if len(loca) != 6 {
+		t.Fatalf("loca length = %d, want 6", len(loca))
+	}
+	g1start := binary.BigEndian.Uint16(loca[2:4])
+	g1end := binary.BigEndian.Uint16(loca[4:6])
+	if g1start != g1end {
+		t.Errorf("empty glyph has non-zero length: %d..%d", g1start, g1end)
+	}
+	if binary.BigEndian.Uint16(loca[0:2]) != 0 {
+		t.Error("first loca offset must be 0")
+	}
+}
+
+func TestWOFF2RejectsCollections(t *testing.T) {
+	header := make([]byte, woff2HeaderSize)
+	copy(header[0:4], "wOF2")
+	copy(header[4:8], "ttcf")
+	binary.BigEndian.PutUint16(header[12:14], 1)
+	if _, err := decodeWOFF2(header); err == nil {
+		t.Error("WOFF2 collection flavor should be rejected")
+	}
+}
@@ -0,0 +1,110 @@
+package text
+
+import (
+	"bytes"
+	"compress/zlib"
+	"encoding/binary"
+	"os"
+	"testing"
+)
+
+// parseSFNTTables splits sfnt data into directory order (tag, payload)
+// pairs for the container-encoding test helpers.
+func parseSFNTTables(t *testing.T, data []byte) (flavor uint32, tags []string, payloads [][]byte) {
+	t.Helper()
+	flavor = binary.BigEndian.Uint32(data[0:4])
+	numTables := int(binary.BigEndian.Uint16(data[4:6]))
+	for i := 0; i < numTables; i++ {
+		entry := data[12+i*16:]
+		tag := string(entry[0:4])
+		offset := binary.BigEndian.Uint32(entry[8:12])
+		length := binary.BigEndian.Uint32(entry[12:16])
+		tags = append(tags, tag)
+		payloads = append(payloads, data[offset:offset+length])
+	}
+	return flavor, tags, payloads
+}
+
+// encodeWOFF wraps sfnt data in a WOFF 1.0 container with zlib-compressed
+// tables.
+func encodeWOFF(t *testing.T, sfnt []byte) []byte {
+	t.Helper()
+	flavor, tags, payloads := parseSFNTTables(t, sfnt)
+
+	var out bytes.Buffer
+	header := make([]byte, woffHeaderSize)
+	copy(header[0:4], "wOFF")
+	binary.BigEndian.PutUint32(header[4:8], flavor)
+	binary.BigEndian.PutUint16(header[12:14], uint16(len(tags)))
+	out.Write(header)
+
+	dirStart := out.Len()
+	out.Write(make([]byte, len(tags)*woffDirEntrySize))
+
+	dir := out.Bytes()[dirStart:]
+	for i, payload := range payloads {
+		var comp bytes.Buffer
+		zw := zlib.NewWriter(&comp)
+		_, _ = zw.Write(payload)
+		_ = zw.Close()
+
+		data := comp.Bytes()
+		if len(data) >= len(payload) {
+			data = payload // store uncompressed when zlib does not help
+		}
+
+		entry := dir[i*woffDirEntrySize:]
+		copy(entry[0:4], tags[i])
+		binary.BigEndian.PutUint32(entry[4:8], uint32(out.Len()))
+		binary.BigEndian.PutUint32(entry[8:12], uint32(len(data)))
+		binary.BigEndian.PutUint32(entry[12:16], uint32(len(payload)))
+		out.Write(data)
+		for out.Len()&3 != 0 {
+			out.WriteByte(0)
+		}
+		dir = out.Bytes()[dirStart:] // re-slice after growth
+	}
+	result := out.Bytes()
+	binary.BigEndian.PutUint32(result[8:12], uint32(len(result)))
+	return result
+}
+
+func TestWOFFRoundTrip(t *testing.T) {
+	sfnt, err := os.ReadFile("testdata/goregular.ttf")
+	if err != nil {
+		t.Fatalf("test font unavailable: %v", err)
+	}
+
+	woff := encodeWOFF(t, sfnt)
+	if !isWOFF(woff) {
+		t.Fatal("encoded data is not recognized as WOFF")
+	}
+
+	source, err := NewFontSource(woff)
+	if err != nil {
+		t.Fatalf("NewFontSource(woff) failed: %v", err)
+	}
+	defer func() { _ = source.Close() }()
+
+	ref, err := NewFontSource(sfnt)
+	if err != nil {
+		t.Fatalf("NewFontSource(sfnt) failed: %v", err)
+	}
+	defer func() { _ = ref.Close() }()
+
+	if got, want := source.Parsed().NumGlyphs(), ref.Parsed().NumGlyphs(); got != want {
+		t.Errorf("NumGlyphs = %d, want %d", got, want)
+	}
+	if got, want := source.Face(16).Advance("Hello"), ref.Face(16).Advance("Hello"); got != want {
+		t.Errorf("Advance = %v, want %v", got, want)
+	}
+}
+
+func TestWOFFTruncated(t *testing.T) {
+	if _, err := decodeWOFF([]byte("wOFF")); err == nil {
+		t.Error("truncated WOFF should fail")
+	}
+	if _, err := NewFontSource([]byte("wOFFxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxx")); err == nil {
+		t.Error("garbage WOFF should fail")
+	}
+}
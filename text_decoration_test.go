@@ -0,0 +1,115 @@
+package gg
+
+import (
+	"testing"
+
+	"github.com/gogpu/gg/text"
+)
+
+func TestDrawStringDecoratedUnderline(t *testing.T) {
+	face := loadTestFont(t, 24)
+
+	plain := NewContext(200, 80)
+	plain.ClearWithColor(White)
+	plain.SetFont(face)
+	plain.SetRGB(0, 0, 0)
+	plain.DrawString("Hello", 10, 40)
+
+	underlined := NewContext(200, 80)
+	underlined.ClearWithColor(White)
+	underlined.SetFont(face)
+	underlined.SetRGB(0, 0, 0)
+	underlined.DrawStringDecorated("Hello", 10, 40, DecorationUnderline)
+
+	// The underline spans the full advance width (including inter-letter
+	// gaps), so the decorated image must have strictly more ink. The
+	// exact row depends on the font's post.underlinePosition, which can
+	// be less than a pixel below the baseline at small sizes.
+	plainInk := countNonWhitePixels(plain, 0, 0, 200, 80)
+	decoInk := countNonWhitePixels(underlined, 0, 0, 200, 80)
+	if decoInk <= plainInk {
+		t.Errorf("underline added no ink: plain=%d decorated=%d", plainInk, decoInk)
+	}
+
+	// The decoration band around the baseline must be continuous across
+	// the whole string width, including gaps between letters.
+	w, _ := text.Measure("Hello", face)
+	for x := 10; x < 10+int(w); x++ {
+		if countNonWhitePixels(underlined, x, 38, x+1, 43) == 0 {
+			t.Errorf("underline band has a gap at x=%d", x)
+			break
+		}
+	}
+}
+
+func TestDrawStringDecoratedStrikethroughAndOverline(t *testing.T) {
+	face := loadTestFont(t, 24)
+
+	dc := NewContext(200, 80)
+	dc.ClearWithColor(White)
+	dc.SetFont(face)
+	dc.SetRGB(0, 0, 0)
+	dc.DrawStringDecorated("xxxx", 10, 50, DecorationStrikethrough|DecorationOverline)
+
+	metrics := face.Metrics()
+
+	// Overline: ink near the ascent line, above any x-height glyph.
+	overTop := int(50 - metrics.Ascent - 1)
+	if n := countNonWhitePixels(dc, 10, overTop, 80, overTop+4); n == 0 {
+		t.Error("overline produced no ink near the ascent line")
+	}
+
+	// Strikethrough: a continuous band through the middle of the text,
+	// including the gaps between letters where glyphs leave no ink.
+	midY := int(50 - metrics.XHeight/2)
+	if n := countNonWhitePixels(dc, 10, midY-2, 80, midY+2); n == 0 {
+		t.Error("strikethrough produced no ink at mid height")
+	}
+}
+
+func TestDrawStringDecoratedNoFont(t *testing.T) {
+	dc := NewContext(50, 50)
+	dc.ClearWithColor(White)
+	dc.DrawStringDecorated("x", 10, 25, DecorationUnderline)
+	if n := countNonWhitePixels(dc, 0, 0, 50, 50); n != 0 {
+		t.Errorf("DrawStringDecorated without font drew %d pixels", n)
+	}
+}
+
+func TestDecorationMetricsFromFont(t *testing.T) {
+	fontPath := findTestFontPath()
+	if fontPath == "" {
+		t.Skip("No system font available")
+	}
+	source, err := text.NewFontSourceFromFile(fontPath)
+	if err != nil {
+		t.Skipf("Failed to load font: %v", err)
+	}
+
+	provider, ok := source.Parsed().(text.DecorationMetricsProvider)
+	if !ok {
+		t.Fatal("parsed font does not implement DecorationMetricsProvider")
+	}
+	dm, ok := provider.DecorationMetrics(24)
+	if !ok {
+		t.Fatal("DecorationMetrics unavailable for a standard TTF")
+	}
+	if dm.UnderlineThickness <= 0 {
+		t.Errorf("underline thickness = %v, want > 0", dm.UnderlineThickness)
+	}
+	if dm.UnderlinePosition >= 0 {
+		t.Errorf("underline position = %v, want below baseline (negative)", dm.UnderlinePosition)
+	}
+	if dm.StrikeoutThickness <= 0 {
+		t.Errorf("strikeout thickness = %v, want > 0", dm.StrikeoutThickness)
+	}
+	if dm.StrikeoutPosition <= 0 {
+		t.Errorf("strikeout position = %v, want above baseline (positive)", dm.StrikeoutPosition)
+	}
+
+	// Metrics scale linearly with size.
+	dm48, _ := provider.DecorationMetrics(48)
+	if dm48.UnderlineThickness != dm.UnderlineThickness*2 {
+		t.Errorf("thickness at 48 = %v, want %v", dm48.UnderlineThickness, dm.UnderlineThickness*2)
+	}
+}
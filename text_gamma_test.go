@@ -0,0 +1,70 @@
+package gg
+
+import (
+	"testing"
+
+	"github.com/gogpu/gg/text"
+)
+
+// TestSetTextGamma tests that per-context gamma correction boosts the
+// coverage of light text on a dark background via the bitmap text path.
+func TestSetTextGamma(t *testing.T) {
+	source, err := text.NewFontSourceFromFile("text/testdata/goregular.ttf")
+	if err != nil {
+		t.Fatalf("load font: %v", err)
+	}
+	defer func() { _ = source.Close() }()
+
+	render := func(gamma float64) float64 {
+		dc := NewContext(200, 40)
+		defer dc.Close()
+		dc.ClearWithColor(RGB(0, 0, 0))
+		dc.SetFont(source.Face(14))
+		dc.SetRGB(1, 1, 1)
+		dc.SetTextGamma(gamma)
+		dc.DrawString("weight", 10, 28)
+
+		sum := 0.0
+		for y := 0; y < 40; y++ {
+			for x := 0; x < 200; x++ {
+				sum += dc.pixmap.GetPixel(x, y).R
+			}
+		}
+		return sum
+	}
+
+	if render(1.4) <= render(0) {
+		t.Error("SetTextGamma(1.4) should boost white-on-black coverage")
+	}
+}
+
+// TestSetStemDarkening tests that stem darkening adds coverage for
+// small text through the context setter.
+func TestSetStemDarkening(t *testing.T) {
+	source, err := text.NewFontSourceFromFile("text/testdata/goregular.ttf")
+	if err != nil {
+		t.Fatalf("load font: %v", err)
+	}
+	defer func() { _ = source.Close() }()
+
+	render := func(darken bool) float64 {
+		dc := NewContext(200, 40)
+		defer dc.Close()
+		dc.SetFont(source.Face(10))
+		dc.SetRGB(0, 0, 0)
+		dc.SetStemDarkening(darken)
+		dc.DrawString("stems", 10, 28)
+
+		sum := 0.0
+		for y := 0; y < 40; y++ {
+			for x := 0; x < 200; x++ {
+				sum += dc.pixmap.GetPixel(x, y).A
+			}
+		}
+		return sum
+	}
+
+	if render(true) <= render(false) {
+		t.Error("SetStemDarkening(true) should add coverage at 10px")
+	}
+}
@@ -0,0 +1,120 @@
+package gg
+
+import (
+	"github.com/gogpu/gg/text"
+)
+
+// FontMetrics returns the vertical metrics of the current font face
+// (ascent, descent, line gap, x-height, cap height). Returns the zero
+// Metrics if no font has been set with SetFont.
+//
+// Unlike MeasureString, these metrics are independent of any particular
+// string — use them for baseline alignment in layouts (e.g. aligning text
+// of different sizes on a shared baseline).
+func (c *Context) FontMetrics() text.Metrics {
+	if c.face == nil {
+		return text.Metrics{}
+	}
+	return c.face.Metrics()
+}
+
+// StringBounds returns the tight ink bounds of s drawn at position (x, y)
+// where y is the baseline, in user space (the current transform is not
+// applied). Unlike MeasureString, which reports the advance width and line
+// height, the ink bounds cover exactly the pixels the glyphs touch:
+// descenders extend Max.Y below the baseline, and italic overhang can
+// extend Max.X beyond the advance width.
+//
+// Returns the zero Rect if no font is set or the string has no ink
+// (e.g. only spaces).
+func (c *Context) StringBounds(s string, x, y float64) Rect {
+	if c.face == nil {
+		return Rect{}
+	}
+	source := c.face.Source()
+	if source == nil {
+		return Rect{}
+	}
+
+	parsed := source.Parsed()
+	size := c.face.Size()
+
+	var bounds Rect
+	hasInk := false
+	for _, sg := range text.Shape(s, c.face) {
+		gb := parsed.GlyphBounds(uint16(sg.GID), size)
+		if gb.Empty() {
+			continue // space, tab, etc. — advance but no ink
+		}
+		// GlyphBounds is Y-down relative to the glyph origin on the baseline.
+		gr := Rect{
+			Min: Point{X: x + sg.X + gb.MinX, Y: y + sg.Y + gb.MinY},
+			Max: Point{X: x + sg.X + gb.MaxX, Y: y + sg.Y + gb.MaxY},
+		}
+		if !hasInk {
+			bounds = gr
+			hasInk = true
+		} else {
+			bounds = bounds.Union(gr)
+		}
+	}
+	return bounds
+}
+
+// CaretPositions returns the caret x offsets for s, relative to the string
+// origin: one entry per rune plus a final entry after the last rune, so
+// len(result) == utf8.RuneCountInString(s) + 1. Entry i is where a text
+// cursor sits before rune i; the last entry equals the total advance.
+//
+// Positions come from the shaper, so kerning is included. When shaping
+// merges several runes into one glyph (ligatures), the carets inside the
+// ligature are interpolated evenly across its advance — the standard
+// behavior for text editing.
+//
+// Returns nil if no font is set.
+func (c *Context) CaretPositions(s string) []float64 {
+	if c.face == nil {
+		return nil
+	}
+
+	runeCount := 0
+	for range s {
+		runeCount++
+	}
+	positions := make([]float64, runeCount+1)
+	known := make([]bool, runeCount+1)
+	known[0] = true
+
+	end := 0.0
+	for _, sg := range text.Shape(s, c.face) {
+		if sg.Cluster >= 0 && sg.Cluster < runeCount {
+			positions[sg.Cluster] = sg.X
+			known[sg.Cluster] = true
+		}
+		if sg.X+sg.XAdvance > end {
+			end = sg.X + sg.XAdvance
+		}
+	}
+	positions[runeCount] = end
+	known[runeCount] = true
+
+	// Fill clusters the shaper merged (ligatures) or skipped (control
+	// characters) by interpolating between the surrounding known carets.
+	for i := 1; i < runeCount; i++ {
+		if known[i] {
+			continue
+		}
+		lo := i - 1
+		for !known[lo] {
+			lo--
+		}
+		hi := i + 1
+		for !known[hi] {
+			hi++
+		}
+		t := float64(i-lo) / float64(hi-lo)
+		positions[i] = positions[lo] + t*(positions[hi]-positions[lo])
+		known[i] = true
+	}
+	return positions
+}
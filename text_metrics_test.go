@@ -0,0 +1,97 @@
+package gg
+
+import (
+	"testing"
+)
+
+func TestFontMetrics(t *testing.T) {
+	dc := NewContext(100, 100)
+	if m := dc.FontMetrics(); m.Ascent != 0 || m.Descent != 0 {
+		t.Errorf("no-font metrics should be zero, got %+v", m)
+	}
+
+	dc.SetFont(loadTestFont(t, 24))
+	m := dc.FontMetrics()
+	if m.Ascent <= 0 || m.Descent <= 0 {
+		t.Errorf("metrics not populated: %+v", m)
+	}
+	if m.Ascent+m.Descent > 2*24 {
+		t.Errorf("ascent+descent = %v implausible for 24px face", m.Ascent+m.Descent)
+	}
+}
+
+func TestStringBoundsTightInk(t *testing.T) {
+	dc := NewContext(200, 100)
+	dc.SetFont(loadTestFont(t, 24))
+
+	b := dc.StringBounds("Hg", 10, 50)
+	if b.Width() <= 0 || b.Height() <= 0 {
+		t.Fatalf("empty ink bounds: %+v", b)
+	}
+
+	// 'H' extends above the baseline, 'g' has a descender below it.
+	if b.Min.Y >= 50 {
+		t.Errorf("Min.Y = %v, want above baseline 50", b.Min.Y)
+	}
+	if b.Max.Y <= 50 {
+		t.Errorf("Max.Y = %v, want below baseline 50 (descender)", b.Max.Y)
+	}
+	if b.Min.X < 10 {
+		t.Errorf("Min.X = %v, want >= draw origin 10", b.Min.X)
+	}
+
+	// Ink bounds are tighter than the advance-based measure.
+	w, _ := dc.MeasureString("Hg")
+	if b.Width() > w+2 {
+		t.Errorf("ink width %v exceeds advance width %v", b.Width(), w)
+	}
+}
+
+func TestStringBoundsNoInk(t *testing.T) {
+	dc := NewContext(100, 100)
+	if b := dc.StringBounds("x", 0, 0); b != (Rect{}) {
+		t.Errorf("no-font bounds should be zero, got %+v", b)
+	}
+
+	dc.SetFont(loadTestFont(t, 24))
+	if b := dc.StringBounds("   ", 10, 50); b != (Rect{}) {
+		t.Errorf("spaces should have no ink, got %+v", b)
+	}
+}
+
+func TestCaretPositions(t *testing.T) {
+	dc := NewContext(100, 100)
+	if dc.CaretPositions("abc") != nil {
+		t.Error("no-font carets should be nil")
+	}
+
+	dc.SetFont(loadTestFont(t, 24))
+	carets := dc.CaretPositions("abc")
+	if len(carets) != 4 {
+		t.Fatalf("len(carets) = %d, want 4", len(carets))
+	}
+	if carets[0] != 0 {
+		t.Errorf("carets[0] = %v, want 0", carets[0])
+	}
+	for i := 1; i < len(carets); i++ {
+		if carets[i] <= carets[i-1] {
+			t.Errorf("carets not increasing: %v", carets)
+		}
+	}
+
+	// The final caret matches the advance width.
+	w, _ := dc.MeasureString("abc")
+	if d := carets[3] - w; d < -0.5 || d > 0.5 {
+		t.Errorf("final caret %v != advance width %v", carets[3], w)
+	}
+}
+
+func TestCaretPositionsEmptyString(t *testing.T) {
+	dc := NewContext(100, 100)
+	dc.SetFont(loadTestFont(t, 24))
+
+	carets := dc.CaretPositions("")
+	if len(carets) != 1 || carets[0] != 0 {
+		t.Errorf("empty string carets = %v, want [0]", carets)
+	}
+}
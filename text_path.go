@@ -0,0 +1,144 @@
+package gg
+
+import (
+	"fmt"
+
+	"github.com/gogpu/gg/text"
+)
+
+// GlyphPath returns the vector outline of the glyph for rune r as a Path,
+// along with the glyph's horizontal advance in pixels. The path is positioned
+// with the glyph origin at (0, 0) on the baseline, using Y-down screen
+// coordinates (ascenders have negative Y). Glyphs without an outline (e.g.
+// space) return an empty path and a non-zero advance.
+//
+// The outline lives in the gg package rather than on text.Face because the
+// text package cannot depend on gg. For laying out whole strings, use
+// [AppendStringPath]; for outlines positioned by a Context's state, use
+// [Context.TextPath].
+func GlyphPath(face text.Face, r rune) (*Path, float64, error) {
+	if face == nil {
+		return nil, 0, fmt.Errorf("gg: GlyphPath: nil face")
+	}
+	source := face.Source()
+	if source == nil {
+		return nil, 0, fmt.Errorf("gg: GlyphPath: face has no font source")
+	}
+
+	parsed := source.Parsed()
+	gid := parsed.GlyphIndex(r)
+	extractor := text.NewOutlineExtractor()
+	outline, err := extractor.ExtractOutlineHintedVar(
+		parsed, text.GlyphID(gid), face.Size(), text.HintingNone, face.Variations())
+	if err != nil {
+		return nil, 0, fmt.Errorf("gg: GlyphPath: %w", err)
+	}
+
+	path := NewPath()
+	if outline == nil {
+		return path, 0, nil
+	}
+	appendOutlineToPath(path, outline, 0, 0)
+	return path, float64(outline.Advance), nil
+}
+
+// AppendStringPath appends the vector outlines of string s to path, shaped
+// and positioned with the first glyph origin at (x, y) where y is the
+// baseline. It returns the x position after the last glyph, so calls can be
+// chained to build mixed-face runs:
+//
+//	p := gg.NewPath()
+//	x, _ := gg.AppendStringPath(p, regular, "Hello ", 10, 50)
+//	_, _ = gg.AppendStringPath(p, bold, "world", x, 50)
+//	dc.FillPath(p)
+//
+// Shaping applies the face's kerning, ligatures, and variations, matching
+// what DrawString renders in vector mode.
+func AppendStringPath(path *Path, face text.Face, s string, x, y float64) (float64, error) {
+	if path == nil {
+		return x, fmt.Errorf("gg: AppendStringPath: nil path")
+	}
+	if face == nil {
+		return x, fmt.Errorf("gg: AppendStringPath: nil face")
+	}
+	source := face.Source()
+	if source == nil {
+		return x, fmt.Errorf("gg: AppendStringPath: face has no font source")
+	}
+
+	parsed := source.Parsed()
+	fontSize := face.Size()
+	extractor := text.NewOutlineExtractor()
+	variations := face.Variations()
+
+	// Use the shared glyph cache so repeated runs of the same face avoid
+	// re-extracting outlines (same keying as Context.textOutlinePath).
+	cache := text.GetGlobalGlyphCache()
+	fontID := computeTextFontID(source)
+	var sizeKey int16
+	switch {
+	case fontSize < 0:
+		sizeKey = 0
+	case fontSize > 32767:
+		sizeKey = 32767
+	default:
+		sizeKey = int16(fontSize) //nolint:gosec // bounds checked above
+	}
+	varHash := text.VariationHash(variations)
+
+	endX := x
+	shaped := text.Shape(s, face)
+	for _, sg := range shaped {
+		endX = x + sg.X + sg.XAdvance
+		cacheKey := text.OutlineCacheKey{
+			FontID:        fontID,
+			GID:           sg.GID,
+			Size:          sizeKey,
+			Hinting:       text.HintingNone,
+			VariationHash: varHash,
+		}
+		outline := cache.GetOrCreate(cacheKey, func() *text.GlyphOutline {
+			o, err := extractor.ExtractOutlineHintedVar(parsed, sg.GID, fontSize, text.HintingNone, variations)
+			if err != nil || o == nil || o.IsEmpty() {
+				return nil
+			}
+			return o
+		})
+		if outline == nil {
+			continue
+		}
+		appendOutlineToPath(path, outline, x+sg.X, y+sg.Y)
+	}
+	return endX, nil
+}
+
+// appendOutlineToPath converts a glyph outline's segments to path commands
+// translated by (dx, dy), closing each contour. Outline coordinates are
+// Y-down (screen convention), so they are added to the baseline directly.
+func appendOutlineToPath(path *Path, outline *text.GlyphOutline, dx, dy float64) {
+	hasContour := false
+	for _, seg := range outline.Segments {
+		switch seg.Op {
+		case text.OutlineOpMoveTo:
+			if hasContour {
+				path.Close()
+			}
+			path.MoveTo(dx+float64(seg.Points[0].X), dy+float64(seg.Points[0].Y))
+			hasContour = true
+		case text.OutlineOpLineTo:
+			path.LineTo(dx+float64(seg.Points[0].X), dy+float64(seg.Points[0].Y))
+		case text.OutlineOpQuadTo:
+			path.QuadraticTo(
+				dx+float64(seg.Points[0].X), dy+float64(seg.Points[0].Y),
+				dx+float64(seg.Points[1].X), dy+float64(seg.Points[1].Y))
+		case text.OutlineOpCubicTo:
+			path.CubicTo(
+				dx+float64(seg.Points[0].X), dy+float64(seg.Points[0].Y),
+				dx+float64(seg.Points[1].X), dy+float64(seg.Points[1].Y),
+				dx+float64(seg.Points[2].X), dy+float64(seg.Points[2].Y))
+		}
+	}
+	if hasContour {
+		path.Close()
+	}
+}
@@ -0,0 +1,128 @@
+package gg
+
+import (
+	"testing"
+)
+
+func TestGlyphPathReturnsOutline(t *testing.T) {
+	face := loadTestFont(t, 24)
+
+	path, advance, err := GlyphPath(face, 'H')
+	if err != nil {
+		t.Fatalf("GlyphPath failed: %v", err)
+	}
+	if path.isEmpty() {
+		t.Fatal("GlyphPath('H') returned empty path")
+	}
+	if advance <= 0 {
+		t.Errorf("GlyphPath('H') advance = %v, want > 0", advance)
+	}
+
+	// Origin is the baseline: 'H' extends above (negative Y) but not
+	// meaningfully below it.
+	b := path.Bounds()
+	if b.Min.Y >= 0 {
+		t.Errorf("glyph bounds %v entirely below baseline", b)
+	}
+	if b.Max.X <= 0 || float64(b.Max.X) > advance+2 {
+		t.Errorf("glyph width %v inconsistent with advance %v", b.Max.X, advance)
+	}
+}
+
+func TestGlyphPathSpaceHasAdvanceOnly(t *testing.T) {
+	face := loadTestFont(t, 24)
+
+	path, advance, err := GlyphPath(face, ' ')
+	if err != nil {
+		t.Fatalf("GlyphPath failed: %v", err)
+	}
+	if !path.isEmpty() {
+		t.Error("space glyph should produce an empty path")
+	}
+	if advance <= 0 {
+		t.Errorf("space advance = %v, want > 0", advance)
+	}
+}
+
+func TestGlyphPathErrors(t *testing.T) {
+	if _, _, err := GlyphPath(nil, 'A'); err == nil {
+		t.Error("GlyphPath(nil face) should fail")
+	}
+}
+
+func TestAppendStringPathAdvancesX(t *testing.T) {
+	face := loadTestFont(t, 24)
+
+	path := NewPath()
+	endX, err := AppendStringPath(path, face, "Hi", 10, 50)
+	if err != nil {
+		t.Fatalf("AppendStringPath failed: %v", err)
+	}
+	if path.isEmpty() {
+		t.Fatal("AppendStringPath produced no outlines")
+	}
+	if endX <= 10 {
+		t.Errorf("endX = %v, want > starting x", endX)
+	}
+
+	// Chained call continues where the first left off.
+	endX2, err := AppendStringPath(path, face, "!", endX, 50)
+	if err != nil {
+		t.Fatalf("chained AppendStringPath failed: %v", err)
+	}
+	if endX2 <= endX {
+		t.Errorf("chained endX = %v, want > %v", endX2, endX)
+	}
+
+	// Outlines sit above the baseline around y=50 and start near x=10.
+	b := path.Bounds()
+	if b.Min.X < 9 || b.Min.Y > 50 || b.Max.Y < 25 {
+		t.Errorf("string path bounds %v inconsistent with origin (10, 50)", b)
+	}
+}
+
+func TestAppendStringPathMatchesTextPath(t *testing.T) {
+	face := loadTestFont(t, 24)
+
+	path := NewPath()
+	if _, err := AppendStringPath(path, face, "Hello", 10, 40); err != nil {
+		t.Fatalf("AppendStringPath failed: %v", err)
+	}
+
+	dc := NewContext(200, 60)
+	dc.SetFont(face)
+	ref := dc.TextPath("Hello", 10, 40)
+	if ref == nil {
+		t.Fatal("TextPath returned nil")
+	}
+
+	// Both build shaped outline paths; filling either must produce the
+	// same pixels.
+	a := NewContext(200, 60)
+	a.ClearWithColor(White)
+	a.SetRGB(0, 0, 0)
+	_ = a.FillPath(path)
+
+	b := NewContext(200, 60)
+	b.ClearWithColor(White)
+	b.SetRGB(0, 0, 0)
+	_ = b.FillPath(ref)
+
+	aData, bData := a.pixmap.Data(), b.pixmap.Data()
+	for i := range aData {
+		if aData[i] != bData[i] {
+			t.Fatalf("pixel data differs at byte %d: %d vs %d", i, aData[i], bData[i])
+		}
+	}
+}
+
+func TestAppendStringPathErrors(t *testing.T) {
+	face := loadTestFont(t, 24)
+
+	if _, err := AppendStringPath(nil, face, "x", 0, 0); err == nil {
+		t.Error("nil path should fail")
+	}
+	if _, err := AppendStringPath(NewPath(), nil, "x", 0, 0); err == nil {
+		t.Error("nil face should fail")
+	}
+}
@@ -0,0 +1,114 @@
+package gg
+
+import (
+	"testing"
+
+	"github.com/gogpu/gg/text"
+)
+
+// TestMeasureStringCached tests that repeated measurements hit the
+// per-context run cache and stay correct across face changes.
+func TestMeasureStringCached(t *testing.T) {
+	face := loadTestFont(t, 24)
+	dc := NewContext(100, 100)
+	defer dc.Close()
+	dc.SetFont(face)
+
+	w1, h1 := dc.MeasureString("60 fps")
+	if w1 <= 0 || h1 <= 0 {
+		t.Fatalf("MeasureString = (%v, %v), want positive", w1, h1)
+	}
+	w2, h2 := dc.MeasureString("60 fps")
+	if w1 != w2 || h1 != h2 {
+		t.Errorf("cached measurement (%v, %v) != first (%v, %v)", w2, h2, w1, h1)
+	}
+	if _, ok := dc.textRuns.runs["60 fps"]; !ok {
+		t.Error("measurement not cached")
+	}
+
+	// A different face must invalidate the cache and produce a new size.
+	big := face.Source().Face(48)
+	dc.SetFont(big)
+	w3, _ := dc.MeasureString("60 fps")
+	if w3 <= w1 {
+		t.Errorf("48px width %v should exceed 24px width %v", w3, w1)
+	}
+	if dc.textRuns.face != big {
+		t.Error("run cache not rebound to new face")
+	}
+}
+
+// TestMeasureStringCacheBounded tests that the run cache clears instead of
+// growing without bound.
+func TestMeasureStringCacheBounded(t *testing.T) {
+	face := loadTestFont(t, 16)
+	dc := NewContext(100, 100)
+	defer dc.Close()
+	dc.SetFont(face)
+
+	for i := 0; i < maxTextRunCacheEntries+10; i++ {
+		dc.MeasureString(string(rune('A' + i%26)))
+		dc.MeasureString(string(rune('A'+i%26)) + string(rune('0'+i%10)) + string(rune('a'+(i/10)%26)))
+	}
+	if n := len(dc.textRuns.runs); n > maxTextRunCacheEntries {
+		t.Errorf("run cache grew to %d entries, cap is %d", n, maxTextRunCacheEntries)
+	}
+}
+
+// TestDrawStringReusedScratch tests that repeated draws through the pooled
+// scratch produce identical output.
+func TestDrawStringReusedScratch(t *testing.T) {
+	face := loadTestFont(t, 24)
+
+	render := func() *Pixmap {
+		dc := NewContext(200, 60)
+		defer dc.Close()
+		dc.ClearWithColor(White)
+		dc.SetFont(face)
+		dc.SetRGB(0, 0, 0)
+		dc.DrawString("60 fps", 10, 40)
+		return dc.pixmap
+	}
+
+	first := render()
+	for i := 0; i < 3; i++ {
+		again := render()
+		for y := 0; y < 60; y += 3 {
+			for x := 0; x < 200; x += 3 {
+				if first.GetPixel(x, y) != again.GetPixel(x, y) {
+					t.Fatalf("draw %d: pixel (%d,%d) differs from first draw", i, x, y)
+				}
+			}
+		}
+	}
+}
+
+// BenchmarkDrawStringFPSCounter measures per-frame overlay text: the same
+// few short strings redrawn every frame, the workload the pooled draw
+// scratch and run cache target. Run with -benchmem to see allocations.
+func BenchmarkDrawStringFPSCounter(b *testing.B) {
+	fontPath := findTestFontPath()
+	if fontPath == "" {
+		b.Skip("No system font available")
+	}
+	source, err := text.NewFontSourceFromFile(fontPath)
+	if err != nil {
+		b.Skipf("Failed to load font: %v", err)
+	}
+
+	dc := NewContext(256, 64)
+	defer dc.Close()
+	dc.SetFont(source.Face(16))
+	dc.SetRGB(0, 1, 0)
+
+	labels := [...]string{"58 fps", "59 fps", "60 fps", "61 fps"}
+	for _, s := range labels { // warm caches
+		dc.DrawString(s, 8, 32)
+	}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		dc.DrawString(labels[i%len(labels)], 8, 32)
+	}
+}
@@ -0,0 +1,119 @@
+package gg
+
+import (
+	"runtime"
+	"sync"
+)
+
+// TiledContext parallelizes CPU rendering of a single large frame by
+// splitting a Pixmap into horizontal bands and rendering each band in
+// its own goroutine. Each band is backed by a zero-copy view of the
+// shared pixmap's rows, so the bands never write overlapping pixels and
+// no locking is needed.
+//
+// The draw callback runs once per band with a Context translated so the
+// callback draws in full-frame coordinates; geometry outside the band is
+// clipped by the band's pixel bounds. The callback typically re-plays
+// the same scene for every band:
+//
+//	pm := gg.NewPixmap(3840, 2160)
+//	tc := gg.NewTiledContext(pm, 0) // 0 = one band per CPU
+//	err := tc.Render(func(dc *gg.Context) error {
+//	    dc.SetRGB(1, 0, 0)
+//	    dc.DrawCircle(1920, 1080, 500)
+//	    return dc.Fill()
+//	})
+//
+// Callbacks must not call Identity or SetTransform with absolute
+// matrices (that would discard the band translation); use Translate,
+// Scale, and Rotate, or wrap absolute transforms in Push/Pop.
+//
+// Rendering always uses the software rasterizer: band pixmap views are
+// CPU memory, and per-band GPU submission would serialize anyway.
+// Output matches a single-context render except that anti-aliased edge
+// coverage of shapes clipped at band boundaries can differ by a few
+// least-significant bits (the scanline filler clips edges to the band).
+type TiledContext struct {
+	pixmap *Pixmap
+	bands  []tiledBand
+}
+
+// tiledBand is one horizontal slice of the target pixmap.
+type tiledBand struct {
+	dc *Context
+	y  int // first row of the band in the full frame
+}
+
+// NewTiledContext creates a tiled renderer over the given pixmap with
+// the given number of horizontal bands. bands <= 0 selects one band per
+// logical CPU; the count is clamped to the pixmap height. Returns nil
+// if pm is nil.
+func NewTiledContext(pm *Pixmap, bands int) *TiledContext {
+	if pm == nil {
+		return nil
+	}
+	if bands <= 0 {
+		bands = runtime.NumCPU()
+	}
+	if bands > pm.Height() {
+		bands = pm.Height()
+	}
+
+	width, height := pm.Width(), pm.Height()
+	data := pm.Data()
+	tc := &TiledContext{pixmap: pm, bands: make([]tiledBand, 0, bands)}
+	rowsPerBand := height / bands
+	for i := 0; i < bands; i++ {
+		y0 := i * rowsPerBand
+		y1 := y0 + rowsPerBand
+		if i == bands-1 {
+			y1 = height // last band absorbs the remainder
+		}
+		view := NewPixmapFromBuffer(data[y0*width*4:y1*width*4], width, y1-y0)
+		dc := NewContextForPixmap(view)
+		tc.bands = append(tc.bands, tiledBand{dc: dc, y: y0})
+	}
+	return tc
+}
+
+// Pixmap returns the shared target pixmap.
+func (t *TiledContext) Pixmap() *Pixmap {
+	return t.pixmap
+}
+
+// NumBands returns the number of horizontal bands.
+func (t *TiledContext) NumBands() int {
+	return len(t.bands)
+}
+
+// Render runs the draw callback once per band, in parallel goroutines.
+// Each callback receives a Context pre-translated so drawing coordinates
+// are full-frame. Render blocks until every band has finished and
+// returns the first callback error, if any.
+//
+// Render may be called repeatedly (e.g. per animation frame); band
+// contexts are reset between calls. It must not be called concurrently
+// with itself or with other access to the pixmap.
+func (t *TiledContext) Render(draw func(dc *Context) error) error {
+	var wg sync.WaitGroup
+	errs := make([]error, len(t.bands))
+	for i := range t.bands {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			band := &t.bands[i]
+			band.dc.Reset()
+			band.dc.Translate(0, -float64(band.y))
+			errs[i] = draw(band.dc)
+		}(i)
+	}
+	wg.Wait()
+
+	t.pixmap.NotifyPixelsChanged()
+	for _, err := range errs {
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,139 @@
+package gg
+
+import (
+	"errors"
+	"testing"
+)
+
+// drawTestScene draws a scene spanning multiple bands.
+func drawTestScene(dc *Context) error {
+	dc.SetRGB(1, 0, 0)
+	dc.DrawCircle(100, 100, 60)
+	if err := dc.Fill(); err != nil {
+		return err
+	}
+	dc.SetRGB(0, 0, 1)
+	dc.SetLineWidth(4)
+	dc.MoveTo(20, 20)
+	dc.LineTo(180, 180)
+	return dc.Stroke()
+}
+
+func TestTiledContextMatchesSingleContext(t *testing.T) {
+	// Reference: plain single-context render.
+	ref := NewContext(200, 200)
+	if err := drawTestScene(ref); err != nil {
+		t.Fatalf("reference render failed: %v", err)
+	}
+
+	pm := NewPixmap(200, 200)
+	tc := NewTiledContext(pm, 4)
+	if tc.NumBands() != 4 {
+		t.Fatalf("NumBands = %d, want 4", tc.NumBands())
+	}
+	if err := tc.Render(drawTestScene); err != nil {
+		t.Fatalf("tiled render failed: %v", err)
+	}
+
+	// Edges clipped at band boundaries are re-quantized to FDot6 by the
+	// edge builder, which shifts anti-aliased coverage along the clipped
+	// edge by a few levels; everything away from AA edges must match
+	// exactly and overall disagreement must stay rare.
+	refData := ref.pixmap.Data()
+	gotData := pm.Data()
+	diff := 0
+	for i := range refData {
+		d := int(refData[i]) - int(gotData[i])
+		if d < 0 {
+			d = -d
+		}
+		if d > 24 {
+			t.Fatalf("byte %d differs beyond AA tolerance: ref=%d got=%d", i, refData[i], gotData[i])
+		}
+		if d != 0 {
+			diff++
+		}
+	}
+	if diff > len(refData)/100 {
+		t.Errorf("%d of %d bytes differ from single-context render", diff, len(refData))
+	}
+}
+
+func TestTiledContextUnevenBands(t *testing.T) {
+	// 103 rows across 4 bands: the last band absorbs the remainder.
+	pm := NewPixmap(50, 103)
+	tc := NewTiledContext(pm, 4)
+	err := tc.Render(func(dc *Context) error {
+		dc.SetRGB(0, 1, 0)
+		dc.DrawRectangle(0, 0, 50, 103)
+		return dc.Fill()
+	})
+	if err != nil {
+		t.Fatalf("Render failed: %v", err)
+	}
+	for _, y := range []int{0, 25, 26, 51, 52, 77, 78, 102} {
+		if got := pm.GetPixel(25, y); got.G < 0.9 {
+			t.Errorf("row %d not painted: %+v", y, got)
+		}
+	}
+}
+
+func TestTiledContextRepeatedRender(t *testing.T) {
+	pm := NewPixmap(40, 40)
+	tc := NewTiledContext(pm, 2)
+
+	for frame := 0; frame < 3; frame++ {
+		x := float64(frame * 10)
+		err := tc.Render(func(dc *Context) error {
+			dc.ClearWithColor(White)
+			dc.SetRGB(1, 0, 0)
+			dc.DrawRectangle(x, 0, 10, 40)
+			return dc.Fill()
+		})
+		if err != nil {
+			t.Fatalf("frame %d failed: %v", frame, err)
+		}
+	}
+
+	// After the last frame, only the final rect position is red.
+	if got := pm.GetPixel(25, 20); got.R < 0.9 || got.G > 0.1 {
+		t.Errorf("final frame rect missing: %+v", got)
+	}
+	if got := pm.GetPixel(5, 20); got.G < 0.9 {
+		t.Errorf("earlier frame not cleared: %+v", got)
+	}
+}
+
+func TestTiledContextPropagatesError(t *testing.T) {
+	pm := NewPixmap(20, 20)
+	tc := NewTiledContext(pm, 2)
+	wantErr := errors.New("band failed")
+	err := tc.Render(func(*Context) error { return wantErr })
+	if !errors.Is(err, wantErr) {
+		t.Errorf("Render error = %v, want %v", err, wantErr)
+	}
+}
+
+func TestTiledContextClampsBands(t *testing.T) {
+	pm := NewPixmap(10, 3)
+	tc := NewTiledContext(pm, 16)
+	if tc.NumBands() != 3 {
+		t.Errorf("NumBands = %d, want clamped to height 3", tc.NumBands())
+	}
+	if NewTiledContext(nil, 4) != nil {
+		t.Error("nil pixmap should give nil TiledContext")
+	}
+}
+
+func BenchmarkTiledRender(b *testing.B) {
+	pm := NewPixmap(1024, 1024)
+	tc := NewTiledContext(pm, 0)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_ = tc.Render(func(dc *Context) error {
+			dc.SetRGB(0.2, 0.4, 0.8)
+			dc.DrawCircle(512, 512, 400)
+			return dc.Fill()
+		})
+	}
+}
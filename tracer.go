@@ -0,0 +1,45 @@
+package gg
+
+import "github.com/gogpu/gg/internal/trace"
+
+// Tracer receives span events from the rendering pipeline. Implement
+// it to profile production workloads; the two-method shape bridges to
+// an OpenTelemetry tracer in a few lines:
+//
+//	type otelTracer struct{ t oteltrace.Tracer }
+//
+//	func (o otelTracer) StartSpan(name string) gg.Span {
+//	    _, span := o.t.Start(context.Background(), name)
+//	    return otelSpan{span}
+//	}
+//
+// Span names emitted by gg are stable, dot-separated identifiers:
+//
+//   - "gg.fill", "gg.stroke": one span per fill/stroke operation,
+//     with "verbs" (path complexity) attached.
+//   - "gg.flush_gpu": presenting pending GPU work to the target.
+//   - "scene.fill", "scene.stroke": scene-graph encode operations.
+//   - "stroke.expand": stroke tessellation, with input and output
+//     verb counts attached.
+//   - "gpu.encode", "gpu.upload", "gpu.dispatch", "gpu.readback":
+//     the phases of the compute pipeline.
+type Tracer = trace.Tracer
+
+// Span is an in-flight traced operation; see Tracer.
+type Span = trace.Span
+
+// SetTracer configures the tracer for gg and all its sub-packages.
+// By default, gg emits no span events. Pass nil to disable tracing.
+//
+// SetTracer is safe for concurrent use: it stores the new tracer
+// atomically, and a disabled call site costs one atomic load. The
+// tracer itself must be safe for concurrent use, since spans for
+// different contexts can start and end on different goroutines.
+//
+// Example:
+//
+//	gg.SetTracer(myTracer)
+//	defer gg.SetTracer(nil)
+func SetTracer(t Tracer) {
+	trace.SetTracer(t)
+}
@@ -0,0 +1,120 @@
+package gg
+
+import (
+	"sync"
+	"testing"
+)
+
+// testTracer records span names and attributes for assertions.
+type testTracer struct {
+	mu    sync.Mutex
+	spans []*testSpan
+}
+
+type testSpan struct {
+	tracer *testTracer
+	name   string
+	attrs  map[string]any
+	ended  bool
+}
+
+func (tt *testTracer) StartSpan(name string) Span {
+	tt.mu.Lock()
+	defer tt.mu.Unlock()
+	s := &testSpan{tracer: tt, name: name, attrs: map[string]any{}}
+	tt.spans = append(tt.spans, s)
+	return s
+}
+
+func (s *testSpan) SetAttr(key string, value any) {
+	s.tracer.mu.Lock()
+	defer s.tracer.mu.Unlock()
+	s.attrs[key] = value
+}
+
+func (s *testSpan) End() {
+	s.tracer.mu.Lock()
+	defer s.tracer.mu.Unlock()
+	s.ended = true
+}
+
+// byName returns all recorded spans with the given name.
+func (tt *testTracer) byName(name string) []*testSpan {
+	tt.mu.Lock()
+	defer tt.mu.Unlock()
+	var out []*testSpan
+	for _, s := range tt.spans {
+		if s.name == name {
+			out = append(out, s)
+		}
+	}
+	return out
+}
+
+// TestTracerSpansEmitted tests that fill and stroke operations emit
+// spans with path complexity attached, and that every span is ended.
+func TestTracerSpansEmitted(t *testing.T) {
+	tracer := &testTracer{}
+	SetTracer(tracer)
+	defer SetTracer(nil)
+
+	dc := NewContext(64, 64)
+	defer dc.Close()
+	dc.DrawCircle(32, 32, 20)
+	if err := dc.Fill(); err != nil {
+		t.Fatalf("Fill: %v", err)
+	}
+	dc.DrawLine(5, 5, 60, 60)
+	if err := dc.Stroke(); err != nil {
+		t.Fatalf("Stroke: %v", err)
+	}
+
+	fills := tracer.byName("gg.fill")
+	if len(fills) == 0 {
+		t.Fatal("no gg.fill spans recorded")
+	}
+	if v, ok := fills[0].attrs["verbs"].(int); !ok || v <= 0 {
+		t.Errorf("gg.fill verbs attr = %v, want positive int", fills[0].attrs["verbs"])
+	}
+	if len(tracer.byName("gg.stroke")) == 0 {
+		t.Error("no gg.stroke spans recorded")
+	}
+
+	tracer.mu.Lock()
+	defer tracer.mu.Unlock()
+	for _, s := range tracer.spans {
+		if !s.ended {
+			t.Errorf("span %q was never ended", s.name)
+		}
+	}
+}
+
+// TestTracerDisabled tests that rendering works identically with no
+// tracer installed (the default).
+func TestTracerDisabled(t *testing.T) {
+	SetTracer(nil)
+	dc := NewContext(16, 16)
+	defer dc.Close()
+	dc.DrawRectangle(2, 2, 10, 10)
+	if err := dc.Fill(); err != nil {
+		t.Fatalf("Fill without tracer: %v", err)
+	}
+}
+
+// TestTracerFlushGPU tests that FlushGPU emits its span even when no
+// GPU accelerator is registered.
+func TestTracerFlushGPU(t *testing.T) {
+	tracer := &testTracer{}
+	SetTracer(tracer)
+	defer SetTracer(nil)
+
+	dc := NewContext(16, 16)
+	defer dc.Close()
+	if err := dc.FlushGPU(); err != nil {
+		t.Fatalf("FlushGPU: %v", err)
+	}
+	spans := tracer.byName("gg.flush_gpu")
+	if len(spans) != 1 || !spans[0].ended {
+		t.Fatalf("gg.flush_gpu spans = %d", len(spans))
+	}
+}
@@ -1,6 +1,10 @@
 package gg
 
-import "math"
+import (
+	"math"
+
+	"github.com/gogpu/gg/internal/detmath"
+)
 
 // Vec2 represents a 2D displacement vector.
 // Unlike Point which represents a position, Vec2 represents a direction and magnitude.
@@ -53,7 +57,7 @@ func (v Vec2) Cross(w Vec2) float64 {
 
 // Length returns the length (magnitude) of the vector.
 func (v Vec2) Length() float64 {
-	return math.Sqrt(v.X*v.X + v.Y*v.Y)
+	return detmath.Sqrt(v.X*v.X + v.Y*v.Y)
 }
 
 // LengthSq returns the squared length of the vector.
@@ -83,8 +87,8 @@ func (v Vec2) Lerp(w Vec2, t float64) Vec2 {
 
 // Rotate returns the vector rotated by angle radians.
 func (v Vec2) Rotate(angle float64) Vec2 {
-	cos := math.Cos(angle)
-	sin := math.Sin(angle)
+	cos := detmath.Cos(angle)
+	sin := detmath.Sin(angle)
 	return Vec2{
 		X: v.X*cos - v.Y*sin,
 		Y: v.X*sin + v.Y*cos,
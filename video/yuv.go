@@ -0,0 +1,248 @@
+// Package video converts rendered Pixmaps to planar YUV frame formats
+// (I420, NV12) for integration with external video encoders.
+//
+// Conversion uses BT.709 coefficients with limited (video) range output:
+// luma in [16, 235] and chroma in [16, 240]. Chroma is subsampled 4:2:0
+// by averaging each 2x2 pixel block.
+//
+// Pixel data is read as premultiplied RGBA, matching gg.Pixmap storage;
+// alpha is ignored, which is equivalent to compositing over black.
+//
+// # Example
+//
+//	dc := gg.NewContext(1280, 720)
+//	// ... draw frame ...
+//	frame := video.NewI420Frame(1280, 720)
+//	if err := video.ConvertI420(frame, dc.Pixmap()); err != nil {
+//	    log.Fatal(err)
+//	}
+//	encoder.Write(frame.Y, frame.U, frame.V)
+package video
+
+import (
+	"fmt"
+
+	"github.com/gogpu/gg"
+	"github.com/gogpu/gg/internal/wide"
+)
+
+// BT.709 limited-range RGB -> YCbCr coefficients in 16.16 fixed point.
+//
+//	Y  =  0.2126 R + 0.7152 G + 0.0722 B, scaled to [16, 235]
+//	Cb = -0.1146 R - 0.3854 G + 0.5000 B, scaled to [16, 240]
+//	Cr =  0.5000 R - 0.4542 G - 0.0458 B, scaled to [16, 240]
+const (
+	yrCoeff = 11966 // 0.2126 * 219/255 * 65536
+	ygCoeff = 40255 // 0.7152 * 219/255 * 65536
+	ybCoeff = 4064  // 0.0722 * 219/255 * 65536
+
+	cbrCoeff = 6596  // 0.1146 * 224/255 * 65536
+	cbgCoeff = 22189 // 0.3854 * 224/255 * 65536
+	cbbCoeff = 28787 // 0.5000 * 224/255 * 65536
+
+	crrCoeff = 28787 // 0.5000 * 224/255 * 65536
+	crgCoeff = 26147 // 0.4542 * 224/255 * 65536
+	crbCoeff = 2640  // 0.0458 * 224/255 * 65536
+)
+
+// I420Frame holds an I420 (YUV 4:2:0 planar) frame.
+// The Y plane is full resolution; U and V are half resolution in both
+// dimensions.
+type I420Frame struct {
+	Width  int
+	Height int
+	Y      []byte // YStride * Height
+	U      []byte // CStride * ((Height+1)/2)
+	V      []byte // CStride * ((Height+1)/2)
+	// YStride is the number of bytes per row in the Y plane.
+	YStride int
+	// CStride is the number of bytes per row in the U and V planes.
+	CStride int
+}
+
+// NewI420Frame allocates an I420 frame for the given dimensions.
+// Odd dimensions are supported; chroma planes round up.
+func NewI420Frame(width, height int) *I420Frame {
+	cw, ch := (width+1)/2, (height+1)/2
+	return &I420Frame{
+		Width:   width,
+		Height:  height,
+		Y:       make([]byte, width*height),
+		U:       make([]byte, cw*ch),
+		V:       make([]byte, cw*ch),
+		YStride: width,
+		CStride: cw,
+	}
+}
+
+// NV12Frame holds an NV12 (YUV 4:2:0 semi-planar) frame.
+// The Y plane is full resolution; the UV plane stores interleaved
+// Cb/Cr pairs at half resolution.
+type NV12Frame struct {
+	Width  int
+	Height int
+	Y      []byte // YStride * Height
+	UV     []byte // UVStride * ((Height+1)/2), interleaved CbCr
+	// YStride is the number of bytes per row in the Y plane.
+	YStride int
+	// UVStride is the number of bytes per row in the UV plane.
+	UVStride int
+}
+
+// NewNV12Frame allocates an NV12 frame for the given dimensions.
+func NewNV12Frame(width, height int) *NV12Frame {
+	cw, ch := (width+1)/2, (height+1)/2
+	return &NV12Frame{
+		Width:    width,
+		Height:   height,
+		Y:        make([]byte, width*height),
+		UV:       make([]byte, cw*2*ch),
+		YStride:  width,
+		UVStride: cw * 2,
+	}
+}
+
+// ConvertI420 converts a pixmap into an existing I420 frame.
+// The frame dimensions must match the pixmap.
+func ConvertI420(dst *I420Frame, p *gg.Pixmap) error {
+	if dst == nil || p == nil {
+		return fmt.Errorf("video: ConvertI420: nil frame or pixmap")
+	}
+	if dst.Width != p.Width() || dst.Height != p.Height() {
+		return fmt.Errorf("video: ConvertI420: frame %dx%d does not match pixmap %dx%d",
+			dst.Width, dst.Height, p.Width(), p.Height())
+	}
+	convertYPlane(dst.Y, dst.YStride, p)
+	convertChroma(p, func(cx, cy int, cb, cr byte) {
+		dst.U[cy*dst.CStride+cx] = cb
+		dst.V[cy*dst.CStride+cx] = cr
+	})
+	return nil
+}
+
+// ConvertNV12 converts a pixmap into an existing NV12 frame.
+// The frame dimensions must match the pixmap.
+func ConvertNV12(dst *NV12Frame, p *gg.Pixmap) error {
+	if dst == nil || p == nil {
+		return fmt.Errorf("video: ConvertNV12: nil frame or pixmap")
+	}
+	if dst.Width != p.Width() || dst.Height != p.Height() {
+		return fmt.Errorf("video: ConvertNV12: frame %dx%d does not match pixmap %dx%d",
+			dst.Width, dst.Height, p.Width(), p.Height())
+	}
+	convertYPlane(dst.Y, dst.YStride, p)
+	convertChroma(p, func(cx, cy int, cb, cr byte) {
+		dst.UV[cy*dst.UVStride+cx*2] = cb
+		dst.UV[cy*dst.UVStride+cx*2+1] = cr
+	})
+	return nil
+}
+
+// ToI420 converts a pixmap to a newly allocated I420 frame.
+func ToI420(p *gg.Pixmap) (*I420Frame, error) {
+	if p == nil {
+		return nil, fmt.Errorf("video: ToI420: nil pixmap")
+	}
+	frame := NewI420Frame(p.Width(), p.Height())
+	if err := ConvertI420(frame, p); err != nil {
+		return nil, err
+	}
+	return frame, nil
+}
+
+// ToNV12 converts a pixmap to a newly allocated NV12 frame.
+func ToNV12(p *gg.Pixmap) (*NV12Frame, error) {
+	if p == nil {
+		return nil, fmt.Errorf("video: ToNV12: nil pixmap")
+	}
+	frame := NewNV12Frame(p.Width(), p.Height())
+	if err := ConvertNV12(frame, p); err != nil {
+		return nil, err
+	}
+	return frame, nil
+}
+
+// convertYPlane fills the luma plane, processing 16 pixels per batch
+// through internal/wide so the compiler can auto-vectorize the inner
+// multiply-accumulate loops.
+func convertYPlane(y []byte, stride int, p *gg.Pixmap) {
+	width, height := p.Width(), p.Height()
+	data := p.Data()
+	for row := 0; row < height; row++ {
+		src := data[row*width*4 : (row+1)*width*4]
+		dst := y[row*stride : row*stride+width]
+		x := 0
+		for ; x+16 <= width; x += 16 {
+			var batch wide.BatchState
+			batch.LoadSrc(src[x*4:])
+			lumaBatch(&batch.SR, &batch.SG, &batch.SB, dst[x:])
+		}
+		for ; x < width; x++ {
+			r := uint32(src[x*4])
+			g := uint32(src[x*4+1])
+			b := uint32(src[x*4+2])
+			dst[x] = lumaScalar(r, g, b)
+		}
+	}
+}
+
+// lumaBatch computes 16 luma values from SoA channel registers.
+func lumaBatch(r, g, b *wide.U16x16, dst []byte) {
+	for i := 0; i < 16; i++ {
+		dst[i] = lumaScalar(uint32(r[i]), uint32(g[i]), uint32(b[i]))
+	}
+}
+
+// lumaScalar computes one limited-range BT.709 luma value.
+func lumaScalar(r, g, b uint32) byte {
+	y := (yrCoeff*r + ygCoeff*g + ybCoeff*b + 1<<15) >> 16
+	return byte(y + 16)
+}
+
+// convertChroma subsamples 2x2 blocks and emits one Cb/Cr pair per block
+// through the store callback.
+func convertChroma(p *gg.Pixmap, store func(cx, cy int, cb, cr byte)) {
+	width, height := p.Width(), p.Height()
+	data := p.Data()
+	cw, ch := (width+1)/2, (height+1)/2
+	for cy := 0; cy < ch; cy++ {
+		for cx := 0; cx < cw; cx++ {
+			// Average the 2x2 block, clamping at image edges.
+			var rSum, gSum, bSum, n int32
+			for dy := 0; dy < 2; dy++ {
+				py := cy*2 + dy
+				if py >= height {
+					continue
+				}
+				for dx := 0; dx < 2; dx++ {
+					px := cx*2 + dx
+					if px >= width {
+						continue
+					}
+					off := (py*width + px) * 4
+					rSum += int32(data[off])
+					gSum += int32(data[off+1])
+					bSum += int32(data[off+2])
+					n++
+				}
+			}
+			r := rSum / n
+			g := gSum / n
+			b := bSum / n
+			cb := (-cbrCoeff*r - cbgCoeff*g + cbbCoeff*b + 1<<15) >> 16
+			cr := (crrCoeff*r - crgCoeff*g - crbCoeff*b + 1<<15) >> 16
+			store(cx, cy, clampChroma(cb+128), clampChroma(cr+128))
+		}
+	}
+}
+
+// clampChroma clamps a chroma sample to the limited range [16, 240].
+func clampChroma(v int32) byte {
+	if v < 16 {
+		return 16
+	}
+	if v > 240 {
+		return 240
+	}
+	return byte(v)
+}
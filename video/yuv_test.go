@@ -0,0 +1,163 @@
+package video
+
+import (
+	"testing"
+
+	"github.com/gogpu/gg"
+)
+
+// solidPixmap returns a pixmap filled with the given color.
+func solidPixmap(w, h int, c gg.RGBA) *gg.Pixmap {
+	p := gg.NewPixmap(w, h)
+	p.Clear(c)
+	return p
+}
+
+func TestI420FrameLayout(t *testing.T) {
+	f := NewI420Frame(33, 17)
+	if len(f.Y) != 33*17 {
+		t.Errorf("Y plane size %d, want %d", len(f.Y), 33*17)
+	}
+	if f.CStride != 17 {
+		t.Errorf("CStride = %d, want 17 (rounded up)", f.CStride)
+	}
+	if len(f.U) != 17*9 || len(f.V) != 17*9 {
+		t.Errorf("chroma plane sizes %d/%d, want %d", len(f.U), len(f.V), 17*9)
+	}
+}
+
+func TestNV12FrameLayout(t *testing.T) {
+	f := NewNV12Frame(32, 16)
+	if len(f.Y) != 32*16 {
+		t.Errorf("Y plane size %d, want %d", len(f.Y), 32*16)
+	}
+	if f.UVStride != 32 {
+		t.Errorf("UVStride = %d, want 32", f.UVStride)
+	}
+	if len(f.UV) != 32*8 {
+		t.Errorf("UV plane size %d, want %d", len(f.UV), 32*8)
+	}
+}
+
+func TestConvertBT709KnownColors(t *testing.T) {
+	// Limited-range BT.709 reference values.
+	tests := []struct {
+		name    string
+		color   gg.RGBA
+		y, u, v byte
+		tol     int
+	}{
+		{"black", gg.Black, 16, 128, 128, 1},
+		{"white", gg.White, 235, 128, 128, 1},
+		{"red", gg.Red, 63, 102, 240, 2},
+		{"green", gg.Green, 173, 42, 26, 2},
+		{"blue", gg.Blue, 32, 240, 118, 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			frame, err := ToI420(solidPixmap(32, 32, tt.color))
+			if err != nil {
+				t.Fatalf("ToI420 failed: %v", err)
+			}
+			checkNear(t, "Y", frame.Y[0], tt.y, tt.tol)
+			checkNear(t, "U", frame.U[0], tt.u, tt.tol)
+			checkNear(t, "V", frame.V[0], tt.v, tt.tol)
+		})
+	}
+}
+
+func checkNear(t *testing.T, name string, got, want byte, tol int) {
+	t.Helper()
+	d := int(got) - int(want)
+	if d < 0 {
+		d = -d
+	}
+	if d > tol {
+		t.Errorf("%s = %d, want %d (±%d)", name, got, want, tol)
+	}
+}
+
+func TestConvertNV12Interleaving(t *testing.T) {
+	frame, err := ToNV12(solidPixmap(16, 16, gg.Blue))
+	if err != nil {
+		t.Fatalf("ToNV12 failed: %v", err)
+	}
+	// NV12 interleaves Cb then Cr; blue has max Cb (240).
+	checkNear(t, "Cb", frame.UV[0], 240, 2)
+	checkNear(t, "Cr", frame.UV[1], 118, 2)
+}
+
+func TestConvertDimensionMismatch(t *testing.T) {
+	p := solidPixmap(8, 8, gg.Black)
+	if err := ConvertI420(NewI420Frame(16, 16), p); err == nil {
+		t.Error("expected error for mismatched I420 frame")
+	}
+	if err := ConvertNV12(NewNV12Frame(16, 16), p); err == nil {
+		t.Error("expected error for mismatched NV12 frame")
+	}
+	if _, err := ToI420(nil); err == nil {
+		t.Error("expected error for nil pixmap")
+	}
+}
+
+func TestConvertOddDimensions(t *testing.T) {
+	// 5x3: chroma planes are 3x2; edge blocks average fewer pixels.
+	p := solidPixmap(5, 3, gg.White)
+	frame, err := ToI420(p)
+	if err != nil {
+		t.Fatalf("ToI420 failed: %v", err)
+	}
+	for i, y := range frame.Y {
+		if int(y) < 234 || int(y) > 236 {
+			t.Fatalf("Y[%d] = %d, want ~235", i, y)
+		}
+	}
+	for i := range frame.U {
+		checkNear(t, "U", frame.U[i], 128, 1)
+		checkNear(t, "V", frame.V[i], 128, 1)
+	}
+}
+
+func TestConvertBatchMatchesScalar(t *testing.T) {
+	// A 33-wide gradient exercises both the 16-pixel batch path and the
+	// scalar tail within each row; verify rows are internally consistent
+	// by comparing against a 1-wide conversion of the same colors.
+	w := 33
+	p := gg.NewPixmap(w, 1)
+	for x := 0; x < w; x++ {
+		p.SetPixel(x, 0, gg.RGB(float64(x)/float64(w-1), 0.25, 0.75))
+	}
+	frame, err := ToI420(p)
+	if err != nil {
+		t.Fatalf("ToI420 failed: %v", err)
+	}
+	for x := 0; x < w; x++ {
+		single := gg.NewPixmap(1, 1)
+		single.SetPixel(0, 0, p.GetPixel(x, 0))
+		ref, err := ToI420(single)
+		if err != nil {
+			t.Fatalf("ToI420 failed: %v", err)
+		}
+		if frame.Y[x] != ref.Y[0] {
+			t.Errorf("Y[%d] = %d, scalar reference %d", x, frame.Y[x], ref.Y[0])
+		}
+	}
+}
+
+func BenchmarkConvertI420(b *testing.B) {
+	p := solidPixmap(1280, 720, gg.RGB(0.3, 0.5, 0.7))
+	frame := NewI420Frame(1280, 720)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_ = ConvertI420(frame, p)
+	}
+}
+
+func BenchmarkConvertNV12(b *testing.B) {
+	p := solidPixmap(1280, 720, gg.RGB(0.3, 0.5, 0.7))
+	frame := NewNV12Frame(1280, 720)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_ = ConvertNV12(frame, p)
+	}
+}
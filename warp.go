@@ -0,0 +1,229 @@
+package gg
+
+import (
+	"math"
+
+	"github.com/gogpu/gg/internal/detmath"
+)
+
+// Path warping applies an arbitrary (generally non-affine) point mapping to
+// a path. Because control points do not map correctly under non-affine
+// distortion, each segment is adaptively re-subdivided: warped sample points
+// are fitted with cubic Beziers, and pieces whose fit deviates from the true
+// warped curve are split until the error falls below a fraction of a pixel.
+// This keeps glyph outlines and other curves smooth after distortion.
+
+// WarpFunc maps a source-space point to its distorted position.
+type WarpFunc func(p Point) Point
+
+const (
+	// warpTolerance is the maximum deviation (in pixels) between a fitted
+	// cubic and the true warped curve before a piece is subdivided.
+	warpTolerance = 0.1
+
+	// warpMaxDepth bounds the recursive subdivision (2^10 pieces per segment).
+	warpMaxDepth = 10
+)
+
+// WarpPath returns a copy of the path with every point mapped through warp.
+// Lines and curves are adaptively re-subdivided and refitted with cubic
+// Beziers so they stay smooth under non-affine distortion. A nil warp
+// returns an unmodified clone.
+func WarpPath(p *Path, warp WarpFunc) *Path {
+	if warp == nil {
+		return p.Clone()
+	}
+
+	result := NewPath()
+	var cur, start Point // current point in source space
+	p.Iterate(func(verb PathVerb, coords []float64) {
+		switch verb {
+		case MoveTo:
+			cur = Pt(coords[0], coords[1])
+			start = cur
+			w := warp(cur)
+			result.MoveTo(w.X, w.Y)
+		case LineTo:
+			seg := NewLine(cur, Pt(coords[0], coords[1]))
+			warpSegment(result, seg.Eval, warp, 0, 1, 0)
+			cur = seg.P1
+		case QuadTo:
+			seg := NewQuadBez(cur, Pt(coords[0], coords[1]), Pt(coords[2], coords[3]))
+			warpSegment(result, seg.Eval, warp, 0, 1, 0)
+			cur = seg.P2
+		case CubicTo:
+			seg := NewCubicBez(cur,
+				Pt(coords[0], coords[1]),
+				Pt(coords[2], coords[3]),
+				Pt(coords[4], coords[5]))
+			warpSegment(result, seg.Eval, warp, 0, 1, 0)
+			cur = seg.P3
+		case Close:
+			result.Close()
+			cur = start
+		}
+	})
+	return result
+}
+
+// warpSegment emits the warped image of eval over [t0, t1] as one or more
+// cubic Beziers. Each candidate cubic interpolates the warped curve at four
+// parameter values; if it strays from the warped midpoint by more than
+// warpTolerance, the interval is split and both halves are refitted.
+func warpSegment(dst *Path, eval func(t float64) Point, warp WarpFunc, t0, t1 float64, depth int) {
+	dt := t1 - t0
+	w0 := warp(eval(t0))
+	wa := warp(eval(t0 + dt/3))
+	wb := warp(eval(t0 + 2*dt/3))
+	w1 := warp(eval(t1))
+
+	fit := cubicThrough(w0, wa, wb, w1)
+
+	if depth < warpMaxDepth {
+		// Probe between the interpolated samples; a single midpoint check
+		// aliases on warps that are symmetric about the segment center.
+		for _, ft := range [...]float64{1.0 / 6, 0.5, 5.0 / 6} {
+			wt := warp(eval(t0 + dt*ft))
+			if wt.Distance(fit.Eval(ft)) > warpTolerance {
+				mid := t0 + dt/2
+				warpSegment(dst, eval, warp, t0, mid, depth+1)
+				warpSegment(dst, eval, warp, mid, t1, depth+1)
+				return
+			}
+		}
+	}
+
+	dst.CubicTo(fit.P1.X, fit.P1.Y, fit.P2.X, fit.P2.Y, fit.P3.X, fit.P3.Y)
+}
+
+// cubicThrough returns the cubic Bezier interpolating the four points at
+// parameter values 0, 1/3, 2/3, and 1 (standard Lagrange-to-Bernstein fit).
+func cubicThrough(p0, pa, pb, p3 Point) CubicBez {
+	return CubicBez{
+		P0: p0,
+		P1: Point{
+			X: (-5*p0.X + 18*pa.X - 9*pb.X + 2*p3.X) / 6,
+			Y: (-5*p0.Y + 18*pa.Y - 9*pb.Y + 2*p3.Y) / 6,
+		},
+		P2: Point{
+			X: (2*p0.X - 9*pa.X + 18*pb.X - 5*p3.X) / 6,
+			Y: (2*p0.Y - 9*pa.Y + 18*pb.Y - 5*p3.Y) / 6,
+		},
+		P3: p3,
+	}
+}
+
+// -------------------------------------------------------------------
+// Built-in warps
+// -------------------------------------------------------------------
+
+// ArcWarp bends the contents of bounds along a circular arc. The bottom
+// edge of bounds becomes an arc subtending bend radians (positive bends
+// upward); vertical distance above the bottom edge is preserved along the
+// arc's radial direction. A bend of zero is the identity.
+func ArcWarp(bounds Rect, bend float64) WarpFunc {
+	if bend == 0 || bounds.Width() == 0 {
+		return func(p Point) Point { return p }
+	}
+
+	cx := bounds.Min.X + bounds.Width()/2
+	baseline := bounds.Max.Y
+	radius := bounds.Width() / bend
+
+	return func(p Point) Point {
+		theta := (p.X - cx) / radius
+		d := radius - (baseline - p.Y)
+		return Point{
+			X: cx + d*detmath.Sin(theta),
+			Y: baseline - radius + d*detmath.Cos(theta),
+		}
+	}
+}
+
+// FlagWarp displaces points vertically along a sine wave, like a waving
+// flag. The wave runs horizontally across bounds with the given peak
+// amplitude and number of full periods.
+func FlagWarp(bounds Rect, amplitude, periods float64) WarpFunc {
+	if bounds.Width() == 0 {
+		return func(p Point) Point { return p }
+	}
+
+	return func(p Point) Point {
+		u := (p.X - bounds.Min.X) / bounds.Width()
+		return Point{
+			X: p.X,
+			Y: p.Y + amplitude*detmath.Sin(2*math.Pi*periods*u),
+		}
+	}
+}
+
+// BulgeWarp expands (positive amount) or pinches (negative amount) the
+// contents of bounds vertically about its horizontal centerline, strongest
+// at the horizontal center and tapering to nothing at the left and right
+// edges. An amount of 0.5 makes the center 50% taller.
+func BulgeWarp(bounds Rect, amount float64) WarpFunc {
+	if bounds.Width() == 0 {
+		return func(p Point) Point { return p }
+	}
+
+	cy := bounds.Min.Y + bounds.Height()/2
+
+	return func(p Point) Point {
+		u := (p.X - bounds.Min.X) / bounds.Width()
+		scale := 1 + amount*detmath.Sin(math.Pi*u)
+		return Point{
+			X: p.X,
+			Y: cy + (p.Y-cy)*scale,
+		}
+	}
+}
+
+// PerspectiveWarp maps the corners of bounds onto an arbitrary convex
+// quadrilateral via a projective transform, producing an envelope
+// distortion with correct perspective foreshortening. The quad corners
+// are given in clockwise order starting at the top-left.
+func PerspectiveWarp(bounds Rect, tl, tr, br, bl Point) WarpFunc {
+	w := bounds.Width()
+	h := bounds.Height()
+	if w == 0 || h == 0 {
+		return func(p Point) Point { return p }
+	}
+
+	// Homography from the unit square to the quad (Heckbert's method):
+	// (u,v) -> ((a*u + b*v + c) / (g*u + h*v + 1), (d*u + e*v + f) / ...).
+	dx1 := tr.Sub(br)
+	dx2 := bl.Sub(br)
+	sum := Point{X: tl.X - tr.X + br.X - bl.X, Y: tl.Y - tr.Y + br.Y - bl.Y}
+
+	var a, b, c, d, e, f, g, hh float64
+	den := dx1.X*dx2.Y - dx1.Y*dx2.X
+	if sum.X == 0 && sum.Y == 0 || den == 0 {
+		// Affine special case: opposite edges are parallel.
+		a = tr.X - tl.X
+		b = bl.X - tl.X
+		d = tr.Y - tl.Y
+		e = bl.Y - tl.Y
+	} else {
+		g = (sum.X*dx2.Y - sum.Y*dx2.X) / den
+		hh = (dx1.X*sum.Y - dx1.Y*sum.X) / den
+		a = tr.X - tl.X + g*tr.X
+		b = bl.X - tl.X + hh*bl.X
+		d = tr.Y - tl.Y + g*tr.Y
+		e = bl.Y - tl.Y + hh*bl.Y
+	}
+	c = tl.X
+	f = tl.Y
+
+	return func(p Point) Point {
+		u := (p.X - bounds.Min.X) / w
+		v := (p.Y - bounds.Min.Y) / h
+		z := g*u + hh*v + 1
+		if z == 0 {
+			return p
+		}
+		return Point{
+			X: (a*u + b*v + c) / z,
+			Y: (d*u + e*v + f) / z,
+		}
+	}
+}
@@ -0,0 +1,227 @@
+package gg
+
+import (
+	"math"
+	"testing"
+)
+
+// sampleWarpedPath flattens a path into a dense point list by evaluating
+// each segment at steps points.
+func sampleWarpedPath(p *Path, steps int) []Point {
+	var points []Point
+	var cur Point
+	p.Iterate(func(verb PathVerb, coords []float64) {
+		switch verb {
+		case MoveTo:
+			cur = Pt(coords[0], coords[1])
+			points = append(points, cur)
+		case LineTo:
+			seg := NewLine(cur, Pt(coords[0], coords[1]))
+			for i := 1; i <= steps; i++ {
+				points = append(points, seg.Eval(float64(i)/float64(steps)))
+			}
+			cur = seg.P1
+		case QuadTo:
+			seg := NewQuadBez(cur, Pt(coords[0], coords[1]), Pt(coords[2], coords[3]))
+			for i := 1; i <= steps; i++ {
+				points = append(points, seg.Eval(float64(i)/float64(steps)))
+			}
+			cur = seg.P2
+		case CubicTo:
+			seg := NewCubicBez(cur,
+				Pt(coords[0], coords[1]),
+				Pt(coords[2], coords[3]),
+				Pt(coords[4], coords[5]))
+			for i := 1; i <= steps; i++ {
+				points = append(points, seg.Eval(float64(i)/float64(steps)))
+			}
+			cur = seg.P3
+		}
+	})
+	return points
+}
+
+// minDistanceTo returns the distance from target to the nearest sample.
+func minDistanceTo(points []Point, target Point) float64 {
+	best := math.Inf(1)
+	for _, p := range points {
+		if d := p.Distance(target); d < best {
+			best = d
+		}
+	}
+	return best
+}
+
+func TestWarpPathNilWarp(t *testing.T) {
+	p := NewPath()
+	p.MoveTo(0, 0)
+	p.LineTo(10, 10)
+
+	warped := WarpPath(p, nil)
+	if warped.NumVerbs() != p.NumVerbs() {
+		t.Errorf("nil warp: got %d verbs, want %d", warped.NumVerbs(), p.NumVerbs())
+	}
+}
+
+func TestWarpPathIdentity(t *testing.T) {
+	p := NewPath()
+	p.MoveTo(0, 0)
+	p.QuadraticTo(50, 100, 100, 0)
+
+	warped := WarpPath(p, func(pt Point) Point { return pt })
+
+	// Under the identity warp the fit is exact: a single cubic equal to the
+	// degree-raised quadratic.
+	verbs := warped.Verbs()
+	if len(verbs) != 2 || verbs[0] != MoveTo || verbs[1] != CubicTo {
+		t.Fatalf("identity warp verbs = %v, want [MoveTo CubicTo]", verbs)
+	}
+	raised := NewQuadBez(Pt(0, 0), Pt(50, 100), Pt(100, 0)).Raise()
+	coords := warped.Coords()
+	got := NewCubicBez(
+		Pt(coords[0], coords[1]),
+		Pt(coords[2], coords[3]),
+		Pt(coords[4], coords[5]),
+		Pt(coords[6], coords[7]))
+	for _, pair := range [][2]Point{
+		{got.P0, raised.P0}, {got.P1, raised.P1}, {got.P2, raised.P2}, {got.P3, raised.P3},
+	} {
+		if pair[0].Distance(pair[1]) > 1e-9 {
+			t.Errorf("control point %v, want %v", pair[0], pair[1])
+		}
+	}
+}
+
+func TestWarpPathAdaptiveSubdivision(t *testing.T) {
+	// A single straight line warped by a two-period sine must be split into
+	// enough cubics to follow the wave closely.
+	p := NewPath()
+	p.MoveTo(0, 0)
+	p.LineTo(200, 0)
+
+	bounds := NewRect(Pt(0, -20), Pt(200, 20))
+	warp := FlagWarp(bounds, 15, 2)
+	warped := WarpPath(p, warp)
+
+	cubics := 0
+	for _, v := range warped.Verbs() {
+		if v == CubicTo {
+			cubics++
+		}
+	}
+	if cubics < 2 {
+		t.Errorf("got %d cubics for a two-period wave, want adaptive split", cubics)
+	}
+
+	samples := sampleWarpedPath(warped, 64)
+	for i := 0; i <= 40; i++ {
+		x := 200 * float64(i) / 40
+		want := warp(Pt(x, 0))
+		if d := minDistanceTo(samples, want); d > 0.5 {
+			t.Errorf("warped line deviates by %v at x=%v", d, x)
+		}
+	}
+}
+
+func TestWarpPathPreservesClose(t *testing.T) {
+	p := NewPath()
+	p.Rectangle(0, 0, 10, 10)
+
+	warped := WarpPath(p, func(pt Point) Point { return pt })
+	verbs := warped.Verbs()
+	if len(verbs) == 0 || verbs[len(verbs)-1] != Close {
+		t.Error("warped path lost its Close verb")
+	}
+}
+
+func TestArcWarpFixedPoints(t *testing.T) {
+	bounds := NewRect(Pt(0, 0), Pt(100, 20))
+	warp := ArcWarp(bounds, math.Pi/2)
+
+	// The baseline center is the arc's anchor and must not move.
+	got := warp(Pt(50, 20))
+	if got.Distance(Pt(50, 20)) > 1e-9 {
+		t.Errorf("baseline center moved to %v", got)
+	}
+
+	// The baseline endpoints stay at arc-length distance W/2 from the anchor
+	// along the arc, so their chord distance shrinks below W/2.
+	end := warp(Pt(100, 20))
+	if chord := end.Distance(Pt(50, 20)); chord >= 50 {
+		t.Errorf("bent endpoint chord = %v, want < 50", chord)
+	}
+
+	// Zero bend is the identity.
+	id := ArcWarp(bounds, 0)
+	if got := id(Pt(30, 7)); got != Pt(30, 7) {
+		t.Errorf("zero bend moved point to %v", got)
+	}
+}
+
+func TestFlagWarpWave(t *testing.T) {
+	bounds := NewRect(Pt(0, 0), Pt(100, 20))
+	warp := FlagWarp(bounds, 10, 1)
+
+	// One full period: nodes at the edges and middle, crest at a quarter.
+	for _, x := range []float64{0, 50, 100} {
+		got := warp(Pt(x, 5))
+		if math.Abs(got.Y-5) > 1e-9 {
+			t.Errorf("node at x=%v displaced to y=%v", x, got.Y)
+		}
+	}
+	crest := warp(Pt(25, 5))
+	if math.Abs(crest.Y-15) > 1e-9 {
+		t.Errorf("crest y = %v, want 15", crest.Y)
+	}
+}
+
+func TestBulgeWarpProfile(t *testing.T) {
+	bounds := NewRect(Pt(0, 0), Pt(100, 40))
+	warp := BulgeWarp(bounds, 0.5)
+
+	// Center column is scaled 1.5x about the centerline.
+	got := warp(Pt(50, 0))
+	if math.Abs(got.Y-(-10)) > 1e-9 {
+		t.Errorf("bulged top center y = %v, want -10", got.Y)
+	}
+	// Edges are untouched.
+	if got := warp(Pt(0, 0)); math.Abs(got.Y) > 1e-9 {
+		t.Errorf("left edge displaced to y=%v", got.Y)
+	}
+	// The centerline never moves.
+	if got := warp(Pt(50, 20)); math.Abs(got.Y-20) > 1e-9 {
+		t.Errorf("centerline displaced to y=%v", got.Y)
+	}
+}
+
+func TestPerspectiveWarpCorners(t *testing.T) {
+	bounds := NewRect(Pt(0, 0), Pt(100, 50))
+	tl, tr := Pt(10, 5), Pt(90, 0)
+	br, bl := Pt(100, 60), Pt(0, 40)
+	warp := PerspectiveWarp(bounds, tl, tr, br, bl)
+
+	cases := []struct{ src, want Point }{
+		{Pt(0, 0), tl},
+		{Pt(100, 0), tr},
+		{Pt(100, 50), br},
+		{Pt(0, 50), bl},
+	}
+	for _, tc := range cases {
+		got := warp(tc.src)
+		if got.Distance(tc.want) > 1e-9 {
+			t.Errorf("corner %v maps to %v, want %v", tc.src, got, tc.want)
+		}
+	}
+}
+
+func TestPerspectiveWarpAffineCase(t *testing.T) {
+	// A parallelogram target degenerates to an affine map.
+	bounds := NewRect(Pt(0, 0), Pt(10, 10))
+	warp := PerspectiveWarp(bounds, Pt(5, 0), Pt(15, 0), Pt(20, 10), Pt(10, 10))
+
+	got := warp(Pt(5, 5))
+	want := Pt(12.5, 5)
+	if got.Distance(want) > 1e-9 {
+		t.Errorf("parallelogram center maps to %v, want %v", got, want)
+	}
+}